@@ -3,19 +3,104 @@ package config
 const (
 	ApiGroup = "/api/v1"
 
+	// Probes, served outside ApiGroup without authentication
+	HealthLive  = "/healthz"
+	HealthReady = "/readyz"
+	Version     = "/version"
+	Metrics     = "/metrics"
+
 	// Rooms
 	RoomCreate       = "/rooms"
 	RoomList         = "/rooms"
 	RoomGetById      = "/rooms/:id"
 	RoomUpdateStatus = "/rooms/status"
 	RoomUpdate       = "/rooms"
-	// RoomDelete       = "/rooms/:id"
+	RoomArchive      = "/rooms/:id/archive"
+	RoomRestore      = "/rooms/:id/restore"
+	RoomRateCreate = "/rooms/:id/rates"
+	RoomRateList   = "/rooms/:id/rates"
+
+	// Locations
+	SiteCreate      = "/sites"
+	SiteList        = "/sites"
+	SiteGetById     = "/sites/:id"
+	SiteUpdate      = "/sites"
+	SiteDelete      = "/sites/:id"
+	BuildingCreate  = "/buildings"
+	BuildingList    = "/buildings"
+	BuildingGetById = "/buildings/:id"
+	BuildingUpdate  = "/buildings"
+	BuildingDelete  = "/buildings/:id"
+	FloorCreate     = "/floors"
+	FloorList       = "/floors"
+	FloorGetById    = "/floors/:id"
+	FloorUpdate     = "/floors"
+	FloorDelete     = "/floors/:id"
+
+	// Calendar
+	BusinessHoursList   = "/business-hours"
+	BusinessHoursUpdate = "/business-hours"
+	HolidayCreate       = "/holidays"
+	HolidayImport       = "/holidays/import"
+	HolidayList         = "/holidays"
+	HolidayDelete       = "/holidays/:id"
+	BlackoutCreate      = "/blackouts"
+	BlackoutList        = "/blackouts"
+	BlackoutDelete      = "/blackouts/:id"
+
+	// Attachments
+	RoomAttachmentCreate     = "/rooms/:id/attachments"
+	RoomAttachmentList       = "/rooms/:id/attachments"
+	FacilityAttachmentCreate = "/facilities/:id/attachments"
+	FacilityAttachmentList   = "/facilities/:id/attachments"
+	AttachmentGetById        = "/attachments/:id"
+	AttachmentThumbnail      = "/attachments/:id/thumbnail"
+	AttachmentDelete         = "/attachments/:id"
+
+	// Room attributes
+	RoomAttributeCreate  = "/roomattributes"
+	RoomAttributeList    = "/roomattributes"
+	RoomAttributeUpdate  = "/roomattributes"
+	RoomAttributeDelete  = "/roomattributes/:id"
+	RoomAttributesGet    = "/rooms/:id/attributes"
+	RoomAttributesUpdate = "/rooms/:id/attributes"
+	RoomSearch           = "/rooms/search"
+
+	// Maintenance
+	MaintenanceCreate    = "/maintenance"
+	MaintenanceList      = "/maintenance"
+	MaintenanceConflicts = "/maintenance/conflicts"
+	MaintenanceGetById   = "/maintenance/:id"
+	MaintenanceUpdate    = "/maintenance"
+	MaintenanceDelete    = "/maintenance/:id"
 
 	// Facilities
-	FacilitiesCreate  = "/facilities"
-	FacilitiesList    = "/facilities"
-	FacilitiesGetById = "/facilities/:id"
-	FacilitiesUpdate  = "/facilities"
+	FacilitiesCreate   = "/facilities"
+	FacilitiesList     = "/facilities"
+	FacilitiesGetById  = "/facilities/:id"
+	FacilitiesUpdate   = "/facilities"
+	FacilitiesArchive  = "/facilities/:id/archive"
+	FacilitiesRestore  = "/facilities/:id/restore"
+	FacilityRateCreate = "/facilities/:id/rates"
+	FacilityRateList   = "/facilities/:id/rates"
+
+	FacilityMovementCreate = "/facilities/:id/movements"
+	FacilityMovementList   = "/facilities/:id/movements"
+
+	FacilityThresholdGet    = "/facilities/:id/threshold"
+	FacilityThresholdUpdate = "/facilities/:id/threshold"
+
+	StockAlertList        = "/stockalerts"
+	StockAlertAcknowledge = "/stockalerts/:id/acknowledge"
+
+	FacilityAssetCreate = "/facilities/:id/assets"
+	FacilityAssetList   = "/facilities/:id/assets"
+
+	AssetGetById      = "/assets/:id"
+	AssetUpdate       = "/assets/:id"
+	AssetCheckOut     = "/assets/:id/checkout"
+	AssetCheckIn      = "/assets/:id/checkin"
+	AssetCheckoutList = "/assets/:id/checkouts"
 
 	// Employees
 	EmployeesList    = "/employees"
@@ -23,7 +108,8 @@ const (
 	EmployeesGetById = "/employees/:id"
 	EmployeesGetByUsername = "/employees/username/:user"
 	EmployeesUpdate  = "/employees"
-	EmployeesDelete  = "/employees/:id"
+	EmployeesArchive = "/employees/:id/archive"
+	EmployeesRestore = "/employees/:id/restore"
 
 	// Transaction
 	TransactionList       = "/transactions"
@@ -39,6 +125,18 @@ const (
 	RoomFacilityGetById = "/roomfacilities/:id"
 	RoomFacilityUpdate  = "/roomfacilities"
 
+	RoomFacilityDelete   = "/roomfacilities/:id"
+	RoomFacilityTransfer = "/roomfacilities/transfer"
+
 	// Auth
 	AuthLogin = "/auth/login"
+
+	// Reports
+	ReportDownload        = "/reports/download"
+	ReportChargeback      = "/reports/chargeback"
+	ReportScheduleCreate  = "/reports/schedules"
+	ReportScheduleList    = "/reports/schedules"
+	ReportScheduleGetById = "/reports/schedules/:id"
+	ReportScheduleUpdate  = "/reports/schedules"
+	ReportScheduleDelete  = "/reports/schedules/:id"
 )
@@ -2,25 +2,67 @@ package config
 
 import (
 	"fmt"
-	"os"
-	"strconv"
+	"log/slog"
+	"net/mail"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
-	"github.com/joho/godotenv"
 )
 
 type DbConfig struct {
-	Host     string
-	Port     string
-	User     string
-	Password string
-	Name     string
-	Driver   string
+	Host            string
+	Port            string
+	User            string
+	Password        string
+	Name            string
+	Driver          string
+	SslMode         string
+	Timezone        string
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+	QueryTimeout    time.Duration
+	ReportTimeout   time.Duration
+	AutoMigrate     bool
+}
+
+// DSN is the lib/pq connection string. The session timezone follows the
+// application timezone so CURRENT_TIMESTAMP and time.Now agree.
+func (c DbConfig) DSN() string {
+	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=%s", c.Host, c.Port, c.User, quoteDSN(c.Password), c.Name, c.SslMode)
+	if c.Timezone != "" {
+		dsn += " timezone=" + quoteDSN(c.Timezone)
+	}
+	return dsn
 }
 
 type ApiConfig struct {
-	ApiPort string
+	ApiPort         string
+	ReadTimeout     time.Duration
+	WriteTimeout    time.Duration
+	IdleTimeout     time.Duration
+	ShutdownTimeout time.Duration
+	LogLevel        slog.Level
+	CorsOrigins     []string
+	Location        *time.Location
+}
+
+type MailConfig struct {
+	MailHost     string
+	MailPort     string
+	MailUser     string
+	MailPassword string
+	MailFrom     string
+	// StockAlertRecipients are told when a facility runs low on stock.
+	StockAlertRecipients []string
+}
+
+// TracingConfig enables OTLP export when an endpoint is set. OtlpEndpoint is
+// the full URL spans are posted to; the exporter reads the remaining OTEL_*
+// variables itself.
+type TracingConfig struct {
+	OtlpEndpoint string
 }
 
 type TokenConfig struct {
@@ -29,47 +71,172 @@ type TokenConfig struct {
 	JwtSigningMethod *jwt.SigningMethodHMAC
 	JwtExpiresTime   time.Duration
 }
+
+// StorageConfig says where uploaded attachments are kept: below StorageDir
+// with the local driver, or in an S3-compatible bucket with the s3 driver.
+type StorageConfig struct {
+	StorageDriver string
+	StorageDir    string
+	S3Endpoint    string
+	S3Region      string
+	S3Bucket      string
+	S3AccessKey   string
+	S3SecretKey   string
+	MaxUploadSize int64
+}
+
+// FeatureConfig switches optional parts of the server off.
+type FeatureConfig struct {
+	ReportScheduler      bool
+	MaintenanceScheduler bool
+	StockAlertScheduler  bool
+	Metrics              bool
+}
+
+// PolicyConfig holds the business rules that differ between deployments.
+type PolicyConfig struct {
+	// ArchivePolicy is usecase.ArchiveRefuse or usecase.ArchiveCascade.
+	ArchivePolicy string
+}
+
 type Config struct {
 	DbConfig
 	ApiConfig
 	TokenConfig
+	MailConfig
+	TracingConfig
+	StorageConfig
+	FeatureConfig
+	PolicyConfig
 }
 
-func (c *Config) ConfigConfiguration() error {
-	err := godotenv.Load()
-	if err != nil {
-		return fmt.Errorf("missing env file %v", err.Error())
-	}
+var sslModes = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+
+// build turns the merged settings into a Config, collecting every problem
+// instead of stopping at the first one.
+func build(values map[string]string) (*Config, error) {
+	p := &parser{values: values}
+	c := &Config{}
+
 	c.DbConfig = DbConfig{
-		Host:     os.Getenv("DB_HOST"),
-		Port:     os.Getenv("DB_PORT"),
-		User:     os.Getenv("DB_USER"),
-		Password: os.Getenv("DB_PASSWORD"),
-		Name:     os.Getenv("DB_NAME"),
-		Driver:   os.Getenv("DB_DRIVER"),
+		Host:            p.required("DB_HOST"),
+		Port:            p.port("DB_PORT"),
+		User:            p.required("DB_USER"),
+		Password:        p.str("DB_PASSWORD"),
+		Name:            p.required("DB_NAME"),
+		Driver:          p.oneOf("DB_DRIVER", "postgres"),
+		SslMode:         p.oneOf("DB_SSLMODE", sslModes...),
+		MaxOpenConns:    p.int("DB_MAX_OPEN_CONNS", 0),
+		MaxIdleConns:    p.int("DB_MAX_IDLE_CONNS", 0),
+		ConnMaxLifetime: p.duration("DB_CONN_MAX_LIFETIME"),
+		QueryTimeout:    p.positiveDuration("DB_QUERY_TIMEOUT"),
+		ReportTimeout:   p.positiveDuration("DB_REPORT_TIMEOUT"),
+		AutoMigrate:     p.bool("DB_AUTO_MIGRATE"),
 	}
 
-	c.ApiConfig = ApiConfig{ApiPort: os.Getenv("API_PORT")}
+	c.ApiConfig = ApiConfig{
+		ApiPort:         p.port("API_PORT"),
+		ReadTimeout:     p.duration("API_READ_TIMEOUT"),
+		WriteTimeout:    p.duration("API_WRITE_TIMEOUT"),
+		IdleTimeout:     p.duration("API_IDLE_TIMEOUT"),
+		ShutdownTimeout: p.positiveDuration("API_SHUTDOWN_TIMEOUT"),
+		CorsOrigins:     p.list("API_CORS_ORIGINS"),
+	}
+	if err := c.LogLevel.UnmarshalText([]byte(p.str("LOG_LEVEL"))); err != nil {
+		p.fail("LOG_LEVEL", "must be debug, info, warn or error")
+	}
+	for _, origin := range c.CorsOrigins {
+		if origin != "*" && !strings.HasPrefix(origin, "http://") && !strings.HasPrefix(origin, "https://") {
+			p.fail("API_CORS_ORIGINS", fmt.Sprintf("origin %q must be * or start with http:// or https://", origin))
+		}
+	}
+	if timezone := p.str("TIMEZONE"); timezone != "" {
+		location, err := time.LoadLocation(timezone)
+		if err != nil {
+			p.fail("TIMEZONE", "must be an IANA time zone such as Asia/Jakarta")
+		}
+		c.Location = location
+		c.DbConfig.Timezone = timezone
+	}
+
+	c.MailConfig = MailConfig{
+		MailHost:     p.str("MAIL_HOST"),
+		MailUser:     p.str("MAIL_USER"),
+		MailPassword: p.str("MAIL_PASSWORD"),
+		MailFrom:     p.str("MAIL_FROM"),
+	}
+	if c.MailHost != "" {
+		c.MailPort = p.port("MAIL_PORT")
+		if c.MailFrom == "" {
+			p.fail("MAIL_FROM", "is required when MAIL_HOST is set")
+		}
+	}
+	c.StockAlertRecipients = p.list("STOCK_ALERT_RECIPIENTS")
+	for _, recipient := range c.StockAlertRecipients {
+		if _, err := mail.ParseAddress(recipient); err != nil {
+			p.fail("STOCK_ALERT_RECIPIENTS", fmt.Sprintf("%q is not a valid email address", recipient))
+		}
+	}
+
+	// Like the OTLP exporter, the base endpoint gets the traces path appended
+	// while the traces endpoint is used as it is.
+	c.TracingConfig = TracingConfig{OtlpEndpoint: p.str("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT")}
+	if base := p.str("OTEL_EXPORTER_OTLP_ENDPOINT"); c.OtlpEndpoint == "" && base != "" {
+		c.OtlpEndpoint = strings.TrimSuffix(base, "/") + "/v1/traces"
+	}
+
+	c.StorageConfig = StorageConfig{
+		StorageDriver: p.oneOf("STORAGE_DRIVER", "local", "s3"),
+		MaxUploadSize: int64(p.int("STORAGE_MAX_UPLOAD_MB", 1)) << 20,
+	}
+	switch c.StorageDriver {
+	case "local":
+		c.StorageDir = p.required("STORAGE_DIR")
+	case "s3":
+		c.S3Endpoint = p.required("STORAGE_S3_ENDPOINT")
+		c.S3Region = p.str("STORAGE_S3_REGION")
+		c.S3Bucket = p.required("STORAGE_S3_BUCKET")
+		c.S3AccessKey = p.required("STORAGE_S3_ACCESS_KEY")
+		c.S3SecretKey = p.required("STORAGE_S3_SECRET_KEY")
+		if c.S3Endpoint != "" && !strings.HasPrefix(c.S3Endpoint, "http://") && !strings.HasPrefix(c.S3Endpoint, "https://") {
+			p.fail("STORAGE_S3_ENDPOINT", "must start with http:// or https://")
+		}
+	}
 
-	tokenExpire, _ := strconv.Atoi(os.Getenv("TOKEN_EXPIRE"))
 	c.TokenConfig = TokenConfig{
-		IssuerName:       os.Getenv("TOKEN_ISSUE"),
-		JwtSignatureKy:   []byte(os.Getenv("TOKEN_SECRET")),
+		IssuerName:       p.required("TOKEN_ISSUE"),
+		JwtSignatureKy:   []byte(p.required("TOKEN_SECRET")),
 		JwtSigningMethod: jwt.SigningMethodHS256,
-		JwtExpiresTime:   time.Duration(tokenExpire) * time.Minute,
+		JwtExpiresTime:   time.Duration(p.int("TOKEN_EXPIRE", 1)) * time.Minute,
+	}
+
+	c.FeatureConfig = FeatureConfig{
+		ReportScheduler:      p.bool("FEATURE_REPORT_SCHEDULER"),
+		MaintenanceScheduler: p.bool("FEATURE_MAINTENANCE_SCHEDULER"),
+		StockAlertScheduler:  p.bool("FEATURE_STOCK_ALERT_SCHEDULER"),
+		Metrics:              p.bool("FEATURE_METRICS"),
 	}
 
-	if c.Host == "" || c.Port == "" || c.User == "" || c.Name == "" || c.Driver == "" || c.ApiPort == "" || c.IssuerName == "" || c.JwtExpiresTime < 0 || len(c.JwtSignatureKy) == 0 {
-		return fmt.Errorf("missing required environment")
+	c.PolicyConfig = PolicyConfig{
+		ArchivePolicy: p.oneOf("ARCHIVE_POLICY", "refuse", "cascade"),
 	}
 
-	return nil
+	if len(p.problems) > 0 {
+		return nil, &ValidationError{Problems: p.problems}
+	}
+	return c, nil
 }
 
+// NewConfig loads the configuration without command-line flags.
 func NewConfig() (*Config, error) {
-	cfg := &Config{}
-	if err := cfg.ConfigConfiguration(); err != nil {
-		return nil, err
+	return Load(nil)
+}
+
+// quoteDSN quotes a connection string value when it contains spaces or
+// quotes.
+func quoteDSN(value string) string {
+	if value != "" && !strings.ContainsAny(value, ` '\`) {
+		return value
 	}
-	return cfg, nil
+	return "'" + strings.NewReplacer(`\`, `\\`, `'`, `\'`).Replace(value) + "'"
 }
@@ -0,0 +1,259 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// clearEnv hides the settings of the developer's shell from the test.
+func clearEnv(t *testing.T) {
+	for _, s := range settings {
+		t.Setenv(s.key, "")
+	}
+	t.Setenv(ConfigFileKey, "")
+}
+
+func requiredEnv(t *testing.T) {
+	t.Setenv("DB_HOST", "localhost")
+	t.Setenv("DB_USER", "postgres")
+	t.Setenv("DB_NAME", "booking")
+	t.Setenv("TOKEN_ISSUE", "reservify")
+	t.Setenv("TOKEN_SECRET", "secret")
+}
+
+func writeFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func TestLoad_DefaultsSuccess(t *testing.T) {
+	clearEnv(t)
+	requiredEnv(t)
+
+	cfg, err := Load(nil)
+
+	require.NoError(t, err)
+	assert.Equal(t, "5432", cfg.Port)
+	assert.Equal(t, "8080", cfg.ApiPort)
+	assert.Equal(t, "disable", cfg.SslMode)
+	assert.Equal(t, 25, cfg.MaxOpenConns)
+	assert.Equal(t, 60*time.Minute, cfg.JwtExpiresTime)
+	assert.Equal(t, "refuse", cfg.ArchivePolicy)
+	assert.Equal(t, "local", cfg.StorageDriver)
+	assert.Equal(t, int64(10<<20), cfg.MaxUploadSize)
+	assert.True(t, cfg.FeatureConfig.Metrics)
+	assert.Nil(t, cfg.Location)
+	assert.Empty(t, cfg.CorsOrigins)
+}
+
+func TestLoad_PrecedenceSuccess(t *testing.T) {
+	clearEnv(t)
+	requiredEnv(t)
+	path := writeFile(t, "config.yaml", `
+db:
+  host: file-host
+  sslmode: require
+  max_open_conns: 10
+api:
+  port: 9000
+  cors_origins:
+    - https://app.example.com
+    - https://admin.example.com
+timezone: Asia/Jakarta
+feature:
+  metrics: false
+`)
+	t.Setenv(ConfigFileKey, path)
+	t.Setenv("DB_HOST", "env-host")
+	t.Setenv("API_PORT", "9100")
+
+	cfg, err := Load(Overrides{"API_PORT": "9200"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "env-host", cfg.Host)
+	assert.Equal(t, "require", cfg.SslMode)
+	assert.Equal(t, 10, cfg.MaxOpenConns)
+	assert.Equal(t, "9200", cfg.ApiPort)
+	assert.Equal(t, []string{"https://app.example.com", "https://admin.example.com"}, cfg.CorsOrigins)
+	assert.Equal(t, "Asia/Jakarta", cfg.Location.String())
+	assert.False(t, cfg.FeatureConfig.Metrics)
+	assert.Contains(t, cfg.DSN(), "sslmode=require timezone=Asia/Jakarta")
+}
+
+func TestLoad_TomlSuccess(t *testing.T) {
+	clearEnv(t)
+	requiredEnv(t)
+	path := writeFile(t, "config.toml", `
+[db]
+report_timeout = "1m"
+auto_migrate = true
+
+[token]
+expire = 15
+`)
+
+	cfg, err := Load(Overrides{ConfigFileKey: path})
+
+	require.NoError(t, err)
+	assert.Equal(t, time.Minute, cfg.ReportTimeout)
+	assert.True(t, cfg.AutoMigrate)
+	assert.Equal(t, 15*time.Minute, cfg.JwtExpiresTime)
+}
+
+func TestLoad_TracingEndpointFromFileSuccess(t *testing.T) {
+	clearEnv(t)
+	requiredEnv(t)
+	path := writeFile(t, "config.yaml", `
+otel:
+  exporter:
+    otlp:
+      endpoint: http://otel-collector:4318/
+`)
+
+	cfg, err := Load(Overrides{ConfigFileKey: path})
+
+	require.NoError(t, err)
+	assert.Equal(t, "http://otel-collector:4318/v1/traces", cfg.OtlpEndpoint)
+
+	cfg, err = Load(Overrides{ConfigFileKey: path, "OTEL_EXPORTER_OTLP_TRACES_ENDPOINT": "http://jaeger:4318/traces"})
+
+	require.NoError(t, err)
+	assert.Equal(t, "http://jaeger:4318/traces", cfg.OtlpEndpoint)
+}
+
+func TestLoad_ValidationFailure(t *testing.T) {
+	clearEnv(t)
+	t.Setenv("DB_HOST", "localhost")
+	t.Setenv("TOKEN_EXPIRE", "abc")
+	t.Setenv("DB_SSLMODE", "maybe")
+	t.Setenv("API_PORT", "99999")
+	t.Setenv("TIMEZONE", "Mars/Olympus")
+	t.Setenv("MAIL_HOST", "smtp.example.com")
+
+	_, err := Load(nil)
+
+	var validationErr *ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	var keys []string
+	for _, problem := range validationErr.Problems {
+		keys = append(keys, problem.Key)
+	}
+	assert.ElementsMatch(t, []string{"DB_USER", "DB_NAME", "DB_SSLMODE", "API_PORT", "TIMEZONE", "MAIL_FROM", "TOKEN_ISSUE", "TOKEN_SECRET", "TOKEN_EXPIRE"}, keys)
+	assert.Contains(t, err.Error(), `TOKEN_EXPIRE "abc" must be an integer of at least 1`)
+}
+
+func TestLoad_ZeroTimeoutFailure(t *testing.T) {
+	clearEnv(t)
+	requiredEnv(t)
+	t.Setenv("DB_QUERY_TIMEOUT", "0s")
+	t.Setenv("API_SHUTDOWN_TIMEOUT", "-1s")
+
+	_, err := Load(Overrides{"DB_REPORT_TIMEOUT": "0"})
+
+	var validationErr *ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	var keys []string
+	for _, problem := range validationErr.Problems {
+		keys = append(keys, problem.Key)
+	}
+	assert.ElementsMatch(t, []string{"DB_QUERY_TIMEOUT", "DB_REPORT_TIMEOUT", "API_SHUTDOWN_TIMEOUT"}, keys)
+}
+
+func TestLoad_EmptyEnvKeepsFileValueSuccess(t *testing.T) {
+	clearEnv(t)
+	requiredEnv(t)
+	t.Setenv(ConfigFileKey, writeFile(t, "config.yaml", "mail:\n  host: smtp.example.com\n  from: reservify@example.com\n"))
+	t.Setenv("MAIL_HOST", "")
+
+	cfg, err := Load(nil)
+	require.NoError(t, err)
+	assert.Equal(t, "smtp.example.com", cfg.MailHost)
+
+	cfg, err = Load(Overrides{"MAIL_HOST": ""})
+	require.NoError(t, err)
+	assert.Empty(t, cfg.MailHost)
+}
+
+func TestLoad_StorageS3Failure(t *testing.T) {
+	clearEnv(t)
+	requiredEnv(t)
+	t.Setenv("STORAGE_DRIVER", "s3")
+	t.Setenv("STORAGE_S3_ENDPOINT", "localhost:9000")
+
+	_, err := Load(nil)
+
+	var validationErr *ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	var keys []string
+	for _, problem := range validationErr.Problems {
+		keys = append(keys, problem.Key)
+	}
+	assert.ElementsMatch(t, []string{"STORAGE_S3_ENDPOINT", "STORAGE_S3_BUCKET", "STORAGE_S3_ACCESS_KEY", "STORAGE_S3_SECRET_KEY"}, keys)
+}
+
+func TestLoad_StockAlertRecipientsFailure(t *testing.T) {
+	clearEnv(t)
+	requiredEnv(t)
+	t.Setenv("STOCK_ALERT_RECIPIENTS", "ga@example.com, facilities")
+
+	_, err := Load(nil)
+
+	var validationErr *ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	require.Len(t, validationErr.Problems, 1)
+	assert.Equal(t, "STOCK_ALERT_RECIPIENTS", validationErr.Problems[0].Key)
+	assert.Contains(t, err.Error(), `"facilities" is not a valid email address`)
+}
+
+func TestLoad_UnknownFileKeyFailure(t *testing.T) {
+	clearEnv(t)
+	requiredEnv(t)
+	path := writeFile(t, "config.yml", "db:\n  hots: localhost\n")
+
+	_, err := Load(Overrides{ConfigFileKey: path})
+
+	var validationErr *ValidationError
+	require.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, "DB_HOTS", validationErr.Problems[0].Key)
+}
+
+func TestLoad_FileFailure(t *testing.T) {
+	clearEnv(t)
+	requiredEnv(t)
+
+	_, err := Load(Overrides{ConfigFileKey: writeFile(t, "config.json", "{}")})
+	assert.Error(t, err)
+
+	_, err = Load(Overrides{ConfigFileKey: filepath.Join(t.TempDir(), "missing.yaml")})
+	assert.Error(t, err)
+}
+
+func TestParseFlags_Success(t *testing.T) {
+	overrides, rest, err := ParseFlags([]string{"-config", "prod.yaml", "--db-host", "db", "-api-port=9000", "migrate", "down", "-x"})
+
+	require.NoError(t, err)
+	assert.Equal(t, Overrides{ConfigFileKey: "prod.yaml", "DB_HOST": "db", "API_PORT": "9000"}, overrides)
+	assert.Equal(t, []string{"migrate", "down", "-x"}, rest)
+}
+
+func TestParseFlags_Failure(t *testing.T) {
+	_, _, err := ParseFlags([]string{"-db-hots", "db"})
+	assert.Error(t, err)
+
+	_, _, err = ParseFlags([]string{"-h"})
+	assert.ErrorIs(t, err, flag.ErrHelp)
+}
+
+func TestDSN_QuoteSuccess(t *testing.T) {
+	cfg := DbConfig{Host: "db", Port: "5432", User: "app", Password: "it's secret", Name: "booking", SslMode: "verify-full"}
+
+	assert.Equal(t, `host=db port=5432 user=app password='it\'s secret' dbname=booking sslmode=verify-full`, cfg.DSN())
+}
@@ -0,0 +1,358 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/joho/godotenv"
+	"github.com/pelletier/go-toml/v2"
+	"gopkg.in/yaml.v3"
+)
+
+type setting struct {
+	key   string
+	def   string
+	usage string
+}
+
+// settings lists every configuration key with its default. The same key is
+// used as environment variable, as flag (lower case with dashes, e.g.
+// --db-host) and in the config file (nested, e.g. db: {host: ...}).
+var settings = []setting{
+	{"DB_HOST", "", "database host"},
+	{"DB_PORT", "5432", "database port"},
+	{"DB_USER", "", "database user"},
+	{"DB_PASSWORD", "", "database password"},
+	{"DB_NAME", "", "database name"},
+	{"DB_DRIVER", "postgres", "database driver"},
+	{"DB_SSLMODE", "disable", "disable, allow, prefer, require, verify-ca or verify-full"},
+	{"DB_MAX_OPEN_CONNS", "25", "maximum open database connections, 0 for unlimited"},
+	{"DB_MAX_IDLE_CONNS", "25", "maximum idle database connections"},
+	{"DB_CONN_MAX_LIFETIME", "5m", "maximum lifetime of a database connection"},
+	{"DB_QUERY_TIMEOUT", "5s", "deadline of a single repository operation"},
+	{"DB_REPORT_TIMEOUT", "30s", "deadline of a report query"},
+	{"DB_AUTO_MIGRATE", "false", "apply pending migrations at startup"},
+	{"API_PORT", "8080", "HTTP port"},
+	{"API_READ_TIMEOUT", "15s", "maximum time to read a request"},
+	{"API_WRITE_TIMEOUT", "30s", "maximum time to write a response"},
+	{"API_IDLE_TIMEOUT", "60s", "keep-alive idle timeout"},
+	{"API_SHUTDOWN_TIMEOUT", "20s", "time given to in-flight requests after SIGTERM"},
+	{"API_CORS_ORIGINS", "", "comma separated origins allowed to call the API, * for any"},
+	{"TIMEZONE", "", "IANA time zone of the application and database session, e.g. Asia/Jakarta"},
+	{"LOG_LEVEL", "info", "debug, info, warn or error"},
+	{"TOKEN_ISSUE", "", "JWT issuer"},
+	{"TOKEN_SECRET", "", "JWT signing secret"},
+	{"TOKEN_EXPIRE", "60", "JWT lifetime in minutes"},
+	{"MAIL_HOST", "", "SMTP host, scheduled reports are disabled when empty"},
+	{"MAIL_PORT", "587", "SMTP port"},
+	{"MAIL_USER", "", "SMTP user"},
+	{"MAIL_PASSWORD", "", "SMTP password"},
+	{"MAIL_FROM", "", "sender address of the report mails"},
+	{"STOCK_ALERT_RECIPIENTS", "", "comma separated addresses GA receives low-stock alerts at"},
+	{"OTEL_EXPORTER_OTLP_ENDPOINT", "", "OTLP/HTTP endpoint, tracing is disabled when empty"},
+	{"OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "", "OTLP/HTTP endpoint for traces only"},
+	{"STORAGE_DRIVER", "local", "local or s3: where attachments are stored"},
+	{"STORAGE_DIR", "data/attachments", "directory of the local attachment store"},
+	{"STORAGE_S3_ENDPOINT", "", "S3-compatible endpoint, e.g. http://localhost:9000 for MinIO"},
+	{"STORAGE_S3_REGION", "us-east-1", "S3 region"},
+	{"STORAGE_S3_BUCKET", "", "S3 bucket of the attachments"},
+	{"STORAGE_S3_ACCESS_KEY", "", "S3 access key"},
+	{"STORAGE_S3_SECRET_KEY", "", "S3 secret key"},
+	{"STORAGE_MAX_UPLOAD_MB", "10", "largest attachment in megabytes"},
+	{"FEATURE_REPORT_SCHEDULER", "true", "deliver scheduled reports"},
+	{"FEATURE_MAINTENANCE_SCHEDULER", "true", "start and end room maintenance windows"},
+	{"FEATURE_STOCK_ALERT_SCHEDULER", "true", "check facility stock against its thresholds"},
+	{"FEATURE_METRICS", "true", "expose Prometheus metrics"},
+	{"ARCHIVE_POLICY", "refuse", "refuse or cascade: what archiving does to open bookings of the record"},
+}
+
+// ConfigFileKey names the config file. It is read from the --config flag or
+// the environment, since it has to be known before the file is loaded.
+const ConfigFileKey = "CONFIG_FILE"
+
+// Overrides are settings given on the command line, keyed like the
+// environment variables.
+type Overrides map[string]string
+
+// Load merges, in increasing precedence, the defaults, the optional config
+// file, the environment (including an optional .env file) and overrides. An
+// environment variable that is set but empty is ignored, as deployments often
+// declare every variable; a file value is cleared with an override instead.
+func Load(overrides Overrides) (*Config, error) {
+	values := make(map[string]string, len(settings))
+	for _, s := range settings {
+		values[s.key] = s.def
+	}
+
+	// variables already set in the environment win over .env
+	if err := godotenv.Load(); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, fmt.Errorf("invalid .env file: %v", err)
+	}
+
+	path := overrides[ConfigFileKey]
+	if path == "" {
+		path = os.Getenv(ConfigFileKey)
+	}
+	if path != "" {
+		fileValues, err := readFile(path)
+		if err != nil {
+			return nil, err
+		}
+		for key, value := range fileValues {
+			values[key] = value
+		}
+	}
+
+	// empty variables keep the file value
+	for _, s := range settings {
+		if value, ok := os.LookupEnv(s.key); ok && value != "" {
+			values[s.key] = value
+		}
+	}
+	for key, value := range overrides {
+		if key != ConfigFileKey {
+			values[key] = value
+		}
+	}
+	return build(values)
+}
+
+// ParseFlags reads the configuration flags at the start of args, up to the
+// first argument that is not a flag, and returns the remaining arguments.
+func ParseFlags(args []string) (Overrides, []string, error) {
+	fs := newFlagSet()
+	if err := fs.Parse(args); err != nil {
+		return nil, nil, err
+	}
+
+	overrides := make(Overrides)
+	fs.Visit(func(f *flag.Flag) {
+		overrides[flagKey(f.Name)] = f.Value.String()
+	})
+	return overrides, fs.Args(), nil
+}
+
+// PrintFlags writes the configuration flags and their defaults to w.
+func PrintFlags(w io.Writer) {
+	fs := newFlagSet()
+	fs.SetOutput(w)
+	fs.PrintDefaults()
+}
+
+func newFlagSet() *flag.FlagSet {
+	fs := flag.NewFlagSet("config", flag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	fs.String("config", "", "YAML or TOML config file, also read from "+ConfigFileKey)
+	for _, s := range settings {
+		fs.String(flagName(s.key), s.def, s.usage)
+	}
+	return fs
+}
+
+func flagName(key string) string {
+	return strings.ReplaceAll(strings.ToLower(key), "_", "-")
+}
+
+func flagKey(name string) string {
+	if name == "config" {
+		return ConfigFileKey
+	}
+	return strings.ReplaceAll(strings.ToUpper(name), "-", "_")
+}
+
+// readFile flattens a YAML or TOML file into setting keys, so that
+// db: {max_open_conns: 10} becomes DB_MAX_OPEN_CONNS=10.
+func readFile(path string) (map[string]string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %v", err)
+	}
+
+	var tree map[string]any
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(content, &tree)
+	case ".toml":
+		err = toml.Unmarshal(content, &tree)
+	default:
+		return nil, fmt.Errorf("unsupported config file %s, use .yaml, .yml or .toml", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid config file %s: %v", path, err)
+	}
+
+	values := make(map[string]string)
+	var problems []FieldError
+	flatten("", tree, values)
+	for key := range values {
+		if !known(key) {
+			problems = append(problems, FieldError{Key: key, Message: "is not a known setting"})
+		}
+	}
+	if len(problems) > 0 {
+		sort.Slice(problems, func(i, j int) bool { return problems[i].Key < problems[j].Key })
+		return nil, &ValidationError{Source: path, Problems: problems}
+	}
+	return values, nil
+}
+
+func flatten(prefix string, node any, values map[string]string) {
+	switch node := node.(type) {
+	case map[string]any:
+		for name, child := range node {
+			key := strings.ToUpper(strings.ReplaceAll(name, "-", "_"))
+			if prefix != "" {
+				key = prefix + "_" + key
+			}
+			flatten(key, child, values)
+		}
+	case []any:
+		items := make([]string, 0, len(node))
+		for _, item := range node {
+			items = append(items, fmt.Sprint(item))
+		}
+		values[prefix] = strings.Join(items, ",")
+	case nil:
+		values[prefix] = ""
+	default:
+		values[prefix] = fmt.Sprint(node)
+	}
+}
+
+func known(key string) bool {
+	for _, s := range settings {
+		if s.key == key {
+			return true
+		}
+	}
+	return false
+}
+
+// FieldError is one invalid setting.
+type FieldError struct {
+	Key     string
+	Value   string
+	Message string
+}
+
+func (e FieldError) String() string {
+	if e.Value == "" {
+		return fmt.Sprintf("%s %s", e.Key, e.Message)
+	}
+	return fmt.Sprintf("%s %q %s", e.Key, e.Value, e.Message)
+}
+
+// ValidationError lists every invalid setting at once.
+type ValidationError struct {
+	Source   string
+	Problems []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	var b strings.Builder
+	b.WriteString("invalid configuration")
+	if e.Source != "" {
+		b.WriteString(" in " + e.Source)
+	}
+	b.WriteString(":")
+	for _, problem := range e.Problems {
+		b.WriteString("\n  - " + problem.String())
+	}
+	return b.String()
+}
+
+type parser struct {
+	values   map[string]string
+	problems []FieldError
+}
+
+func (p *parser) fail(key, message string) {
+	p.problems = append(p.problems, FieldError{Key: key, Value: p.values[key], Message: message})
+}
+
+func (p *parser) str(key string) string {
+	return strings.TrimSpace(p.values[key])
+}
+
+func (p *parser) required(key string) string {
+	value := p.str(key)
+	if value == "" {
+		p.fail(key, "is required")
+	}
+	return value
+}
+
+func (p *parser) int(key string, min int) int {
+	n, err := strconv.Atoi(p.str(key))
+	if err != nil || n < min {
+		p.fail(key, fmt.Sprintf("must be an integer of at least %d", min))
+		return 0
+	}
+	return n
+}
+
+func (p *parser) port(key string) string {
+	value := p.str(key)
+	if n, err := strconv.Atoi(value); err != nil || n < 1 || n > 65535 {
+		p.fail(key, "must be a port between 1 and 65535")
+	}
+	return value
+}
+
+func (p *parser) duration(key string) time.Duration {
+	d, err := time.ParseDuration(p.str(key))
+	if err != nil || d < 0 {
+		p.fail(key, "must be a duration such as 30s")
+		return 0
+	}
+	return d
+}
+
+// positiveDuration is a duration that must be above zero, for deadlines that
+// would otherwise expire at once.
+func (p *parser) positiveDuration(key string) time.Duration {
+	d, err := time.ParseDuration(p.str(key))
+	if err != nil || d <= 0 {
+		p.fail(key, "must be a positive duration such as 30s")
+		return 0
+	}
+	return d
+}
+
+func (p *parser) bool(key string) bool {
+	b, err := strconv.ParseBool(p.str(key))
+	if err != nil {
+		p.fail(key, "must be true or false")
+	}
+	return b
+}
+
+func (p *parser) oneOf(key string, allowed ...string) string {
+	value := p.str(key)
+	for _, a := range allowed {
+		if value == a {
+			return value
+		}
+	}
+	p.fail(key, "must be one of "+strings.Join(allowed, ", "))
+	return value
+}
+
+func (p *parser) list(key string) []string {
+	var items []string
+	for _, item := range strings.Split(p.str(key), ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
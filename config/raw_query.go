@@ -6,50 +6,291 @@ const (
 	UpdateRoomFacility         = `UPDATE trx_room_facility SET room_id = $1, facility_id = $2, quantity = $3, description= $4, updated_at = CURRENT_TIMESTAMP WHERE id=$5 RETURNING created_at, updated_at`
 	GetCountRoomFacility       = `SELECT COUNT(*) FROM trx_room_facility`
 	GetQuantityFacilityByID    = `SELECT quantity FROM facilities WHERE id = $1`
-	UpdateQuantityFacilityByID = `UPDATE facilities SET quantity = $1 WHERE id = $2`
+	LockRoomFacilityAllocation = `SELECT facility_id, quantity FROM trx_room_facility WHERE id = $1 FOR UPDATE`
 	InsertTrxRoomFacility      = `INSERT INTO trx_room_facility (room_id, facility_id, quantity, description, updated_at) VALUES ($1, $2, $3, $4,CURRENT_TIMESTAMP) RETURNING id, created_at, updated_at`
 
-	SelectTransactionList         = `SELECT id, employee_id, room_id, description, status, start_time, end_time, created_at, updated_at FROM transactions WHERE created_at BETWEEN $3 AND ($4::date + 1) - interval '1 second' ORDER BY created_at DESC LIMIT $1 OFFSET $2`
-	SelectRoomWithFacilities      = `SELECT r.id, r.facility_id, r.quantity, r.description, r.created_at, r.updated_at FROM rooms t JOIN trx_room_facility r on t.id = r.room_id WHERE t.id = $1;`
-	GetIdListTransaction          = `SELECT COUNT(*) FROM transactions`
-	GetEmployeeIdListTransaction  = `SELECT COUNT(*) FROM transactions WHERE employee_id = $1`
-	SelectTransactionByID         = `SELECT id, employee_id, room_id, description, status, start_time, end_time, created_at, updated_at FROM transactions WHERE id = $1`
-	SelectTransactionByEmployeeID = `SELECT id, employee_id, room_id, description, status, start_time, end_time, created_at, updated_at FROM transactions WHERE employee_id = $1 ORDER BY created_at DESC LIMIT $2 OFFSET $3`
-	InsertTransactions            = `INSERT INTO transactions (employee_id, room_id, description, start_time, end_time, updated_at) VALUES ($1, $2, $3, $4, $5, CURRENT_TIMESTAMP) RETURNING id, status, created_at, updated_at`
-	UpdatePermission              = `UPDATE transactions SET status = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2 RETURNING employee_id, room_id, description, start_time, end_time, created_at`
-	InsertRoomFacility            = `INSERT INTO trx_room_facility (room_id, facility_id, quantity, description, updated_at) VALUES ($1, $2, $3, $4, CURRENT_TIMESTAMP) RETURNING id, created_at, updated_at`
-	UpdateFacilityQuantity        = `UPDATE facilities SET quantity = quantity - $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2 RETURNING id, created_at, updated_at`
-	SelectQuantityFacility        = `SELECT quantity FROM facilities WHERE id = $1`
-	SelectRoomByID2               = `SELECT status FROM rooms WHERE id = $1`
+	DeleteRoomFacility         = `DELETE FROM trx_room_facility WHERE id = $1`
+	LockFacilityQuantity       = `SELECT quantity FROM facilities WHERE id = $1 FOR UPDATE`
+	LockRoomFacility           = `SELECT id, room_id, facility_id, quantity, COALESCE(description, ''), created_at, updated_at FROM trx_room_facility WHERE id = $1 FOR UPDATE`
+	UpdateRoomFacilityQuantity = `UPDATE trx_room_facility SET quantity = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $1 RETURNING updated_at`
+
+	// LockTransferRoomFacilities locks the allocation $1 a transfer takes from
+	// and the allocation of the same facility in room $2 it adds to, in id
+	// order so that two transfers between the same rooms cannot deadlock.
+	LockTransferRoomFacilities = `SELECT id, room_id, facility_id, quantity, COALESCE(description, ''), created_at, updated_at FROM trx_room_facility WHERE id IN ($1, (SELECT t.id FROM trx_room_facility t WHERE t.room_id = $2 AND t.facility_id = (SELECT s.facility_id FROM trx_room_facility s WHERE s.id = $1) ORDER BY t.created_at LIMIT 1)) ORDER BY id FOR UPDATE`
+	// LockRoomStatus reads the status of a room, 'archived' for an archived one,
+	// and keeps it from being archived or taken out of service until the
+	// transaction ends.
+	LockRoomStatus = `SELECT CASE WHEN archived_at IS NULL THEN status::text ELSE 'archived' END FROM rooms WHERE id = $1 FOR SHARE`
+
+	SelectTransactionList                       = `SELECT id, employee_id, room_id, description, status, start_time, end_time, created_at, updated_at FROM transactions WHERE created_at BETWEEN $3 AND ($4::date + 1) - interval '1 second' ORDER BY created_at DESC LIMIT $1 OFFSET $2`
+	SelectTransactionFacilitiesByTransactionIDs = `SELECT id, transaction_id, facility_id, quantity, description, created_at, updated_at FROM transaction_facilities WHERE transaction_id = ANY($1) ORDER BY created_at`
+	GetIdListTransaction                        = `SELECT COUNT(*) FROM transactions`
+	GetEmployeeIdListTransaction                = `SELECT COUNT(*) FROM transactions WHERE employee_id = $1`
+	SelectTransactionByID                       = `SELECT id, employee_id, room_id, description, status, start_time, end_time, created_at, updated_at FROM transactions WHERE id = $1`
+	SelectTransactionByEmployeeID               = `SELECT id, employee_id, room_id, description, status, start_time, end_time, created_at, updated_at FROM transactions WHERE employee_id = $1 ORDER BY created_at DESC LIMIT $2 OFFSET $3`
+	InsertTransactions                          = `INSERT INTO transactions (employee_id, room_id, description, start_time, end_time, updated_at) VALUES ($1, $2, $3, $4, $5, CURRENT_TIMESTAMP) RETURNING id, status, created_at, updated_at`
+	UpdatePermission                            = `UPDATE transactions SET status = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2 RETURNING employee_id, room_id, description, start_time, end_time, created_at`
+	InsertTransactionFacility                   = `INSERT INTO transaction_facilities (transaction_id, facility_id, quantity, description, updated_at) VALUES ($1, $2, $3, $4, CURRENT_TIMESTAMP) RETURNING id, created_at, updated_at`
+	SelectQuantityFacility                      = `SELECT quantity, archived_at IS NOT NULL FROM facilities WHERE id = $1 FOR UPDATE`
+	DeleteTransactionFacilitiesBefore           = `DELETE FROM transaction_facilities WHERE transaction_id IN (SELECT id FROM transactions WHERE end_time < $1)`
+	DeleteTransactionCostsBefore                = `DELETE FROM transaction_costs WHERE transaction_id IN (SELECT id FROM transactions WHERE end_time < $1)`
+	DeleteTransactionsBefore                    = `DELETE FROM transactions WHERE end_time < $1`
+	DeclineTransactions                         = `UPDATE transactions SET status = 'declined', updated_at = CURRENT_TIMESTAMP WHERE id = ANY($1)`
 	// `SELECT id, date, amount, transaction_type, balance, description, created_at, updated_at FROM expenses WHERE LOWER(transaction_type::text) = LOWER($1)`
 
-	InsertRoom            = `INSERT INTO rooms (name, room_type, capacity, status) VALUES ($1, $2, $3, $4) RETURNING id, created_at, updated_at`
-	SelectRoomByID        = `SELECT id, name, room_type, capacity, status, created_at, updated_at FROM rooms WHERE id = $1`
-	SelectRoomList        = `SELECT id, name, room_type, capacity, status, created_at, updated_at FROM rooms ORDER BY created_at DESC LIMIT $1 OFFSET $2`
-	SelectRoomListStatus  = `SELECT id, name, room_type, capacity, status, created_at, updated_at FROM rooms WHERE status = $1 ORDER BY created_at DESC LIMIT $2 OFFSET $3`
-	UpdateRoomByID        = `UPDATE rooms SET name = $2, room_type = $3, capacity = $4, status = $5, updated_at = CURRENT_TIMESTAMP WHERE id = $1 RETURNING created_at, updated_at`
-	UpdateRoomStatus      = `UPDATE rooms SET status = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $1 RETURNING name, room_type, capacity, created_at, updated_at`
-	SelectCountRoom       = `SELECT COUNT(*) FROM rooms`
-	SelectCountRoomStatus = `SELECT COUNT(*) FROM rooms WHERE status = $1`
-
-	InsertFasilities     = `INSERT INTO facilities (name, quantity) VALUES ($1, $2) RETURNING id, created_at, updated_at`
-	SelectFasilitiesList = `SELECT id, name, quantity, created_at, updated_at FROM facilities ORDER BY created_at DESC LIMIT $1 OFFSET $2`
-	SelectFasilitiesById = `SELECT id, name, quantity, created_at, updated_at FROM facilities WHERE id = $1`
-	UpdateFasilities     = `UPDATE facilities SET name = $1, quantity = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $3 RETURNING created_at, updated_at`
-	TotalRowsFasilities  = `SELECT COUNT(*) FROM facilities`
+	// SelectBookedFacilities sums what each booking in $1 still holds of the
+	// stock per facility, in facility order so that their rows are locked in
+	// order. Assets checked in damaged or retired gave their unit back when
+	// they came in, so they are not held any more.
+	SelectBookedFacilities = `SELECT b.transaction_id, b.facility_id, b.booked - b.returned FROM (` +
+		`SELECT tf.transaction_id, tf.facility_id, SUM(tf.quantity) AS booked, ` +
+		`(SELECT COUNT(*) FROM facility_asset_checkouts c JOIN facility_assets a ON a.id = c.asset_id WHERE c.transaction_id = tf.transaction_id AND a.facility_id = tf.facility_id AND c.condition IN ('damaged', 'retired')) AS returned ` +
+		`FROM transaction_facilities tf WHERE tf.transaction_id = ANY($1) GROUP BY tf.transaction_id, tf.facility_id) b ` +
+		`WHERE b.booked > b.returned ORDER BY b.facility_id, b.transaction_id`
+	LockTransactionStatus = `SELECT status FROM transactions WHERE id = $1 FOR UPDATE`
+
+	InsertRoom                = `INSERT INTO rooms (name, room_type, capacity, status, floor_id) VALUES ($1, $2, $3, $4, NULLIF($5, '')::uuid) RETURNING id, created_at, updated_at`
+	SelectRoomByID            = `SELECT r.id, r.name, r.room_type, r.capacity, r.status, r.created_at, r.updated_at, r.archived_at, r.floor_id, f.name, f.level, b.id, b.name, s.id, s.name, s.timezone FROM rooms r LEFT JOIN floors f ON f.id = r.floor_id LEFT JOIN buildings b ON b.id = f.building_id LEFT JOIN sites s ON s.id = b.site_id WHERE r.id = $1`
+	SelectRoomList            = `SELECT r.id, r.name, r.room_type, r.capacity, r.status, r.created_at, r.updated_at, r.floor_id, f.name, f.level, b.id, b.name, s.id, s.name, s.timezone FROM rooms r LEFT JOIN floors f ON f.id = r.floor_id LEFT JOIN buildings b ON b.id = f.building_id LEFT JOIN sites s ON s.id = b.site_id WHERE r.archived_at IS NULL ORDER BY r.created_at DESC LIMIT $1 OFFSET $2`
+	SelectRoomListStatus      = `SELECT r.id, r.name, r.room_type, r.capacity, r.status, r.created_at, r.updated_at, r.floor_id, f.name, f.level, b.id, b.name, s.id, s.name, s.timezone FROM rooms r LEFT JOIN floors f ON f.id = r.floor_id LEFT JOIN buildings b ON b.id = f.building_id LEFT JOIN sites s ON s.id = b.site_id WHERE r.status = $1 AND r.archived_at IS NULL ORDER BY r.created_at DESC LIMIT $2 OFFSET $3`
+	UpdateRoomByID            = `UPDATE rooms SET name = $2, room_type = $3, capacity = $4, status = $5, floor_id = NULLIF($6, '')::uuid, updated_at = CURRENT_TIMESTAMP WHERE id = $1 RETURNING created_at, updated_at`
+	UpdateRoomStatus          = `UPDATE rooms SET status = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $1 RETURNING name, room_type, capacity, created_at, updated_at`
+	SelectCountRoom           = `SELECT COUNT(*) FROM rooms WHERE archived_at IS NULL`
+	SelectCountRoomStatus     = `SELECT COUNT(*) FROM rooms WHERE status = $1 AND archived_at IS NULL`
+	SelectRoomListByLocation  = `SELECT r.id, r.name, r.room_type, r.capacity, r.status, r.created_at, r.updated_at, r.floor_id, f.name, f.level, b.id, b.name, s.id, s.name, s.timezone FROM rooms r LEFT JOIN floors f ON f.id = r.floor_id LEFT JOIN buildings b ON b.id = f.building_id LEFT JOIN sites s ON s.id = b.site_id WHERE r.archived_at IS NULL AND ($1 = '' OR r.status::text = $1) AND ($2 = '' OR s.id::text = $2) AND ($3 = '' OR b.id::text = $3) AND ($4 = '' OR f.id::text = $4) ORDER BY r.created_at DESC LIMIT $5 OFFSET $6`
+	SelectCountRoomByLocation = `SELECT COUNT(*) FROM rooms r LEFT JOIN floors f ON f.id = r.floor_id LEFT JOIN buildings b ON b.id = f.building_id LEFT JOIN sites s ON s.id = b.site_id WHERE r.archived_at IS NULL AND ($1 = '' OR r.status::text = $1) AND ($2 = '' OR s.id::text = $2) AND ($3 = '' OR b.id::text = $3) AND ($4 = '' OR f.id::text = $4)`
+	SelectAvailableRooms      = `SELECT r.id, r.name, r.room_type, r.capacity, r.status, r.created_at, r.updated_at, r.floor_id, f.name, f.level, b.id, b.name, s.id, s.name, s.timezone ` + availableRooms + ` ORDER BY r.name LIMIT $8 OFFSET $9`
+	SelectCountAvailableRooms = `SELECT COUNT(*) ` + availableRooms
+
+	// availableRooms holds the rooms at the site $1, building $2 or floor $3
+	// that can be booked from $4 to $5: available, without a booking,
+	// maintenance window or blackout overlapping the period, not closed for a
+	// holiday on one of its local days, and within one opening interval of the
+	// business hours of the room, or else of its site. $6 and $7 are the period
+	// again, read with its offset to find the local time at the site.
+	availableRooms = `FROM rooms r LEFT JOIN floors f ON f.id = r.floor_id LEFT JOIN buildings b ON b.id = f.building_id LEFT JOIN sites s ON s.id = b.site_id ` +
+		`CROSS JOIN LATERAL (SELECT $6::timestamptz AT TIME ZONE COALESCE(s.timezone, 'UTC') AS start_time, $7::timestamptz AT TIME ZONE COALESCE(s.timezone, 'UTC') AS end_time) l ` +
+		`WHERE r.archived_at IS NULL AND r.status = 'available' AND ($1 = '' OR s.id::text = $1) AND ($2 = '' OR b.id::text = $2) AND ($3 = '' OR f.id::text = $3) ` +
+		`AND NOT EXISTS (SELECT 1 FROM transactions t WHERE t.room_id = r.id AND t.status IN ('pending', 'accepted') AND t.start_time < $5 AND t.end_time > $4) ` +
+		`AND NOT EXISTS (SELECT 1 FROM room_maintenance m WHERE m.room_id = r.id AND m.start_time < $5 AND m.end_time > $4) ` +
+		`AND NOT EXISTS (SELECT 1 FROM blackouts k WHERE (k.room_id = r.id OR k.site_id = s.id OR (k.site_id IS NULL AND k.room_id IS NULL)) AND k.start_time < $5 AND k.end_time > $4) ` +
+		`AND NOT EXISTS (SELECT 1 FROM holidays h WHERE (h.site_id IS NULL OR h.site_id = s.id) AND h.date >= l.start_time::date AND h.date < l.end_time) ` +
+		`AND (NOT EXISTS (SELECT 1 FROM business_hours o WHERE o.room_id = r.id OR o.site_id = s.id) OR EXISTS (SELECT 1 FROM business_hours o ` +
+		`WHERE (o.room_id = r.id OR (o.site_id = s.id AND NOT EXISTS (SELECT 1 FROM business_hours ro WHERE ro.room_id = r.id))) ` +
+		`AND o.weekday = EXTRACT(DOW FROM l.start_time) AND o.open_time <= date_trunc('minute', l.start_time)::time ` +
+		`AND CASE l.end_time::date - l.start_time::date WHEN 0 THEN date_trunc('minute', l.end_time)::time <= o.close_time ` +
+		`WHEN 1 THEN date_trunc('minute', l.end_time)::time = '00:00' AND o.close_time = '24:00' ELSE false END))`
+
+	SelectArchivedRoomList     = `SELECT id, name, room_type, capacity, status, created_at, updated_at, archived_at FROM rooms WHERE archived_at IS NOT NULL ORDER BY archived_at DESC LIMIT $1 OFFSET $2`
+	SelectCountArchivedRoom    = `SELECT COUNT(*) FROM rooms WHERE archived_at IS NOT NULL`
+	LockRoom                   = `SELECT archived_at FROM rooms WHERE id = $1 FOR UPDATE`
+	SelectOpenRoomTransactions = `SELECT id FROM transactions WHERE room_id = $1 AND status IN ('pending', 'accepted') AND end_time > CURRENT_TIMESTAMP FOR UPDATE`
+	ArchiveRoom                = `UPDATE rooms SET archived_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP WHERE id = $1 RETURNING archived_at`
+	RestoreRoom                = `UPDATE rooms SET archived_at = NULL, updated_at = CURRENT_TIMESTAMP WHERE id = $1 RETURNING id, name, room_type, capacity, status, created_at, updated_at`
+
+	InsertFasilities     = `INSERT INTO facilities (name, quantity) VALUES ($1, 0) RETURNING id, created_at, updated_at`
+	SelectFasilitiesList = `SELECT id, name, quantity, created_at, updated_at FROM facilities WHERE archived_at IS NULL ORDER BY created_at DESC LIMIT $1 OFFSET $2`
+	SelectFasilitiesById = `SELECT id, name, quantity, created_at, updated_at, archived_at FROM facilities WHERE id = $1`
+	UpdateFasilities     = `UPDATE facilities SET name = $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2 RETURNING quantity, created_at, updated_at`
+	TotalRowsFasilities  = `SELECT COUNT(*) FROM facilities WHERE archived_at IS NULL`
+
+	SelectArchivedFacilityList     = `SELECT id, name, quantity, created_at, updated_at, archived_at FROM facilities WHERE archived_at IS NOT NULL ORDER BY archived_at DESC LIMIT $1 OFFSET $2`
+	SelectCountArchivedFacility    = `SELECT COUNT(*) FROM facilities WHERE archived_at IS NOT NULL`
+	LockFacility                   = `SELECT archived_at FROM facilities WHERE id = $1 FOR UPDATE`
+	SelectOpenFacilityTransactions = `SELECT t.id FROM transactions t WHERE t.status IN ('pending', 'accepted') AND t.end_time > CURRENT_TIMESTAMP AND EXISTS (SELECT 1 FROM transaction_facilities tf WHERE tf.transaction_id = t.id AND tf.facility_id = $1) FOR UPDATE`
+	ArchiveFacility                = `UPDATE facilities SET archived_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP WHERE id = $1 RETURNING archived_at`
+	RestoreFacility                = `UPDATE facilities SET archived_at = NULL, updated_at = CURRENT_TIMESTAMP WHERE id = $1 RETURNING id, name, quantity, created_at, updated_at`
+
+	InsertFacilityMovement  = `INSERT INTO facility_movements (facility_id, kind, quantity, balance, actor_id, reason, reference_id) VALUES ($1, $2, $3, 0, NULLIF($4, '')::uuid, $5, NULLIF($6, '')::uuid) RETURNING id, balance, created_at`
+	SelectFacilityMovements = `SELECT id, facility_id, kind, quantity, balance, COALESCE(actor_id::text, ''), reason, COALESCE(reference_id::text, ''), created_at FROM facility_movements WHERE facility_id = $1 ORDER BY created_at DESC, id LIMIT $2 OFFSET $3`
+	CountFacilityMovements  = `SELECT COUNT(*) FROM facility_movements WHERE facility_id = $1`
+
+	SelectFacilityThreshold = `SELECT id, min_quantity, quantity, updated_at FROM facilities WHERE id = $1`
+	UpdateFacilityThreshold = `UPDATE facilities SET min_quantity = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $1 RETURNING id, min_quantity, quantity, updated_at`
+
+	// stockAlertColumns are the columns of an alert a joined with its facility f.
+	stockAlertColumns = `a.id, a.facility_id, f.name, a.quantity, a.min_quantity, a.notified_at, COALESCE(a.acknowledged_by::text, ''), a.acknowledged_at, a.resolved_at, a.created_at`
+	// ResolveStockAlerts and RaiseStockAlerts check the facilities in $1, or
+	// all of them when $1 is NULL.
+	ResolveStockAlerts = `UPDATE facility_stock_alerts a SET resolved_at = CURRENT_TIMESTAMP FROM facilities f WHERE f.id = a.facility_id AND a.resolved_at IS NULL ` +
+		`AND (f.quantity >= f.min_quantity OR f.archived_at IS NOT NULL) AND ($1::uuid[] IS NULL OR f.id = ANY($1))`
+	RaiseStockAlerts = `WITH a AS (INSERT INTO facility_stock_alerts (facility_id, quantity, min_quantity) SELECT id, quantity, min_quantity FROM facilities ` +
+		`WHERE quantity < min_quantity AND archived_at IS NULL AND ($1::uuid[] IS NULL OR id = ANY($1)) ON CONFLICT (facility_id) WHERE resolved_at IS NULL DO NOTHING RETURNING *) ` +
+		`SELECT ` + stockAlertColumns + ` FROM a JOIN facilities f ON f.id = a.facility_id ORDER BY f.name`
+	// ClaimStockAlertNotifications marks the open alerts nobody was told about
+	// yet as notified, so only one instance sends them.
+	ClaimStockAlertNotifications = `WITH a AS (UPDATE facility_stock_alerts SET notified_at = CURRENT_TIMESTAMP WHERE notified_at IS NULL AND resolved_at IS NULL RETURNING *) ` +
+		`SELECT ` + stockAlertColumns + ` FROM a JOIN facilities f ON f.id = a.facility_id ORDER BY f.name`
+	UnclaimStockAlertNotifications = `UPDATE facility_stock_alerts SET notified_at = NULL WHERE id = ANY($1)`
+	SelectStockAlerts              = `SELECT ` + stockAlertColumns + ` FROM facility_stock_alerts a JOIN facilities f ON f.id = a.facility_id WHERE $1 = '' OR ($1 = 'open') = (a.resolved_at IS NULL) ORDER BY a.created_at DESC, a.id LIMIT $2 OFFSET $3`
+	CountStockAlerts               = `SELECT COUNT(*) FROM facility_stock_alerts a WHERE $1 = '' OR ($1 = 'open') = (a.resolved_at IS NULL)`
+	AcknowledgeStockAlert          = `WITH a AS (UPDATE facility_stock_alerts SET acknowledged_by = NULLIF($2, '')::uuid, acknowledged_at = CURRENT_TIMESTAMP WHERE id = $1 RETURNING *) ` +
+		`SELECT ` + stockAlertColumns + ` FROM a JOIN facilities f ON f.id = a.facility_id`
+
+	// facilityAssetColumns are the columns of an asset a and of its open
+	// check-out c, joined by facilityAssetTables.
+	facilityAssetColumns = `a.id, a.facility_id, a.asset_tag, a.serial_number, a.condition, COALESCE(to_char(a.purchase_date, 'YYYY-MM-DD'), ''), COALESCE(a.room_id::text, ''), a.location, ` +
+		`COALESCE(c.transaction_id::text, ''), c.checked_out_at, a.created_at, a.updated_at`
+	facilityAssetTables = `facility_assets a LEFT JOIN facility_asset_checkouts c ON c.asset_id = a.id AND c.checked_in_at IS NULL`
+	// LockFacilityForAsset locks the facility an asset is registered for and
+	// tells whether it is tracked, archived and allocated to rooms.
+	LockFacilityForAsset    = `SELECT f.quantity, f.tracked, f.archived_at IS NOT NULL, EXISTS (SELECT 1 FROM trx_room_facility r WHERE r.facility_id = f.id) FROM facilities f WHERE f.id = $1 FOR UPDATE`
+	TrackFacility           = `UPDATE facilities SET tracked = TRUE, updated_at = CURRENT_TIMESTAMP WHERE id = $1`
+	InsertFacilityAsset     = `INSERT INTO facility_assets (facility_id, asset_tag, serial_number, condition, purchase_date, room_id, location) VALUES ($1, $2, $3, $4, NULLIF($5, '')::date, NULLIF($6, '')::uuid, $7) RETURNING id, created_at, updated_at`
+	SelectFacilityAssetList = `SELECT ` + facilityAssetColumns + ` FROM ` + facilityAssetTables + ` WHERE a.facility_id = $1 ORDER BY a.asset_tag LIMIT $2 OFFSET $3`
+	CountFacilityAssets     = `SELECT COUNT(*) FROM facility_assets WHERE facility_id = $1`
+	SelectFacilityAssetById = `SELECT ` + facilityAssetColumns + ` FROM ` + facilityAssetTables + ` WHERE a.id = $1`
+	LockFacilityAsset       = `SELECT ` + facilityAssetColumns + ` FROM ` + facilityAssetTables + ` WHERE a.id = $1 FOR UPDATE OF a`
+	UpdateFacilityAsset     = `UPDATE facility_assets SET asset_tag = $2, serial_number = $3, condition = $4, purchase_date = NULLIF($5, '')::date, room_id = NULLIF($6, '')::uuid, location = $7, updated_at = CURRENT_TIMESTAMP WHERE id = $1 RETURNING updated_at`
+	// LockCheckoutBooking locks the booking $1 an asset of facility $2 is
+	// checked out to, and tells whether it is accepted and not over, how many
+	// units of the facility it booked and how many assets it has out.
+	LockCheckoutBooking = `SELECT t.status = 'accepted' AND t.end_time > CURRENT_TIMESTAMP, ` +
+		`COALESCE((SELECT SUM(tf.quantity) FROM transaction_facilities tf WHERE tf.transaction_id = t.id AND tf.facility_id = $2), 0), ` +
+		`(SELECT COUNT(*) FROM facility_asset_checkouts c JOIN facility_assets a ON a.id = c.asset_id WHERE c.transaction_id = t.id AND a.facility_id = $2 AND c.checked_in_at IS NULL) ` +
+		`FROM transactions t WHERE t.id = $1 FOR UPDATE`
+	InsertAssetCheckout  = `INSERT INTO facility_asset_checkouts (asset_id, transaction_id, checked_out_by) VALUES ($1, $2, NULLIF($3, '')::uuid) RETURNING checked_out_at`
+	CheckInAssetCheckout = `UPDATE facility_asset_checkouts SET checked_in_by = NULLIF($2, '')::uuid, checked_in_at = CURRENT_TIMESTAMP, condition = $3 WHERE asset_id = $1 AND checked_in_at IS NULL`
+	// LockCheckInBooking locks the booking $1 an asset comes back from and
+	// tells whether it still holds its units, i.e. was not declined.
+	LockCheckInBooking   = `SELECT status <> 'declined' FROM transactions WHERE id = $1 FOR UPDATE`
+	SelectAssetCheckouts = `SELECT id, asset_id, COALESCE(transaction_id::text, ''), COALESCE(checked_out_by::text, ''), checked_out_at, COALESCE(checked_in_by::text, ''), checked_in_at, COALESCE(condition, '') ` +
+		`FROM facility_asset_checkouts WHERE asset_id = $1 ORDER BY checked_out_at DESC, id LIMIT $2 OFFSET $3`
+	CountAssetCheckouts = `SELECT COUNT(*) FROM facility_asset_checkouts WHERE asset_id = $1`
 
 	// Employee
 	// done
-	InsertEmployee    = "INSERT INTO employees(name, username, password, role, division, position, contact, updated_at) VALUES($1, $2, crypt($3, gen_salt('bf')), $4, $5, $6, $7, CURRENT_TIMESTAMP) RETURNING id, created_at, updated_at;"
-	SelectAllEmployee = "SELECT id, name, username, password, role, division, position, contact, created_at, updated_at FROM employees LIMIT $1 OFFSET $2;"
+	InsertEmployee      = "INSERT INTO employees(name, username, password, role, division, position, contact, updated_at) VALUES($1, $2, crypt($3, gen_salt('bf')), $4, $5, $6, $7, CURRENT_TIMESTAMP) RETURNING id, created_at, updated_at;"
+	SelectAllEmployee   = "SELECT id, name, username, password, role, division, position, contact, created_at, updated_at FROM employees WHERE archived_at IS NULL LIMIT $1 OFFSET $2;"
+	SelectCountEmployee = `SELECT COUNT(*) FROM employees WHERE archived_at IS NULL`
 	// done
-	SelectEmployeeByID       = "SELECT id, name, username, password, role, division, position, contact, created_at, updated_at FROM employees WHERE id = $1;"
-	SelectEmployeeByUsername = "SELECT id, name, username, password, role, division, position, contact, created_at, updated_at FROM employees WHERE username = $1;"
-	SelectEmployeeForLogin   = `SELECT id, name, username, password, role FROM employees WHERE username = $1 AND password = crypt($2, password)`
+	SelectEmployeeByID       = "SELECT id, name, username, password, role, division, position, contact, created_at, updated_at, archived_at FROM employees WHERE id = $1;"
+	SelectEmployeeByUsername = "SELECT id, name, username, password, role, division, position, contact, created_at, updated_at, archived_at FROM employees WHERE username = $1;"
+	SelectEmployeeForLogin   = `SELECT id, name, username, password, role FROM employees WHERE username = $1 AND password = crypt($2, password) AND archived_at IS NULL`
 	// done
 
 	UpdateEmployee = `UPDATE employees SET name = $1, username = $2, password = crypt($3, password), role = $4, division = $5, position = $6, contact = $7, updated_at = CURRENT_TIMESTAMP WHERE id = $8 RETURNING created_at, updated_at`
 
-	SelectReportList             = `SELECT t.id, t.employee_id, e.name, e.username, e.division, e.position, e.contact, t.room_id, r.name, r.room_type, r.capacity, t.description, t.status, t.start_time, t.end_time, t.created_at, t.updated_at FROM transactions t JOIN employees e on e.id = t.employee_id JOIN rooms r on r.id = t.room_id WHERE t.created_at BETWEEN $1 AND $2 ORDER BY created_at DESC`
-	SelectReportFacilityByRoomID = `SELECT t.facility_id, f.name, t.quantity FROM trx_room_facility t JOIN facilities f ON t.facility_id = f.id WHERE t.room_id = $1`
+	SelectArchivedEmployeeList     = `SELECT id, name, username, password, role, division, position, contact, created_at, updated_at, archived_at FROM employees WHERE archived_at IS NOT NULL ORDER BY archived_at DESC LIMIT $1 OFFSET $2`
+	SelectCountArchivedEmployee    = `SELECT COUNT(*) FROM employees WHERE archived_at IS NOT NULL`
+	LockEmployee                   = `SELECT archived_at FROM employees WHERE id = $1 FOR UPDATE`
+	SelectOpenEmployeeTransactions = `SELECT id FROM transactions WHERE employee_id = $1 AND status IN ('pending', 'accepted') AND end_time > CURRENT_TIMESTAMP FOR UPDATE`
+	ArchiveEmployee                = `UPDATE employees SET archived_at = CURRENT_TIMESTAMP, updated_at = CURRENT_TIMESTAMP WHERE id = $1 RETURNING archived_at`
+	RestoreEmployee                = `UPDATE employees SET archived_at = NULL, updated_at = CURRENT_TIMESTAMP WHERE id = $1 RETURNING id, name, username, password, role, division, position, contact, created_at, updated_at`
+
+	SelectReportList                     = `SELECT t.id, t.employee_id, e.name, e.username, e.division, e.position, e.contact, t.room_id, r.name, r.room_type, r.capacity, t.description, t.status, t.start_time, t.end_time, t.created_at, t.updated_at, r.floor_id, f.name, f.level, b.id, b.name, s.id, s.name, s.timezone FROM transactions t JOIN employees e on e.id = t.employee_id JOIN rooms r on r.id = t.room_id LEFT JOIN floors f ON f.id = r.floor_id LEFT JOIN buildings b ON b.id = f.building_id LEFT JOIN sites s ON s.id = b.site_id WHERE t.created_at BETWEEN $1 AND $2 ORDER BY t.created_at DESC`
+	SelectReportFacilityByTransactionIDs = `SELECT t.transaction_id, t.facility_id, f.name, t.quantity FROM transaction_facilities t JOIN facilities f ON t.facility_id = f.id WHERE t.transaction_id = ANY($1) ORDER BY t.created_at`
+
+	InsertReportSchedule        = `INSERT INTO report_schedules (name, cron_expression, range_param, filter_status, filter_division, filter_room_id, filter_site_id, filter_building_id, filter_floor_id, format, recipients, is_active, updated_at) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, CURRENT_TIMESTAMP) RETURNING id, created_at, updated_at`
+	SelectReportScheduleList    = `SELECT id, name, cron_expression, range_param, filter_status, filter_division, filter_room_id, filter_site_id, filter_building_id, filter_floor_id, format, recipients, is_active, last_run_at, created_at, updated_at FROM report_schedules ORDER BY created_at DESC LIMIT $1 OFFSET $2`
+	SelectReportScheduleByID    = `SELECT id, name, cron_expression, range_param, filter_status, filter_division, filter_room_id, filter_site_id, filter_building_id, filter_floor_id, format, recipients, is_active, last_run_at, created_at, updated_at FROM report_schedules WHERE id = $1`
+	SelectActiveReportSchedule  = `SELECT id, name, cron_expression, range_param, filter_status, filter_division, filter_room_id, filter_site_id, filter_building_id, filter_floor_id, format, recipients, is_active, last_run_at, created_at, updated_at FROM report_schedules WHERE is_active = TRUE`
+	UpdateReportSchedule        = `UPDATE report_schedules SET name = $1, cron_expression = $2, range_param = $3, filter_status = $4, filter_division = $5, filter_room_id = $6, filter_site_id = $7, filter_building_id = $8, filter_floor_id = $9, format = $10, recipients = $11, is_active = $12, updated_at = CURRENT_TIMESTAMP WHERE id = $13 RETURNING last_run_at, created_at, updated_at`
+	UpdateReportScheduleLastRun = `UPDATE report_schedules SET last_run_at = $2 WHERE id = $1 AND (last_run_at IS NULL OR last_run_at < $2) RETURNING id`
+	DeleteReportSchedule        = `DELETE FROM report_schedules WHERE id = $1`
+	SelectCountReportSchedule   = `SELECT COUNT(*) FROM report_schedules`
+
+	InsertSite          = `INSERT INTO sites (name, address, timezone) VALUES ($1, $2, $3) RETURNING id, created_at, updated_at`
+	SelectSiteByID      = `SELECT id, name, address, timezone, created_at, updated_at FROM sites WHERE id = $1`
+	SelectSiteList      = `SELECT id, name, address, timezone, created_at, updated_at FROM sites ORDER BY name LIMIT $1 OFFSET $2`
+	SelectCountSite     = `SELECT COUNT(*) FROM sites`
+	UpdateSite          = `UPDATE sites SET name = $2, address = $3, timezone = $4, updated_at = CURRENT_TIMESTAMP WHERE id = $1 RETURNING created_at, updated_at`
+	DeleteSite          = `DELETE FROM sites WHERE id = $1`
+	InsertBuilding      = `INSERT INTO buildings (site_id, name) VALUES ($1, $2) RETURNING id, created_at, updated_at`
+	SelectBuildingByID  = `SELECT id, site_id, name, created_at, updated_at FROM buildings WHERE id = $1`
+	SelectBuildingList  = `SELECT id, site_id, name, created_at, updated_at FROM buildings WHERE $1 = '' OR site_id::text = $1 ORDER BY name LIMIT $2 OFFSET $3`
+	SelectCountBuilding = `SELECT COUNT(*) FROM buildings WHERE $1 = '' OR site_id::text = $1`
+	UpdateBuilding      = `UPDATE buildings SET site_id = $2, name = $3, updated_at = CURRENT_TIMESTAMP WHERE id = $1 RETURNING created_at, updated_at`
+	DeleteBuilding      = `DELETE FROM buildings WHERE id = $1`
+	InsertFloor         = `INSERT INTO floors (building_id, name, level) VALUES ($1, $2, $3) RETURNING id, created_at, updated_at`
+	SelectFloorByID     = `SELECT id, building_id, name, level, created_at, updated_at FROM floors WHERE id = $1`
+	SelectFloorList     = `SELECT id, building_id, name, level, created_at, updated_at FROM floors WHERE $1 = '' OR building_id::text = $1 ORDER BY building_id, level LIMIT $2 OFFSET $3`
+	SelectCountFloor    = `SELECT COUNT(*) FROM floors WHERE $1 = '' OR building_id::text = $1`
+	UpdateFloor         = `UPDATE floors SET building_id = $2, name = $3, level = $4, updated_at = CURRENT_TIMESTAMP WHERE id = $1 RETURNING created_at, updated_at`
+	DeleteFloor         = `DELETE FROM floors WHERE id = $1`
+
+	SelectRoomSite          = `SELECT COALESCE(b.site_id::text, ''), COALESCE(s.timezone, 'UTC') FROM rooms r LEFT JOIN floors f ON f.id = r.floor_id LEFT JOIN buildings b ON b.id = f.building_id LEFT JOIN sites s ON s.id = b.site_id WHERE r.id = $1`
+	SelectRoomBusinessHours = `SELECT id, COALESCE(site_id::text, ''), COALESCE(room_id::text, ''), weekday, to_char(open_time, 'HH24:MI'), to_char(close_time, 'HH24:MI') FROM business_hours WHERE room_id::text = $1 OR site_id::text = $2 ORDER BY weekday, open_time`
+	SelectBusinessHours     = `SELECT id, COALESCE(site_id::text, ''), COALESCE(room_id::text, ''), weekday, to_char(open_time, 'HH24:MI'), to_char(close_time, 'HH24:MI') FROM business_hours WHERE COALESCE(site_id::text, '') = $1 AND COALESCE(room_id::text, '') = $2 ORDER BY weekday, open_time`
+	DeleteBusinessHours     = `DELETE FROM business_hours WHERE COALESCE(site_id::text, '') = $1 AND COALESCE(room_id::text, '') = $2`
+	InsertBusinessHours     = `INSERT INTO business_hours (site_id, room_id, weekday, open_time, close_time) VALUES (NULLIF($1, '')::uuid, NULLIF($2, '')::uuid, $3, $4, $5) RETURNING id`
+	InsertHoliday           = `INSERT INTO holidays (site_id, date, name) VALUES (NULLIF($1, '')::uuid, $2, $3) RETURNING id, created_at`
+	ImportHoliday           = `INSERT INTO holidays (site_id, date, name) VALUES (NULLIF($1, '')::uuid, $2, $3) ON CONFLICT DO NOTHING`
+	SelectHolidayList       = `SELECT id, COALESCE(site_id::text, ''), to_char(date, 'YYYY-MM-DD'), name, created_at FROM holidays WHERE ($1 = '' OR site_id IS NULL OR site_id::text = $1) AND ($2 = 0 OR EXTRACT(YEAR FROM date) = $2) ORDER BY date`
+	SelectRoomHolidays      = `SELECT id, COALESCE(site_id::text, ''), to_char(date, 'YYYY-MM-DD'), name, created_at FROM holidays WHERE (site_id IS NULL OR site_id::text = $1) AND date BETWEEN $2 AND $3 ORDER BY date`
+	DeleteHoliday           = `DELETE FROM holidays WHERE id = $1`
+	InsertBlackout          = `INSERT INTO blackouts (site_id, room_id, reason, start_time, end_time) VALUES (NULLIF($1, '')::uuid, NULLIF($2, '')::uuid, $3, $4, $5) RETURNING id, created_at`
+	SelectBlackoutList      = `SELECT id, COALESCE(site_id::text, ''), COALESCE(room_id::text, ''), reason, start_time, end_time, created_at FROM blackouts WHERE end_time > $1 AND start_time < $2 ORDER BY start_time`
+	SelectRoomBlackouts     = `SELECT id, COALESCE(site_id::text, ''), COALESCE(room_id::text, ''), reason, start_time, end_time, created_at FROM blackouts WHERE (room_id::text = $1 OR site_id::text = $2 OR (site_id IS NULL AND room_id IS NULL)) AND end_time > $3 AND start_time < $4 ORDER BY start_time`
+	DeleteBlackout          = `DELETE FROM blackouts WHERE id = $1`
+
+	InsertMaintenance          = `INSERT INTO room_maintenance (room_id, assignee_id, reason, start_time, end_time) VALUES ($1, $2, $3, $4, $5) RETURNING id, created_at, updated_at`
+	SelectMaintenanceByID      = `SELECT id, room_id, assignee_id, reason, start_time, end_time, started_at, completed_at, created_at, updated_at FROM room_maintenance WHERE id = $1`
+	SelectMaintenanceList      = `SELECT id, room_id, assignee_id, reason, start_time, end_time, started_at, completed_at, created_at, updated_at FROM room_maintenance WHERE ($1 = '' OR room_id::text = $1) AND (NOT $2 OR completed_at IS NULL) ORDER BY start_time DESC LIMIT $3 OFFSET $4`
+	SelectCountMaintenance     = `SELECT COUNT(*) FROM room_maintenance WHERE ($1 = '' OR room_id::text = $1) AND (NOT $2 OR completed_at IS NULL)`
+	UpdateMaintenance          = `UPDATE room_maintenance SET assignee_id = $2, reason = $3, start_time = $4, end_time = $5, updated_at = CURRENT_TIMESTAMP WHERE id = $1 RETURNING room_id, started_at, completed_at, created_at, updated_at`
+	LockMaintenance            = `SELECT room_id, started_at IS NOT NULL AND completed_at IS NULL FROM room_maintenance WHERE id = $1 FOR UPDATE`
+	DeleteMaintenance          = `DELETE FROM room_maintenance WHERE id = $1`
+	StartDueMaintenance        = `UPDATE room_maintenance SET started_at = $1, updated_at = CURRENT_TIMESTAMP WHERE started_at IS NULL AND start_time <= $1 AND end_time > $1 RETURNING room_id`
+	CompleteDueMaintenance     = `UPDATE room_maintenance SET completed_at = $1, updated_at = CURRENT_TIMESTAMP WHERE completed_at IS NULL AND end_time <= $1 RETURNING room_id`
+	TakeRoomsOutOfService      = `UPDATE rooms SET status_before_maintenance = status, status = 'unavailable', updated_at = CURRENT_TIMESTAMP WHERE id = ANY($1) AND status <> 'unavailable'`
+	ReturnRoomsToService       = `UPDATE rooms SET status = CASE WHEN status = 'unavailable' THEN status_before_maintenance ELSE status END, status_before_maintenance = NULL, updated_at = CURRENT_TIMESTAMP WHERE id = ANY($1) AND status_before_maintenance IS NOT NULL AND NOT EXISTS (SELECT 1 FROM room_maintenance m WHERE m.room_id = rooms.id AND m.started_at IS NOT NULL AND m.completed_at IS NULL)`
+	SelectRoomMaintenance      = `SELECT id, room_id, assignee_id, reason, start_time, end_time, started_at, completed_at, created_at, updated_at FROM room_maintenance WHERE room_id::text = $1 AND end_time > $2 AND start_time < $3 ORDER BY start_time`
+	SelectMaintenanceConflicts = `SELECT m.id, m.reason, t.id, t.employee_id, t.room_id, COALESCE(t.description, ''), t.status, t.start_time, t.end_time, t.created_at, t.updated_at FROM room_maintenance m JOIN transactions t ON t.room_id = m.room_id WHERE t.status = 'accepted' AND t.start_time < m.end_time AND t.end_time > m.start_time AND t.end_time > $2 AND ($1 = '' OR m.id::text = $1) ORDER BY t.start_time`
+
+	SelectAttachmentOwner    = `SELECT EXISTS (SELECT 1 FROM rooms WHERE $1 = 'room' AND id = $2 AND archived_at IS NULL UNION ALL SELECT 1 FROM facilities WHERE $1 = 'facility' AND id = $2 AND archived_at IS NULL)`
+	InsertAttachment         = `INSERT INTO attachments (owner_type, owner_id, filename, content_type, size, storage_key, thumbnail_key) VALUES ($1, $2, $3, $4, $5, $6, NULLIF($7, '')) RETURNING id, created_at`
+	SelectAttachmentByID     = `SELECT id, owner_type, owner_id, filename, content_type, size, storage_key, COALESCE(thumbnail_key, ''), created_at FROM attachments WHERE id = $1`
+	SelectAttachmentsByOwner = `SELECT id, owner_type, owner_id, filename, content_type, size, storage_key, COALESCE(thumbnail_key, ''), created_at FROM attachments WHERE owner_type = $1 AND owner_id = $2 ORDER BY created_at`
+	DeleteAttachment         = `DELETE FROM attachments WHERE id = $1 RETURNING id, owner_type, owner_id, filename, content_type, size, storage_key, COALESCE(thumbnail_key, ''), created_at`
+
+	InsertRoomAttribute       = `INSERT INTO room_attributes (key, name, type, options) VALUES ($1, $2, $3, $4) RETURNING id, created_at, updated_at`
+	SelectRoomAttributeList   = `SELECT id, key, name, type, options, created_at, updated_at FROM room_attributes ORDER BY name`
+	SelectRoomAttributeByID   = `SELECT id, key, name, type, options, created_at, updated_at FROM room_attributes WHERE id = $1`
+	LockRoomAttribute         = `SELECT type FROM room_attributes WHERE id = $1 FOR UPDATE`
+	SelectUnlistedOptions     = `SELECT DISTINCT value FROM room_attribute_values WHERE attribute_id = $1 AND NOT (value = ANY($2)) ORDER BY value`
+	UpdateRoomAttribute       = `UPDATE room_attributes SET name = $2, options = $3, updated_at = CURRENT_TIMESTAMP WHERE id = $1 RETURNING key, type, created_at, updated_at`
+	DeleteRoomAttribute       = `DELETE FROM room_attributes WHERE id = $1`
+	SelectRoomAttributeValues = `SELECT v.room_id, a.id, a.key, a.type, v.value FROM room_attribute_values v JOIN room_attributes a ON a.id = v.attribute_id WHERE v.room_id = ANY($1) ORDER BY a.key`
+	DeleteRoomAttributeValues = `DELETE FROM room_attribute_values WHERE room_id = $1`
+	InsertRoomAttributeValue  = `INSERT INTO room_attribute_values (room_id, attribute_id, value) VALUES ($1, $2, $3)`
+
+	// roomSearch selects the rooms matching $1 to $13 of GET /rooms/search:
+	// name, status, room type, capacity range, site, building, floor, the
+	// attribute filters as parallel arrays of keys, JSON value lists and
+	// numeric bounds, and the floor that distance is measured from.
+	roomSearch = `WITH ref AS (SELECT level, building_id FROM floors WHERE id::text = $13), ` +
+		`matched AS (SELECT r.id, r.name, r.room_type, r.capacity, r.status, r.created_at, r.updated_at, r.floor_id, f.name AS floor_name, f.level, b.id AS building_id, b.name AS building_name, s.id AS site_id, s.name AS site_name, s.timezone, ` +
+		`(SELECT ABS(f.level - ref.level) FROM ref WHERE ref.building_id = b.id) AS distance ` +
+		`FROM rooms r LEFT JOIN floors f ON f.id = r.floor_id LEFT JOIN buildings b ON b.id = f.building_id LEFT JOIN sites s ON s.id = b.site_id ` +
+		`WHERE r.archived_at IS NULL AND ($1 = '' OR r.name ILIKE '%' || $1 || '%') AND ($2 = '' OR r.status::text = $2) AND ($3 = '' OR r.room_type = $3) ` +
+		`AND ($4 = 0 OR r.capacity >= $4) AND ($5 = 0 OR r.capacity <= $5) AND ($6 = '' OR s.id::text = $6) AND ($7 = '' OR b.id::text = $7) AND ($8 = '' OR f.id::text = $8) ` +
+		`AND NOT EXISTS (SELECT 1 FROM unnest($9::text[], $10::text[], $11::text[], $12::text[]) AS q(key, vals, lo, hi) WHERE NOT EXISTS (` +
+		`SELECT 1 FROM room_attribute_values v JOIN room_attributes a ON a.id = v.attribute_id WHERE v.room_id = r.id AND a.key = q.key AND ` +
+		`CASE WHEN jsonb_array_length(q.vals::jsonb) > 0 THEN v.value IN (SELECT jsonb_array_elements_text(q.vals::jsonb)) ` +
+		`WHEN a.type = 'number' THEN (q.lo = '' OR v.value::numeric >= q.lo::numeric) AND (q.hi = '' OR v.value::numeric <= q.hi::numeric) ELSE true END))) `
+	SelectRoomSearch = roomSearch + `SELECT id, name, room_type, capacity, status, created_at, updated_at, floor_id, floor_name, level, building_id, building_name, site_id, site_name, timezone, distance FROM matched ` +
+		`ORDER BY CASE WHEN $14 = 'capacity' THEN capacity END, CASE WHEN $14 = '-capacity' THEN capacity END DESC, CASE WHEN $14 = 'distance' THEN distance END, name, id LIMIT $15 OFFSET $16`
+	SelectRoomSearchFacets = roomSearch + `SELECT 'status', status::text, '', COUNT(*) FROM matched GROUP BY status ` +
+		`UNION ALL SELECT 'roomType', room_type, '', COUNT(*) FROM matched GROUP BY room_type ` +
+		`UNION ALL SELECT 'building', building_id::text, building_name, COUNT(*) FROM matched WHERE building_id IS NOT NULL GROUP BY building_id, building_name ` +
+		`UNION ALL SELECT 'attr.' || a.key, v.value, '', COUNT(*) FROM matched m JOIN room_attribute_values v ON v.room_id = m.id JOIN room_attributes a ON a.id = v.attribute_id WHERE a.type IN ('boolean', 'enum') GROUP BY a.key, v.value ` +
+		`ORDER BY 1, 4 DESC, 2`
+
+	InsertRoomRate             = `INSERT INTO room_rates (room_id, hourly_rate, effective_from) VALUES ($1, $2, $3) RETURNING id, created_at`
+	SelectRoomRatesByRoomID    = `SELECT id, room_id, hourly_rate, effective_from, created_at FROM room_rates WHERE room_id = $1 ORDER BY effective_from DESC`
+	SelectRoomRateAt           = `SELECT id, room_id, hourly_rate, effective_from, created_at FROM room_rates WHERE room_id = $1 AND effective_from <= $2 ORDER BY effective_from DESC LIMIT 1`
+	InsertFacilityRate         = `INSERT INTO facility_rates (facility_id, unit_rate, effective_from) VALUES ($1, $2, $3) RETURNING id, created_at`
+	SelectFacilityRatesByID    = `SELECT id, facility_id, unit_rate, effective_from, created_at FROM facility_rates WHERE facility_id = $1 ORDER BY effective_from DESC`
+	SelectFacilityRatesAt      = `SELECT DISTINCT ON (facility_id) facility_id, unit_rate FROM facility_rates WHERE facility_id = ANY($1) AND effective_from <= $2 ORDER BY facility_id, effective_from DESC`
+	UpsertTransactionCost      = `INSERT INTO transaction_costs (transaction_id, hourly_rate, room_cost, facility_cost, total_cost) VALUES ($1, $2, $3, $4, $5) ON CONFLICT (transaction_id) DO UPDATE SET hourly_rate = EXCLUDED.hourly_rate, room_cost = EXCLUDED.room_cost, facility_cost = EXCLUDED.facility_cost, total_cost = EXCLUDED.total_cost, created_at = CURRENT_TIMESTAMP RETURNING created_at`
+	SelectChargebackByDivision = `SELECT e.division, date_trunc($3, t.start_time) AS period, COUNT(t.id), SUM(c.room_cost), SUM(c.facility_cost), SUM(c.total_cost) FROM transaction_costs c JOIN transactions t ON t.id = c.transaction_id JOIN employees e ON e.id = t.employee_id WHERE t.status = 'accepted' AND t.start_time BETWEEN $1 AND $2 GROUP BY e.division, period ORDER BY period, e.division`
+
+	CreateSchemaMigrations = `CREATE TABLE IF NOT EXISTS schema_migrations (version BIGINT PRIMARY KEY, name VARCHAR(200) NOT NULL, checksum CHAR(64) NOT NULL, applied_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP)`
+	SelectSchemaMigrations = `SELECT version, checksum, applied_at FROM schema_migrations ORDER BY version`
+	InsertSchemaMigration  = `INSERT INTO schema_migrations (version, name, checksum) VALUES ($1, $2, $3)`
+	DeleteSchemaMigration  = `DELETE FROM schema_migrations WHERE version = $1`
+	LockSchemaMigrations   = `SELECT pg_advisory_lock($1)`
+	UnlockSchemaMigrations = `SELECT pg_advisory_unlock($1)`
 )
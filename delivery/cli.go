@@ -0,0 +1,363 @@
+package delivery
+
+import (
+	"booking-room-app/config"
+	"booking-room-app/entity"
+	"booking-room-app/migrations"
+	"booking-room-app/shared/logger"
+	"booking-room-app/usecase"
+	"bufio"
+	"context"
+	"database/sql"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const usage = `usage: booking-room-app [config flags] <command> [flags]
+
+commands:
+  serve            run the HTTP API (default)
+  migrate          apply or roll back database migrations
+  seed             insert demo rooms, facilities and employees
+  create-admin     create an admin account
+  reset-password   set a new password for an employee
+  export-report    write the transaction or chargeback report
+  purge-old-data   delete bookings that ended before a date
+
+Run "booking-room-app <command> -h" for the flags of a command.
+`
+
+// Execute runs the command named by the first argument after the
+// configuration flags, serve when there is none. Commands other than serve
+// log to stderr so stdout carries their output.
+func Execute(args []string) error {
+	overrides, args, err := config.ParseFlags(args)
+	if errors.Is(err, flag.ErrHelp) {
+		printUsage(os.Stdout)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("%v, run with -h for the list of flags", err)
+	}
+
+	name := "serve"
+	if len(args) > 0 {
+		name, args = args[0], args[1:]
+	}
+	if name == "help" {
+		printUsage(os.Stdout)
+		return nil
+	}
+
+	run, ok := map[string]func(c *cli, ctx context.Context, args []string) error{
+		"seed":           (*cli).seed,
+		"create-admin":   (*cli).createAdmin,
+		"reset-password": (*cli).resetPassword,
+		"export-report":  (*cli).exportReport,
+		"purge-old-data": (*cli).purgeOldData,
+	}[name]
+	if !ok && name != "serve" && name != "migrate" {
+		return fmt.Errorf("unknown command %q\n\n%s", name, usage)
+	}
+
+	cfg, err := config.Load(overrides)
+	if err != nil {
+		return err
+	}
+	if cfg.Location != nil {
+		time.Local = cfg.Location
+	}
+
+	if name == "serve" {
+		server, err := NewServer(cfg)
+		if err != nil {
+			return err
+		}
+		return server.Run()
+	}
+
+	slog.SetDefault(logger.New(os.Stderr, cfg.LogLevel))
+
+	db, err := openDB(cfg.DbConfig)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	// commands are not run by an employee of the API
+	ctx, stop := signal.NotifyContext(logger.WithSystem(context.Background()), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if name == "migrate" {
+		migrator, err := migrations.New(db)
+		if err != nil {
+			return err
+		}
+		return runMigrate(ctx, migrator, args, os.Stdout)
+	}
+	return run(newCLI(cfg, db, os.Stdin, os.Stdout), ctx, args)
+}
+
+func printUsage(w io.Writer) {
+	fmt.Fprint(w, usage)
+	fmt.Fprintln(w, "\nconfig flags, each also read from the upper-case environment variable (e.g. -db-host and DB_HOST):")
+	config.PrintFlags(w)
+}
+
+// cli runs the maintenance commands through the same usecases as the API.
+type cli struct {
+	uc  useCases
+	in  io.Reader
+	out io.Writer
+}
+
+func newCLI(cfg *config.Config, db *sql.DB, in io.Reader, out io.Writer) *cli {
+	return &cli{uc: newUseCases(cfg, db), in: in, out: out}
+}
+
+// seed inserts demo data. Rooms and facilities are only added to an empty
+// table and existing usernames are skipped, so it can run more than once.
+func (c *cli) seed(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("seed", flag.ContinueOnError)
+	fs.SetOutput(c.out)
+	password := fs.String("password", "password", "password of the demo employees")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	_, paging, err := c.uc.room.FindAllRoom(ctx, 1, 1)
+	if err != nil {
+		return err
+	}
+	if paging.TotalRows == 0 {
+		for _, room := range demoRooms {
+			if _, err := c.uc.room.RegisterNewRoom(ctx, room); err != nil {
+				return err
+			}
+			fmt.Fprintf(c.out, "room %s created\n", room.Name)
+		}
+	} else {
+		fmt.Fprintln(c.out, "rooms already exist, skipped")
+	}
+
+	_, paging, err = c.uc.facilities.FindAllFacilities(ctx, 1, 1)
+	if err != nil {
+		return err
+	}
+	if paging.TotalRows == 0 {
+		for _, facility := range demoFacilities {
+			if _, err := c.uc.facilities.RegisterNewFacilities(ctx, facility); err != nil {
+				return err
+			}
+			fmt.Fprintf(c.out, "facility %s created\n", facility.Name)
+		}
+	} else {
+		fmt.Fprintln(c.out, "facilities already exist, skipped")
+	}
+
+	for _, employee := range demoEmployees {
+		if _, err := c.uc.employee.FindEmployeesByUsername(ctx, employee.Username); err == nil {
+			fmt.Fprintf(c.out, "employee %s already exists, skipped\n", employee.Username)
+			continue
+		}
+		employee.Password = *password
+		if _, err := c.uc.employee.RegisterNewEmployee(ctx, employee); err != nil {
+			return err
+		}
+		fmt.Fprintf(c.out, "employee %s (%s) created\n", employee.Username, employee.Role)
+	}
+	return nil
+}
+
+func (c *cli) createAdmin(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("create-admin", flag.ContinueOnError)
+	fs.SetOutput(c.out)
+	name := fs.String("name", "", "full name (required)")
+	username := fs.String("username", "", "login username (required)")
+	password := fs.String("password", "", "login password, prefer -password-stdin")
+	passwordStdin := fs.Bool("password-stdin", false, "read the password from stdin")
+	division := fs.String("division", "General Affair", "division")
+	position := fs.String("position", "Administrator", "position")
+	contact := fs.String("contact", "", "phone number (required)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := c.readPassword(password, *passwordStdin); err != nil {
+		return err
+	}
+	if *username == "" {
+		return errors.New("oops, username is required")
+	}
+	if _, err := c.uc.employee.FindEmployeesByUsername(ctx, *username); err == nil {
+		return fmt.Errorf("oops, username %s is already taken", *username)
+	}
+
+	employee, err := c.uc.employee.RegisterNewEmployee(ctx, entity.Employee{
+		Name:     *name,
+		Username: *username,
+		Password: *password,
+		Role:     "admin",
+		Division: *division,
+		Position: *position,
+		Contact:  *contact,
+	})
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(c.out, "admin %s created with id %s\n", employee.Username, employee.ID)
+	return nil
+}
+
+func (c *cli) resetPassword(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("reset-password", flag.ContinueOnError)
+	fs.SetOutput(c.out)
+	username := fs.String("username", "", "login username (required)")
+	password := fs.String("password", "", "new password, prefer -password-stdin")
+	passwordStdin := fs.Bool("password-stdin", false, "read the new password from stdin")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if err := c.readPassword(password, *passwordStdin); err != nil {
+		return err
+	}
+
+	employee, err := c.uc.employee.ResetPassword(ctx, *username, *password)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(c.out, "password of %s updated\n", employee.Username)
+	return nil
+}
+
+func (c *cli) exportReport(ctx context.Context, args []string) error {
+	now := time.Now()
+	fs := flag.NewFlagSet("export-report", flag.ContinueOnError)
+	fs.SetOutput(c.out)
+	report := fs.String("report", "transactions", "transactions or chargeback")
+	format := fs.String("format", usecase.ReportFormatCSV, "csv or json")
+	output := fs.String("output", "", "file to write, stdout when empty")
+	rangeParam := fs.String("range", "month", "transactions: day, week, month or year")
+	status := fs.String("status", "", "transactions: only this status")
+	division := fs.String("division", "", "transactions: only this division")
+	roomId := fs.String("room-id", "", "transactions: only this room")
+	siteId := fs.String("site-id", "", "transactions: only rooms at this site")
+	buildingId := fs.String("building-id", "", "transactions: only rooms in this building")
+	floorId := fs.String("floor-id", "", "transactions: only rooms on this floor")
+	startDate := fs.String("start", time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local).Format("2006-01-02"), "chargeback: first day, YYYY-MM-DD")
+	endDate := fs.String("end", now.Format("2006-01-02"), "chargeback: last day, YYYY-MM-DD")
+	period := fs.String("period", "month", "chargeback: day, week, month or year")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	var content []byte
+	var err error
+	switch *report {
+	case "transactions":
+		if *rangeParam != "day" && *rangeParam != "week" && *rangeParam != "month" && *rangeParam != "year" {
+			return fmt.Errorf("oops, invalid range %s", *rangeParam)
+		}
+		filter := entity.ReportFilter{
+			Status:         *status,
+			Division:       *division,
+			RoomId:         *roomId,
+			LocationFilter: entity.LocationFilter{SiteId: *siteId, BuildingId: *buildingId, FloorId: *floorId},
+		}
+		content, err = c.uc.report.ExportReports(ctx, *rangeParam, filter, *format)
+	case "chargeback":
+		start, parseErr := time.Parse("2006-01-02", *startDate)
+		if parseErr != nil {
+			return fmt.Errorf("oops, invalid start date %s", *startDate)
+		}
+		end, parseErr := time.Parse("2006-01-02", *endDate)
+		if parseErr != nil {
+			return fmt.Errorf("oops, invalid end date %s", *endDate)
+		}
+		// include the whole end day
+		end = end.AddDate(0, 0, 1).Add(-time.Second)
+		content, err = c.uc.report.ExportChargeback(ctx, start, end, *period, *format)
+	default:
+		return fmt.Errorf("oops, unknown report %s", *report)
+	}
+	if err != nil {
+		return err
+	}
+
+	if *output == "" {
+		_, err = c.out.Write(content)
+		return err
+	}
+	if err := os.WriteFile(*output, content, 0o644); err != nil {
+		return err
+	}
+	fmt.Fprintf(c.out, "%s report written to %s\n", *report, *output)
+	return nil
+}
+
+func (c *cli) purgeOldData(ctx context.Context, args []string) error {
+	fs := flag.NewFlagSet("purge-old-data", flag.ContinueOnError)
+	fs.SetOutput(c.out)
+	olderThan := fs.Int("older-than", 365, "delete bookings that ended more than this many days ago")
+	beforeDate := fs.String("before", "", "delete bookings that ended before this date, YYYY-MM-DD, overrides -older-than")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	before := time.Now().AddDate(0, 0, -*olderThan)
+	if *beforeDate != "" {
+		parsed, err := time.Parse("2006-01-02", *beforeDate)
+		if err != nil {
+			return fmt.Errorf("oops, invalid date %s", *beforeDate)
+		}
+		before = parsed
+	}
+
+	deleted, err := c.uc.transactions.PurgeTransactions(ctx, before)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(c.out, "%d transactions that ended before %s deleted\n", deleted, before.Format("2006-01-02 15:04"))
+	return nil
+}
+
+// readPassword replaces password with the first line of stdin when asked to,
+// which keeps it out of the shell history and process list.
+func (c *cli) readPassword(password *string, fromStdin bool) error {
+	if !fromStdin {
+		return nil
+	}
+	line, err := bufio.NewReader(c.in).ReadString('\n')
+	if err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	*password = strings.TrimRight(line, "\r\n")
+	return nil
+}
+
+var demoRooms = []entity.Room{
+	{Name: "Ruang Melati", RoomType: "meeting", Capacity: 8, Status: "available"},
+	{Name: "Ruang Mawar", RoomType: "meeting", Capacity: 12, Status: "available"},
+	{Name: "Aula Utama", RoomType: "hall", Capacity: 100, Status: "available"},
+	{Name: "Ruang Training", RoomType: "training", Capacity: 30, Status: "available"},
+}
+
+var demoFacilities = []entity.Facilities{
+	{Name: "Proyektor", Quantity: 5},
+	{Name: "Whiteboard", Quantity: 8},
+	{Name: "Sound System", Quantity: 2},
+	{Name: "Kursi Tambahan", Quantity: 50},
+}
+
+var demoEmployees = []entity.Employee{
+	{Name: "Admin Demo", Username: "admin", Role: "admin", Division: "General Affair", Position: "Administrator", Contact: "081200000001"},
+	{Name: "GA Demo", Username: "ga", Role: "ga", Division: "General Affair", Position: "Staff", Contact: "081200000002"},
+	{Name: "Employee Demo", Username: "employee", Role: "employee", Division: "Engineering", Position: "Staff", Contact: "081200000003"},
+}
@@ -0,0 +1,159 @@
+package delivery
+
+import (
+	"booking-room-app/entity"
+	"booking-room-app/mock/usecase_mock"
+	"booking-room-app/shared/model"
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CliTestSuite struct {
+	suite.Suite
+	rum *usecase_mock.RoomUseCaseMock
+	fum *usecase_mock.FacilitiesUseCaseMock
+	eum *usecase_mock.EmployeeUseCaseMock
+	rpm *usecase_mock.ReportUseCaseMock
+	tum *usecase_mock.TransactionsUseCaseMock
+	in  *bytes.Buffer
+	out *bytes.Buffer
+	cli *cli
+}
+
+func (suite *CliTestSuite) SetupTest() {
+	suite.rum = new(usecase_mock.RoomUseCaseMock)
+	suite.fum = new(usecase_mock.FacilitiesUseCaseMock)
+	suite.eum = new(usecase_mock.EmployeeUseCaseMock)
+	suite.rpm = new(usecase_mock.ReportUseCaseMock)
+	suite.tum = new(usecase_mock.TransactionsUseCaseMock)
+	suite.in = new(bytes.Buffer)
+	suite.out = new(bytes.Buffer)
+	suite.cli = &cli{
+		uc: useCases{
+			room:         suite.rum,
+			facilities:   suite.fum,
+			employee:     suite.eum,
+			report:       suite.rpm,
+			transactions: suite.tum,
+		},
+		in:  suite.in,
+		out: suite.out,
+	}
+}
+
+func (suite *CliTestSuite) TestSeed_Success() {
+	suite.rum.On("FindAllRoom", mock.Anything, 1, 1).Return([]entity.Room{}, model.Paging{}, nil)
+	suite.rum.On("RegisterNewRoom", mock.Anything, mock.AnythingOfType("entity.Room")).Return(entity.Room{}, nil)
+	suite.fum.On("FindAllFacilities", mock.Anything, 1, 1).Return([]entity.Facilities{{}}, model.Paging{TotalRows: 1}, nil)
+	suite.eum.On("FindEmployeesByUsername", mock.Anything, "admin").Return(entity.Employee{Username: "admin"}, nil)
+	suite.eum.On("FindEmployeesByUsername", mock.Anything, mock.Anything).Return(entity.Employee{}, errors.New("not found"))
+	suite.eum.On("RegisterNewEmployee", mock.Anything, mock.MatchedBy(func(e entity.Employee) bool {
+		return e.Password == "demo123"
+	})).Return(entity.Employee{}, nil)
+
+	err := suite.cli.seed(context.Background(), []string{"-password", "demo123"})
+
+	assert.NoError(suite.T(), err)
+	suite.rum.AssertNumberOfCalls(suite.T(), "RegisterNewRoom", len(demoRooms))
+	suite.fum.AssertNotCalled(suite.T(), "RegisterNewFacilities", mock.Anything, mock.Anything)
+	suite.eum.AssertNumberOfCalls(suite.T(), "RegisterNewEmployee", len(demoEmployees)-1)
+	assert.Contains(suite.T(), suite.out.String(), "employee admin already exists, skipped")
+}
+
+func (suite *CliTestSuite) TestCreateAdmin_PasswordStdinSuccess() {
+	suite.in.WriteString("s3cret\n")
+	expected := entity.Employee{Name: "Siti", Username: "siti", Password: "s3cret", Role: "admin", Division: "General Affair", Position: "Administrator", Contact: "0812"}
+	suite.eum.On("FindEmployeesByUsername", mock.Anything, "siti").Return(entity.Employee{}, errors.New("not found"))
+	suite.eum.On("RegisterNewEmployee", mock.Anything, expected).Return(entity.Employee{ID: "1", Username: "siti"}, nil)
+
+	err := suite.cli.createAdmin(context.Background(), []string{"-name", "Siti", "-username", "siti", "-contact", "0812", "-password-stdin"})
+
+	assert.NoError(suite.T(), err)
+	assert.Contains(suite.T(), suite.out.String(), "admin siti created with id 1")
+}
+
+func (suite *CliTestSuite) TestCreateAdmin_TakenFailure() {
+	suite.eum.On("FindEmployeesByUsername", mock.Anything, "siti").Return(entity.Employee{Username: "siti"}, nil)
+
+	err := suite.cli.createAdmin(context.Background(), []string{"-name", "Siti", "-username", "siti", "-contact", "0812", "-password", "x"})
+
+	assert.Error(suite.T(), err)
+	suite.eum.AssertNotCalled(suite.T(), "RegisterNewEmployee", mock.Anything, mock.Anything)
+}
+
+func (suite *CliTestSuite) TestResetPassword_Success() {
+	suite.eum.On("ResetPassword", mock.Anything, "siti", "n3w").Return(entity.Employee{Username: "siti"}, nil)
+
+	err := suite.cli.resetPassword(context.Background(), []string{"-username", "siti", "-password", "n3w"})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "password of siti updated\n", suite.out.String())
+}
+
+func (suite *CliTestSuite) TestExportReport_TransactionsSuccess() {
+	filter := entity.ReportFilter{Status: "accepted", Division: "HR"}
+	suite.rpm.On("ExportReports", mock.Anything, "week", filter, "json").Return([]byte(`[]`), nil)
+
+	err := suite.cli.exportReport(context.Background(), []string{"-range", "week", "-status", "accepted", "-division", "HR", "-format", "json"})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "[]", suite.out.String())
+}
+
+func (suite *CliTestSuite) TestExportReport_ChargebackFileSuccess() {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	end := time.Date(2024, 1, 31, 23, 59, 59, 0, time.UTC)
+	suite.rpm.On("ExportChargeback", mock.Anything, start, end, "week", "csv").Return([]byte("Divisi\n"), nil)
+	output := filepath.Join(suite.T().TempDir(), "chargeback.csv")
+
+	err := suite.cli.exportReport(context.Background(), []string{"-report", "chargeback", "-start", "2024-01-01", "-end", "2024-01-31", "-period", "week", "-output", output})
+
+	assert.NoError(suite.T(), err)
+	content, _ := os.ReadFile(output)
+	assert.Equal(suite.T(), "Divisi\n", string(content))
+}
+
+func (suite *CliTestSuite) TestExportReport_InvalidFailure() {
+	cases := [][]string{{"-report", "rooms"}, {"-range", "decade"}, {"-report", "chargeback", "-start", "01-01-2024"}}
+	for _, args := range cases {
+		assert.Error(suite.T(), suite.cli.exportReport(context.Background(), args), strings.Join(args, " "))
+	}
+}
+
+func (suite *CliTestSuite) TestPurgeOldData_Success() {
+	before := time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+	suite.tum.On("PurgeTransactions", mock.Anything, before).Return(int64(7), nil)
+
+	err := suite.cli.purgeOldData(context.Background(), []string{"-before", "2023-06-01"})
+
+	assert.NoError(suite.T(), err)
+	assert.Contains(suite.T(), suite.out.String(), "7 transactions")
+}
+
+func (suite *CliTestSuite) TestPurgeOldData_Failure() {
+	suite.tum.On("PurgeTransactions", mock.Anything, mock.AnythingOfType("time.Time")).Return(int64(0), errors.New("error"))
+
+	err := suite.cli.purgeOldData(context.Background(), []string{"-older-than", "30"})
+
+	assert.Error(suite.T(), err)
+}
+
+func (suite *CliTestSuite) TestExecute_UnknownCommandFailure() {
+	err := Execute([]string{"frobnicate"})
+
+	assert.ErrorContains(suite.T(), err, `unknown command "frobnicate"`)
+}
+
+func TestCliTestSuite(t *testing.T) {
+	suite.Run(t, new(CliTestSuite))
+}
@@ -0,0 +1,155 @@
+package controller
+
+import (
+	"booking-room-app/config"
+	"booking-room-app/delivery/middleware"
+	"booking-room-app/entity"
+	"booking-room-app/shared/apperror"
+	"booking-room-app/shared/common"
+	"booking-room-app/usecase"
+	"errors"
+	"mime"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// multipartOverhead allows for the boundaries and part headers around the
+// uploaded file.
+const multipartOverhead = 1 << 20
+
+type AttachmentController struct {
+	attachmentUC   usecase.AttachmentUseCase
+	maxSize        int64
+	rg             *gin.RouterGroup
+	authMiddleware middleware.AuthMiddleware
+}
+
+// uploadHandler stores the multipart field "file" as an attachment of the
+// room or facility in the path.
+func (a *AttachmentController) uploadHandler(ownerType string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := common.ParamUUID(c, "id")
+		if err != nil {
+			common.SendErrorResponse(c, err)
+			return
+		}
+
+		c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, a.maxSize+multipartOverhead)
+		header, err := c.FormFile("file")
+		if err != nil {
+			var maxErr *http.MaxBytesError
+			switch {
+			case errors.As(err, &maxErr):
+				common.SendErrorResponse(c, usecase.ErrAttachmentTooLarge.Wrap(err))
+			case errors.Is(err, http.ErrMissingFile):
+				common.SendErrorResponse(c, apperror.Required("file"))
+			default:
+				common.SendErrorResponse(c, apperror.Validation("the request must be multipart/form-data with a file").Wrap(err))
+			}
+			return
+		}
+		if header.Size > a.maxSize {
+			common.SendErrorResponse(c, usecase.ErrAttachmentTooLarge)
+			return
+		}
+
+		file, err := header.Open()
+		if err != nil {
+			common.SendErrorResponse(c, err)
+			return
+		}
+		defer file.Close()
+
+		attachment, err := a.attachmentUC.UploadAttachment(c.Request.Context(), ownerType, id, header.Filename, file)
+		if err != nil {
+			common.SendErrorResponse(c, err)
+			return
+		}
+		common.SendCreateResponse(c, withURLs(attachment), "Created")
+	}
+}
+
+func (a *AttachmentController) listHandler(ownerType string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := common.ParamUUID(c, "id")
+		if err != nil {
+			common.SendErrorResponse(c, err)
+			return
+		}
+
+		attachments, err := a.attachmentUC.FindAttachments(c.Request.Context(), ownerType, id)
+		if err != nil {
+			common.SendErrorResponse(c, err)
+			return
+		}
+		for i := range attachments {
+			attachments[i] = withURLs(attachments[i])
+		}
+		common.SendSingleResponse(c, attachments, "Ok")
+	}
+}
+
+// downloadHandler streams the file, or with thumbnail set the preview of an
+// image. Browsers show it inline under its original file name.
+func (a *AttachmentController) downloadHandler(thumbnail bool) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		id, err := common.ParamUUID(c, "id")
+		if err != nil {
+			common.SendErrorResponse(c, err)
+			return
+		}
+
+		attachment, body, err := a.attachmentUC.OpenAttachment(c.Request.Context(), id, thumbnail)
+		if err != nil {
+			common.SendErrorResponse(c, err)
+			return
+		}
+		defer body.Close()
+
+		c.DataFromReader(http.StatusOK, attachment.Size, attachment.ContentType, body, map[string]string{
+			"Content-Disposition":    mime.FormatMediaType("inline", map[string]string{"filename": attachment.Filename}),
+			"X-Content-Type-Options": "nosniff",
+		})
+	}
+}
+
+func (a *AttachmentController) deleteHandler(c *gin.Context) {
+	id, err := common.ParamUUID(c, "id")
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	if err := a.attachmentUC.DeleteAttachment(c.Request.Context(), id); err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendNoContentResponse(c)
+}
+
+// withURLs fills in where the file and its thumbnail are downloaded.
+func withURLs(attachment entity.Attachment) entity.Attachment {
+	attachment.URL = config.ApiGroup + strings.Replace(config.AttachmentGetById, ":id", attachment.ID, 1)
+	if attachment.ThumbnailKey != "" {
+		attachment.ThumbnailURL = config.ApiGroup + strings.Replace(config.AttachmentThumbnail, ":id", attachment.ID, 1)
+	}
+	return attachment
+}
+
+func (a *AttachmentController) Route() {
+	a.rg.POST(config.RoomAttachmentCreate, a.authMiddleware.RequireToken("admin", "ga"), a.uploadHandler(entity.AttachmentOwnerRoom))
+	a.rg.GET(config.RoomAttachmentList, a.authMiddleware.RequireToken("employee", "admin", "ga"), a.listHandler(entity.AttachmentOwnerRoom))
+	a.rg.POST(config.FacilityAttachmentCreate, a.authMiddleware.RequireToken("admin", "ga"), a.uploadHandler(entity.AttachmentOwnerFacility))
+	a.rg.GET(config.FacilityAttachmentList, a.authMiddleware.RequireToken("employee", "admin", "ga"), a.listHandler(entity.AttachmentOwnerFacility))
+	a.rg.GET(config.AttachmentGetById, a.authMiddleware.RequireToken("employee", "admin", "ga"), a.downloadHandler(false))
+	a.rg.GET(config.AttachmentThumbnail, a.authMiddleware.RequireToken("employee", "admin", "ga"), a.downloadHandler(true))
+	a.rg.DELETE(config.AttachmentDelete, a.authMiddleware.RequireToken("admin", "ga"), a.deleteHandler)
+}
+
+// NewAttachmentController refuses uploads larger than maxSize bytes before
+// they reach attachmentUC.
+func NewAttachmentController(attachmentUC usecase.AttachmentUseCase, maxSize int64, rg *gin.RouterGroup, authMiddleware middleware.AuthMiddleware) *AttachmentController {
+	return &AttachmentController{attachmentUC: attachmentUC, maxSize: maxSize, rg: rg, authMiddleware: authMiddleware}
+}
@@ -0,0 +1,142 @@
+package controller
+
+import (
+	"booking-room-app/entity"
+	"booking-room-app/mock/middleware_mock"
+	"booking-room-app/mock/usecase_mock"
+	"bytes"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+const attachmentId = "8b1f0c2d-3e4a-4b5c-9d6e-7f8091a2b3c4"
+
+type AttachmentControllerTestSuite struct {
+	suite.Suite
+	rg  *gin.RouterGroup
+	aum *usecase_mock.AttachmentUseCaseMock
+	amm *middleware_mock.AuthMiddlewareMock
+}
+
+func (suite *AttachmentControllerTestSuite) SetupTest() {
+	suite.aum = new(usecase_mock.AttachmentUseCaseMock)
+	router := gin.Default()
+	gin.SetMode(gin.TestMode)
+	suite.rg = router.Group(apiGroup)
+}
+
+func (suite *AttachmentControllerTestSuite) uploadRequest(field, filename string, content []byte) *http.Request {
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+	part, _ := writer.CreateFormFile(field, filename)
+	part.Write(content)
+	writer.Close()
+
+	request, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/rooms/%s/attachments", apiGroup, floorId), &body)
+	request.Header.Set("Content-Type", writer.FormDataContentType())
+	return request
+}
+
+func (suite *AttachmentControllerTestSuite) TestUploadHandler_Success() {
+	created := entity.Attachment{ID: attachmentId, OwnerType: "room", OwnerId: floorId, Filename: "melati.png", ContentType: "image/png", Size: 4, ThumbnailKey: "rooms/x_thumb.jpg"}
+	suite.aum.On("UploadAttachment", mock.Anything, "room", floorId, "melati.png", mock.Anything).Return(created, nil)
+
+	handlerFunc := NewAttachmentController(suite.aum, 10<<20, suite.rg, suite.amm)
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = suite.uploadRequest("file", "melati.png", []byte("\x89PNG"))
+	c.Params = gin.Params{{Key: "id", Value: floorId}}
+	handlerFunc.uploadHandler("room")(c)
+
+	assert.Equal(suite.T(), http.StatusCreated, responseRecorder.Code)
+	assert.Contains(suite.T(), responseRecorder.Body.String(), fmt.Sprintf(`"url":"/api/v1/attachments/%s"`, attachmentId))
+	assert.Contains(suite.T(), responseRecorder.Body.String(), fmt.Sprintf(`"thumbnailUrl":"/api/v1/attachments/%s/thumbnail"`, attachmentId))
+	assert.NotContains(suite.T(), responseRecorder.Body.String(), "rooms/x_thumb.jpg")
+}
+
+func (suite *AttachmentControllerTestSuite) TestUploadHandler_MissingFileFailure() {
+	handlerFunc := NewAttachmentController(suite.aum, 10<<20, suite.rg, suite.amm)
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = suite.uploadRequest("photo", "melati.png", []byte("\x89PNG"))
+	c.Params = gin.Params{{Key: "id", Value: floorId}}
+	handlerFunc.uploadHandler("room")(c)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, responseRecorder.Code)
+	assert.Contains(suite.T(), responseRecorder.Body.String(), `"field":"file"`)
+}
+
+func (suite *AttachmentControllerTestSuite) TestUploadHandler_TooLargeFailure() {
+	handlerFunc := NewAttachmentController(suite.aum, 8, suite.rg, suite.amm)
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = suite.uploadRequest("file", "manual.pdf", []byte("%PDF-1.7 and then some more"))
+	c.Params = gin.Params{{Key: "id", Value: floorId}}
+	handlerFunc.uploadHandler("room")(c)
+
+	assert.Equal(suite.T(), http.StatusRequestEntityTooLarge, responseRecorder.Code)
+	assert.Contains(suite.T(), responseRecorder.Body.String(), `"error":"attachment_too_large"`)
+}
+
+func (suite *AttachmentControllerTestSuite) TestListHandler_Success() {
+	suite.aum.On("FindAttachments", mock.Anything, "facility", floorId).Return([]entity.Attachment{{ID: attachmentId, ContentType: "application/pdf"}}, nil)
+
+	handlerFunc := NewAttachmentController(suite.aum, 10<<20, suite.rg, suite.amm)
+	request, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/facilities/%s/attachments", apiGroup, floorId), nil)
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	c.Params = gin.Params{{Key: "id", Value: floorId}}
+	handlerFunc.listHandler("facility")(c)
+
+	assert.Equal(suite.T(), http.StatusOK, responseRecorder.Code)
+	assert.Contains(suite.T(), responseRecorder.Body.String(), fmt.Sprintf(`"url":"/api/v1/attachments/%s"`, attachmentId))
+	assert.NotContains(suite.T(), responseRecorder.Body.String(), "thumbnailUrl")
+}
+
+func (suite *AttachmentControllerTestSuite) TestDownloadHandler_Success() {
+	attachment := entity.Attachment{ID: attachmentId, Filename: "Ruang Melati.pdf", ContentType: "application/pdf", Size: 8}
+	suite.aum.On("OpenAttachment", mock.Anything, attachmentId, false).Return(attachment, io.NopCloser(strings.NewReader("%PDF-1.7")), nil)
+
+	handlerFunc := NewAttachmentController(suite.aum, 10<<20, suite.rg, suite.amm)
+	request, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/attachments/%s", apiGroup, attachmentId), nil)
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	c.Params = gin.Params{{Key: "id", Value: attachmentId}}
+	handlerFunc.downloadHandler(false)(c)
+
+	assert.Equal(suite.T(), http.StatusOK, responseRecorder.Code)
+	assert.Equal(suite.T(), "%PDF-1.7", responseRecorder.Body.String())
+	assert.Equal(suite.T(), "application/pdf", responseRecorder.Header().Get("Content-Type"))
+	assert.Equal(suite.T(), `inline; filename="Ruang Melati.pdf"`, responseRecorder.Header().Get("Content-Disposition"))
+	assert.Equal(suite.T(), "nosniff", responseRecorder.Header().Get("X-Content-Type-Options"))
+}
+
+func (suite *AttachmentControllerTestSuite) TestDeleteHandler_Success() {
+	suite.aum.On("DeleteAttachment", mock.Anything, attachmentId).Return(nil)
+
+	handlerFunc := NewAttachmentController(suite.aum, 10<<20, suite.rg, suite.amm)
+	request, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/attachments/%s", apiGroup, attachmentId), nil)
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	c.Params = gin.Params{{Key: "id", Value: attachmentId}}
+	handlerFunc.deleteHandler(c)
+
+	assert.Equal(suite.T(), http.StatusNoContent, responseRecorder.Code)
+}
+
+func TestAttachmentControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(AttachmentControllerTestSuite))
+}
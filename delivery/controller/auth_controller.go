@@ -5,7 +5,6 @@ import (
 	"booking-room-app/entity/dto"
 	"booking-room-app/shared/common"
 	"booking-room-app/usecase"
-	"net/http"
 
 	"github.com/gin-gonic/gin"
 )
@@ -17,13 +16,13 @@ type AuthController struct {
 
 func (a *AuthController) loginHandler(ctx *gin.Context) {
 	var payload dto.AuthRequestDto
-	if err := ctx.ShouldBindJSON(&payload); err != nil {
-		common.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+	if err := common.BindJSON(ctx, &payload); err != nil {
+		common.SendErrorResponse(ctx, err)
 		return
 	}
-	rsv, err := a.authUc.Login(payload)
+	rsv, err := a.authUc.Login(ctx.Request.Context(), payload)
 	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		common.SendErrorResponse(ctx, err)
 		return
 	}
 	common.SendSingleResponse(ctx, rsv, "Ok")
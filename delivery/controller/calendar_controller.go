@@ -0,0 +1,178 @@
+package controller
+
+import (
+	"booking-room-app/config"
+	"booking-room-app/delivery/middleware"
+	"booking-room-app/entity/dto"
+	"booking-room-app/shared/common"
+	"booking-room-app/usecase"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// maxCalendarSize limits the iCalendar file of a holiday import.
+const maxCalendarSize = 1 << 20
+
+type CalendarController struct {
+	calendarUC     usecase.CalendarUseCase
+	rg             *gin.RouterGroup
+	authMiddleware middleware.AuthMiddleware
+}
+
+func (cc *CalendarController) getHoursHandler(c *gin.Context) {
+	var query dto.HoursQueryDto
+	if err := common.BindQuery(c, &query); err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	hours, err := cc.calendarUC.FindHours(c.Request.Context(), query.SiteId, query.RoomId)
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendSingleResponse(c, hours, "Ok")
+}
+
+func (cc *CalendarController) replaceHoursHandler(c *gin.Context) {
+	var payload dto.WeeklyHoursRequestDto
+	if err := common.BindJSON(c, &payload); err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	hours, err := cc.calendarUC.ReplaceHours(c.Request.Context(), payload.Entity())
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendSingleResponse(c, hours, "Updated")
+}
+
+func (cc *CalendarController) createHolidayHandler(c *gin.Context) {
+	var payload dto.HolidayRequestDto
+	if err := common.BindJSON(c, &payload); err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	holiday, err := cc.calendarUC.RegisterHoliday(c.Request.Context(), payload.Entity())
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendCreateResponse(c, holiday, "Created")
+}
+
+// importHolidaysHandler reads an iCalendar file from the request body.
+func (cc *CalendarController) importHolidaysHandler(c *gin.Context) {
+	var query dto.HolidayQueryDto
+	if err := common.BindQuery(c, &query); err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	body := http.MaxBytesReader(c.Writer, c.Request.Body, maxCalendarSize)
+	imported, err := cc.calendarUC.ImportHolidays(c.Request.Context(), query.SiteId, body)
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendCreateResponse(c, gin.H{"imported": imported}, "Imported")
+}
+
+func (cc *CalendarController) listHolidaysHandler(c *gin.Context) {
+	var query dto.HolidayQueryDto
+	if err := common.BindQuery(c, &query); err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	holidays, err := cc.calendarUC.FindHolidays(c.Request.Context(), query.SiteId, query.Year)
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendSingleResponse(c, holidays, "Ok")
+}
+
+func (cc *CalendarController) deleteHolidayHandler(c *gin.Context) {
+	id, err := common.ParamUUID(c, "id")
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	if err := cc.calendarUC.DeleteHoliday(c.Request.Context(), id); err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendNoContentResponse(c)
+}
+
+func (cc *CalendarController) createBlackoutHandler(c *gin.Context) {
+	var payload dto.BlackoutRequestDto
+	if err := common.BindJSON(c, &payload); err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	blackout, err := cc.calendarUC.RegisterBlackout(c.Request.Context(), payload.Entity())
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendCreateResponse(c, blackout, "Created")
+}
+
+// listBlackoutsHandler lists the blackouts between from and to, by default
+// the coming year.
+func (cc *CalendarController) listBlackoutsHandler(c *gin.Context) {
+	var query dto.BlackoutQueryDto
+	if err := common.BindQuery(c, &query); err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	if query.From.IsZero() {
+		query.From = time.Now()
+	}
+
+	blackouts, err := cc.calendarUC.FindBlackouts(c.Request.Context(), query.From, query.To)
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendSingleResponse(c, blackouts, "Ok")
+}
+
+func (cc *CalendarController) deleteBlackoutHandler(c *gin.Context) {
+	id, err := common.ParamUUID(c, "id")
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	if err := cc.calendarUC.DeleteBlackout(c.Request.Context(), id); err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendNoContentResponse(c)
+}
+
+func (cc *CalendarController) Route() {
+	cc.rg.GET(config.BusinessHoursList, cc.authMiddleware.RequireToken("employee", "admin", "ga"), cc.getHoursHandler)
+	cc.rg.PUT(config.BusinessHoursUpdate, cc.authMiddleware.RequireToken("admin"), cc.replaceHoursHandler)
+	cc.rg.POST(config.HolidayCreate, cc.authMiddleware.RequireToken("admin"), cc.createHolidayHandler)
+	cc.rg.POST(config.HolidayImport, cc.authMiddleware.RequireToken("admin"), cc.importHolidaysHandler)
+	cc.rg.GET(config.HolidayList, cc.authMiddleware.RequireToken("employee", "admin", "ga"), cc.listHolidaysHandler)
+	cc.rg.DELETE(config.HolidayDelete, cc.authMiddleware.RequireToken("admin"), cc.deleteHolidayHandler)
+	cc.rg.POST(config.BlackoutCreate, cc.authMiddleware.RequireToken("admin"), cc.createBlackoutHandler)
+	cc.rg.GET(config.BlackoutList, cc.authMiddleware.RequireToken("employee", "admin", "ga"), cc.listBlackoutsHandler)
+	cc.rg.DELETE(config.BlackoutDelete, cc.authMiddleware.RequireToken("admin"), cc.deleteBlackoutHandler)
+}
+
+func NewCalendarController(calendarUC usecase.CalendarUseCase, rg *gin.RouterGroup, authMiddleware middleware.AuthMiddleware) *CalendarController {
+	return &CalendarController{calendarUC: calendarUC, rg: rg, authMiddleware: authMiddleware}
+}
@@ -0,0 +1,138 @@
+package controller
+
+import (
+	"booking-room-app/entity"
+	"booking-room-app/mock/middleware_mock"
+	"booking-room-app/mock/usecase_mock"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type CalendarControllerTestSuite struct {
+	suite.Suite
+	rg  *gin.RouterGroup
+	cum *usecase_mock.CalendarUseCaseMock
+	amm *middleware_mock.AuthMiddlewareMock
+}
+
+func (suite *CalendarControllerTestSuite) SetupTest() {
+	suite.cum = new(usecase_mock.CalendarUseCaseMock)
+	router := gin.Default()
+	gin.SetMode(gin.TestMode)
+	suite.rg = router.Group(apiGroup)
+}
+
+func (suite *CalendarControllerTestSuite) TestReplaceHoursHandler_Success() {
+	week := entity.WeeklyHours{SiteId: siteId, Hours: []entity.BusinessHours{{Weekday: time.Monday, Open: "08:00", Close: "17:00"}}}
+	suite.cum.On("ReplaceHours", mock.Anything, week).Return(week, nil)
+
+	handlerFunc := NewCalendarController(suite.cum, suite.rg, suite.amm)
+	request, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/business-hours", apiGroup), strings.NewReader(fmt.Sprintf(`{"siteId": "%s", "hours": [{"weekday": 1, "open": "08:00", "close": "17:00"}]}`, siteId)))
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	handlerFunc.replaceHoursHandler(c)
+
+	assert.Equal(suite.T(), http.StatusOK, responseRecorder.Code)
+}
+
+func (suite *CalendarControllerTestSuite) TestReplaceHoursHandler_InvalidClockFailure() {
+	handlerFunc := NewCalendarController(suite.cum, suite.rg, suite.amm)
+	request, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/business-hours", apiGroup), strings.NewReader(fmt.Sprintf(`{"siteId": "%s", "hours": [{"weekday": 1, "open": "8am", "close": "17:00"}]}`, siteId)))
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	handlerFunc.replaceHoursHandler(c)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, responseRecorder.Code)
+	assert.Contains(suite.T(), responseRecorder.Body.String(), `"field":"hours[0].open"`)
+}
+
+func (suite *CalendarControllerTestSuite) TestImportHolidaysHandler_Success() {
+	suite.cum.On("ImportHolidays", mock.Anything, siteId, mock.Anything).Return(int64(16), nil)
+
+	handlerFunc := NewCalendarController(suite.cum, suite.rg, suite.amm)
+	request, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/holidays/import?siteId=%s", apiGroup, siteId), strings.NewReader("BEGIN:VCALENDAR\nEND:VCALENDAR\n"))
+	request.Header.Set("Content-Type", "text/calendar")
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	handlerFunc.importHolidaysHandler(c)
+
+	assert.Equal(suite.T(), http.StatusCreated, responseRecorder.Code)
+	assert.Contains(suite.T(), responseRecorder.Body.String(), `"imported":16`)
+}
+
+func (suite *CalendarControllerTestSuite) TestCreateHolidayHandler_InvalidDateFailure() {
+	handlerFunc := NewCalendarController(suite.cum, suite.rg, suite.amm)
+	request, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/holidays", apiGroup), strings.NewReader(`{"date": "01/01/2024", "name": "New Year"}`))
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	handlerFunc.createHolidayHandler(c)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, responseRecorder.Code)
+	assert.Contains(suite.T(), responseRecorder.Body.String(), "date must be formatted as 2006-01-02")
+}
+
+func (suite *CalendarControllerTestSuite) TestCreateBlackoutHandler_Success() {
+	start := time.Date(2024, time.March, 1, 13, 0, 0, 0, time.UTC)
+	blackout := entity.Blackout{RoomId: siteId, Reason: "Town hall", StartTime: start, EndTime: start.Add(3 * time.Hour)}
+	suite.cum.On("RegisterBlackout", mock.Anything, blackout).Return(blackout, nil)
+
+	handlerFunc := NewCalendarController(suite.cum, suite.rg, suite.amm)
+	request, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/blackouts", apiGroup), strings.NewReader(fmt.Sprintf(`{"roomId": "%s", "reason": "Town hall", "startTime": "2024-03-01T13:00:00Z", "endTime": "2024-03-01T16:00:00Z"}`, siteId)))
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	handlerFunc.createBlackoutHandler(c)
+
+	assert.Equal(suite.T(), http.StatusCreated, responseRecorder.Code)
+}
+
+func (suite *CalendarControllerTestSuite) TestDeleteHolidayHandler_Success() {
+	suite.cum.On("DeleteHoliday", mock.Anything, siteId).Return(nil)
+
+	handlerFunc := NewCalendarController(suite.cum, suite.rg, suite.amm)
+	request, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/holidays/%s", apiGroup, siteId), nil)
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	c.Params = gin.Params{{Key: "id", Value: siteId}}
+	handlerFunc.deleteHolidayHandler(c)
+
+	assert.Equal(suite.T(), http.StatusNoContent, c.Writer.Status())
+}
+
+func (suite *CalendarControllerTestSuite) TestListHolidaysHandler_Success() {
+	suite.cum.On("FindHolidays", mock.Anything, "", 2024).Return([]entity.Holiday{{Date: "2024-01-01", Name: "New Year"}}, nil)
+
+	handlerFunc := NewCalendarController(suite.cum, suite.rg, suite.amm)
+	request, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/holidays?year=2024", apiGroup), nil)
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	handlerFunc.listHolidaysHandler(c)
+
+	assert.Equal(suite.T(), http.StatusOK, responseRecorder.Code)
+}
+
+func TestCalendarControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(CalendarControllerTestSuite))
+}
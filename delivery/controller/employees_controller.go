@@ -4,9 +4,10 @@ import (
 	"booking-room-app/config"
 	"booking-room-app/delivery/middleware"
 	"booking-room-app/entity"
+	"booking-room-app/entity/dto"
 	"booking-room-app/shared/common"
+	"booking-room-app/shared/model"
 	"booking-room-app/usecase"
-	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
@@ -20,15 +21,15 @@ type EmployeeController struct {
 
 func (e *EmployeeController) createHandler(ctx *gin.Context) {
 
-	var payload entity.Employee
-	if err := ctx.ShouldBindJSON(&payload); err != nil {
-		common.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+	var payload dto.EmployeeRequestDto
+	if err := common.BindJSON(ctx, &payload); err != nil {
+		common.SendErrorResponse(ctx, err)
 		return
 	}
-	employee, err := e.employeeUC.RegisterNewEmployee(payload)
+	employee, err := e.employeeUC.RegisterNewEmployee(ctx.Request.Context(), payload.Entity())
 
 	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		common.SendErrorResponse(ctx, err)
 		return
 
 	}
@@ -38,20 +39,20 @@ func (e *EmployeeController) createHandler(ctx *gin.Context) {
 // read by
 func (e *EmployeeController) getByIdHandler(ctx *gin.Context) {
 	id := ctx.Param("id")
-	employee, err := e.employeeUC.FindEmployeesByID(id)
+	employee, err := e.employeeUC.FindEmployeesByID(ctx.Request.Context(), id)
 	if err != nil {
 
-		common.SendErrorResponse(ctx, http.StatusNotFound, "Employee with ID "+id+" not found")
+		common.SendErrorResponse(ctx, err)
 		return
 	}
 	common.SendSingleResponse(ctx, employee, "Ok")
 }
 func (e *EmployeeController) getByUsernameHandler(ctx *gin.Context) {
 	username := ctx.Param("user")
-	employee, err := e.employeeUC.FindEmployeesByUsername(username)
+	employee, err := e.employeeUC.FindEmployeesByUsername(ctx.Request.Context(), username)
 	if err != nil {
 
-		common.SendErrorResponse(ctx, http.StatusNotFound, "Employee with Username "+username+" not found")
+		common.SendErrorResponse(ctx, err)
 		return
 	}
 	common.SendSingleResponse(ctx, employee, "Ok")
@@ -60,15 +61,14 @@ func (e *EmployeeController) getByUsernameHandler(ctx *gin.Context) {
 // update
 
 func (e *EmployeeController) putHandler(ctx *gin.Context) {
-	var payload entity.Employee
-	err := ctx.ShouldBindJSON(&payload)
-	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusBadGateway, "Failed to bind data")
+	var payload dto.UpdateEmployeeRequestDto
+	if err := common.BindJSON(ctx, &payload); err != nil {
+		common.SendErrorResponse(ctx, err)
 		return
 	}
-	employee, err := e.employeeUC.UpdateEmployee(payload)
+	employee, err := e.employeeUC.UpdateEmployee(ctx.Request.Context(), payload.Entity())
 	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		common.SendErrorResponse(ctx, err)
 		return
 	}
 	common.SendSingleResponse(ctx, employee, "Updated")
@@ -80,9 +80,16 @@ func (e *EmployeeController) ListHandler(ctx *gin.Context) {
 	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
 	size, _ := strconv.Atoi(ctx.DefaultQuery("size", "5"))
 
-	employees, paging, err := e.employeeUC.ListAll(page, size)
+	var employees []entity.Employee
+	var paging model.Paging
+	var err error
+	if ctx.Query("archived") == "true" {
+		employees, paging, err = e.employeeUC.FindArchivedEmployees(ctx.Request.Context(), page, size)
+	} else {
+		employees, paging, err = e.employeeUC.ListAll(ctx.Request.Context(), page, size)
+	}
 	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		common.SendErrorResponse(ctx, err)
 		return
 	}
 
@@ -94,6 +101,34 @@ func (e *EmployeeController) ListHandler(ctx *gin.Context) {
 	common.SendPagedResponse(ctx, response, paging, "Ok")
 }
 
+func (e *EmployeeController) archiveHandler(ctx *gin.Context) {
+	id, err := common.ParamUUID(ctx, "id")
+	if err != nil {
+		common.SendErrorResponse(ctx, err)
+		return
+	}
+	archival, err := e.employeeUC.ArchiveEmployee(ctx.Request.Context(), id)
+	if err != nil {
+		common.SendErrorResponse(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, archival, "Archived")
+}
+
+func (e *EmployeeController) restoreHandler(ctx *gin.Context) {
+	id, err := common.ParamUUID(ctx, "id")
+	if err != nil {
+		common.SendErrorResponse(ctx, err)
+		return
+	}
+	employee, err := e.employeeUC.RestoreEmployee(ctx.Request.Context(), id)
+	if err != nil {
+		common.SendErrorResponse(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, employee, "Restored")
+}
+
 // route
 func (e *EmployeeController) Route() {
 	e.rg.GET(config.EmployeesGetById, e.authMiddleware.RequireToken("admin", "employee", "ga"), e.getByIdHandler)
@@ -101,6 +136,8 @@ func (e *EmployeeController) Route() {
 	e.rg.POST(config.EmployeesCreate, e.authMiddleware.RequireToken("admin"), e.createHandler)
 	e.rg.PUT(config.EmployeesUpdate, e.authMiddleware.RequireToken("admin"), e.putHandler)
 	e.rg.GET(config.EmployeesList, e.authMiddleware.RequireToken("admin", "employee", "ga"), e.ListHandler)
+	e.rg.POST(config.EmployeesArchive, e.authMiddleware.RequireToken("admin"), e.archiveHandler)
+	e.rg.POST(config.EmployeesRestore, e.authMiddleware.RequireToken("admin"), e.restoreHandler)
 }
 
 func NewEmployeeController(employeeUC usecase.EmployeesUseCase, rg *gin.RouterGroup, authMiddleware middleware.AuthMiddleware) *EmployeeController {
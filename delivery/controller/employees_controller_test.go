@@ -4,6 +4,7 @@ import (
 	"booking-room-app/entity"
 	"booking-room-app/mock/middleware_mock"
 	"booking-room-app/mock/usecase_mock"
+	"booking-room-app/shared/apperror"
 	"booking-room-app/shared/model"
 	"errors"
 	"net/http"
@@ -16,6 +17,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -54,7 +56,7 @@ func (suite *EmployeeControllerTestSuite) TestCreateHandler_Success(){
 	mockPayload := entity.Employee{
 		Name:      "a",
 		Username:  "a",
-		Password:  "a",
+		Password:  "secret1",
 		Role:      "admin",
 		Division:  "a",
 		Position:  "a",
@@ -62,14 +64,14 @@ func (suite *EmployeeControllerTestSuite) TestCreateHandler_Success(){
 	}
 
 	mockEmployee := expect
-	suite.eum.On("RegisterNewEmployee", mockPayload).Return(mockEmployee, nil)
+	suite.eum.On("RegisterNewEmployee", mock.Anything, mockPayload).Return(mockEmployee, nil)
 
 	handlerFunc := NewEmployeeController(suite.eum, suite.rg, suite.amm)
 	handlerFunc.Route()
 	requestBody := `{
 		"name": "a",
 		"username": "a",
-		"password": "a",
+		"password": "secret1",
 		"role": "admin",
 		"division": "a",
 		"position": "a",
@@ -92,7 +94,7 @@ func (suite *EmployeeControllerTestSuite) TestCreateHandlerPayload_BadRequestFai
 	mockError := errors.New("example error message")
 
 	mockEmployee := expect
-	suite.eum.On("RegisterNewEmployee", &mockPayload).Return(mockEmployee, mockError)
+	suite.eum.On("RegisterNewEmployee", mock.Anything, &mockPayload).Return(mockEmployee, mockError)
 
 	handlerFunc := NewEmployeeController(suite.eum, suite.rg, suite.amm)
 	request, err := http.NewRequest(http.MethodPost, "/api/v1/employees", nil)
@@ -119,7 +121,7 @@ func (suite *EmployeeControllerTestSuite) TestCreateHandler_InternalServerError(
 	}
 	mockError := errors.New("example error message")
 	mockEmployee := expect
-	suite.eum.On("RegisterNewEmployee", mockPayload).Return(mockEmployee, mockError)
+	suite.eum.On("RegisterNewEmployee", mock.Anything, mockPayload).Return(mockEmployee, mockError)
 
 	handlerFunc := NewEmployeeController(suite.eum, suite.rg, suite.amm)
 	handlerFunc.Route()
@@ -146,10 +148,10 @@ func (suite *EmployeeControllerTestSuite) TestCreateHandler_InternalServerError(
 
 func (suite *EmployeeControllerTestSuite) TestUpdateHandler_Success(){
 	mockPayload := entity.Employee{
-		ID: "1",
+		ID: "5b0c3f4e-8a21-4c7d-9e6f-2d1a7b3c9e10",
 		Name:      "a",
 		Username:  "a",
-		Password:  "a",
+		Password:  "secret1",
 		Role:      "admin",
 		Division:  "a",
 		Position:  "a",
@@ -157,15 +159,15 @@ func (suite *EmployeeControllerTestSuite) TestUpdateHandler_Success(){
 	}
 
 	mockEmployee := expect
-	suite.eum.On("UpdateEmployee", mockPayload).Return(mockEmployee, nil)
+	suite.eum.On("UpdateEmployee", mock.Anything, mockPayload).Return(mockEmployee, nil)
 
 	handlerFunc := NewEmployeeController(suite.eum, suite.rg, suite.amm)
 	handlerFunc.Route()
 	requestBody := `{
-		"id": "1",
+		"id": "5b0c3f4e-8a21-4c7d-9e6f-2d1a7b3c9e10",
 		"name": "a",
 		"username": "a",
-		"password": "a",
+		"password": "secret1",
 		"role": "admin",
 		"division": "a",
 		"position": "a",
@@ -188,7 +190,7 @@ func (suite *EmployeeControllerTestSuite) TestUpdateHandler_BadRequest(){
 	mockError := errors.New("example error message")
 
 	mockEmployee := expect
-	suite.eum.On("UpdateEmployee", mockPayload).Return(mockEmployee, mockError)
+	suite.eum.On("UpdateEmployee", mock.Anything, mockPayload).Return(mockEmployee, mockError)
 
 	handlerFunc := NewEmployeeController(suite.eum, suite.rg, suite.amm)
 
@@ -211,7 +213,7 @@ func (suite *EmployeeControllerTestSuite) TestUpdateHandler_NotFound(){
 	mockError := errors.New("not found ID " + mockPayload.ID)
 
 	mockEmployee := expect
-	suite.eum.On("UpdateEmployee", mockPayload).Return(mockEmployee, mockError)
+	suite.eum.On("UpdateEmployee", mock.Anything, mockPayload).Return(mockEmployee, mockError)
 
 	handlerFunc := NewEmployeeController(suite.eum, suite.rg, suite.amm)
 	handlerFunc.Route()
@@ -236,7 +238,7 @@ func (suite *EmployeeControllerTestSuite) TestListHandler_Success(){
 		TotalRows: 5,
 		TotalPages: 1,
 	}
-	suite.eum.On("ListAll", 1, 5).Return(employee, paging, nil)
+	suite.eum.On("ListAll", mock.Anything, 1, 5).Return(employee, paging, nil)
 	handlerFunc := NewEmployeeController(suite.eum, suite.rg, suite.amm)
 	handlerFunc.Route()
 	request, err := http.NewRequest(http.MethodGet, "/api/v1/employees?page=1&size=5", nil)
@@ -255,7 +257,7 @@ func (suite *EmployeeControllerTestSuite) TestListHandler_Fail(){
 	employee := []entity.Employee{expect}
 	errMock := errors.New("something went wrong")
 
-	suite.eum.On("ListAll", 1, 5).Return(employee, model.Paging{}, errMock)
+	suite.eum.On("ListAll", mock.Anything, 1, 5).Return(employee, model.Paging{}, errMock)
 	handlerFunc := NewEmployeeController(suite.eum, suite.rg, suite.amm)
 	request, err := http.NewRequest(http.MethodGet, "/api/v1/employees?page=1&size=5", nil)
 	assert.NoError(suite.T(), err)
@@ -270,7 +272,7 @@ func (suite *EmployeeControllerTestSuite) TestListHandler_Fail(){
 }
 
 func (suite *EmployeeControllerTestSuite) TestGetEmployeesByID_Success(){
-	suite.eum.On("FindEmployeesByID", "").Return(expect, nil)
+	suite.eum.On("FindEmployeesByID", mock.Anything, "").Return(expect, nil)
 	handlerFunc := NewEmployeeController(suite.eum, suite.rg, suite.amm)
 	request, err := http.NewRequest(http.MethodGet, "/api/v1/employees/1", nil)
 
@@ -285,7 +287,7 @@ func (suite *EmployeeControllerTestSuite) TestGetEmployeesByID_Success(){
 }
 
 func (suite *EmployeeControllerTestSuite) TestGetEmployeesByUsername_Success(){
-	suite.eum.On("FindEmployeesByUsername", "").Return(expect, nil)
+	suite.eum.On("FindEmployeesByUsername", mock.Anything, "").Return(expect, nil)
 	handlerFunc := NewEmployeeController(suite.eum, suite.rg, suite.amm)
 	request, err := http.NewRequest(http.MethodGet, "/api/v1/employees/username/johndoe", nil)
 
@@ -300,8 +302,8 @@ func (suite *EmployeeControllerTestSuite) TestGetEmployeesByUsername_Success(){
 }
 
 func (suite *EmployeeControllerTestSuite) TestGetEmployeesByID_Error(){
-	mockError := errors.New("employee not found")
-	suite.eum.On("FindEmployeesByID", "").Return(expect, mockError)
+	mockError := apperror.NotFound("employee")
+	suite.eum.On("FindEmployeesByID", mock.Anything, "").Return(expect, mockError)
 
 	handlerFunc := NewEmployeeController(suite.eum, suite.rg, suite.amm)
 	request, err := http.NewRequest(http.MethodGet, "/api/v1/employees/1", nil)
@@ -317,8 +319,8 @@ func (suite *EmployeeControllerTestSuite) TestGetEmployeesByID_Error(){
 }
 
 func (suite *EmployeeControllerTestSuite) TestGetEmployeesByUsername_Error(){
-	mockError := errors.New("employee not found")
-	suite.eum.On("FindEmployeesByUsername", "").Return(expect, mockError)
+	mockError := apperror.NotFound("employee")
+	suite.eum.On("FindEmployeesByUsername", mock.Anything, "").Return(expect, mockError)
 
 	handlerFunc := NewEmployeeController(suite.eum, suite.rg, suite.amm)
 	request, err := http.NewRequest(http.MethodGet, "/api/v1/employees/username/johndoe", nil)
@@ -4,9 +4,10 @@ import (
 	"booking-room-app/config"
 	"booking-room-app/delivery/middleware"
 	"booking-room-app/entity"
+	"booking-room-app/entity/dto"
 	"booking-room-app/shared/common"
+	"booking-room-app/shared/model"
 	"booking-room-app/usecase"
-	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
@@ -19,15 +20,15 @@ type FacilitiesController struct {
 }
 
 func (f *FacilitiesController) updateHandler(ctx *gin.Context) {
-	var payload entity.Facilities
-	if err := ctx.ShouldBindJSON(&payload); err != nil {
-		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+	var payload dto.UpdateFacilityRequestDto
+	if err := common.BindJSON(ctx, &payload); err != nil {
+		common.SendErrorResponse(ctx, err)
 		return
 	}
 
-	facility, err := f.facilitiesUC.EditFacilities(payload)
+	facility, err := f.facilitiesUC.EditFacilities(ctx.Request.Context(), payload.Entity())
 	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		common.SendErrorResponse(ctx, err)
 		return
 	}
 
@@ -36,9 +37,9 @@ func (f *FacilitiesController) updateHandler(ctx *gin.Context) {
 
 func (f *FacilitiesController) getHandler(ctx *gin.Context) {
 	id := ctx.Param("id")
-	facility, err := f.facilitiesUC.FindFacilitiesById(id)
+	facility, err := f.facilitiesUC.FindFacilitiesById(ctx.Request.Context(), id)
 	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusNotFound, "Facilities with ID "+id+" not found")
+		common.SendErrorResponse(ctx, err)
 		return
 	}
 
@@ -49,9 +50,16 @@ func (f *FacilitiesController) listHandler(ctx *gin.Context) {
 	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
 	size, _ := strconv.Atoi(ctx.DefaultQuery("size", "5"))
 
-	facilities, paging, err := f.facilitiesUC.FindAllFacilities(page, size)
+	var facilities []entity.Facilities
+	var paging model.Paging
+	var err error
+	if ctx.Query("archived") == "true" {
+		facilities, paging, err = f.facilitiesUC.FindArchivedFacilities(ctx.Request.Context(), page, size)
+	} else {
+		facilities, paging, err = f.facilitiesUC.FindAllFacilities(ctx.Request.Context(), page, size)
+	}
 	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		common.SendErrorResponse(ctx, err)
 		return
 	}
 
@@ -65,24 +73,98 @@ func (f *FacilitiesController) listHandler(ctx *gin.Context) {
 }
 
 func (f *FacilitiesController) createHandler(ctx *gin.Context) {
-	var payload entity.Facilities
-	if err := ctx.ShouldBindJSON(&payload); err != nil {
-		common.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+	var payload dto.FacilityRequestDto
+	if err := common.BindJSON(ctx, &payload); err != nil {
+		common.SendErrorResponse(ctx, err)
 		return
 	}
-	facility, err := f.facilitiesUC.RegisterNewFacilities(payload)
+	facility, err := f.facilitiesUC.RegisterNewFacilities(ctx.Request.Context(), payload.Entity())
 	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		common.SendErrorResponse(ctx, err)
 		return
 	}
 	common.SendCreateResponse(ctx, facility, "Created")
 }
 
+func (f *FacilitiesController) archiveHandler(ctx *gin.Context) {
+	id, err := common.ParamUUID(ctx, "id")
+	if err != nil {
+		common.SendErrorResponse(ctx, err)
+		return
+	}
+
+	archival, err := f.facilitiesUC.ArchiveFacility(ctx.Request.Context(), id)
+	if err != nil {
+		common.SendErrorResponse(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, archival, "Archived")
+}
+
+func (f *FacilitiesController) restoreHandler(ctx *gin.Context) {
+	id, err := common.ParamUUID(ctx, "id")
+	if err != nil {
+		common.SendErrorResponse(ctx, err)
+		return
+	}
+
+	facility, err := f.facilitiesUC.RestoreFacility(ctx.Request.Context(), id)
+	if err != nil {
+		common.SendErrorResponse(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, facility, "Restored")
+}
+
+func (f *FacilitiesController) createMovementHandler(ctx *gin.Context) {
+	id, err := common.ParamUUID(ctx, "id")
+	if err != nil {
+		common.SendErrorResponse(ctx, err)
+		return
+	}
+	var payload dto.FacilityMovementRequestDto
+	if err := common.BindJSON(ctx, &payload); err != nil {
+		common.SendErrorResponse(ctx, err)
+		return
+	}
+
+	movement, err := f.facilitiesUC.RecordMovement(ctx.Request.Context(), payload.Entity(id))
+	if err != nil {
+		common.SendErrorResponse(ctx, err)
+		return
+	}
+	common.SendCreateResponse(ctx, movement, "Created")
+}
+
+func (f *FacilitiesController) listMovementsHandler(ctx *gin.Context) {
+	id, err := common.ParamUUID(ctx, "id")
+	if err != nil {
+		common.SendErrorResponse(ctx, err)
+		return
+	}
+	page, _ := strconv.Atoi(ctx.DefaultQuery("page", "1"))
+	size, _ := strconv.Atoi(ctx.DefaultQuery("size", "5"))
+
+	movements, paging, err := f.facilitiesUC.FindMovements(ctx.Request.Context(), id, page, size)
+	if err != nil {
+		common.SendErrorResponse(ctx, err)
+		return
+	}
+
+	var response []interface{}
+	for _, m := range movements {
+		response = append(response, m)
+	}
+	common.SendPagedResponse(ctx, response, paging, "Ok")
+}
+
 func (f *FacilitiesController) Route() {
 	f.rg.POST(config.FacilitiesCreate, f.authMiddleware.RequireToken("admin"), f.createHandler)
 	f.rg.GET(config.FacilitiesList, f.authMiddleware.RequireToken("admin", "employee", "ga"), f.listHandler)
 	f.rg.GET(config.FacilitiesGetById, f.authMiddleware.RequireToken("admin", "employee", "ga"), f.getHandler)
 	f.rg.PUT(config.FacilitiesUpdate, f.authMiddleware.RequireToken("admin"), f.updateHandler)
+	f.rg.POST(config.FacilityMovementCreate, f.authMiddleware.RequireToken("admin", "ga"), f.createMovementHandler)
+	f.rg.GET(config.FacilityMovementList, f.authMiddleware.RequireToken("admin", "ga"), f.listMovementsHandler)
 }
 
 func NewFacilitiesController(facilitiesUC usecase.FacilitiesUseCase, rg *gin.RouterGroup, authMiddleware middleware.AuthMiddleware) *FacilitiesController {
@@ -4,6 +4,7 @@ import (
 	"booking-room-app/entity"
 	"booking-room-app/mock/middleware_mock"
 	"booking-room-app/mock/usecase_mock"
+	"booking-room-app/shared/apperror"
 	"booking-room-app/shared/model"
 	"errors"
 	"net/http"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -51,7 +53,7 @@ func (suite *FacilitiesControllerTestSuite) TestListHandler_Success() {
 		TotalRows:   5,
 		TotalPages:  1,
 	}
-	suite.fum.On("FindAllFacilities", 1, 5).Return(mockFacility, mockPaging, nil)
+	suite.fum.On("FindAllFacilities", mock.Anything, 1, 5).Return(mockFacility, mockPaging, nil)
 	handlerFunc := NewFacilitiesController(suite.fum, suite.rg, suite.amm)
 	handlerFunc.Route()
 	request, err := http.NewRequest(http.MethodGet, "/api/v1/facilities?page=1&size=5", nil)
@@ -70,7 +72,7 @@ func (suite *FacilitiesControllerTestSuite) TestListHandler_Fail() {
 	mockFacility := []entity.Facilities{expectedFasilities}
 	mockError := errors.New("something went wrong")
 
-	suite.fum.On("FindAllFacilities", 1, 5).Return(mockFacility, model.Paging{}, mockError)
+	suite.fum.On("FindAllFacilities", mock.Anything, 1, 5).Return(mockFacility, model.Paging{}, mockError)
 	handlerFunc := NewFacilitiesController(suite.fum, suite.rg, suite.amm)
 	request, err := http.NewRequest(http.MethodGet, "/api/v1/facilities?page=1&size=5", nil)
 	assert.NoError(suite.T(), err)
@@ -87,7 +89,7 @@ func (suite *FacilitiesControllerTestSuite) TestListHandler_Fail() {
 // test gethandler
 func (suite *FacilitiesControllerTestSuite) TestGetHandler_Success() {
 	// mockID := "1"
-	suite.fum.On("FindFacilitiesById", "").Return(expectedFasilities, nil)
+	suite.fum.On("FindFacilitiesById", mock.Anything, "").Return(expectedFasilities, nil)
 
 	handlerFunc := NewFacilitiesController(suite.fum, suite.rg, suite.amm)
 	request, err := http.NewRequest(http.MethodGet, "/api/v1/facilities/1", nil)
@@ -104,8 +106,8 @@ func (suite *FacilitiesControllerTestSuite) TestGetHandler_Success() {
 
 func (suite *FacilitiesControllerTestSuite) TestGetHandler_Error() {
 
-	mockError := errors.New("facility not found")
-	suite.fum.On("FindFacilitiesById", "").Return(expectedFasilities, mockError)
+	mockError := apperror.NotFound("facility")
+	suite.fum.On("FindFacilitiesById", mock.Anything, "").Return(expectedFasilities, mockError)
 
 	handlerFunc := NewFacilitiesController(suite.fum, suite.rg, suite.amm)
 	request, err := http.NewRequest(http.MethodGet, "/api/v1/facilities/1", nil)
@@ -128,7 +130,7 @@ func (suite *FacilitiesControllerTestSuite) TestCreateHandler_Success() {
 	}
 	mockFacility := expectedFasilities
 
-	suite.fum.On("RegisterNewFacilities", mockPayload).Return(mockFacility, nil)
+	suite.fum.On("RegisterNewFacilities", mock.Anything, mockPayload).Return(mockFacility, nil)
 
 	handlerFunc := NewFacilitiesController(suite.fum, suite.rg, suite.amm)
 	requestBody := `{"name": "This is name", "quantity": 10}`
@@ -144,13 +146,31 @@ func (suite *FacilitiesControllerTestSuite) TestCreateHandler_Success() {
 	assert.Equal(suite.T(), http.StatusCreated, responseRecorder.Code)
 }
 
+func (suite *FacilitiesControllerTestSuite) TestCreateHandler_ZeroQuantitySuccess() {
+	// a facility tracked by asset starts without stock
+	mockPayload := entity.Facilities{Name: "Laptop"}
+	suite.fum.On("RegisterNewFacilities", mock.Anything, mockPayload).Return(entity.Facilities{ID: "1", Name: "Laptop"}, nil)
+
+	handlerFunc := NewFacilitiesController(suite.fum, suite.rg, suite.amm)
+	request, err := http.NewRequest(http.MethodPost, "/api/v1/facilities", strings.NewReader(`{"name": "Laptop", "quantity": 0}`))
+	assert.NoError(suite.T(), err)
+
+	responseRecorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(responseRecorder)
+	ctx.Request = request
+
+	handlerFunc.createHandler(ctx)
+
+	assert.Equal(suite.T(), http.StatusCreated, responseRecorder.Code)
+}
+
 func (suite *FacilitiesControllerTestSuite) TestCreateHandler_BadRequest() {
 	// Simulate a scenario where binding the JSON payload fails
 	mockPayload := entity.Facilities{}
 	mockError := errors.New("example error message")
 
 	// Mock the ShouldBindJSON method to return an error
-	suite.fum.On("RegisterNewFacilities", &mockPayload).Return(expectedFasilities, mockError)
+	suite.fum.On("RegisterNewFacilities", mock.Anything, &mockPayload).Return(expectedFasilities, mockError)
 
 	handlerFunc := NewFacilitiesController(suite.fum, suite.rg, suite.amm)
 	request, err := http.NewRequest(http.MethodPost, "/api/v1/facilities", nil)
@@ -172,7 +192,7 @@ func (suite *FacilitiesControllerTestSuite) TestCreateHandler_InternalServerErro
 	}
 	mockError := errors.New("example error message")
 
-	suite.fum.On("RegisterNewFacilities", mockPayload).Return(expectedFasilities, mockError)
+	suite.fum.On("RegisterNewFacilities", mock.Anything, mockPayload).Return(expectedFasilities, mockError)
 
 	handlerFunc := NewFacilitiesController(suite.fum, suite.rg, suite.amm)
 	requestBody := `{"name": "This is name", "quantity": 10}`
@@ -191,16 +211,15 @@ func (suite *FacilitiesControllerTestSuite) TestCreateHandler_InternalServerErro
 func (suite *FacilitiesControllerTestSuite) TestUpdateHandler_Success() {
 	// Simulate a successful scenario
 	mockPayload := entity.Facilities{
-		ID:       "1",
-		Name:     "This is name",
-		Quantity: 10,
+		ID:   "9c4e2b7a-3f1d-4e8b-a6c2-5d7f1e3b9a40",
+		Name: "This is name",
 	}
 	mockFacility := expectedFasilities
 
-	suite.fum.On("EditFacilities", mockPayload).Return(mockFacility, nil)
+	suite.fum.On("EditFacilities", mock.Anything, mockPayload).Return(mockFacility, nil)
 
 	handlerFunc := NewFacilitiesController(suite.fum, suite.rg, suite.amm)
-	requestBody := `{"id": "1","name": "This is name", "quantity": 10}`
+	requestBody := `{"id": "9c4e2b7a-3f1d-4e8b-a6c2-5d7f1e3b9a40","name": "This is name"}`
 	request, err := http.NewRequest(http.MethodPut, "/api/v1/facilities", strings.NewReader(requestBody))
 	assert.NoError(suite.T(), err)
 
@@ -217,7 +236,7 @@ func (suite *FacilitiesControllerTestSuite) TestUpdateHandler_BadRequest() {
 	mockPayload := entity.Facilities{}
 	mockError := errors.New("example error message")
 
-	suite.fum.On("EditFacilities", &mockPayload).Return(expectedFasilities, mockError)
+	suite.fum.On("EditFacilities", mock.Anything, &mockPayload).Return(expectedFasilities, mockError)
 
 	handlerFunc := NewFacilitiesController(suite.fum, suite.rg, suite.amm)
 	request, err := http.NewRequest(http.MethodPut, "/api/v1/facilities", nil)
@@ -238,7 +257,7 @@ func (suite *FacilitiesControllerTestSuite) TestUpdateHandler_NotFound() {
 	}
 	mockError := errors.New("not found ID " + mockPayload.ID)
 
-	suite.fum.On("EditFacilities", mockPayload).Return(expectedFasilities, mockError)
+	suite.fum.On("EditFacilities", mock.Anything, mockPayload).Return(expectedFasilities, mockError)
 
 	handlerFunc := NewFacilitiesController(suite.fum, suite.rg, suite.amm)
 	requestBody := `{"id": "nonexistent_id"}`
@@ -254,6 +273,58 @@ func (suite *FacilitiesControllerTestSuite) TestUpdateHandler_NotFound() {
 	assert.Equal(suite.T(), http.StatusNotFound, responseRecorder.Code)
 }
 
+const facilityId = "1e6f7a8b-9c0d-4f1a-8b2c-4d5e6f7a8b9c"
+
+func (suite *FacilitiesControllerTestSuite) TestCreateMovementHandler_Success() {
+	movement := entity.FacilityMovement{FacilityId: facilityId, Kind: entity.MovementWriteOff, Quantity: 2, Reason: "lost after the town hall"}
+	suite.fum.On("RecordMovement", mock.Anything, movement).Return(entity.FacilityMovement{ID: "m1", FacilityId: facilityId, Kind: entity.MovementWriteOff, Quantity: -2, Balance: 8}, nil)
+
+	handlerFunc := NewFacilitiesController(suite.fum, suite.rg, suite.amm)
+	request, _ := http.NewRequest(http.MethodPost, "/api/v1/facilities/"+facilityId+"/movements", strings.NewReader(`{"kind": "write_off", "quantity": 2, "reason": "lost after the town hall"}`))
+
+	responseRecorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(responseRecorder)
+	ctx.Request = request
+	ctx.Params = gin.Params{{Key: "id", Value: facilityId}}
+	handlerFunc.createMovementHandler(ctx)
+
+	assert.Equal(suite.T(), http.StatusCreated, responseRecorder.Code)
+	assert.Contains(suite.T(), responseRecorder.Body.String(), `"balance":8`)
+}
+
+func (suite *FacilitiesControllerTestSuite) TestCreateMovementHandler_AllocationFailure() {
+	handlerFunc := NewFacilitiesController(suite.fum, suite.rg, suite.amm)
+	request, _ := http.NewRequest(http.MethodPost, "/api/v1/facilities/"+facilityId+"/movements", strings.NewReader(`{"kind": "allocation", "quantity": 2, "reason": "room 3"}`))
+
+	responseRecorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(responseRecorder)
+	ctx.Request = request
+	ctx.Params = gin.Params{{Key: "id", Value: facilityId}}
+	handlerFunc.createMovementHandler(ctx)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, responseRecorder.Code)
+	assert.Contains(suite.T(), responseRecorder.Body.String(), `"field":"kind"`)
+	suite.fum.AssertNotCalled(suite.T(), "RecordMovement", mock.Anything, mock.Anything)
+}
+
+func (suite *FacilitiesControllerTestSuite) TestListMovementsHandler_Success() {
+	movements := []entity.FacilityMovement{{ID: "m1", FacilityId: facilityId, Kind: entity.MovementPurchase, Quantity: 10, Balance: 10}}
+	paging := model.Paging{Page: 1, RowsPerPage: 5, TotalRows: 1, TotalPages: 1}
+	suite.fum.On("FindMovements", mock.Anything, facilityId, 1, 5).Return(movements, paging, nil)
+
+	handlerFunc := NewFacilitiesController(suite.fum, suite.rg, suite.amm)
+	request, _ := http.NewRequest(http.MethodGet, "/api/v1/facilities/"+facilityId+"/movements", nil)
+
+	responseRecorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(responseRecorder)
+	ctx.Request = request
+	ctx.Params = gin.Params{{Key: "id", Value: facilityId}}
+	handlerFunc.listMovementsHandler(ctx)
+
+	assert.Equal(suite.T(), http.StatusOK, responseRecorder.Code)
+	assert.Contains(suite.T(), responseRecorder.Body.String(), `"kind":"purchase"`)
+}
+
 func TestFacilitiesControllerTestSuite(t *testing.T) {
 	suite.Run(t, new(FacilitiesControllerTestSuite))
 }
@@ -0,0 +1,175 @@
+package controller
+
+import (
+	"booking-room-app/config"
+	"booking-room-app/delivery/middleware"
+	"booking-room-app/entity/dto"
+	"booking-room-app/shared/common"
+	"booking-room-app/usecase"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type FacilityAssetController struct {
+	facilityAssetUC usecase.FacilityAssetUseCase
+	rg              *gin.RouterGroup
+	authMiddleware  middleware.AuthMiddleware
+}
+
+func (f *FacilityAssetController) createHandler(c *gin.Context) {
+	facilityId, err := common.ParamUUID(c, "id")
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	var payload dto.FacilityAssetRequestDto
+	if err := common.BindJSON(c, &payload); err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	asset := payload.Entity()
+	asset.FacilityId = facilityId
+	asset, err = f.facilityAssetUC.RegisterAsset(c.Request.Context(), asset)
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendCreateResponse(c, asset, "Created")
+}
+
+func (f *FacilityAssetController) listHandler(c *gin.Context) {
+	facilityId, err := common.ParamUUID(c, "id")
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	size, _ := strconv.Atoi(c.DefaultQuery("size", "5"))
+
+	assets, paging, err := f.facilityAssetUC.FindAssets(c.Request.Context(), facilityId, page, size)
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	var response []interface{}
+	for _, v := range assets {
+		response = append(response, v)
+	}
+	common.SendPagedResponse(c, response, paging, "Ok")
+}
+
+func (f *FacilityAssetController) getHandler(c *gin.Context) {
+	id, err := common.ParamUUID(c, "id")
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	asset, err := f.facilityAssetUC.FindAssetById(c.Request.Context(), id)
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendSingleResponse(c, asset, "Ok")
+}
+
+func (f *FacilityAssetController) updateHandler(c *gin.Context) {
+	id, err := common.ParamUUID(c, "id")
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	var payload dto.FacilityAssetRequestDto
+	if err := common.BindJSON(c, &payload); err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	asset := payload.Entity()
+	asset.ID = id
+	asset, err = f.facilityAssetUC.UpdateAsset(c.Request.Context(), asset)
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendSingleResponse(c, asset, "Updated")
+}
+
+func (f *FacilityAssetController) checkOutHandler(c *gin.Context) {
+	id, err := common.ParamUUID(c, "id")
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	var payload dto.AssetCheckOutRequestDto
+	if err := common.BindJSON(c, &payload); err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	asset, err := f.facilityAssetUC.CheckOutAsset(c.Request.Context(), id, payload.TransactionId)
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendSingleResponse(c, asset, "Checked out")
+}
+
+func (f *FacilityAssetController) checkInHandler(c *gin.Context) {
+	id, err := common.ParamUUID(c, "id")
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	var payload dto.AssetCheckInRequestDto
+	if err := common.BindJSON(c, &payload); err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	asset, err := f.facilityAssetUC.CheckInAsset(c.Request.Context(), payload.Entity(id))
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendSingleResponse(c, asset, "Checked in")
+}
+
+func (f *FacilityAssetController) listCheckoutsHandler(c *gin.Context) {
+	id, err := common.ParamUUID(c, "id")
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	size, _ := strconv.Atoi(c.DefaultQuery("size", "5"))
+
+	checkouts, paging, err := f.facilityAssetUC.FindCheckouts(c.Request.Context(), id, page, size)
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	var response []interface{}
+	for _, v := range checkouts {
+		response = append(response, v)
+	}
+	common.SendPagedResponse(c, response, paging, "Ok")
+}
+
+func (f *FacilityAssetController) Route() {
+	f.rg.POST(config.FacilityAssetCreate, f.authMiddleware.RequireToken("admin", "ga"), f.createHandler)
+	f.rg.GET(config.FacilityAssetList, f.authMiddleware.RequireToken("admin", "ga"), f.listHandler)
+	f.rg.GET(config.AssetGetById, f.authMiddleware.RequireToken("admin", "ga"), f.getHandler)
+	f.rg.PUT(config.AssetUpdate, f.authMiddleware.RequireToken("admin", "ga"), f.updateHandler)
+	f.rg.POST(config.AssetCheckOut, f.authMiddleware.RequireToken("admin", "ga"), f.checkOutHandler)
+	f.rg.POST(config.AssetCheckIn, f.authMiddleware.RequireToken("admin", "ga"), f.checkInHandler)
+	f.rg.GET(config.AssetCheckoutList, f.authMiddleware.RequireToken("admin", "ga"), f.listCheckoutsHandler)
+}
+
+func NewFacilityAssetController(facilityAssetUC usecase.FacilityAssetUseCase, rg *gin.RouterGroup, authMiddleware middleware.AuthMiddleware) *FacilityAssetController {
+	return &FacilityAssetController{facilityAssetUC: facilityAssetUC, rg: rg, authMiddleware: authMiddleware}
+}
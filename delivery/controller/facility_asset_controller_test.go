@@ -0,0 +1,218 @@
+package controller
+
+import (
+	"booking-room-app/entity"
+	"booking-room-app/mock/middleware_mock"
+	"booking-room-app/mock/usecase_mock"
+	"booking-room-app/shared/apperror"
+	"booking-room-app/shared/model"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	assetId       = "4b5c6d7e-8f9a-4b0c-9d1e-2f3a4b5c6d7e"
+	transactionId = "5c6d7e8f-9a0b-4c1d-8e2f-3a4b5c6d7e8f"
+)
+
+type FacilityAssetControllerTestSuite struct {
+	suite.Suite
+	rg  *gin.RouterGroup
+	fum *usecase_mock.FacilityAssetUseCaseMock
+	amm *middleware_mock.AuthMiddlewareMock
+}
+
+func (suite *FacilityAssetControllerTestSuite) SetupTest() {
+	suite.fum = new(usecase_mock.FacilityAssetUseCaseMock)
+	router := gin.Default()
+	gin.SetMode(gin.TestMode)
+	suite.rg = router.Group(apiGroup)
+}
+
+func (suite *FacilityAssetControllerTestSuite) TestCreateHandler_Success() {
+	payload := entity.FacilityAsset{FacilityId: facilityId, AssetTag: "LPT-001", Condition: entity.AssetConditionNew, PurchaseDate: "2024-01-15"}
+	asset := payload
+	asset.ID = assetId
+	suite.fum.On("RegisterAsset", mock.Anything, payload).Return(asset, nil)
+
+	handlerFunc := NewFacilityAssetController(suite.fum, suite.rg, suite.amm)
+	request, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/facilities/%s/assets", apiGroup, facilityId), strings.NewReader(`{"assetTag": "LPT-001", "condition": "new", "purchaseDate": "2024-01-15"}`))
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	c.Params = gin.Params{{Key: "id", Value: facilityId}}
+	handlerFunc.createHandler(c)
+
+	assert.Equal(suite.T(), http.StatusCreated, responseRecorder.Code)
+	assert.Contains(suite.T(), responseRecorder.Body.String(), `"assetTag":"LPT-001"`)
+}
+
+func (suite *FacilityAssetControllerTestSuite) TestCreateHandler_InvalidConditionFailure() {
+	handlerFunc := NewFacilityAssetController(suite.fum, suite.rg, suite.amm)
+	request, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/facilities/%s/assets", apiGroup, facilityId), strings.NewReader(`{"assetTag": "LPT-001", "condition": "broken"}`))
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	c.Params = gin.Params{{Key: "id", Value: facilityId}}
+	handlerFunc.createHandler(c)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, responseRecorder.Code)
+	assert.Contains(suite.T(), responseRecorder.Body.String(), `"field":"condition"`)
+	suite.fum.AssertNotCalled(suite.T(), "RegisterAsset", mock.Anything, mock.Anything)
+}
+
+func (suite *FacilityAssetControllerTestSuite) TestCreateHandler_UntrackedStockFailure() {
+	suite.fum.On("RegisterAsset", mock.Anything, mock.Anything).Return(entity.FacilityAsset{}, apperror.Conflict("untracked_stock", "the facility has stock or room allocations that are not tracked by asset"))
+
+	handlerFunc := NewFacilityAssetController(suite.fum, suite.rg, suite.amm)
+	request, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/facilities/%s/assets", apiGroup, facilityId), strings.NewReader(`{"assetTag": "LPT-001", "condition": "good"}`))
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	c.Params = gin.Params{{Key: "id", Value: facilityId}}
+	handlerFunc.createHandler(c)
+
+	assert.Equal(suite.T(), http.StatusConflict, responseRecorder.Code)
+}
+
+func (suite *FacilityAssetControllerTestSuite) TestListHandler_Success() {
+	assets := []entity.FacilityAsset{{ID: assetId, FacilityId: facilityId, AssetTag: "LPT-001", Condition: entity.AssetConditionGood}}
+	paging := model.Paging{Page: 1, RowsPerPage: 5, TotalRows: 1, TotalPages: 1}
+	suite.fum.On("FindAssets", mock.Anything, facilityId, 1, 5).Return(assets, paging, nil)
+
+	handlerFunc := NewFacilityAssetController(suite.fum, suite.rg, suite.amm)
+	request, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/facilities/%s/assets", apiGroup, facilityId), nil)
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	c.Params = gin.Params{{Key: "id", Value: facilityId}}
+	handlerFunc.listHandler(c)
+
+	assert.Equal(suite.T(), http.StatusOK, responseRecorder.Code)
+	assert.Contains(suite.T(), responseRecorder.Body.String(), `"assetTag":"LPT-001"`)
+}
+
+func (suite *FacilityAssetControllerTestSuite) TestGetHandler_NotFoundFailure() {
+	suite.fum.On("FindAssetById", mock.Anything, assetId).Return(entity.FacilityAsset{}, apperror.NotFound("facility asset"))
+
+	handlerFunc := NewFacilityAssetController(suite.fum, suite.rg, suite.amm)
+	request, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/assets/%s", apiGroup, assetId), nil)
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	c.Params = gin.Params{{Key: "id", Value: assetId}}
+	handlerFunc.getHandler(c)
+
+	assert.Equal(suite.T(), http.StatusNotFound, responseRecorder.Code)
+}
+
+func (suite *FacilityAssetControllerTestSuite) TestUpdateHandler_Success() {
+	payload := entity.FacilityAsset{ID: assetId, AssetTag: "LPT-001", Condition: entity.AssetConditionDamaged, Location: "repair shop"}
+	suite.fum.On("UpdateAsset", mock.Anything, payload).Return(payload, nil)
+
+	handlerFunc := NewFacilityAssetController(suite.fum, suite.rg, suite.amm)
+	request, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/assets/%s", apiGroup, assetId), strings.NewReader(`{"assetTag": "LPT-001", "condition": "damaged", "location": "repair shop"}`))
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	c.Params = gin.Params{{Key: "id", Value: assetId}}
+	handlerFunc.updateHandler(c)
+
+	assert.Equal(suite.T(), http.StatusOK, responseRecorder.Code)
+}
+
+func (suite *FacilityAssetControllerTestSuite) TestCheckOutHandler_Success() {
+	asset := entity.FacilityAsset{ID: assetId, FacilityId: facilityId, AssetTag: "LPT-001", Condition: entity.AssetConditionGood, TransactionId: transactionId}
+	suite.fum.On("CheckOutAsset", mock.Anything, assetId, transactionId).Return(asset, nil)
+
+	handlerFunc := NewFacilityAssetController(suite.fum, suite.rg, suite.amm)
+	request, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/assets/%s/checkout", apiGroup, assetId), strings.NewReader(fmt.Sprintf(`{"transactionId": "%s"}`, transactionId)))
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	c.Params = gin.Params{{Key: "id", Value: assetId}}
+	handlerFunc.checkOutHandler(c)
+
+	assert.Equal(suite.T(), http.StatusOK, responseRecorder.Code)
+	assert.Contains(suite.T(), responseRecorder.Body.String(), `"transactionId":"`+transactionId+`"`)
+}
+
+func (suite *FacilityAssetControllerTestSuite) TestCheckOutHandler_MissingTransactionFailure() {
+	handlerFunc := NewFacilityAssetController(suite.fum, suite.rg, suite.amm)
+	request, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/assets/%s/checkout", apiGroup, assetId), strings.NewReader(`{}`))
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	c.Params = gin.Params{{Key: "id", Value: assetId}}
+	handlerFunc.checkOutHandler(c)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, responseRecorder.Code)
+	assert.Contains(suite.T(), responseRecorder.Body.String(), `"field":"transactionId"`)
+}
+
+func (suite *FacilityAssetControllerTestSuite) TestCheckInHandler_Success() {
+	payload := entity.AssetCheckIn{AssetId: assetId, Condition: entity.AssetConditionGood, RoomId: roomId}
+	suite.fum.On("CheckInAsset", mock.Anything, payload).Return(entity.FacilityAsset{ID: assetId, Condition: entity.AssetConditionGood, RoomId: roomId}, nil)
+
+	handlerFunc := NewFacilityAssetController(suite.fum, suite.rg, suite.amm)
+	request, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/assets/%s/checkin", apiGroup, assetId), strings.NewReader(fmt.Sprintf(`{"condition": "good", "roomId": "%s"}`, roomId)))
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	c.Params = gin.Params{{Key: "id", Value: assetId}}
+	handlerFunc.checkInHandler(c)
+
+	assert.Equal(suite.T(), http.StatusOK, responseRecorder.Code)
+}
+
+func (suite *FacilityAssetControllerTestSuite) TestCheckInHandler_NotCheckedOutFailure() {
+	suite.fum.On("CheckInAsset", mock.Anything, mock.Anything).Return(entity.FacilityAsset{}, apperror.Conflict("asset_not_checked_out", "the asset is not checked out"))
+
+	handlerFunc := NewFacilityAssetController(suite.fum, suite.rg, suite.amm)
+	request, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/assets/%s/checkin", apiGroup, assetId), strings.NewReader(`{"condition": "good"}`))
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	c.Params = gin.Params{{Key: "id", Value: assetId}}
+	handlerFunc.checkInHandler(c)
+
+	assert.Equal(suite.T(), http.StatusConflict, responseRecorder.Code)
+}
+
+func (suite *FacilityAssetControllerTestSuite) TestListCheckoutsHandler_Success() {
+	checkouts := []entity.AssetCheckout{{ID: "1", AssetId: assetId, TransactionId: transactionId}}
+	suite.fum.On("FindCheckouts", mock.Anything, assetId, 2, 10).Return(checkouts, model.Paging{Page: 2, RowsPerPage: 10, TotalRows: 11, TotalPages: 2}, nil)
+
+	handlerFunc := NewFacilityAssetController(suite.fum, suite.rg, suite.amm)
+	request, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/assets/%s/checkouts?page=2&size=10", apiGroup, assetId), nil)
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	c.Params = gin.Params{{Key: "id", Value: assetId}}
+	handlerFunc.listCheckoutsHandler(c)
+
+	assert.Equal(suite.T(), http.StatusOK, responseRecorder.Code)
+}
+
+func TestFacilityAssetControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(FacilityAssetControllerTestSuite))
+}
@@ -0,0 +1,62 @@
+package controller
+
+import (
+	"booking-room-app/config"
+	"booking-room-app/shared/buildinfo"
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// ReadinessCheck reports whether a dependency is able to serve traffic.
+type ReadinessCheck func(ctx context.Context) error
+
+type HealthController struct {
+	router gin.IRoutes
+	checks map[string]ReadinessCheck
+}
+
+type healthResponse struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+func (h *HealthController) liveHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, healthResponse{Status: "ok"})
+}
+
+// readyHandler runs every check and answers 503 when one of them fails, so the
+// load balancer stops routing traffic to this instance.
+func (h *HealthController) readyHandler(c *gin.Context) {
+	ctx, cancel := context.WithTimeout(c.Request.Context(), 2*time.Second)
+	defer cancel()
+
+	code := http.StatusOK
+	response := healthResponse{Status: "ok", Checks: make(map[string]string)}
+	for name, check := range h.checks {
+		if err := check(ctx); err != nil {
+			code = http.StatusServiceUnavailable
+			response.Status = "unavailable"
+			response.Checks[name] = err.Error()
+			continue
+		}
+		response.Checks[name] = "ok"
+	}
+	c.JSON(code, response)
+}
+
+func (h *HealthController) versionHandler(c *gin.Context) {
+	c.JSON(http.StatusOK, buildinfo.Get())
+}
+
+func (h *HealthController) Route() {
+	h.router.GET(config.HealthLive, h.liveHandler)
+	h.router.GET(config.HealthReady, h.readyHandler)
+	h.router.GET(config.Version, h.versionHandler)
+}
+
+func NewHealthController(router gin.IRoutes, checks map[string]ReadinessCheck) *HealthController {
+	return &HealthController{router: router, checks: checks}
+}
@@ -0,0 +1,80 @@
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type HealthControllerTestSuite struct {
+	suite.Suite
+	router *gin.Engine
+}
+
+func (suite *HealthControllerTestSuite) SetupTest() {
+	gin.SetMode(gin.TestMode)
+	suite.router = gin.New()
+}
+
+func (suite *HealthControllerTestSuite) serve(path string) *httptest.ResponseRecorder {
+	request, _ := http.NewRequest(http.MethodGet, path, nil)
+	responseRecorder := httptest.NewRecorder()
+	suite.router.ServeHTTP(responseRecorder, request)
+	return responseRecorder
+}
+
+func (suite *HealthControllerTestSuite) TestLiveHandler_Success() {
+	NewHealthController(suite.router, nil).Route()
+
+	responseRecorder := suite.serve("/healthz")
+
+	assert.Equal(suite.T(), http.StatusOK, responseRecorder.Code)
+}
+
+func (suite *HealthControllerTestSuite) TestReadyHandler_Success() {
+	NewHealthController(suite.router, map[string]ReadinessCheck{
+		"database": func(ctx context.Context) error { return nil },
+	}).Route()
+
+	responseRecorder := suite.serve("/readyz")
+
+	var response healthResponse
+	assert.Equal(suite.T(), http.StatusOK, responseRecorder.Code)
+	assert.NoError(suite.T(), json.Unmarshal(responseRecorder.Body.Bytes(), &response))
+	assert.Equal(suite.T(), "ok", response.Checks["database"])
+}
+
+func (suite *HealthControllerTestSuite) TestReadyHandler_Unavailable() {
+	NewHealthController(suite.router, map[string]ReadinessCheck{
+		"database":        func(ctx context.Context) error { return fmt.Errorf("connection refused") },
+		"reportScheduler": func(ctx context.Context) error { return nil },
+	}).Route()
+
+	responseRecorder := suite.serve("/readyz")
+
+	var response healthResponse
+	assert.Equal(suite.T(), http.StatusServiceUnavailable, responseRecorder.Code)
+	assert.NoError(suite.T(), json.Unmarshal(responseRecorder.Body.Bytes(), &response))
+	assert.Equal(suite.T(), "connection refused", response.Checks["database"])
+	assert.Equal(suite.T(), "ok", response.Checks["reportScheduler"])
+}
+
+func (suite *HealthControllerTestSuite) TestVersionHandler_Success() {
+	NewHealthController(suite.router, nil).Route()
+
+	responseRecorder := suite.serve("/version")
+
+	assert.Equal(suite.T(), http.StatusOK, responseRecorder.Code)
+	assert.Contains(suite.T(), responseRecorder.Body.String(), "goVersion")
+}
+
+func TestHealthControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(HealthControllerTestSuite))
+}
@@ -0,0 +1,282 @@
+package controller
+
+import (
+	"booking-room-app/config"
+	"booking-room-app/delivery/middleware"
+	"booking-room-app/entity/dto"
+	"booking-room-app/shared/common"
+	"booking-room-app/usecase"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type LocationController struct {
+	locationUC     usecase.LocationUseCase
+	rg             *gin.RouterGroup
+	authMiddleware middleware.AuthMiddleware
+}
+
+func (l *LocationController) createSiteHandler(c *gin.Context) {
+	var payload dto.SiteRequestDto
+	if err := common.BindJSON(c, &payload); err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	site, err := l.locationUC.RegisterSite(c.Request.Context(), payload.Entity())
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendCreateResponse(c, site, "Created")
+}
+
+func (l *LocationController) getSiteHandler(c *gin.Context) {
+	id, err := common.ParamUUID(c, "id")
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	site, err := l.locationUC.FindSiteByID(c.Request.Context(), id)
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendSingleResponse(c, site, "Ok")
+}
+
+func (l *LocationController) listSitesHandler(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	size, _ := strconv.Atoi(c.DefaultQuery("size", "5"))
+
+	sites, paging, err := l.locationUC.FindAllSites(c.Request.Context(), page, size)
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	var response []interface{}
+	for _, v := range sites {
+		response = append(response, v)
+	}
+	common.SendPagedResponse(c, response, paging, "Ok")
+}
+
+func (l *LocationController) updateSiteHandler(c *gin.Context) {
+	var payload dto.UpdateSiteRequestDto
+	if err := common.BindJSON(c, &payload); err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	site, err := l.locationUC.UpdateSite(c.Request.Context(), payload.Entity())
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendSingleResponse(c, site, "Updated")
+}
+
+func (l *LocationController) deleteSiteHandler(c *gin.Context) {
+	id, err := common.ParamUUID(c, "id")
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	if err := l.locationUC.DeleteSite(c.Request.Context(), id); err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendNoContentResponse(c)
+}
+
+func (l *LocationController) createBuildingHandler(c *gin.Context) {
+	var payload dto.BuildingRequestDto
+	if err := common.BindJSON(c, &payload); err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	building, err := l.locationUC.RegisterBuilding(c.Request.Context(), payload.Entity())
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendCreateResponse(c, building, "Created")
+}
+
+func (l *LocationController) getBuildingHandler(c *gin.Context) {
+	id, err := common.ParamUUID(c, "id")
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	building, err := l.locationUC.FindBuildingByID(c.Request.Context(), id)
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendSingleResponse(c, building, "Ok")
+}
+
+// listBuildingsHandler lists every building, or with siteId only those of one site.
+func (l *LocationController) listBuildingsHandler(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	size, _ := strconv.Atoi(c.DefaultQuery("size", "5"))
+
+	var filter dto.LocationFilterDto
+	if err := common.BindQuery(c, &filter); err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	buildings, paging, err := l.locationUC.FindAllBuildings(c.Request.Context(), filter.SiteId, page, size)
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	var response []interface{}
+	for _, v := range buildings {
+		response = append(response, v)
+	}
+	common.SendPagedResponse(c, response, paging, "Ok")
+}
+
+func (l *LocationController) updateBuildingHandler(c *gin.Context) {
+	var payload dto.UpdateBuildingRequestDto
+	if err := common.BindJSON(c, &payload); err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	building, err := l.locationUC.UpdateBuilding(c.Request.Context(), payload.Entity())
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendSingleResponse(c, building, "Updated")
+}
+
+func (l *LocationController) deleteBuildingHandler(c *gin.Context) {
+	id, err := common.ParamUUID(c, "id")
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	if err := l.locationUC.DeleteBuilding(c.Request.Context(), id); err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendNoContentResponse(c)
+}
+
+func (l *LocationController) createFloorHandler(c *gin.Context) {
+	var payload dto.FloorRequestDto
+	if err := common.BindJSON(c, &payload); err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	floor, err := l.locationUC.RegisterFloor(c.Request.Context(), payload.Entity())
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendCreateResponse(c, floor, "Created")
+}
+
+func (l *LocationController) getFloorHandler(c *gin.Context) {
+	id, err := common.ParamUUID(c, "id")
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	floor, err := l.locationUC.FindFloorByID(c.Request.Context(), id)
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendSingleResponse(c, floor, "Ok")
+}
+
+// listFloorsHandler lists every floor, or with buildingId only those of one building.
+func (l *LocationController) listFloorsHandler(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	size, _ := strconv.Atoi(c.DefaultQuery("size", "5"))
+
+	var filter dto.LocationFilterDto
+	if err := common.BindQuery(c, &filter); err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	floors, paging, err := l.locationUC.FindAllFloors(c.Request.Context(), filter.BuildingId, page, size)
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	var response []interface{}
+	for _, v := range floors {
+		response = append(response, v)
+	}
+	common.SendPagedResponse(c, response, paging, "Ok")
+}
+
+func (l *LocationController) updateFloorHandler(c *gin.Context) {
+	var payload dto.UpdateFloorRequestDto
+	if err := common.BindJSON(c, &payload); err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	floor, err := l.locationUC.UpdateFloor(c.Request.Context(), payload.Entity())
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendSingleResponse(c, floor, "Updated")
+}
+
+func (l *LocationController) deleteFloorHandler(c *gin.Context) {
+	id, err := common.ParamUUID(c, "id")
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	if err := l.locationUC.DeleteFloor(c.Request.Context(), id); err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendNoContentResponse(c)
+}
+
+func (l *LocationController) Route() {
+	l.rg.POST(config.SiteCreate, l.authMiddleware.RequireToken("admin"), l.createSiteHandler)
+	l.rg.GET(config.SiteList, l.authMiddleware.RequireToken("employee", "admin", "ga"), l.listSitesHandler)
+	l.rg.GET(config.SiteGetById, l.authMiddleware.RequireToken("employee", "admin", "ga"), l.getSiteHandler)
+	l.rg.PUT(config.SiteUpdate, l.authMiddleware.RequireToken("admin"), l.updateSiteHandler)
+	l.rg.DELETE(config.SiteDelete, l.authMiddleware.RequireToken("admin"), l.deleteSiteHandler)
+	l.rg.POST(config.BuildingCreate, l.authMiddleware.RequireToken("admin"), l.createBuildingHandler)
+	l.rg.GET(config.BuildingList, l.authMiddleware.RequireToken("employee", "admin", "ga"), l.listBuildingsHandler)
+	l.rg.GET(config.BuildingGetById, l.authMiddleware.RequireToken("employee", "admin", "ga"), l.getBuildingHandler)
+	l.rg.PUT(config.BuildingUpdate, l.authMiddleware.RequireToken("admin"), l.updateBuildingHandler)
+	l.rg.DELETE(config.BuildingDelete, l.authMiddleware.RequireToken("admin"), l.deleteBuildingHandler)
+	l.rg.POST(config.FloorCreate, l.authMiddleware.RequireToken("admin"), l.createFloorHandler)
+	l.rg.GET(config.FloorList, l.authMiddleware.RequireToken("employee", "admin", "ga"), l.listFloorsHandler)
+	l.rg.GET(config.FloorGetById, l.authMiddleware.RequireToken("employee", "admin", "ga"), l.getFloorHandler)
+	l.rg.PUT(config.FloorUpdate, l.authMiddleware.RequireToken("admin"), l.updateFloorHandler)
+	l.rg.DELETE(config.FloorDelete, l.authMiddleware.RequireToken("admin"), l.deleteFloorHandler)
+}
+
+func NewLocationController(locationUC usecase.LocationUseCase, rg *gin.RouterGroup, authMiddleware middleware.AuthMiddleware) *LocationController {
+	return &LocationController{locationUC: locationUC, rg: rg, authMiddleware: authMiddleware}
+}
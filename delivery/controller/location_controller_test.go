@@ -0,0 +1,162 @@
+package controller
+
+import (
+	"booking-room-app/entity"
+	"booking-room-app/mock/middleware_mock"
+	"booking-room-app/mock/usecase_mock"
+	"booking-room-app/shared/apperror"
+	"booking-room-app/shared/model"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	siteId     = "6f1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d"
+	buildingId = "7a2b3c4d-5e6f-4b7c-9d8e-0f1a2b3c4d5e"
+	floorId    = "8b3c4d5e-6f7a-4c8d-8e9f-1a2b3c4d5e6f"
+)
+
+type LocationControllerTestSuite struct {
+	suite.Suite
+	rg  *gin.RouterGroup
+	lum *usecase_mock.LocationUseCaseMock
+	amm *middleware_mock.AuthMiddlewareMock
+}
+
+func (suite *LocationControllerTestSuite) SetupTest() {
+	suite.lum = new(usecase_mock.LocationUseCaseMock)
+	router := gin.Default()
+	gin.SetMode(gin.TestMode)
+	suite.rg = router.Group(apiGroup)
+}
+
+func (suite *LocationControllerTestSuite) TestCreateSiteHandler_Success() {
+	payload := entity.Site{Name: "Jakarta HQ", Address: "Jl. Sudirman 1", Timezone: "Asia/Jakarta"}
+	created := payload
+	created.ID = siteId
+	suite.lum.On("RegisterSite", mock.Anything, payload).Return(created, nil)
+
+	handlerFunc := NewLocationController(suite.lum, suite.rg, suite.amm)
+	request, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/sites", apiGroup), strings.NewReader(`{"name": "Jakarta HQ", "address": "Jl. Sudirman 1", "timezone": "Asia/Jakarta"}`))
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	handlerFunc.createSiteHandler(c)
+
+	assert.Equal(suite.T(), http.StatusCreated, responseRecorder.Code)
+}
+
+func (suite *LocationControllerTestSuite) TestCreateSiteHandler_InvalidTimezoneFailure() {
+	handlerFunc := NewLocationController(suite.lum, suite.rg, suite.amm)
+	request, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/sites", apiGroup), strings.NewReader(`{"name": "Jakarta HQ", "timezone": "Jakarta"}`))
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	handlerFunc.createSiteHandler(c)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, responseRecorder.Code)
+	assert.Contains(suite.T(), responseRecorder.Body.String(), `"field":"timezone"`)
+	suite.lum.AssertNotCalled(suite.T(), "RegisterSite", mock.Anything, mock.Anything)
+}
+
+func (suite *LocationControllerTestSuite) TestGetBuildingHandler_NotFoundFailure() {
+	suite.lum.On("FindBuildingByID", mock.Anything, buildingId).Return(entity.Building{}, apperror.NotFound("building"))
+
+	handlerFunc := NewLocationController(suite.lum, suite.rg, suite.amm)
+	request, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/buildings/%s", apiGroup, buildingId), nil)
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	c.Params = gin.Params{{Key: "id", Value: buildingId}}
+	handlerFunc.getBuildingHandler(c)
+
+	assert.Equal(suite.T(), http.StatusNotFound, responseRecorder.Code)
+}
+
+func (suite *LocationControllerTestSuite) TestListBuildingsHandler_BySiteSuccess() {
+	buildings := []entity.Building{{ID: buildingId, SiteId: siteId, Name: "Tower A"}}
+	suite.lum.On("FindAllBuildings", mock.Anything, siteId, 1, 5).Return(buildings, model.Paging{Page: 1, RowsPerPage: 5, TotalRows: 1, TotalPages: 1}, nil)
+
+	handlerFunc := NewLocationController(suite.lum, suite.rg, suite.amm)
+	request, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/buildings?siteId=%s", apiGroup, siteId), nil)
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	handlerFunc.listBuildingsHandler(c)
+
+	assert.Equal(suite.T(), http.StatusOK, responseRecorder.Code)
+}
+
+func (suite *LocationControllerTestSuite) TestListFloorsHandler_InvalidBuildingIdFailure() {
+	handlerFunc := NewLocationController(suite.lum, suite.rg, suite.amm)
+	request, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/floors?buildingId=tower-a", apiGroup), nil)
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	handlerFunc.listFloorsHandler(c)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, responseRecorder.Code)
+	assert.Contains(suite.T(), responseRecorder.Body.String(), `"field":"buildingId"`)
+}
+
+func (suite *LocationControllerTestSuite) TestUpdateFloorHandler_Success() {
+	payload := entity.Floor{ID: floorId, BuildingId: buildingId, Name: "Basement", Level: -1}
+	suite.lum.On("UpdateFloor", mock.Anything, payload).Return(payload, nil)
+
+	handlerFunc := NewLocationController(suite.lum, suite.rg, suite.amm)
+	request, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/floors", apiGroup), strings.NewReader(fmt.Sprintf(`{"id": "%s", "buildingId": "%s", "name": "Basement", "level": -1}`, floorId, buildingId)))
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	handlerFunc.updateFloorHandler(c)
+
+	assert.Equal(suite.T(), http.StatusOK, responseRecorder.Code)
+}
+
+func (suite *LocationControllerTestSuite) TestDeleteSiteHandler_Success() {
+	suite.lum.On("DeleteSite", mock.Anything, siteId).Return(nil)
+
+	handlerFunc := NewLocationController(suite.lum, suite.rg, suite.amm)
+	request, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/sites/%s", apiGroup, siteId), nil)
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	c.Params = gin.Params{{Key: "id", Value: siteId}}
+	handlerFunc.deleteSiteHandler(c)
+
+	assert.Equal(suite.T(), http.StatusNoContent, c.Writer.Status())
+}
+
+func (suite *LocationControllerTestSuite) TestDeleteFloorHandler_InUseFailure() {
+	suite.lum.On("DeleteFloor", mock.Anything, floorId).Return(apperror.Conflict("floor_in_use", "the floor still has rooms"))
+
+	handlerFunc := NewLocationController(suite.lum, suite.rg, suite.amm)
+	request, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/floors/%s", apiGroup, floorId), nil)
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	c.Params = gin.Params{{Key: "id", Value: floorId}}
+	handlerFunc.deleteFloorHandler(c)
+
+	assert.Equal(suite.T(), http.StatusConflict, responseRecorder.Code)
+}
+
+func TestLocationControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(LocationControllerTestSuite))
+}
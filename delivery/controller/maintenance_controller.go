@@ -0,0 +1,126 @@
+package controller
+
+import (
+	"booking-room-app/config"
+	"booking-room-app/delivery/middleware"
+	"booking-room-app/entity/dto"
+	"booking-room-app/shared/common"
+	"booking-room-app/usecase"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type MaintenanceController struct {
+	maintenanceUC  usecase.MaintenanceUseCase
+	rg             *gin.RouterGroup
+	authMiddleware middleware.AuthMiddleware
+}
+
+func (m *MaintenanceController) createHandler(c *gin.Context) {
+	var payload dto.MaintenanceRequestDto
+	if err := common.BindJSON(c, &payload); err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	maintenance, err := m.maintenanceUC.RegisterMaintenance(c.Request.Context(), payload.Entity())
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendCreateResponse(c, maintenance, "Created")
+}
+
+// listHandler lists the maintenance windows, newest first, optionally of one
+// room or only those that have not ended.
+func (m *MaintenanceController) listHandler(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	size, _ := strconv.Atoi(c.DefaultQuery("size", "5"))
+
+	var query dto.MaintenanceQueryDto
+	if err := common.BindQuery(c, &query); err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	windows, paging, err := m.maintenanceUC.FindAllMaintenance(c.Request.Context(), query.Entity(), page, size)
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	var response []interface{}
+	for _, v := range windows {
+		response = append(response, v)
+	}
+	common.SendPagedResponse(c, response, paging, "Ok")
+}
+
+// conflictsHandler lists the accepted bookings that overlap a maintenance
+// window and have to be relocated.
+func (m *MaintenanceController) conflictsHandler(c *gin.Context) {
+	conflicts, err := m.maintenanceUC.FindConflicts(c.Request.Context())
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendSingleResponse(c, conflicts, "Ok")
+}
+
+func (m *MaintenanceController) getHandler(c *gin.Context) {
+	id, err := common.ParamUUID(c, "id")
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	maintenance, err := m.maintenanceUC.FindMaintenanceByID(c.Request.Context(), id)
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendSingleResponse(c, maintenance, "Ok")
+}
+
+func (m *MaintenanceController) updateHandler(c *gin.Context) {
+	var payload dto.UpdateMaintenanceRequestDto
+	if err := common.BindJSON(c, &payload); err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	maintenance, err := m.maintenanceUC.UpdateMaintenance(c.Request.Context(), payload.Entity())
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendSingleResponse(c, maintenance, "Updated")
+}
+
+func (m *MaintenanceController) deleteHandler(c *gin.Context) {
+	id, err := common.ParamUUID(c, "id")
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	if err := m.maintenanceUC.DeleteMaintenance(c.Request.Context(), id); err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendNoContentResponse(c)
+}
+
+func (m *MaintenanceController) Route() {
+	m.rg.POST(config.MaintenanceCreate, m.authMiddleware.RequireToken("admin", "ga"), m.createHandler)
+	m.rg.GET(config.MaintenanceList, m.authMiddleware.RequireToken("employee", "admin", "ga"), m.listHandler)
+	m.rg.GET(config.MaintenanceConflicts, m.authMiddleware.RequireToken("admin", "ga"), m.conflictsHandler)
+	m.rg.GET(config.MaintenanceGetById, m.authMiddleware.RequireToken("employee", "admin", "ga"), m.getHandler)
+	m.rg.PUT(config.MaintenanceUpdate, m.authMiddleware.RequireToken("admin", "ga"), m.updateHandler)
+	m.rg.DELETE(config.MaintenanceDelete, m.authMiddleware.RequireToken("admin", "ga"), m.deleteHandler)
+}
+
+func NewMaintenanceController(maintenanceUC usecase.MaintenanceUseCase, rg *gin.RouterGroup, authMiddleware middleware.AuthMiddleware) *MaintenanceController {
+	return &MaintenanceController{maintenanceUC: maintenanceUC, rg: rg, authMiddleware: authMiddleware}
+}
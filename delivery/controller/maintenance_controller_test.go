@@ -0,0 +1,136 @@
+package controller
+
+import (
+	"booking-room-app/entity"
+	"booking-room-app/mock/middleware_mock"
+	"booking-room-app/mock/usecase_mock"
+	"booking-room-app/shared/model"
+	"booking-room-app/usecase"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+const maintenanceId = "5d3c1a4e-2b6f-4e8a-9c0d-7f1e2a3b4c5d"
+
+type MaintenanceControllerTestSuite struct {
+	suite.Suite
+	rg  *gin.RouterGroup
+	mum *usecase_mock.MaintenanceUseCaseMock
+	amm *middleware_mock.AuthMiddlewareMock
+}
+
+func (suite *MaintenanceControllerTestSuite) SetupTest() {
+	suite.mum = new(usecase_mock.MaintenanceUseCaseMock)
+	router := gin.Default()
+	gin.SetMode(gin.TestMode)
+	suite.rg = router.Group(apiGroup)
+}
+
+func (suite *MaintenanceControllerTestSuite) TestCreateHandler_Success() {
+	start := time.Date(2030, time.March, 1, 8, 0, 0, 0, time.UTC)
+	payload := entity.Maintenance{RoomId: floorId, AssigneeId: siteId, Reason: "Replace projector", StartTime: start, EndTime: start.Add(4 * time.Hour)}
+	created := payload
+	created.ID = maintenanceId
+	created.Conflicts = []entity.Transaction{{ID: "t1", Status: "accepted"}}
+	suite.mum.On("RegisterMaintenance", mock.Anything, payload).Return(created, nil)
+
+	handlerFunc := NewMaintenanceController(suite.mum, suite.rg, suite.amm)
+	request, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/maintenance", apiGroup), strings.NewReader(fmt.Sprintf(`{"roomId": "%s", "assigneeId": "%s", "reason": "Replace projector", "startTime": "2030-03-01T08:00:00Z", "endTime": "2030-03-01T12:00:00Z"}`, floorId, siteId)))
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	handlerFunc.createHandler(c)
+
+	assert.Equal(suite.T(), http.StatusCreated, responseRecorder.Code)
+	assert.Contains(suite.T(), responseRecorder.Body.String(), `"conflicts":[{"id":"t1"`)
+}
+
+func (suite *MaintenanceControllerTestSuite) TestCreateHandler_InvalidFailure() {
+	handlerFunc := NewMaintenanceController(suite.mum, suite.rg, suite.amm)
+	request, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/maintenance", apiGroup), strings.NewReader(fmt.Sprintf(`{"roomId": "%s", "reason": "Replace projector", "startTime": "2030-03-01T08:00:00Z", "endTime": "2030-03-01T07:00:00Z"}`, floorId)))
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	handlerFunc.createHandler(c)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, responseRecorder.Code)
+	assert.Contains(suite.T(), responseRecorder.Body.String(), `"field":"assigneeId"`)
+	assert.Contains(suite.T(), responseRecorder.Body.String(), `"field":"endTime"`)
+}
+
+func (suite *MaintenanceControllerTestSuite) TestListHandler_Success() {
+	filter := entity.MaintenanceFilter{RoomId: floorId, Open: true}
+	suite.mum.On("FindAllMaintenance", mock.Anything, filter, 1, 5).Return([]entity.Maintenance{{ID: maintenanceId}}, model.Paging{Page: 1, RowsPerPage: 5, TotalRows: 1, TotalPages: 1}, nil)
+
+	handlerFunc := NewMaintenanceController(suite.mum, suite.rg, suite.amm)
+	request, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/maintenance?roomId=%s&open=true", apiGroup, floorId), nil)
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	handlerFunc.listHandler(c)
+
+	assert.Equal(suite.T(), http.StatusOK, responseRecorder.Code)
+}
+
+func (suite *MaintenanceControllerTestSuite) TestConflictsHandler_Success() {
+	suite.mum.On("FindConflicts", mock.Anything).Return([]entity.MaintenanceConflict{{MaintenanceId: maintenanceId, Reason: "Replace projector", Transaction: entity.Transaction{ID: "t1"}}}, nil)
+
+	handlerFunc := NewMaintenanceController(suite.mum, suite.rg, suite.amm)
+	request, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/maintenance/conflicts", apiGroup), nil)
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	handlerFunc.conflictsHandler(c)
+
+	assert.Equal(suite.T(), http.StatusOK, responseRecorder.Code)
+	assert.Contains(suite.T(), responseRecorder.Body.String(), maintenanceId)
+}
+
+func (suite *MaintenanceControllerTestSuite) TestUpdateHandler_CompletedFailure() {
+	start := time.Date(2030, time.March, 1, 8, 0, 0, 0, time.UTC)
+	payload := entity.Maintenance{ID: maintenanceId, AssigneeId: siteId, Reason: "Replace projector", StartTime: start, EndTime: start.Add(time.Hour)}
+	suite.mum.On("UpdateMaintenance", mock.Anything, payload).Return(entity.Maintenance{}, usecase.ErrMaintenanceCompleted)
+
+	handlerFunc := NewMaintenanceController(suite.mum, suite.rg, suite.amm)
+	request, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/maintenance", apiGroup), strings.NewReader(fmt.Sprintf(`{"id": "%s", "assigneeId": "%s", "reason": "Replace projector", "startTime": "2030-03-01T08:00:00Z", "endTime": "2030-03-01T09:00:00Z"}`, maintenanceId, siteId)))
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	handlerFunc.updateHandler(c)
+
+	assert.Equal(suite.T(), http.StatusConflict, responseRecorder.Code)
+	assert.Contains(suite.T(), responseRecorder.Body.String(), "maintenance_completed")
+}
+
+func (suite *MaintenanceControllerTestSuite) TestDeleteHandler_Success() {
+	suite.mum.On("DeleteMaintenance", mock.Anything, maintenanceId).Return(nil)
+
+	handlerFunc := NewMaintenanceController(suite.mum, suite.rg, suite.amm)
+	request, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/maintenance/%s", apiGroup, maintenanceId), nil)
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	c.Params = gin.Params{{Key: "id", Value: maintenanceId}}
+	handlerFunc.deleteHandler(c)
+
+	assert.Equal(suite.T(), http.StatusNoContent, c.Writer.Status())
+}
+
+func TestMaintenanceControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(MaintenanceControllerTestSuite))
+}
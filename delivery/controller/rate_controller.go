@@ -0,0 +1,86 @@
+package controller
+
+import (
+	"booking-room-app/config"
+	"booking-room-app/delivery/middleware"
+	"booking-room-app/entity/dto"
+	"booking-room-app/shared/common"
+	"booking-room-app/usecase"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RateController struct {
+	rateUC         usecase.RateUseCase
+	rg             *gin.RouterGroup
+	authMiddleware middleware.AuthMiddleware
+}
+
+func (r *RateController) createRoomRateHandler(c *gin.Context) {
+	roomId, err := common.ParamUUID(c, "id")
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	var payload dto.RoomRateRequestDto
+	if err := common.BindJSON(c, &payload); err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	rate, err := r.rateUC.RegisterRoomRate(c.Request.Context(), payload.Entity(roomId))
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendCreateResponse(c, rate, "Created")
+}
+
+func (r *RateController) listRoomRatesHandler(c *gin.Context) {
+	rates, err := r.rateUC.FindRoomRates(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendSingleResponse(c, rates, "Ok")
+}
+
+func (r *RateController) createFacilityRateHandler(c *gin.Context) {
+	facilityId, err := common.ParamUUID(c, "id")
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	var payload dto.FacilityRateRequestDto
+	if err := common.BindJSON(c, &payload); err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	rate, err := r.rateUC.RegisterFacilityRate(c.Request.Context(), payload.Entity(facilityId))
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendCreateResponse(c, rate, "Created")
+}
+
+func (r *RateController) listFacilityRatesHandler(c *gin.Context) {
+	rates, err := r.rateUC.FindFacilityRates(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendSingleResponse(c, rates, "Ok")
+}
+
+func (r *RateController) Route() {
+	r.rg.POST(config.RoomRateCreate, r.authMiddleware.RequireToken("admin"), r.createRoomRateHandler)
+	r.rg.GET(config.RoomRateList, r.authMiddleware.RequireToken("admin", "ga"), r.listRoomRatesHandler)
+	r.rg.POST(config.FacilityRateCreate, r.authMiddleware.RequireToken("admin"), r.createFacilityRateHandler)
+	r.rg.GET(config.FacilityRateList, r.authMiddleware.RequireToken("admin", "ga"), r.listFacilityRatesHandler)
+}
+
+func NewRateController(rateUC usecase.RateUseCase, rg *gin.RouterGroup, authMiddleware middleware.AuthMiddleware) *RateController {
+	return &RateController{rateUC: rateUC, rg: rg, authMiddleware: authMiddleware}
+}
@@ -0,0 +1,106 @@
+package controller
+
+import (
+	"booking-room-app/entity"
+	"booking-room-app/mock/middleware_mock"
+	"booking-room-app/mock/usecase_mock"
+	"booking-room-app/shared/apperror"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type RateControllerTestSuite struct {
+	suite.Suite
+	rg  *gin.RouterGroup
+	rum *usecase_mock.RateUseCaseMock
+	amm *middleware_mock.AuthMiddlewareMock
+}
+
+func (suite *RateControllerTestSuite) SetupTest() {
+	suite.rum = new(usecase_mock.RateUseCaseMock)
+	router := gin.Default()
+	gin.SetMode(gin.TestMode)
+	suite.rg = router.Group(apiGroup)
+}
+
+func (suite *RateControllerTestSuite) TestCreateRoomRateHandler_Success() {
+	payload := entity.RoomRate{RoomId: "6f1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d", HourlyRate: 150000, EffectiveFrom: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)}
+	suite.rum.On("RegisterRoomRate", mock.Anything, payload).Return(payload, nil)
+
+	handlerFunc := NewRateController(suite.rum, suite.rg, suite.amm)
+	handlerFunc.Route()
+
+	request, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/rooms/6f1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d/rates", apiGroup), strings.NewReader(`{"hourlyRate": 150000, "effectiveFrom": "2024-01-01T00:00:00Z"}`))
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	c.Params = gin.Params{{Key: "id", Value: "6f1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d"}}
+	handlerFunc.createRoomRateHandler(c)
+
+	assert.Equal(suite.T(), http.StatusCreated, responseRecorder.Code)
+}
+
+func (suite *RateControllerTestSuite) TestCreateFacilityRateHandler_BadRequest() {
+	suite.rum.On("RegisterFacilityRate", mock.Anything, entity.FacilityRate{FacilityId: "7a2b3c4d-5e6f-4b7c-9d8e-0f1a2b3c4d5e", UnitRate: -1}).Return(entity.FacilityRate{}, apperror.Validation("unit rate must not be negative"))
+
+	handlerFunc := NewRateController(suite.rum, suite.rg, suite.amm)
+	handlerFunc.Route()
+
+	request, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/facilities/7a2b3c4d-5e6f-4b7c-9d8e-0f1a2b3c4d5e/rates", apiGroup), strings.NewReader(`{"unitRate": -1}`))
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	c.Params = gin.Params{{Key: "id", Value: "7a2b3c4d-5e6f-4b7c-9d8e-0f1a2b3c4d5e"}}
+	handlerFunc.createFacilityRateHandler(c)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, responseRecorder.Code)
+}
+
+func (suite *RateControllerTestSuite) TestListRoomRatesHandler_Success() {
+	suite.rum.On("FindRoomRates", mock.Anything, "1").Return([]entity.RoomRate{{ID: "1", RoomId: "6f1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d", HourlyRate: 150000}}, nil)
+
+	handlerFunc := NewRateController(suite.rum, suite.rg, suite.amm)
+	handlerFunc.Route()
+
+	request, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/rooms/6f1b2c3d-4e5f-4a6b-8c7d-9e0f1a2b3c4d/rates", apiGroup), nil)
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	c.Params = gin.Params{{Key: "id", Value: "1"}}
+	handlerFunc.listRoomRatesHandler(c)
+
+	assert.Equal(suite.T(), http.StatusOK, responseRecorder.Code)
+}
+
+func (suite *RateControllerTestSuite) TestListFacilityRatesHandler_Failure() {
+	suite.rum.On("FindFacilityRates", mock.Anything, "1").Return([]entity.FacilityRate{}, fmt.Errorf("error"))
+
+	handlerFunc := NewRateController(suite.rum, suite.rg, suite.amm)
+	handlerFunc.Route()
+
+	request, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/facilities/7a2b3c4d-5e6f-4b7c-9d8e-0f1a2b3c4d5e/rates", apiGroup), nil)
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	c.Params = gin.Params{{Key: "id", Value: "1"}}
+	handlerFunc.listFacilityRatesHandler(c)
+
+	assert.Equal(suite.T(), http.StatusInternalServerError, responseRecorder.Code)
+}
+
+func TestRateControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(RateControllerTestSuite))
+}
@@ -1,11 +1,16 @@
 package controller
 
 import (
+	"booking-room-app/config"
 	"booking-room-app/delivery/middleware"
+	"booking-room-app/entity"
+	"booking-room-app/entity/dto"
+	"booking-room-app/shared/apperror"
 	"booking-room-app/shared/common"
 	"booking-room-app/usecase"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -20,13 +25,30 @@ func (r *ReportController) downloadHandler(c *gin.Context) {
 	rangeParam := c.Query("range")
 
 	if rangeParam == "" || (rangeParam != "day" && rangeParam != "week" && rangeParam != "month" && rangeParam != "year") {
-		common.SendErrorResponse(c, http.StatusBadRequest, "Invalid range parameter")
+		common.SendErrorResponse(c, apperror.Validation("invalid range parameter", apperror.Field("range", "oneof", "range must be day, week, month or year")))
 		return
 	}
 
-	_, err := r.reportUC.PrintAllReports(rangeParam)
+	var location dto.LocationFilterDto
+	if err := common.BindQuery(c, &location); err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	if filter := location.Entity(); !filter.Empty() {
+		// only the bookings of rooms at the location
+		content, err := r.reportUC.ExportReports(c.Request.Context(), rangeParam, entity.ReportFilter{LocationFilter: filter}, usecase.ReportFormatCSV)
+		if err != nil {
+			common.SendErrorResponse(c, err)
+			return
+		}
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%s", "transaction.csv"))
+		c.Data(http.StatusOK, "text/csv", content)
+		return
+	}
+
+	_, err := r.reportUC.PrintAllReports(c.Request.Context(), rangeParam)
 	if err != nil {
-		common.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		common.SendErrorResponse(c, err)
 		return
 	}
 
@@ -35,8 +57,64 @@ func (r *ReportController) downloadHandler(c *gin.Context) {
 	c.File("public/transaction.csv")
 }
 
+// chargebackHandler returns the cost per division and period as JSON, or as
+// a file download when format is given.
+func (r *ReportController) chargebackHandler(c *gin.Context) {
+	now := time.Now()
+	startDate := c.DefaultQuery("startDate", time.Date(now.Year(), now.Month(), 1, 0, 0, 0, 0, time.Local).Format("2006-01-02"))
+	endDate := c.DefaultQuery("endDate", now.Format("2006-01-02"))
+	period := c.DefaultQuery("period", "month")
+	format := c.Query("format")
+
+	startDateTime, err := time.Parse("2006-01-02", startDate)
+	if err != nil {
+		common.SendErrorResponse(c, invalidDate("startDate", err))
+		return
+	}
+	endDateTime, err := time.Parse("2006-01-02", endDate)
+	if err != nil {
+		common.SendErrorResponse(c, invalidDate("endDate", err))
+		return
+	}
+	// include the whole end day
+	endDateTime = endDateTime.AddDate(0, 0, 1).Add(-time.Second)
+
+	if format == "" {
+		chargebacks, err := r.reportUC.ChargebackReport(c.Request.Context(), startDateTime, endDateTime, period)
+		if err != nil {
+			common.SendErrorResponse(c, err)
+			return
+		}
+		common.SendSingleResponse(c, chargebacks, "Ok")
+		return
+	}
+
+	if format != usecase.ReportFormatCSV && format != usecase.ReportFormatJSON {
+		common.SendErrorResponse(c, apperror.Validation("invalid format parameter", apperror.Field("format", "oneof", "format must be csv or json")))
+		return
+	}
+	content, err := r.reportUC.ExportChargeback(c.Request.Context(), startDateTime, endDateTime, period, format)
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	contentType := "text/csv"
+	if format == usecase.ReportFormatJSON {
+		contentType = "application/json"
+	}
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=chargeback-%s-%s.%s", startDate, endDate, format))
+	c.Data(http.StatusOK, contentType, content)
+}
+
+// invalidDate reports a query parameter that is not a YYYY-MM-DD date.
+func invalidDate(param string, err error) error {
+	return apperror.Validation("invalid "+param+" format", apperror.Field(param, "date", param+" must be a date in the format YYYY-MM-DD")).Wrap(err)
+}
+
 func (r *ReportController) Route() {
-	r.rg.GET("/reports/download", r.authMiddleware.RequireToken("admin"), r.downloadHandler)
+	r.rg.GET(config.ReportDownload, r.authMiddleware.RequireToken("admin"), r.downloadHandler)
+	r.rg.GET(config.ReportChargeback, r.authMiddleware.RequireToken("admin"), r.chargebackHandler)
 }
 
 func NewReportController(reportUC usecase.ReportUseCase, rg *gin.RouterGroup, authMiddleware middleware.AuthMiddleware) *ReportController {
@@ -13,6 +13,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -60,7 +61,7 @@ func (suite *ReportControllerTestSuite) SetupTest() {
 }
 
 func (suite *ReportControllerTestSuite) TestDownloadHandler_Success() {
-	suite.rum.On("PrintAllReports", "day").Return(expectedReport, nil)
+	suite.rum.On("PrintAllReports", mock.Anything, "day").Return(expectedReport, nil)
 
 	handlerFunc := NewReportController(suite.rum, suite.rg, suite.amm)
 	handlerFunc.Route()
@@ -77,7 +78,7 @@ func (suite *ReportControllerTestSuite) TestDownloadHandler_Success() {
 }
 
 func (suite *ReportControllerTestSuite) TestDownloadHandler_Failure() {
-	suite.rum.On("PrintAllReports", "day").Return(expectedReport, fmt.Errorf("error"))
+	suite.rum.On("PrintAllReports", mock.Anything, "day").Return(expectedReport, fmt.Errorf("error"))
 
 	handlerFunc := NewReportController(suite.rum, suite.rg, suite.amm)
 	handlerFunc.Route()
@@ -93,7 +94,7 @@ func (suite *ReportControllerTestSuite) TestDownloadHandler_Failure() {
 }
 
 func (suite *ReportControllerTestSuite) TestDownloadHandler_EmptyRangeFailure() {
-	suite.rum.On("PrintAllReports", "da").Return(expectedReport, fmt.Errorf("error"))
+	suite.rum.On("PrintAllReports", mock.Anything, "da").Return(expectedReport, fmt.Errorf("error"))
 
 	handlerFunc := NewReportController(suite.rum, suite.rg, suite.amm)
 	handlerFunc.Route()
@@ -108,6 +109,58 @@ func (suite *ReportControllerTestSuite) TestDownloadHandler_EmptyRangeFailure()
 	assert.Equal(suite.T(), http.StatusBadRequest, responseRecorder.Code)
 }
 
+func (suite *ReportControllerTestSuite) TestChargebackHandler_Success() {
+	startDate := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2024, time.January, 31, 23, 59, 59, 0, time.UTC)
+	suite.rum.On("ChargebackReport", mock.Anything, startDate, endDate, "month").Return([]dto.ChargebackDto{{Division: "IT", Period: startDate, TotalCost: 100000}}, nil)
+
+	handlerFunc := NewReportController(suite.rum, suite.rg, suite.amm)
+	handlerFunc.Route()
+
+	request, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/reports/chargeback?startDate=2024-01-01&endDate=2024-01-31", apiGroup), nil)
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	handlerFunc.chargebackHandler(c)
+
+	assert.Equal(suite.T(), http.StatusOK, responseRecorder.Code)
+}
+
+func (suite *ReportControllerTestSuite) TestChargebackHandler_ExportSuccess() {
+	startDate := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2024, time.January, 31, 23, 59, 59, 0, time.UTC)
+	suite.rum.On("ExportChargeback", mock.Anything, startDate, endDate, "week", "csv").Return([]byte("Divisi\n"), nil)
+
+	handlerFunc := NewReportController(suite.rum, suite.rg, suite.amm)
+	handlerFunc.Route()
+
+	request, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/reports/chargeback?startDate=2024-01-01&endDate=2024-01-31&period=week&format=csv", apiGroup), nil)
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	handlerFunc.chargebackHandler(c)
+
+	assert.Equal(suite.T(), http.StatusOK, responseRecorder.Code)
+	assert.Equal(suite.T(), "text/csv", responseRecorder.Header().Get("Content-Type"))
+	assert.Contains(suite.T(), responseRecorder.Header().Get("Content-Disposition"), "chargeback-2024-01-01-2024-01-31.csv")
+}
+
+func (suite *ReportControllerTestSuite) TestChargebackHandler_InvalidDateFailure() {
+	handlerFunc := NewReportController(suite.rum, suite.rg, suite.amm)
+	handlerFunc.Route()
+
+	request, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/reports/chargeback?startDate=01-01-2024", apiGroup), nil)
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	handlerFunc.chargebackHandler(c)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, responseRecorder.Code)
+}
+
 func TestReportControllerTestSuite(t *testing.T) {
 	suite.Run(t, new(ReportControllerTestSuite))
 }
@@ -0,0 +1,96 @@
+package controller
+
+import (
+	"booking-room-app/config"
+	"booking-room-app/delivery/middleware"
+	"booking-room-app/entity/dto"
+	"booking-room-app/shared/common"
+	"booking-room-app/usecase"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type ReportScheduleController struct {
+	reportScheduleUC usecase.ReportScheduleUseCase
+	rg               *gin.RouterGroup
+	authMiddleware   middleware.AuthMiddleware
+}
+
+func (r *ReportScheduleController) createHandler(c *gin.Context) {
+	var payload dto.ReportScheduleRequestDto
+	if err := common.BindJSON(c, &payload); err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	schedule, err := r.reportScheduleUC.RegisterNewSchedule(c.Request.Context(), payload.Entity())
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendCreateResponse(c, schedule, "Created")
+}
+
+func (r *ReportScheduleController) getHandler(c *gin.Context) {
+	id := c.Param("id")
+	schedule, err := r.reportScheduleUC.FindScheduleByID(c.Request.Context(), id)
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendSingleResponse(c, schedule, "Ok")
+}
+
+func (r *ReportScheduleController) listHandler(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	size, _ := strconv.Atoi(c.DefaultQuery("size", "5"))
+
+	schedules, paging, err := r.reportScheduleUC.FindAllSchedules(c.Request.Context(), page, size)
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	var response []interface{}
+	for _, v := range schedules {
+		response = append(response, v)
+	}
+	common.SendPagedResponse(c, response, paging, "Ok")
+}
+
+func (r *ReportScheduleController) updateHandler(c *gin.Context) {
+	var payload dto.UpdateReportScheduleRequestDto
+	if err := common.BindJSON(c, &payload); err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	schedule, err := r.reportScheduleUC.UpdateSchedule(c.Request.Context(), payload.Entity())
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendSingleResponse(c, schedule, "Updated")
+}
+
+func (r *ReportScheduleController) deleteHandler(c *gin.Context) {
+	id := c.Param("id")
+	if err := r.reportScheduleUC.DeleteSchedule(c.Request.Context(), id); err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendNoContentResponse(c)
+}
+
+func (r *ReportScheduleController) Route() {
+	r.rg.POST(config.ReportScheduleCreate, r.authMiddleware.RequireToken("admin"), r.createHandler)
+	r.rg.GET(config.ReportScheduleList, r.authMiddleware.RequireToken("admin"), r.listHandler)
+	r.rg.GET(config.ReportScheduleGetById, r.authMiddleware.RequireToken("admin"), r.getHandler)
+	r.rg.PUT(config.ReportScheduleUpdate, r.authMiddleware.RequireToken("admin"), r.updateHandler)
+	r.rg.DELETE(config.ReportScheduleDelete, r.authMiddleware.RequireToken("admin"), r.deleteHandler)
+}
+
+func NewReportScheduleController(reportScheduleUC usecase.ReportScheduleUseCase, rg *gin.RouterGroup, authMiddleware middleware.AuthMiddleware) *ReportScheduleController {
+	return &ReportScheduleController{reportScheduleUC: reportScheduleUC, rg: rg, authMiddleware: authMiddleware}
+}
@@ -0,0 +1,143 @@
+package controller
+
+import (
+	"booking-room-app/entity"
+	"booking-room-app/mock/middleware_mock"
+	"booking-room-app/mock/usecase_mock"
+	"booking-room-app/shared/apperror"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+var expectedReportSchedule = entity.ReportSchedule{
+	ID:             "1",
+	Name:           "Weekly booking report",
+	CronExpression: "0 8 * * 1",
+	Range:          "week",
+	Format:         "csv",
+	Recipients:     []string{"manager@example.com"},
+	IsActive:       true,
+}
+
+var reportScheduleBody = `{
+	"name": "Weekly booking report",
+	"cronExpression": "0 8 * * 1",
+	"range": "week",
+	"format": "csv",
+	"recipients": ["manager@example.com"]
+}`
+
+type ReportScheduleControllerTestSuite struct {
+	suite.Suite
+	rg   *gin.RouterGroup
+	rsum *usecase_mock.ReportScheduleUseCaseMock
+	amm  *middleware_mock.AuthMiddlewareMock
+}
+
+func (suite *ReportScheduleControllerTestSuite) SetupTest() {
+	suite.rsum = new(usecase_mock.ReportScheduleUseCaseMock)
+	router := gin.Default()
+	gin.SetMode(gin.TestMode)
+	suite.rg = router.Group(apiGroup)
+}
+
+func (suite *ReportScheduleControllerTestSuite) TestCreateHandler_Success() {
+	payload := expectedReportSchedule
+	payload.ID = ""
+	payload.IsActive = false
+	suite.rsum.On("RegisterNewSchedule", mock.Anything, payload).Return(expectedReportSchedule, nil)
+
+	handlerFunc := NewReportScheduleController(suite.rsum, suite.rg, suite.amm)
+	handlerFunc.Route()
+
+	request, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/reports/schedules", apiGroup), strings.NewReader(reportScheduleBody))
+	assert.NoError(suite.T(), err)
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	handlerFunc.createHandler(c)
+
+	assert.Equal(suite.T(), http.StatusCreated, responseRecorder.Code)
+}
+
+func (suite *ReportScheduleControllerTestSuite) TestCreateHandler_BadRequest() {
+	payload := expectedReportSchedule
+	payload.ID = ""
+	payload.IsActive = false
+	suite.rsum.On("RegisterNewSchedule", mock.Anything, payload).Return(entity.ReportSchedule{}, apperror.Required("name"))
+
+	handlerFunc := NewReportScheduleController(suite.rsum, suite.rg, suite.amm)
+	handlerFunc.Route()
+
+	request, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/reports/schedules", apiGroup), strings.NewReader(reportScheduleBody))
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	handlerFunc.createHandler(c)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, responseRecorder.Code)
+}
+
+func (suite *ReportScheduleControllerTestSuite) TestListHandler_Success() {
+	suite.rsum.On("FindAllSchedules", mock.Anything, 1, 5).Return([]entity.ReportSchedule{expectedReportSchedule}, expectedPaging, nil)
+
+	handlerFunc := NewReportScheduleController(suite.rsum, suite.rg, suite.amm)
+	handlerFunc.Route()
+
+	request, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/reports/schedules", apiGroup), nil)
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	handlerFunc.listHandler(c)
+
+	assert.Equal(suite.T(), http.StatusOK, responseRecorder.Code)
+}
+
+func (suite *ReportScheduleControllerTestSuite) TestGetHandler_NotFound() {
+	suite.rsum.On("FindScheduleByID", mock.Anything, "1").Return(entity.ReportSchedule{}, apperror.NotFound("report schedule"))
+
+	handlerFunc := NewReportScheduleController(suite.rsum, suite.rg, suite.amm)
+	handlerFunc.Route()
+
+	request, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/reports/schedules/1", apiGroup), nil)
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	c.Params = gin.Params{{Key: "id", Value: "1"}}
+	handlerFunc.getHandler(c)
+
+	assert.Equal(suite.T(), http.StatusNotFound, responseRecorder.Code)
+}
+
+func (suite *ReportScheduleControllerTestSuite) TestDeleteHandler_Success() {
+	suite.rsum.On("DeleteSchedule", mock.Anything, "1").Return(nil)
+
+	handlerFunc := NewReportScheduleController(suite.rsum, suite.rg, suite.amm)
+	handlerFunc.Route()
+
+	request, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("%s/reports/schedules/1", apiGroup), nil)
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	c.Params = gin.Params{{Key: "id", Value: "1"}}
+	handlerFunc.deleteHandler(c)
+
+	assert.Equal(suite.T(), http.StatusNoContent, c.Writer.Status())
+}
+
+func TestReportScheduleControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(ReportScheduleControllerTestSuite))
+}
@@ -0,0 +1,146 @@
+package controller
+
+import (
+	"booking-room-app/config"
+	"booking-room-app/delivery/middleware"
+	"booking-room-app/entity/dto"
+	"booking-room-app/shared/common"
+	"booking-room-app/usecase"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type RoomAttributeController struct {
+	roomAttributeUC usecase.RoomAttributeUseCase
+	rg              *gin.RouterGroup
+	authMiddleware  middleware.AuthMiddleware
+}
+
+func (r *RoomAttributeController) createHandler(c *gin.Context) {
+	var payload dto.RoomAttributeRequestDto
+	if err := common.BindJSON(c, &payload); err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	attribute, err := r.roomAttributeUC.RegisterAttribute(c.Request.Context(), payload.Entity())
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendCreateResponse(c, attribute, "Created")
+}
+
+func (r *RoomAttributeController) listHandler(c *gin.Context) {
+	attributes, err := r.roomAttributeUC.FindAllAttributes(c.Request.Context())
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendSingleResponse(c, attributes, "Ok")
+}
+
+func (r *RoomAttributeController) updateHandler(c *gin.Context) {
+	var payload dto.UpdateRoomAttributeRequestDto
+	if err := common.BindJSON(c, &payload); err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	attribute, err := r.roomAttributeUC.UpdateAttribute(c.Request.Context(), payload.Entity())
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendSingleResponse(c, attribute, "Updated")
+}
+
+func (r *RoomAttributeController) deleteHandler(c *gin.Context) {
+	id, err := common.ParamUUID(c, "id")
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	if err := r.roomAttributeUC.DeleteAttribute(c.Request.Context(), id); err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendNoContentResponse(c)
+}
+
+func (r *RoomAttributeController) getRoomAttributesHandler(c *gin.Context) {
+	id, err := common.ParamUUID(c, "id")
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	attributes, err := r.roomAttributeUC.FindRoomAttributes(c.Request.Context(), id)
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendSingleResponse(c, attributes, "Ok")
+}
+
+func (r *RoomAttributeController) updateRoomAttributesHandler(c *gin.Context) {
+	id, err := common.ParamUUID(c, "id")
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	var payload dto.RoomAttributesRequestDto
+	if err := common.BindJSON(c, &payload); err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	attributes, err := r.roomAttributeUC.ReplaceRoomAttributes(c.Request.Context(), id, payload.Attributes)
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendSingleResponse(c, attributes, "Updated")
+}
+
+// searchHandler finds rooms by text, status, type, capacity, location and
+// attr.<key> filters, and counts the matches by value next to the page.
+func (r *RoomAttributeController) searchHandler(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	size, _ := strconv.Atoi(c.DefaultQuery("size", "5"))
+	var query dto.RoomSearchQueryDto
+	if err := common.BindQuery(c, &query); err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	search := query.Entity()
+	search.Attributes = dto.AttributeFilters(c.Request.URL.Query())
+
+	rooms, facets, paging, err := r.roomAttributeUC.SearchRooms(c.Request.Context(), search, page, size)
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	var response []interface{}
+	for _, v := range rooms {
+		response = append(response, v)
+	}
+	common.SendSearchResponse(c, response, paging, facets, "Ok")
+}
+
+func (r *RoomAttributeController) Route() {
+	r.rg.POST(config.RoomAttributeCreate, r.authMiddleware.RequireToken("admin"), r.createHandler)
+	r.rg.GET(config.RoomAttributeList, r.authMiddleware.RequireToken("employee", "admin", "ga"), r.listHandler)
+	r.rg.PUT(config.RoomAttributeUpdate, r.authMiddleware.RequireToken("admin"), r.updateHandler)
+	r.rg.DELETE(config.RoomAttributeDelete, r.authMiddleware.RequireToken("admin"), r.deleteHandler)
+	r.rg.GET(config.RoomAttributesGet, r.authMiddleware.RequireToken("employee", "admin", "ga"), r.getRoomAttributesHandler)
+	r.rg.PUT(config.RoomAttributesUpdate, r.authMiddleware.RequireToken("admin"), r.updateRoomAttributesHandler)
+	r.rg.GET(config.RoomSearch, r.authMiddleware.RequireToken("employee", "admin", "ga"), r.searchHandler)
+}
+
+func NewRoomAttributeController(roomAttributeUC usecase.RoomAttributeUseCase, rg *gin.RouterGroup, authMiddleware middleware.AuthMiddleware) *RoomAttributeController {
+	return &RoomAttributeController{roomAttributeUC: roomAttributeUC, rg: rg, authMiddleware: authMiddleware}
+}
@@ -0,0 +1,161 @@
+package controller
+
+import (
+	"booking-room-app/entity"
+	"booking-room-app/mock/middleware_mock"
+	"booking-room-app/mock/usecase_mock"
+	"booking-room-app/shared/apperror"
+	"booking-room-app/shared/model"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+const (
+	roomId          = "9c4d5e6f-7a8b-4d9e-8f0a-2b3c4d5e6f7a"
+	roomAttributeId = "0d5e6f7a-8b9c-4e0f-9a1b-3c4d5e6f7a8b"
+)
+
+type RoomAttributeControllerTestSuite struct {
+	suite.Suite
+	rg  *gin.RouterGroup
+	rum *usecase_mock.RoomAttributeUseCaseMock
+	amm *middleware_mock.AuthMiddlewareMock
+}
+
+func (suite *RoomAttributeControllerTestSuite) SetupTest() {
+	suite.rum = new(usecase_mock.RoomAttributeUseCaseMock)
+	router := gin.Default()
+	gin.SetMode(gin.TestMode)
+	suite.rg = router.Group(apiGroup)
+}
+
+func (suite *RoomAttributeControllerTestSuite) TestCreateHandler_Success() {
+	payload := entity.RoomAttribute{Key: "layout", Name: "Layout", Type: "enum", Options: []string{"boardroom", "classroom"}}
+	created := payload
+	created.ID = roomAttributeId
+	suite.rum.On("RegisterAttribute", mock.Anything, payload).Return(created, nil)
+
+	handlerFunc := NewRoomAttributeController(suite.rum, suite.rg, suite.amm)
+	request, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/roomattributes", apiGroup), strings.NewReader(`{"key": "layout", "name": "Layout", "type": "enum", "options": ["boardroom", "classroom"]}`))
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	handlerFunc.createHandler(c)
+
+	assert.Equal(suite.T(), http.StatusCreated, responseRecorder.Code)
+}
+
+func (suite *RoomAttributeControllerTestSuite) TestCreateHandler_InvalidTypeFailure() {
+	handlerFunc := NewRoomAttributeController(suite.rum, suite.rg, suite.amm)
+	request, _ := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/roomattributes", apiGroup), strings.NewReader(`{"key": "opened", "name": "Opened", "type": "date"}`))
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	handlerFunc.createHandler(c)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, responseRecorder.Code)
+	assert.Contains(suite.T(), responseRecorder.Body.String(), `"field":"type"`)
+	suite.rum.AssertNotCalled(suite.T(), "RegisterAttribute", mock.Anything, mock.Anything)
+}
+
+func (suite *RoomAttributeControllerTestSuite) TestUpdateHandler_OptionInUseFailure() {
+	payload := entity.RoomAttribute{ID: roomAttributeId, Name: "Layout", Options: []string{"boardroom"}}
+	suite.rum.On("UpdateAttribute", mock.Anything, payload).Return(entity.RoomAttribute{}, apperror.Conflict("attribute_option_in_use", "rooms still use classroom"))
+
+	handlerFunc := NewRoomAttributeController(suite.rum, suite.rg, suite.amm)
+	request, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/roomattributes", apiGroup), strings.NewReader(fmt.Sprintf(`{"id": "%s", "name": "Layout", "options": ["boardroom"]}`, roomAttributeId)))
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	handlerFunc.updateHandler(c)
+
+	assert.Equal(suite.T(), http.StatusConflict, responseRecorder.Code)
+	assert.Contains(suite.T(), responseRecorder.Body.String(), `"error":"attribute_option_in_use"`)
+}
+
+func (suite *RoomAttributeControllerTestSuite) TestUpdateRoomAttributesHandler_Success() {
+	values := map[string]any{"whiteboard": true, "seats": float64(12), "layout": "boardroom"}
+	suite.rum.On("ReplaceRoomAttributes", mock.Anything, roomId, values).Return(values, nil)
+
+	handlerFunc := NewRoomAttributeController(suite.rum, suite.rg, suite.amm)
+	request, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/rooms/%s/attributes", apiGroup, roomId), strings.NewReader(`{"attributes": {"whiteboard": true, "seats": 12, "layout": "boardroom"}}`))
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	c.Params = gin.Params{{Key: "id", Value: roomId}}
+	handlerFunc.updateRoomAttributesHandler(c)
+
+	assert.Equal(suite.T(), http.StatusOK, responseRecorder.Code)
+}
+
+func (suite *RoomAttributeControllerTestSuite) TestSearchHandler_Success() {
+	search := entity.RoomSearch{
+		Query:       "melati",
+		MinCapacity: 6,
+		Location:    entity.LocationFilter{BuildingId: buildingId},
+		Sort:        "distance",
+		NearFloorId: floorId,
+		Attributes: []entity.AttributeFilter{
+			{Key: "layout", Values: []string{"boardroom", "u_shape"}},
+			{Key: "seats", Min: "10", Max: ""},
+			{Key: "whiteboard", Values: []string{"true"}},
+		},
+	}
+	distance := 1
+	items := []entity.RoomSearchItem{{Room: entity.Room{ID: roomId, Name: "Melati"}, Attributes: map[string]any{"whiteboard": true}, Distance: &distance}}
+	facets := entity.RoomFacets{Status: []entity.FacetCount{{Value: "available", Count: 1}}, Attributes: map[string][]entity.FacetCount{"whiteboard": {{Value: "true", Count: 1}}}}
+	paging := model.Paging{Page: 2, RowsPerPage: 10, TotalRows: 11, TotalPages: 2}
+	suite.rum.On("SearchRooms", mock.Anything, search, 2, 10).Return(items, facets, paging, nil)
+
+	handlerFunc := NewRoomAttributeController(suite.rum, suite.rg, suite.amm)
+	query := fmt.Sprintf("q=+melati+&minCapacity=6&buildingId=%s&sort=distance&nearFloorId=%s&attr.whiteboard=true&attr.layout=boardroom,u_shape&attr.seats=10..&page=2&size=10", buildingId, floorId)
+	request, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/rooms/search?%s", apiGroup, query), nil)
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	handlerFunc.searchHandler(c)
+
+	assert.Equal(suite.T(), http.StatusOK, responseRecorder.Code)
+	var response struct {
+		Data   []map[string]any  `json:"data"`
+		Paging model.Paging      `json:"paging"`
+		Facets entity.RoomFacets `json:"facets"`
+	}
+	assert.NoError(suite.T(), json.Unmarshal(responseRecorder.Body.Bytes(), &response))
+	assert.Equal(suite.T(), "Melati", response.Data[0]["name"])
+	assert.Equal(suite.T(), float64(1), response.Data[0]["distance"])
+	assert.Equal(suite.T(), paging, response.Paging)
+	assert.Equal(suite.T(), facets.Attributes, response.Facets.Attributes)
+}
+
+func (suite *RoomAttributeControllerTestSuite) TestSearchHandler_InvalidSortFailure() {
+	handlerFunc := NewRoomAttributeController(suite.rum, suite.rg, suite.amm)
+	request, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/rooms/search?sort=price", apiGroup), nil)
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	handlerFunc.searchHandler(c)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, responseRecorder.Code)
+	assert.Contains(suite.T(), responseRecorder.Body.String(), `"field":"sort"`)
+	suite.rum.AssertNotCalled(suite.T(), "SearchRooms", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRoomAttributeControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(RoomAttributeControllerTestSuite))
+}
@@ -3,10 +3,9 @@ package controller
 import (
 	"booking-room-app/config"
 	"booking-room-app/delivery/middleware"
-	"booking-room-app/entity"
+	"booking-room-app/entity/dto"
 	"booking-room-app/shared/common"
 	"booking-room-app/usecase"
-	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
@@ -19,21 +18,15 @@ type RoomFacilityController struct {
 }
 
 func (t *RoomFacilityController) createRoomFacilityHandler(ctx *gin.Context) {
-	var payload entity.RoomFacility
-	err := ctx.ShouldBindJSON(&payload)
-	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+	var payload dto.RoomFacilityRequestDto
+	if err := common.BindJSON(ctx, &payload); err != nil {
+		common.SendErrorResponse(ctx, err)
 		return
 	}
 
-	if payload.RoomId == "" || payload.FacilityId == "" || payload.Quantity == 0 {
-		common.SendErrorResponse(ctx, http.StatusBadRequest, "oops, field required")
-		return
-	}
-
-	transactions, err := t.transactionUC.AddRoomFacilityTransaction(payload)
+	transactions, err := t.transactionUC.AddRoomFacilityTransaction(ctx.Request.Context(), payload.Entity())
 	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		common.SendErrorResponse(ctx, err)
 		return
 	}
 	common.SendCreateResponse(ctx, transactions, "Created")
@@ -43,9 +36,9 @@ func (t *RoomFacilityController) listRoomFacilityHandler(ctx *gin.Context) {
 	page, _ := strconv.Atoi(ctx.Query("page"))
 	size, _ := strconv.Atoi(ctx.Query("size"))
 
-	transactions, paging, err := t.transactionUC.FindAllRoomFacility(page, size)
+	transactions, paging, err := t.transactionUC.FindAllRoomFacility(ctx.Request.Context(), page, size)
 	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		common.SendErrorResponse(ctx, err)
 		return
 	}
 
@@ -58,9 +51,9 @@ func (t *RoomFacilityController) listRoomFacilityHandler(ctx *gin.Context) {
 
 func (t *RoomFacilityController) getRoomFacilityById(ctx *gin.Context) {
 	id := ctx.Param("id")
-	transactions, err := t.transactionUC.FindRoomFacilityById(id)
+	transactions, err := t.transactionUC.FindRoomFacilityById(ctx.Request.Context(), id)
 	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusNotFound, "Roomfacilities with ID "+id+" not found")
+		common.SendErrorResponse(ctx, err)
 		return
 	}
 
@@ -68,23 +61,47 @@ func (t *RoomFacilityController) getRoomFacilityById(ctx *gin.Context) {
 }
 
 func (t *RoomFacilityController) updateRoomFacilityHandler(ctx *gin.Context) {
-	var payload entity.RoomFacility
-	if err := ctx.ShouldBindJSON(&payload); err != nil {
-		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+	var payload dto.UpdateRoomFacilityRequestDto
+	if err := common.BindJSON(ctx, &payload); err != nil {
+		common.SendErrorResponse(ctx, err)
 		return
 	}
 
-	if payload.FacilityId == "" && payload.RoomId == "" && payload.Quantity == 0 {
-		common.SendErrorResponse(ctx, http.StatusBadRequest, "oops, field required")
+	transactions, err := t.transactionUC.UpdateRoomFacilityTransaction(ctx.Request.Context(), payload.Entity())
+	if err != nil {
+		common.SendErrorResponse(ctx, err)
 		return
 	}
+	common.SendCreateResponse(ctx, transactions, "Updated")
+}
 
-	transactions, err := t.transactionUC.UpdateRoomFacilityTransaction(payload)
+func (t *RoomFacilityController) deleteRoomFacilityHandler(ctx *gin.Context) {
+	id, err := common.ParamUUID(ctx, "id")
 	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+		common.SendErrorResponse(ctx, err)
 		return
 	}
-	common.SendCreateResponse(ctx, transactions, "Updated")
+
+	if err := t.transactionUC.RemoveRoomFacility(ctx.Request.Context(), id); err != nil {
+		common.SendErrorResponse(ctx, err)
+		return
+	}
+	common.SendNoContentResponse(ctx)
+}
+
+func (t *RoomFacilityController) transferRoomFacilityHandler(ctx *gin.Context) {
+	var payload dto.RoomFacilityTransferRequestDto
+	if err := common.BindJSON(ctx, &payload); err != nil {
+		common.SendErrorResponse(ctx, err)
+		return
+	}
+
+	transfer, err := t.transactionUC.TransferRoomFacility(ctx.Request.Context(), payload.Entity())
+	if err != nil {
+		common.SendErrorResponse(ctx, err)
+		return
+	}
+	common.SendSingleResponse(ctx, transfer, "Transferred")
 }
 
 func (t *RoomFacilityController) Route() {
@@ -92,6 +109,8 @@ func (t *RoomFacilityController) Route() {
 	t.rg.GET(config.RoomFacilityGetById, t.authMiddleware.RequireToken("admin"), t.getRoomFacilityById)
 	t.rg.POST(config.RoomFacilityCreate, t.authMiddleware.RequireToken("admin"), t.createRoomFacilityHandler)
 	t.rg.PUT(config.RoomFacilityUpdate, t.authMiddleware.RequireToken("admin"), t.updateRoomFacilityHandler)
+	t.rg.DELETE(config.RoomFacilityDelete, t.authMiddleware.RequireToken("admin"), t.deleteRoomFacilityHandler)
+	t.rg.POST(config.RoomFacilityTransfer, t.authMiddleware.RequireToken("admin"), t.transferRoomFacilityHandler)
 }
 
 func NewRoomFacilityController(transactionUC usecase.RoomFacilityUsecase, rg *gin.RouterGroup, authMiddleware middleware.AuthMiddleware) *RoomFacilityController {
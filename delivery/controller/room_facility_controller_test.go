@@ -15,6 +15,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -52,7 +53,7 @@ func (suite *RoomFacilityControllerTestSuite) TestCreateRoomFacilityHandler_Succ
 	assert.NoError(suite.T(), err)
 
 	// call usecase mock
-	suite.rfum.On("AddRoomFacilityTransaction").Return(expectedRoomFacility, http.StatusCreated, nil)
+	suite.rfum.On("AddRoomFacilityTransaction", mock.Anything).Return(expectedRoomFacility, http.StatusCreated, nil)
 
 	// record response
 	responseRecorder := httptest.NewRecorder()
@@ -160,7 +161,7 @@ func (suite *RoomFacilityControllerTestSuite) TestCreateRoomFacilityHandler_AddR
 	assert.NoError(suite.T(), err)
 
 	// call usecase mock
-	suite.rfum.On("AddRoomFacilityTransaction").Return(expectedRoomFacility, http.StatusInternalServerError, fmt.Errorf("fail to add room-facility"))
+	suite.rfum.On("AddRoomFacilityTransaction", mock.Anything).Return(expectedRoomFacility, http.StatusInternalServerError, fmt.Errorf("fail to add room-facility"))
 
 	// record response
 	responseRecorder := httptest.NewRecorder()
@@ -192,7 +193,7 @@ func (suite *RoomFacilityControllerTestSuite) TestListRoomFacilityHandler_Succes
 	}
 
 	// call usecase mock
-	suite.rfum.On("FindAllRoomFacility").Return(mockRoomFacility, mockPaging, nil)
+	suite.rfum.On("FindAllRoomFacility", mock.Anything).Return(mockRoomFacility, mockPaging, nil)
 
 	// record response
 	responseRecorder := httptest.NewRecorder()
@@ -223,7 +224,7 @@ func (suite *RoomFacilityControllerTestSuite) TestListRoomFacilityHandler_FindAl
 	}
 
 	// call usecase mock
-	suite.rfum.On("FindAllRoomFacility").Return(mockRoomFacility, mockPaging, fmt.Errorf("failed to find all room-facility"))
+	suite.rfum.On("FindAllRoomFacility", mock.Anything).Return(mockRoomFacility, mockPaging, fmt.Errorf("failed to find all room-facility"))
 
 	// record response
 	responseRecorder := httptest.NewRecorder()
@@ -247,7 +248,7 @@ func (suite *RoomFacilityControllerTestSuite) TestGetRoomFacilityById_Success()
 	assert.NoError(suite.T(), err)
 
 	// call usecase mock
-	suite.rfum.On("FindRoomFacilityById").Return(expectedRoomFacility, http.StatusOK, nil)
+	suite.rfum.On("FindRoomFacilityById", mock.Anything).Return(expectedRoomFacility, http.StatusOK, nil)
 
 	// record response
 	responseRecorder := httptest.NewRecorder()
@@ -270,7 +271,7 @@ func (suite *RoomFacilityControllerTestSuite) TestGetRoomFacilityById_FindRoomFa
 	assert.NoError(suite.T(), err)
 
 	// call usecase mock
-	suite.rfum.On("FindRoomFacilityById").Return(expectedRoomFacility, http.StatusNotFound, fmt.Errorf("failed to find room-facility by id"))
+	suite.rfum.On("FindRoomFacilityById", mock.Anything).Return(expectedRoomFacility, http.StatusNotFound, fmt.Errorf("failed to find room-facility by id"))
 
 	// record response
 	responseRecorder := httptest.NewRecorder()
@@ -295,7 +296,7 @@ func (suite *RoomFacilityControllerTestSuite) TestUpdateRoomFacilityHandler_Succ
 	assert.NoError(suite.T(), err)
 
 	// call usecase mock
-	suite.rfum.On("UpdateRoomFacilityTransaction").Return(expectedRoomFacility, http.StatusCreated, nil)
+	suite.rfum.On("UpdateRoomFacilityTransaction", mock.Anything).Return(expectedRoomFacility, http.StatusCreated, nil)
 
 	// record response
 	responseRecorder := httptest.NewRecorder()
@@ -361,7 +362,7 @@ func (suite *RoomFacilityControllerTestSuite) TestUpdateRoomFacilityHandler_Upda
 	assert.NoError(suite.T(), err)
 
 	// call usecase mock
-	suite.rfum.On("UpdateRoomFacilityTransaction").Return(expectedRoomFacility, http.StatusInternalServerError, fmt.Errorf("fail to update room-facility"))
+	suite.rfum.On("UpdateRoomFacilityTransaction", mock.Anything).Return(expectedRoomFacility, http.StatusInternalServerError, fmt.Errorf("fail to update room-facility"))
 
 	// record response
 	responseRecorder := httptest.NewRecorder()
@@ -383,6 +384,58 @@ func (suite *RoomFacilityControllerTestSuite) TestRoute_Success() {
 	handlerFunc.Route()
 }
 
+const roomFacilityId = "3a8b9c0d-1e2f-4b3c-8d4e-6f7a8b9c0d1e"
+
+func (suite *RoomFacilityControllerTestSuite) TestDeleteRoomFacilityHandler_Success() {
+	suite.rfum.On("RemoveRoomFacility", mock.Anything, roomFacilityId).Return(nil)
+
+	handlerFunc := NewRoomFacilityController(suite.rfum, suite.rg, suite.amm)
+	request, _ := http.NewRequest(http.MethodDelete, fmt.Sprintf("/api/v1/roomfacilities/%s", roomFacilityId), nil)
+
+	responseRecorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(responseRecorder)
+	ctx.Request = request
+	ctx.Params = gin.Params{{Key: "id", Value: roomFacilityId}}
+	handlerFunc.deleteRoomFacilityHandler(ctx)
+
+	assert.Equal(suite.T(), http.StatusNoContent, ctx.Writer.Status())
+}
+
+func (suite *RoomFacilityControllerTestSuite) TestTransferRoomFacilityHandler_Success() {
+	payload := entity.RoomFacilityTransfer{RoomFacilityId: roomFacilityId, RoomId: roomId, Quantity: 2}
+	transfer := payload
+	transfer.Source = expectedRoomFacility
+	transfer.Target = entity.RoomFacility{ID: "target id", RoomId: roomId, FacilityId: expectedRoomFacility.FacilityId, Quantity: 2}
+	suite.rfum.On("TransferRoomFacility", mock.Anything, payload).Return(transfer, nil)
+
+	handlerFunc := NewRoomFacilityController(suite.rfum, suite.rg, suite.amm)
+	requestBody := fmt.Sprintf(`{"roomFacilityId": "%s", "roomId": "%s", "quantity": 2}`, roomFacilityId, roomId)
+	request, _ := http.NewRequest(http.MethodPost, "/api/v1/roomfacilities/transfer", strings.NewReader(requestBody))
+
+	responseRecorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(responseRecorder)
+	ctx.Request = request
+	handlerFunc.transferRoomFacilityHandler(ctx)
+
+	assert.Equal(suite.T(), http.StatusOK, responseRecorder.Code)
+	assert.Contains(suite.T(), responseRecorder.Body.String(), `"target":{"id":"target id"`)
+}
+
+func (suite *RoomFacilityControllerTestSuite) TestTransferRoomFacilityHandler_BindingFail() {
+	handlerFunc := NewRoomFacilityController(suite.rfum, suite.rg, suite.amm)
+	requestBody := fmt.Sprintf(`{"roomFacilityId": "%s", "roomId": "%s", "quantity": 0}`, roomFacilityId, roomId)
+	request, _ := http.NewRequest(http.MethodPost, "/api/v1/roomfacilities/transfer", strings.NewReader(requestBody))
+
+	responseRecorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(responseRecorder)
+	ctx.Request = request
+	handlerFunc.transferRoomFacilityHandler(ctx)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, responseRecorder.Code)
+	assert.Contains(suite.T(), responseRecorder.Body.String(), `"field":"quantity"`)
+	suite.rfum.AssertNotCalled(suite.T(), "TransferRoomFacility", mock.Anything, mock.Anything)
+}
+
 func TestRommFacilityControllerTestSuite(t *testing.T) {
 	suite.Run(t, new(RoomFacilityControllerTestSuite))
 }
@@ -4,11 +4,10 @@ import (
 	"booking-room-app/config"
 	"booking-room-app/delivery/middleware"
 	"booking-room-app/entity"
+	"booking-room-app/entity/dto"
 	"booking-room-app/shared/common"
 	"booking-room-app/shared/model"
 	"booking-room-app/usecase"
-	"fmt"
-	"net/http"
 	"strconv"
 
 	"github.com/gin-gonic/gin"
@@ -21,15 +20,15 @@ type RoomController struct {
 }
 
 func (r *RoomController) createHandler(c *gin.Context) {
-	var payload entity.Room
-	if err := c.ShouldBindJSON(&payload); err != nil {
-		common.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+	var payload dto.RoomRequestDto
+	if err := common.BindJSON(c, &payload); err != nil {
+		common.SendErrorResponse(c, err)
 		return
 	}
 
-	room, err := r.roomUC.RegisterNewRoom(payload)
+	room, err := r.roomUC.RegisterNewRoom(c.Request.Context(), payload.Entity())
 	if err != nil {
-		common.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		common.SendErrorResponse(c, err)
 		return
 	}
 	common.SendCreateResponse(c, room, "Created")
@@ -37,9 +36,9 @@ func (r *RoomController) createHandler(c *gin.Context) {
 
 func (r *RoomController) getHandler(c *gin.Context) {
 	id := c.Param("id")
-	room, err := r.roomUC.FindRoomByID(id)
+	room, err := r.roomUC.FindRoomByID(c.Request.Context(), id)
 	if err != nil {
-		common.SendErrorResponse(c, http.StatusNotFound, fmt.Sprintf("Room with ID %s not found", id))
+		common.SendErrorResponse(c, err)
 		return
 	}
 	common.SendSingleResponse(c, room, "Ok")
@@ -50,26 +49,52 @@ func (r *RoomController) listHandler(c *gin.Context) {
 	size, _ := strconv.Atoi(c.Query("size"))
 	status := c.Query("status")
 
+	var location dto.LocationFilterDto
+	if err := common.BindQuery(c, &location); err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	var period dto.PeriodQueryDto
+	if err := common.BindQuery(c, &period); err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
 	var rooms []entity.Room
 	var paging model.Paging
 	var err error
 
-	if status == "" {
+	if c.Query("archived") == "true" {
+		if page == 0 && size == 0 {
+			page, size = 1, 5
+		}
+		rooms, paging, err = r.roomUC.FindArchivedRooms(c.Request.Context(), page, size)
+	} else if !period.Empty() {
 		if page == 0 && size == 0 {
-			rooms, paging, err = r.roomUC.FindAllRoom(1, 5)
+			page, size = 1, 5
+		}
+		rooms, paging, err = r.roomUC.FindAvailableRooms(c.Request.Context(), location.Entity(), period.StartTime, period.EndTime, page, size)
+	} else if filter := location.Entity(); !filter.Empty() {
+		if page == 0 && size == 0 {
+			page, size = 1, 5
+		}
+		rooms, paging, err = r.roomUC.FindRoomsByLocation(c.Request.Context(), filter, status, page, size)
+	} else if status == "" {
+		if page == 0 && size == 0 {
+			rooms, paging, err = r.roomUC.FindAllRoom(c.Request.Context(), 1, 5)
 		} else {
-			rooms, paging, err = r.roomUC.FindAllRoom(page, size)
+			rooms, paging, err = r.roomUC.FindAllRoom(c.Request.Context(), page, size)
 		}
 	} else {
 		if page == 0 && size == 0 {
-			rooms, paging, err = r.roomUC.FindAllRoomStatus(status, 1, 5)
+			rooms, paging, err = r.roomUC.FindAllRoomStatus(c.Request.Context(), status, 1, 5)
 		} else {
-			rooms, paging, err = r.roomUC.FindAllRoomStatus(status, page, size)
+			rooms, paging, err = r.roomUC.FindAllRoomStatus(c.Request.Context(), status, page, size)
 		}
 	}
 
 	if err != nil {
-		common.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		common.SendErrorResponse(c, err)
 		return
 	}
 
@@ -81,40 +106,72 @@ func (r *RoomController) listHandler(c *gin.Context) {
 }
 
 func (r *RoomController) updateDetailHandler(c *gin.Context) {
-	var payload entity.Room
-	if err := c.ShouldBindJSON(&payload); err != nil {
-		common.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+	var payload dto.UpdateRoomRequestDto
+	if err := common.BindJSON(c, &payload); err != nil {
+		common.SendErrorResponse(c, err)
 		return
 	}
 
-	room, err := r.roomUC.UpdateRoomDetail(payload)
+	room, err := r.roomUC.UpdateRoomDetail(c.Request.Context(), payload.Entity())
 	if err != nil {
-		common.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+		common.SendErrorResponse(c, err)
 		return
 	}
 	common.SendCreateResponse(c, room, "Updated")
 }
 func (r *RoomController) updateStatusHandler(c *gin.Context) {
-	var payload entity.Room
-	if err := c.ShouldBindJSON(&payload); err != nil {
-		common.SendErrorResponse(c, http.StatusBadRequest, err.Error())
+	var payload dto.RoomStatusRequestDto
+	if err := common.BindJSON(c, &payload); err != nil {
+		common.SendErrorResponse(c, err)
 		return
 	}
 
-	room, err := r.roomUC.UpdateRoomStatus(payload)
+	room, err := r.roomUC.UpdateRoomStatus(c.Request.Context(), payload.Entity())
 	if err != nil {
-		common.SendErrorResponse(c, http.StatusInternalServerError, err.Error())
+		common.SendErrorResponse(c, err)
 		return
 	}
 	common.SendCreateResponse(c, room, "Ok")
 }
 
+func (r *RoomController) archiveHandler(c *gin.Context) {
+	id, err := common.ParamUUID(c, "id")
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	archival, err := r.roomUC.ArchiveRoom(c.Request.Context(), id)
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendSingleResponse(c, archival, "Archived")
+}
+
+func (r *RoomController) restoreHandler(c *gin.Context) {
+	id, err := common.ParamUUID(c, "id")
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	room, err := r.roomUC.RestoreRoom(c.Request.Context(), id)
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendSingleResponse(c, room, "Restored")
+}
+
 func (r *RoomController) Route() {
 	r.rg.POST(config.RoomCreate, r.authMiddleware.RequireToken("admin"), r.createHandler)
 	r.rg.GET(config.RoomList, r.authMiddleware.RequireToken("employee", "admin", "ga"), r.listHandler)
 	r.rg.GET(config.RoomGetById, r.authMiddleware.RequireToken("employee", "admin", "ga"), r.getHandler)
 	r.rg.PUT(config.RoomUpdate, r.authMiddleware.RequireToken("admin"), r.updateDetailHandler)
 	r.rg.PUT(config.RoomUpdateStatus, r.authMiddleware.RequireToken("admin", "ga"), r.updateStatusHandler)
+	r.rg.POST(config.RoomArchive, r.authMiddleware.RequireToken("admin"), r.archiveHandler)
+	r.rg.POST(config.RoomRestore, r.authMiddleware.RequireToken("admin"), r.restoreHandler)
 }
 
 func NewRoomController(roomUC usecase.RoomUseCase, authMiddleware middleware.AuthMiddleware, rg *gin.RouterGroup) *RoomController {
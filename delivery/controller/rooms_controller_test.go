@@ -4,7 +4,10 @@ import (
 	"booking-room-app/entity"
 	"booking-room-app/mock/middleware_mock"
 	"booking-room-app/mock/usecase_mock"
+	"booking-room-app/shared/apperror"
+	"booking-room-app/shared/logger"
 	"booking-room-app/shared/model"
+	"context"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -14,13 +17,14 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
 var expectedRoom = entity.Room{
 	ID:        "a3d8e4ef-2e85-4ea5-9509-795f256226c3",
 	Name:      "Ruang Candradimuka",
-	RoomType:  "Ruang Meeting",
+	RoomType:  "meeting",
 	Capacity:  42,
 	Status:    "available",
 	CreatedAt: time.Now(),
@@ -57,19 +61,19 @@ func (suite *RoomControllerTestSuite) SetupTest() {
 func (suite *RoomControllerTestSuite) TestCreateHandler_Success() {
 	mockPayload := entity.Room{
 		Name:     "Ruang Candradimuka",
-		RoomType: "Ruang Meeting",
+		RoomType: "meeting",
 		Capacity: 42,
 		Status:   "available",
 	}
 
-	suite.rum.On("RegisterNewRoom", mockPayload).Return(expectedRoom, nil)
+	suite.rum.On("RegisterNewRoom", mock.Anything, mockPayload).Return(expectedRoom, nil)
 
 	handlerFunc := NewRoomController(suite.rum, suite.amm, suite.rg)
 	handlerFunc.Route()
 
 	requestBody := `{
         "name": "Ruang Candradimuka",
-        "room_type": "Ruang Meeting",
+        "room_type": "meeting",
         "capacity": 42,
         "status": "available"
     }`
@@ -88,7 +92,7 @@ func (suite *RoomControllerTestSuite) TestCreateHandler_Success() {
 func (suite *RoomControllerTestSuite) TestCreateHandler_BadRequestFailure() {
 	mockPayload := entity.Room{}
 
-	suite.rum.On("RegisterNewRoom", &mockPayload).Return(expectedRoom, fmt.Errorf("error"))
+	suite.rum.On("RegisterNewRoom", mock.Anything, &mockPayload).Return(expectedRoom, fmt.Errorf("error"))
 
 	handlerFunc := NewRoomController(suite.rum, suite.amm, suite.rg)
 	handlerFunc.Route()
@@ -108,19 +112,19 @@ func (suite *RoomControllerTestSuite) TestCreateHandler_BadRequestFailure() {
 func (suite *RoomControllerTestSuite) TestCreateHandler_InternalServerErrorFailure() {
 	mockPayload := entity.Room{
 		Name:     "Ruang Candradimuka",
-		RoomType: "Ruang Meeting",
+		RoomType: "meeting",
 		Capacity: 42,
 		Status:   "available",
 	}
 
-	suite.rum.On("RegisterNewRoom", mockPayload).Return(expectedRoom, fmt.Errorf("error"))
+	suite.rum.On("RegisterNewRoom", mock.Anything, mockPayload).Return(expectedRoom, fmt.Errorf("error"))
 
 	handlerFunc := NewRoomController(suite.rum, suite.amm, suite.rg)
 	handlerFunc.Route()
 
 	requestBody := `{
         "name": "Ruang Candradimuka",
-        "room_type": "Ruang Meeting",
+        "room_type": "meeting",
         "capacity": 42,
         "status": "available"
     }`
@@ -137,7 +141,7 @@ func (suite *RoomControllerTestSuite) TestCreateHandler_InternalServerErrorFailu
 }
 
 func (suite *RoomControllerTestSuite) TestGetHandler_Success() {
-	suite.rum.On("FindRoomByID", "").Return(expectedRoom, nil)
+	suite.rum.On("FindRoomByID", mock.Anything, "").Return(expectedRoom, nil)
 
 	handlerFunc := NewRoomController(suite.rum, suite.amm, suite.rg)
 	handlerFunc.Route()
@@ -154,8 +158,27 @@ func (suite *RoomControllerTestSuite) TestGetHandler_Success() {
 	assert.Equal(suite.T(), http.StatusOK, responseRecorder.Code)
 }
 
+func (suite *RoomControllerTestSuite) TestGetHandler_RequestContextSuccess() {
+	requestCtx := logger.WithRequestID(context.Background(), "abc-123")
+	suite.rum.On("FindRoomByID", mock.MatchedBy(func(ctx context.Context) bool {
+		return logger.RequestID(ctx) == "abc-123"
+	}), "").Return(expectedRoom, nil)
+
+	handlerFunc := NewRoomController(suite.rum, suite.amm, suite.rg)
+
+	request, _ := http.NewRequestWithContext(requestCtx, http.MethodGet, fmt.Sprintf("%s%s/%s", apiGroup, resource, expectedRoom.ID), nil)
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	handlerFunc.getHandler(c)
+
+	assert.Equal(suite.T(), http.StatusOK, responseRecorder.Code)
+	suite.rum.AssertExpectations(suite.T())
+}
+
 func (suite *RoomControllerTestSuite) TestGetHandler_Failure() {
-	suite.rum.On("FindRoomByID", "").Return(expectedRoom, fmt.Errorf("error"))
+	suite.rum.On("FindRoomByID", mock.Anything, "").Return(expectedRoom, apperror.NotFound("room"))
 
 	handlerFunc := NewRoomController(suite.rum, suite.amm, suite.rg)
 	handlerFunc.Route()
@@ -172,7 +195,7 @@ func (suite *RoomControllerTestSuite) TestGetHandler_Failure() {
 
 func (suite *RoomControllerTestSuite) TestListHandler_Success() {
 	mockRooms := []entity.Room{expectedRoom}
-	suite.rum.On("FindAllRoom", page, size).Return(mockRooms, expectedPaging, nil)
+	suite.rum.On("FindAllRoom", mock.Anything, page, size).Return(mockRooms, expectedPaging, nil)
 
 	handlerFunc := NewRoomController(suite.rum, suite.amm, suite.rg)
 	handlerFunc.Route()
@@ -192,7 +215,7 @@ func (suite *RoomControllerTestSuite) TestListHandler_Success() {
 
 func (suite *RoomControllerTestSuite) TestListHandler_EmptyPaginationSuccess() {
 	mockRooms := []entity.Room{expectedRoom}
-	suite.rum.On("FindAllRoom", page, size).Return(mockRooms, expectedPaging, nil)
+	suite.rum.On("FindAllRoom", mock.Anything, page, size).Return(mockRooms, expectedPaging, nil)
 
 	handlerFunc := NewRoomController(suite.rum, suite.amm, suite.rg)
 	handlerFunc.Route()
@@ -212,7 +235,7 @@ func (suite *RoomControllerTestSuite) TestListHandler_EmptyPaginationSuccess() {
 
 func (suite *RoomControllerTestSuite) TestListHandler_StatusEmptyPaginationSuccess() {
 	mockRooms := []entity.Room{expectedRoom}
-	suite.rum.On("FindAllRoomStatus", expectedRoom.Status, page, size).Return(mockRooms, expectedPaging, nil)
+	suite.rum.On("FindAllRoomStatus", mock.Anything, expectedRoom.Status, page, size).Return(mockRooms, expectedPaging, nil)
 
 	handlerFunc := NewRoomController(suite.rum, suite.amm, suite.rg)
 	handlerFunc.Route()
@@ -232,7 +255,7 @@ func (suite *RoomControllerTestSuite) TestListHandler_StatusEmptyPaginationSucce
 
 func (suite *RoomControllerTestSuite) TestListHandler_StatusPaginationSuccess() {
 	mockRooms := []entity.Room{expectedRoom}
-	suite.rum.On("FindAllRoomStatus", expectedRoom.Status, page, size).Return(mockRooms, expectedPaging, nil)
+	suite.rum.On("FindAllRoomStatus", mock.Anything, expectedRoom.Status, page, size).Return(mockRooms, expectedPaging, nil)
 
 	handlerFunc := NewRoomController(suite.rum, suite.amm, suite.rg)
 	handlerFunc.Route()
@@ -252,7 +275,7 @@ func (suite *RoomControllerTestSuite) TestListHandler_StatusPaginationSuccess()
 
 func (suite *RoomControllerTestSuite) TestListHandler_BadRequestFailure() {
 	mockRooms := []entity.Room{expectedRoom}
-	suite.rum.On("FindAllRoom", page, size).Return(mockRooms, model.Paging{}, fmt.Errorf("error"))
+	suite.rum.On("FindAllRoom", mock.Anything, page, size).Return(mockRooms, model.Paging{}, apperror.Validation("page must be positive"))
 
 	handlerFunc := NewRoomController(suite.rum, suite.amm, suite.rg)
 	handlerFunc.Route()
@@ -272,12 +295,12 @@ func (suite *RoomControllerTestSuite) TestUpdateDetailHandler_Success() {
 	mockPayload := entity.Room{
 		ID:       "a3d8e4ef-2e85-4ea5-9509-795f256226c3",
 		Name:     "Ruang Singasari",
-		RoomType: "Ruang Meeting",
+		RoomType: "meeting",
 		Capacity: 37,
 		Status:   "available",
 	}
 
-	suite.rum.On("UpdateRoomDetail", mockPayload).Return(expectedRoom, nil)
+	suite.rum.On("UpdateRoomDetail", mock.Anything, mockPayload).Return(expectedRoom, nil)
 
 	handlerFunc := NewRoomController(suite.rum, suite.amm, suite.rg)
 	handlerFunc.Route()
@@ -285,7 +308,7 @@ func (suite *RoomControllerTestSuite) TestUpdateDetailHandler_Success() {
 	requestBody := `{
         "id": "a3d8e4ef-2e85-4ea5-9509-795f256226c3",
         "name": "Ruang Singasari",
-        "room_type": "Ruang Meeting",
+        "room_type": "meeting",
         "capacity": 37,
         "status": "available"
     }`
@@ -304,7 +327,7 @@ func (suite *RoomControllerTestSuite) TestUpdateDetailHandler_Success() {
 func (suite *RoomControllerTestSuite) TestUpdateDetailHandler_BadRequestFailure() {
 	mockPayload := entity.Room{}
 
-	suite.rum.On("UpdateRoomDetail", &mockPayload).Return(expectedRoom, fmt.Errorf("error"))
+	suite.rum.On("UpdateRoomDetail", mock.Anything, &mockPayload).Return(expectedRoom, fmt.Errorf("error"))
 
 	handlerFunc := NewRoomController(suite.rum, suite.amm, suite.rg)
 	handlerFunc.Route()
@@ -325,12 +348,12 @@ func (suite *RoomControllerTestSuite) TestUpdateDetailHandler_InternalServerErro
 	mockPayload := entity.Room{
 		ID:       "a3d8e4ef-2e85-4ea5-9509-795f256226c3",
 		Name:     "Ruang Singasari",
-		RoomType: "Ruang Meeting",
+		RoomType: "meeting",
 		Capacity: 37,
 		Status:   "available",
 	}
 
-	suite.rum.On("UpdateRoomDetail", mockPayload).Return(entity.Room{}, fmt.Errorf("error"))
+	suite.rum.On("UpdateRoomDetail", mock.Anything, mockPayload).Return(entity.Room{}, fmt.Errorf("error"))
 
 	handlerFunc := NewRoomController(suite.rum, suite.amm, suite.rg)
 	handlerFunc.Route()
@@ -338,7 +361,7 @@ func (suite *RoomControllerTestSuite) TestUpdateDetailHandler_InternalServerErro
 	requestBody := `{
         "id": "a3d8e4ef-2e85-4ea5-9509-795f256226c3",
         "name": "Ruang Singasari",
-        "room_type": "Ruang Meeting",
+        "room_type": "meeting",
         "capacity": 37,
         "status": "available"
     }`
@@ -360,7 +383,7 @@ func (suite *RoomControllerTestSuite) TestUpdateStatusHandler_Success() {
 		Status: "booked",
 	}
 
-	suite.rum.On("UpdateRoomStatus", mockPayload).Return(expectedRoom, nil)
+	suite.rum.On("UpdateRoomStatus", mock.Anything, mockPayload).Return(expectedRoom, nil)
 
 	handlerFunc := NewRoomController(suite.rum, suite.amm, suite.rg)
 	handlerFunc.Route()
@@ -384,7 +407,7 @@ func (suite *RoomControllerTestSuite) TestUpdateStatusHandler_Success() {
 func (suite *RoomControllerTestSuite) TestUpdateStatusHandler_BadRequestFailure() {
 	mockPayload := entity.Room{}
 
-	suite.rum.On("UpdateRoomStatus", &mockPayload).Return(expectedRoom, fmt.Errorf("error"))
+	suite.rum.On("UpdateRoomStatus", mock.Anything, &mockPayload).Return(expectedRoom, fmt.Errorf("error"))
 
 	handlerFunc := NewRoomController(suite.rum, suite.amm, suite.rg)
 	handlerFunc.Route()
@@ -407,7 +430,7 @@ func (suite *RoomControllerTestSuite) TestUpdateStatusHandler_InternalServerErro
 		Status: "available",
 	}
 
-	suite.rum.On("UpdateRoomStatus", mockPayload).Return(entity.Room{}, fmt.Errorf("error"))
+	suite.rum.On("UpdateRoomStatus", mock.Anything, mockPayload).Return(entity.Room{}, fmt.Errorf("error"))
 
 	handlerFunc := NewRoomController(suite.rum, suite.amm, suite.rg)
 	handlerFunc.Route()
@@ -428,6 +451,142 @@ func (suite *RoomControllerTestSuite) TestUpdateStatusHandler_InternalServerErro
 	assert.Equal(suite.T(), http.StatusInternalServerError, responseRecorder.Code)
 }
 
+func (suite *RoomControllerTestSuite) TestArchiveHandler_Success() {
+	archival := entity.Archival{ID: expectedRoom.ID, ArchivedAt: time.Now(), DeclinedTransactions: []string{}}
+	suite.rum.On("ArchiveRoom", mock.Anything, expectedRoom.ID).Return(archival, nil)
+
+	handlerFunc := NewRoomController(suite.rum, suite.amm, suite.rg)
+	request, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/rooms/%s/archive", apiGroup, expectedRoom.ID), nil)
+	assert.NoError(suite.T(), err)
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	c.Params = gin.Params{{Key: "id", Value: expectedRoom.ID}}
+
+	handlerFunc.archiveHandler(c)
+
+	assert.Equal(suite.T(), http.StatusOK, responseRecorder.Code)
+}
+
+func (suite *RoomControllerTestSuite) TestArchiveHandler_ConflictFailure() {
+	suite.rum.On("ArchiveRoom", mock.Anything, expectedRoom.ID).Return(entity.Archival{}, apperror.Conflict("open_transactions", "the room has open bookings"))
+
+	handlerFunc := NewRoomController(suite.rum, suite.amm, suite.rg)
+	request, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/rooms/%s/archive", apiGroup, expectedRoom.ID), nil)
+	assert.NoError(suite.T(), err)
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	c.Params = gin.Params{{Key: "id", Value: expectedRoom.ID}}
+
+	handlerFunc.archiveHandler(c)
+
+	assert.Equal(suite.T(), http.StatusConflict, responseRecorder.Code)
+}
+
+func (suite *RoomControllerTestSuite) TestArchiveHandler_BadRequestFailure() {
+	handlerFunc := NewRoomController(suite.rum, suite.amm, suite.rg)
+	request, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/rooms/1/archive", apiGroup), nil)
+	assert.NoError(suite.T(), err)
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	c.Params = gin.Params{{Key: "id", Value: "1"}}
+
+	handlerFunc.archiveHandler(c)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, responseRecorder.Code)
+	suite.rum.AssertNotCalled(suite.T(), "ArchiveRoom", mock.Anything, mock.Anything)
+}
+
+func (suite *RoomControllerTestSuite) TestRestoreHandler_Success() {
+	suite.rum.On("RestoreRoom", mock.Anything, expectedRoom.ID).Return(expectedRoom, nil)
+
+	handlerFunc := NewRoomController(suite.rum, suite.amm, suite.rg)
+	request, err := http.NewRequest(http.MethodPost, fmt.Sprintf("%s/rooms/%s/restore", apiGroup, expectedRoom.ID), nil)
+	assert.NoError(suite.T(), err)
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	c.Params = gin.Params{{Key: "id", Value: expectedRoom.ID}}
+
+	handlerFunc.restoreHandler(c)
+
+	assert.Equal(suite.T(), http.StatusOK, responseRecorder.Code)
+}
+
+func (suite *RoomControllerTestSuite) TestListHandler_ArchivedSuccess() {
+	suite.rum.On("FindArchivedRooms", mock.Anything, 1, 5).Return([]entity.Room{expectedRoom}, model.Paging{Page: 1, RowsPerPage: 5, TotalRows: 1, TotalPages: 1}, nil)
+
+	handlerFunc := NewRoomController(suite.rum, suite.amm, suite.rg)
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s%s?archived=true", apiGroup, resource), nil)
+	assert.NoError(suite.T(), err)
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+
+	handlerFunc.listHandler(c)
+
+	assert.Equal(suite.T(), http.StatusOK, responseRecorder.Code)
+	suite.rum.AssertNotCalled(suite.T(), "FindAllRoom", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *RoomControllerTestSuite) TestListHandler_LocationSuccess() {
+	filter := entity.LocationFilter{SiteId: siteId, FloorId: floorId}
+	suite.rum.On("FindRoomsByLocation", mock.Anything, filter, "available", 1, 5).Return([]entity.Room{expectedRoom}, model.Paging{Page: 1, RowsPerPage: 5, TotalRows: 1, TotalPages: 1}, nil)
+
+	handlerFunc := NewRoomController(suite.rum, suite.amm, suite.rg)
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s%s?status=available&siteId=%s&floorId=%s", apiGroup, resource, siteId, floorId), nil)
+	assert.NoError(suite.T(), err)
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+
+	handlerFunc.listHandler(c)
+
+	assert.Equal(suite.T(), http.StatusOK, responseRecorder.Code)
+	suite.rum.AssertNotCalled(suite.T(), "FindAllRoomStatus", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *RoomControllerTestSuite) TestListHandler_AvailabilitySuccess() {
+	start := time.Date(2024, time.March, 1, 9, 0, 0, 0, time.UTC)
+	filter := entity.LocationFilter{SiteId: siteId}
+	suite.rum.On("FindAvailableRooms", mock.Anything, filter, start, start.Add(time.Hour), 1, 5).Return([]entity.Room{expectedRoom}, model.Paging{Page: 1, RowsPerPage: 5, TotalRows: 1, TotalPages: 1}, nil)
+
+	handlerFunc := NewRoomController(suite.rum, suite.amm, suite.rg)
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s%s?siteId=%s&startTime=2024-03-01T09:00:00Z&endTime=2024-03-01T10:00:00Z", apiGroup, resource, siteId), nil)
+	assert.NoError(suite.T(), err)
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+
+	handlerFunc.listHandler(c)
+
+	assert.Equal(suite.T(), http.StatusOK, responseRecorder.Code)
+	suite.rum.AssertNotCalled(suite.T(), "FindRoomsByLocation", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *RoomControllerTestSuite) TestListHandler_InvalidLocationFailure() {
+	handlerFunc := NewRoomController(suite.rum, suite.amm, suite.rg)
+	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s%s?siteId=jakarta", apiGroup, resource), nil)
+	assert.NoError(suite.T(), err)
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+
+	handlerFunc.listHandler(c)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, responseRecorder.Code)
+}
+
 func TestRoomControllerTestSuite(t *testing.T) {
 	suite.Run(t, new(RoomControllerTestSuite))
 }
@@ -0,0 +1,101 @@
+package controller
+
+import (
+	"booking-room-app/config"
+	"booking-room-app/delivery/middleware"
+	"booking-room-app/entity/dto"
+	"booking-room-app/shared/common"
+	"booking-room-app/usecase"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+)
+
+type StockAlertController struct {
+	stockAlertUC   usecase.StockAlertUseCase
+	rg             *gin.RouterGroup
+	authMiddleware middleware.AuthMiddleware
+}
+
+func (s *StockAlertController) getThresholdHandler(c *gin.Context) {
+	id, err := common.ParamUUID(c, "id")
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	threshold, err := s.stockAlertUC.FindThreshold(c.Request.Context(), id)
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendSingleResponse(c, threshold, "Ok")
+}
+
+func (s *StockAlertController) updateThresholdHandler(c *gin.Context) {
+	id, err := common.ParamUUID(c, "id")
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	var payload dto.StockThresholdRequestDto
+	if err := common.BindJSON(c, &payload); err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	threshold, err := s.stockAlertUC.UpdateThreshold(c.Request.Context(), payload.Entity(id))
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendSingleResponse(c, threshold, "Updated")
+}
+
+func (s *StockAlertController) listHandler(c *gin.Context) {
+	page, _ := strconv.Atoi(c.DefaultQuery("page", "1"))
+	size, _ := strconv.Atoi(c.DefaultQuery("size", "5"))
+	var query dto.StockAlertQueryDto
+	if err := common.BindQuery(c, &query); err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	alerts, paging, err := s.stockAlertUC.FindAlerts(c.Request.Context(), query.Status, page, size)
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	var response []interface{}
+	for _, v := range alerts {
+		response = append(response, v)
+	}
+	common.SendPagedResponse(c, response, paging, "Ok")
+}
+
+func (s *StockAlertController) acknowledgeHandler(c *gin.Context) {
+	id, err := common.ParamUUID(c, "id")
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+
+	alert, err := s.stockAlertUC.AcknowledgeAlert(c.Request.Context(), id)
+	if err != nil {
+		common.SendErrorResponse(c, err)
+		return
+	}
+	common.SendSingleResponse(c, alert, "Updated")
+}
+
+func (s *StockAlertController) Route() {
+	s.rg.GET(config.FacilityThresholdGet, s.authMiddleware.RequireToken("admin", "ga"), s.getThresholdHandler)
+	s.rg.PUT(config.FacilityThresholdUpdate, s.authMiddleware.RequireToken("admin", "ga"), s.updateThresholdHandler)
+	s.rg.GET(config.StockAlertList, s.authMiddleware.RequireToken("admin", "ga"), s.listHandler)
+	s.rg.PUT(config.StockAlertAcknowledge, s.authMiddleware.RequireToken("admin", "ga"), s.acknowledgeHandler)
+}
+
+func NewStockAlertController(stockAlertUC usecase.StockAlertUseCase, rg *gin.RouterGroup, authMiddleware middleware.AuthMiddleware) *StockAlertController {
+	return &StockAlertController{stockAlertUC: stockAlertUC, rg: rg, authMiddleware: authMiddleware}
+}
@@ -0,0 +1,115 @@
+package controller
+
+import (
+	"booking-room-app/entity"
+	"booking-room-app/mock/middleware_mock"
+	"booking-room-app/mock/usecase_mock"
+	"booking-room-app/shared/apperror"
+	"booking-room-app/shared/model"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+const stockAlertId = "2f7a8b9c-0d1e-4a2b-9c3d-5e6f7a8b9c0d"
+
+type StockAlertControllerTestSuite struct {
+	suite.Suite
+	rg  *gin.RouterGroup
+	sum *usecase_mock.StockAlertUseCaseMock
+	amm *middleware_mock.AuthMiddlewareMock
+}
+
+func (suite *StockAlertControllerTestSuite) SetupTest() {
+	suite.sum = new(usecase_mock.StockAlertUseCaseMock)
+	router := gin.Default()
+	gin.SetMode(gin.TestMode)
+	suite.rg = router.Group(apiGroup)
+}
+
+func (suite *StockAlertControllerTestSuite) TestUpdateThresholdHandler_Success() {
+	threshold := entity.StockThreshold{FacilityId: facilityId, MinQuantity: 0}
+	suite.sum.On("UpdateThreshold", mock.Anything, threshold).Return(threshold, nil)
+
+	handlerFunc := NewStockAlertController(suite.sum, suite.rg, suite.amm)
+	request, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/facilities/%s/threshold", apiGroup, facilityId), strings.NewReader(`{"minQuantity": 0}`))
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	c.Params = gin.Params{{Key: "id", Value: facilityId}}
+	handlerFunc.updateThresholdHandler(c)
+
+	assert.Equal(suite.T(), http.StatusOK, responseRecorder.Code)
+}
+
+func (suite *StockAlertControllerTestSuite) TestUpdateThresholdHandler_MissingMinQuantityFailure() {
+	handlerFunc := NewStockAlertController(suite.sum, suite.rg, suite.amm)
+	request, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/facilities/%s/threshold", apiGroup, facilityId), strings.NewReader(`{}`))
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	c.Params = gin.Params{{Key: "id", Value: facilityId}}
+	handlerFunc.updateThresholdHandler(c)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, responseRecorder.Code)
+	assert.Contains(suite.T(), responseRecorder.Body.String(), `"field":"minQuantity"`)
+	suite.sum.AssertNotCalled(suite.T(), "UpdateThreshold", mock.Anything, mock.Anything)
+}
+
+func (suite *StockAlertControllerTestSuite) TestListHandler_Success() {
+	alerts := []entity.StockAlert{{ID: stockAlertId, FacilityId: facilityId, FacilityName: "HDMI adapter", Quantity: 1, MinQuantity: 3}}
+	paging := model.Paging{Page: 1, RowsPerPage: 5, TotalRows: 1, TotalPages: 1}
+	suite.sum.On("FindAlerts", mock.Anything, "open", 1, 5).Return(alerts, paging, nil)
+
+	handlerFunc := NewStockAlertController(suite.sum, suite.rg, suite.amm)
+	request, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/stockalerts?status=open", apiGroup), nil)
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	handlerFunc.listHandler(c)
+
+	assert.Equal(suite.T(), http.StatusOK, responseRecorder.Code)
+	assert.Contains(suite.T(), responseRecorder.Body.String(), `"facilityName":"HDMI adapter"`)
+}
+
+func (suite *StockAlertControllerTestSuite) TestListHandler_InvalidStatusFailure() {
+	handlerFunc := NewStockAlertController(suite.sum, suite.rg, suite.amm)
+	request, _ := http.NewRequest(http.MethodGet, fmt.Sprintf("%s/stockalerts?status=closed", apiGroup), nil)
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	handlerFunc.listHandler(c)
+
+	assert.Equal(suite.T(), http.StatusBadRequest, responseRecorder.Code)
+	suite.sum.AssertNotCalled(suite.T(), "FindAlerts", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *StockAlertControllerTestSuite) TestAcknowledgeHandler_NotFoundFailure() {
+	suite.sum.On("AcknowledgeAlert", mock.Anything, stockAlertId).Return(entity.StockAlert{}, apperror.NotFound("stock alert"))
+
+	handlerFunc := NewStockAlertController(suite.sum, suite.rg, suite.amm)
+	request, _ := http.NewRequest(http.MethodPut, fmt.Sprintf("%s/stockalerts/%s/acknowledge", apiGroup, stockAlertId), nil)
+
+	responseRecorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(responseRecorder)
+	c.Request = request
+	c.Params = gin.Params{{Key: "id", Value: stockAlertId}}
+	handlerFunc.acknowledgeHandler(c)
+
+	assert.Equal(suite.T(), http.StatusNotFound, responseRecorder.Code)
+}
+
+func TestStockAlertControllerTestSuite(t *testing.T) {
+	suite.Run(t, new(StockAlertControllerTestSuite))
+}
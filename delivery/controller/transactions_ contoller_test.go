@@ -4,6 +4,7 @@ import (
 	"booking-room-app/entity"
 	"booking-room-app/mock/middleware_mock"
 	"booking-room-app/mock/usecase_mock"
+	"booking-room-app/shared/apperror"
 	"errors"
 	"fmt"
 	"net/http"
@@ -14,6 +15,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -21,7 +23,7 @@ var expectedTransactions = entity.Transaction{
 	ID:             "1",
 	EmployeeId:     "1",
 	RoomId:         "1",
-	RoomFacilities: nil,
+	Facilities:     nil,
 	Description:    "Test",
 	Status:         "pending",
 	StartTime:      time.Date(2023, time.December, 25, 12, 0, 0, 0, time.UTC),
@@ -48,23 +50,21 @@ func (suite *TransactionsControllerTestSuite) SetupTest() {
 
 func (suite *TransactionsControllerTestSuite) TestCreateHandler_Success() {
 	mockPayload := entity.Transaction{
-		ID:          "1",
-		EmployeeId:  "1",
-		RoomId:      "1",
+		EmployeeId:  "4b1d2e3f-5a6b-4c7d-8e9f-0a1b2c3d4e5f",
+		RoomId:      "8c2d3e4f-6a7b-4d8e-9f0a-1b2c3d4e5f60",
 		Description: "Test",
 		StartTime:   time.Date(2023, time.December, 25, 12, 0, 0, 0, time.UTC),
 		EndTime:     time.Date(2023, time.December, 25, 15, 0, 0, 0, time.UTC),
 	}
 
-	suite.tum.On("RequestNewBookingRooms", mockPayload).Return(expectedTransactions, nil)
+	suite.tum.On("RequestNewBookingRooms", mock.Anything, mockPayload).Return(expectedTransactions, nil)
 
 	handlerFunc := NewTransactionsController(suite.tum, suite.rg, suite.amm)
 	handlerFunc.Route()
 
 	requestBody := `{
-        "id": "1",
-        "employeeId": "1",
-        "roomId": "1",
+        "employeeId": "4b1d2e3f-5a6b-4c7d-8e9f-0a1b2c3d4e5f",
+        "roomId": "8c2d3e4f-6a7b-4d8e-9f0a-1b2c3d4e5f60",
         "description": "Test",
 		"startTime": "2023-12-25T12:00:00Z",
         "endTime": "2023-12-25T15:00:00Z"
@@ -84,7 +84,7 @@ func (suite *TransactionsControllerTestSuite) TestCreateHandler_Success() {
 func (suite *TransactionsControllerTestSuite) TestCreateHandler_fail() {
 	mockPayload := entity.Transaction{}
 
-	suite.tum.On("RequestNewBookingRooms", &mockPayload).Return(expectedTransactions, fmt.Errorf("error"))
+	suite.tum.On("RequestNewBookingRooms", mock.Anything, &mockPayload).Return(expectedTransactions, fmt.Errorf("error"))
 
 	handlerFunc := NewTransactionsController(suite.tum, suite.rg, suite.amm)
 	handlerFunc.Route()
@@ -102,23 +102,21 @@ func (suite *TransactionsControllerTestSuite) TestCreateHandler_fail() {
 
 func (suite *TransactionsControllerTestSuite) TestCreateHandler_InternalServerErrorFailure() {
 	mockPayload := entity.Transaction{
-		ID:          "1",
-		EmployeeId:  "1",
-		RoomId:      "1",
+		EmployeeId:  "4b1d2e3f-5a6b-4c7d-8e9f-0a1b2c3d4e5f",
+		RoomId:      "8c2d3e4f-6a7b-4d8e-9f0a-1b2c3d4e5f60",
 		Description: "Test",
 		StartTime:   time.Date(2023, time.December, 25, 12, 0, 0, 0, time.UTC),
 		EndTime:     time.Date(2023, time.December, 25, 15, 0, 0, 0, time.UTC),
 	}
 
-	suite.tum.On("RequestNewBookingRooms", mockPayload).Return(expectedTransactions, fmt.Errorf("error"))
+	suite.tum.On("RequestNewBookingRooms", mock.Anything, mockPayload).Return(expectedTransactions, fmt.Errorf("error"))
 
 	handlerFunc := NewTransactionsController(suite.tum, suite.rg, suite.amm)
 	handlerFunc.Route()
 
 	requestBody := `{
-        "id": "1",
-        "employeeId": "1",
-        "roomId": "1",
+        "employeeId": "4b1d2e3f-5a6b-4c7d-8e9f-0a1b2c3d4e5f",
+        "roomId": "8c2d3e4f-6a7b-4d8e-9f0a-1b2c3d4e5f60",
         "description": "Test",
 		"startTime": "2023-12-25T12:00:00Z",
         "endTime": "2023-12-25T15:00:00Z"
@@ -137,7 +135,7 @@ func (suite *TransactionsControllerTestSuite) TestCreateHandler_InternalServerEr
 
 func (suite *TransactionsControllerTestSuite) TestListHandler_Success() {
 	mockTransactions := []entity.Transaction{expectedTransactions}
-	suite.tum.On("FindAllTransactions", page, size, time.Date(1000, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(3000, time.December, 31, 0, 0, 0, 0, time.UTC)).Return(mockTransactions, expectedPaging, nil)
+	suite.tum.On("FindAllTransactions", mock.Anything, page, size, time.Date(1000, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(3000, time.December, 31, 0, 0, 0, 0, time.UTC)).Return(mockTransactions, expectedPaging, nil)
 	handlerFunc := NewTransactionsController(suite.tum, suite.rg, suite.amm)
 	handlerFunc.Route()
 
@@ -157,7 +155,7 @@ func (suite *TransactionsControllerTestSuite) TestListHandler_Success() {
 
 func (suite *TransactionsControllerTestSuite) TestListHandler_StartDateTimeBadRequest() {
 	mockTransactions := []entity.Transaction{expectedTransactions}
-	suite.tum.On("FindAllTransactions", page, size, time.Date(1000, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(3000, time.December, 31, 0, 0, 0, 0, time.UTC)).Return(mockTransactions, expectedPaging, nil)
+	suite.tum.On("FindAllTransactions", mock.Anything, page, size, time.Date(1000, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(3000, time.December, 31, 0, 0, 0, 0, time.UTC)).Return(mockTransactions, expectedPaging, nil)
 	handlerFunc := NewTransactionsController(suite.tum, suite.rg, suite.amm)
 	handlerFunc.Route()
 
@@ -177,7 +175,7 @@ func (suite *TransactionsControllerTestSuite) TestListHandler_StartDateTimeBadRe
 
 func (suite *TransactionsControllerTestSuite) TestListHandler_EndDateTimeBadRequest() {
 	mockTransactions := []entity.Transaction{expectedTransactions}
-	suite.tum.On("FindAllTransactions", page, size, time.Date(1000, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(3000, time.December, 31, 0, 0, 0, 0, time.UTC)).Return(mockTransactions, expectedPaging, nil)
+	suite.tum.On("FindAllTransactions", mock.Anything, page, size, time.Date(1000, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(3000, time.December, 31, 0, 0, 0, 0, time.UTC)).Return(mockTransactions, expectedPaging, nil)
 	handlerFunc := NewTransactionsController(suite.tum, suite.rg, suite.amm)
 	handlerFunc.Route()
 
@@ -198,7 +196,7 @@ func (suite *TransactionsControllerTestSuite) TestListHandler_EndDateTimeBadRequ
 func (suite *TransactionsControllerTestSuite) TestListHandler_PaginationBadRequest() {
 	mockTransactions := []entity.Transaction{expectedTransactions}
 	mockError := errors.New("something went wrong")
-	suite.tum.On("FindAllTransactions", page, size, time.Date(1000, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(3000, time.December, 31, 0, 0, 0, 0, time.UTC)).Return(mockTransactions, expectedPaging, mockError)
+	suite.tum.On("FindAllTransactions", mock.Anything, page, size, time.Date(1000, time.January, 1, 0, 0, 0, 0, time.UTC), time.Date(3000, time.December, 31, 0, 0, 0, 0, time.UTC)).Return(mockTransactions, expectedPaging, mockError)
 	handlerFunc := NewTransactionsController(suite.tum, suite.rg, suite.amm)
 	handlerFunc.Route()
 
@@ -218,7 +216,7 @@ func (suite *TransactionsControllerTestSuite) TestListHandler_PaginationBadReque
 
 func (suite *TransactionsControllerTestSuite) TestgetTransactionById_Success() {
 	// mockID := "1"
-	suite.tum.On("FindTransactionsById", "").Return(expectedTransactions, nil)
+	suite.tum.On("FindTransactionsById", mock.Anything, "").Return(expectedTransactions, nil)
 
 	handlerFunc := NewTransactionsController(suite.tum, suite.rg, suite.amm)
 	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s%s", apiGroup, transactionsPoint), nil)
@@ -234,8 +232,8 @@ func (suite *TransactionsControllerTestSuite) TestgetTransactionById_Success() {
 }
 
 func (suite *TransactionsControllerTestSuite) TestGetTransactionById_Fail() {
-	mockError := errors.New("transaction not found")
-	suite.tum.On("FindTransactionsById", "").Return(expectedTransactions, mockError)
+	mockError := apperror.NotFound("transaction")
+	suite.tum.On("FindTransactionsById", mock.Anything, "").Return(expectedTransactions, mockError)
 
 	handlerFunc := NewTransactionsController(suite.tum, suite.rg, suite.amm)
 	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s%s", apiGroup, transactionsPoint), nil)
@@ -252,7 +250,7 @@ func (suite *TransactionsControllerTestSuite) TestGetTransactionById_Fail() {
 
 func (suite *TransactionsControllerTestSuite) TestgetTransactionByEmployeeId_Success() {
 	mockTransactions := []entity.Transaction{expectedTransactions}
-	suite.tum.On("FindTransactionsByEmployeeId", "").Return(mockTransactions, expectedPaging, nil)
+	suite.tum.On("FindTransactionsByEmployeeId", mock.Anything, "").Return(mockTransactions, expectedPaging, nil)
 
 	handlerFunc := NewTransactionsController(suite.tum, suite.rg, suite.amm)
 	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s%s", apiGroup, transactionsPoint), nil)
@@ -270,8 +268,8 @@ func (suite *TransactionsControllerTestSuite) TestgetTransactionByEmployeeId_Suc
 func (suite *TransactionsControllerTestSuite) TestgetTransactionByEmployeeId_Fail() {
 	mockTransactions := []entity.Transaction{expectedTransactions}
 
-	mockError := errors.New("transaction not found")
-	suite.tum.On("FindTransactionsByEmployeeId", "").Return(mockTransactions, expectedPaging, mockError)
+	mockError := apperror.NotFound("transaction")
+	suite.tum.On("FindTransactionsByEmployeeId", mock.Anything, "").Return(mockTransactions, expectedPaging, mockError)
 
 	handlerFunc := NewTransactionsController(suite.tum, suite.rg, suite.amm)
 	request, err := http.NewRequest(http.MethodGet, fmt.Sprintf("%s%s", apiGroup, transactionsPoint), nil)
@@ -288,14 +286,14 @@ func (suite *TransactionsControllerTestSuite) TestgetTransactionByEmployeeId_Fai
 
 func (suite *TransactionsControllerTestSuite) TestUpdateHandler_Success() {
 	mockPayload := entity.Transaction{
-		ID:     "1",
+		ID:     "2e3f4a5b-7c8d-4e9f-a0b1-c2d3e4f5a6b7",
 		Status: "accepted",
 	}
 
-	suite.tum.On("AccStatusBooking", mockPayload).Return(mockPayload, nil)
+	suite.tum.On("AccStatusBooking", mock.Anything, mockPayload).Return(mockPayload, nil)
 
 	handlerFunc := NewTransactionsController(suite.tum, suite.rg, suite.amm)
-	requestBody := `{"id": "1","status": "accepted"}`
+	requestBody := `{"id": "2e3f4a5b-7c8d-4e9f-a0b1-c2d3e4f5a6b7","status": "accepted"}`
 	request, err := http.NewRequest(http.MethodPut, fmt.Sprintf("%s%s", apiGroup, transactionsPoint), strings.NewReader(requestBody))
 	assert.NoError(suite.T(), err)
 	responseRecorder := httptest.NewRecorder()
@@ -311,7 +309,7 @@ func (suite *TransactionsControllerTestSuite) TestUpdateHandler_BadRequest() {
 	mockPayload := entity.Transaction{}
 	mockError := errors.New("example error message")
 
-	suite.tum.On("AccStatusBooking", &mockPayload).Return(mockPayload, mockError)
+	suite.tum.On("AccStatusBooking", mock.Anything, &mockPayload).Return(mockPayload, mockError)
 
 	handlerFunc := NewTransactionsController(suite.tum, suite.rg, suite.amm)
 	request, err := http.NewRequest(http.MethodPut, fmt.Sprintf("%s%s", apiGroup, transactionsPoint), nil)
@@ -328,14 +326,15 @@ func (suite *TransactionsControllerTestSuite) TestUpdateHandler_BadRequest() {
 
 func (suite *TransactionsControllerTestSuite) TestUpdateHandler_NotFound() {
 	mockPayload := entity.Transaction{
-		ID: "nonexistent_id",
+		ID:     "2e3f4a5b-7c8d-4e9f-a0b1-c2d3e4f5a6b7",
+		Status: "declined",
 	}
-	mockError := errors.New("not found ID " + mockPayload.ID)
+	mockError := apperror.NotFound("transaction")
 
-	suite.tum.On("AccStatusBooking", mockPayload).Return(mockPayload, mockError)
+	suite.tum.On("AccStatusBooking", mock.Anything, mockPayload).Return(mockPayload, mockError)
 
 	handlerFunc := NewTransactionsController(suite.tum, suite.rg, suite.amm)
-	requestBody := `{"id": "nonexistent_id"}`
+	requestBody := `{"id": "2e3f4a5b-7c8d-4e9f-a0b1-c2d3e4f5a6b7","status": "declined"}`
 	request, err := http.NewRequest(http.MethodPut, fmt.Sprintf("%s%s", apiGroup, transactionsPoint), strings.NewReader(requestBody))
 	assert.NoError(suite.T(), err)
 	responseRecorder := httptest.NewRecorder()
@@ -344,7 +343,7 @@ func (suite *TransactionsControllerTestSuite) TestUpdateHandler_NotFound() {
 	c.Set(resource, mockPayload)
 	handlerFunc.updateStatusHandler(c)
 
-	assert.Equal(suite.T(), http.StatusInternalServerError, responseRecorder.Code)
+	assert.Equal(suite.T(), http.StatusNotFound, responseRecorder.Code)
 }
 
 func TestTransactionControllerTestSuite(t *testing.T) {
@@ -3,11 +3,9 @@ package controller
 import (
 	"booking-room-app/config"
 	"booking-room-app/delivery/middleware"
-	"booking-room-app/entity"
+	"booking-room-app/entity/dto"
 	"booking-room-app/shared/common"
 	"booking-room-app/usecase"
-	"fmt"
-	"net/http"
 	"strconv"
 	"time"
 
@@ -21,15 +19,15 @@ type TransactionsController struct {
 }
 
 func (t *TransactionsController) createHandler(ctx *gin.Context) {
-	var payload entity.Transaction
-	if err := ctx.ShouldBindJSON(&payload); err != nil {
-		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+	var payload dto.TransactionRequestDto
+	if err := common.BindJSON(ctx, &payload); err != nil {
+		common.SendErrorResponse(ctx, err)
 		return
 	}
 
-	transactions, err := t.transactionUC.RequestNewBookingRooms(payload)
+	transactions, err := t.transactionUC.RequestNewBookingRooms(ctx.Request.Context(), payload.Entity())
 	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		common.SendErrorResponse(ctx, err)
 		return
 	}
 	common.SendCreateResponse(ctx, transactions, "Created")
@@ -57,19 +55,19 @@ func (t *TransactionsController) listHandler(ctx *gin.Context) {
 
 	startDateTime, err := time.Parse("2006-01-02", startDate)
 	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusBadRequest, "Invalid startDate format")
+		common.SendErrorResponse(ctx, invalidDate("startDate", err))
 		return
 	}
 
 	endDateTime, err := time.Parse("2006-01-02", endDate)
 	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusBadRequest, "Invalid endDate format")
+		common.SendErrorResponse(ctx, invalidDate("endDate", err))
 		return
 	}
 
-	transactions, paging, err := t.transactionUC.FindAllTransactions(page, size, startDateTime, endDateTime)
+	transactions, paging, err := t.transactionUC.FindAllTransactions(ctx.Request.Context(), page, size, startDateTime, endDateTime)
 	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		common.SendErrorResponse(ctx, err)
 		return
 	}
 
@@ -82,9 +80,9 @@ func (t *TransactionsController) listHandler(ctx *gin.Context) {
 
 func (t *TransactionsController) getTransactionById(ctx *gin.Context) {
 	id := ctx.Param("id")
-	transactions, err := t.transactionUC.FindTransactionsById(id)
+	transactions, err := t.transactionUC.FindTransactionsById(ctx.Request.Context(), id)
 	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusNotFound, "transaction with transaction ID "+id+" not found")
+		common.SendErrorResponse(ctx, err)
 		return
 	}
 
@@ -101,10 +99,9 @@ func (t *TransactionsController) getTransactionByEmployeeId(ctx *gin.Context) {
 		size = 5
 	}
 
-	transactions, paging, err := t.transactionUC.FindTransactionsByEmployeeId(employeeId, page, size)
+	transactions, paging, err := t.transactionUC.FindTransactionsByEmployeeId(ctx.Request.Context(), employeeId, page, size)
 	if err != nil {
-		fmt.Println(employeeId)
-		common.SendErrorResponse(ctx, http.StatusNotFound, "transaction with employee ID "+employeeId+" not found")
+		common.SendErrorResponse(ctx, err)
 		return
 	}
 
@@ -117,15 +114,15 @@ func (t *TransactionsController) getTransactionByEmployeeId(ctx *gin.Context) {
 }
 
 func (t *TransactionsController) updateStatusHandler(ctx *gin.Context) {
-	var payload entity.Transaction
-	if err := ctx.ShouldBindJSON(&payload); err != nil {
-		common.SendErrorResponse(ctx, http.StatusBadRequest, err.Error())
+	var payload dto.TransactionStatusRequestDto
+	if err := common.BindJSON(ctx, &payload); err != nil {
+		common.SendErrorResponse(ctx, err)
 		return
 	}
 
-	transactions, err := t.transactionUC.AccStatusBooking(payload)
+	transactions, err := t.transactionUC.AccStatusBooking(ctx.Request.Context(), payload.Entity())
 	if err != nil {
-		common.SendErrorResponse(ctx, http.StatusInternalServerError, err.Error())
+		common.SendErrorResponse(ctx, err)
 		return
 	}
 	common.SendCreateResponse(ctx, transactions, "Updated")
@@ -1,14 +1,21 @@
 package middleware
 
 import (
+	"booking-room-app/shared/apperror"
+	"booking-room-app/shared/common"
+	"booking-room-app/shared/logger"
 	"booking-room-app/shared/service"
-	"log"
-	"net/http"
+	"fmt"
 	"strings"
 
 	"github.com/gin-gonic/gin"
 )
 
+var (
+	errMissingToken = apperror.Unauthorized("missing_token", "a bearer token is required")
+	errInvalidToken = apperror.Unauthorized("invalid_token", "the token is invalid or expired")
+)
+
 type AuthMiddleware interface {
 	RequireToken(roles ...string) gin.HandlerFunc
 }
@@ -25,25 +32,25 @@ func (a *authMiddleware) RequireToken(roles ...string) gin.HandlerFunc {
 	return func(ctx *gin.Context) {
 		var autHeader AuthHeader
 		if err := ctx.ShouldBindHeader(&autHeader); err != nil {
-			log.Printf("RequireToken.autHeader: %v \n", err.Error())
-			ctx.AbortWithStatus(http.StatusUnauthorized)
+			common.SendErrorResponse(ctx, errMissingToken.Wrap(err))
 			return
 		}
 
 		tokenHeader := strings.Replace(autHeader.AuthorizationHeader, "Bearer ", "", -1)
 		if tokenHeader == "" {
-			log.Printf("RequireToken.tokenHeader \n")
-			ctx.AbortWithStatus(http.StatusUnauthorized)
+			common.SendErrorResponse(ctx, errMissingToken)
 			return
 		}
 
 		claims, err := a.jwtService.ParseToken(tokenHeader)
 		if err != nil {
-			log.Printf("RequireToken.ParseToken: %v \n", err.Error())
-			ctx.AbortWithStatus(http.StatusUnauthorized)
+			common.SendErrorResponse(ctx, errInvalidToken.Wrap(err))
 			return
 		}
 		ctx.Set("user", claims["username"])
+		if userId, ok := claims["userId"].(string); ok {
+			ctx.Request = ctx.Request.WithContext(logger.WithUserID(ctx.Request.Context(), userId))
+		}
 
 		validRole := false
 		// admin, user, other....
@@ -55,8 +62,7 @@ func (a *authMiddleware) RequireToken(roles ...string) gin.HandlerFunc {
 		}
 
 		if !validRole {
-			log.Printf("RequireToken.validRole\n")
-			ctx.AbortWithStatus(http.StatusForbidden)
+			common.SendErrorResponse(ctx, apperror.Forbidden(fmt.Sprintf("role %v may not access this resource", claims["role"])))
 			return
 		}
 
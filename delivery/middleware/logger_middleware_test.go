@@ -0,0 +1,44 @@
+package middleware
+
+import (
+	"booking-room-app/shared/logger"
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoggerMiddleware_RequestContextSuccess(t *testing.T) {
+	var buf bytes.Buffer
+	previous := slog.Default()
+	slog.SetDefault(logger.New(&buf, slog.LevelInfo))
+	defer slog.SetDefault(previous)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequestIDMiddleware(), LoggerMiddleware())
+	router.GET("/rooms/:id", func(c *gin.Context) {
+		// stands in for RequireToken
+		c.Request = c.Request.WithContext(logger.WithUserID(c.Request.Context(), "user-1"))
+		c.Status(http.StatusNotFound)
+	})
+
+	request, _ := http.NewRequest(http.MethodGet, "/rooms/1", nil)
+	request.Header.Set(RequestIDHeader, "abc-123")
+	router.ServeHTTP(httptest.NewRecorder(), request)
+
+	var line map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+	assert.Equal(t, "WARN", line["level"])
+	assert.Equal(t, "abc-123", line["requestId"])
+	assert.Equal(t, "user-1", line["userId"])
+	assert.Equal(t, "/rooms/:id", line["route"])
+	assert.Equal(t, "/rooms/1", line["path"])
+	assert.Equal(t, float64(http.StatusNotFound), line["status"])
+}
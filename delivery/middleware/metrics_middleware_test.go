@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"booking-room-app/shared/metrics"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricsMiddleware_RouteTemplateSuccess(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(MetricsMiddleware())
+	router.GET("/rooms/:id", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	before := testutil.CollectAndCount(metrics.HTTPRequestDuration)
+	for _, path := range []string{"/rooms/1", "/rooms/2", "/missing"} {
+		request, _ := http.NewRequest(http.MethodGet, path, nil)
+		router.ServeHTTP(httptest.NewRecorder(), request)
+	}
+
+	// one series per route template and status, not per path
+	assert.Equal(t, before+2, testutil.CollectAndCount(metrics.HTTPRequestDuration))
+	histogram := metrics.HTTPRequestDuration.WithLabelValues(http.MethodGet, "/rooms/:id", "200").(prometheus.Histogram)
+	var metric dto.Metric
+	assert.NoError(t, histogram.Write(&metric))
+	assert.Equal(t, uint64(2), metric.GetHistogram().GetSampleCount())
+}
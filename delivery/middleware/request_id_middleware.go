@@ -0,0 +1,53 @@
+package middleware
+
+import (
+	"booking-room-app/shared/logger"
+	"crypto/rand"
+	"encoding/hex"
+
+	"github.com/gin-gonic/gin"
+)
+
+const RequestIDHeader = "X-Request-ID"
+
+// RequestIDMiddleware reuses the caller's X-Request-ID, or generates one, and
+// stores it with the route template in the request context so every log line
+// of the request can be correlated.
+func RequestIDMiddleware() gin.HandlerFunc {
+	return func(ctx *gin.Context) {
+		id := ctx.GetHeader(RequestIDHeader)
+		if !validRequestID(id) {
+			id = newRequestID()
+		}
+		ctx.Header(RequestIDHeader, id)
+
+		reqCtx := logger.WithRequestID(ctx.Request.Context(), id)
+		if route := ctx.FullPath(); route != "" {
+			reqCtx = logger.WithRoute(reqCtx, route)
+		}
+		ctx.Request = ctx.Request.WithContext(reqCtx)
+		ctx.Next()
+	}
+}
+
+// validRequestID rejects IDs that are empty, too long or could forge log
+// lines, so clients cannot inject arbitrary content into the logs.
+func validRequestID(id string) bool {
+	if id == "" || len(id) > 128 {
+		return false
+	}
+	for _, r := range id {
+		if r < 0x21 || r > 0x7e {
+			return false
+		}
+	}
+	return true
+}
+
+func newRequestID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(b)
+}
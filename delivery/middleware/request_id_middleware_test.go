@@ -0,0 +1,50 @@
+package middleware
+
+import (
+	"booking-room-app/shared/logger"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+)
+
+func requestIDRouter(seen *string) *gin.Engine {
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	router.Use(RequestIDMiddleware())
+	router.GET("/rooms/:id", func(c *gin.Context) {
+		*seen = logger.RequestID(c.Request.Context())
+		c.Status(http.StatusOK)
+	})
+	return router
+}
+
+func TestRequestIDMiddleware_ReuseHeaderSuccess(t *testing.T) {
+	var seen string
+	request, _ := http.NewRequest(http.MethodGet, "/rooms/1", nil)
+	request.Header.Set(RequestIDHeader, "abc-123")
+	recorder := httptest.NewRecorder()
+
+	requestIDRouter(&seen).ServeHTTP(recorder, request)
+
+	assert.Equal(t, "abc-123", seen)
+	assert.Equal(t, "abc-123", recorder.Header().Get(RequestIDHeader))
+}
+
+func TestRequestIDMiddleware_GenerateSuccess(t *testing.T) {
+	for _, header := range []string{"", "has space", strings.Repeat("a", 129)} {
+		var seen string
+		request, _ := http.NewRequest(http.MethodGet, "/rooms/1", nil)
+		request.Header.Set(RequestIDHeader, header)
+		recorder := httptest.NewRecorder()
+
+		requestIDRouter(&seen).ServeHTTP(recorder, request)
+
+		assert.Len(t, seen, 32)
+		assert.NotEqual(t, header, seen)
+		assert.Equal(t, seen, recorder.Header().Get(RequestIDHeader))
+	}
+}
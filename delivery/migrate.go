@@ -0,0 +1,70 @@
+package delivery
+
+import (
+	"booking-room-app/migrations"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+const migrateUsage = "usage: migrate [up | down [n] | status | force <version>]"
+
+func runMigrate(ctx context.Context, migrator *migrations.Migrator, args []string, out io.Writer) error {
+	command := "up"
+	if len(args) > 0 {
+		command = args[0]
+	}
+
+	switch {
+	case command == "up" && len(args) <= 1:
+		applied, err := migrator.Up(ctx)
+		printMigrations(out, "applied", applied)
+		return err
+	case command == "down" && len(args) <= 2:
+		steps := 1
+		if len(args) == 2 {
+			n, err := strconv.Atoi(args[1])
+			if err != nil {
+				return fmt.Errorf("invalid number of steps %q", args[1])
+			}
+			steps = n
+		}
+		rolledBack, err := migrator.Down(ctx, steps)
+		printMigrations(out, "rolled back", rolledBack)
+		return err
+	case command == "status" && len(args) == 1:
+		statuses, err := migrator.Status(ctx)
+		if err != nil {
+			return err
+		}
+		for _, status := range statuses {
+			appliedAt := "pending"
+			if status.AppliedAt != nil {
+				appliedAt = status.AppliedAt.Format("2006-01-02 15:04:05")
+			}
+			fmt.Fprintf(out, "%06d_%s\t%s\n", status.Version, status.Name, appliedAt)
+		}
+		return nil
+	case command == "force" && len(args) == 2:
+		version, err := strconv.ParseInt(args[1], 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid version %q", args[1])
+		}
+		forced, err := migrator.Force(ctx, version)
+		printMigrations(out, "marked as applied", forced)
+		return err
+	}
+	return errors.New(migrateUsage)
+}
+
+func printMigrations(out io.Writer, verb string, list []migrations.Migration) {
+	if len(list) == 0 {
+		fmt.Fprintf(out, "no migrations %s\n", verb)
+		return
+	}
+	for _, migration := range list {
+		fmt.Fprintf(out, "%s %06d_%s\n", verb, migration.Version, migration.Name)
+	}
+}
@@ -0,0 +1,52 @@
+package delivery
+
+import (
+	"booking-room-app/config"
+	"booking-room-app/migrations"
+	"bytes"
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRunMigrate_StatusSuccess(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	migrator, err := migrations.New(db)
+	require.NoError(t, err)
+	first := migrator.Migrations()[0]
+	mock.ExpectQuery(regexp.QuoteMeta(config.SelectSchemaMigrations)).WillReturnRows(sqlmock.NewRows([]string{"version", "checksum", "applied_at"}).AddRow(first.Version, first.Checksum, time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)))
+
+	var out bytes.Buffer
+	err = runMigrate(context.Background(), migrator, []string{"status"}, &out)
+
+	assert.NoError(t, err)
+	assert.Contains(t, out.String(), "000001_initial_schema\t2024-01-02 03:04:05")
+	assert.Contains(t, out.String(), "000002_report_schedules\tpending")
+}
+
+func TestRunMigrate_UsageFailure(t *testing.T) {
+	migrator, err := migrations.New(nil)
+	require.NoError(t, err)
+
+	cases := [][]string{{"sideways"}, {"down", "two"}, {"force"}, {"force", "x"}, {"up", "now"}}
+	for _, args := range cases {
+		assert.Error(t, runMigrate(context.Background(), migrator, args, &bytes.Buffer{}), args)
+	}
+}
+
+func TestReadinessChecks_PendingMigrationsFailure(t *testing.T) {
+	db, mock, _ := sqlmock.New()
+	migrator, err := migrations.New(db)
+	require.NoError(t, err)
+	mock.ExpectQuery(regexp.QuoteMeta(config.SelectSchemaMigrations)).WillReturnRows(sqlmock.NewRows([]string{"version", "checksum", "applied_at"}))
+
+	s := &Server{migrator: migrator}
+	err = s.readinessChecks()["migrations"](context.Background())
+
+	assert.ErrorContains(t, err, "pending migrations")
+}
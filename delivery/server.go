@@ -4,89 +4,358 @@ import (
 	"booking-room-app/config"
 	"booking-room-app/delivery/controller"
 	"booking-room-app/delivery/middleware"
-	"booking-room-app/repository"
+	"booking-room-app/delivery/worker"
+	"booking-room-app/migrations"
+	"booking-room-app/shared/logger"
+	"booking-room-app/shared/metrics"
 	"booking-room-app/shared/service"
+	"booking-room-app/shared/tracing"
 	"booking-room-app/usecase"
+	"context"
 	"database/sql"
+	"errors"
 	"fmt"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"github.com/XSAM/otelsql"
 	"github.com/gin-gonic/gin"
 	_ "github.com/lib/pq"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
 )
 
 type Server struct {
-	roomUC         usecase.RoomUseCase
-	facilitiesUC   usecase.FacilitiesUseCase
-	employeeUC     usecase.EmployeesUseCase
-	roomFacilityUc usecase.RoomFacilityUsecase
-	transactionsUc usecase.TransactionsUsecase
-	reportUC       usecase.ReportUseCase
-	authUsc        usecase.AuthUseCase
-	engine         *gin.Engine
-	jwtService     service.JwtService
-	host           string
+	roomUC          usecase.RoomUseCase
+	locationUC      usecase.LocationUseCase
+	calendarUC      usecase.CalendarUseCase
+	maintenanceUC   usecase.MaintenanceUseCase
+	attachmentUC    usecase.AttachmentUseCase
+	roomAttributeUC usecase.RoomAttributeUseCase
+	facilitiesUC    usecase.FacilitiesUseCase
+	employeeUC      usecase.EmployeesUseCase
+	roomFacilityUc  usecase.RoomFacilityUsecase
+	stockAlertUC    usecase.StockAlertUseCase
+	facilityAssetUC usecase.FacilityAssetUseCase
+	transactionsUc  usecase.TransactionsUsecase
+	reportUC        usecase.ReportUseCase
+	reportSchUC     usecase.ReportScheduleUseCase
+	rateUC          usecase.RateUseCase
+	authUsc         usecase.AuthUseCase
+	engine          *gin.Engine
+	jwtService      service.JwtService
+	reportSch       *worker.Scheduler
+	maintenanceSch  *worker.Scheduler
+	stockAlertSch   *worker.Scheduler
+	db              *sql.DB
+	migrator        *migrations.Migrator
+	shutdownTracing func(context.Context) error
+	apiCfg          config.ApiConfig
+	maxUploadSize   int64
+	features        config.FeatureConfig
+	host            string
 }
 
 func (s *Server) initRoute() {
+	controller.NewHealthController(s.engine, s.readinessChecks()).Route()
+	if s.features.Metrics {
+		s.engine.GET(config.Metrics, gin.WrapH(promhttp.HandlerFor(metrics.Registry, promhttp.HandlerOpts{})))
+	}
+
 	rg := s.engine.Group(config.ApiGroup)
 
 	authMiddleware := middleware.NewAuthMiddleware(s.jwtService)
 	controller.NewRoomController(s.roomUC, authMiddleware, rg).Route()
+	controller.NewLocationController(s.locationUC, rg, authMiddleware).Route()
+	controller.NewCalendarController(s.calendarUC, rg, authMiddleware).Route()
+	controller.NewMaintenanceController(s.maintenanceUC, rg, authMiddleware).Route()
+	controller.NewAttachmentController(s.attachmentUC, s.maxUploadSize, rg, authMiddleware).Route()
+	controller.NewRoomAttributeController(s.roomAttributeUC, rg, authMiddleware).Route()
 	controller.NewFacilitiesController(s.facilitiesUC, rg, authMiddleware).Route()
 	controller.NewEmployeeController(s.employeeUC, rg, authMiddleware).Route()
 	controller.NewRoomFacilityController(s.roomFacilityUc, rg, authMiddleware).Route()
+	controller.NewStockAlertController(s.stockAlertUC, rg, authMiddleware).Route()
+	controller.NewFacilityAssetController(s.facilityAssetUC, rg, authMiddleware).Route()
 	controller.NewTransactionsController(s.transactionsUc, rg, authMiddleware).Route()
 	controller.NewAuthController(s.authUsc, rg).Route()
 	controller.NewReportController(s.reportUC, rg, authMiddleware).Route()
+	controller.NewReportScheduleController(s.reportSchUC, rg, authMiddleware).Route()
+	controller.NewRateController(s.rateUC, rg, authMiddleware).Route()
 }
 
-func (s *Server) Run() {
+func (s *Server) readinessChecks() map[string]controller.ReadinessCheck {
+	checks := make(map[string]controller.ReadinessCheck)
+	if s.db != nil {
+		checks["database"] = s.db.PingContext
+	}
+	if s.migrator != nil {
+		checks["migrations"] = func(ctx context.Context) error {
+			pending, err := s.migrator.Pending(ctx)
+			if err != nil {
+				return err
+			}
+			if len(pending) > 0 {
+				return fmt.Errorf("%d pending migrations, run the migrate command", len(pending))
+			}
+			return nil
+		}
+	}
+	if s.reportSch != nil {
+		checks["reportScheduler"] = func(ctx context.Context) error {
+			if !s.reportSch.Running() {
+				return errors.New("report scheduler is not running")
+			}
+			return nil
+		}
+	}
+	if s.maintenanceSch != nil {
+		checks["maintenanceScheduler"] = func(ctx context.Context) error {
+			if !s.maintenanceSch.Running() {
+				return errors.New("maintenance scheduler is not running")
+			}
+			return nil
+		}
+	}
+	if s.stockAlertSch != nil {
+		checks["stockAlertScheduler"] = func(ctx context.Context) error {
+			if !s.stockAlertSch.Running() {
+				return errors.New("stock alert scheduler is not running")
+			}
+			return nil
+		}
+	}
+	return checks
+}
+
+// Run serves until SIGINT or SIGTERM, then drains in-flight requests and
+// background workers before returning.
+func (s *Server) Run() error {
 	s.initRoute()
-	if err := s.engine.Run(s.host); err != nil {
-		panic(fmt.Errorf("server not running on host %s, becauce error %v", s.host, err.Error()))
+
+	listener, err := net.Listen("tcp", s.host)
+	if err != nil {
+		return fmt.Errorf("server not running on host %s, becauce error %v", s.host, err.Error())
 	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	return s.serve(ctx, listener)
 }
 
-func NewServer() *Server {
-	cfg, _ := config.NewConfig()
+func (s *Server) serve(ctx context.Context, listener net.Listener) error {
+	srv := &http.Server{
+		Handler:      s.engine,
+		ReadTimeout:  s.apiCfg.ReadTimeout,
+		WriteTimeout: s.apiCfg.WriteTimeout,
+		IdleTimeout:  s.apiCfg.IdleTimeout,
+	}
+
+	if s.reportSch != nil {
+		s.reportSch.Start()
+	}
+	if s.maintenanceSch != nil {
+		s.maintenanceSch.Start()
+	}
+	if s.stockAlertSch != nil {
+		s.stockAlertSch.Start()
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- srv.Serve(listener)
+	}()
+	slog.Info("server listening", "addr", listener.Addr().String())
+
+	var err error
+	select {
+	case err = <-serveErr:
+	case <-ctx.Done():
+		slog.Info("shutting down server")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), s.apiCfg.ShutdownTimeout)
+		defer cancel()
+		err = srv.Shutdown(shutdownCtx)
+	}
+
+	if s.reportSch != nil {
+		s.reportSch.Stop()
+	}
+	if s.maintenanceSch != nil {
+		s.maintenanceSch.Stop()
+	}
+	if s.stockAlertSch != nil {
+		s.stockAlertSch.Stop()
+	}
+	if s.db != nil {
+		if closeErr := s.db.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+	}
+
+	if s.shutdownTracing != nil {
+		// flush the spans of the last requests
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), s.apiCfg.ShutdownTimeout)
+		defer cancel()
+		if traceErr := s.shutdownTracing(shutdownCtx); traceErr != nil {
+			slog.Error("tracing shutdown", "err", traceErr)
+		}
+	}
+
+	if errors.Is(err, http.ErrServerClosed) {
+		return nil
+	}
+	return err
+}
 
-	dsn := fmt.Sprintf("host=%s port=%s user=%s password=%s dbname=%s sslmode=disable", cfg.Host, cfg.Port, cfg.User, cfg.Password, cfg.Name)
-	db, err := sql.Open(cfg.Driver, dsn)
+func NewServer(cfg *config.Config) (*Server, error) {
+	slog.SetDefault(logger.New(os.Stdout, cfg.LogLevel))
+
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.TracingConfig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to set up tracing: %v", err.Error())
+	}
+
+	db, err := openDB(cfg.DbConfig)
 	if err != nil {
-		panic(err.Error())
-	}
-
-	// Inject DB ke -> repository
-	roomRepo := repository.NewRoomRepository(db)
-	facilityRepo := repository.NewFasilitesRepository(db)
-	employeeRepo := repository.NewEmployeeRepository(db)
-	roomFacilityRepo := repository.NewRoomFacilityRepository(db)
-	transactionsRepo := repository.NewTransactionsRepository(db)
-	reportRepo := repository.NewReportRepository(db)
-
-	// Inject REPO ke -> useCase
-	roomUC := usecase.NewRoomUseCase(roomRepo)
-	facilitiesUC := usecase.NewFacilitiesUseCase(facilityRepo)
-	employeeUC := usecase.NewEmployeeUseCase(employeeRepo)
-	roomFacilityUc := usecase.NewRoomFacilityUsecase(roomFacilityRepo)
-	transactionsUc := usecase.NewTransactionsUsecase(transactionsRepo)
-	jwtService := service.NewJwtService(cfg.TokenConfig)
-	authUc := usecase.NewAuthUseCase(employeeUC, jwtService)
-	reportUC := usecase.NewReportUseCase(reportRepo)
-
-	engine := gin.Default()
+		shutdownTracing(context.Background())
+		return nil, err
+	}
+
+	migrator, err := migrations.New(db)
+	if err != nil {
+		db.Close()
+		shutdownTracing(context.Background())
+		return nil, err
+	}
+	if cfg.AutoMigrate {
+		if _, err := migrator.Up(context.Background()); err != nil {
+			db.Close()
+			shutdownTracing(context.Background())
+			return nil, fmt.Errorf("failed to migrate database: %v", err.Error())
+		}
+	}
+
+	uc := newUseCases(cfg, db)
+	attachmentUC, err := newAttachmentUseCase(cfg.StorageConfig, db, repositoryTimeouts(cfg.DbConfig))
+	if err != nil {
+		db.Close()
+		shutdownTracing(context.Background())
+		return nil, fmt.Errorf("failed to open the attachment store: %v", err.Error())
+	}
+
+	// scheduled reports can only be delivered when a mail server is configured
+	var reportScheduler *worker.Scheduler
+	switch {
+	case !cfg.FeatureConfig.ReportScheduler:
+		slog.Info("scheduled reports are disabled by FEATURE_REPORT_SCHEDULER")
+	case cfg.MailHost == "":
+		slog.Warn("MAIL_HOST is not set, scheduled reports are disabled")
+	default:
+		reportScheduler = worker.NewReportScheduler(uc.reportSchedule, cfg.ReportTimeout)
+	}
+
+	var maintenanceScheduler *worker.Scheduler
+	if cfg.FeatureConfig.MaintenanceScheduler {
+		maintenanceScheduler = worker.NewMaintenanceScheduler(uc.maintenance)
+	} else {
+		slog.Info("maintenance windows do not change the room status, disabled by FEATURE_MAINTENANCE_SCHEDULER")
+	}
+
+	// low-stock alerts are raised while a request is served but mailed from here
+	var stockAlertScheduler *worker.Scheduler
+	if cfg.FeatureConfig.StockAlertScheduler {
+		stockAlertScheduler = worker.NewStockAlertScheduler(uc.stockAlert)
+	} else {
+		slog.Info("stock is only checked when it is allocated, disabled by FEATURE_STOCK_ALERT_SCHEDULER")
+		if cfg.MailHost != "" && len(cfg.StockAlertRecipients) > 0 {
+			stockAlertScheduler = worker.NewStockAlertMailer(uc.stockAlert)
+		}
+	}
+
+	engine := gin.New()
+	engine.Use(
+		gin.Recovery(),
+		otelgin.Middleware(tracing.ServiceName, otelgin.WithFilter(tracedRequest)),
+		middleware.RequestIDMiddleware(),
+		middleware.LoggerMiddleware(),
+	)
+	if cfg.FeatureConfig.Metrics {
+		// the database pool is exported alongside the HTTP and domain metrics
+		metrics.Registry.MustRegister(collectors.NewDBStatsCollector(db, cfg.Name))
+		engine.Use(middleware.MetricsMiddleware())
+	}
+	if len(cfg.CorsOrigins) > 0 {
+		engine.Use(middleware.CORSMiddleware(cfg.CorsOrigins))
+	}
 	host := fmt.Sprintf(":%s", cfg.ApiPort)
 
 	return &Server{
-		authUsc:        authUc,
-		roomUC:         roomUC,
-		facilitiesUC:   facilitiesUC,
-		employeeUC:     employeeUC,
-		transactionsUc: transactionsUc,
-		roomFacilityUc: roomFacilityUc,
-		reportUC:       reportUC,
-		engine:         engine,
-		jwtService:     jwtService,
-		host:           host,
+		db:              db,
+		migrator:        migrator,
+		shutdownTracing: shutdownTracing,
+		apiCfg:          cfg.ApiConfig,
+		maxUploadSize:   cfg.MaxUploadSize,
+		features:        cfg.FeatureConfig,
+		authUsc:         uc.auth,
+		roomUC:          uc.room,
+		locationUC:      uc.location,
+		calendarUC:      uc.calendar,
+		maintenanceUC:   uc.maintenance,
+		attachmentUC:    attachmentUC,
+		roomAttributeUC: uc.roomAttribute,
+		facilitiesUC:    uc.facilities,
+		employeeUC:      uc.employee,
+		transactionsUc:  uc.transactions,
+		roomFacilityUc:  uc.roomFacility,
+		stockAlertUC:    uc.stockAlert,
+		facilityAssetUC: uc.facilityAsset,
+		reportUC:        uc.report,
+		reportSchUC:     uc.reportSchedule,
+		rateUC:          uc.rate,
+		reportSch:       reportScheduler,
+		maintenanceSch:  maintenanceScheduler,
+		stockAlertSch:   stockAlertScheduler,
+		engine:          engine,
+		jwtService:      uc.jwtService,
+		host:            host,
+	}, nil
+}
+
+// tracedRequest leaves probes and metric scrapes out of the traces.
+func tracedRequest(r *http.Request) bool {
+	switch r.URL.Path {
+	case config.HealthLive, config.HealthReady, config.Metrics:
+		return false
+	}
+	return true
+}
+
+// openDB applies the pool settings and fails fast when the database cannot be
+// reached, instead of on the first request.
+func openDB(cfg config.DbConfig) (*sql.DB, error) {
+	db, err := otelsql.Open(cfg.Driver, cfg.DSN(),
+		otelsql.WithAttributes(semconv.DBSystemPostgreSQL),
+		otelsql.WithSpanNameFormatter(tracing.SQLSpanName),
+		otelsql.WithSpanOptions(otelsql.SpanOptions{OmitConnResetSession: true, OmitRows: true}),
+	)
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(cfg.MaxOpenConns)
+	db.SetMaxIdleConns(cfg.MaxIdleConns)
+	db.SetConnMaxLifetime(cfg.ConnMaxLifetime)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("failed to connect to database %s:%s: %v", cfg.Host, cfg.Port, err.Error())
 	}
+	return db, nil
 }
@@ -0,0 +1,119 @@
+package delivery
+
+import (
+	"booking-room-app/config"
+	"booking-room-app/shared/tracing"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/gin-gonic/gin/otelgin"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestServe_DrainsInFlightRequestsOnShutdown(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	started := make(chan struct{})
+	engine.GET("/slow", func(c *gin.Context) {
+		close(started)
+		time.Sleep(200 * time.Millisecond)
+		c.String(http.StatusOK, "done")
+	})
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	s := &Server{engine: engine, apiCfg: config.ApiConfig{ShutdownTimeout: 5 * time.Second}}
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() {
+		served <- s.serve(ctx, listener)
+	}()
+
+	type result struct {
+		status int
+		body   string
+		err    error
+	}
+	responses := make(chan result, 1)
+	go func() {
+		resp, err := http.Get("http://" + listener.Addr().String() + "/slow")
+		if err != nil {
+			responses <- result{err: err}
+			return
+		}
+		defer resp.Body.Close()
+		body, _ := io.ReadAll(resp.Body)
+		responses <- result{status: resp.StatusCode, body: string(body)}
+	}()
+
+	<-started
+	cancel()
+
+	response := <-responses
+	require.NoError(t, response.err)
+	assert.Equal(t, http.StatusOK, response.status)
+	assert.Equal(t, "done", response.body)
+	assert.NoError(t, <-served)
+
+	_, err = net.DialTimeout("tcp", listener.Addr().String(), time.Second)
+	assert.Error(t, err)
+}
+
+func TestServe_ShutdownTimeoutFailure(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	engine := gin.New()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	engine.GET("/stuck", func(c *gin.Context) {
+		close(started)
+		<-release
+	})
+	defer close(release)
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	s := &Server{engine: engine, apiCfg: config.ApiConfig{ShutdownTimeout: 50 * time.Millisecond}}
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() {
+		served <- s.serve(ctx, listener)
+	}()
+
+	go http.Get("http://" + listener.Addr().String() + "/stuck")
+	<-started
+	cancel()
+
+	assert.ErrorIs(t, <-served, context.DeadlineExceeded)
+}
+
+func TestTracedRequest_RouteSpans(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	engine := gin.New()
+	engine.Use(otelgin.Middleware(tracing.ServiceName, otelgin.WithTracerProvider(provider), otelgin.WithFilter(tracedRequest)))
+	engine.GET(config.HealthLive, func(c *gin.Context) { c.Status(http.StatusOK) })
+	engine.GET(config.ApiGroup+config.RoomGetById, func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	for _, path := range []string{config.HealthLive, config.ApiGroup + "/rooms/1"} {
+		request, _ := http.NewRequest(http.MethodGet, path, nil)
+		engine.ServeHTTP(httptest.NewRecorder(), request)
+	}
+
+	spans := exporter.GetSpans()
+	if assert.Len(t, spans, 1) {
+		assert.Equal(t, "/api/v1/rooms/:id", spans[0].Name)
+	}
+}
@@ -0,0 +1,110 @@
+package delivery
+
+import (
+	"booking-room-app/config"
+	"booking-room-app/repository"
+	"booking-room-app/shared/service"
+	"booking-room-app/shared/storage"
+	"booking-room-app/usecase"
+	"database/sql"
+)
+
+// useCases is the business layer shared by the HTTP server and the CLI
+// commands, so both apply the same rules.
+type useCases struct {
+	room           usecase.RoomUseCase
+	calendar       usecase.CalendarUseCase
+	maintenance    usecase.MaintenanceUseCase
+	roomAttribute  usecase.RoomAttributeUseCase
+	location       usecase.LocationUseCase
+	facilities     usecase.FacilitiesUseCase
+	employee       usecase.EmployeesUseCase
+	roomFacility   usecase.RoomFacilityUsecase
+	stockAlert     usecase.StockAlertUseCase
+	facilityAsset  usecase.FacilityAssetUseCase
+	transactions   usecase.TransactionsUsecase
+	report         usecase.ReportUseCase
+	reportSchedule usecase.ReportScheduleUseCase
+	rate           usecase.RateUseCase
+	auth           usecase.AuthUseCase
+	jwtService     service.JwtService
+}
+
+func newUseCases(cfg *config.Config, db *sql.DB) useCases {
+	timeouts := repositoryTimeouts(cfg.DbConfig)
+
+	// Inject DB ke -> repository
+	roomRepo := repository.NewRoomRepository(db, timeouts)
+	locationRepo := repository.NewLocationRepository(db, timeouts)
+	facilityRepo := repository.NewFasilitesRepository(db, timeouts)
+	employeeRepo := repository.NewEmployeeRepository(db, timeouts)
+	roomFacilityRepo := repository.NewRoomFacilityRepository(db, timeouts)
+	transactionsRepo := repository.NewTransactionsRepository(db, timeouts)
+	reportRepo := repository.NewReportRepository(db, timeouts)
+	reportScheduleRepo := repository.NewReportScheduleRepository(db, timeouts)
+	rateRepo := repository.NewRateRepository(db, timeouts)
+	calendarRepo := repository.NewCalendarRepository(db, timeouts)
+	maintenanceRepo := repository.NewMaintenanceRepository(db, timeouts)
+	roomAttributeRepo := repository.NewRoomAttributeRepository(db, timeouts)
+	roomSearchRepo := repository.NewRoomSearchRepository(db, timeouts)
+	stockAlertRepo := repository.NewStockAlertRepository(db, timeouts)
+	facilityAssetRepo := repository.NewFacilityAssetRepository(db, timeouts)
+
+	// low-stock alerts are only listed in the API when no mail server is configured
+	var stockAlertRecipients []string
+	if cfg.MailHost != "" {
+		stockAlertRecipients = cfg.StockAlertRecipients
+	}
+	mailService := service.NewMailService(cfg.MailConfig)
+	stockAlert := usecase.NewStockAlertUseCase(stockAlertRepo, mailService, stockAlertRecipients)
+
+	// Inject REPO ke -> useCase
+	uc := useCases{
+		calendar:     usecase.NewCalendarUseCase(calendarRepo),
+		maintenance:  usecase.NewMaintenanceUseCase(maintenanceRepo),
+		location:     usecase.NewLocationUseCase(locationRepo),
+		facilities:   usecase.NewFacilitiesUseCase(facilityRepo, cfg.ArchivePolicy),
+		employee:     usecase.NewEmployeeUseCase(employeeRepo, cfg.ArchivePolicy),
+		roomFacility: usecase.NewRoomFacilityUsecase(roomFacilityRepo, stockAlert),
+		stockAlert:   stockAlert,
+		rate:         usecase.NewRateUseCase(rateRepo),
+		report:       usecase.NewReportUseCase(reportRepo),
+		jwtService:   service.NewJwtService(cfg.TokenConfig),
+	}
+	uc.room = usecase.NewRoomUseCase(roomRepo, cfg.ArchivePolicy)
+	uc.roomAttribute = usecase.NewRoomAttributeUseCase(roomAttributeRepo, roomSearchRepo, roomRepo)
+	uc.facilityAsset = usecase.NewFacilityAssetUseCase(facilityAssetRepo, uc.stockAlert)
+	uc.transactions = usecase.NewTransactionsUsecase(transactionsRepo, uc.rate, uc.calendar, uc.stockAlert)
+	uc.auth = usecase.NewAuthUseCase(uc.employee, uc.jwtService)
+	uc.reportSchedule = usecase.NewReportScheduleUseCase(reportScheduleRepo, uc.report, mailService)
+	return uc
+}
+
+// newAttachmentUseCase is kept apart from newUseCases because only the server
+// serves attachments and opening the blob store can fail.
+func newAttachmentUseCase(cfg config.StorageConfig, db *sql.DB, timeouts repository.Timeouts) (usecase.AttachmentUseCase, error) {
+	var store storage.BlobStore
+	var err error
+	switch cfg.StorageDriver {
+	case "s3":
+		store, err = storage.NewS3Store(storage.S3Config{
+			Endpoint:  cfg.S3Endpoint,
+			Region:    cfg.S3Region,
+			Bucket:    cfg.S3Bucket,
+			AccessKey: cfg.S3AccessKey,
+			SecretKey: cfg.S3SecretKey,
+		})
+	default:
+		store, err = storage.NewLocalStore(cfg.StorageDir)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return usecase.NewAttachmentUseCase(repository.NewAttachmentRepository(db, timeouts), store, cfg.MaxUploadSize), nil
+}
+
+// repositoryTimeouts are the deadlines of DB_QUERY_TIMEOUT and
+// DB_REPORT_TIMEOUT for every repository.
+func repositoryTimeouts(cfg config.DbConfig) repository.Timeouts {
+	return repository.Timeouts{Query: cfg.QueryTimeout, Report: cfg.ReportTimeout}
+}
@@ -0,0 +1,9 @@
+package worker
+
+import "booking-room-app/usecase"
+
+// NewMaintenanceScheduler takes rooms out of service when a maintenance
+// window begins and returns them to service when it ends.
+func NewMaintenanceScheduler(maintenanceUC usecase.MaintenanceUseCase) *Scheduler {
+	return newScheduler("MaintenanceScheduler.SyncRoomStatus", defaultTimeout, maintenanceUC.SyncRoomStatus)
+}
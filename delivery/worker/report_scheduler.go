@@ -0,0 +1,12 @@
+package worker
+
+import (
+	"booking-room-app/usecase"
+	"time"
+)
+
+// NewReportScheduler delivers the report schedules whose cron expression is
+// due, giving a run up to timeout to export and send them.
+func NewReportScheduler(reportScheduleUC usecase.ReportScheduleUseCase, timeout time.Duration) *Scheduler {
+	return newScheduler("ReportScheduler.RunDueSchedules", timeout, reportScheduleUC.RunDueSchedules)
+}
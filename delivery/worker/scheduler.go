@@ -0,0 +1,91 @@
+package worker
+
+import (
+	"booking-room-app/shared/logger"
+	"context"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// defaultTimeout bounds a run to the minute before the next one.
+const defaultTimeout = time.Minute
+
+// Scheduler wakes up at the start of every minute and runs its job with the
+// current time. A run gets a context that ends after timeout or when the
+// scheduler is stopped.
+type Scheduler struct {
+	name    string
+	run     func(ctx context.Context, now time.Time) error
+	timeout time.Duration
+	now     func() time.Time
+	cancel  context.CancelFunc
+	done    chan struct{}
+	mu      sync.Mutex
+	running bool
+}
+
+func (s *Scheduler) Start() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.running {
+		return
+	}
+
+	var ctx context.Context
+	ctx, s.cancel = context.WithCancel(logger.WithSystem(context.Background()))
+	s.done = make(chan struct{})
+	s.running = true
+	go s.loop(ctx, s.done)
+}
+
+// Stop cancels a run that is in progress and waits for it to return.
+func (s *Scheduler) Stop() {
+	s.mu.Lock()
+	if !s.running {
+		s.mu.Unlock()
+		return
+	}
+	s.running = false
+	s.cancel()
+	done := s.done
+	s.mu.Unlock()
+
+	<-done
+}
+
+func (s *Scheduler) Running() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.running
+}
+
+func (s *Scheduler) loop(ctx context.Context, done chan<- struct{}) {
+	defer close(done)
+
+	for {
+		now := s.now()
+		timer := time.NewTimer(now.Truncate(time.Minute).Add(time.Minute).Sub(now))
+
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+			s.runOnce(ctx)
+		}
+	}
+}
+
+func (s *Scheduler) runOnce(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeout)
+	defer cancel()
+
+	if err := s.run(ctx, s.now()); err != nil {
+		slog.ErrorContext(ctx, s.name, "err", err)
+	}
+}
+
+func newScheduler(name string, timeout time.Duration, run func(ctx context.Context, now time.Time) error) *Scheduler {
+	return &Scheduler{name: name, run: run, timeout: timeout, now: time.Now}
+}
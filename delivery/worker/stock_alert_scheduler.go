@@ -0,0 +1,15 @@
+package worker
+
+import "booking-room-app/usecase"
+
+// NewStockAlertScheduler raises alerts for the facilities below their stock
+// threshold and retries the notifications that could not be sent.
+func NewStockAlertScheduler(stockAlertUC usecase.StockAlertUseCase) *Scheduler {
+	return newScheduler("StockAlertScheduler.CheckStockLevels", defaultTimeout, stockAlertUC.CheckStockLevels)
+}
+
+// NewStockAlertMailer only sends the alerts raised when stock is allocated,
+// for when the check of every facility is turned off.
+func NewStockAlertMailer(stockAlertUC usecase.StockAlertUseCase) *Scheduler {
+	return newScheduler("StockAlertScheduler.SendAlerts", defaultTimeout, stockAlertUC.SendAlerts)
+}
@@ -0,0 +1,11 @@
+package entity
+
+import "time"
+
+// Archival is the result of archiving a room, facility or employee. It lists
+// the open bookings that were declined because they referred to the record.
+type Archival struct {
+	ID                   string    `json:"id"`
+	ArchivedAt           time.Time `json:"archivedAt"`
+	DeclinedTransactions []string  `json:"declinedTransactions"`
+}
@@ -0,0 +1,26 @@
+package entity
+
+import "time"
+
+// Owners an attachment can belong to.
+const (
+	AttachmentOwnerRoom     = "room"
+	AttachmentOwnerFacility = "facility"
+)
+
+// Attachment is a photo or document of a room or a facility. URL and
+// ThumbnailURL are where the file and, for images, its thumbnail are
+// downloaded; the blob store keys stay internal.
+type Attachment struct {
+	ID           string    `json:"id"`
+	OwnerType    string    `json:"ownerType"`
+	OwnerId      string    `json:"ownerId"`
+	Filename     string    `json:"filename"`
+	ContentType  string    `json:"contentType"`
+	Size         int64     `json:"size"`
+	URL          string    `json:"url"`
+	ThumbnailURL string    `json:"thumbnailUrl,omitempty"`
+	StorageKey   string    `json:"-"`
+	ThumbnailKey string    `json:"-"`
+	CreatedAt    time.Time `json:"createdAt"`
+}
@@ -0,0 +1,56 @@
+package entity
+
+import "time"
+
+// BusinessHours is one opening interval of a site or room on a weekday. Open
+// and Close are HH:MM in the local time of the site; Close may be 24:00.
+type BusinessHours struct {
+	ID      string       `json:"id"`
+	SiteId  string       `json:"siteId,omitempty"`
+	RoomId  string       `json:"roomId,omitempty"`
+	Weekday time.Weekday `json:"weekday"`
+	Open    string       `json:"open"`
+	Close   string       `json:"close"`
+}
+
+// WeeklyHours is the whole week of a site or a room. The hours of a room
+// replace those of its site; without any hours a room is always open.
+type WeeklyHours struct {
+	SiteId string          `json:"siteId,omitempty"`
+	RoomId string          `json:"roomId,omitempty"`
+	Hours  []BusinessHours `json:"hours"`
+}
+
+// Holiday closes a site, or every site when SiteId is empty, for a whole
+// local day. Date is formatted as 2006-01-02.
+type Holiday struct {
+	ID        string    `json:"id"`
+	SiteId    string    `json:"siteId,omitempty"`
+	Date      string    `json:"date"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// Blackout closes a room, the rooms of a site, or every room when both ids
+// are empty, for an ad-hoc period such as an event.
+type Blackout struct {
+	ID        string    `json:"id"`
+	SiteId    string    `json:"siteId,omitempty"`
+	RoomId    string    `json:"roomId,omitempty"`
+	Reason    string    `json:"reason"`
+	StartTime time.Time `json:"startTime"`
+	EndTime   time.Time `json:"endTime"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// RoomCalendar is what decides whether a room can be booked for a period:
+// the time zone of its site, the hours that apply to it, and the holidays,
+// blackouts and maintenance windows around the period.
+type RoomCalendar struct {
+	RoomId      string
+	Timezone    string
+	Hours       []BusinessHours
+	Holidays    []Holiday
+	Blackouts   []Blackout
+	Maintenance []Maintenance
+}
@@ -1,8 +1,8 @@
 package dto
 
 type AuthRequestDto struct {
-	User     string `json:"username"`
-	Password string `json:"password"`
+	User     string `json:"username" validate:"required,notblank"`
+	Password string `json:"password" validate:"required"`
 }
 
 type AuthResponseDto struct {
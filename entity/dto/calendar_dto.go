@@ -0,0 +1,89 @@
+package dto
+
+import (
+	"booking-room-app/entity"
+	"time"
+)
+
+// WeeklyHoursRequestDto is the body of PUT /business-hours. It replaces the
+// whole week of either a site or a room.
+type WeeklyHoursRequestDto struct {
+	SiteId string                    `json:"siteId" validate:"omitempty,uuid"`
+	RoomId string                    `json:"roomId" validate:"omitempty,uuid"`
+	Hours  []BusinessHoursRequestDto `json:"hours" validate:"max=50,dive"`
+}
+
+// BusinessHoursRequestDto is one opening interval; weekday 0 is Sunday.
+type BusinessHoursRequestDto struct {
+	Weekday int    `json:"weekday" validate:"min=0,max=6"`
+	Open    string `json:"open" validate:"required,clock"`
+	Close   string `json:"close" validate:"required,clock"`
+}
+
+func (d WeeklyHoursRequestDto) Entity() entity.WeeklyHours {
+	week := entity.WeeklyHours{SiteId: d.SiteId, RoomId: d.RoomId, Hours: []entity.BusinessHours{}}
+	for _, interval := range d.Hours {
+		week.Hours = append(week.Hours, entity.BusinessHours{
+			Weekday: time.Weekday(interval.Weekday),
+			Open:    interval.Open,
+			Close:   interval.Close,
+		})
+	}
+	return week
+}
+
+// HoursQueryDto is the query of GET /business-hours.
+type HoursQueryDto struct {
+	SiteId string `form:"siteId" json:"siteId" validate:"omitempty,uuid"`
+	RoomId string `form:"roomId" json:"roomId" validate:"omitempty,uuid"`
+}
+
+// HolidayRequestDto is the body of POST /holidays. Without siteId the holiday
+// closes every site.
+type HolidayRequestDto struct {
+	SiteId string `json:"siteId" validate:"omitempty,uuid"`
+	Date   string `json:"date" validate:"required,datetime=2006-01-02"`
+	Name   string `json:"name" validate:"required,notblank,max=200"`
+}
+
+func (d HolidayRequestDto) Entity() entity.Holiday {
+	return entity.Holiday{SiteId: d.SiteId, Date: d.Date, Name: d.Name}
+}
+
+// HolidayQueryDto is the query of GET /holidays and POST /holidays/import.
+type HolidayQueryDto struct {
+	SiteId string `form:"siteId" json:"siteId" validate:"omitempty,uuid"`
+	Year   int    `form:"year" json:"year" validate:"omitempty,min=1900,max=9999"`
+}
+
+// BlackoutRequestDto is the body of POST /blackouts. Set roomId or siteId to
+// close one room or one site; with neither every room is closed.
+type BlackoutRequestDto struct {
+	SiteId    string    `json:"siteId" validate:"omitempty,uuid"`
+	RoomId    string    `json:"roomId" validate:"omitempty,uuid"`
+	Reason    string    `json:"reason" validate:"required,notblank,max=200"`
+	StartTime time.Time `json:"startTime" validate:"required"`
+	EndTime   time.Time `json:"endTime" validate:"required,gtfield=StartTime"`
+}
+
+func (d BlackoutRequestDto) Entity() entity.Blackout {
+	return entity.Blackout{SiteId: d.SiteId, RoomId: d.RoomId, Reason: d.Reason, StartTime: d.StartTime, EndTime: d.EndTime}
+}
+
+// BlackoutQueryDto is the query of GET /blackouts in RFC 3339.
+type BlackoutQueryDto struct {
+	From time.Time `form:"from" json:"from"`
+	To   time.Time `form:"to" json:"to"`
+}
+
+// PeriodQueryDto is a startTime, endTime query in RFC 3339, e.g. the
+// availability search of GET /rooms.
+type PeriodQueryDto struct {
+	StartTime time.Time `form:"startTime" json:"startTime"`
+	EndTime   time.Time `form:"endTime" json:"endTime"`
+}
+
+// Empty reports whether neither end of the period is set.
+func (d PeriodQueryDto) Empty() bool {
+	return d.StartTime.IsZero() && d.EndTime.IsZero()
+}
@@ -0,0 +1,12 @@
+package dto
+
+import "time"
+
+type ChargebackDto struct {
+	Division     string    `json:"division"`
+	Period       time.Time `json:"period"`
+	Transactions int       `json:"transactions"`
+	RoomCost     int64     `json:"roomCost"`
+	FacilityCost int64     `json:"facilityCost"`
+	TotalCost    int64     `json:"totalCost"`
+}
@@ -0,0 +1,38 @@
+package dto
+
+import "booking-room-app/entity"
+
+// EmployeeRequestDto is the body of POST /employees.
+type EmployeeRequestDto struct {
+	Name     string `json:"name" validate:"required,notblank,max=50"`
+	Username string `json:"username" validate:"required,notblank,max=50"`
+	Password string `json:"password" validate:"required,min=6,max=72"`
+	Role     string `json:"role" validate:"required,role"`
+	Division string `json:"division" validate:"required,notblank,max=50"`
+	Position string `json:"position" validate:"required,notblank,max=50"`
+	Contact  string `json:"contact" validate:"required,notblank,max=20"`
+}
+
+func (d EmployeeRequestDto) Entity() entity.Employee {
+	return entity.Employee{
+		Name:     d.Name,
+		Username: d.Username,
+		Password: d.Password,
+		Role:     d.Role,
+		Division: d.Division,
+		Position: d.Position,
+		Contact:  d.Contact,
+	}
+}
+
+// UpdateEmployeeRequestDto is the body of PUT /employees.
+type UpdateEmployeeRequestDto struct {
+	ID string `json:"id" validate:"required,uuid"`
+	EmployeeRequestDto
+}
+
+func (d UpdateEmployeeRequestDto) Entity() entity.Employee {
+	employee := d.EmployeeRequestDto.Entity()
+	employee.ID = d.ID
+	return employee
+}
@@ -0,0 +1,69 @@
+package dto
+
+import "booking-room-app/entity"
+
+// FacilityRequestDto is the body of POST /facilities. A facility whose units
+// are tracked as assets starts without stock.
+type FacilityRequestDto struct {
+	Name     string `json:"name" validate:"required,notblank,min=3,max=100"`
+	Quantity int    `json:"quantity" validate:"gte=0"`
+}
+
+func (d FacilityRequestDto) Entity() entity.Facilities {
+	return entity.Facilities{Name: d.Name, Quantity: d.Quantity}
+}
+
+// UpdateFacilityRequestDto is the body of PUT /facilities. The stock is not
+// edited here but through POST /facilities/:id/movements.
+type UpdateFacilityRequestDto struct {
+	ID   string `json:"id" validate:"required,uuid"`
+	Name string `json:"name" validate:"required,notblank,min=3,max=100"`
+}
+
+func (d UpdateFacilityRequestDto) Entity() entity.Facilities {
+	return entity.Facilities{ID: d.ID, Name: d.Name}
+}
+
+// FacilityMovementRequestDto is the body of POST /facilities/:id/movements.
+// The quantity of an adjustment is signed, the others are positive counts.
+type FacilityMovementRequestDto struct {
+	Kind     string `json:"kind" validate:"required,oneof=purchase damage write_off adjustment"`
+	Quantity int    `json:"quantity" validate:"required"`
+	Reason   string `json:"reason" validate:"required,notblank,max=500"`
+}
+
+func (d FacilityMovementRequestDto) Entity(facilityId string) entity.FacilityMovement {
+	return entity.FacilityMovement{FacilityId: facilityId, Kind: d.Kind, Quantity: d.Quantity, Reason: d.Reason}
+}
+
+// FacilityAssetRequestDto is the body of POST /facilities/:id/assets and PUT
+// /assets/:id.
+type FacilityAssetRequestDto struct {
+	AssetTag     string `json:"assetTag" validate:"required,notblank,max=50"`
+	SerialNumber string `json:"serialNumber" validate:"max=100"`
+	Condition    string `json:"condition" validate:"required,oneof=new good fair damaged retired"`
+	PurchaseDate string `json:"purchaseDate" validate:"omitempty,datetime=2006-01-02"`
+	RoomId       string `json:"roomId" validate:"omitempty,uuid"`
+	Location     string `json:"location" validate:"max=200"`
+}
+
+func (d FacilityAssetRequestDto) Entity() entity.FacilityAsset {
+	return entity.FacilityAsset{AssetTag: d.AssetTag, SerialNumber: d.SerialNumber, Condition: d.Condition, PurchaseDate: d.PurchaseDate, RoomId: d.RoomId, Location: d.Location}
+}
+
+// AssetCheckOutRequestDto is the body of POST /assets/:id/checkout.
+type AssetCheckOutRequestDto struct {
+	TransactionId string `json:"transactionId" validate:"required,uuid"`
+}
+
+// AssetCheckInRequestDto is the body of POST /assets/:id/checkin. Without a
+// room or location the asset goes back where it was kept.
+type AssetCheckInRequestDto struct {
+	Condition string `json:"condition" validate:"required,oneof=new good fair damaged retired"`
+	RoomId    string `json:"roomId" validate:"omitempty,uuid"`
+	Location  string `json:"location" validate:"max=200"`
+}
+
+func (d AssetCheckInRequestDto) Entity(assetId string) entity.AssetCheckIn {
+	return entity.AssetCheckIn{AssetId: assetId, Condition: d.Condition, RoomId: d.RoomId, Location: d.Location}
+}
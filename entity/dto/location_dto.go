@@ -0,0 +1,84 @@
+package dto
+
+import "booking-room-app/entity"
+
+// SiteRequestDto is the body of POST /sites.
+type SiteRequestDto struct {
+	Name     string `json:"name" validate:"required,notblank,max=100"`
+	Address  string `json:"address" validate:"max=500"`
+	Timezone string `json:"timezone" validate:"omitempty,timezone,max=64"`
+}
+
+func (d SiteRequestDto) Entity() entity.Site {
+	return entity.Site{Name: d.Name, Address: d.Address, Timezone: d.Timezone}
+}
+
+// UpdateSiteRequestDto is the body of PUT /sites.
+type UpdateSiteRequestDto struct {
+	ID string `json:"id" validate:"required,uuid"`
+	SiteRequestDto
+}
+
+func (d UpdateSiteRequestDto) Entity() entity.Site {
+	site := d.SiteRequestDto.Entity()
+	site.ID = d.ID
+	return site
+}
+
+// BuildingRequestDto is the body of POST /buildings.
+type BuildingRequestDto struct {
+	SiteId string `json:"siteId" validate:"required,uuid"`
+	Name   string `json:"name" validate:"required,notblank,max=100"`
+}
+
+func (d BuildingRequestDto) Entity() entity.Building {
+	return entity.Building{SiteId: d.SiteId, Name: d.Name}
+}
+
+// UpdateBuildingRequestDto is the body of PUT /buildings.
+type UpdateBuildingRequestDto struct {
+	ID string `json:"id" validate:"required,uuid"`
+	BuildingRequestDto
+}
+
+func (d UpdateBuildingRequestDto) Entity() entity.Building {
+	building := d.BuildingRequestDto.Entity()
+	building.ID = d.ID
+	return building
+}
+
+// FloorRequestDto is the body of POST /floors. Level orders the floors of a
+// building; basements have a negative level.
+type FloorRequestDto struct {
+	BuildingId string `json:"buildingId" validate:"required,uuid"`
+	Name       string `json:"name" validate:"required,notblank,max=100"`
+	Level      int    `json:"level"`
+}
+
+func (d FloorRequestDto) Entity() entity.Floor {
+	return entity.Floor{BuildingId: d.BuildingId, Name: d.Name, Level: d.Level}
+}
+
+// UpdateFloorRequestDto is the body of PUT /floors.
+type UpdateFloorRequestDto struct {
+	ID string `json:"id" validate:"required,uuid"`
+	FloorRequestDto
+}
+
+func (d UpdateFloorRequestDto) Entity() entity.Floor {
+	floor := d.FloorRequestDto.Entity()
+	floor.ID = d.ID
+	return floor
+}
+
+// LocationFilterDto is the siteId, buildingId and floorId query of the room
+// and report endpoints.
+type LocationFilterDto struct {
+	SiteId     string `form:"siteId" json:"siteId" validate:"omitempty,uuid"`
+	BuildingId string `form:"buildingId" json:"buildingId" validate:"omitempty,uuid"`
+	FloorId    string `form:"floorId" json:"floorId" validate:"omitempty,uuid"`
+}
+
+func (d LocationFilterDto) Entity() entity.LocationFilter {
+	return entity.LocationFilter{SiteId: d.SiteId, BuildingId: d.BuildingId, FloorId: d.FloorId}
+}
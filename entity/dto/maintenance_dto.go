@@ -0,0 +1,43 @@
+package dto
+
+import (
+	"booking-room-app/entity"
+	"time"
+)
+
+// MaintenanceRequestDto is the body of POST /maintenance.
+type MaintenanceRequestDto struct {
+	RoomId     string    `json:"roomId" validate:"required,uuid"`
+	AssigneeId string    `json:"assigneeId" validate:"required,uuid"`
+	Reason     string    `json:"reason" validate:"required,notblank,max=500"`
+	StartTime  time.Time `json:"startTime" validate:"required"`
+	EndTime    time.Time `json:"endTime" validate:"required,gtfield=StartTime"`
+}
+
+func (d MaintenanceRequestDto) Entity() entity.Maintenance {
+	return entity.Maintenance{RoomId: d.RoomId, AssigneeId: d.AssigneeId, Reason: d.Reason, StartTime: d.StartTime, EndTime: d.EndTime}
+}
+
+// UpdateMaintenanceRequestDto is the body of PUT /maintenance. The room of a
+// window cannot be changed.
+type UpdateMaintenanceRequestDto struct {
+	ID         string    `json:"id" validate:"required,uuid"`
+	AssigneeId string    `json:"assigneeId" validate:"required,uuid"`
+	Reason     string    `json:"reason" validate:"required,notblank,max=500"`
+	StartTime  time.Time `json:"startTime" validate:"required"`
+	EndTime    time.Time `json:"endTime" validate:"required,gtfield=StartTime"`
+}
+
+func (d UpdateMaintenanceRequestDto) Entity() entity.Maintenance {
+	return entity.Maintenance{ID: d.ID, AssigneeId: d.AssigneeId, Reason: d.Reason, StartTime: d.StartTime, EndTime: d.EndTime}
+}
+
+// MaintenanceQueryDto is the query of GET /maintenance.
+type MaintenanceQueryDto struct {
+	RoomId string `form:"roomId" json:"roomId" validate:"omitempty,uuid"`
+	Open   bool   `form:"open" json:"open"`
+}
+
+func (d MaintenanceQueryDto) Entity() entity.MaintenanceFilter {
+	return entity.MaintenanceFilter{RoomId: d.RoomId, Open: d.Open}
+}
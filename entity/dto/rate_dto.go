@@ -0,0 +1,26 @@
+package dto
+
+import (
+	"booking-room-app/entity"
+	"time"
+)
+
+// RoomRateRequestDto is the body of POST /rooms/:id/rates.
+type RoomRateRequestDto struct {
+	HourlyRate    int64     `json:"hourlyRate" validate:"gte=0"`
+	EffectiveFrom time.Time `json:"effectiveFrom"`
+}
+
+func (d RoomRateRequestDto) Entity(roomId string) entity.RoomRate {
+	return entity.RoomRate{RoomId: roomId, HourlyRate: d.HourlyRate, EffectiveFrom: d.EffectiveFrom}
+}
+
+// FacilityRateRequestDto is the body of POST /facilities/:id/rates.
+type FacilityRateRequestDto struct {
+	UnitRate      int64     `json:"unitRate" validate:"gte=0"`
+	EffectiveFrom time.Time `json:"effectiveFrom"`
+}
+
+func (d FacilityRateRequestDto) Entity(facilityId string) entity.FacilityRate {
+	return entity.FacilityRate{FacilityId: facilityId, UnitRate: d.UnitRate, EffectiveFrom: d.EffectiveFrom}
+}
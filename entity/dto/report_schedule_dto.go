@@ -0,0 +1,45 @@
+package dto
+
+import "booking-room-app/entity"
+
+// ReportScheduleRequestDto is the body of POST /reports/schedules.
+type ReportScheduleRequestDto struct {
+	Name           string                 `json:"name" validate:"required,notblank,max=100"`
+	CronExpression string                 `json:"cronExpression" validate:"required,cron"`
+	Range          string                 `json:"range" validate:"required,report_range"`
+	Filter         ReportFilterRequestDto `json:"filter"`
+	Format         string                 `json:"format" validate:"omitempty,report_format"`
+	Recipients     []string               `json:"recipients" validate:"required,min=1,dive,email"`
+}
+
+type ReportFilterRequestDto struct {
+	Status   string `json:"status" validate:"omitempty,transaction_status"`
+	Division string `json:"division" validate:"max=50"`
+	RoomId   string `json:"roomId" validate:"omitempty,uuid"`
+	LocationFilterDto
+}
+
+func (d ReportScheduleRequestDto) Entity() entity.ReportSchedule {
+	return entity.ReportSchedule{
+		Name:           d.Name,
+		CronExpression: d.CronExpression,
+		Range:          d.Range,
+		Filter:         entity.ReportFilter{Status: d.Filter.Status, Division: d.Filter.Division, RoomId: d.Filter.RoomId, LocationFilter: d.Filter.LocationFilterDto.Entity()},
+		Format:         d.Format,
+		Recipients:     d.Recipients,
+	}
+}
+
+// UpdateReportScheduleRequestDto is the body of PUT /reports/schedules.
+type UpdateReportScheduleRequestDto struct {
+	ID string `json:"id" validate:"required,uuid"`
+	ReportScheduleRequestDto
+	IsActive bool `json:"isActive"`
+}
+
+func (d UpdateReportScheduleRequestDto) Entity() entity.ReportSchedule {
+	schedule := d.ReportScheduleRequestDto.Entity()
+	schedule.ID = d.ID
+	schedule.IsActive = d.IsActive
+	return schedule
+}
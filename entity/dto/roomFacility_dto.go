@@ -1,7 +1,46 @@
 package dto
 
+import "booking-room-app/entity"
+
 type RoomFacilityDto struct {
 	FacilityID string `json:"facilityId"`
 	Name       string `json:"name"`
 	Quantity   int    `json:"quantity"`
 }
+
+// RoomFacilityRequestDto is the body of POST /roomfacilities.
+type RoomFacilityRequestDto struct {
+	RoomId      string `json:"roomId" validate:"required,uuid"`
+	FacilityId  string `json:"facilityId" validate:"required,uuid"`
+	Quantity    int    `json:"quantity" validate:"required,gt=0"`
+	Description string `json:"description" validate:"max=500"`
+}
+
+func (d RoomFacilityRequestDto) Entity() entity.RoomFacility {
+	return entity.RoomFacility{RoomId: d.RoomId, FacilityId: d.FacilityId, Quantity: d.Quantity, Description: d.Description}
+}
+
+// UpdateRoomFacilityRequestDto is the body of PUT /roomfacilities. Fields
+// left empty keep their current value, but at least one must be given.
+type UpdateRoomFacilityRequestDto struct {
+	ID          string `json:"id" validate:"required,uuid"`
+	RoomId      string `json:"roomId" validate:"required_without_all=FacilityId Quantity,omitempty,uuid"`
+	FacilityId  string `json:"facilityId" validate:"omitempty,uuid"`
+	Quantity    int    `json:"quantity" validate:"gte=0"`
+	Description string `json:"description" validate:"max=500"`
+}
+
+func (d UpdateRoomFacilityRequestDto) Entity() entity.RoomFacility {
+	return entity.RoomFacility{ID: d.ID, RoomId: d.RoomId, FacilityId: d.FacilityId, Quantity: d.Quantity, Description: d.Description}
+}
+
+// RoomFacilityTransferRequestDto is the body of POST /roomfacilities/transfer.
+type RoomFacilityTransferRequestDto struct {
+	RoomFacilityId string `json:"roomFacilityId" validate:"required,uuid"`
+	RoomId         string `json:"roomId" validate:"required,uuid"`
+	Quantity       int    `json:"quantity" validate:"required,gt=0"`
+}
+
+func (d RoomFacilityTransferRequestDto) Entity() entity.RoomFacilityTransfer {
+	return entity.RoomFacilityTransfer{RoomFacilityId: d.RoomFacilityId, RoomId: d.RoomId, Quantity: d.Quantity}
+}
@@ -0,0 +1,93 @@
+package dto
+
+import (
+	"booking-room-app/entity"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// RoomAttributeRequestDto is the body of POST /roomattributes. Options are
+// the values of an enum.
+type RoomAttributeRequestDto struct {
+	Key     string   `json:"key" validate:"required,max=50"`
+	Name    string   `json:"name" validate:"required,notblank,max=100"`
+	Type    string   `json:"type" validate:"required,oneof=boolean number text enum"`
+	Options []string `json:"options" validate:"omitempty,dive,required,notblank,max=50"`
+}
+
+func (d RoomAttributeRequestDto) Entity() entity.RoomAttribute {
+	return entity.RoomAttribute{Key: d.Key, Name: d.Name, Type: d.Type, Options: d.Options}
+}
+
+// UpdateRoomAttributeRequestDto is the body of PUT /roomattributes. The key
+// and type of an attribute cannot be changed.
+type UpdateRoomAttributeRequestDto struct {
+	ID      string   `json:"id" validate:"required,uuid"`
+	Name    string   `json:"name" validate:"required,notblank,max=100"`
+	Options []string `json:"options" validate:"omitempty,dive,required,notblank,max=50"`
+}
+
+func (d UpdateRoomAttributeRequestDto) Entity() entity.RoomAttribute {
+	return entity.RoomAttribute{ID: d.ID, Name: d.Name, Options: d.Options}
+}
+
+// RoomAttributesRequestDto is the body of PUT /rooms/:id/attributes, the
+// values of the room by attribute key.
+type RoomAttributesRequestDto struct {
+	Attributes map[string]any `json:"attributes" validate:"required"`
+}
+
+// RoomSearchQueryDto is the query of GET /rooms/search, apart from the
+// attr.<key> filters read by AttributeFilters.
+type RoomSearchQueryDto struct {
+	Query       string `form:"q" json:"q" validate:"max=100"`
+	Status      string `form:"status" json:"status" validate:"omitempty,room_status"`
+	RoomType    string `form:"roomType" json:"roomType" validate:"omitempty,room_type"`
+	MinCapacity int    `form:"minCapacity" json:"minCapacity" validate:"gte=0"`
+	MaxCapacity int    `form:"maxCapacity" json:"maxCapacity" validate:"gte=0"`
+	LocationFilterDto
+	Sort        string `form:"sort" json:"sort" validate:"omitempty,oneof=name capacity -capacity distance"`
+	NearFloorId string `form:"nearFloorId" json:"nearFloorId" validate:"omitempty,uuid"`
+}
+
+func (d RoomSearchQueryDto) Entity() entity.RoomSearch {
+	return entity.RoomSearch{
+		Query:       strings.TrimSpace(d.Query),
+		Status:      d.Status,
+		RoomType:    d.RoomType,
+		MinCapacity: d.MinCapacity,
+		MaxCapacity: d.MaxCapacity,
+		Location:    d.LocationFilterDto.Entity(),
+		Sort:        d.Sort,
+		NearFloorId: d.NearFloorId,
+	}
+}
+
+// AttributeFilters reads the attr.<key> parameters of a search query, in key
+// order. A value is a comma separated list, e.g. attr.layout=boardroom,theatre,
+// or for numbers a range, e.g. attr.seats=10..20 with either bound optional.
+func AttributeFilters(query url.Values) []entity.AttributeFilter {
+	var filters []entity.AttributeFilter
+	for name, values := range query {
+		key, ok := strings.CutPrefix(name, "attr.")
+		if !ok {
+			continue
+		}
+		filter := entity.AttributeFilter{Key: key}
+		for _, value := range values {
+			if low, high, ok := strings.Cut(value, ".."); ok {
+				filter.Min, filter.Max = strings.TrimSpace(low), strings.TrimSpace(high)
+				continue
+			}
+			for _, v := range strings.Split(value, ",") {
+				if v = strings.TrimSpace(v); v != "" {
+					filter.Values = append(filter.Values, v)
+				}
+			}
+		}
+		filters = append(filters, filter)
+	}
+	sort.Slice(filters, func(i, j int) bool { return filters[i].Key < filters[j].Key })
+	return filters
+}
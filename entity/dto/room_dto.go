@@ -0,0 +1,38 @@
+package dto
+
+import "booking-room-app/entity"
+
+// RoomRequestDto is the body of POST /rooms.
+type RoomRequestDto struct {
+	Name     string `json:"name" validate:"required,notblank,max=100"`
+	RoomType string `json:"room_type" validate:"required,room_type"`
+	Capacity int    `json:"capacity" validate:"required,gt=0"`
+	Status   string `json:"status" validate:"required,room_status"`
+	FloorId  string `json:"floor_id" validate:"omitempty,uuid"`
+}
+
+func (d RoomRequestDto) Entity() entity.Room {
+	return entity.Room{Name: d.Name, RoomType: d.RoomType, Capacity: d.Capacity, Status: d.Status, FloorId: d.FloorId}
+}
+
+// UpdateRoomRequestDto is the body of PUT /rooms.
+type UpdateRoomRequestDto struct {
+	ID string `json:"id" validate:"required,uuid"`
+	RoomRequestDto
+}
+
+func (d UpdateRoomRequestDto) Entity() entity.Room {
+	room := d.RoomRequestDto.Entity()
+	room.ID = d.ID
+	return room
+}
+
+// RoomStatusRequestDto is the body of PUT /rooms/status.
+type RoomStatusRequestDto struct {
+	ID     string `json:"id" validate:"required,uuid"`
+	Status string `json:"status" validate:"required,room_status"`
+}
+
+func (d RoomStatusRequestDto) Entity() entity.Room {
+	return entity.Room{ID: d.ID, Status: d.Status}
+}
@@ -0,0 +1,18 @@
+package dto
+
+import "booking-room-app/entity"
+
+// StockThresholdRequestDto is the body of PUT /facilities/:id/threshold. A
+// minimum of 0 turns the alerts of the facility off.
+type StockThresholdRequestDto struct {
+	MinQuantity *int `json:"minQuantity" validate:"required,gte=0"`
+}
+
+func (d StockThresholdRequestDto) Entity(facilityId string) entity.StockThreshold {
+	return entity.StockThreshold{FacilityId: facilityId, MinQuantity: *d.MinQuantity}
+}
+
+// StockAlertQueryDto is the query of GET /stockalerts.
+type StockAlertQueryDto struct {
+	Status string `form:"status" json:"status" validate:"omitempty,oneof=open resolved"`
+}
@@ -0,0 +1,50 @@
+package dto
+
+import (
+	"booking-room-app/entity"
+	"time"
+)
+
+// TransactionRequestDto is the body of POST /transactions.
+type TransactionRequestDto struct {
+	EmployeeId  string                          `json:"employeeId" validate:"required,uuid"`
+	RoomId      string                          `json:"roomId" validate:"required,uuid"`
+	Facilities  []TransactionFacilityRequestDto `json:"facilities" validate:"omitempty,dive"`
+	Description string                          `json:"description" validate:"max=500"`
+	StartTime   time.Time                       `json:"startTime" validate:"required"`
+	EndTime     time.Time                       `json:"endTime" validate:"required,gtfield=StartTime"`
+}
+
+type TransactionFacilityRequestDto struct {
+	FacilityId  string `json:"facilityId" validate:"required,uuid"`
+	Quantity    int    `json:"quantity" validate:"required,gt=0"`
+	Description string `json:"description" validate:"max=500"`
+}
+
+func (d TransactionRequestDto) Entity() entity.Transaction {
+	transaction := entity.Transaction{
+		EmployeeId:  d.EmployeeId,
+		RoomId:      d.RoomId,
+		Description: d.Description,
+		StartTime:   d.StartTime,
+		EndTime:     d.EndTime,
+	}
+	for _, facility := range d.Facilities {
+		transaction.Facilities = append(transaction.Facilities, entity.TransactionFacility{
+			FacilityId:  facility.FacilityId,
+			Quantity:    facility.Quantity,
+			Description: facility.Description,
+		})
+	}
+	return transaction
+}
+
+// TransactionStatusRequestDto is the body of PUT /transactions/status.
+type TransactionStatusRequestDto struct {
+	ID     string `json:"id" validate:"required,uuid"`
+	Status string `json:"status" validate:"required,oneof=accepted declined"`
+}
+
+func (d TransactionStatusRequestDto) Entity() entity.Transaction {
+	return entity.Transaction{ID: d.ID, Status: d.Status}
+}
@@ -3,14 +3,15 @@ package entity
 import "time"
 
 type Employee struct {
-	ID        string    `json:"id"`
-	Name      string    `json:"name"`
-	Username  string    `json:"username"`
-	Password  string    `json:"password"`
-	Role      string    `json:"role"`
-	Division  string    `json:"division"`
-	Position  string    `json:"position"`
-	Contact   string    `json:"contact"`
-	CreatedAt time.Time `json:"createdAt"`
-	UpdatedAt time.Time `json:"updatedAt"`
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Username   string     `json:"username"`
+	Password   string     `json:"password"`
+	Role       string     `json:"role"`
+	Division   string     `json:"division"`
+	Position   string     `json:"position"`
+	Contact    string     `json:"contact"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	UpdatedAt  time.Time  `json:"updatedAt"`
+	ArchivedAt *time.Time `json:"archivedAt,omitempty"`
 }
@@ -0,0 +1,64 @@
+package entity
+
+import "time"
+
+// Conditions of a facility asset.
+const (
+	AssetConditionNew     = "new"
+	AssetConditionGood    = "good"
+	AssetConditionFair    = "fair"
+	AssetConditionDamaged = "damaged"
+	AssetConditionRetired = "retired"
+)
+
+// Serviceable reports whether an asset in condition can be used, and so is
+// counted in the stock of its facility.
+func Serviceable(condition string) bool {
+	switch condition {
+	case AssetConditionNew, AssetConditionGood, AssetConditionFair:
+		return true
+	}
+	return false
+}
+
+// FacilityAsset is one unit of a tracked facility, such as a laptop or a
+// projector. RoomId or Location is where it is kept; TransactionId and
+// CheckedOutAt are set while it is checked out to a booking. PurchaseDate is
+// formatted as 2006-01-02.
+type FacilityAsset struct {
+	ID            string     `json:"id"`
+	FacilityId    string     `json:"facilityId"`
+	AssetTag      string     `json:"assetTag"`
+	SerialNumber  string     `json:"serialNumber,omitempty"`
+	Condition     string     `json:"condition"`
+	PurchaseDate  string     `json:"purchaseDate,omitempty"`
+	RoomId        string     `json:"roomId,omitempty"`
+	Location      string     `json:"location,omitempty"`
+	TransactionId string     `json:"transactionId,omitempty"`
+	CheckedOutAt  *time.Time `json:"checkedOutAt,omitempty"`
+	CreatedAt     time.Time  `json:"createdAt"`
+	UpdatedAt     time.Time  `json:"updatedAt"`
+}
+
+// AssetCheckout is the check-out of an asset to a booking. Condition is the
+// condition the asset was checked in with, empty while it is still out.
+// TransactionId is empty once the booking was purged.
+type AssetCheckout struct {
+	ID            string     `json:"id"`
+	AssetId       string     `json:"assetId"`
+	TransactionId string     `json:"transactionId,omitempty"`
+	CheckedOutBy  string     `json:"checkedOutBy,omitempty"`
+	CheckedOutAt  time.Time  `json:"checkedOutAt"`
+	CheckedInBy   string     `json:"checkedInBy,omitempty"`
+	CheckedInAt   *time.Time `json:"checkedInAt,omitempty"`
+	Condition     string     `json:"condition,omitempty"`
+}
+
+// AssetCheckIn returns a checked out asset in Condition. An empty RoomId and
+// Location keep where the asset was kept before.
+type AssetCheckIn struct {
+	AssetId   string
+	Condition string
+	RoomId    string
+	Location  string
+}
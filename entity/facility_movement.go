@@ -0,0 +1,29 @@
+package entity
+
+import "time"
+
+// Kinds of a facility stock movement.
+const (
+	MovementPurchase   = "purchase"
+	MovementAllocation = "allocation"
+	MovementRelease    = "release"
+	MovementDamage     = "damage"
+	MovementWriteOff   = "write_off"
+	MovementAdjustment = "adjustment"
+)
+
+// FacilityMovement is an entry of the stock ledger of a facility. Quantity is
+// signed, negative when stock leaves, and Balance is the stock after it.
+// ActorId is the employee who made the change and ReferenceId the room
+// facility or booking it was made for, when there is one.
+type FacilityMovement struct {
+	ID          string    `json:"id"`
+	FacilityId  string    `json:"facilityId"`
+	Kind        string    `json:"kind"`
+	Quantity    int       `json:"quantity"`
+	Balance     int       `json:"balance"`
+	ActorId     string    `json:"actorId,omitempty"`
+	Reason      string    `json:"reason"`
+	ReferenceId string    `json:"referenceId,omitempty"`
+	CreatedAt   time.Time `json:"createdAt"`
+}
@@ -0,0 +1,70 @@
+package entity
+
+import "time"
+
+// Site is an office location. Its timezone is an IANA name such as
+// Asia/Jakarta.
+type Site struct {
+	ID        string    `json:"id"`
+	Name      string    `json:"name"`
+	Address   string    `json:"address"`
+	Timezone  string    `json:"timezone"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type Building struct {
+	ID        string    `json:"id"`
+	SiteId    string    `json:"siteId"`
+	Name      string    `json:"name"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+type Floor struct {
+	ID         string    `json:"id"`
+	BuildingId string    `json:"buildingId"`
+	Name       string    `json:"name"`
+	Level      int       `json:"level"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+}
+
+// RoomLocation is where a room is, from its floor up to the site.
+type RoomLocation struct {
+	SiteId       string `json:"siteId"`
+	SiteName     string `json:"siteName"`
+	Timezone     string `json:"timezone"`
+	BuildingId   string `json:"buildingId"`
+	BuildingName string `json:"buildingName"`
+	FloorId      string `json:"floorId"`
+	FloorName    string `json:"floorName"`
+	Level        int    `json:"level"`
+}
+
+// LocationFilter narrows rooms down to a site, building or floor. Empty
+// fields match everything.
+type LocationFilter struct {
+	SiteId     string `json:"siteId,omitempty"`
+	BuildingId string `json:"buildingId,omitempty"`
+	FloorId    string `json:"floorId,omitempty"`
+}
+
+// Empty reports whether the filter matches every room.
+func (f LocationFilter) Empty() bool {
+	return f.SiteId == "" && f.BuildingId == "" && f.FloorId == ""
+}
+
+// Matches reports whether a room at location passes the filter. Rooms
+// without a location only pass an empty filter.
+func (f LocationFilter) Matches(location *RoomLocation) bool {
+	if f.Empty() {
+		return true
+	}
+	if location == nil {
+		return false
+	}
+	return (f.SiteId == "" || f.SiteId == location.SiteId) &&
+		(f.BuildingId == "" || f.BuildingId == location.BuildingId) &&
+		(f.FloorId == "" || f.FloorId == location.FloorId)
+}
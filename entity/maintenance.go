@@ -0,0 +1,35 @@
+package entity
+
+import "time"
+
+// Maintenance is a scheduled window in which a room is out of service.
+// StartedAt and CompletedAt are set when the room is taken out of and
+// returned to service.
+type Maintenance struct {
+	ID          string        `json:"id"`
+	RoomId      string        `json:"roomId"`
+	AssigneeId  string        `json:"assigneeId"`
+	Reason      string        `json:"reason"`
+	StartTime   time.Time     `json:"startTime"`
+	EndTime     time.Time     `json:"endTime"`
+	StartedAt   *time.Time    `json:"startedAt,omitempty"`
+	CompletedAt *time.Time    `json:"completedAt,omitempty"`
+	Conflicts   []Transaction `json:"conflicts,omitempty"`
+	CreatedAt   time.Time     `json:"createdAt"`
+	UpdatedAt   time.Time     `json:"updatedAt"`
+}
+
+// MaintenanceConflict is an accepted booking that overlaps a maintenance
+// window of its room and has to be relocated.
+type MaintenanceConflict struct {
+	MaintenanceId string      `json:"maintenanceId"`
+	Reason        string      `json:"reason"`
+	Transaction   Transaction `json:"transaction"`
+}
+
+// MaintenanceFilter narrows GET /maintenance to a room, to the windows that
+// are not completed yet, or both.
+type MaintenanceFilter struct {
+	RoomId string
+	Open   bool
+}
@@ -0,0 +1,32 @@
+package entity
+
+import "time"
+
+// Rates are stored in the smallest currency unit. A rate applies from
+// EffectiveFrom until the next rate of the same room or facility.
+type RoomRate struct {
+	ID            string    `json:"id"`
+	RoomId        string    `json:"roomId"`
+	HourlyRate    int64     `json:"hourlyRate"`
+	EffectiveFrom time.Time `json:"effectiveFrom"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+type FacilityRate struct {
+	ID            string    `json:"id"`
+	FacilityId    string    `json:"facilityId"`
+	UnitRate      int64     `json:"unitRate"`
+	EffectiveFrom time.Time `json:"effectiveFrom"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
+
+// TransactionCost is the cost of an accepted transaction, computed with the
+// rates in effect at its start time.
+type TransactionCost struct {
+	TransactionId string    `json:"transactionId"`
+	HourlyRate    int64     `json:"hourlyRate"`
+	RoomCost      int64     `json:"roomCost"`
+	FacilityCost  int64     `json:"facilityCost"`
+	TotalCost     int64     `json:"totalCost"`
+	CreatedAt     time.Time `json:"createdAt"`
+}
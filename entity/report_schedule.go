@@ -0,0 +1,24 @@
+package entity
+
+import "time"
+
+type ReportFilter struct {
+	Status   string `json:"status,omitempty"`
+	Division string `json:"division,omitempty"`
+	RoomId   string `json:"roomId,omitempty"`
+	LocationFilter
+}
+
+type ReportSchedule struct {
+	ID             string       `json:"id"`
+	Name           string       `json:"name"`
+	CronExpression string       `json:"cronExpression"`
+	Range          string       `json:"range"`
+	Filter         ReportFilter `json:"filter"`
+	Format         string       `json:"format"`
+	Recipients     []string     `json:"recipients"`
+	IsActive       bool         `json:"isActive"`
+	LastRunAt      *time.Time   `json:"lastRunAt"`
+	CreatedAt      time.Time    `json:"createdAt"`
+	UpdatedAt      time.Time    `json:"updatedAt"`
+}
@@ -0,0 +1,81 @@
+package entity
+
+import "time"
+
+// Types of a room attribute.
+const (
+	AttributeBoolean = "boolean"
+	AttributeNumber  = "number"
+	AttributeText    = "text"
+	AttributeEnum    = "enum"
+)
+
+// RoomAttribute is an admin-defined property of rooms, such as
+// video_conference or layout. Options lists the values of an enum.
+type RoomAttribute struct {
+	ID        string    `json:"id"`
+	Key       string    `json:"key"`
+	Name      string    `json:"name"`
+	Type      string    `json:"type"`
+	Options   []string  `json:"options,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// RoomAttributeValue is the value of an attribute for one room, in the text
+// form it is stored in.
+type RoomAttributeValue struct {
+	RoomId      string
+	AttributeId string
+	Key         string
+	Type        string
+	Value       string
+}
+
+// AttributeFilter keeps the rooms whose attribute Key has one of Values or,
+// for numbers, lies between Min and Max. Empty bounds are open.
+type AttributeFilter struct {
+	Key    string
+	Values []string
+	Min    string
+	Max    string
+}
+
+// RoomSearch is the query of GET /rooms/search. Empty fields match every
+// room. Sort is name, capacity, -capacity or distance, the number of floors
+// between a room and NearFloorId in the same building.
+type RoomSearch struct {
+	Query       string
+	Status      string
+	RoomType    string
+	MinCapacity int
+	MaxCapacity int
+	Location    LocationFilter
+	Attributes  []AttributeFilter
+	Sort        string
+	NearFloorId string
+}
+
+// RoomSearchItem is a room found by a search, with its attributes and, when
+// sorting by distance, how many floors it is away.
+type RoomSearchItem struct {
+	Room
+	Attributes map[string]any `json:"attributes"`
+	Distance   *int           `json:"distance,omitempty"`
+}
+
+// FacetCount is how many of the found rooms have a value.
+type FacetCount struct {
+	Value string `json:"value"`
+	Label string `json:"label,omitempty"`
+	Count int    `json:"count"`
+}
+
+// RoomFacets counts the found rooms by status, room type, building and the
+// values of the boolean and enum attributes.
+type RoomFacets struct {
+	Status     []FacetCount            `json:"status"`
+	RoomType   []FacetCount            `json:"roomType"`
+	Building   []FacetCount            `json:"building"`
+	Attributes map[string][]FacetCount `json:"attributes"`
+}
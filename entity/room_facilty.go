@@ -12,3 +12,13 @@ type RoomFacility struct {
 	UpdatedAt   time.Time `json:"updatedAt"`
 }
 
+// RoomFacilityTransfer moves Quantity of the allocation RoomFacilityId to the
+// room RoomId. Source is what is left of the allocation, with a Quantity of 0
+// when all of it moved, and Target is the allocation in the other room.
+type RoomFacilityTransfer struct {
+	RoomFacilityId string       `json:"roomFacilityId"`
+	RoomId         string       `json:"roomId"`
+	Quantity       int          `json:"quantity"`
+	Source         RoomFacility `json:"source"`
+	Target         RoomFacility `json:"target"`
+}
@@ -3,11 +3,14 @@ package entity
 import "time"
 
 type Room struct {
-	ID        string    `json:"id"`
-	Name      string    `json:"name"`
-	RoomType  string    `json:"room_type"`
-	Capacity  int       `json:"capacity"`
-	Status    string    `json:"status"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID         string        `json:"id"`
+	Name       string        `json:"name"`
+	RoomType   string        `json:"room_type"`
+	Capacity   int           `json:"capacity"`
+	Status     string        `json:"status"`
+	FloorId    string        `json:"floor_id,omitempty"`
+	Location   *RoomLocation `json:"location,omitempty"`
+	CreatedAt  time.Time     `json:"created_at"`
+	UpdatedAt  time.Time     `json:"updated_at"`
+	ArchivedAt *time.Time    `json:"archived_at,omitempty"`
 }
@@ -0,0 +1,28 @@
+package entity
+
+import "time"
+
+// StockThreshold is the reorder threshold of a facility next to its stock.
+// A MinQuantity of 0 turns the low-stock alerts of the facility off.
+type StockThreshold struct {
+	FacilityId  string    `json:"facilityId"`
+	MinQuantity int       `json:"minQuantity"`
+	Quantity    int       `json:"quantity"`
+	UpdatedAt   time.Time `json:"updatedAt"`
+}
+
+// StockAlert says that the stock of a facility fell below its threshold.
+// Quantity and MinQuantity are the values when it was raised. It is resolved
+// once the stock is back at the threshold.
+type StockAlert struct {
+	ID             string     `json:"id"`
+	FacilityId     string     `json:"facilityId"`
+	FacilityName   string     `json:"facilityName"`
+	Quantity       int        `json:"quantity"`
+	MinQuantity    int        `json:"minQuantity"`
+	NotifiedAt     *time.Time `json:"notifiedAt,omitempty"`
+	AcknowledgedBy string     `json:"acknowledgedBy,omitempty"`
+	AcknowledgedAt *time.Time `json:"acknowledgedAt,omitempty"`
+	ResolvedAt     *time.Time `json:"resolvedAt,omitempty"`
+	CreatedAt      time.Time  `json:"createdAt"`
+}
@@ -0,0 +1,13 @@
+package entity
+
+import "time"
+
+type TransactionFacility struct {
+	ID            string    `json:"id"`
+	TransactionId string    `json:"transactionId,omitempty"`
+	FacilityId    string    `json:"facilityId"`
+	Quantity      int       `json:"quantity"`
+	Description   string    `json:"description"`
+	CreatedAt     time.Time `json:"createdAt"`
+	UpdatedAt     time.Time `json:"updatedAt"`
+}
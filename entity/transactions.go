@@ -6,7 +6,8 @@ type Transaction struct {
 	ID          string `json:"id"`
 	EmployeeId  string `json:"employeeId"`
 	RoomId      string `json:"roomId"`
-	RoomFacilities []RoomFacility `json:"roomFacilities,omitempty"`
+	Facilities []TransactionFacility `json:"facilities,omitempty"`
+	Cost        *TransactionCost `json:"cost,omitempty"`
 	Description string `json:"description"`
 	Status      string `json:"status"`
 	StartTime time.Time `json:"startTime"`
@@ -2,8 +2,16 @@ package main
 
 import (
 	"booking-room-app/delivery"
+	"log"
+	"os"
+
+	// site time zones are validated and applied without relying on the
+	// zoneinfo of the host
+	_ "time/tzdata"
 )
 
 func main() {
-	delivery.NewServer().Run()
+	if err := delivery.Execute(os.Args[1:]); err != nil {
+		log.Fatal(err)
+	}
 }
@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS transactions;
+DROP TYPE IF EXISTS transaction_status;
+DROP TABLE IF EXISTS trx_room_facility;
+DROP TABLE IF EXISTS rooms;
+DROP TYPE IF EXISTS status_type;
+DROP TABLE IF EXISTS facilities;
+DROP TABLE IF EXISTS employees;
+DROP TYPE IF EXISTS role_type;
@@ -0,0 +1,15 @@
+CREATE TABLE report_schedules (
+    id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+    name VARCHAR(100) NOT NULL,
+    cron_expression VARCHAR(100) NOT NULL,
+    range_param VARCHAR(10) NOT NULL,
+    filter_status VARCHAR(20) NOT NULL DEFAULT '',
+    filter_division VARCHAR(50) NOT NULL DEFAULT '',
+    filter_room_id VARCHAR(36) NOT NULL DEFAULT '',
+    format VARCHAR(10) NOT NULL DEFAULT 'csv',
+    recipients TEXT[] NOT NULL,
+    is_active BOOLEAN NOT NULL DEFAULT TRUE,
+    last_run_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,13 @@
+CREATE TABLE transaction_facilities (
+    id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+    transaction_id  uuid NOT NULL,
+    facility_id     uuid NOT NULL,
+    quantity        INT NOT NULL,
+    description TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (transaction_id) REFERENCES transactions(id),
+    FOREIGN KEY (facility_id) REFERENCES facilities(id)
+);
+
+CREATE INDEX idx_transaction_facilities_transaction_id ON transaction_facilities(transaction_id);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS transaction_costs;
+DROP TABLE IF EXISTS facility_rates;
+DROP TABLE IF EXISTS room_rates;
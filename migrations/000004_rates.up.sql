@@ -0,0 +1,29 @@
+CREATE TABLE room_rates (
+    id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+    room_id uuid NOT NULL,
+    hourly_rate BIGINT NOT NULL CHECK (hourly_rate >= 0),
+    effective_from TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (room_id) REFERENCES rooms(id),
+    UNIQUE (room_id, effective_from)
+);
+
+CREATE TABLE facility_rates (
+    id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+    facility_id uuid NOT NULL,
+    unit_rate BIGINT NOT NULL CHECK (unit_rate >= 0),
+    effective_from TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (facility_id) REFERENCES facilities(id),
+    UNIQUE (facility_id, effective_from)
+);
+
+CREATE TABLE transaction_costs (
+    transaction_id uuid PRIMARY KEY,
+    hourly_rate BIGINT NOT NULL,
+    room_cost BIGINT NOT NULL,
+    facility_cost BIGINT NOT NULL,
+    total_cost BIGINT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (transaction_id) REFERENCES transactions(id)
+);
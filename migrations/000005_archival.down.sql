@@ -0,0 +1,7 @@
+DROP INDEX IF EXISTS idx_transaction_facilities_facility_id;
+DROP INDEX IF EXISTS idx_transactions_employee_id_end_time;
+DROP INDEX IF EXISTS idx_transactions_room_id_end_time;
+
+ALTER TABLE employees DROP COLUMN IF EXISTS archived_at;
+ALTER TABLE facilities DROP COLUMN IF EXISTS archived_at;
+ALTER TABLE rooms DROP COLUMN IF EXISTS archived_at;
@@ -0,0 +1,7 @@
+ALTER TABLE rooms ADD COLUMN archived_at TIMESTAMP;
+ALTER TABLE facilities ADD COLUMN archived_at TIMESTAMP;
+ALTER TABLE employees ADD COLUMN archived_at TIMESTAMP;
+
+CREATE INDEX idx_transactions_room_id_end_time ON transactions(room_id, end_time);
+CREATE INDEX idx_transactions_employee_id_end_time ON transactions(employee_id, end_time);
+CREATE INDEX idx_transaction_facilities_facility_id ON transaction_facilities(facility_id);
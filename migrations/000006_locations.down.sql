@@ -0,0 +1,10 @@
+ALTER TABLE report_schedules DROP COLUMN IF EXISTS filter_floor_id;
+ALTER TABLE report_schedules DROP COLUMN IF EXISTS filter_building_id;
+ALTER TABLE report_schedules DROP COLUMN IF EXISTS filter_site_id;
+
+DROP INDEX IF EXISTS idx_rooms_floor_id;
+ALTER TABLE rooms DROP COLUMN IF EXISTS floor_id;
+
+DROP TABLE IF EXISTS floors;
+DROP TABLE IF EXISTS buildings;
+DROP TABLE IF EXISTS sites;
@@ -0,0 +1,36 @@
+CREATE TABLE sites (
+    id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+    name VARCHAR(100) UNIQUE NOT NULL,
+    address TEXT NOT NULL DEFAULT '',
+    timezone VARCHAR(64) NOT NULL DEFAULT 'UTC',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE buildings (
+    id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+    site_id uuid NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (site_id) REFERENCES sites(id),
+    UNIQUE (site_id, name)
+);
+
+CREATE TABLE floors (
+    id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+    building_id uuid NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    level INT NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    FOREIGN KEY (building_id) REFERENCES buildings(id),
+    UNIQUE (building_id, level)
+);
+
+ALTER TABLE rooms ADD COLUMN floor_id uuid REFERENCES floors(id);
+CREATE INDEX idx_rooms_floor_id ON rooms(floor_id);
+
+ALTER TABLE report_schedules ADD COLUMN filter_site_id VARCHAR(36) NOT NULL DEFAULT '';
+ALTER TABLE report_schedules ADD COLUMN filter_building_id VARCHAR(36) NOT NULL DEFAULT '';
+ALTER TABLE report_schedules ADD COLUMN filter_floor_id VARCHAR(36) NOT NULL DEFAULT '';
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS blackouts;
+DROP TABLE IF EXISTS holidays;
+DROP TABLE IF EXISTS business_hours;
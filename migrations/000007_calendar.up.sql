@@ -0,0 +1,39 @@
+-- Weekly opening hours belong to a site or to a room; the hours of a room
+-- replace those of its site. Times are local to the site.
+CREATE TABLE business_hours (
+    id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+    site_id uuid REFERENCES sites(id),
+    room_id uuid REFERENCES rooms(id),
+    weekday SMALLINT NOT NULL CHECK (weekday BETWEEN 0 AND 6),
+    open_time TIME NOT NULL,
+    close_time TIME NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK ((site_id IS NULL) <> (room_id IS NULL)),
+    CHECK (open_time < close_time)
+);
+CREATE INDEX idx_business_hours_site_id ON business_hours(site_id);
+CREATE INDEX idx_business_hours_room_id ON business_hours(room_id);
+
+-- A holiday without a site closes every site.
+CREATE TABLE holidays (
+    id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+    site_id uuid REFERENCES sites(id),
+    date DATE NOT NULL,
+    name VARCHAR(200) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+CREATE UNIQUE INDEX idx_holidays_site_date ON holidays (COALESCE(site_id, '00000000-0000-0000-0000-000000000000'::uuid), date);
+
+-- A blackout closes one room, every room of a site, or with neither set
+-- every room.
+CREATE TABLE blackouts (
+    id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+    site_id uuid REFERENCES sites(id),
+    room_id uuid REFERENCES rooms(id),
+    reason VARCHAR(200) NOT NULL,
+    start_time TIMESTAMP NOT NULL,
+    end_time TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (end_time > start_time)
+);
+CREATE INDEX idx_blackouts_period ON blackouts(start_time, end_time);
@@ -0,0 +1,18 @@
+-- A maintenance window takes a room out of service. started_at and
+-- completed_at record when the room was taken out and returned to service.
+CREATE TABLE room_maintenance (
+    id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+    room_id uuid NOT NULL REFERENCES rooms(id),
+    assignee_id uuid NOT NULL REFERENCES employees(id),
+    reason TEXT NOT NULL,
+    start_time TIMESTAMP NOT NULL,
+    end_time TIMESTAMP NOT NULL,
+    started_at TIMESTAMP,
+    completed_at TIMESTAMP,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    CHECK (end_time > start_time)
+);
+
+CREATE INDEX idx_room_maintenance_room_id_end_time ON room_maintenance(room_id, end_time);
+CREATE INDEX idx_room_maintenance_open ON room_maintenance(start_time, end_time) WHERE completed_at IS NULL;
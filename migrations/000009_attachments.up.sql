@@ -0,0 +1,16 @@
+-- Attachments are the photos and documents of a room or a facility. The file
+-- itself lives in the blob store under storage_key; images also get a
+-- thumbnail under thumbnail_key.
+CREATE TABLE attachments (
+    id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+    owner_type VARCHAR(20) NOT NULL CHECK (owner_type IN ('room', 'facility')),
+    owner_id uuid NOT NULL,
+    filename VARCHAR(255) NOT NULL,
+    content_type VARCHAR(100) NOT NULL,
+    size BIGINT NOT NULL CHECK (size > 0),
+    storage_key TEXT NOT NULL UNIQUE,
+    thumbnail_key TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_attachments_owner ON attachments(owner_type, owner_id);
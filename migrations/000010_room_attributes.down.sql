@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS room_attribute_values;
+DROP TABLE IF EXISTS room_attributes;
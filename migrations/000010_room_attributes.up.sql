@@ -0,0 +1,28 @@
+-- Room attributes are defined by the admins. A value is stored as text in the
+-- form of its type: true or false, a number, free text or one of the options
+-- of an enum.
+CREATE TABLE room_attributes (
+    id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+    key VARCHAR(50) NOT NULL UNIQUE,
+    name VARCHAR(100) NOT NULL,
+    type VARCHAR(20) NOT NULL CHECK (type IN ('boolean', 'number', 'text', 'enum')),
+    options TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE room_attribute_values (
+    room_id uuid NOT NULL REFERENCES rooms(id),
+    attribute_id uuid NOT NULL REFERENCES room_attributes(id) ON DELETE CASCADE,
+    value TEXT NOT NULL,
+    PRIMARY KEY (room_id, attribute_id)
+);
+
+CREATE INDEX idx_room_attribute_values_attribute_id_value ON room_attribute_values(attribute_id, value);
+
+INSERT INTO room_attributes (key, name, type, options) VALUES
+    ('video_conference', 'Video conferencing', 'boolean', '{}'),
+    ('whiteboard', 'Whiteboard', 'boolean', '{}'),
+    ('wheelchair_accessible', 'Wheelchair accessible', 'boolean', '{}'),
+    ('natural_light', 'Natural light', 'boolean', '{}'),
+    ('layout', 'Layout', 'enum', '{boardroom,classroom,theatre,u_shape,open}');
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS facility_movements;
+DROP FUNCTION IF EXISTS refuse_facility_movement_change();
+DROP FUNCTION IF EXISTS apply_facility_movement();
@@ -0,0 +1,57 @@
+-- The stock of a facility is the sum of its movements. quantity is signed:
+-- purchases and releases add stock, allocations, damage and write-offs take
+-- it away, adjustments do either. balance is the stock after the movement.
+CREATE TABLE facility_movements (
+    id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+    facility_id uuid NOT NULL REFERENCES facilities(id),
+    kind VARCHAR(20) NOT NULL CHECK (kind IN ('purchase', 'allocation', 'release', 'damage', 'write_off', 'adjustment')),
+    quantity INT NOT NULL,
+    balance INT NOT NULL,
+    actor_id uuid,
+    reason TEXT NOT NULL DEFAULT '',
+    reference_id uuid,
+    created_at TIMESTAMP NOT NULL DEFAULT clock_timestamp(),
+    CHECK (
+        (kind IN ('purchase', 'release') AND quantity > 0) OR
+        (kind IN ('allocation', 'damage', 'write_off') AND quantity < 0) OR
+        (kind = 'adjustment' AND quantity <> 0)
+    )
+);
+
+CREATE INDEX idx_facility_movements_facility_id_created_at ON facility_movements(facility_id, created_at);
+
+-- The stock recorded so far becomes the opening balance of the ledger.
+INSERT INTO facility_movements (facility_id, kind, quantity, balance, reason, created_at)
+SELECT id, 'adjustment', quantity, quantity, 'opening balance', created_at FROM facilities WHERE quantity <> 0;
+
+-- facilities.quantity is kept as the running balance: every movement is
+-- applied to it under the row lock of the facility, and a movement that
+-- would leave the stock negative is refused.
+CREATE FUNCTION apply_facility_movement() RETURNS trigger AS $$
+BEGIN
+    UPDATE facilities SET quantity = quantity + NEW.quantity, updated_at = CURRENT_TIMESTAMP
+    WHERE id = NEW.facility_id
+    RETURNING quantity INTO NEW.balance;
+    IF NOT FOUND THEN
+        RAISE EXCEPTION 'facility % does not exist', NEW.facility_id
+            USING ERRCODE = 'foreign_key_violation', DETAIL = format('Key (facility_id)=(%s) is not present in table "facilities".', NEW.facility_id);
+    END IF;
+    IF NEW.balance < 0 THEN
+        RAISE EXCEPTION 'facility % has % in stock, % requested', NEW.facility_id, NEW.balance - NEW.quantity, -NEW.quantity
+            USING ERRCODE = 'check_violation', CONSTRAINT = 'facility_movements_stock';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER facility_movements_apply BEFORE INSERT ON facility_movements
+FOR EACH ROW EXECUTE FUNCTION apply_facility_movement();
+
+CREATE FUNCTION refuse_facility_movement_change() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'facility movements are append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER facility_movements_append_only BEFORE UPDATE OR DELETE ON facility_movements
+FOR EACH ROW EXECUTE FUNCTION refuse_facility_movement_change();
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS facility_stock_alerts;
+ALTER TABLE facilities DROP COLUMN IF EXISTS min_quantity;
@@ -0,0 +1,20 @@
+-- min_quantity is the reorder threshold of a facility, 0 when it has none.
+ALTER TABLE facilities ADD COLUMN min_quantity INT NOT NULL DEFAULT 0 CHECK (min_quantity >= 0);
+
+-- An alert is raised when the stock of a facility falls below its threshold
+-- and resolved when the stock is back, so a facility has at most one open
+-- alert. quantity and min_quantity are the values when it was raised.
+CREATE TABLE facility_stock_alerts (
+    id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+    facility_id uuid NOT NULL REFERENCES facilities(id),
+    quantity INT NOT NULL,
+    min_quantity INT NOT NULL,
+    notified_at TIMESTAMP,
+    acknowledged_by uuid,
+    acknowledged_at TIMESTAMP,
+    resolved_at TIMESTAMP,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX idx_facility_stock_alerts_open ON facility_stock_alerts(facility_id) WHERE resolved_at IS NULL;
+CREATE INDEX idx_facility_stock_alerts_created_at ON facility_stock_alerts(created_at);
@@ -0,0 +1,20 @@
+CREATE OR REPLACE FUNCTION apply_facility_movement() RETURNS trigger AS $$
+BEGIN
+    UPDATE facilities SET quantity = quantity + NEW.quantity, updated_at = CURRENT_TIMESTAMP
+    WHERE id = NEW.facility_id
+    RETURNING quantity INTO NEW.balance;
+    IF NOT FOUND THEN
+        RAISE EXCEPTION 'facility % does not exist', NEW.facility_id
+            USING ERRCODE = 'foreign_key_violation', DETAIL = format('Key (facility_id)=(%s) is not present in table "facilities".', NEW.facility_id);
+    END IF;
+    IF NEW.balance < 0 THEN
+        RAISE EXCEPTION 'facility % has % in stock, % requested', NEW.facility_id, NEW.balance - NEW.quantity, -NEW.quantity
+            USING ERRCODE = 'check_violation', CONSTRAINT = 'facility_movements_stock';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+DROP TABLE IF EXISTS facility_asset_checkouts;
+DROP TABLE IF EXISTS facility_assets;
+ALTER TABLE facilities DROP COLUMN IF EXISTS tracked;
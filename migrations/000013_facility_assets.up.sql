@@ -0,0 +1,64 @@
+-- A tracked facility counts its units one by one as assets instead of by a
+-- plain quantity. Its stock follows its serviceable assets through the
+-- ledger, so only movements that come from one of its assets are accepted.
+ALTER TABLE facilities ADD COLUMN tracked BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- new, good and fair assets are serviceable and counted in the stock of
+-- their facility, damaged and retired ones are not. room_id or location is
+-- where the asset is kept when it is not checked out.
+CREATE TABLE facility_assets (
+    id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+    facility_id uuid NOT NULL REFERENCES facilities(id),
+    asset_tag VARCHAR(50) NOT NULL UNIQUE,
+    serial_number VARCHAR(100) NOT NULL DEFAULT '',
+    condition VARCHAR(20) NOT NULL CHECK (condition IN ('new', 'good', 'fair', 'damaged', 'retired')),
+    purchase_date DATE,
+    room_id uuid REFERENCES rooms(id),
+    location TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_facility_assets_facility_id ON facility_assets(facility_id);
+
+-- An asset is checked out to a booking until it is checked in, with the
+-- condition it came back in. The history outlives the purged bookings.
+CREATE TABLE facility_asset_checkouts (
+    id uuid DEFAULT uuid_generate_v4() PRIMARY KEY,
+    asset_id uuid NOT NULL REFERENCES facility_assets(id),
+    transaction_id uuid REFERENCES transactions(id) ON DELETE SET NULL,
+    checked_out_by uuid,
+    checked_out_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    checked_in_by uuid,
+    checked_in_at TIMESTAMP,
+    condition VARCHAR(20)
+);
+
+CREATE UNIQUE INDEX idx_facility_asset_checkouts_open ON facility_asset_checkouts(asset_id) WHERE checked_in_at IS NULL;
+CREATE INDEX idx_facility_asset_checkouts_asset_id_checked_out_at ON facility_asset_checkouts(asset_id, checked_out_at);
+CREATE INDEX idx_facility_asset_checkouts_transaction_id ON facility_asset_checkouts(transaction_id) WHERE checked_in_at IS NULL;
+
+CREATE OR REPLACE FUNCTION apply_facility_movement() RETURNS trigger AS $$
+BEGIN
+    UPDATE facilities SET quantity = quantity + NEW.quantity, updated_at = CURRENT_TIMESTAMP
+    WHERE id = NEW.facility_id
+    RETURNING quantity INTO NEW.balance;
+    IF NOT FOUND THEN
+        RAISE EXCEPTION 'facility % does not exist', NEW.facility_id
+            USING ERRCODE = 'foreign_key_violation', DETAIL = format('Key (facility_id)=(%s) is not present in table "facilities".', NEW.facility_id);
+    END IF;
+    IF NEW.balance < 0 THEN
+        RAISE EXCEPTION 'facility % has % in stock, % requested', NEW.facility_id, NEW.balance - NEW.quantity, -NEW.quantity
+            USING ERRCODE = 'check_violation', CONSTRAINT = 'facility_movements_stock';
+    END IF;
+    -- allocations and releases move units of a tracked facility around,
+    -- everything else must be done to one of its assets
+    IF NEW.kind NOT IN ('allocation', 'release')
+        AND EXISTS (SELECT 1 FROM facilities WHERE id = NEW.facility_id AND tracked)
+        AND NOT EXISTS (SELECT 1 FROM facility_assets WHERE id = NEW.reference_id AND facility_id = NEW.facility_id) THEN
+        RAISE EXCEPTION 'facility % is tracked by asset', NEW.facility_id
+            USING ERRCODE = 'check_violation', CONSTRAINT = 'facility_movements_tracked';
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
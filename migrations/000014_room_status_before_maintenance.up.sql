@@ -0,0 +1,8 @@
+-- status_before_maintenance is the status a room had when a maintenance
+-- window took it out of service, restored when it returns to service. It is
+-- NULL when the room is not out of service for maintenance.
+ALTER TABLE rooms ADD COLUMN status_before_maintenance status_type;
+
+UPDATE rooms SET status_before_maintenance = 'available'
+WHERE status = 'unavailable'
+  AND EXISTS (SELECT 1 FROM room_maintenance m WHERE m.room_id = rooms.id AND m.started_at IS NOT NULL AND m.completed_at IS NULL);
@@ -0,0 +1,221 @@
+package migrations
+
+import (
+	"booking-room-app/config"
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+var testFiles = fstest.MapFS{
+	"000001_create_rooms.up.sql":      {Data: []byte("CREATE TABLE rooms (id uuid PRIMARY KEY);")},
+	"000001_create_rooms.down.sql":    {Data: []byte("DROP TABLE rooms;")},
+	"000002_add_room_name.up.sql":     {Data: []byte("ALTER TABLE rooms ADD COLUMN name TEXT;")},
+	"000002_add_room_name.down.sql":   {Data: []byte("ALTER TABLE rooms DROP COLUMN name;")},
+	"000010_create_bookings.up.sql":   {Data: []byte("CREATE TABLE bookings (id uuid PRIMARY KEY);")},
+	"000010_create_bookings.down.sql": {Data: []byte("DROP TABLE bookings;")},
+}
+
+type MigratorTestSuite struct {
+	suite.Suite
+	mockDb   *sql.DB
+	mockSql  sqlmock.Sqlmock
+	migrator *Migrator
+}
+
+func (suite *MigratorTestSuite) SetupTest() {
+	db, mock, _ := sqlmock.New()
+	suite.mockDb = db
+	suite.mockSql = mock
+
+	migrator, err := NewFromFS(db, testFiles)
+	require.NoError(suite.T(), err)
+	suite.migrator = migrator
+}
+
+func (suite *MigratorTestSuite) expectLock() {
+	suite.mockSql.ExpectExec(regexp.QuoteMeta(config.LockSchemaMigrations)).WithArgs(lockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+	suite.mockSql.ExpectExec(regexp.QuoteMeta(config.CreateSchemaMigrations)).WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func (suite *MigratorTestSuite) expectUnlock() {
+	suite.mockSql.ExpectExec(regexp.QuoteMeta(config.UnlockSchemaMigrations)).WithArgs(lockKey).WillReturnResult(sqlmock.NewResult(0, 0))
+}
+
+func (suite *MigratorTestSuite) appliedRows(versions ...int64) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"version", "checksum", "applied_at"})
+	for _, version := range versions {
+		migration, _ := suite.migrator.find(version)
+		rows.AddRow(version, migration.Checksum, time.Now())
+	}
+	return rows
+}
+
+func (suite *MigratorTestSuite) TestLoad_Success() {
+	migrations := suite.migrator.Migrations()
+
+	require.Len(suite.T(), migrations, 3)
+	assert.Equal(suite.T(), []int64{1, 2, 10}, []int64{migrations[0].Version, migrations[1].Version, migrations[2].Version})
+	assert.Equal(suite.T(), "add_room_name", migrations[1].Name)
+	assert.Len(suite.T(), migrations[0].Checksum, 64)
+}
+
+func (suite *MigratorTestSuite) TestLoad_Failure() {
+	cases := []fstest.MapFS{
+		{"000001_create_rooms.up.sql": {Data: []byte("CREATE TABLE rooms ();")}},
+		{"create_rooms.sql": {Data: []byte("CREATE TABLE rooms ();")}},
+		{
+			"000001_create_rooms.up.sql":  {Data: []byte("CREATE TABLE rooms ();")},
+			"000001_create_room.down.sql": {Data: []byte("DROP TABLE rooms;")},
+		},
+	}
+	for _, files := range cases {
+		_, err := NewFromFS(nil, files)
+		assert.Error(suite.T(), err)
+	}
+}
+
+func (suite *MigratorTestSuite) TestEmbedded_Success() {
+	migrator, err := New(nil)
+
+	require.NoError(suite.T(), err)
+	for i, migration := range migrator.Migrations() {
+		assert.Equal(suite.T(), int64(i+1), migration.Version)
+		assert.NotEmpty(suite.T(), migration.Down)
+	}
+}
+
+func (suite *MigratorTestSuite) TestUp_Success() {
+	suite.expectLock()
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectSchemaMigrations)).WillReturnRows(suite.appliedRows(1))
+	for _, version := range []int64{2, 10} {
+		migration, _ := suite.migrator.find(version)
+		suite.mockSql.ExpectBegin()
+		suite.mockSql.ExpectExec(regexp.QuoteMeta(migration.Up)).WillReturnResult(sqlmock.NewResult(0, 0))
+		suite.mockSql.ExpectExec(regexp.QuoteMeta(config.InsertSchemaMigration)).WithArgs(version, migration.Name, migration.Checksum).WillReturnResult(sqlmock.NewResult(0, 1))
+		suite.mockSql.ExpectCommit()
+	}
+	suite.expectUnlock()
+
+	applied, err := suite.migrator.Up(context.Background())
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), applied, 2)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *MigratorTestSuite) TestUp_MigrationFailure() {
+	migration, _ := suite.migrator.find(10)
+	suite.expectLock()
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectSchemaMigrations)).WillReturnRows(suite.appliedRows(1, 2))
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectExec(regexp.QuoteMeta(migration.Up)).WillReturnError(errors.New("syntax error"))
+	suite.mockSql.ExpectRollback()
+	suite.expectUnlock()
+
+	applied, err := suite.migrator.Up(context.Background())
+
+	assert.ErrorContains(suite.T(), err, "000010_create_bookings")
+	assert.Empty(suite.T(), applied)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *MigratorTestSuite) TestUp_ChecksumFailure() {
+	suite.expectLock()
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectSchemaMigrations)).WillReturnRows(sqlmock.NewRows([]string{"version", "checksum", "applied_at"}).AddRow(1, "edited", time.Now()))
+	suite.expectUnlock()
+
+	_, err := suite.migrator.Up(context.Background())
+
+	assert.ErrorIs(suite.T(), err, ErrChecksumMismatch)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *MigratorTestSuite) TestUp_UnknownFailure() {
+	suite.expectLock()
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectSchemaMigrations)).WillReturnRows(sqlmock.NewRows([]string{"version", "checksum", "applied_at"}).AddRow(99, "newer", time.Now()))
+	suite.expectUnlock()
+
+	_, err := suite.migrator.Up(context.Background())
+
+	assert.ErrorIs(suite.T(), err, ErrUnknownMigration)
+}
+
+func (suite *MigratorTestSuite) TestDown_Success() {
+	migration, _ := suite.migrator.find(2)
+	suite.expectLock()
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectSchemaMigrations)).WillReturnRows(suite.appliedRows(1, 2))
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectExec(regexp.QuoteMeta(migration.Down)).WillReturnResult(sqlmock.NewResult(0, 0))
+	suite.mockSql.ExpectExec(regexp.QuoteMeta(config.DeleteSchemaMigration)).WithArgs(int64(2)).WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mockSql.ExpectCommit()
+	suite.expectUnlock()
+
+	rolledBack, err := suite.migrator.Down(context.Background(), 1)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []Migration{migration}, rolledBack)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *MigratorTestSuite) TestDown_StepsFailure() {
+	_, err := suite.migrator.Down(context.Background(), 0)
+
+	assert.Error(suite.T(), err)
+}
+
+func (suite *MigratorTestSuite) TestForce_Success() {
+	suite.expectLock()
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectSchemaMigrations)).WillReturnRows(suite.appliedRows())
+	for _, version := range []int64{1, 2} {
+		migration, _ := suite.migrator.find(version)
+		suite.mockSql.ExpectExec(regexp.QuoteMeta(config.InsertSchemaMigration)).WithArgs(version, migration.Name, migration.Checksum).WillReturnResult(sqlmock.NewResult(0, 1))
+	}
+	suite.expectUnlock()
+
+	forced, err := suite.migrator.Force(context.Background(), 2)
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), forced, 2)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *MigratorTestSuite) TestForce_UnknownVersionFailure() {
+	_, err := suite.migrator.Force(context.Background(), 3)
+
+	assert.Error(suite.T(), err)
+}
+
+func (suite *MigratorTestSuite) TestPending_NoTableSuccess() {
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectSchemaMigrations)).WillReturnError(&pq.Error{Code: "42P01"})
+
+	pending, err := suite.migrator.Pending(context.Background())
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), pending, 3)
+}
+
+func (suite *MigratorTestSuite) TestStatus_Success() {
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectSchemaMigrations)).WillReturnRows(suite.appliedRows(1))
+
+	statuses, err := suite.migrator.Status(context.Background())
+
+	assert.NoError(suite.T(), err)
+	require.Len(suite.T(), statuses, 3)
+	assert.NotNil(suite.T(), statuses[0].AppliedAt)
+	assert.Nil(suite.T(), statuses[1].AppliedAt)
+}
+
+func TestMigratorTestSuite(t *testing.T) {
+	suite.Run(t, new(MigratorTestSuite))
+}
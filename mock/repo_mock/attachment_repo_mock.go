@@ -0,0 +1,37 @@
+package repo_mock
+
+import (
+	"booking-room-app/entity"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type AttachmentRepoMock struct {
+	mock.Mock
+}
+
+func (m *AttachmentRepoMock) OwnerExists(ctx context.Context, ownerType, ownerId string) (bool, error) {
+	args := m.Called(ctx, ownerType, ownerId)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *AttachmentRepoMock) Create(ctx context.Context, payload entity.Attachment) (entity.Attachment, error) {
+	args := m.Called(ctx, payload)
+	return args.Get(0).(entity.Attachment), args.Error(1)
+}
+
+func (m *AttachmentRepoMock) Get(ctx context.Context, id string) (entity.Attachment, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(entity.Attachment), args.Error(1)
+}
+
+func (m *AttachmentRepoMock) List(ctx context.Context, ownerType, ownerId string) ([]entity.Attachment, error) {
+	args := m.Called(ctx, ownerType, ownerId)
+	return args.Get(0).([]entity.Attachment), args.Error(1)
+}
+
+func (m *AttachmentRepoMock) Delete(ctx context.Context, id string) (entity.Attachment, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(entity.Attachment), args.Error(1)
+}
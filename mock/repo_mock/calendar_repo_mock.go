@@ -0,0 +1,63 @@
+package repo_mock
+
+import (
+	"booking-room-app/entity"
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type CalendarRepoMock struct {
+	mock.Mock
+}
+
+func (c *CalendarRepoMock) ListHours(ctx context.Context, siteId, roomId string) ([]entity.BusinessHours, error) {
+	args := c.Called(ctx, siteId, roomId)
+	return args.Get(0).([]entity.BusinessHours), args.Error(1)
+}
+
+func (c *CalendarRepoMock) ReplaceHours(ctx context.Context, payload entity.WeeklyHours) (entity.WeeklyHours, error) {
+	args := c.Called(ctx, payload)
+	return args.Get(0).(entity.WeeklyHours), args.Error(1)
+}
+
+func (c *CalendarRepoMock) CreateHoliday(ctx context.Context, payload entity.Holiday) (entity.Holiday, error) {
+	args := c.Called(ctx, payload)
+	return args.Get(0).(entity.Holiday), args.Error(1)
+}
+
+func (c *CalendarRepoMock) ImportHolidays(ctx context.Context, holidays []entity.Holiday) (int64, error) {
+	args := c.Called(ctx, holidays)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (c *CalendarRepoMock) ListHolidays(ctx context.Context, siteId string, year int) ([]entity.Holiday, error) {
+	args := c.Called(ctx, siteId, year)
+	return args.Get(0).([]entity.Holiday), args.Error(1)
+}
+
+func (c *CalendarRepoMock) DeleteHoliday(ctx context.Context, id string) error {
+	args := c.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (c *CalendarRepoMock) CreateBlackout(ctx context.Context, payload entity.Blackout) (entity.Blackout, error) {
+	args := c.Called(ctx, payload)
+	return args.Get(0).(entity.Blackout), args.Error(1)
+}
+
+func (c *CalendarRepoMock) ListBlackouts(ctx context.Context, from, to time.Time) ([]entity.Blackout, error) {
+	args := c.Called(ctx, from, to)
+	return args.Get(0).([]entity.Blackout), args.Error(1)
+}
+
+func (c *CalendarRepoMock) DeleteBlackout(ctx context.Context, id string) error {
+	args := c.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (c *CalendarRepoMock) GetRoomCalendar(ctx context.Context, roomId string, start, end time.Time) (entity.RoomCalendar, error) {
+	args := c.Called(ctx, roomId, start, end)
+	return args.Get(0).(entity.RoomCalendar), args.Error(1)
+}
@@ -3,6 +3,7 @@ package repo_mock
 import (
 	"booking-room-app/entity"
 	"booking-room-app/shared/model"
+	"context"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -12,32 +13,47 @@ type EmployeeRepoMock struct {
 }
 
 // GetEmployeesByUsernameForLogin implements repository.EmployeeRepository.
-func (e *EmployeeRepoMock) GetEmployeesByUsernameForLogin(username string, password string) (entity.Employee, error) {
-	args := e.Called(username, password)
+func (e *EmployeeRepoMock) GetEmployeesByUsernameForLogin(ctx context.Context, username string, password string) (entity.Employee, error) {
+	args := e.Called(ctx, username, password)
 	return args.Get(0).(entity.Employee), args.Error(1)
 }
 
-func (e *EmployeeRepoMock) GetEmployeesByID(id string) (entity.Employee, error) {
-	args := e.Called(id)
+func (e *EmployeeRepoMock) GetEmployeesByID(ctx context.Context, id string) (entity.Employee, error) {
+	args := e.Called(ctx, id)
 	return args.Get(0).(entity.Employee), args.Error(1)
 }
 
-func (e *EmployeeRepoMock) GetEmployeesByUsername(username string) (entity.Employee, error) {
-	args := e.Called(username)
+func (e *EmployeeRepoMock) GetEmployeesByUsername(ctx context.Context, username string) (entity.Employee, error) {
+	args := e.Called(ctx, username)
 	return args.Get(0).(entity.Employee), args.Error(1)
 }
 
-func (e *EmployeeRepoMock) CreateEmployee(payload entity.Employee) (entity.Employee, error) {
-	args := e.Called(payload)
+func (e *EmployeeRepoMock) CreateEmployee(ctx context.Context, payload entity.Employee) (entity.Employee, error) {
+	args := e.Called(ctx, payload)
 	return args.Get(0).(entity.Employee), args.Error(1)
 }
 
-func (e *EmployeeRepoMock) UpdateEmployee(payload entity.Employee) (entity.Employee, error) {
-	args := e.Called(payload)
+func (e *EmployeeRepoMock) UpdateEmployee(ctx context.Context, payload entity.Employee) (entity.Employee, error) {
+	args := e.Called(ctx, payload)
 	return args.Get(0).(entity.Employee), args.Error(1)
 }
 
-func (e *EmployeeRepoMock) List(page, size int) ([]entity.Employee, model.Paging, error) {
-	args := e.Called(page, size)
+func (e *EmployeeRepoMock) List(ctx context.Context, page, size int) ([]entity.Employee, model.Paging, error) {
+	args := e.Called(ctx, page, size)
 	return args.Get(0).([]entity.Employee), args.Get(1).(model.Paging), args.Error(2)
-}
\ No newline at end of file
+}
+
+func (e *EmployeeRepoMock) ListArchived(ctx context.Context, page, size int) ([]entity.Employee, model.Paging, error) {
+	args := e.Called(ctx, page, size)
+	return args.Get(0).([]entity.Employee), args.Get(1).(model.Paging), args.Error(2)
+}
+
+func (e *EmployeeRepoMock) Archive(ctx context.Context, id string, cascade bool) (entity.Archival, error) {
+	args := e.Called(ctx, id, cascade)
+	return args.Get(0).(entity.Archival), args.Error(1)
+}
+
+func (e *EmployeeRepoMock) Restore(ctx context.Context, id string) (entity.Employee, error) {
+	args := e.Called(ctx, id)
+	return args.Get(0).(entity.Employee), args.Error(1)
+}
@@ -3,6 +3,7 @@ package repo_mock
 import (
 	"booking-room-app/entity"
 	"booking-room-app/shared/model"
+	"context"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -11,19 +12,44 @@ type FacilitiesRepoMock struct {
 	mock.Mock
 }
 
-func (m *FacilitiesRepoMock) List(page, size int) ([]entity.Facilities, model.Paging, error) {
-	args := m.Called(page, size)
+func (m *FacilitiesRepoMock) List(ctx context.Context, page, size int) ([]entity.Facilities, model.Paging, error) {
+	args := m.Called(ctx, page, size)
 	return args.Get(0).([]entity.Facilities), args.Get(1).(model.Paging), args.Error(2)
 }
-func (m *FacilitiesRepoMock) Create(payload entity.Facilities) (entity.Facilities, error) {
-	args := m.Called(payload)
+func (m *FacilitiesRepoMock) Create(ctx context.Context, payload entity.Facilities) (entity.Facilities, error) {
+	args := m.Called(ctx, payload)
 	return args.Get(0).(entity.Facilities), args.Error(1)
 }
-func (m *FacilitiesRepoMock) GetById(id string) (entity.Facilities, error) {
-	args := m.Called(id)
+func (m *FacilitiesRepoMock) GetById(ctx context.Context, id string) (entity.Facilities, error) {
+	args := m.Called(ctx, id)
 	return args.Get(0).(entity.Facilities), args.Error(1)
 }
-func (m *FacilitiesRepoMock) UpdateById(payload entity.Facilities) (entity.Facilities, error) {
-	args := m.Called(payload)
+func (m *FacilitiesRepoMock) UpdateById(ctx context.Context, payload entity.Facilities) (entity.Facilities, error) {
+	args := m.Called(ctx, payload)
 	return args.Get(0).(entity.Facilities), args.Error(1)
 }
+
+func (m *FacilitiesRepoMock) ListArchived(ctx context.Context, page, size int) ([]entity.Facilities, model.Paging, error) {
+	args := m.Called(ctx, page, size)
+	return args.Get(0).([]entity.Facilities), args.Get(1).(model.Paging), args.Error(2)
+}
+
+func (m *FacilitiesRepoMock) Archive(ctx context.Context, id string, cascade bool) (entity.Archival, error) {
+	args := m.Called(ctx, id, cascade)
+	return args.Get(0).(entity.Archival), args.Error(1)
+}
+
+func (m *FacilitiesRepoMock) Restore(ctx context.Context, id string) (entity.Facilities, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(entity.Facilities), args.Error(1)
+}
+
+func (m *FacilitiesRepoMock) RecordMovement(ctx context.Context, movement entity.FacilityMovement) (entity.FacilityMovement, error) {
+	args := m.Called(ctx, movement)
+	return args.Get(0).(entity.FacilityMovement), args.Error(1)
+}
+
+func (m *FacilitiesRepoMock) ListMovements(ctx context.Context, facilityId string, page, size int) ([]entity.FacilityMovement, model.Paging, error) {
+	args := m.Called(ctx, facilityId, page, size)
+	return args.Get(0).([]entity.FacilityMovement), args.Get(1).(model.Paging), args.Error(2)
+}
@@ -0,0 +1,88 @@
+package repo_mock
+
+import (
+	"booking-room-app/entity"
+	"booking-room-app/shared/model"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type LocationRepoMock struct {
+	mock.Mock
+}
+
+func (l *LocationRepoMock) CreateSite(ctx context.Context, payload entity.Site) (entity.Site, error) {
+	args := l.Called(ctx, payload)
+	return args.Get(0).(entity.Site), args.Error(1)
+}
+
+func (l *LocationRepoMock) GetSite(ctx context.Context, id string) (entity.Site, error) {
+	args := l.Called(ctx, id)
+	return args.Get(0).(entity.Site), args.Error(1)
+}
+
+func (l *LocationRepoMock) ListSites(ctx context.Context, page, size int) ([]entity.Site, model.Paging, error) {
+	args := l.Called(ctx, page, size)
+	return args.Get(0).([]entity.Site), args.Get(1).(model.Paging), args.Error(2)
+}
+
+func (l *LocationRepoMock) UpdateSite(ctx context.Context, payload entity.Site) (entity.Site, error) {
+	args := l.Called(ctx, payload)
+	return args.Get(0).(entity.Site), args.Error(1)
+}
+
+func (l *LocationRepoMock) DeleteSite(ctx context.Context, id string) error {
+	args := l.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (l *LocationRepoMock) CreateBuilding(ctx context.Context, payload entity.Building) (entity.Building, error) {
+	args := l.Called(ctx, payload)
+	return args.Get(0).(entity.Building), args.Error(1)
+}
+
+func (l *LocationRepoMock) GetBuilding(ctx context.Context, id string) (entity.Building, error) {
+	args := l.Called(ctx, id)
+	return args.Get(0).(entity.Building), args.Error(1)
+}
+
+func (l *LocationRepoMock) ListBuildings(ctx context.Context, siteId string, page, size int) ([]entity.Building, model.Paging, error) {
+	args := l.Called(ctx, siteId, page, size)
+	return args.Get(0).([]entity.Building), args.Get(1).(model.Paging), args.Error(2)
+}
+
+func (l *LocationRepoMock) UpdateBuilding(ctx context.Context, payload entity.Building) (entity.Building, error) {
+	args := l.Called(ctx, payload)
+	return args.Get(0).(entity.Building), args.Error(1)
+}
+
+func (l *LocationRepoMock) DeleteBuilding(ctx context.Context, id string) error {
+	args := l.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (l *LocationRepoMock) CreateFloor(ctx context.Context, payload entity.Floor) (entity.Floor, error) {
+	args := l.Called(ctx, payload)
+	return args.Get(0).(entity.Floor), args.Error(1)
+}
+
+func (l *LocationRepoMock) GetFloor(ctx context.Context, id string) (entity.Floor, error) {
+	args := l.Called(ctx, id)
+	return args.Get(0).(entity.Floor), args.Error(1)
+}
+
+func (l *LocationRepoMock) ListFloors(ctx context.Context, buildingId string, page, size int) ([]entity.Floor, model.Paging, error) {
+	args := l.Called(ctx, buildingId, page, size)
+	return args.Get(0).([]entity.Floor), args.Get(1).(model.Paging), args.Error(2)
+}
+
+func (l *LocationRepoMock) UpdateFloor(ctx context.Context, payload entity.Floor) (entity.Floor, error) {
+	args := l.Called(ctx, payload)
+	return args.Get(0).(entity.Floor), args.Error(1)
+}
+
+func (l *LocationRepoMock) DeleteFloor(ctx context.Context, id string) error {
+	args := l.Called(ctx, id)
+	return args.Error(0)
+}
@@ -0,0 +1,49 @@
+package repo_mock
+
+import (
+	"booking-room-app/entity"
+	"booking-room-app/shared/model"
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MaintenanceRepoMock struct {
+	mock.Mock
+}
+
+func (m *MaintenanceRepoMock) Create(ctx context.Context, payload entity.Maintenance) (entity.Maintenance, error) {
+	args := m.Called(ctx, payload)
+	return args.Get(0).(entity.Maintenance), args.Error(1)
+}
+
+func (m *MaintenanceRepoMock) Get(ctx context.Context, id string) (entity.Maintenance, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(entity.Maintenance), args.Error(1)
+}
+
+func (m *MaintenanceRepoMock) List(ctx context.Context, filter entity.MaintenanceFilter, page, size int) ([]entity.Maintenance, model.Paging, error) {
+	args := m.Called(ctx, filter, page, size)
+	return args.Get(0).([]entity.Maintenance), args.Get(1).(model.Paging), args.Error(2)
+}
+
+func (m *MaintenanceRepoMock) Update(ctx context.Context, payload entity.Maintenance) (entity.Maintenance, error) {
+	args := m.Called(ctx, payload)
+	return args.Get(0).(entity.Maintenance), args.Error(1)
+}
+
+func (m *MaintenanceRepoMock) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MaintenanceRepoMock) ListConflicts(ctx context.Context, maintenanceId string, now time.Time) ([]entity.MaintenanceConflict, error) {
+	args := m.Called(ctx, maintenanceId, now)
+	return args.Get(0).([]entity.MaintenanceConflict), args.Error(1)
+}
+
+func (m *MaintenanceRepoMock) SyncRoomStatus(ctx context.Context, now time.Time) (int64, int64, error) {
+	args := m.Called(ctx, now)
+	return args.Get(0).(int64), args.Get(1).(int64), args.Error(2)
+}
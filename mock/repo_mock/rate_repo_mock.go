@@ -0,0 +1,43 @@
+package repo_mock
+
+import (
+	"booking-room-app/entity"
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type RateRepoMock struct {
+	mock.Mock
+}
+
+func (r *RateRepoMock) CreateRoomRate(ctx context.Context, payload entity.RoomRate) (entity.RoomRate, error) {
+	args := r.Called(ctx, payload)
+	return args.Get(0).(entity.RoomRate), args.Error(1)
+}
+
+func (r *RateRepoMock) ListRoomRates(ctx context.Context, roomId string) ([]entity.RoomRate, error) {
+	args := r.Called(ctx, roomId)
+	return args.Get(0).([]entity.RoomRate), args.Error(1)
+}
+
+func (r *RateRepoMock) GetRoomRateAt(ctx context.Context, roomId string, at time.Time) (entity.RoomRate, error) {
+	args := r.Called(ctx, roomId, at)
+	return args.Get(0).(entity.RoomRate), args.Error(1)
+}
+
+func (r *RateRepoMock) CreateFacilityRate(ctx context.Context, payload entity.FacilityRate) (entity.FacilityRate, error) {
+	args := r.Called(ctx, payload)
+	return args.Get(0).(entity.FacilityRate), args.Error(1)
+}
+
+func (r *RateRepoMock) ListFacilityRates(ctx context.Context, facilityId string) ([]entity.FacilityRate, error) {
+	args := r.Called(ctx, facilityId)
+	return args.Get(0).([]entity.FacilityRate), args.Error(1)
+}
+
+func (r *RateRepoMock) GetFacilityRatesAt(ctx context.Context, facilityIds []string, at time.Time) (map[string]int64, error) {
+	args := r.Called(ctx, facilityIds, at)
+	return args.Get(0).(map[string]int64), args.Error(1)
+}
@@ -2,6 +2,7 @@ package repo_mock
 
 import (
 	"booking-room-app/entity/dto"
+	"context"
 	"time"
 
 	"github.com/stretchr/testify/mock"
@@ -11,7 +12,12 @@ type ReportRepoMock struct {
 	mock.Mock
 }
 
-func (r *ReportRepoMock) List(startDate, endDate time.Time) ([]dto.ReportDto, error) {
-	args := r.Called(startDate, endDate)
+func (r *ReportRepoMock) List(ctx context.Context, startDate, endDate time.Time) ([]dto.ReportDto, error) {
+	args := r.Called(ctx, startDate, endDate)
 	return args.Get(0).([]dto.ReportDto), args.Error(1)
 }
+
+func (r *ReportRepoMock) Chargeback(ctx context.Context, startDate, endDate time.Time, period string) ([]dto.ChargebackDto, error) {
+	args := r.Called(ctx, startDate, endDate, period)
+	return args.Get(0).([]dto.ChargebackDto), args.Error(1)
+}
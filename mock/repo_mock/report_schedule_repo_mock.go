@@ -0,0 +1,49 @@
+package repo_mock
+
+import (
+	"booking-room-app/entity"
+	"booking-room-app/shared/model"
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type ReportScheduleRepoMock struct {
+	mock.Mock
+}
+
+func (r *ReportScheduleRepoMock) Create(ctx context.Context, payload entity.ReportSchedule) (entity.ReportSchedule, error) {
+	args := r.Called(ctx, payload)
+	return args.Get(0).(entity.ReportSchedule), args.Error(1)
+}
+
+func (r *ReportScheduleRepoMock) Get(ctx context.Context, id string) (entity.ReportSchedule, error) {
+	args := r.Called(ctx, id)
+	return args.Get(0).(entity.ReportSchedule), args.Error(1)
+}
+
+func (r *ReportScheduleRepoMock) List(ctx context.Context, page, size int) ([]entity.ReportSchedule, model.Paging, error) {
+	args := r.Called(ctx, page, size)
+	return args.Get(0).([]entity.ReportSchedule), args.Get(1).(model.Paging), args.Error(2)
+}
+
+func (r *ReportScheduleRepoMock) ListActive(ctx context.Context) ([]entity.ReportSchedule, error) {
+	args := r.Called(ctx)
+	return args.Get(0).([]entity.ReportSchedule), args.Error(1)
+}
+
+func (r *ReportScheduleRepoMock) Update(ctx context.Context, payload entity.ReportSchedule) (entity.ReportSchedule, error) {
+	args := r.Called(ctx, payload)
+	return args.Get(0).(entity.ReportSchedule), args.Error(1)
+}
+
+func (r *ReportScheduleRepoMock) ClaimRun(ctx context.Context, id string, runAt time.Time) (bool, error) {
+	args := r.Called(ctx, id, runAt)
+	return args.Bool(0), args.Error(1)
+}
+
+func (r *ReportScheduleRepoMock) Delete(ctx context.Context, id string) error {
+	args := r.Called(ctx, id)
+	return args.Error(0)
+}
@@ -0,0 +1,47 @@
+package repo_mock
+
+import (
+	"booking-room-app/entity"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type RoomAttributeRepoMock struct {
+	mock.Mock
+}
+
+func (m *RoomAttributeRepoMock) Create(ctx context.Context, payload entity.RoomAttribute) (entity.RoomAttribute, error) {
+	args := m.Called(ctx, payload)
+	return args.Get(0).(entity.RoomAttribute), args.Error(1)
+}
+
+func (m *RoomAttributeRepoMock) Get(ctx context.Context, id string) (entity.RoomAttribute, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(entity.RoomAttribute), args.Error(1)
+}
+
+func (m *RoomAttributeRepoMock) List(ctx context.Context) ([]entity.RoomAttribute, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]entity.RoomAttribute), args.Error(1)
+}
+
+func (m *RoomAttributeRepoMock) Update(ctx context.Context, payload entity.RoomAttribute) (entity.RoomAttribute, error) {
+	args := m.Called(ctx, payload)
+	return args.Get(0).(entity.RoomAttribute), args.Error(1)
+}
+
+func (m *RoomAttributeRepoMock) Delete(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *RoomAttributeRepoMock) ListValues(ctx context.Context, roomIds []string) ([]entity.RoomAttributeValue, error) {
+	args := m.Called(ctx, roomIds)
+	return args.Get(0).([]entity.RoomAttributeValue), args.Error(1)
+}
+
+func (m *RoomAttributeRepoMock) ReplaceValues(ctx context.Context, roomId string, values []entity.RoomAttributeValue) error {
+	args := m.Called(ctx, roomId, values)
+	return args.Error(0)
+}
@@ -3,6 +3,7 @@ package repo_mock
 import (
 	"booking-room-app/entity"
 	"booking-room-app/shared/model"
+	"context"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -11,27 +12,37 @@ type RoomFacilityRepoMock struct {
 	mock.Mock
 }
 
-func (m *RoomFacilityRepoMock) CreateRoomFacility(payload entity.RoomFacility, newFacilityQuantity int) (entity.RoomFacility, error) {
-	args := m.Called(payload, newFacilityQuantity)
+func (m *RoomFacilityRepoMock) CreateRoomFacility(ctx context.Context, payload entity.RoomFacility) (entity.RoomFacility, error) {
+	args := m.Called(ctx, payload)
 	return args.Get(0).(entity.RoomFacility), args.Error(1)
 }
 
-func (m *RoomFacilityRepoMock) GetQuantityFacilityByID(id string) (int, error) {
-	args := m.Called(id)
+func (m *RoomFacilityRepoMock) GetQuantityFacilityByID(ctx context.Context, id string) (int, error) {
+	args := m.Called(ctx, id)
 	return args.Int(0), args.Error(1)
 }
 
-func (m *RoomFacilityRepoMock) GetRoomFacilityById(id string) (entity.RoomFacility, error) {
-	args := m.Called(id)
+func (m *RoomFacilityRepoMock) GetRoomFacilityById(ctx context.Context, id string) (entity.RoomFacility, error) {
+	args := m.Called(ctx, id)
 	return args.Get(0).(entity.RoomFacility), args.Error(1)
 }
 
-func (m *RoomFacilityRepoMock) ListRoomFacility(page int, size int) ([]entity.RoomFacility, model.Paging, error) {
-	args := m.Called(page, size)
+func (m *RoomFacilityRepoMock) ListRoomFacility(ctx context.Context, page int, size int) ([]entity.RoomFacility, model.Paging, error) {
+	args := m.Called(ctx, page, size)
 	return args.Get(0).([]entity.RoomFacility), args.Get(1).(model.Paging), args.Error(2)
 }
 
-func (m *RoomFacilityRepoMock) UpdateRoomFacility(payload entity.RoomFacility, newFacilityQuantity int) (entity.RoomFacility, error) {
-	args := m.Called(payload, newFacilityQuantity)
+func (m *RoomFacilityRepoMock) UpdateRoomFacility(ctx context.Context, payload entity.RoomFacility) (entity.RoomFacility, error) {
+	args := m.Called(ctx, payload)
 	return args.Get(0).(entity.RoomFacility), args.Error(1)
 }
+
+func (m *RoomFacilityRepoMock) DeleteRoomFacility(ctx context.Context, id string) (entity.RoomFacility, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(entity.RoomFacility), args.Error(1)
+}
+
+func (m *RoomFacilityRepoMock) TransferRoomFacility(ctx context.Context, payload entity.RoomFacilityTransfer) (entity.RoomFacilityTransfer, error) {
+	args := m.Called(ctx, payload)
+	return args.Get(0).(entity.RoomFacilityTransfer), args.Error(1)
+}
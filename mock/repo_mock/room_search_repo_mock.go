@@ -0,0 +1,18 @@
+package repo_mock
+
+import (
+	"booking-room-app/entity"
+	"booking-room-app/shared/model"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type RoomSearchRepoMock struct {
+	mock.Mock
+}
+
+func (m *RoomSearchRepoMock) Search(ctx context.Context, search entity.RoomSearch, page, size int) ([]entity.RoomSearchItem, entity.RoomFacets, model.Paging, error) {
+	args := m.Called(ctx, search, page, size)
+	return args.Get(0).([]entity.RoomSearchItem), args.Get(1).(entity.RoomFacets), args.Get(2).(model.Paging), args.Error(3)
+}
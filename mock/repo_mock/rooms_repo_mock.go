@@ -3,6 +3,8 @@ package repo_mock
 import (
 	"booking-room-app/entity"
 	"booking-room-app/shared/model"
+	"context"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -11,32 +13,57 @@ type RoomRepoMock struct {
 	mock.Mock
 }
 
-func (r *RoomRepoMock) Create(payload entity.Room) (entity.Room, error) {
-	args := r.Called(payload)
+func (r *RoomRepoMock) Create(ctx context.Context, payload entity.Room) (entity.Room, error) {
+	args := r.Called(ctx, payload)
 	return args.Get(0).(entity.Room), args.Error(1)
 }
 
-func (r *RoomRepoMock) Get(id string) (entity.Room, error) {
-	args := r.Called(id)
+func (r *RoomRepoMock) Get(ctx context.Context, id string) (entity.Room, error) {
+	args := r.Called(ctx, id)
 	return args.Get(0).(entity.Room), args.Error(1)
 }
 
-func (r *RoomRepoMock) List(page, size int) ([]entity.Room, model.Paging, error) {
-	args := r.Called(page, size)
+func (r *RoomRepoMock) List(ctx context.Context, page, size int) ([]entity.Room, model.Paging, error) {
+	args := r.Called(ctx, page, size)
 	return args.Get(0).([]entity.Room), args.Get(1).(model.Paging), args.Error(2)
 }
 
-func (r *RoomRepoMock) ListStatus(status string, page, size int) ([]entity.Room, model.Paging, error) {
-	args := r.Called(status, page, size)
+func (r *RoomRepoMock) ListAvailable(ctx context.Context, filter entity.LocationFilter, start, end time.Time, page, size int) ([]entity.Room, model.Paging, error) {
+	args := r.Called(ctx, filter, start, end, page, size)
 	return args.Get(0).([]entity.Room), args.Get(1).(model.Paging), args.Error(2)
 }
 
-func (r *RoomRepoMock) Update(payload entity.Room) (entity.Room, error) {
-	args := r.Called(payload)
+func (r *RoomRepoMock) ListStatus(ctx context.Context, status string, page, size int) ([]entity.Room, model.Paging, error) {
+	args := r.Called(ctx, status, page, size)
+	return args.Get(0).([]entity.Room), args.Get(1).(model.Paging), args.Error(2)
+}
+
+func (r *RoomRepoMock) Update(ctx context.Context, payload entity.Room) (entity.Room, error) {
+	args := r.Called(ctx, payload)
 	return args.Get(0).(entity.Room), args.Error(1)
 }
 
-func (r *RoomRepoMock) UpdateStatus(payload entity.Room) (entity.Room, error) {
-	args := r.Called(payload)
+func (r *RoomRepoMock) UpdateStatus(ctx context.Context, payload entity.Room) (entity.Room, error) {
+	args := r.Called(ctx, payload)
+	return args.Get(0).(entity.Room), args.Error(1)
+}
+
+func (r *RoomRepoMock) ListArchived(ctx context.Context, page, size int) ([]entity.Room, model.Paging, error) {
+	args := r.Called(ctx, page, size)
+	return args.Get(0).([]entity.Room), args.Get(1).(model.Paging), args.Error(2)
+}
+
+func (r *RoomRepoMock) Archive(ctx context.Context, id string, cascade bool) (entity.Archival, error) {
+	args := r.Called(ctx, id, cascade)
+	return args.Get(0).(entity.Archival), args.Error(1)
+}
+
+func (r *RoomRepoMock) Restore(ctx context.Context, id string) (entity.Room, error) {
+	args := r.Called(ctx, id)
 	return args.Get(0).(entity.Room), args.Error(1)
 }
+
+func (r *RoomRepoMock) ListByLocation(ctx context.Context, filter entity.LocationFilter, status string, page, size int) ([]entity.Room, model.Paging, error) {
+	args := r.Called(ctx, filter, status, page, size)
+	return args.Get(0).([]entity.Room), args.Get(1).(model.Paging), args.Error(2)
+}
@@ -0,0 +1,48 @@
+package repo_mock
+
+import (
+	"booking-room-app/entity"
+	"booking-room-app/shared/model"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type StockAlertRepoMock struct {
+	mock.Mock
+}
+
+func (s *StockAlertRepoMock) GetThreshold(ctx context.Context, facilityId string) (entity.StockThreshold, error) {
+	args := s.Called(ctx, facilityId)
+	return args.Get(0).(entity.StockThreshold), args.Error(1)
+}
+
+func (s *StockAlertRepoMock) UpdateThreshold(ctx context.Context, payload entity.StockThreshold) (entity.StockThreshold, error) {
+	args := s.Called(ctx, payload)
+	return args.Get(0).(entity.StockThreshold), args.Error(1)
+}
+
+func (s *StockAlertRepoMock) Check(ctx context.Context, facilityIds []string) ([]entity.StockAlert, error) {
+	args := s.Called(ctx, facilityIds)
+	return args.Get(0).([]entity.StockAlert), args.Error(1)
+}
+
+func (s *StockAlertRepoMock) ClaimNotifications(ctx context.Context) ([]entity.StockAlert, error) {
+	args := s.Called(ctx)
+	return args.Get(0).([]entity.StockAlert), args.Error(1)
+}
+
+func (s *StockAlertRepoMock) UnclaimNotifications(ctx context.Context, ids []string) error {
+	args := s.Called(ctx, ids)
+	return args.Error(0)
+}
+
+func (s *StockAlertRepoMock) List(ctx context.Context, status string, page, size int) ([]entity.StockAlert, model.Paging, error) {
+	args := s.Called(ctx, status, page, size)
+	return args.Get(0).([]entity.StockAlert), args.Get(1).(model.Paging), args.Error(2)
+}
+
+func (s *StockAlertRepoMock) Acknowledge(ctx context.Context, id, actorId string) (entity.StockAlert, error) {
+	args := s.Called(ctx, id, actorId)
+	return args.Get(0).(entity.StockAlert), args.Error(1)
+}
@@ -3,6 +3,7 @@ package repo_mock
 import (
 	"booking-room-app/entity"
 	"booking-room-app/shared/model"
+	"context"
 	"time"
 
 	"github.com/stretchr/testify/mock"
@@ -13,33 +14,37 @@ type TransactionsRepoMock struct {
 }
 
 // Create implements repository.TransactionsRepository.
-func (t *TransactionsRepoMock) Create(payload entity.Transaction) (entity.Transaction, error) {
-	args := t.Called(payload)
+func (t *TransactionsRepoMock) Create(ctx context.Context, payload entity.Transaction) (entity.Transaction, error) {
+	args := t.Called(ctx, payload)
 	return args.Get(0).(entity.Transaction), args.Error(1)
 }
 
 // GetTransactionByEmployeId implements repository.TransactionsRepository.
 
 // GetTransactionById implements repository.TransactionsRepository.
-func (t *TransactionsRepoMock) GetTransactionById(id string) (entity.Transaction, error) {
-	args := t.Called(id)
+func (t *TransactionsRepoMock) GetTransactionById(ctx context.Context, id string) (entity.Transaction, error) {
+	args := t.Called(ctx, id)
 	return args.Get(0).(entity.Transaction), args.Error(1)
 }
 
 // UpdatePemission implements repository.TransactionsRepository.
-func (t *TransactionsRepoMock) UpdatePemission(payload entity.Transaction) (entity.Transaction, error) {
-	args := t.Called(payload)
+func (t *TransactionsRepoMock) UpdatePemission(ctx context.Context, payload entity.Transaction) (entity.Transaction, error) {
+	args := t.Called(ctx, payload)
 	return args.Get(0).(entity.Transaction), args.Error(1)
 }
 
-func (t *TransactionsRepoMock) List(page, size int, startDate, endDate time.Time) ([]entity.Transaction, model.Paging, error) {
-	args := t.Called(page, size, startDate, endDate)
+func (t *TransactionsRepoMock) List(ctx context.Context, page, size int, startDate, endDate time.Time) ([]entity.Transaction, model.Paging, error) {
+	args := t.Called(ctx, page, size, startDate, endDate)
 	return args.Get(0).([]entity.Transaction), args.Get(1).(model.Paging), args.Error(2)
 
 }
 
-func (t *TransactionsRepoMock) GetTransactionByEmployeId(EmployeeId string, page, size int) ([]entity.Transaction, model.Paging, error) {
-	args := t.Called(EmployeeId, page, size)
+func (t *TransactionsRepoMock) GetTransactionByEmployeId(ctx context.Context, EmployeeId string, page, size int) ([]entity.Transaction, model.Paging, error) {
+	args := t.Called(ctx, EmployeeId, page, size)
 	return args.Get(0).([]entity.Transaction), args.Get(1).(model.Paging), args.Error(2)
 }
 
+func (t *TransactionsRepoMock) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	args := t.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
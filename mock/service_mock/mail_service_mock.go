@@ -0,0 +1,17 @@
+package service_mock
+
+import (
+	"booking-room-app/shared/model"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MailServiceMock struct {
+	mock.Mock
+}
+
+func (m *MailServiceMock) Send(ctx context.Context, message model.MailMessage) error {
+	args := m.Called(ctx, message)
+	return args.Error(0)
+}
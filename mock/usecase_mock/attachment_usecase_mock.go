@@ -0,0 +1,34 @@
+package usecase_mock
+
+import (
+	"booking-room-app/entity"
+	"context"
+	"io"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type AttachmentUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *AttachmentUseCaseMock) UploadAttachment(ctx context.Context, ownerType, ownerId, filename string, body io.Reader) (entity.Attachment, error) {
+	args := m.Called(ctx, ownerType, ownerId, filename, body)
+	return args.Get(0).(entity.Attachment), args.Error(1)
+}
+
+func (m *AttachmentUseCaseMock) FindAttachments(ctx context.Context, ownerType, ownerId string) ([]entity.Attachment, error) {
+	args := m.Called(ctx, ownerType, ownerId)
+	return args.Get(0).([]entity.Attachment), args.Error(1)
+}
+
+func (m *AttachmentUseCaseMock) OpenAttachment(ctx context.Context, id string, thumbnail bool) (entity.Attachment, io.ReadCloser, error) {
+	args := m.Called(ctx, id, thumbnail)
+	body, _ := args.Get(1).(io.ReadCloser)
+	return args.Get(0).(entity.Attachment), body, args.Error(2)
+}
+
+func (m *AttachmentUseCaseMock) DeleteAttachment(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
@@ -2,6 +2,7 @@ package usecase_mock
 
 import (
 	"booking-room-app/entity/dto"
+	"context"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -10,7 +11,7 @@ type AuthUseCaseMock struct {
 	mock.Mock
 }
 
-func (m *AuthUseCaseMock) Login(payload dto.AuthRequestDto) (dto.AuthResponseDto, error) {
-	args := m.Called(payload)
+func (m *AuthUseCaseMock) Login(ctx context.Context, payload dto.AuthRequestDto) (dto.AuthResponseDto, error) {
+	args := m.Called(ctx, payload)
 	return args.Get(0).(dto.AuthResponseDto), args.Error(1)
 }
@@ -0,0 +1,64 @@
+package usecase_mock
+
+import (
+	"booking-room-app/entity"
+	"context"
+	"io"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type CalendarUseCaseMock struct {
+	mock.Mock
+}
+
+func (c *CalendarUseCaseMock) FindHours(ctx context.Context, siteId, roomId string) (entity.WeeklyHours, error) {
+	args := c.Called(ctx, siteId, roomId)
+	return args.Get(0).(entity.WeeklyHours), args.Error(1)
+}
+
+func (c *CalendarUseCaseMock) ReplaceHours(ctx context.Context, payload entity.WeeklyHours) (entity.WeeklyHours, error) {
+	args := c.Called(ctx, payload)
+	return args.Get(0).(entity.WeeklyHours), args.Error(1)
+}
+
+func (c *CalendarUseCaseMock) RegisterHoliday(ctx context.Context, payload entity.Holiday) (entity.Holiday, error) {
+	args := c.Called(ctx, payload)
+	return args.Get(0).(entity.Holiday), args.Error(1)
+}
+
+func (c *CalendarUseCaseMock) ImportHolidays(ctx context.Context, siteId string, calendar io.Reader) (int64, error) {
+	args := c.Called(ctx, siteId, calendar)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (c *CalendarUseCaseMock) FindHolidays(ctx context.Context, siteId string, year int) ([]entity.Holiday, error) {
+	args := c.Called(ctx, siteId, year)
+	return args.Get(0).([]entity.Holiday), args.Error(1)
+}
+
+func (c *CalendarUseCaseMock) DeleteHoliday(ctx context.Context, id string) error {
+	args := c.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (c *CalendarUseCaseMock) RegisterBlackout(ctx context.Context, payload entity.Blackout) (entity.Blackout, error) {
+	args := c.Called(ctx, payload)
+	return args.Get(0).(entity.Blackout), args.Error(1)
+}
+
+func (c *CalendarUseCaseMock) FindBlackouts(ctx context.Context, from, to time.Time) ([]entity.Blackout, error) {
+	args := c.Called(ctx, from, to)
+	return args.Get(0).([]entity.Blackout), args.Error(1)
+}
+
+func (c *CalendarUseCaseMock) DeleteBlackout(ctx context.Context, id string) error {
+	args := c.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (c *CalendarUseCaseMock) CheckBookable(ctx context.Context, roomId string, start, end time.Time) error {
+	args := c.Called(ctx, roomId, start, end)
+	return args.Error(0)
+}
@@ -3,6 +3,7 @@ package usecase_mock
 import (
 	"booking-room-app/entity"
 	"booking-room-app/shared/model"
+	"context"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -12,34 +13,54 @@ type EmployeeUseCaseMock struct {
 }
 
 // FindEmployeForLogin implements usecase.EmployeesUseCase.
-func (e *EmployeeUseCaseMock) FindEmployeForLogin(username string, password string) (entity.Employee, error) {
-	args := e.Called(username, password)
+func (e *EmployeeUseCaseMock) FindEmployeForLogin(ctx context.Context, username string, password string) (entity.Employee, error) {
+	args := e.Called(ctx, username, password)
 	return args.Get(0).(entity.Employee), args.Error(1)
 }
 
-func (e *EmployeeUseCaseMock) RegisterNewEmployee(payload entity.Employee) (entity.Employee, error) {
-	args := e.Called(payload)
+func (e *EmployeeUseCaseMock) RegisterNewEmployee(ctx context.Context, payload entity.Employee) (entity.Employee, error) {
+	args := e.Called(ctx, payload)
 	return args.Get(0).(entity.Employee), args.Error(1)
 }
 
-func (e *EmployeeUseCaseMock) FindEmployeesByID(id string) (entity.Employee, error) {
-	args := e.Called(id)
+func (e *EmployeeUseCaseMock) FindEmployeesByID(ctx context.Context, id string) (entity.Employee, error) {
+	args := e.Called(ctx, id)
 	return args.Get(0).(entity.Employee), args.Error(1)
 
 }
-func (e *EmployeeUseCaseMock) FindEmployeesByUsername(username string) (entity.Employee, error) {
-	args := e.Called(username)
+func (e *EmployeeUseCaseMock) FindEmployeesByUsername(ctx context.Context, username string) (entity.Employee, error) {
+	args := e.Called(ctx, username)
 	return args.Get(0).(entity.Employee), args.Error(1)
 }
-func (e *EmployeeUseCaseMock) FindEmployeeForLogin(username, password string) (entity.Employee, error) {
-	args := e.Called(username, password)
+func (e *EmployeeUseCaseMock) FindEmployeeForLogin(ctx context.Context, username, password string) (entity.Employee, error) {
+	args := e.Called(ctx, username, password)
 	return args.Get(0).(entity.Employee), args.Error(1)
 }
-func (e *EmployeeUseCaseMock) UpdateEmployee(payload entity.Employee) (entity.Employee, error) {
-	args := e.Called(payload)
+func (e *EmployeeUseCaseMock) UpdateEmployee(ctx context.Context, payload entity.Employee) (entity.Employee, error) {
+	args := e.Called(ctx, payload)
 	return args.Get(0).(entity.Employee), args.Error(1)
 }
-func (e *EmployeeUseCaseMock) ListAll(page, size int) ([]entity.Employee, model.Paging, error) {
-	args := e.Called(page, size)
+func (e *EmployeeUseCaseMock) ListAll(ctx context.Context, page, size int) ([]entity.Employee, model.Paging, error) {
+	args := e.Called(ctx, page, size)
+	return args.Get(0).([]entity.Employee), args.Get(1).(model.Paging), args.Error(2)
+}
+
+func (e *EmployeeUseCaseMock) ResetPassword(ctx context.Context, username string, password string) (entity.Employee, error) {
+	args := e.Called(ctx, username, password)
+	return args.Get(0).(entity.Employee), args.Error(1)
+}
+
+func (e *EmployeeUseCaseMock) ArchiveEmployee(ctx context.Context, id string) (entity.Archival, error) {
+	args := e.Called(ctx, id)
+	return args.Get(0).(entity.Archival), args.Error(1)
+}
+
+func (e *EmployeeUseCaseMock) RestoreEmployee(ctx context.Context, id string) (entity.Employee, error) {
+	args := e.Called(ctx, id)
+	return args.Get(0).(entity.Employee), args.Error(1)
+}
+
+func (e *EmployeeUseCaseMock) FindArchivedEmployees(ctx context.Context, page, size int) ([]entity.Employee, model.Paging, error) {
+	args := e.Called(ctx, page, size)
 	return args.Get(0).([]entity.Employee), args.Get(1).(model.Paging), args.Error(2)
 }
@@ -3,6 +3,7 @@ package usecase_mock
 import (
 	"booking-room-app/entity"
 	"booking-room-app/shared/model"
+	"context"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -11,19 +12,44 @@ type FacilitiesUseCaseMock struct {
 	mock.Mock
 }
 
-func (m *FacilitiesUseCaseMock) FindAllFacilities(page, size int) ([]entity.Facilities, model.Paging, error) {
-	args := m.Called(page, size)
+func (m *FacilitiesUseCaseMock) FindAllFacilities(ctx context.Context, page, size int) ([]entity.Facilities, model.Paging, error) {
+	args := m.Called(ctx, page, size)
 	return args.Get(0).([]entity.Facilities), args.Get(1).(model.Paging), args.Error(2)
 }
-func (m *FacilitiesUseCaseMock) RegisterNewFacilities(payload entity.Facilities) (entity.Facilities, error) {
-	args := m.Called(payload)
+func (m *FacilitiesUseCaseMock) RegisterNewFacilities(ctx context.Context, payload entity.Facilities) (entity.Facilities, error) {
+	args := m.Called(ctx, payload)
 	return args.Get(0).(entity.Facilities), args.Error(1)
 }
-func (m *FacilitiesUseCaseMock) FindFacilitiesById(id string) (entity.Facilities, error) {
-	args := m.Called(id)
+func (m *FacilitiesUseCaseMock) FindFacilitiesById(ctx context.Context, id string) (entity.Facilities, error) {
+	args := m.Called(ctx, id)
 	return args.Get(0).(entity.Facilities), args.Error(1)
 }
-func (m *FacilitiesUseCaseMock) EditFacilities(payload entity.Facilities) (entity.Facilities, error) {
-	args := m.Called(payload)
+func (m *FacilitiesUseCaseMock) EditFacilities(ctx context.Context, payload entity.Facilities) (entity.Facilities, error) {
+	args := m.Called(ctx, payload)
 	return args.Get(0).(entity.Facilities), args.Error(1)
 }
+
+func (m *FacilitiesUseCaseMock) ArchiveFacility(ctx context.Context, id string) (entity.Archival, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(entity.Archival), args.Error(1)
+}
+
+func (m *FacilitiesUseCaseMock) RestoreFacility(ctx context.Context, id string) (entity.Facilities, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(entity.Facilities), args.Error(1)
+}
+
+func (m *FacilitiesUseCaseMock) FindArchivedFacilities(ctx context.Context, page, size int) ([]entity.Facilities, model.Paging, error) {
+	args := m.Called(ctx, page, size)
+	return args.Get(0).([]entity.Facilities), args.Get(1).(model.Paging), args.Error(2)
+}
+
+func (m *FacilitiesUseCaseMock) RecordMovement(ctx context.Context, movement entity.FacilityMovement) (entity.FacilityMovement, error) {
+	args := m.Called(ctx, movement)
+	return args.Get(0).(entity.FacilityMovement), args.Error(1)
+}
+
+func (m *FacilitiesUseCaseMock) FindMovements(ctx context.Context, facilityId string, page, size int) ([]entity.FacilityMovement, model.Paging, error) {
+	args := m.Called(ctx, facilityId, page, size)
+	return args.Get(0).([]entity.FacilityMovement), args.Get(1).(model.Paging), args.Error(2)
+}
@@ -0,0 +1,48 @@
+package usecase_mock
+
+import (
+	"booking-room-app/entity"
+	"booking-room-app/shared/model"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type FacilityAssetUseCaseMock struct {
+	mock.Mock
+}
+
+func (f *FacilityAssetUseCaseMock) RegisterAsset(ctx context.Context, payload entity.FacilityAsset) (entity.FacilityAsset, error) {
+	args := f.Called(ctx, payload)
+	return args.Get(0).(entity.FacilityAsset), args.Error(1)
+}
+
+func (f *FacilityAssetUseCaseMock) FindAssets(ctx context.Context, facilityId string, page, size int) ([]entity.FacilityAsset, model.Paging, error) {
+	args := f.Called(ctx, facilityId, page, size)
+	return args.Get(0).([]entity.FacilityAsset), args.Get(1).(model.Paging), args.Error(2)
+}
+
+func (f *FacilityAssetUseCaseMock) FindAssetById(ctx context.Context, id string) (entity.FacilityAsset, error) {
+	args := f.Called(ctx, id)
+	return args.Get(0).(entity.FacilityAsset), args.Error(1)
+}
+
+func (f *FacilityAssetUseCaseMock) UpdateAsset(ctx context.Context, payload entity.FacilityAsset) (entity.FacilityAsset, error) {
+	args := f.Called(ctx, payload)
+	return args.Get(0).(entity.FacilityAsset), args.Error(1)
+}
+
+func (f *FacilityAssetUseCaseMock) CheckOutAsset(ctx context.Context, id, transactionId string) (entity.FacilityAsset, error) {
+	args := f.Called(ctx, id, transactionId)
+	return args.Get(0).(entity.FacilityAsset), args.Error(1)
+}
+
+func (f *FacilityAssetUseCaseMock) CheckInAsset(ctx context.Context, payload entity.AssetCheckIn) (entity.FacilityAsset, error) {
+	args := f.Called(ctx, payload)
+	return args.Get(0).(entity.FacilityAsset), args.Error(1)
+}
+
+func (f *FacilityAssetUseCaseMock) FindCheckouts(ctx context.Context, id string, page, size int) ([]entity.AssetCheckout, model.Paging, error) {
+	args := f.Called(ctx, id, page, size)
+	return args.Get(0).([]entity.AssetCheckout), args.Get(1).(model.Paging), args.Error(2)
+}
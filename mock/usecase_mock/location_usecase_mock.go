@@ -0,0 +1,88 @@
+package usecase_mock
+
+import (
+	"booking-room-app/entity"
+	"booking-room-app/shared/model"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type LocationUseCaseMock struct {
+	mock.Mock
+}
+
+func (l *LocationUseCaseMock) RegisterSite(ctx context.Context, payload entity.Site) (entity.Site, error) {
+	args := l.Called(ctx, payload)
+	return args.Get(0).(entity.Site), args.Error(1)
+}
+
+func (l *LocationUseCaseMock) FindSiteByID(ctx context.Context, id string) (entity.Site, error) {
+	args := l.Called(ctx, id)
+	return args.Get(0).(entity.Site), args.Error(1)
+}
+
+func (l *LocationUseCaseMock) FindAllSites(ctx context.Context, page, size int) ([]entity.Site, model.Paging, error) {
+	args := l.Called(ctx, page, size)
+	return args.Get(0).([]entity.Site), args.Get(1).(model.Paging), args.Error(2)
+}
+
+func (l *LocationUseCaseMock) UpdateSite(ctx context.Context, payload entity.Site) (entity.Site, error) {
+	args := l.Called(ctx, payload)
+	return args.Get(0).(entity.Site), args.Error(1)
+}
+
+func (l *LocationUseCaseMock) DeleteSite(ctx context.Context, id string) error {
+	args := l.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (l *LocationUseCaseMock) RegisterBuilding(ctx context.Context, payload entity.Building) (entity.Building, error) {
+	args := l.Called(ctx, payload)
+	return args.Get(0).(entity.Building), args.Error(1)
+}
+
+func (l *LocationUseCaseMock) FindBuildingByID(ctx context.Context, id string) (entity.Building, error) {
+	args := l.Called(ctx, id)
+	return args.Get(0).(entity.Building), args.Error(1)
+}
+
+func (l *LocationUseCaseMock) FindAllBuildings(ctx context.Context, siteId string, page, size int) ([]entity.Building, model.Paging, error) {
+	args := l.Called(ctx, siteId, page, size)
+	return args.Get(0).([]entity.Building), args.Get(1).(model.Paging), args.Error(2)
+}
+
+func (l *LocationUseCaseMock) UpdateBuilding(ctx context.Context, payload entity.Building) (entity.Building, error) {
+	args := l.Called(ctx, payload)
+	return args.Get(0).(entity.Building), args.Error(1)
+}
+
+func (l *LocationUseCaseMock) DeleteBuilding(ctx context.Context, id string) error {
+	args := l.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (l *LocationUseCaseMock) RegisterFloor(ctx context.Context, payload entity.Floor) (entity.Floor, error) {
+	args := l.Called(ctx, payload)
+	return args.Get(0).(entity.Floor), args.Error(1)
+}
+
+func (l *LocationUseCaseMock) FindFloorByID(ctx context.Context, id string) (entity.Floor, error) {
+	args := l.Called(ctx, id)
+	return args.Get(0).(entity.Floor), args.Error(1)
+}
+
+func (l *LocationUseCaseMock) FindAllFloors(ctx context.Context, buildingId string, page, size int) ([]entity.Floor, model.Paging, error) {
+	args := l.Called(ctx, buildingId, page, size)
+	return args.Get(0).([]entity.Floor), args.Get(1).(model.Paging), args.Error(2)
+}
+
+func (l *LocationUseCaseMock) UpdateFloor(ctx context.Context, payload entity.Floor) (entity.Floor, error) {
+	args := l.Called(ctx, payload)
+	return args.Get(0).(entity.Floor), args.Error(1)
+}
+
+func (l *LocationUseCaseMock) DeleteFloor(ctx context.Context, id string) error {
+	args := l.Called(ctx, id)
+	return args.Error(0)
+}
@@ -0,0 +1,49 @@
+package usecase_mock
+
+import (
+	"booking-room-app/entity"
+	"booking-room-app/shared/model"
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type MaintenanceUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *MaintenanceUseCaseMock) RegisterMaintenance(ctx context.Context, payload entity.Maintenance) (entity.Maintenance, error) {
+	args := m.Called(ctx, payload)
+	return args.Get(0).(entity.Maintenance), args.Error(1)
+}
+
+func (m *MaintenanceUseCaseMock) FindMaintenanceByID(ctx context.Context, id string) (entity.Maintenance, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(entity.Maintenance), args.Error(1)
+}
+
+func (m *MaintenanceUseCaseMock) FindAllMaintenance(ctx context.Context, filter entity.MaintenanceFilter, page, size int) ([]entity.Maintenance, model.Paging, error) {
+	args := m.Called(ctx, filter, page, size)
+	return args.Get(0).([]entity.Maintenance), args.Get(1).(model.Paging), args.Error(2)
+}
+
+func (m *MaintenanceUseCaseMock) UpdateMaintenance(ctx context.Context, payload entity.Maintenance) (entity.Maintenance, error) {
+	args := m.Called(ctx, payload)
+	return args.Get(0).(entity.Maintenance), args.Error(1)
+}
+
+func (m *MaintenanceUseCaseMock) DeleteMaintenance(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MaintenanceUseCaseMock) FindConflicts(ctx context.Context) ([]entity.MaintenanceConflict, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]entity.MaintenanceConflict), args.Error(1)
+}
+
+func (m *MaintenanceUseCaseMock) SyncRoomStatus(ctx context.Context, now time.Time) error {
+	args := m.Called(ctx, now)
+	return args.Error(0)
+}
@@ -0,0 +1,37 @@
+package usecase_mock
+
+import (
+	"booking-room-app/entity"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type RateUseCaseMock struct {
+	mock.Mock
+}
+
+func (r *RateUseCaseMock) RegisterRoomRate(ctx context.Context, payload entity.RoomRate) (entity.RoomRate, error) {
+	args := r.Called(ctx, payload)
+	return args.Get(0).(entity.RoomRate), args.Error(1)
+}
+
+func (r *RateUseCaseMock) FindRoomRates(ctx context.Context, roomId string) ([]entity.RoomRate, error) {
+	args := r.Called(ctx, roomId)
+	return args.Get(0).([]entity.RoomRate), args.Error(1)
+}
+
+func (r *RateUseCaseMock) RegisterFacilityRate(ctx context.Context, payload entity.FacilityRate) (entity.FacilityRate, error) {
+	args := r.Called(ctx, payload)
+	return args.Get(0).(entity.FacilityRate), args.Error(1)
+}
+
+func (r *RateUseCaseMock) FindFacilityRates(ctx context.Context, facilityId string) ([]entity.FacilityRate, error) {
+	args := r.Called(ctx, facilityId)
+	return args.Get(0).([]entity.FacilityRate), args.Error(1)
+}
+
+func (r *RateUseCaseMock) PriceTransaction(ctx context.Context, transaction entity.Transaction) (entity.TransactionCost, error) {
+	args := r.Called(ctx, transaction)
+	return args.Get(0).(entity.TransactionCost), args.Error(1)
+}
@@ -0,0 +1,44 @@
+package usecase_mock
+
+import (
+	"booking-room-app/entity"
+	"booking-room-app/shared/model"
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type ReportScheduleUseCaseMock struct {
+	mock.Mock
+}
+
+func (r *ReportScheduleUseCaseMock) RegisterNewSchedule(ctx context.Context, payload entity.ReportSchedule) (entity.ReportSchedule, error) {
+	args := r.Called(ctx, payload)
+	return args.Get(0).(entity.ReportSchedule), args.Error(1)
+}
+
+func (r *ReportScheduleUseCaseMock) FindScheduleByID(ctx context.Context, id string) (entity.ReportSchedule, error) {
+	args := r.Called(ctx, id)
+	return args.Get(0).(entity.ReportSchedule), args.Error(1)
+}
+
+func (r *ReportScheduleUseCaseMock) FindAllSchedules(ctx context.Context, page, size int) ([]entity.ReportSchedule, model.Paging, error) {
+	args := r.Called(ctx, page, size)
+	return args.Get(0).([]entity.ReportSchedule), args.Get(1).(model.Paging), args.Error(2)
+}
+
+func (r *ReportScheduleUseCaseMock) UpdateSchedule(ctx context.Context, payload entity.ReportSchedule) (entity.ReportSchedule, error) {
+	args := r.Called(ctx, payload)
+	return args.Get(0).(entity.ReportSchedule), args.Error(1)
+}
+
+func (r *ReportScheduleUseCaseMock) DeleteSchedule(ctx context.Context, id string) error {
+	args := r.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (r *ReportScheduleUseCaseMock) RunDueSchedules(ctx context.Context, now time.Time) error {
+	args := r.Called(ctx, now)
+	return args.Error(0)
+}
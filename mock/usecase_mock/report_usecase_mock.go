@@ -1,7 +1,10 @@
 package usecase_mock
 
 import (
+	"booking-room-app/entity"
 	"booking-room-app/entity/dto"
+	"context"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -10,7 +13,22 @@ type ReportUseCaseMock struct {
 	mock.Mock
 }
 
-func (r *ReportUseCaseMock) PrintAllReports(rangeParam string) ([]dto.ReportDto, error) {
-	args := r.Called(rangeParam)
+func (r *ReportUseCaseMock) PrintAllReports(ctx context.Context, rangeParam string) ([]dto.ReportDto, error) {
+	args := r.Called(ctx, rangeParam)
 	return args.Get(0).([]dto.ReportDto), args.Error(1)
 }
+
+func (r *ReportUseCaseMock) ExportReports(ctx context.Context, rangeParam string, filter entity.ReportFilter, format string) ([]byte, error) {
+	args := r.Called(ctx, rangeParam, filter, format)
+	return args.Get(0).([]byte), args.Error(1)
+}
+
+func (r *ReportUseCaseMock) ChargebackReport(ctx context.Context, startDate, endDate time.Time, period string) ([]dto.ChargebackDto, error) {
+	args := r.Called(ctx, startDate, endDate, period)
+	return args.Get(0).([]dto.ChargebackDto), args.Error(1)
+}
+
+func (r *ReportUseCaseMock) ExportChargeback(ctx context.Context, startDate, endDate time.Time, period string, format string) ([]byte, error) {
+	args := r.Called(ctx, startDate, endDate, period, format)
+	return args.Get(0).([]byte), args.Error(1)
+}
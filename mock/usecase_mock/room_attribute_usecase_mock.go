@@ -0,0 +1,48 @@
+package usecase_mock
+
+import (
+	"booking-room-app/entity"
+	"booking-room-app/shared/model"
+	"context"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type RoomAttributeUseCaseMock struct {
+	mock.Mock
+}
+
+func (m *RoomAttributeUseCaseMock) RegisterAttribute(ctx context.Context, payload entity.RoomAttribute) (entity.RoomAttribute, error) {
+	args := m.Called(ctx, payload)
+	return args.Get(0).(entity.RoomAttribute), args.Error(1)
+}
+
+func (m *RoomAttributeUseCaseMock) FindAllAttributes(ctx context.Context) ([]entity.RoomAttribute, error) {
+	args := m.Called(ctx)
+	return args.Get(0).([]entity.RoomAttribute), args.Error(1)
+}
+
+func (m *RoomAttributeUseCaseMock) UpdateAttribute(ctx context.Context, payload entity.RoomAttribute) (entity.RoomAttribute, error) {
+	args := m.Called(ctx, payload)
+	return args.Get(0).(entity.RoomAttribute), args.Error(1)
+}
+
+func (m *RoomAttributeUseCaseMock) DeleteAttribute(ctx context.Context, id string) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *RoomAttributeUseCaseMock) FindRoomAttributes(ctx context.Context, roomId string) (map[string]any, error) {
+	args := m.Called(ctx, roomId)
+	return args.Get(0).(map[string]any), args.Error(1)
+}
+
+func (m *RoomAttributeUseCaseMock) ReplaceRoomAttributes(ctx context.Context, roomId string, values map[string]any) (map[string]any, error) {
+	args := m.Called(ctx, roomId, values)
+	return args.Get(0).(map[string]any), args.Error(1)
+}
+
+func (m *RoomAttributeUseCaseMock) SearchRooms(ctx context.Context, search entity.RoomSearch, page, size int) ([]entity.RoomSearchItem, entity.RoomFacets, model.Paging, error) {
+	args := m.Called(ctx, search, page, size)
+	return args.Get(0).([]entity.RoomSearchItem), args.Get(1).(entity.RoomFacets), args.Get(2).(model.Paging), args.Error(3)
+}
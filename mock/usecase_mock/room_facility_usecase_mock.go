@@ -3,6 +3,7 @@ package usecase_mock
 import (
 	"booking-room-app/entity"
 	"booking-room-app/shared/model"
+	"context"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -11,22 +12,32 @@ type RoomFacilityUseCaseMock struct {
 	mock.Mock
 }
 
-func (r *RoomFacilityUseCaseMock) AddRoomFacilityTransaction(payload entity.RoomFacility) (entity.RoomFacility, error) {
-	args := r.Called(payload)
+func (r *RoomFacilityUseCaseMock) AddRoomFacilityTransaction(ctx context.Context, payload entity.RoomFacility) (entity.RoomFacility, error) {
+	args := r.Called(ctx, payload)
 	return args.Get(0).(entity.RoomFacility), args.Error(1)
 }
 
-func (r *RoomFacilityUseCaseMock) FindAllRoomFacility(page, size int) ([]entity.RoomFacility, model.Paging, error) {
-	args := r.Called(page, size)
+func (r *RoomFacilityUseCaseMock) FindAllRoomFacility(ctx context.Context, page, size int) ([]entity.RoomFacility, model.Paging, error) {
+	args := r.Called(ctx, page, size)
 	return args.Get(0).([]entity.RoomFacility), args.Get(1).(model.Paging), args.Error(2)
 }
 
-func (r *RoomFacilityUseCaseMock) FindRoomFacilityById(id string) (entity.RoomFacility, error) {
-	args := r.Called(id)
+func (r *RoomFacilityUseCaseMock) FindRoomFacilityById(ctx context.Context, id string) (entity.RoomFacility, error) {
+	args := r.Called(ctx, id)
 	return args.Get(0).(entity.RoomFacility), args.Error(1)
 }
 
-func (r *RoomFacilityUseCaseMock) UpdateRoomFacilityTransaction(payload entity.RoomFacility) (entity.RoomFacility, error) {
-	args := r.Called(payload)
+func (r *RoomFacilityUseCaseMock) UpdateRoomFacilityTransaction(ctx context.Context, payload entity.RoomFacility) (entity.RoomFacility, error) {
+	args := r.Called(ctx, payload)
 	return args.Get(0).(entity.RoomFacility), args.Error(1)
 }
+
+func (r *RoomFacilityUseCaseMock) RemoveRoomFacility(ctx context.Context, id string) error {
+	args := r.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (r *RoomFacilityUseCaseMock) TransferRoomFacility(ctx context.Context, payload entity.RoomFacilityTransfer) (entity.RoomFacilityTransfer, error) {
+	args := r.Called(ctx, payload)
+	return args.Get(0).(entity.RoomFacilityTransfer), args.Error(1)
+}
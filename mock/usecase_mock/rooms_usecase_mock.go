@@ -3,6 +3,8 @@ package usecase_mock
 import (
 	"booking-room-app/entity"
 	"booking-room-app/shared/model"
+	"context"
+	"time"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -11,32 +13,57 @@ type RoomUseCaseMock struct {
 	mock.Mock
 }
 
-func (r *RoomUseCaseMock) RegisterNewRoom(payload entity.Room) (entity.Room, error) {
-	args := r.Called(payload)
+func (r *RoomUseCaseMock) RegisterNewRoom(ctx context.Context, payload entity.Room) (entity.Room, error) {
+	args := r.Called(ctx, payload)
 	return args.Get(0).(entity.Room), args.Error(1)
 }
 
-func (r *RoomUseCaseMock) FindRoomByID(id string) (entity.Room, error) {
-	args := r.Called(id)
+func (r *RoomUseCaseMock) FindRoomByID(ctx context.Context, id string) (entity.Room, error) {
+	args := r.Called(ctx, id)
 	return args.Get(0).(entity.Room), args.Error(1)
 }
 
-func (r *RoomUseCaseMock) FindAllRoom(page, size int) ([]entity.Room, model.Paging, error) {
-	args := r.Called(page, size)
+func (r *RoomUseCaseMock) FindAllRoom(ctx context.Context, page, size int) ([]entity.Room, model.Paging, error) {
+	args := r.Called(ctx, page, size)
 	return args.Get(0).([]entity.Room), args.Get(1).(model.Paging), args.Error(2)
 }
 
-func (r *RoomUseCaseMock) FindAllRoomStatus(status string, page, size int) ([]entity.Room, model.Paging, error) {
-	args := r.Called(status, page, size)
+func (r *RoomUseCaseMock) FindAllRoomStatus(ctx context.Context, status string, page, size int) ([]entity.Room, model.Paging, error) {
+	args := r.Called(ctx, status, page, size)
 	return args.Get(0).([]entity.Room), args.Get(1).(model.Paging), args.Error(2)
 }
 
-func (r *RoomUseCaseMock) UpdateRoomDetail(payload entity.Room) (entity.Room, error) {
-	args := r.Called(payload)
+func (r *RoomUseCaseMock) UpdateRoomDetail(ctx context.Context, payload entity.Room) (entity.Room, error) {
+	args := r.Called(ctx, payload)
 	return args.Get(0).(entity.Room), args.Error(1)
 }
 
-func (r *RoomUseCaseMock) UpdateRoomStatus(payload entity.Room) (entity.Room, error) {
-	args := r.Called(payload)
+func (r *RoomUseCaseMock) UpdateRoomStatus(ctx context.Context, payload entity.Room) (entity.Room, error) {
+	args := r.Called(ctx, payload)
 	return args.Get(0).(entity.Room), args.Error(1)
 }
+
+func (r *RoomUseCaseMock) ArchiveRoom(ctx context.Context, id string) (entity.Archival, error) {
+	args := r.Called(ctx, id)
+	return args.Get(0).(entity.Archival), args.Error(1)
+}
+
+func (r *RoomUseCaseMock) RestoreRoom(ctx context.Context, id string) (entity.Room, error) {
+	args := r.Called(ctx, id)
+	return args.Get(0).(entity.Room), args.Error(1)
+}
+
+func (r *RoomUseCaseMock) FindArchivedRooms(ctx context.Context, page, size int) ([]entity.Room, model.Paging, error) {
+	args := r.Called(ctx, page, size)
+	return args.Get(0).([]entity.Room), args.Get(1).(model.Paging), args.Error(2)
+}
+
+func (r *RoomUseCaseMock) FindRoomsByLocation(ctx context.Context, filter entity.LocationFilter, status string, page, size int) ([]entity.Room, model.Paging, error) {
+	args := r.Called(ctx, filter, status, page, size)
+	return args.Get(0).([]entity.Room), args.Get(1).(model.Paging), args.Error(2)
+}
+
+func (r *RoomUseCaseMock) FindAvailableRooms(ctx context.Context, filter entity.LocationFilter, start, end time.Time, page, size int) ([]entity.Room, model.Paging, error) {
+	args := r.Called(ctx, filter, start, end, page, size)
+	return args.Get(0).([]entity.Room), args.Get(1).(model.Paging), args.Error(2)
+}
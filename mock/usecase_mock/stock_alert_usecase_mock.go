@@ -0,0 +1,49 @@
+package usecase_mock
+
+import (
+	"booking-room-app/entity"
+	"booking-room-app/shared/model"
+	"context"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+)
+
+type StockAlertUseCaseMock struct {
+	mock.Mock
+}
+
+func (s *StockAlertUseCaseMock) FindThreshold(ctx context.Context, facilityId string) (entity.StockThreshold, error) {
+	args := s.Called(ctx, facilityId)
+	return args.Get(0).(entity.StockThreshold), args.Error(1)
+}
+
+func (s *StockAlertUseCaseMock) UpdateThreshold(ctx context.Context, payload entity.StockThreshold) (entity.StockThreshold, error) {
+	args := s.Called(ctx, payload)
+	return args.Get(0).(entity.StockThreshold), args.Error(1)
+}
+
+func (s *StockAlertUseCaseMock) CheckStock(ctx context.Context, facilityIds ...string) error {
+	args := s.Called(ctx, facilityIds)
+	return args.Error(0)
+}
+
+func (s *StockAlertUseCaseMock) CheckStockLevels(ctx context.Context, now time.Time) error {
+	args := s.Called(ctx, now)
+	return args.Error(0)
+}
+
+func (s *StockAlertUseCaseMock) SendAlerts(ctx context.Context, now time.Time) error {
+	args := s.Called(ctx, now)
+	return args.Error(0)
+}
+
+func (s *StockAlertUseCaseMock) FindAlerts(ctx context.Context, status string, page, size int) ([]entity.StockAlert, model.Paging, error) {
+	args := s.Called(ctx, status, page, size)
+	return args.Get(0).([]entity.StockAlert), args.Get(1).(model.Paging), args.Error(2)
+}
+
+func (s *StockAlertUseCaseMock) AcknowledgeAlert(ctx context.Context, id string) (entity.StockAlert, error) {
+	args := s.Called(ctx, id)
+	return args.Get(0).(entity.StockAlert), args.Error(1)
+}
@@ -3,6 +3,7 @@ package usecase_mock
 import (
 	"booking-room-app/entity"
 	"booking-room-app/shared/model"
+	"context"
 	"time"
 
 	"github.com/stretchr/testify/mock"
@@ -13,29 +14,34 @@ type TransactionsUseCaseMock struct {
 }
 
 // FindAllTransactions implements usecase.TransactionsUsecase.
-func (t *TransactionsUseCaseMock) FindAllTransactions(page , size int, startDate time.Time, endDate time.Time) ([]entity.Transaction, model.Paging, error) {
-	args := t.Called(page, size, startDate, endDate)
+func (t *TransactionsUseCaseMock) FindAllTransactions(ctx context.Context, page, size int, startDate time.Time, endDate time.Time) ([]entity.Transaction, model.Paging, error) {
+	args := t.Called(ctx, page, size, startDate, endDate)
 	return args.Get(0).([]entity.Transaction), args.Get(1).(model.Paging), args.Error(2)
 }
 
 // FindTransactionsByEmployeeId implements usecase.TransactionsUsecase.
-func (t *TransactionsUseCaseMock) FindTransactionsByEmployeeId(employeeId string, page, size int) ([]entity.Transaction, model.Paging, error) {
-	args := t.Called(employeeId)
+func (t *TransactionsUseCaseMock) FindTransactionsByEmployeeId(ctx context.Context, employeeId string, page, size int) ([]entity.Transaction, model.Paging, error) {
+	args := t.Called(ctx, employeeId)
 	return args.Get(0).([]entity.Transaction), args.Get(1).(model.Paging), args.Error(2)
 }
 
 // FindTransactionsById implements usecase.TransactionsUsecase.
-func (t *TransactionsUseCaseMock) FindTransactionsById(id string) (entity.Transaction, error) {
-	args := t.Called(id)
+func (t *TransactionsUseCaseMock) FindTransactionsById(ctx context.Context, id string) (entity.Transaction, error) {
+	args := t.Called(ctx, id)
 	return args.Get(0).(entity.Transaction), args.Error(1)
 }
 
-func (t *TransactionsUseCaseMock) RequestNewBookingRooms(payload entity.Transaction) (entity.Transaction, error) {
-	args := t.Called(payload)
+func (t *TransactionsUseCaseMock) RequestNewBookingRooms(ctx context.Context, payload entity.Transaction) (entity.Transaction, error) {
+	args := t.Called(ctx, payload)
 	return args.Get(0).(entity.Transaction), args.Error(1)
 }
 
-func (t *TransactionsUseCaseMock) AccStatusBooking(payload entity.Transaction) (entity.Transaction, error) {
-	args := t.Called(payload)
+func (t *TransactionsUseCaseMock) AccStatusBooking(ctx context.Context, payload entity.Transaction) (entity.Transaction, error) {
+	args := t.Called(ctx, payload)
 	return args.Get(0).(entity.Transaction), args.Error(1)
-}
\ No newline at end of file
+}
+
+func (t *TransactionsUseCaseMock) PurgeTransactions(ctx context.Context, before time.Time) (int64, error) {
+	args := t.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
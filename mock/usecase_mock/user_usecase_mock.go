@@ -3,6 +3,7 @@ package usecase_mock
 import (
 	"booking-room-app/entity"
 	"booking-room-app/shared/model"
+	"context"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -12,28 +13,48 @@ type UserUseCaseMock struct {
 }
 
 // FindEmployeForLogin implements usecase.EmployeesUseCase.
-func (t *UserUseCaseMock) FindEmployeForLogin(username string, password string) (entity.Employee, error) {
-	args := t.Called(username, password)
+func (t *UserUseCaseMock) FindEmployeForLogin(ctx context.Context, username string, password string) (entity.Employee, error) {
+	args := t.Called(ctx, username, password)
 	return args.Get(0).(entity.Employee), args.Error(1)
 }
 
-func (m *UserUseCaseMock) FindEmployeesByID(id string) (entity.Employee, error) {
-	args := m.Called(id)
+func (m *UserUseCaseMock) FindEmployeesByID(ctx context.Context, id string) (entity.Employee, error) {
+	args := m.Called(ctx, id)
 	return args.Get(0).(entity.Employee), args.Error(1)
 }
-func (m *UserUseCaseMock) FindEmployeesByUsername(username string) (entity.Employee, error) {
-	args := m.Called(username)
+func (m *UserUseCaseMock) FindEmployeesByUsername(ctx context.Context, username string) (entity.Employee, error) {
+	args := m.Called(ctx, username)
 	return args.Get(0).(entity.Employee), args.Error(1)
 }
-func (m *UserUseCaseMock) RegisterNewEmployee(payload entity.Employee) (entity.Employee, error) {
-	args := m.Called(payload)
+func (m *UserUseCaseMock) RegisterNewEmployee(ctx context.Context, payload entity.Employee) (entity.Employee, error) {
+	args := m.Called(ctx, payload)
 	return args.Get(0).(entity.Employee), args.Error(1)
 }
-func (m *UserUseCaseMock) UpdateEmployee(payload entity.Employee) (entity.Employee, error) {
-	args := m.Called(payload)
+func (m *UserUseCaseMock) UpdateEmployee(ctx context.Context, payload entity.Employee) (entity.Employee, error) {
+	args := m.Called(ctx, payload)
 	return args.Get(0).(entity.Employee), args.Error(1)
 }
-func (m *UserUseCaseMock) ListAll(page, size int) ([]entity.Employee, model.Paging, error) {
-	args := m.Called(page, size)
+func (m *UserUseCaseMock) ListAll(ctx context.Context, page, size int) ([]entity.Employee, model.Paging, error) {
+	args := m.Called(ctx, page, size)
+	return args.Get(0).([]entity.Employee), args.Get(1).(model.Paging), args.Error(2)
+}
+
+func (t *UserUseCaseMock) ResetPassword(ctx context.Context, username string, password string) (entity.Employee, error) {
+	args := t.Called(ctx, username, password)
+	return args.Get(0).(entity.Employee), args.Error(1)
+}
+
+func (m *UserUseCaseMock) ArchiveEmployee(ctx context.Context, id string) (entity.Archival, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(entity.Archival), args.Error(1)
+}
+
+func (m *UserUseCaseMock) RestoreEmployee(ctx context.Context, id string) (entity.Employee, error) {
+	args := m.Called(ctx, id)
+	return args.Get(0).(entity.Employee), args.Error(1)
+}
+
+func (m *UserUseCaseMock) FindArchivedEmployees(ctx context.Context, page, size int) ([]entity.Employee, model.Paging, error) {
+	args := m.Called(ctx, page, size)
 	return args.Get(0).([]entity.Employee), args.Get(1).(model.Paging), args.Error(2)
 }
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"booking-room-app/config"
+	"booking-room-app/entity"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// ErrOpenTransactions is returned when a record that pending or accepted
+// bookings still refer to is archived without cascading.
+var ErrOpenTransactions = errors.New("open transactions refer to the record")
+
+// archiveQueries are the statements that archive one kind of record.
+type archiveQueries struct {
+	lock    string // selects archived_at of the record FOR UPDATE
+	open    string // selects the ids of the open bookings that refer to it
+	archive string // sets archived_at and returns it
+}
+
+var (
+	roomArchive     = archiveQueries{lock: config.LockRoom, open: config.SelectOpenRoomTransactions, archive: config.ArchiveRoom}
+	facilityArchive = archiveQueries{lock: config.LockFacility, open: config.SelectOpenFacilityTransactions, archive: config.ArchiveFacility}
+	employeeArchive = archiveQueries{lock: config.LockEmployee, open: config.SelectOpenEmployeeTransactions, archive: config.ArchiveEmployee}
+)
+
+// archive marks the record with the given id as archived in one database
+// transaction. Open bookings that refer to it are declined, and the facilities
+// they took released, when cascade is set, otherwise they make archive fail
+// with ErrOpenTransactions. Archiving an archived record changes nothing.
+func archive(ctx context.Context, db *sql.DB, timeout time.Duration, queries archiveQueries, id string, cascade bool) (entity.Archival, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		slog.ErrorContext(ctx, "archive.BeginTransaction", "err", err)
+		return entity.Archival{}, err
+	}
+
+	archival, err := archiveTx(ctx, tx, queries, id, cascade)
+	if err != nil {
+		tx.Rollback()
+		return entity.Archival{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		slog.ErrorContext(ctx, "archive.TransactionCommit", "err", err)
+		return entity.Archival{}, err
+	}
+	return archival, nil
+}
+
+func archiveTx(ctx context.Context, tx *sql.Tx, queries archiveQueries, id string, cascade bool) (entity.Archival, error) {
+	archival := entity.Archival{ID: id, DeclinedTransactions: []string{}}
+
+	var archived sql.NullTime
+	if err := tx.QueryRowContext(ctx, queries.lock, id).Scan(&archived); err != nil {
+		return entity.Archival{}, err
+	}
+	if archived.Valid {
+		archival.ArchivedAt = archived.Time
+		return archival, nil
+	}
+
+	open, err := openTransactions(ctx, tx, queries.open, id)
+	if err != nil {
+		slog.ErrorContext(ctx, "archive.OpenTransactions", "err", err)
+		return entity.Archival{}, err
+	}
+	if len(open) > 0 {
+		if !cascade {
+			return entity.Archival{}, fmt.Errorf("%w: %d open bookings", ErrOpenTransactions, len(open))
+		}
+		if _, err := tx.ExecContext(ctx, config.DeclineTransactions, pq.Array(open)); err != nil {
+			slog.ErrorContext(ctx, "archive.DeclineTransactions", "err", err)
+			return entity.Archival{}, err
+		}
+		if err := releaseBookings(ctx, tx, open); err != nil {
+			return entity.Archival{}, err
+		}
+		archival.DeclinedTransactions = open
+	}
+
+	if err := tx.QueryRowContext(ctx, queries.archive, id).Scan(&archival.ArchivedAt); err != nil {
+		slog.ErrorContext(ctx, "archive.Archive", "err", err)
+		return entity.Archival{}, err
+	}
+	return archival, nil
+}
+
+func openTransactions(ctx context.Context, tx *sql.Tx, query, id string) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, query, id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var transactionId string
+		if err := rows.Scan(&transactionId); err != nil {
+			return nil, err
+		}
+		ids = append(ids, transactionId)
+	}
+	return ids, rows.Err()
+}
+
+// archivedAt converts a nullable archived_at column for an entity.
+func archivedAt(value sql.NullTime) *time.Time {
+	if !value.Valid {
+		return nil
+	}
+	return &value.Time
+}
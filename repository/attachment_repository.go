@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"booking-room-app/config"
+	"booking-room-app/entity"
+	"context"
+	"database/sql"
+	"log/slog"
+)
+
+type AttachmentRepository interface {
+	OwnerExists(ctx context.Context, ownerType, ownerId string) (bool, error)
+	Create(ctx context.Context, payload entity.Attachment) (entity.Attachment, error)
+	Get(ctx context.Context, id string) (entity.Attachment, error)
+	List(ctx context.Context, ownerType, ownerId string) ([]entity.Attachment, error)
+	Delete(ctx context.Context, id string) (entity.Attachment, error)
+}
+
+type attachmentRepository struct {
+	db       *sql.DB
+	timeouts Timeouts
+}
+
+// OwnerExists implements AttachmentRepository. Archived rooms and facilities
+// do not take new attachments.
+func (a *attachmentRepository) OwnerExists(ctx context.Context, ownerType, ownerId string) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.timeouts.Query)
+	defer cancel()
+
+	var exists bool
+	if err := a.db.QueryRowContext(ctx, config.SelectAttachmentOwner, ownerType, ownerId).Scan(&exists); err != nil {
+		slog.ErrorContext(ctx, "attachmentRepository.OwnerExistsQueryRow", "err", err)
+		return false, err
+	}
+	return exists, nil
+}
+
+// Create implements AttachmentRepository.
+func (a *attachmentRepository) Create(ctx context.Context, payload entity.Attachment) (entity.Attachment, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.timeouts.Query)
+	defer cancel()
+
+	err := a.db.QueryRowContext(ctx, config.InsertAttachment, payload.OwnerType, payload.OwnerId, payload.Filename, payload.ContentType, payload.Size, payload.StorageKey, payload.ThumbnailKey).Scan(&payload.ID, &payload.CreatedAt)
+	if err != nil {
+		slog.ErrorContext(ctx, "attachmentRepository.CreateQueryRow", "err", err)
+		return entity.Attachment{}, err
+	}
+
+	return payload, nil
+}
+
+// Get implements AttachmentRepository.
+func (a *attachmentRepository) Get(ctx context.Context, id string) (entity.Attachment, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.timeouts.Query)
+	defer cancel()
+
+	attachment, err := scanAttachment(a.db.QueryRowContext(ctx, config.SelectAttachmentByID, id))
+	if err != nil {
+		slog.ErrorContext(ctx, "attachmentRepository.GetQueryRow", "err", err)
+		return entity.Attachment{}, err
+	}
+	return attachment, nil
+}
+
+// List implements AttachmentRepository. Attachments are listed in upload
+// order.
+func (a *attachmentRepository) List(ctx context.Context, ownerType, ownerId string) ([]entity.Attachment, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.timeouts.Query)
+	defer cancel()
+
+	rows, err := a.db.QueryContext(ctx, config.SelectAttachmentsByOwner, ownerType, ownerId)
+	if err != nil {
+		slog.ErrorContext(ctx, "attachmentRepository.ListQuery", "err", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	attachments := []entity.Attachment{}
+	for rows.Next() {
+		attachment, err := scanAttachment(rows)
+		if err != nil {
+			slog.ErrorContext(ctx, "attachmentRepository.ListScan", "err", err)
+			return nil, err
+		}
+		attachments = append(attachments, attachment)
+	}
+	return attachments, rows.Err()
+}
+
+// Delete implements AttachmentRepository. It returns the deleted row, so the
+// caller can remove its blobs.
+func (a *attachmentRepository) Delete(ctx context.Context, id string) (entity.Attachment, error) {
+	ctx, cancel := context.WithTimeout(ctx, a.timeouts.Query)
+	defer cancel()
+
+	attachment, err := scanAttachment(a.db.QueryRowContext(ctx, config.DeleteAttachment, id))
+	if err != nil {
+		slog.ErrorContext(ctx, "attachmentRepository.DeleteQueryRow", "err", err)
+		return entity.Attachment{}, err
+	}
+	return attachment, nil
+}
+
+func scanAttachment(row rowScanner) (entity.Attachment, error) {
+	var attachment entity.Attachment
+	err := row.Scan(&attachment.ID, &attachment.OwnerType, &attachment.OwnerId, &attachment.Filename, &attachment.ContentType, &attachment.Size, &attachment.StorageKey, &attachment.ThumbnailKey, &attachment.CreatedAt)
+	return attachment, err
+}
+
+func NewAttachmentRepository(db *sql.DB, timeouts Timeouts) AttachmentRepository {
+	return &attachmentRepository{db: db, timeouts: timeouts}
+}
@@ -0,0 +1,94 @@
+package repository
+
+import (
+	"booking-room-app/config"
+	"booking-room-app/entity"
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+var attachmentColumns = []string{"id", "owner_type", "owner_id", "filename", "content_type", "size", "storage_key", "thumbnail_key", "created_at"}
+
+type AttachmentRepositoryTestSuite struct {
+	suite.Suite
+	mockDb  *sql.DB
+	mockSql sqlmock.Sqlmock
+	repo    AttachmentRepository
+}
+
+func (suite *AttachmentRepositoryTestSuite) SetupTest() {
+	db, mock, _ := sqlmock.New()
+	suite.mockDb = db
+	suite.mockSql = mock
+	suite.repo = NewAttachmentRepository(suite.mockDb, DefaultTimeouts())
+}
+
+func (suite *AttachmentRepositoryTestSuite) TestOwnerExists_Success() {
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectAttachmentOwner)).WithArgs("room", "9").
+		WillReturnRows(sqlmock.NewRows([]string{"exists"}).AddRow(true))
+
+	exists, err := suite.repo.OwnerExists(context.Background(), "room", "9")
+
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), exists)
+}
+
+func (suite *AttachmentRepositoryTestSuite) TestCreate_Success() {
+	now := time.Now()
+	payload := entity.Attachment{OwnerType: "room", OwnerId: "9", Filename: "melati.jpg", ContentType: "image/jpeg", Size: 2048, StorageKey: "rooms/9/a.jpg", ThumbnailKey: "rooms/9/a_thumb.jpg"}
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.InsertAttachment)).WithArgs("room", "9", "melati.jpg", "image/jpeg", int64(2048), "rooms/9/a.jpg", "rooms/9/a_thumb.jpg").
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow("1", now))
+
+	actual, err := suite.repo.Create(context.Background(), payload)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "1", actual.ID)
+	assert.Equal(suite.T(), now, actual.CreatedAt)
+}
+
+func (suite *AttachmentRepositoryTestSuite) TestList_Success() {
+	now := time.Now()
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectAttachmentsByOwner)).WithArgs("facility", "3").
+		WillReturnRows(sqlmock.NewRows(attachmentColumns).
+			AddRow("1", "facility", "3", "manual.pdf", "application/pdf", 4096, "facilities/3/a.pdf", "", now).
+			AddRow("2", "facility", "3", "front.png", "image/png", 1024, "facilities/3/b.png", "facilities/3/b_thumb.jpg", now))
+
+	actual, err := suite.repo.List(context.Background(), "facility", "3")
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), actual, 2)
+	assert.Empty(suite.T(), actual[0].ThumbnailKey)
+	assert.Equal(suite.T(), "facilities/3/b_thumb.jpg", actual[1].ThumbnailKey)
+}
+
+func (suite *AttachmentRepositoryTestSuite) TestGet_Fail() {
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectAttachmentByID)).WithArgs("1").WillReturnError(sql.ErrNoRows)
+
+	_, err := suite.repo.Get(context.Background(), "1")
+
+	assert.True(suite.T(), errors.Is(err, sql.ErrNoRows))
+}
+
+func (suite *AttachmentRepositoryTestSuite) TestDelete_Success() {
+	now := time.Now()
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.DeleteAttachment)).WithArgs("1").
+		WillReturnRows(sqlmock.NewRows(attachmentColumns).AddRow("1", "room", "9", "melati.jpg", "image/jpeg", 2048, "rooms/9/a.jpg", "rooms/9/a_thumb.jpg", now))
+
+	actual, err := suite.repo.Delete(context.Background(), "1")
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "rooms/9/a.jpg", actual.StorageKey)
+	assert.Equal(suite.T(), "rooms/9/a_thumb.jpg", actual.ThumbnailKey)
+}
+
+func TestAttachmentRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(AttachmentRepositoryTestSuite))
+}
@@ -0,0 +1,320 @@
+package repository
+
+import (
+	"booking-room-app/config"
+	"booking-room-app/entity"
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+)
+
+type CalendarRepository interface {
+	ListHours(ctx context.Context, siteId, roomId string) ([]entity.BusinessHours, error)
+	ReplaceHours(ctx context.Context, payload entity.WeeklyHours) (entity.WeeklyHours, error)
+	CreateHoliday(ctx context.Context, payload entity.Holiday) (entity.Holiday, error)
+	ImportHolidays(ctx context.Context, holidays []entity.Holiday) (int64, error)
+	ListHolidays(ctx context.Context, siteId string, year int) ([]entity.Holiday, error)
+	DeleteHoliday(ctx context.Context, id string) error
+	CreateBlackout(ctx context.Context, payload entity.Blackout) (entity.Blackout, error)
+	ListBlackouts(ctx context.Context, from, to time.Time) ([]entity.Blackout, error)
+	DeleteBlackout(ctx context.Context, id string) error
+	GetRoomCalendar(ctx context.Context, roomId string, start, end time.Time) (entity.RoomCalendar, error)
+}
+
+type calendarRepository struct {
+	db       *sql.DB
+	timeouts Timeouts
+}
+
+// ListHours implements CalendarRepository.
+func (c *calendarRepository) ListHours(ctx context.Context, siteId, roomId string) ([]entity.BusinessHours, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeouts.Query)
+	defer cancel()
+
+	rows, err := c.db.QueryContext(ctx, config.SelectBusinessHours, siteId, roomId)
+	if err != nil {
+		slog.ErrorContext(ctx, "calendarRepository.ListHoursQuery", "err", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanBusinessHours(rows)
+}
+
+// ReplaceHours implements CalendarRepository. The week of the site or room is
+// replaced as a whole, in one database transaction.
+func (c *calendarRepository) ReplaceHours(ctx context.Context, payload entity.WeeklyHours) (entity.WeeklyHours, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeouts.Query)
+	defer cancel()
+
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		slog.ErrorContext(ctx, "calendarRepository.ReplaceHoursBeginTransaction", "err", err)
+		return entity.WeeklyHours{}, err
+	}
+
+	if _, err := tx.ExecContext(ctx, config.DeleteBusinessHours, payload.SiteId, payload.RoomId); err != nil {
+		slog.ErrorContext(ctx, "calendarRepository.ReplaceHoursDelete", "err", err)
+		tx.Rollback()
+		return entity.WeeklyHours{}, err
+	}
+
+	hours := make([]entity.BusinessHours, 0, len(payload.Hours))
+	for _, interval := range payload.Hours {
+		interval.SiteId = payload.SiteId
+		interval.RoomId = payload.RoomId
+		err := tx.QueryRowContext(ctx, config.InsertBusinessHours, payload.SiteId, payload.RoomId, int(interval.Weekday), interval.Open, interval.Close).Scan(&interval.ID)
+		if err != nil {
+			slog.ErrorContext(ctx, "calendarRepository.ReplaceHoursInsert", "err", err)
+			tx.Rollback()
+			return entity.WeeklyHours{}, err
+		}
+		hours = append(hours, interval)
+	}
+
+	if err := tx.Commit(); err != nil {
+		slog.ErrorContext(ctx, "calendarRepository.ReplaceHoursCommit", "err", err)
+		return entity.WeeklyHours{}, err
+	}
+
+	payload.Hours = hours
+	return payload, nil
+}
+
+// CreateHoliday implements CalendarRepository.
+func (c *calendarRepository) CreateHoliday(ctx context.Context, payload entity.Holiday) (entity.Holiday, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeouts.Query)
+	defer cancel()
+
+	err := c.db.QueryRowContext(ctx, config.InsertHoliday, payload.SiteId, payload.Date, payload.Name).Scan(&payload.ID, &payload.CreatedAt)
+	if err != nil {
+		slog.ErrorContext(ctx, "calendarRepository.CreateHolidayQueryRow", "err", err)
+		return entity.Holiday{}, err
+	}
+
+	return payload, nil
+}
+
+// ImportHolidays implements CalendarRepository. Days that already have a
+// holiday for the same site are skipped; the number of new holidays is
+// returned.
+func (c *calendarRepository) ImportHolidays(ctx context.Context, holidays []entity.Holiday) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeouts.Query)
+	defer cancel()
+
+	tx, err := c.db.BeginTx(ctx, nil)
+	if err != nil {
+		slog.ErrorContext(ctx, "calendarRepository.ImportHolidaysBeginTransaction", "err", err)
+		return 0, err
+	}
+
+	var imported int64
+	for _, holiday := range holidays {
+		result, err := tx.ExecContext(ctx, config.ImportHoliday, holiday.SiteId, holiday.Date, holiday.Name)
+		if err != nil {
+			slog.ErrorContext(ctx, "calendarRepository.ImportHolidaysExec", "err", err)
+			tx.Rollback()
+			return 0, err
+		}
+		affected, err := result.RowsAffected()
+		if err != nil {
+			tx.Rollback()
+			return 0, err
+		}
+		imported += affected
+	}
+
+	if err := tx.Commit(); err != nil {
+		slog.ErrorContext(ctx, "calendarRepository.ImportHolidaysCommit", "err", err)
+		return 0, err
+	}
+	return imported, nil
+}
+
+// ListHolidays implements CalendarRepository. An empty siteId lists the
+// holidays of every site and a zero year those of every year.
+func (c *calendarRepository) ListHolidays(ctx context.Context, siteId string, year int) ([]entity.Holiday, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeouts.Query)
+	defer cancel()
+
+	rows, err := c.db.QueryContext(ctx, config.SelectHolidayList, siteId, year)
+	if err != nil {
+		slog.ErrorContext(ctx, "calendarRepository.ListHolidaysQuery", "err", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanHolidays(rows)
+}
+
+// DeleteHoliday implements CalendarRepository.
+func (c *calendarRepository) DeleteHoliday(ctx context.Context, id string) error {
+	return deleteByID(ctx, c.db, c.timeouts.Query, config.DeleteHoliday, id)
+}
+
+// CreateBlackout implements CalendarRepository.
+func (c *calendarRepository) CreateBlackout(ctx context.Context, payload entity.Blackout) (entity.Blackout, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeouts.Query)
+	defer cancel()
+
+	err := c.db.QueryRowContext(ctx, config.InsertBlackout, payload.SiteId, payload.RoomId, payload.Reason, payload.StartTime, payload.EndTime).Scan(&payload.ID, &payload.CreatedAt)
+	if err != nil {
+		slog.ErrorContext(ctx, "calendarRepository.CreateBlackoutQueryRow", "err", err)
+		return entity.Blackout{}, err
+	}
+
+	return payload, nil
+}
+
+// ListBlackouts implements CalendarRepository. It returns the blackouts that
+// overlap the period from, to.
+func (c *calendarRepository) ListBlackouts(ctx context.Context, from, to time.Time) ([]entity.Blackout, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeouts.Query)
+	defer cancel()
+
+	rows, err := c.db.QueryContext(ctx, config.SelectBlackoutList, from, to)
+	if err != nil {
+		slog.ErrorContext(ctx, "calendarRepository.ListBlackoutsQuery", "err", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	return scanBlackouts(rows)
+}
+
+// DeleteBlackout implements CalendarRepository.
+func (c *calendarRepository) DeleteBlackout(ctx context.Context, id string) error {
+	return deleteByID(ctx, c.db, c.timeouts.Query, config.DeleteBlackout, id)
+}
+
+// GetRoomCalendar implements CalendarRepository. Holidays are looked up by the
+// local dates of the period, so a day either side is included to cover any
+// time zone. It returns ErrRoomNotFound for an unknown room.
+func (c *calendarRepository) GetRoomCalendar(ctx context.Context, roomId string, start, end time.Time) (entity.RoomCalendar, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeouts.Query)
+	defer cancel()
+
+	calendar := entity.RoomCalendar{RoomId: roomId}
+	var siteId string
+	err := c.db.QueryRowContext(ctx, config.SelectRoomSite, roomId).Scan(&siteId, &calendar.Timezone)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.RoomCalendar{}, ErrRoomNotFound
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "calendarRepository.GetRoomCalendarSite", "err", err)
+		return entity.RoomCalendar{}, err
+	}
+
+	rows, err := c.db.QueryContext(ctx, config.SelectRoomBusinessHours, roomId, siteId)
+	if err != nil {
+		slog.ErrorContext(ctx, "calendarRepository.GetRoomCalendarHours", "err", err)
+		return entity.RoomCalendar{}, err
+	}
+	hours, err := scanBusinessHours(rows)
+	rows.Close()
+	if err != nil {
+		return entity.RoomCalendar{}, err
+	}
+	calendar.Hours = roomHours(hours)
+
+	from := start.AddDate(0, 0, -1).Format(time.DateOnly)
+	to := end.AddDate(0, 0, 1).Format(time.DateOnly)
+	rows, err = c.db.QueryContext(ctx, config.SelectRoomHolidays, siteId, from, to)
+	if err != nil {
+		slog.ErrorContext(ctx, "calendarRepository.GetRoomCalendarHolidays", "err", err)
+		return entity.RoomCalendar{}, err
+	}
+	calendar.Holidays, err = scanHolidays(rows)
+	rows.Close()
+	if err != nil {
+		return entity.RoomCalendar{}, err
+	}
+
+	rows, err = c.db.QueryContext(ctx, config.SelectRoomBlackouts, roomId, siteId, start, end)
+	if err != nil {
+		slog.ErrorContext(ctx, "calendarRepository.GetRoomCalendarBlackouts", "err", err)
+		return entity.RoomCalendar{}, err
+	}
+	calendar.Blackouts, err = scanBlackouts(rows)
+	rows.Close()
+	if err != nil {
+		return entity.RoomCalendar{}, err
+	}
+
+	rows, err = c.db.QueryContext(ctx, config.SelectRoomMaintenance, roomId, start, end)
+	if err != nil {
+		slog.ErrorContext(ctx, "calendarRepository.GetRoomCalendarMaintenance", "err", err)
+		return entity.RoomCalendar{}, err
+	}
+	calendar.Maintenance, err = scanMaintenance(rows)
+	rows.Close()
+	if err != nil {
+		return entity.RoomCalendar{}, err
+	}
+
+	return calendar, nil
+}
+
+// roomHours keeps the hours of the room itself when it has any, otherwise
+// those of its site.
+func roomHours(hours []entity.BusinessHours) []entity.BusinessHours {
+	var own, site []entity.BusinessHours
+	for _, interval := range hours {
+		if interval.RoomId != "" {
+			own = append(own, interval)
+		} else {
+			site = append(site, interval)
+		}
+	}
+	if len(own) > 0 {
+		return own
+	}
+	return site
+}
+
+func scanBusinessHours(rows *sql.Rows) ([]entity.BusinessHours, error) {
+	hours := []entity.BusinessHours{}
+	for rows.Next() {
+		var interval entity.BusinessHours
+		var weekday int
+		if err := rows.Scan(&interval.ID, &interval.SiteId, &interval.RoomId, &weekday, &interval.Open, &interval.Close); err != nil {
+			slog.Error("calendarRepository.BusinessHoursScan", "err", err)
+			return nil, err
+		}
+		interval.Weekday = time.Weekday(weekday)
+		hours = append(hours, interval)
+	}
+	return hours, rows.Err()
+}
+
+func scanHolidays(rows *sql.Rows) ([]entity.Holiday, error) {
+	holidays := []entity.Holiday{}
+	for rows.Next() {
+		var holiday entity.Holiday
+		if err := rows.Scan(&holiday.ID, &holiday.SiteId, &holiday.Date, &holiday.Name, &holiday.CreatedAt); err != nil {
+			slog.Error("calendarRepository.HolidayScan", "err", err)
+			return nil, err
+		}
+		holidays = append(holidays, holiday)
+	}
+	return holidays, rows.Err()
+}
+
+func scanBlackouts(rows *sql.Rows) ([]entity.Blackout, error) {
+	blackouts := []entity.Blackout{}
+	for rows.Next() {
+		var blackout entity.Blackout
+		if err := rows.Scan(&blackout.ID, &blackout.SiteId, &blackout.RoomId, &blackout.Reason, &blackout.StartTime, &blackout.EndTime, &blackout.CreatedAt); err != nil {
+			slog.Error("calendarRepository.BlackoutScan", "err", err)
+			return nil, err
+		}
+		blackouts = append(blackouts, blackout)
+	}
+	return blackouts, rows.Err()
+}
+
+func NewCalendarRepository(db *sql.DB, timeouts Timeouts) CalendarRepository {
+	return &calendarRepository{db: db, timeouts: timeouts}
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"booking-room-app/config"
+	"booking-room-app/entity"
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+var hoursColumns = []string{"id", "site_id", "room_id", "weekday", "open_time", "close_time"}
+
+var blackoutColumns = []string{"id", "site_id", "room_id", "reason", "start_time", "end_time", "created_at"}
+
+type CalendarRepositoryTestSuite struct {
+	suite.Suite
+	mockDb  *sql.DB
+	mockSql sqlmock.Sqlmock
+	repo    CalendarRepository
+}
+
+func (suite *CalendarRepositoryTestSuite) SetupTest() {
+	db, mock, _ := sqlmock.New()
+	suite.mockDb = db
+	suite.mockSql = mock
+	suite.repo = NewCalendarRepository(suite.mockDb, DefaultTimeouts())
+}
+
+func (suite *CalendarRepositoryTestSuite) TestReplaceHours_Success() {
+	week := entity.WeeklyHours{SiteId: "1", Hours: []entity.BusinessHours{
+		{Weekday: time.Monday, Open: "08:00", Close: "12:00"},
+		{Weekday: time.Monday, Open: "13:00", Close: "17:00"},
+	}}
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectExec(regexp.QuoteMeta(config.DeleteBusinessHours)).WithArgs("1", "").WillReturnResult(sqlmock.NewResult(0, 5))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.InsertBusinessHours)).WithArgs("1", "", 1, "08:00", "12:00").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("h1"))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.InsertBusinessHours)).WithArgs("1", "", 1, "13:00", "17:00").WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("h2"))
+	suite.mockSql.ExpectCommit()
+
+	actual, err := suite.repo.ReplaceHours(context.Background(), week)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []entity.BusinessHours{
+		{ID: "h1", SiteId: "1", Weekday: time.Monday, Open: "08:00", Close: "12:00"},
+		{ID: "h2", SiteId: "1", Weekday: time.Monday, Open: "13:00", Close: "17:00"},
+	}, actual.Hours)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *CalendarRepositoryTestSuite) TestReplaceHours_InsertFailure() {
+	week := entity.WeeklyHours{RoomId: "9", Hours: []entity.BusinessHours{{Weekday: time.Monday, Open: "08:00", Close: "12:00"}}}
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectExec(regexp.QuoteMeta(config.DeleteBusinessHours)).WithArgs("", "9").WillReturnResult(sqlmock.NewResult(0, 0))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.InsertBusinessHours)).WithArgs("", "9", 1, "08:00", "12:00").WillReturnError(errors.New("insert business hours failed"))
+	suite.mockSql.ExpectRollback()
+
+	_, err := suite.repo.ReplaceHours(context.Background(), week)
+
+	assert.Error(suite.T(), err)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *CalendarRepositoryTestSuite) TestImportHolidays_SkipsExistingSuccess() {
+	holidays := []entity.Holiday{{Date: "2024-01-01", Name: "New Year"}, {Date: "2024-04-10", Name: "Idul Fitri"}}
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectExec(regexp.QuoteMeta(config.ImportHoliday)).WithArgs("", "2024-01-01", "New Year").WillReturnResult(sqlmock.NewResult(0, 0))
+	suite.mockSql.ExpectExec(regexp.QuoteMeta(config.ImportHoliday)).WithArgs("", "2024-04-10", "Idul Fitri").WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mockSql.ExpectCommit()
+
+	imported, err := suite.repo.ImportHolidays(context.Background(), holidays)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(1), imported)
+}
+
+func (suite *CalendarRepositoryTestSuite) TestDeleteBlackout_NotFoundFailure() {
+	suite.mockSql.ExpectExec(regexp.QuoteMeta(config.DeleteBlackout)).WithArgs("1").WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := suite.repo.DeleteBlackout(context.Background(), "1")
+
+	assert.ErrorIs(suite.T(), err, sql.ErrNoRows)
+}
+
+func (suite *CalendarRepositoryTestSuite) TestGetRoomCalendar_RoomHoursReplaceSiteSuccess() {
+	start := time.Date(2024, time.January, 8, 2, 0, 0, 0, time.UTC)
+	end := start.Add(2 * time.Hour)
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectRoomSite)).WithArgs("9").WillReturnRows(sqlmock.NewRows([]string{"site_id", "timezone"}).AddRow("1", "Asia/Jakarta"))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectRoomBusinessHours)).WithArgs("9", "1").WillReturnRows(sqlmock.NewRows(hoursColumns).
+		AddRow("h1", "1", "", 1, "08:00", "17:00").
+		AddRow("h2", "", "9", 1, "10:00", "12:00"))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectRoomHolidays)).WithArgs("1", "2024-01-07", "2024-01-09").WillReturnRows(sqlmock.NewRows([]string{"id", "site_id", "date", "name", "created_at"}))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectRoomBlackouts)).WithArgs("9", "1", start, end).WillReturnRows(sqlmock.NewRows(blackoutColumns).AddRow("b1", "", "", "Town hall", start, end, start))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectRoomMaintenance)).WithArgs("9", start, end).WillReturnRows(sqlmock.NewRows(maintenanceColumns))
+
+	actual, err := suite.repo.GetRoomCalendar(context.Background(), "9", start, end)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Asia/Jakarta", actual.Timezone)
+	assert.Equal(suite.T(), []entity.BusinessHours{{ID: "h2", RoomId: "9", Weekday: time.Monday, Open: "10:00", Close: "12:00"}}, actual.Hours)
+	assert.Empty(suite.T(), actual.Holidays)
+	assert.Len(suite.T(), actual.Blackouts, 1)
+	assert.Empty(suite.T(), actual.Maintenance)
+}
+
+func (suite *CalendarRepositoryTestSuite) TestGetRoomCalendar_UnknownRoomFailure() {
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectRoomSite)).WithArgs("9").WillReturnError(sql.ErrNoRows)
+
+	_, err := suite.repo.GetRoomCalendar(context.Background(), "9", time.Now(), time.Now().Add(time.Hour))
+
+	assert.ErrorIs(suite.T(), err, ErrRoomNotFound)
+}
+
+func TestCalendarRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(CalendarRepositoryTestSuite))
+}
@@ -4,31 +4,39 @@ import (
 	"booking-room-app/config"
 	"booking-room-app/entity"
 	"booking-room-app/shared/model"
+	"context"
 	"database/sql"
-	"log"
+	"log/slog"
 	"math"
 	"time"
 )
 
 type EmployeeRepository interface {
-	GetEmployeesByID(id string) (entity.Employee, error)
-	GetEmployeesByUsername(username string) (entity.Employee, error)
-	GetEmployeesByUsernameForLogin(username, password string) (entity.Employee, error)
-	CreateEmployee(payload entity.Employee) (entity.Employee, error)
-	UpdateEmployee(payload entity.Employee) (entity.Employee, error)
-	List(page, size int) ([]entity.Employee, model.Paging, error)
+	GetEmployeesByID(ctx context.Context, id string) (entity.Employee, error)
+	GetEmployeesByUsername(ctx context.Context, username string) (entity.Employee, error)
+	GetEmployeesByUsernameForLogin(ctx context.Context, username, password string) (entity.Employee, error)
+	CreateEmployee(ctx context.Context, payload entity.Employee) (entity.Employee, error)
+	UpdateEmployee(ctx context.Context, payload entity.Employee) (entity.Employee, error)
+	List(ctx context.Context, page, size int) ([]entity.Employee, model.Paging, error)
+	ListArchived(ctx context.Context, page, size int) ([]entity.Employee, model.Paging, error)
+	Archive(ctx context.Context, id string, cascade bool) (entity.Archival, error)
+	Restore(ctx context.Context, id string) (entity.Employee, error)
 }
 
 type employeeRepository struct {
-	db *sql.DB
+	db       *sql.DB
+	timeouts Timeouts
 }
 
-func (e *employeeRepository) CreateEmployee(payload entity.Employee) (entity.Employee, error) {
+func (e *employeeRepository) CreateEmployee(ctx context.Context, payload entity.Employee) (entity.Employee, error) {
+	ctx, cancel := context.WithTimeout(ctx, e.timeouts.Query)
+	defer cancel()
+
 	var employee entity.Employee
 
 	payload.UpdatedAt = time.Now()
 
-	err := e.db.QueryRow(config.InsertEmployee,
+	err := e.db.QueryRowContext(ctx, config.InsertEmployee,
 		payload.Name,
 		payload.Username,
 		payload.Password,
@@ -38,7 +46,7 @@ func (e *employeeRepository) CreateEmployee(payload entity.Employee) (entity.Emp
 		payload.Contact).Scan(&employee.ID, &employee.CreatedAt, &employee.UpdatedAt)
 
 	if err != nil {
-		log.Println("employeeRepository.QueryRow: ", err.Error())
+		slog.ErrorContext(ctx, "employeeRepository.QueryRow", "err", err)
 		return entity.Employee{}, err
 	}
 
@@ -55,9 +63,13 @@ func (e *employeeRepository) CreateEmployee(payload entity.Employee) (entity.Emp
 }
 
 // GetEmployeesByID implements EmployeeRepository.
-func (e *employeeRepository) GetEmployeesByID(id string) (entity.Employee, error) {
+func (e *employeeRepository) GetEmployeesByID(ctx context.Context, id string) (entity.Employee, error) {
+	ctx, cancel := context.WithTimeout(ctx, e.timeouts.Query)
+	defer cancel()
+
 	var employee entity.Employee
-	err := e.db.QueryRow(config.SelectEmployeeByID, id).Scan(
+	var archived sql.NullTime
+	err := e.db.QueryRowContext(ctx, config.SelectEmployeeByID, id).Scan(
 		&employee.ID,
 		&employee.Name,
 		&employee.Username,
@@ -67,17 +79,23 @@ func (e *employeeRepository) GetEmployeesByID(id string) (entity.Employee, error
 		&employee.Position,
 		&employee.Contact,
 		&employee.CreatedAt,
-		&employee.UpdatedAt)
+		&employee.UpdatedAt,
+		&archived)
 	if err != nil {
-		log.Println("employeeRepository.GetEmployeeByID.QueryRow: ", err.Error())
+		slog.ErrorContext(ctx, "employeeRepository.GetEmployeeByID.QueryRow", "err", err)
 		return entity.Employee{}, err
 	}
+	employee.ArchivedAt = archivedAt(archived)
 	return employee, nil
 }
 
-func (e *employeeRepository) GetEmployeesByUsername(username string) (entity.Employee, error) {
+func (e *employeeRepository) GetEmployeesByUsername(ctx context.Context, username string) (entity.Employee, error) {
+	ctx, cancel := context.WithTimeout(ctx, e.timeouts.Query)
+	defer cancel()
+
 	var employee entity.Employee
-	err := e.db.QueryRow(config.SelectEmployeeByUsername, username).Scan(
+	var archived sql.NullTime
+	err := e.db.QueryRowContext(ctx, config.SelectEmployeeByUsername, username).Scan(
 		&employee.ID,
 		&employee.Name,
 		&employee.Username,
@@ -87,35 +105,43 @@ func (e *employeeRepository) GetEmployeesByUsername(username string) (entity.Emp
 		&employee.Position,
 		&employee.Contact,
 		&employee.CreatedAt,
-		&employee.UpdatedAt)
+		&employee.UpdatedAt,
+		&archived)
 	if err != nil {
-		log.Println("employeeRepository.GetEmployeeByID.QueryRow: ", err.Error())
+		slog.ErrorContext(ctx, "employeeRepository.GetEmployeeByID.QueryRow", "err", err)
 		return entity.Employee{}, err
 	}
+	employee.ArchivedAt = archivedAt(archived)
 	return employee, nil
 }
 
-func (e *employeeRepository) GetEmployeesByUsernameForLogin(username, password string) (entity.Employee, error) {
+func (e *employeeRepository) GetEmployeesByUsernameForLogin(ctx context.Context, username, password string) (entity.Employee, error) {
+	ctx, cancel := context.WithTimeout(ctx, e.timeouts.Query)
+	defer cancel()
+
 	var employee entity.Employee
-	err := e.db.QueryRow(config.SelectEmployeeForLogin, username, password).Scan(
+	err := e.db.QueryRowContext(ctx, config.SelectEmployeeForLogin, username, password).Scan(
 		&employee.ID,
 		&employee.Name,
 		&employee.Username,
 		&employee.Password,
 		&employee.Role)
 	if err != nil {
-		log.Println("employeeRepository.GetEmployeeByID.QueryRow: ", err.Error())
+		slog.ErrorContext(ctx, "employeeRepository.GetEmployeeByID.QueryRow", "err", err)
 		return entity.Employee{}, err
 	}
 	return employee, nil
 }
 
 // UpdateEmployee implements EmployeeRepository.
-func (e *employeeRepository) UpdateEmployee(payload entity.Employee) (entity.Employee, error) {
+func (e *employeeRepository) UpdateEmployee(ctx context.Context, payload entity.Employee) (entity.Employee, error) {
+	ctx, cancel := context.WithTimeout(ctx, e.timeouts.Query)
+	defer cancel()
+
 	var employee entity.Employee
 	payload.UpdatedAt = time.Now()
 
-	err := e.db.QueryRow(config.UpdateEmployee,
+	err := e.db.QueryRowContext(ctx, config.UpdateEmployee,
 		payload.Name,
 		payload.Username,
 		payload.Password,
@@ -126,7 +152,7 @@ func (e *employeeRepository) UpdateEmployee(payload entity.Employee) (entity.Emp
 		payload.ID).Scan(&employee.CreatedAt, &employee.UpdatedAt)
 
 	if err != nil {
-		log.Println("employeeRepository.QueryRow: ", err.Error())
+		slog.ErrorContext(ctx, "employeeRepository.QueryRow", "err", err)
 		return entity.Employee{}, err
 	}
 	employee.ID = payload.ID
@@ -142,16 +168,72 @@ func (e *employeeRepository) UpdateEmployee(payload entity.Employee) (entity.Emp
 	return employee, nil
 }
 
-func (e *employeeRepository) List(page, size int) ([]entity.Employee, model.Paging, error) {
+func (e *employeeRepository) List(ctx context.Context, page, size int) ([]entity.Employee, model.Paging, error) {
+	ctx, cancel := context.WithTimeout(ctx, e.timeouts.Query)
+	defer cancel()
+
+	var employees []entity.Employee
+	offset := (page - 1) * size
+	rows, err := e.db.QueryContext(ctx, config.SelectAllEmployee, size, offset)
+	if err != nil {
+		slog.ErrorContext(ctx, "employeeRepository.Query", "err", err)
+		return nil, model.Paging{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var emp entity.Employee
+		err := rows.Scan(
+			&emp.ID,
+			&emp.Name,
+			&emp.Username,
+			&emp.Password,
+			&emp.Role,
+			&emp.Division,
+			&emp.Position,
+			&emp.Contact,
+			&emp.CreatedAt,
+			&emp.UpdatedAt,
+		)
+		if err != nil {
+			slog.ErrorContext(ctx, "employeeRepository.Rows.Next()", "err", err)
+			return nil, model.Paging{}, err
+		}
+
+		employees = append(employees, emp)
+	}
+
+	totalRows := 0
+	if err := e.db.QueryRowContext(ctx, config.SelectCountEmployee).Scan(&totalRows); err != nil {
+		return nil, model.Paging{}, err
+	}
+
+	paging := model.Paging{
+		Page:        page,
+		RowsPerPage: size,
+		TotalRows:   totalRows,
+		TotalPages:  int(math.Ceil(float64(totalRows) / float64(size))),
+	}
+	return employees, paging, nil
+}
+
+// ListArchived implements EmployeeRepository.
+func (e *employeeRepository) ListArchived(ctx context.Context, page, size int) ([]entity.Employee, model.Paging, error) {
+	ctx, cancel := context.WithTimeout(ctx, e.timeouts.Query)
+	defer cancel()
+
 	var employees []entity.Employee
 	offset := (page - 1) * size
-	rows, err := e.db.Query(config.SelectAllEmployee, size, offset)
+	rows, err := e.db.QueryContext(ctx, config.SelectArchivedEmployeeList, size, offset)
 	if err != nil {
-		log.Println("employeeRepository.Query:", err.Error())
+		slog.ErrorContext(ctx, "employeeRepository.ListArchived", "err", err)
 		return nil, model.Paging{}, err
 	}
+	defer rows.Close()
+
 	for rows.Next() {
 		var emp entity.Employee
+		var archived sql.NullTime
 		err := rows.Scan(
 			&emp.ID,
 			&emp.Name,
@@ -163,17 +245,19 @@ func (e *employeeRepository) List(page, size int) ([]entity.Employee, model.Pagi
 			&emp.Contact,
 			&emp.CreatedAt,
 			&emp.UpdatedAt,
+			&archived,
 		)
 		if err != nil {
-			log.Println("employeeRepository.Rows.Next():", err.Error())
+			slog.ErrorContext(ctx, "employeeRepository.ListArchived.Scan", "err", err)
 			return nil, model.Paging{}, err
 		}
+		emp.ArchivedAt = archivedAt(archived)
 
 		employees = append(employees, emp)
 	}
 
 	totalRows := 0
-	if err := e.db.QueryRow("SELECT COUNT(*) FROM employees").Scan(&totalRows); err != nil {
+	if err := e.db.QueryRowContext(ctx, config.SelectCountArchivedEmployee).Scan(&totalRows); err != nil {
 		return nil, model.Paging{}, err
 	}
 
@@ -186,6 +270,35 @@ func (e *employeeRepository) List(page, size int) ([]entity.Employee, model.Pagi
 	return employees, paging, nil
 }
 
-func NewEmployeeRepository(db *sql.DB) EmployeeRepository {
-	return &employeeRepository{db: db}
+// Archive implements EmployeeRepository.
+func (e *employeeRepository) Archive(ctx context.Context, id string, cascade bool) (entity.Archival, error) {
+	return archive(ctx, e.db, e.timeouts.Query, employeeArchive, id, cascade)
+}
+
+// Restore implements EmployeeRepository.
+func (e *employeeRepository) Restore(ctx context.Context, id string) (entity.Employee, error) {
+	ctx, cancel := context.WithTimeout(ctx, e.timeouts.Query)
+	defer cancel()
+
+	var employee entity.Employee
+	err := e.db.QueryRowContext(ctx, config.RestoreEmployee, id).Scan(
+		&employee.ID,
+		&employee.Name,
+		&employee.Username,
+		&employee.Password,
+		&employee.Role,
+		&employee.Division,
+		&employee.Position,
+		&employee.Contact,
+		&employee.CreatedAt,
+		&employee.UpdatedAt)
+	if err != nil {
+		slog.ErrorContext(ctx, "employeeRepository.Restore.QueryRow", "err", err)
+		return entity.Employee{}, err
+	}
+	return employee, nil
+}
+
+func NewEmployeeRepository(db *sql.DB, timeouts Timeouts) EmployeeRepository {
+	return &employeeRepository{db: db, timeouts: timeouts}
 }
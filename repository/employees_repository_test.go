@@ -4,6 +4,7 @@ import (
 	"booking-room-app/config"
 	"booking-room-app/entity"
 	"booking-room-app/shared/model"
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -40,25 +41,25 @@ func (suite *EmployeeRepositoryTestSuite) SetupTest() {
 	db, mock, _ := sqlmock.New()
 	suite.mockDb = db
 	suite.mockSql = mock
-	suite.repo = NewEmployeeRepository(suite.mockDb)
+	suite.repo = NewEmployeeRepository(suite.mockDb, DefaultTimeouts())
 }
 
 func (suite *EmployeeRepositoryTestSuite) TestGetEmployeeByID_success() {
 
-	rows := sqlmock.NewRows([]string{"id", "name", "username", "password", "role", "division", "position", "contact", "created_at", "updated_at"}).AddRow(expectEmployee.ID, expectEmployee.Name, expectEmployee.Username, expectEmployee.Password, expectEmployee.Role, expectEmployee.Division, expectEmployee.Position, expectEmployee.Contact, expectEmployee.CreatedAt, expectEmployee.UpdatedAt)
+	rows := sqlmock.NewRows([]string{"id", "name", "username", "password", "role", "division", "position", "contact", "created_at", "updated_at", "archived_at"}).AddRow(expectEmployee.ID, expectEmployee.Name, expectEmployee.Username, expectEmployee.Password, expectEmployee.Role, expectEmployee.Division, expectEmployee.Position, expectEmployee.Contact, expectEmployee.CreatedAt, expectEmployee.UpdatedAt, nil)
 
-	suite.mockSql.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, username, password, role, division, position, contact, created_at, updated_at FROM employees WHERE id = $1`)).WithArgs(expectEmployee.ID).WillReturnRows(rows)
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, username, password, role, division, position, contact, created_at, updated_at, archived_at FROM employees WHERE id = $1`)).WithArgs(expectEmployee.ID).WillReturnRows(rows)
 
-	_, actualError := suite.repo.GetEmployeesByID(expectEmployee.ID)
+	_, actualError := suite.repo.GetEmployeesByID(context.Background(), expectEmployee.ID)
 	assert.Nil(suite.T(), actualError)
 	assert.NoError(suite.T(), actualError)
 }
 func (suite *EmployeeRepositoryTestSuite) TestGetEmployeeByUsername_success() {
-	rows := sqlmock.NewRows([]string{"id", "name", "username", "password", "role", "division", "position", "contact", "created_at", "updated_at"}).AddRow(expectEmployee.ID, expectEmployee.Name, expectEmployee.Username, expectEmployee.Password, expectEmployee.Role, expectEmployee.Division, expectEmployee.Position, expectEmployee.Contact, expectEmployee.CreatedAt, expectEmployee.UpdatedAt)
+	rows := sqlmock.NewRows([]string{"id", "name", "username", "password", "role", "division", "position", "contact", "created_at", "updated_at", "archived_at"}).AddRow(expectEmployee.ID, expectEmployee.Name, expectEmployee.Username, expectEmployee.Password, expectEmployee.Role, expectEmployee.Division, expectEmployee.Position, expectEmployee.Contact, expectEmployee.CreatedAt, expectEmployee.UpdatedAt, nil)
 
-	suite.mockSql.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, username, password, role, division, position, contact, created_at, updated_at FROM employees WHERE username = $1`)).WithArgs(expectEmployee.Username).WillReturnRows(rows)
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(`SELECT id, name, username, password, role, division, position, contact, created_at, updated_at, archived_at FROM employees WHERE username = $1`)).WithArgs(expectEmployee.Username).WillReturnRows(rows)
 
-	_, actualError := suite.repo.GetEmployeesByUsername(expectEmployee.Username)
+	_, actualError := suite.repo.GetEmployeesByUsername(context.Background(), expectEmployee.Username)
 	assert.Nil(suite.T(), actualError)
 	assert.NoError(suite.T(), actualError)
 }
@@ -66,14 +67,14 @@ func (suite *EmployeeRepositoryTestSuite) TestGetEmployeeByUsername_success() {
 func (suite *EmployeeRepositoryTestSuite) TestGetEmployeeById_Fail() {
 	suite.mockSql.ExpectQuery(`SELECT`).WillReturnError(fmt.Errorf("error"))
 
-	_, err := suite.repo.GetEmployeesByID("12")
+	_, err := suite.repo.GetEmployeesByID(context.Background(), "12")
 	assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
 }
 func (suite *EmployeeRepositoryTestSuite) TestGetEmployeeByUsername_Fail() {
 	suite.mockSql.ExpectQuery(`SELECT`).WillReturnError(fmt.Errorf("error"))
 
-	_, err := suite.repo.GetEmployeesByUsername("12")
+	_, err := suite.repo.GetEmployeesByUsername(context.Background(), "12")
 	assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
 }
@@ -90,7 +91,7 @@ func (suite *EmployeeRepositoryTestSuite) TestCreateEmployee_success() {
 		expectEmployee.Position,
 		expectEmployee.Contact).WillReturnRows(rows)
 
-	_, err := suite.repo.CreateEmployee(expectEmployee)
+	_, err := suite.repo.CreateEmployee(context.Background(), expectEmployee)
 	suite.NoError(err)
 }
 
@@ -106,7 +107,7 @@ func (suite *EmployeeRepositoryTestSuite) TestCreateEmployee_Fail() {
 		expectEmployee.Position,
 		expectEmployee.Contact).WillReturnRows(rows)
 
-	_, err := suite.repo.CreateEmployee(expectEmployee)
+	_, err := suite.repo.CreateEmployee(context.Background(), expectEmployee)
 	suite.Error(err)
 }
 
@@ -120,7 +121,7 @@ func (suite *EmployeeRepositoryTestSuite) TestGetUsernameForLogin_Success() {
 
 	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectEmployeeForLogin)).WithArgs(expectEmployee.Username, expectEmployee.Password).WillReturnRows(rows)
 
-	_, actualError := suite.repo.GetEmployeesByUsernameForLogin(expectEmployee.Username, expectEmployee.Password)
+	_, actualError := suite.repo.GetEmployeesByUsernameForLogin(context.Background(), expectEmployee.Username, expectEmployee.Password)
 	assert.Nil(suite.T(), actualError)
 	assert.NoError(suite.T(), actualError)
 }
@@ -134,7 +135,7 @@ func (suite *EmployeeRepositoryTestSuite) TestGetUsernameForLogin_Fail() {
 
 	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectEmployeeForLogin)).WithArgs(expectEmployee.Username, expectEmployee.Password).WillReturnRows(rows)
 
-	_, actualError := suite.repo.GetEmployeesByUsernameForLogin(expectEmployee.Username, expectEmployee.Password)
+	_, actualError := suite.repo.GetEmployeesByUsernameForLogin(context.Background(), expectEmployee.Username, expectEmployee.Password)
 	assert.NotNil(suite.T(), actualError)
 	assert.Error(suite.T(), actualError)
 }
@@ -152,7 +153,7 @@ func (suite *EmployeeRepositoryTestSuite) TestUpdateEmployee_success() {
 		expectEmployee.Contact,
 		expectEmployee.ID).WillReturnRows(rows)
 
-	_, err := suite.repo.UpdateEmployee(expectEmployee)
+	_, err := suite.repo.UpdateEmployee(context.Background(), expectEmployee)
 	suite.NoError(err)
 }
 
@@ -169,7 +170,7 @@ func (suite *EmployeeRepositoryTestSuite) TestUpdateEmployee_Fail() {
 		expectEmployee.Contact,
 		expectEmployee.ID).WillReturnRows(rows)
 
-	_, err := suite.repo.UpdateEmployee(expectEmployee)
+	_, err := suite.repo.UpdateEmployee(context.Background(), expectEmployee)
 	suite.Error(err)
 }
 
@@ -198,7 +199,7 @@ func (suite *EmployeeRepositoryTestSuite) TestList_Success() {
 	suite.mockSql.ExpectQuery(`SELECT`).
 		WillReturnRows(sqlmock.NewRows([]string{"total_rows"}).AddRow(2))
 
-	employees, paging, err := suite.repo.List(page, size)
+	employees, paging, err := suite.repo.List(context.Background(), page, size)
 	assert.Nil(suite.T(), err)
 	assert.Equal(suite.T(), expectEmployee, employees)
 	assert.Equal(suite.T(), expectedPaging, paging)
@@ -229,7 +230,7 @@ func (suite *EmployeeRepositoryTestSuite) TestScanEmployee_Fail() {
 		WithArgs(size, (page-1)*size).
 		WillReturnRows(rows)
 
-	_, _, err := suite.repo.List(page, size)
+	_, _, err := suite.repo.List(context.Background(), page, size)
 	assert.Error(suite.T(), err)
 }
 
@@ -241,7 +242,7 @@ func (suite *EmployeeRepositoryTestSuite) TestList_Fail() {
 		WithArgs(size, (page-1)*size).
 		WillReturnError(errors.New("some SQL error"))
 
-	_, _, err := suite.repo.List(page, size)
+	_, _, err := suite.repo.List(context.Background(), page, size)
 	assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
 }
@@ -259,7 +260,7 @@ func (suite *EmployeeRepositoryTestSuite) TestList_ScanTotalRows() {
 	suite.mockSql.ExpectQuery(`SELECT`).
 		WillReturnError(errors.New("error"))
 
-	_, _, err := suite.repo.List(page, size)
+	_, _, err := suite.repo.List(context.Background(), page, size)
 	assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
 }
@@ -4,56 +4,95 @@ import (
 	"booking-room-app/config"
 	"booking-room-app/entity"
 	"booking-room-app/shared/model"
+	"context"
 	"database/sql"
-	"log"
+	"log/slog"
 	"math"
 )
 
 type FasilitiesRepository interface {
-	List(page, size int) ([]entity.Facilities, model.Paging, error)
-	Create(payload entity.Facilities) (entity.Facilities, error)
-	GetById(id string) (entity.Facilities, error)
-	UpdateById(payload entity.Facilities) (entity.Facilities, error)
+	List(ctx context.Context, page, size int) ([]entity.Facilities, model.Paging, error)
+	Create(ctx context.Context, payload entity.Facilities) (entity.Facilities, error)
+	GetById(ctx context.Context, id string) (entity.Facilities, error)
+	UpdateById(ctx context.Context, payload entity.Facilities) (entity.Facilities, error)
+	ListArchived(ctx context.Context, page, size int) ([]entity.Facilities, model.Paging, error)
+	Archive(ctx context.Context, id string, cascade bool) (entity.Archival, error)
+	Restore(ctx context.Context, id string) (entity.Facilities, error)
+	RecordMovement(ctx context.Context, movement entity.FacilityMovement) (entity.FacilityMovement, error)
+	ListMovements(ctx context.Context, facilityId string, page, size int) ([]entity.FacilityMovement, model.Paging, error)
 }
 
 type fasilitiesRepository struct {
-	db *sql.DB
+	db       *sql.DB
+	timeouts Timeouts
 }
 
 // create facilities (ADMIN) -POST
 // Create implements FasilitiesRepository.
-func (f *fasilitiesRepository) Create(payload entity.Facilities) (entity.Facilities, error) {
+func (f *fasilitiesRepository) Create(ctx context.Context, payload entity.Facilities) (entity.Facilities, error) {
+	ctx, cancel := context.WithTimeout(ctx, f.timeouts.Query)
+	defer cancel()
+
 	var fasilities entity.Facilities
 
-	err := f.db.QueryRow(config.InsertFasilities,
-		payload.Name,
-		payload.Quantity).Scan(
+	tx, err := f.db.BeginTx(ctx, nil)
+	if err != nil {
+		slog.ErrorContext(ctx, "fasilitiesRepository.Create.BeginTx", "err", err)
+		return entity.Facilities{}, err
+	}
+
+	err = tx.QueryRowContext(ctx, config.InsertFasilities,
+		payload.Name).Scan(
 		&fasilities.ID,
 		&fasilities.CreatedAt,
 		&fasilities.UpdatedAt)
 
 	if err != nil {
-		log.Println("fasilities repository.QueryRow:", err.Error())
+		slog.ErrorContext(ctx, "fasilities repository.QueryRow", "err", err)
+		tx.Rollback()
+		return entity.Facilities{}, err
+	}
+
+	// the initial stock enters through the ledger like any other purchase
+	if payload.Quantity != 0 {
+		movement, err := recordMovement(ctx, tx, entity.FacilityMovement{
+			FacilityId: fasilities.ID,
+			Kind:       entity.MovementPurchase,
+			Quantity:   payload.Quantity,
+			Reason:     "initial stock",
+		})
+		if err != nil {
+			tx.Rollback()
+			return entity.Facilities{}, err
+		}
+		fasilities.Quantity = movement.Balance
+	}
+
+	if err := tx.Commit(); err != nil {
+		slog.ErrorContext(ctx, "fasilitiesRepository.Create.Commit", "err", err)
 		return entity.Facilities{}, err
 	}
 	fasilities.Name = payload.Name
-	fasilities.Quantity = payload.Quantity
 	fasilities.UpdatedAt = fasilities.CreatedAt
 	return fasilities, nil
 }
 
 // List facilities (ADMIN) -POST
 // GetAll implements FasilitiesRepository.
-func (f *fasilitiesRepository) List(page, size int) ([]entity.Facilities, model.Paging, error) {
+func (f *fasilitiesRepository) List(ctx context.Context, page, size int) ([]entity.Facilities, model.Paging, error) {
+	ctx, cancel := context.WithTimeout(ctx, f.timeouts.Query)
+	defer cancel()
+
 	var facilities []entity.Facilities
 	offset := (page - 1) * size
 
-	rows, err := f.db.Query(config.SelectFasilitiesList, size, offset)
+	rows, err := f.db.QueryContext(ctx, config.SelectFasilitiesList, size, offset)
 
 	if err != nil {
-		log.Println("fasilities repository.Query: ", err.Error())
+		slog.ErrorContext(ctx, "fasilities repository.Query", "err", err)
 		return nil, model.Paging{}, err
 	}
+	defer rows.Close()
 
 	for rows.Next() {
 		var facility entity.Facilities
@@ -66,7 +105,7 @@ func (f *fasilitiesRepository) List(page, size int) ([]entity.Facilities, model.
 		)
 
 		if err != nil {
-			log.Println("scan facility : ", err.Error())
+			slog.ErrorContext(ctx, "scan facility", "err", err)
 			return nil, model.Paging{}, err
 		}
 
@@ -74,7 +113,7 @@ func (f *fasilitiesRepository) List(page, size int) ([]entity.Facilities, model.
 	}
 
 	totalRows := 0
-	if err := f.db.QueryRow(config.TotalRowsFasilities).Scan(&totalRows); err != nil {
+	if err := f.db.QueryRowContext(ctx, config.TotalRowsFasilities).Scan(&totalRows); err != nil {
 		return nil, model.Paging{}, err
 	}
 
@@ -90,19 +129,25 @@ func (f *fasilitiesRepository) List(page, size int) ([]entity.Facilities, model.
 
 // get facility details by ID (ADMIN) -GET
 // GetById implements FasilitiesRepository.
-func (f *fasilitiesRepository) GetById(id string) (entity.Facilities, error) {
+func (f *fasilitiesRepository) GetById(ctx context.Context, id string) (entity.Facilities, error) {
+	ctx, cancel := context.WithTimeout(ctx, f.timeouts.Query)
+	defer cancel()
+
 	var fasilities entity.Facilities
-	err := f.db.QueryRow(config.SelectFasilitiesById, id).Scan(
+	var archived sql.NullTime
+	err := f.db.QueryRowContext(ctx, config.SelectFasilitiesById, id).Scan(
 		&fasilities.ID,
 		&fasilities.Name,
 		&fasilities.Quantity,
 		&fasilities.CreatedAt,
-		&fasilities.UpdatedAt)
+		&fasilities.UpdatedAt,
+		&archived)
 
 	if err != nil {
-		log.Println("fasilitiesRepository.Get.QueryRow:", err.Error())
+		slog.ErrorContext(ctx, "fasilitiesRepository.Get.QueryRow", "err", err)
 		return entity.Facilities{}, err
 	}
+	fasilities.ArchivedAt = archivedAt(archived)
 
 	return fasilities, nil
 
@@ -110,27 +155,104 @@ func (f *fasilitiesRepository) GetById(id string) (entity.Facilities, error) {
 
 // Update facility details by ID (ADMIN) -PUT
 // Update implements FasilitiesRepository.
-func (f *fasilitiesRepository) UpdateById(payload entity.Facilities) (entity.Facilities, error) {
+func (f *fasilitiesRepository) UpdateById(ctx context.Context, payload entity.Facilities) (entity.Facilities, error) {
+	ctx, cancel := context.WithTimeout(ctx, f.timeouts.Query)
+	defer cancel()
+
 	var fasilities entity.Facilities
 
-	err := f.db.QueryRow(config.UpdateFasilities,
+	// the quantity is only changed by the movements of the stock ledger
+	err := f.db.QueryRowContext(ctx, config.UpdateFasilities,
 		payload.Name,
-		payload.Quantity,
 		payload.ID).Scan(
-		&fasilities.CreatedAt, &fasilities.UpdatedAt)
+		&fasilities.Quantity, &fasilities.CreatedAt, &fasilities.UpdatedAt)
 
 	if err != nil {
-		log.Println("fasilitiesRepository.query:", err.Error())
+		slog.ErrorContext(ctx, "fasilitiesRepository.query", "err", err)
 		return entity.Facilities{}, err
 	}
 
 	fasilities.ID = payload.ID
 	fasilities.Name = payload.Name
-	fasilities.Quantity = payload.Quantity
 
 	return fasilities, nil
 }
 
-func NewFasilitesRepository(db *sql.DB) FasilitiesRepository {
-	return &fasilitiesRepository{db: db}
+// ListArchived implements FasilitiesRepository.
+func (f *fasilitiesRepository) ListArchived(ctx context.Context, page, size int) ([]entity.Facilities, model.Paging, error) {
+	ctx, cancel := context.WithTimeout(ctx, f.timeouts.Query)
+	defer cancel()
+
+	var facilities []entity.Facilities
+	offset := (page - 1) * size
+
+	rows, err := f.db.QueryContext(ctx, config.SelectArchivedFacilityList, size, offset)
+	if err != nil {
+		slog.ErrorContext(ctx, "fasilities repository.ListArchived", "err", err)
+		return nil, model.Paging{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var facility entity.Facilities
+		var archived sql.NullTime
+		err := rows.Scan(
+			&facility.ID,
+			&facility.Name,
+			&facility.Quantity,
+			&facility.CreatedAt,
+			&facility.UpdatedAt,
+			&archived,
+		)
+		if err != nil {
+			slog.ErrorContext(ctx, "scan archived facility", "err", err)
+			return nil, model.Paging{}, err
+		}
+		facility.ArchivedAt = archivedAt(archived)
+
+		facilities = append(facilities, facility)
+	}
+
+	totalRows := 0
+	if err := f.db.QueryRowContext(ctx, config.SelectCountArchivedFacility).Scan(&totalRows); err != nil {
+		return nil, model.Paging{}, err
+	}
+
+	paging := model.Paging{
+		Page:        page,
+		RowsPerPage: size,
+		TotalRows:   totalRows,
+		TotalPages:  int(math.Ceil(float64(totalRows) / float64(size))),
+	}
+
+	return facilities, paging, nil
+}
+
+// Archive implements FasilitiesRepository.
+func (f *fasilitiesRepository) Archive(ctx context.Context, id string, cascade bool) (entity.Archival, error) {
+	return archive(ctx, f.db, f.timeouts.Query, facilityArchive, id, cascade)
+}
+
+// Restore implements FasilitiesRepository.
+func (f *fasilitiesRepository) Restore(ctx context.Context, id string) (entity.Facilities, error) {
+	ctx, cancel := context.WithTimeout(ctx, f.timeouts.Query)
+	defer cancel()
+
+	var facility entity.Facilities
+	err := f.db.QueryRowContext(ctx, config.RestoreFacility, id).Scan(
+		&facility.ID,
+		&facility.Name,
+		&facility.Quantity,
+		&facility.CreatedAt,
+		&facility.UpdatedAt)
+	if err != nil {
+		slog.ErrorContext(ctx, "fasilitiesRepository.Restore.QueryRow", "err", err)
+		return entity.Facilities{}, err
+	}
+
+	return facility, nil
+}
+
+func NewFasilitesRepository(db *sql.DB, timeouts Timeouts) FasilitiesRepository {
+	return &fasilitiesRepository{db: db, timeouts: timeouts}
 }
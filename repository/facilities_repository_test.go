@@ -3,6 +3,7 @@ package repository
 import (
 	"booking-room-app/entity"
 	"booking-room-app/shared/model"
+	"context"
 	"database/sql"
 	"errors"
 	"fmt"
@@ -33,7 +34,7 @@ func (suite *FasilitiesRepositoryTestSuite) SetupTest() {
 	db, mock, _ := sqlmock.New()
 	suite.mockDb = db
 	suite.mockSql = mock
-	suite.repo = NewFasilitesRepository(suite.mockDb)
+	suite.repo = NewFasilitesRepository(suite.mockDb, DefaultTimeouts())
 }
 
 // test create
@@ -44,21 +45,31 @@ func (suite *FasilitiesRepositoryTestSuite) TestCreate_Success() {
 		expectedFasilities.CreatedAt,
 		expectedFasilities.UpdatedAt)
 
-	suite.mockSql.ExpectQuery(`INSERT`).WithArgs(
-		expectedFasilities.Name,
-		expectedFasilities.Quantity).WillReturnRows(rows)
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectQuery(`INSERT INTO facilities`).WithArgs(
+		expectedFasilities.Name).WillReturnRows(rows)
+	suite.mockSql.ExpectQuery(`INSERT INTO facility_movements`).WithArgs(
+		expectedFasilities.ID,
+		entity.MovementPurchase,
+		expectedFasilities.Quantity,
+		"actor id",
+		"initial stock",
+		"").WillReturnRows(sqlmock.NewRows([]string{"id", "balance", "created_at"}).AddRow("m1", expectedFasilities.Quantity, time.Now()))
+	suite.mockSql.ExpectCommit()
 
-	actual, err := suite.repo.Create(expectedFasilities)
+	actual, err := suite.repo.Create(actorCtx, expectedFasilities)
 	assert.Nil(suite.T(), err)
 	assert.Equal(suite.T(), expectedFasilities.Name, actual.Name)
+	assert.Equal(suite.T(), expectedFasilities.Quantity, actual.Quantity)
 }
 
 func (suite *FasilitiesRepositoryTestSuite) TestCreate_Fail() {
+	suite.mockSql.ExpectBegin()
 	suite.mockSql.ExpectQuery(`INSERT`).WithArgs(
-		expectedFasilities.Name,
-		expectedFasilities.Quantity).WillReturnError(errors.New("error"))
+		expectedFasilities.Name).WillReturnError(errors.New("error"))
+	suite.mockSql.ExpectRollback()
 
-	_, err := suite.repo.Create(expectedFasilities)
+	_, err := suite.repo.Create(context.Background(), expectedFasilities)
 	assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
 }
@@ -66,12 +77,12 @@ func (suite *FasilitiesRepositoryTestSuite) TestCreate_Fail() {
 // test get by id
 func (suite *FasilitiesRepositoryTestSuite) TestGetById_Success() {
 
-	rows := sqlmock.NewRows([]string{"id", "name", "quantity", "created_at", "updated_at"}).AddRow(
-		expectedFasilities.ID, expectedFasilities.Name, expectedFasilities.Quantity, expectedFasilities.CreatedAt, expectedFasilities.UpdatedAt)
+	rows := sqlmock.NewRows([]string{"id", "name", "quantity", "created_at", "updated_at", "archived_at"}).AddRow(
+		expectedFasilities.ID, expectedFasilities.Name, expectedFasilities.Quantity, expectedFasilities.CreatedAt, expectedFasilities.UpdatedAt, nil)
 
 	suite.mockSql.ExpectQuery(`SELECT`).WithArgs(expectedFasilities.ID).WillReturnRows(rows)
 
-	actual, err := suite.repo.GetById(expectedFasilities.ID)
+	actual, err := suite.repo.GetById(context.Background(), expectedFasilities.ID)
 	assert.Nil(suite.T(), err)
 	assert.Equal(suite.T(), expectedFasilities.Name, actual.Name)
 }
@@ -79,7 +90,7 @@ func (suite *FasilitiesRepositoryTestSuite) TestGetById_Success() {
 func (suite *FasilitiesRepositoryTestSuite) TestGetById_Fail() {
 	suite.mockSql.ExpectQuery(`SELECT`).WillReturnError(fmt.Errorf("error"))
 
-	_, err := suite.repo.GetById("12")
+	_, err := suite.repo.GetById(context.Background(), "12")
 	assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
 }
@@ -87,26 +98,26 @@ func (suite *FasilitiesRepositoryTestSuite) TestGetById_Fail() {
 // test update
 func (suite *FasilitiesRepositoryTestSuite) TestUpdate_Success() {
 
-	rows := sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(
-		expectedFasilities.CreatedAt, expectedFasilities.UpdatedAt)
+	rows := sqlmock.NewRows([]string{"quantity", "created_at", "updated_at"}).AddRow(
+		expectedFasilities.Quantity, expectedFasilities.CreatedAt, expectedFasilities.UpdatedAt)
 
 	suite.mockSql.ExpectQuery(`UPDATE`).WithArgs(
 		expectedFasilities.Name,
-		expectedFasilities.Quantity,
 		expectedFasilities.ID).WillReturnRows(rows)
 
-	actual, err := suite.repo.UpdateById(expectedFasilities)
+	actual, err := suite.repo.UpdateById(context.Background(), expectedFasilities)
 	assert.Nil(suite.T(), err)
 	assert.Equal(suite.T(), expectedFasilities.Name, actual.Name)
+	assert.Equal(suite.T(), expectedFasilities.Quantity, actual.Quantity)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
 }
 
 func (suite *FasilitiesRepositoryTestSuite) TestUpdate_Fail() {
 	suite.mockSql.ExpectQuery(`UPDATE`).WithArgs(
 		expectedFasilities.Name,
-		expectedFasilities.Quantity,
 		expectedFasilities.ID).WillReturnError(errors.New("error"))
 
-	_, err := suite.repo.UpdateById(expectedFasilities)
+	_, err := suite.repo.UpdateById(context.Background(), expectedFasilities)
 	assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
 }
@@ -137,7 +148,7 @@ func (suite *FasilitiesRepositoryTestSuite) TestList_Success() {
 	suite.mockSql.ExpectQuery(`SELECT`).
 		WillReturnRows(sqlmock.NewRows([]string{"total_rows"}).AddRow(2))
 
-	facilities, paging, err := suite.repo.List(page, size)
+	facilities, paging, err := suite.repo.List(context.Background(), page, size)
 	assert.Nil(suite.T(), err)
 	assert.Equal(suite.T(), expectedFacility, facilities)
 	assert.Equal(suite.T(), expectedPaging, paging)
@@ -151,7 +162,7 @@ func (suite *FasilitiesRepositoryTestSuite) TestList_Fail() {
 		WithArgs(size, (page-1)*size).
 		WillReturnError(errors.New("some SQL error"))
 
-	_, _, err := suite.repo.List(page, size)
+	_, _, err := suite.repo.List(context.Background(), page, size)
 	assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
 }
@@ -167,7 +178,7 @@ func (suite *FasilitiesRepositoryTestSuite) TestList_ScanFail() {
 	suite.mockSql.ExpectQuery(`SELECT`).
 		WithArgs(size, (page-1)*size).WillReturnRows(rows)
 
-	_, _, err := suite.repo.List(page, size)
+	_, _, err := suite.repo.List(context.Background(), page, size)
 	assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
 }
@@ -186,7 +197,7 @@ func (suite *FasilitiesRepositoryTestSuite) TestList_ScanTotalRows() {
 	suite.mockSql.ExpectQuery(`SELECT`).
 		WillReturnError(errors.New("error"))
 
-	_, _, err := suite.repo.List(page, size)
+	_, _, err := suite.repo.List(context.Background(), page, size)
 	assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
 }
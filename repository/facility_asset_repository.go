@@ -0,0 +1,474 @@
+package repository
+
+import (
+	"booking-room-app/config"
+	"booking-room-app/entity"
+	"booking-room-app/shared/logger"
+	"booking-room-app/shared/model"
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"time"
+)
+
+var (
+	// ErrUntrackedStock is returned when the first asset of a facility is
+	// registered while the facility still has stock or room allocations that
+	// are not tracked by asset.
+	ErrUntrackedStock = errors.New("the facility has untracked stock")
+	// ErrFacilityTracked is returned when the stock of a tracked facility is
+	// changed other than through one of its assets.
+	ErrFacilityTracked = errors.New("the facility is tracked by asset")
+	// ErrAssetRetired is returned when a retired asset is changed.
+	ErrAssetRetired = errors.New("the asset is retired")
+	// ErrAssetUnavailable is returned when a damaged or retired asset is
+	// checked out.
+	ErrAssetUnavailable = errors.New("the asset is not serviceable")
+	// ErrAssetCheckedOut is returned when an asset that is out is checked out
+	// again or has its condition changed before it is checked in.
+	ErrAssetCheckedOut = errors.New("the asset is checked out")
+	// ErrAssetNotCheckedOut is returned when an asset that is in is checked
+	// in.
+	ErrAssetNotCheckedOut = errors.New("the asset is not checked out")
+	// ErrBookingNotFound is returned when an asset is checked out to a
+	// booking that does not exist.
+	ErrBookingNotFound = errors.New("the booking does not exist")
+	// ErrBookingClosed is returned when an asset is checked out to a booking
+	// that is not accepted or is over.
+	ErrBookingClosed = errors.New("the booking is not accepted or has ended")
+	// ErrFacilityNotBooked is returned when a booking already has as many
+	// assets of the facility out as it booked.
+	ErrFacilityNotBooked = errors.New("the booking has no unit of the facility left to check out")
+)
+
+type FacilityAssetRepository interface {
+	Create(ctx context.Context, payload entity.FacilityAsset) (entity.FacilityAsset, error)
+	List(ctx context.Context, facilityId string, page, size int) ([]entity.FacilityAsset, model.Paging, error)
+	GetById(ctx context.Context, id string) (entity.FacilityAsset, error)
+	Update(ctx context.Context, payload entity.FacilityAsset) (entity.FacilityAsset, error)
+	CheckOut(ctx context.Context, id, transactionId string) (entity.FacilityAsset, error)
+	CheckIn(ctx context.Context, payload entity.AssetCheckIn) (entity.FacilityAsset, error)
+	ListCheckouts(ctx context.Context, id string, page, size int) ([]entity.AssetCheckout, model.Paging, error)
+}
+
+type facilityAssetRepository struct {
+	db       *sql.DB
+	timeouts Timeouts
+}
+
+// Create implements FacilityAssetRepository. The first asset of a facility
+// makes it tracked, which needs the facility to have no stock or room
+// allocations of its own. A serviceable asset enters the stock as a purchase.
+func (f *facilityAssetRepository) Create(ctx context.Context, payload entity.FacilityAsset) (entity.FacilityAsset, error) {
+	ctx, cancel := context.WithTimeout(ctx, f.timeouts.Query)
+	defer cancel()
+
+	tx, err := f.db.BeginTx(ctx, nil)
+	if err != nil {
+		slog.ErrorContext(ctx, "facilityAssetRepository.Create.BeginTx", "err", err)
+		return entity.FacilityAsset{}, err
+	}
+
+	var quantity int
+	var tracked, archived, allocated bool
+	err = tx.QueryRowContext(ctx, config.LockFacilityForAsset, payload.FacilityId).Scan(&quantity, &tracked, &archived, &allocated)
+	if err != nil {
+		slog.ErrorContext(ctx, "facilityAssetRepository.Create.LockFacility", "err", err)
+		tx.Rollback()
+		return entity.FacilityAsset{}, err
+	}
+	switch {
+	case archived:
+		tx.Rollback()
+		return entity.FacilityAsset{}, ErrFacilityArchived
+	case !tracked && (quantity != 0 || allocated):
+		tx.Rollback()
+		return entity.FacilityAsset{}, ErrUntrackedStock
+	case !tracked:
+		if _, err := tx.ExecContext(ctx, config.TrackFacility, payload.FacilityId); err != nil {
+			slog.ErrorContext(ctx, "facilityAssetRepository.Create.TrackFacility", "err", err)
+			tx.Rollback()
+			return entity.FacilityAsset{}, err
+		}
+	}
+
+	err = tx.QueryRowContext(ctx, config.InsertFacilityAsset,
+		payload.FacilityId,
+		payload.AssetTag,
+		payload.SerialNumber,
+		payload.Condition,
+		payload.PurchaseDate,
+		payload.RoomId,
+		payload.Location).Scan(&payload.ID, &payload.CreatedAt, &payload.UpdatedAt)
+	if err != nil {
+		slog.ErrorContext(ctx, "facilityAssetRepository.Create.Insert", "err", err)
+		tx.Rollback()
+		return entity.FacilityAsset{}, err
+	}
+
+	if entity.Serviceable(payload.Condition) {
+		_, err := recordMovement(ctx, tx, entity.FacilityMovement{
+			FacilityId:  payload.FacilityId,
+			Kind:        entity.MovementPurchase,
+			Quantity:    1,
+			Reason:      "asset " + payload.AssetTag + " registered",
+			ReferenceId: payload.ID,
+		})
+		if err != nil {
+			tx.Rollback()
+			return entity.FacilityAsset{}, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		slog.ErrorContext(ctx, "facilityAssetRepository.Create.Commit", "err", err)
+		return entity.FacilityAsset{}, err
+	}
+	return payload, nil
+}
+
+// List implements FacilityAssetRepository, by asset tag.
+func (f *facilityAssetRepository) List(ctx context.Context, facilityId string, page, size int) ([]entity.FacilityAsset, model.Paging, error) {
+	ctx, cancel := context.WithTimeout(ctx, f.timeouts.Query)
+	defer cancel()
+
+	rows, err := f.db.QueryContext(ctx, config.SelectFacilityAssetList, facilityId, size, (page-1)*size)
+	if err != nil {
+		slog.ErrorContext(ctx, "facilityAssetRepository.List.Query", "err", err)
+		return nil, model.Paging{}, err
+	}
+	defer rows.Close()
+
+	var assets []entity.FacilityAsset
+	for rows.Next() {
+		asset, err := scanFacilityAsset(rows)
+		if err != nil {
+			slog.ErrorContext(ctx, "facilityAssetRepository.List.Scan", "err", err)
+			return nil, model.Paging{}, err
+		}
+		assets = append(assets, asset)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, model.Paging{}, err
+	}
+
+	totalRows := 0
+	if err := f.db.QueryRowContext(ctx, config.CountFacilityAssets, facilityId).Scan(&totalRows); err != nil {
+		slog.ErrorContext(ctx, "facilityAssetRepository.List.Count", "err", err)
+		return nil, model.Paging{}, err
+	}
+
+	return assets, paging(page, size, totalRows), nil
+}
+
+// GetById implements FacilityAssetRepository.
+func (f *facilityAssetRepository) GetById(ctx context.Context, id string) (entity.FacilityAsset, error) {
+	ctx, cancel := context.WithTimeout(ctx, f.timeouts.Query)
+	defer cancel()
+
+	asset, err := scanFacilityAsset(f.db.QueryRowContext(ctx, config.SelectFacilityAssetById, id))
+	if err != nil {
+		slog.ErrorContext(ctx, "facilityAssetRepository.GetById.QueryRow", "err", err)
+		return entity.FacilityAsset{}, err
+	}
+	return asset, nil
+}
+
+// Update implements FacilityAssetRepository. A change of condition takes the
+// asset in or out of the stock of its facility; it is refused for a retired
+// asset and for one that is checked out, which changes condition when it is
+// checked in.
+func (f *facilityAssetRepository) Update(ctx context.Context, payload entity.FacilityAsset) (entity.FacilityAsset, error) {
+	ctx, cancel := context.WithTimeout(ctx, f.timeouts.Query)
+	defer cancel()
+
+	tx, err := f.db.BeginTx(ctx, nil)
+	if err != nil {
+		slog.ErrorContext(ctx, "facilityAssetRepository.Update.BeginTx", "err", err)
+		return entity.FacilityAsset{}, err
+	}
+
+	asset, err := scanFacilityAsset(tx.QueryRowContext(ctx, config.LockFacilityAsset, payload.ID))
+	if err != nil {
+		slog.ErrorContext(ctx, "facilityAssetRepository.Update.Lock", "err", err)
+		tx.Rollback()
+		return entity.FacilityAsset{}, err
+	}
+	switch {
+	case asset.Condition == entity.AssetConditionRetired:
+		tx.Rollback()
+		return entity.FacilityAsset{}, ErrAssetRetired
+	case asset.CheckedOutAt != nil && payload.Condition != asset.Condition:
+		tx.Rollback()
+		return entity.FacilityAsset{}, ErrAssetCheckedOut
+	}
+
+	from := asset.Condition
+	asset.AssetTag = payload.AssetTag
+	asset.SerialNumber = payload.SerialNumber
+	asset.Condition = payload.Condition
+	asset.PurchaseDate = payload.PurchaseDate
+	asset.RoomId = payload.RoomId
+	asset.Location = payload.Location
+	if err := f.save(ctx, tx, &asset, from); err != nil {
+		tx.Rollback()
+		return entity.FacilityAsset{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		slog.ErrorContext(ctx, "facilityAssetRepository.Update.Commit", "err", err)
+		return entity.FacilityAsset{}, err
+	}
+	return asset, nil
+}
+
+// CheckOut implements FacilityAssetRepository. The asset must be serviceable
+// and in, and the booking accepted, not over and with fewer assets of the
+// facility out than it booked. The stock is not moved, the booking already
+// took it.
+func (f *facilityAssetRepository) CheckOut(ctx context.Context, id, transactionId string) (entity.FacilityAsset, error) {
+	ctx, cancel := context.WithTimeout(ctx, f.timeouts.Query)
+	defer cancel()
+
+	tx, err := f.db.BeginTx(ctx, nil)
+	if err != nil {
+		slog.ErrorContext(ctx, "facilityAssetRepository.CheckOut.BeginTx", "err", err)
+		return entity.FacilityAsset{}, err
+	}
+
+	asset, err := scanFacilityAsset(tx.QueryRowContext(ctx, config.LockFacilityAsset, id))
+	if err != nil {
+		slog.ErrorContext(ctx, "facilityAssetRepository.CheckOut.Lock", "err", err)
+		tx.Rollback()
+		return entity.FacilityAsset{}, err
+	}
+	switch {
+	case !entity.Serviceable(asset.Condition):
+		tx.Rollback()
+		return entity.FacilityAsset{}, ErrAssetUnavailable
+	case asset.CheckedOutAt != nil:
+		tx.Rollback()
+		return entity.FacilityAsset{}, ErrAssetCheckedOut
+	}
+
+	var open bool
+	var booked, out int
+	err = tx.QueryRowContext(ctx, config.LockCheckoutBooking, transactionId, asset.FacilityId).Scan(&open, &booked, &out)
+	if errors.Is(err, sql.ErrNoRows) {
+		tx.Rollback()
+		return entity.FacilityAsset{}, ErrBookingNotFound
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "facilityAssetRepository.CheckOut.LockBooking", "err", err)
+		tx.Rollback()
+		return entity.FacilityAsset{}, err
+	}
+	switch {
+	case !open:
+		tx.Rollback()
+		return entity.FacilityAsset{}, ErrBookingClosed
+	case out >= booked:
+		tx.Rollback()
+		return entity.FacilityAsset{}, ErrFacilityNotBooked
+	}
+
+	var checkedOutAt time.Time
+	err = tx.QueryRowContext(ctx, config.InsertAssetCheckout, asset.ID, transactionId, logger.UserID(ctx)).Scan(&checkedOutAt)
+	if err != nil {
+		slog.ErrorContext(ctx, "facilityAssetRepository.CheckOut.Insert", "err", err)
+		tx.Rollback()
+		return entity.FacilityAsset{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		slog.ErrorContext(ctx, "facilityAssetRepository.CheckOut.Commit", "err", err)
+		return entity.FacilityAsset{}, err
+	}
+	asset.TransactionId = transactionId
+	asset.CheckedOutAt = &checkedOutAt
+	return asset, nil
+}
+
+// CheckIn implements FacilityAssetRepository. The asset comes back in the
+// given condition, which takes it out of the stock when it is no longer
+// serviceable. Its booking took its unit from the stock already, so a
+// damaged or retired return first releases that unit from the booking,
+// unless the booking was declined and released it itself.
+func (f *facilityAssetRepository) CheckIn(ctx context.Context, payload entity.AssetCheckIn) (entity.FacilityAsset, error) {
+	ctx, cancel := context.WithTimeout(ctx, f.timeouts.Query)
+	defer cancel()
+
+	tx, err := f.db.BeginTx(ctx, nil)
+	if err != nil {
+		slog.ErrorContext(ctx, "facilityAssetRepository.CheckIn.BeginTx", "err", err)
+		return entity.FacilityAsset{}, err
+	}
+
+	asset, err := scanFacilityAsset(tx.QueryRowContext(ctx, config.LockFacilityAsset, payload.AssetId))
+	if err != nil {
+		slog.ErrorContext(ctx, "facilityAssetRepository.CheckIn.Lock", "err", err)
+		tx.Rollback()
+		return entity.FacilityAsset{}, err
+	}
+	if asset.CheckedOutAt == nil {
+		tx.Rollback()
+		return entity.FacilityAsset{}, ErrAssetNotCheckedOut
+	}
+
+	if _, err := tx.ExecContext(ctx, config.CheckInAssetCheckout, asset.ID, logger.UserID(ctx), payload.Condition); err != nil {
+		slog.ErrorContext(ctx, "facilityAssetRepository.CheckIn.Exec", "err", err)
+		tx.Rollback()
+		return entity.FacilityAsset{}, err
+	}
+
+	if !entity.Serviceable(payload.Condition) && asset.TransactionId != "" {
+		var held bool
+		err := tx.QueryRowContext(ctx, config.LockCheckInBooking, asset.TransactionId).Scan(&held)
+		if err != nil {
+			slog.ErrorContext(ctx, "facilityAssetRepository.CheckIn.LockBooking", "err", err)
+			tx.Rollback()
+			return entity.FacilityAsset{}, err
+		}
+		if held {
+			_, err := recordMovement(ctx, tx, entity.FacilityMovement{
+				FacilityId:  asset.FacilityId,
+				Kind:        entity.MovementRelease,
+				Quantity:    1,
+				Reason:      "asset " + asset.AssetTag + " returned from booking",
+				ReferenceId: asset.TransactionId,
+			})
+			if err != nil {
+				tx.Rollback()
+				return entity.FacilityAsset{}, err
+			}
+		}
+	}
+
+	from := asset.Condition
+	asset.Condition = payload.Condition
+	if payload.RoomId != "" || payload.Location != "" {
+		asset.RoomId = payload.RoomId
+		asset.Location = payload.Location
+	}
+	if err := f.save(ctx, tx, &asset, from); err != nil {
+		tx.Rollback()
+		return entity.FacilityAsset{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		slog.ErrorContext(ctx, "facilityAssetRepository.CheckIn.Commit", "err", err)
+		return entity.FacilityAsset{}, err
+	}
+	asset.TransactionId = ""
+	asset.CheckedOutAt = nil
+	return asset, nil
+}
+
+// ListCheckouts implements FacilityAssetRepository, newest first.
+func (f *facilityAssetRepository) ListCheckouts(ctx context.Context, id string, page, size int) ([]entity.AssetCheckout, model.Paging, error) {
+	ctx, cancel := context.WithTimeout(ctx, f.timeouts.Query)
+	defer cancel()
+
+	rows, err := f.db.QueryContext(ctx, config.SelectAssetCheckouts, id, size, (page-1)*size)
+	if err != nil {
+		slog.ErrorContext(ctx, "facilityAssetRepository.ListCheckouts.Query", "err", err)
+		return nil, model.Paging{}, err
+	}
+	defer rows.Close()
+
+	var checkouts []entity.AssetCheckout
+	for rows.Next() {
+		var checkout entity.AssetCheckout
+		err := rows.Scan(
+			&checkout.ID,
+			&checkout.AssetId,
+			&checkout.TransactionId,
+			&checkout.CheckedOutBy,
+			&checkout.CheckedOutAt,
+			&checkout.CheckedInBy,
+			&checkout.CheckedInAt,
+			&checkout.Condition)
+		if err != nil {
+			slog.ErrorContext(ctx, "facilityAssetRepository.ListCheckouts.Scan", "err", err)
+			return nil, model.Paging{}, err
+		}
+		checkouts = append(checkouts, checkout)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, model.Paging{}, err
+	}
+
+	totalRows := 0
+	if err := f.db.QueryRowContext(ctx, config.CountAssetCheckouts, id).Scan(&totalRows); err != nil {
+		slog.ErrorContext(ctx, "facilityAssetRepository.ListCheckouts.Count", "err", err)
+		return nil, model.Paging{}, err
+	}
+
+	return checkouts, paging(page, size, totalRows), nil
+}
+
+// save writes a locked asset whose condition was from, recording the
+// movement the change of condition makes to the stock.
+func (f *facilityAssetRepository) save(ctx context.Context, tx *sql.Tx, asset *entity.FacilityAsset, from string) error {
+	err := tx.QueryRowContext(ctx, config.UpdateFacilityAsset,
+		asset.ID,
+		asset.AssetTag,
+		asset.SerialNumber,
+		asset.Condition,
+		asset.PurchaseDate,
+		asset.RoomId,
+		asset.Location).Scan(&asset.UpdatedAt)
+	if err != nil {
+		slog.ErrorContext(ctx, "facilityAssetRepository.save", "err", err)
+		return err
+	}
+
+	if movement, ok := conditionMovement(*asset, from); ok {
+		if _, err := recordMovement(ctx, tx, movement); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// conditionMovement is the movement that takes an asset out of the stock of
+// its facility when it is damaged or retired, or back in when it is
+// repaired. Changes between serviceable conditions do not move the stock.
+func conditionMovement(asset entity.FacilityAsset, from string) (entity.FacilityMovement, bool) {
+	movement := entity.FacilityMovement{FacilityId: asset.FacilityId, ReferenceId: asset.ID}
+	was, is := entity.Serviceable(from), entity.Serviceable(asset.Condition)
+	switch {
+	case was && asset.Condition == entity.AssetConditionRetired:
+		movement.Kind, movement.Quantity, movement.Reason = entity.MovementWriteOff, -1, "asset "+asset.AssetTag+" retired"
+	case was && !is:
+		movement.Kind, movement.Quantity, movement.Reason = entity.MovementDamage, -1, "asset "+asset.AssetTag+" damaged"
+	case !was && is:
+		movement.Kind, movement.Quantity, movement.Reason = entity.MovementAdjustment, 1, "asset "+asset.AssetTag+" repaired"
+	default:
+		return entity.FacilityMovement{}, false
+	}
+	return movement, true
+}
+
+func scanFacilityAsset(row rowScanner) (entity.FacilityAsset, error) {
+	var asset entity.FacilityAsset
+	err := row.Scan(
+		&asset.ID,
+		&asset.FacilityId,
+		&asset.AssetTag,
+		&asset.SerialNumber,
+		&asset.Condition,
+		&asset.PurchaseDate,
+		&asset.RoomId,
+		&asset.Location,
+		&asset.TransactionId,
+		&asset.CheckedOutAt,
+		&asset.CreatedAt,
+		&asset.UpdatedAt)
+	return asset, err
+}
+
+func NewFacilityAssetRepository(db *sql.DB, timeouts Timeouts) FacilityAssetRepository {
+	return &facilityAssetRepository{db: db, timeouts: timeouts}
+}
@@ -0,0 +1,489 @@
+package repository
+
+import (
+	"booking-room-app/config"
+	"booking-room-app/entity"
+	"booking-room-app/shared/logger"
+	"booking-room-app/shared/model"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+var expectedAsset = entity.FacilityAsset{
+	ID:           "asset id",
+	FacilityId:   "facility id",
+	AssetTag:     "LPT-001",
+	SerialNumber: "SN123",
+	Condition:    entity.AssetConditionGood,
+	PurchaseDate: "2024-01-15",
+	RoomId:       "room id",
+	CreatedAt:    time.Time{},
+	UpdatedAt:    time.Time{},
+}
+
+type FacilityAssetRepositoryTestSuite struct {
+	suite.Suite
+	mockDb  *sql.DB
+	mockSql sqlmock.Sqlmock
+	repo    FacilityAssetRepository
+}
+
+func (suite *FacilityAssetRepositoryTestSuite) SetupTest() {
+	db, mock, _ := sqlmock.New()
+	suite.mockDb = db
+	suite.mockSql = mock
+	suite.repo = NewFacilityAssetRepository(suite.mockDb, DefaultTimeouts())
+}
+
+func (suite *FacilityAssetRepositoryTestSuite) TestCreate_Success() {
+	suite.mockSql.ExpectBegin()
+	suite.expectFacilityLock(0, false, false, false)
+	suite.mockSql.ExpectExec(regexp.QuoteMeta(config.TrackFacility)).WithArgs(expectedAsset.FacilityId).WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.InsertFacilityAsset)).
+		WithArgs(expectedAsset.FacilityId, expectedAsset.AssetTag, expectedAsset.SerialNumber, expectedAsset.Condition, expectedAsset.PurchaseDate, expectedAsset.RoomId, expectedAsset.Location).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow(expectedAsset.ID, expectedAsset.CreatedAt, expectedAsset.UpdatedAt))
+	suite.expectMovement(entity.MovementPurchase, 1, "asset LPT-001 registered")
+	suite.mockSql.ExpectCommit()
+
+	payload := expectedAsset
+	payload.ID = ""
+	actual, err := suite.repo.Create(actorCtx, payload)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expectedAsset, actual)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *FacilityAssetRepositoryTestSuite) TestCreate_DamagedSuccess() {
+	damaged := expectedAsset
+	damaged.Condition = entity.AssetConditionDamaged
+	suite.mockSql.ExpectBegin()
+	suite.expectFacilityLock(3, true, false, true)
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.InsertFacilityAsset)).
+		WithArgs(damaged.FacilityId, damaged.AssetTag, damaged.SerialNumber, damaged.Condition, damaged.PurchaseDate, damaged.RoomId, damaged.Location).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow(damaged.ID, damaged.CreatedAt, damaged.UpdatedAt))
+	suite.mockSql.ExpectCommit()
+
+	_, err := suite.repo.Create(context.Background(), damaged)
+
+	assert.NoError(suite.T(), err)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *FacilityAssetRepositoryTestSuite) TestCreate_UntrackedStockFailure() {
+	suite.mockSql.ExpectBegin()
+	suite.expectFacilityLock(0, false, false, true)
+	suite.mockSql.ExpectRollback()
+
+	_, err := suite.repo.Create(context.Background(), expectedAsset)
+
+	assert.ErrorIs(suite.T(), err, ErrUntrackedStock)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *FacilityAssetRepositoryTestSuite) TestCreate_ArchivedFailure() {
+	suite.mockSql.ExpectBegin()
+	suite.expectFacilityLock(0, true, true, false)
+	suite.mockSql.ExpectRollback()
+
+	_, err := suite.repo.Create(context.Background(), expectedAsset)
+
+	assert.ErrorIs(suite.T(), err, ErrFacilityArchived)
+}
+
+func (suite *FacilityAssetRepositoryTestSuite) TestCreate_InsertFailure() {
+	suite.mockSql.ExpectBegin()
+	suite.expectFacilityLock(2, true, false, false)
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.InsertFacilityAsset)).
+		WithArgs(expectedAsset.FacilityId, expectedAsset.AssetTag, expectedAsset.SerialNumber, expectedAsset.Condition, expectedAsset.PurchaseDate, expectedAsset.RoomId, expectedAsset.Location).
+		WillReturnError(fmt.Errorf("error"))
+	suite.mockSql.ExpectRollback()
+
+	_, err := suite.repo.Create(context.Background(), expectedAsset)
+
+	assert.Error(suite.T(), err)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *FacilityAssetRepositoryTestSuite) TestList_Success() {
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectFacilityAssetList)).WithArgs(expectedAsset.FacilityId, 5, 0).WillReturnRows(assetRows(expectedAsset))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.CountFacilityAssets)).WithArgs(expectedAsset.FacilityId).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	actual, paging, err := suite.repo.List(context.Background(), expectedAsset.FacilityId, 1, 5)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []entity.FacilityAsset{expectedAsset}, actual)
+	assert.Equal(suite.T(), model.Paging{Page: 1, RowsPerPage: 5, TotalRows: 1, TotalPages: 1}, paging)
+}
+
+func (suite *FacilityAssetRepositoryTestSuite) TestList_Failure() {
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectFacilityAssetList)).WithArgs(expectedAsset.FacilityId, 5, 0).WillReturnError(fmt.Errorf("error"))
+
+	_, _, err := suite.repo.List(context.Background(), expectedAsset.FacilityId, 1, 5)
+
+	assert.Error(suite.T(), err)
+}
+
+func (suite *FacilityAssetRepositoryTestSuite) TestGetById_Success() {
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectFacilityAssetById)).WithArgs(expectedAsset.ID).WillReturnRows(assetRows(expectedAsset))
+
+	actual, err := suite.repo.GetById(context.Background(), expectedAsset.ID)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expectedAsset, actual)
+}
+
+func (suite *FacilityAssetRepositoryTestSuite) TestGetById_Failure() {
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectFacilityAssetById)).WithArgs(expectedAsset.ID).WillReturnError(sql.ErrNoRows)
+
+	_, err := suite.repo.GetById(context.Background(), expectedAsset.ID)
+
+	assert.ErrorIs(suite.T(), err, sql.ErrNoRows)
+}
+
+func (suite *FacilityAssetRepositoryTestSuite) TestUpdate_DamagedSuccess() {
+	payload := expectedAsset
+	payload.Condition = entity.AssetConditionDamaged
+	payload.RoomId = ""
+	payload.Location = "repair shop"
+	suite.mockSql.ExpectBegin()
+	suite.expectAssetLock(expectedAsset)
+	suite.expectSave(payload)
+	suite.expectMovement(entity.MovementDamage, -1, "asset LPT-001 damaged")
+	suite.mockSql.ExpectCommit()
+
+	actual, err := suite.repo.Update(actorCtx, payload)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), payload, actual)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *FacilityAssetRepositoryTestSuite) TestUpdate_RetiredFailure() {
+	retired := expectedAsset
+	retired.Condition = entity.AssetConditionRetired
+	suite.mockSql.ExpectBegin()
+	suite.expectAssetLock(retired)
+	suite.mockSql.ExpectRollback()
+
+	_, err := suite.repo.Update(context.Background(), expectedAsset)
+
+	assert.ErrorIs(suite.T(), err, ErrAssetRetired)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *FacilityAssetRepositoryTestSuite) TestUpdate_CheckedOutFailure() {
+	suite.mockSql.ExpectBegin()
+	suite.expectAssetLock(checkedOutAsset())
+	suite.mockSql.ExpectRollback()
+
+	payload := expectedAsset
+	payload.Condition = entity.AssetConditionDamaged
+	_, err := suite.repo.Update(context.Background(), payload)
+
+	assert.ErrorIs(suite.T(), err, ErrAssetCheckedOut)
+}
+
+func (suite *FacilityAssetRepositoryTestSuite) TestCheckOut_Success() {
+	ctx := logger.WithUserID(context.Background(), "actor id")
+	checkedOutAt := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	suite.mockSql.ExpectBegin()
+	suite.expectAssetLock(expectedAsset)
+	suite.expectBookingLock(true, 2, 1)
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.InsertAssetCheckout)).WithArgs(expectedAsset.ID, "transaction id", "actor id").WillReturnRows(sqlmock.NewRows([]string{"checked_out_at"}).AddRow(checkedOutAt))
+	suite.mockSql.ExpectCommit()
+
+	actual, err := suite.repo.CheckOut(ctx, expectedAsset.ID, "transaction id")
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "transaction id", actual.TransactionId)
+	assert.Equal(suite.T(), &checkedOutAt, actual.CheckedOutAt)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *FacilityAssetRepositoryTestSuite) TestCheckOut_UnavailableFailure() {
+	damaged := expectedAsset
+	damaged.Condition = entity.AssetConditionDamaged
+	suite.mockSql.ExpectBegin()
+	suite.expectAssetLock(damaged)
+	suite.mockSql.ExpectRollback()
+
+	_, err := suite.repo.CheckOut(context.Background(), expectedAsset.ID, "transaction id")
+
+	assert.ErrorIs(suite.T(), err, ErrAssetUnavailable)
+}
+
+func (suite *FacilityAssetRepositoryTestSuite) TestCheckOut_CheckedOutFailure() {
+	suite.mockSql.ExpectBegin()
+	suite.expectAssetLock(checkedOutAsset())
+	suite.mockSql.ExpectRollback()
+
+	_, err := suite.repo.CheckOut(context.Background(), expectedAsset.ID, "transaction id")
+
+	assert.ErrorIs(suite.T(), err, ErrAssetCheckedOut)
+}
+
+func (suite *FacilityAssetRepositoryTestSuite) TestCheckOut_BookingNotFoundFailure() {
+	suite.mockSql.ExpectBegin()
+	suite.expectAssetLock(expectedAsset)
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.LockCheckoutBooking)).WithArgs("transaction id", expectedAsset.FacilityId).WillReturnError(sql.ErrNoRows)
+	suite.mockSql.ExpectRollback()
+
+	_, err := suite.repo.CheckOut(context.Background(), expectedAsset.ID, "transaction id")
+
+	assert.ErrorIs(suite.T(), err, ErrBookingNotFound)
+}
+
+func (suite *FacilityAssetRepositoryTestSuite) TestCheckOut_BookingClosedFailure() {
+	suite.mockSql.ExpectBegin()
+	suite.expectAssetLock(expectedAsset)
+	suite.expectBookingLock(false, 2, 0)
+	suite.mockSql.ExpectRollback()
+
+	_, err := suite.repo.CheckOut(context.Background(), expectedAsset.ID, "transaction id")
+
+	assert.ErrorIs(suite.T(), err, ErrBookingClosed)
+}
+
+func (suite *FacilityAssetRepositoryTestSuite) TestCheckOut_NotBookedFailure() {
+	suite.mockSql.ExpectBegin()
+	suite.expectAssetLock(expectedAsset)
+	suite.expectBookingLock(true, 2, 2)
+	suite.mockSql.ExpectRollback()
+
+	_, err := suite.repo.CheckOut(context.Background(), expectedAsset.ID, "transaction id")
+
+	assert.ErrorIs(suite.T(), err, ErrFacilityNotBooked)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *FacilityAssetRepositoryTestSuite) TestCheckIn_Success() {
+	ctx := logger.WithUserID(context.Background(), "actor id")
+	expected := expectedAsset
+	expected.Condition = entity.AssetConditionDamaged
+	expected.RoomId = ""
+	expected.Location = "storage"
+	suite.mockSql.ExpectBegin()
+	suite.expectAssetLock(checkedOutAsset())
+	suite.mockSql.ExpectExec(regexp.QuoteMeta(config.CheckInAssetCheckout)).WithArgs(expectedAsset.ID, "actor id", entity.AssetConditionDamaged).WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.expectCheckInBookingLock(true)
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.InsertFacilityMovement)).WithArgs(expectedAsset.FacilityId, entity.MovementRelease, 1, "actor id", "asset LPT-001 returned from booking", "transaction id").WillReturnRows(sqlmock.NewRows([]string{"id", "balance", "created_at"}).AddRow("movement id", 4, time.Time{}))
+	suite.expectSave(expected)
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.InsertFacilityMovement)).WithArgs(expectedAsset.FacilityId, entity.MovementDamage, -1, "actor id", "asset LPT-001 damaged", expectedAsset.ID).WillReturnRows(sqlmock.NewRows([]string{"id", "balance", "created_at"}).AddRow("movement id", 3, time.Time{}))
+	suite.mockSql.ExpectCommit()
+
+	actual, err := suite.repo.CheckIn(ctx, entity.AssetCheckIn{AssetId: expectedAsset.ID, Condition: entity.AssetConditionDamaged, Location: "storage"})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expected, actual)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *FacilityAssetRepositoryTestSuite) TestCheckIn_DamagedFromDeclinedBookingSuccess() {
+	damaged := expectedAsset
+	damaged.Condition = entity.AssetConditionDamaged
+	suite.mockSql.ExpectBegin()
+	suite.expectAssetLock(checkedOutAsset())
+	suite.mockSql.ExpectExec(regexp.QuoteMeta(config.CheckInAssetCheckout)).WithArgs(expectedAsset.ID, "actor id", entity.AssetConditionDamaged).WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.expectCheckInBookingLock(false)
+	suite.expectSave(damaged)
+	suite.expectMovement(entity.MovementDamage, -1, "asset LPT-001 damaged")
+	suite.mockSql.ExpectCommit()
+
+	_, err := suite.repo.CheckIn(actorCtx, entity.AssetCheckIn{AssetId: expectedAsset.ID, Condition: entity.AssetConditionDamaged})
+
+	assert.NoError(suite.T(), err)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+/* Test the stock of a tracked facility follows its serviceable assets when a booked asset comes back damaged */
+func (suite *FacilityAssetRepositoryTestSuite) TestCheckIn_DamagedThenBookingReleasedSuccess() {
+	// three serviceable assets, one of them booked
+	serviceable := 3
+	stock := &ledger{quantity: 2}
+	damaged := expectedAsset
+	damaged.Condition = entity.AssetConditionDamaged
+	expectLedgerMovement := func(kind, reason, referenceId string) {
+		suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.InsertFacilityMovement)).WithArgs(expectedAsset.FacilityId, kind, stock, "actor id", reason, referenceId).
+			WillReturnRows(sqlmock.NewRows([]string{"id", "balance", "created_at"}).AddRow("movement id", 0, time.Time{}))
+	}
+
+	// checked out to the booking, the stock does not move
+	suite.mockSql.ExpectBegin()
+	suite.expectAssetLock(expectedAsset)
+	suite.expectBookingLock(true, 1, 0)
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.InsertAssetCheckout)).WithArgs(expectedAsset.ID, "transaction id", "actor id").WillReturnRows(sqlmock.NewRows([]string{"checked_out_at"}).AddRow(time.Now()))
+	suite.mockSql.ExpectCommit()
+	_, err := suite.repo.CheckOut(actorCtx, expectedAsset.ID, "transaction id")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), serviceable-1, stock.quantity)
+
+	// checked in damaged, the booking holds nothing any more
+	suite.mockSql.ExpectBegin()
+	suite.expectAssetLock(checkedOutAsset())
+	suite.mockSql.ExpectExec(regexp.QuoteMeta(config.CheckInAssetCheckout)).WithArgs(expectedAsset.ID, "actor id", entity.AssetConditionDamaged).WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.expectCheckInBookingLock(true)
+	expectLedgerMovement(entity.MovementRelease, "asset LPT-001 returned from booking", "transaction id")
+	suite.expectSave(damaged)
+	expectLedgerMovement(entity.MovementDamage, "asset LPT-001 damaged", expectedAsset.ID)
+	suite.mockSql.ExpectCommit()
+	_, err = suite.repo.CheckIn(actorCtx, entity.AssetCheckIn{AssetId: expectedAsset.ID, Condition: entity.AssetConditionDamaged})
+	serviceable--
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), serviceable, stock.quantity)
+
+	// the booking is released, the damaged unit is not counted back
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.LockTransactionStatus)).WithArgs("transaction id").WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("accepted"))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.UpdatePermission)).WithArgs("declined", "transaction id").WillReturnRows(
+		sqlmock.NewRows([]string{"employee_id", "room_id", "description", "start_time", "end_time", "created_at"}).AddRow("employee id", "room id", "", time.Time{}, time.Time{}, time.Time{}))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectBookedFacilities)).WithArgs(pq.Array([]string{"transaction id"})).WillReturnRows(
+		sqlmock.NewRows([]string{"transaction_id", "facility_id", "quantity"}))
+	suite.mockSql.ExpectCommit()
+	_, err = NewTransactionsRepository(suite.mockDb, DefaultTimeouts()).UpdatePemission(actorCtx, entity.Transaction{ID: "transaction id", Status: "declined"})
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), serviceable, stock.quantity)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *FacilityAssetRepositoryTestSuite) TestCheckIn_KeepsLocationSuccess() {
+	suite.mockSql.ExpectBegin()
+	suite.expectAssetLock(checkedOutAsset())
+	suite.mockSql.ExpectExec(regexp.QuoteMeta(config.CheckInAssetCheckout)).WithArgs(expectedAsset.ID, "", entity.AssetConditionGood).WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.expectSave(expectedAsset)
+	suite.mockSql.ExpectCommit()
+
+	actual, err := suite.repo.CheckIn(context.Background(), entity.AssetCheckIn{AssetId: expectedAsset.ID, Condition: entity.AssetConditionGood})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expectedAsset, actual)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *FacilityAssetRepositoryTestSuite) TestCheckIn_NotCheckedOutFailure() {
+	suite.mockSql.ExpectBegin()
+	suite.expectAssetLock(expectedAsset)
+	suite.mockSql.ExpectRollback()
+
+	_, err := suite.repo.CheckIn(context.Background(), entity.AssetCheckIn{AssetId: expectedAsset.ID, Condition: entity.AssetConditionGood})
+
+	assert.ErrorIs(suite.T(), err, ErrAssetNotCheckedOut)
+}
+
+func (suite *FacilityAssetRepositoryTestSuite) TestListCheckouts_Success() {
+	checkedInAt := time.Date(2024, 3, 1, 17, 0, 0, 0, time.UTC)
+	expected := entity.AssetCheckout{ID: "checkout id", AssetId: expectedAsset.ID, TransactionId: "transaction id", CheckedOutBy: "actor id", CheckedOutAt: time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC), CheckedInBy: "actor id", CheckedInAt: &checkedInAt, Condition: entity.AssetConditionGood}
+	rows := sqlmock.NewRows([]string{"id", "asset_id", "transaction_id", "checked_out_by", "checked_out_at", "checked_in_by", "checked_in_at", "condition"}).
+		AddRow(expected.ID, expected.AssetId, expected.TransactionId, expected.CheckedOutBy, expected.CheckedOutAt, expected.CheckedInBy, checkedInAt, expected.Condition)
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectAssetCheckouts)).WithArgs(expectedAsset.ID, 5, 0).WillReturnRows(rows)
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.CountAssetCheckouts)).WithArgs(expectedAsset.ID).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	actual, paging, err := suite.repo.ListCheckouts(context.Background(), expectedAsset.ID, 1, 5)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []entity.AssetCheckout{expected}, actual)
+	assert.Equal(suite.T(), 1, paging.TotalRows)
+}
+
+func (suite *FacilityAssetRepositoryTestSuite) TestListCheckouts_Failure() {
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectAssetCheckouts)).WithArgs(expectedAsset.ID, 5, 0).WillReturnError(fmt.Errorf("error"))
+
+	_, _, err := suite.repo.ListCheckouts(context.Background(), expectedAsset.ID, 1, 5)
+
+	assert.Error(suite.T(), err)
+}
+
+func (suite *FacilityAssetRepositoryTestSuite) TestConditionMovement() {
+	tests := []struct {
+		from, to string
+		kind     string
+		quantity int
+	}{
+		{entity.AssetConditionNew, entity.AssetConditionFair, "", 0},
+		{entity.AssetConditionGood, entity.AssetConditionDamaged, entity.MovementDamage, -1},
+		{entity.AssetConditionGood, entity.AssetConditionRetired, entity.MovementWriteOff, -1},
+		{entity.AssetConditionDamaged, entity.AssetConditionRetired, "", 0},
+		{entity.AssetConditionDamaged, entity.AssetConditionGood, entity.MovementAdjustment, 1},
+	}
+	for _, test := range tests {
+		asset := expectedAsset
+		asset.Condition = test.to
+		movement, ok := conditionMovement(asset, test.from)
+		assert.Equal(suite.T(), test.kind != "", ok, test.from+" to "+test.to)
+		assert.Equal(suite.T(), test.kind, movement.Kind, test.from+" to "+test.to)
+		assert.Equal(suite.T(), test.quantity, movement.Quantity, test.from+" to "+test.to)
+	}
+}
+
+func checkedOutAsset() entity.FacilityAsset {
+	checkedOutAt := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	asset := expectedAsset
+	asset.TransactionId = "transaction id"
+	asset.CheckedOutAt = &checkedOutAt
+	return asset
+}
+
+func assetRows(asset entity.FacilityAsset) *sqlmock.Rows {
+	var checkedOutAt any
+	if asset.CheckedOutAt != nil {
+		checkedOutAt = *asset.CheckedOutAt
+	}
+	return sqlmock.NewRows([]string{"id", "facility_id", "asset_tag", "serial_number", "condition", "purchase_date", "room_id", "location", "transaction_id", "checked_out_at", "created_at", "updated_at"}).
+		AddRow(asset.ID, asset.FacilityId, asset.AssetTag, asset.SerialNumber, asset.Condition, asset.PurchaseDate, asset.RoomId, asset.Location, asset.TransactionId, checkedOutAt, asset.CreatedAt, asset.UpdatedAt)
+}
+
+func (suite *FacilityAssetRepositoryTestSuite) expectFacilityLock(quantity int, tracked, archived, allocated bool) {
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.LockFacilityForAsset)).WithArgs(expectedAsset.FacilityId).
+		WillReturnRows(sqlmock.NewRows([]string{"quantity", "tracked", "archived", "allocated"}).AddRow(quantity, tracked, archived, allocated))
+}
+
+func (suite *FacilityAssetRepositoryTestSuite) expectAssetLock(asset entity.FacilityAsset) {
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.LockFacilityAsset)).WithArgs(asset.ID).WillReturnRows(assetRows(asset))
+}
+
+func (suite *FacilityAssetRepositoryTestSuite) expectBookingLock(open bool, booked, out int) {
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.LockCheckoutBooking)).WithArgs("transaction id", expectedAsset.FacilityId).
+		WillReturnRows(sqlmock.NewRows([]string{"open", "booked", "out"}).AddRow(open, booked, out))
+}
+
+func (suite *FacilityAssetRepositoryTestSuite) expectCheckInBookingLock(held bool) {
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.LockCheckInBooking)).WithArgs("transaction id").
+		WillReturnRows(sqlmock.NewRows([]string{"held"}).AddRow(held))
+}
+
+func (suite *FacilityAssetRepositoryTestSuite) expectSave(asset entity.FacilityAsset) {
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.UpdateFacilityAsset)).
+		WithArgs(asset.ID, asset.AssetTag, asset.SerialNumber, asset.Condition, asset.PurchaseDate, asset.RoomId, asset.Location).
+		WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(asset.UpdatedAt))
+}
+
+func (suite *FacilityAssetRepositoryTestSuite) expectMovement(kind string, quantity int, reason string) {
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.InsertFacilityMovement)).WithArgs(expectedAsset.FacilityId, kind, quantity, "actor id", reason, expectedAsset.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "balance", "created_at"}).AddRow("movement id", 3, time.Time{}))
+}
+
+// ledger stands in for the trigger that applies the quantity of each
+// movement to the stock of the facility.
+type ledger struct {
+	quantity int
+}
+
+func (l *ledger) Match(v driver.Value) bool {
+	quantity, ok := v.(int64)
+	l.quantity += int(quantity)
+	return ok
+}
+
+func TestFacilityAssetRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(FacilityAssetRepositoryTestSuite))
+}
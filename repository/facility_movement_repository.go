@@ -0,0 +1,111 @@
+package repository
+
+import (
+	"booking-room-app/config"
+	"booking-room-app/entity"
+	"booking-room-app/shared/logger"
+	"booking-room-app/shared/model"
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+
+	"github.com/lib/pq"
+)
+
+// ErrMissingActor is returned when a movement made on behalf of an employee
+// does not know who the employee is.
+var ErrMissingActor = errors.New("the movement has no actor")
+
+// queryRower is a *sql.DB or a *sql.Tx, so a movement can be recorded on its
+// own or as part of the change that caused it.
+type queryRower interface {
+	QueryRowContext(ctx context.Context, query string, args ...any) *sql.Row
+}
+
+// recordMovement appends a movement to the stock ledger. The database applies
+// it to the facility and refuses it with ErrInsufficientStock when the stock
+// would go negative, or with ErrFacilityTracked when the facility is tracked
+// by asset and the movement does not come from one of its assets. The actor
+// is the authenticated employee unless set; only system work may record a
+// movement without one.
+func recordMovement(ctx context.Context, q queryRower, movement entity.FacilityMovement) (entity.FacilityMovement, error) {
+	if movement.ActorId == "" {
+		movement.ActorId = logger.UserID(ctx)
+	}
+	if movement.ActorId == "" && !logger.IsSystem(ctx) {
+		slog.ErrorContext(ctx, "recordMovement", "facilityId", movement.FacilityId, "kind", movement.Kind, "err", ErrMissingActor)
+		return entity.FacilityMovement{}, ErrMissingActor
+	}
+	err := q.QueryRowContext(ctx, config.InsertFacilityMovement,
+		movement.FacilityId,
+		movement.Kind,
+		movement.Quantity,
+		movement.ActorId,
+		movement.Reason,
+		movement.ReferenceId).Scan(&movement.ID, &movement.Balance, &movement.CreatedAt)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Constraint == "facility_movements_stock" {
+			return entity.FacilityMovement{}, ErrInsufficientStock
+		}
+		if errors.As(err, &pqErr) && pqErr.Constraint == "facility_movements_tracked" {
+			return entity.FacilityMovement{}, ErrFacilityTracked
+		}
+		slog.ErrorContext(ctx, "recordMovement.QueryRow", "err", err)
+		return entity.FacilityMovement{}, err
+	}
+	return movement, nil
+}
+
+// RecordMovement implements FasilitiesRepository.
+func (f *fasilitiesRepository) RecordMovement(ctx context.Context, movement entity.FacilityMovement) (entity.FacilityMovement, error) {
+	ctx, cancel := context.WithTimeout(ctx, f.timeouts.Query)
+	defer cancel()
+
+	return recordMovement(ctx, f.db, movement)
+}
+
+// ListMovements implements FasilitiesRepository, newest first.
+func (f *fasilitiesRepository) ListMovements(ctx context.Context, facilityId string, page, size int) ([]entity.FacilityMovement, model.Paging, error) {
+	ctx, cancel := context.WithTimeout(ctx, f.timeouts.Query)
+	defer cancel()
+
+	rows, err := f.db.QueryContext(ctx, config.SelectFacilityMovements, facilityId, size, (page-1)*size)
+	if err != nil {
+		slog.ErrorContext(ctx, "fasilitiesRepository.ListMovements.Query", "err", err)
+		return nil, model.Paging{}, err
+	}
+	defer rows.Close()
+
+	var movements []entity.FacilityMovement
+	for rows.Next() {
+		var movement entity.FacilityMovement
+		err := rows.Scan(
+			&movement.ID,
+			&movement.FacilityId,
+			&movement.Kind,
+			&movement.Quantity,
+			&movement.Balance,
+			&movement.ActorId,
+			&movement.Reason,
+			&movement.ReferenceId,
+			&movement.CreatedAt)
+		if err != nil {
+			slog.ErrorContext(ctx, "fasilitiesRepository.ListMovements.Scan", "err", err)
+			return nil, model.Paging{}, err
+		}
+		movements = append(movements, movement)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, model.Paging{}, err
+	}
+
+	totalRows := 0
+	if err := f.db.QueryRowContext(ctx, config.CountFacilityMovements, facilityId).Scan(&totalRows); err != nil {
+		slog.ErrorContext(ctx, "fasilitiesRepository.ListMovements.Count", "err", err)
+		return nil, model.Paging{}, err
+	}
+
+	return movements, paging(page, size, totalRows), nil
+}
@@ -0,0 +1,123 @@
+package repository
+
+import (
+	"booking-room-app/config"
+	"booking-room-app/entity"
+	"booking-room-app/shared/logger"
+	"booking-room-app/shared/model"
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+var expectedMovement = entity.FacilityMovement{
+	ID:         "1",
+	FacilityId: "2",
+	Kind:       entity.MovementDamage,
+	Quantity:   -3,
+	Balance:    7,
+	ActorId:    "3",
+	Reason:     "dropped",
+	CreatedAt:  time.Now(),
+}
+
+// actorCtx is the context of a request made by the employee "actor id".
+var actorCtx = logger.WithUserID(context.Background(), "actor id")
+
+type FacilityMovementRepositoryTestSuite struct {
+	suite.Suite
+	mockDb  *sql.DB
+	mockSql sqlmock.Sqlmock
+	repo    FasilitiesRepository
+}
+
+func (suite *FacilityMovementRepositoryTestSuite) SetupTest() {
+	db, mock, _ := sqlmock.New()
+	suite.mockDb = db
+	suite.mockSql = mock
+	suite.repo = NewFasilitesRepository(suite.mockDb, DefaultTimeouts())
+}
+
+func (suite *FacilityMovementRepositoryTestSuite) TestRecordMovement_Success() {
+	ctx := logger.WithUserID(context.Background(), expectedMovement.ActorId)
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.InsertFacilityMovement)).WithArgs(expectedMovement.FacilityId, expectedMovement.Kind, expectedMovement.Quantity, expectedMovement.ActorId, expectedMovement.Reason, "").WillReturnRows(sqlmock.NewRows([]string{"id", "balance", "created_at"}).AddRow(expectedMovement.ID, expectedMovement.Balance, expectedMovement.CreatedAt))
+
+	actual, err := suite.repo.RecordMovement(ctx, entity.FacilityMovement{FacilityId: "2", Kind: entity.MovementDamage, Quantity: -3, Reason: "dropped"})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expectedMovement, actual)
+}
+
+func (suite *FacilityMovementRepositoryTestSuite) TestRecordMovement_SystemSuccess() {
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.InsertFacilityMovement)).WithArgs(expectedMovement.FacilityId, expectedMovement.Kind, expectedMovement.Quantity, "", expectedMovement.Reason, "").WillReturnRows(sqlmock.NewRows([]string{"id", "balance", "created_at"}).AddRow(expectedMovement.ID, expectedMovement.Balance, expectedMovement.CreatedAt))
+
+	actual, err := suite.repo.RecordMovement(logger.WithSystem(context.Background()), entity.FacilityMovement{FacilityId: "2", Kind: entity.MovementDamage, Quantity: -3, Reason: "dropped"})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "", actual.ActorId)
+}
+
+func (suite *FacilityMovementRepositoryTestSuite) TestRecordMovement_MissingActorFailure() {
+	_, err := suite.repo.RecordMovement(context.Background(), entity.FacilityMovement{FacilityId: "2", Kind: entity.MovementDamage, Quantity: -3, Reason: "dropped"})
+
+	assert.ErrorIs(suite.T(), err, ErrMissingActor)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *FacilityMovementRepositoryTestSuite) TestRecordMovement_InsufficientStockFailure() {
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.InsertFacilityMovement)).WithArgs(expectedMovement.FacilityId, expectedMovement.Kind, expectedMovement.Quantity, expectedMovement.ActorId, expectedMovement.Reason, "").WillReturnError(&pq.Error{Code: "23514", Constraint: "facility_movements_stock"})
+
+	_, err := suite.repo.RecordMovement(context.Background(), expectedMovement)
+
+	assert.ErrorIs(suite.T(), err, ErrInsufficientStock)
+}
+
+func (suite *FacilityMovementRepositoryTestSuite) TestRecordMovement_TrackedFailure() {
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.InsertFacilityMovement)).WithArgs(expectedMovement.FacilityId, expectedMovement.Kind, expectedMovement.Quantity, expectedMovement.ActorId, expectedMovement.Reason, "").WillReturnError(&pq.Error{Code: "23514", Constraint: "facility_movements_tracked"})
+
+	_, err := suite.repo.RecordMovement(context.Background(), expectedMovement)
+
+	assert.ErrorIs(suite.T(), err, ErrFacilityTracked)
+}
+
+func (suite *FacilityMovementRepositoryTestSuite) TestRecordMovement_Failure() {
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.InsertFacilityMovement)).WithArgs(expectedMovement.FacilityId, expectedMovement.Kind, expectedMovement.Quantity, expectedMovement.ActorId, expectedMovement.Reason, "").WillReturnError(fmt.Errorf("error"))
+
+	_, err := suite.repo.RecordMovement(context.Background(), expectedMovement)
+
+	assert.Error(suite.T(), err)
+	assert.NotErrorIs(suite.T(), err, ErrInsufficientStock)
+}
+
+func (suite *FacilityMovementRepositoryTestSuite) TestListMovements_Success() {
+	rows := sqlmock.NewRows([]string{"id", "facility_id", "kind", "quantity", "balance", "actor_id", "reason", "reference_id", "created_at"}).
+		AddRow(expectedMovement.ID, expectedMovement.FacilityId, expectedMovement.Kind, expectedMovement.Quantity, expectedMovement.Balance, expectedMovement.ActorId, expectedMovement.Reason, "", expectedMovement.CreatedAt)
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectFacilityMovements)).WithArgs("2", 5, 5).WillReturnRows(rows)
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.CountFacilityMovements)).WithArgs("2").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(6))
+
+	actual, paging, err := suite.repo.ListMovements(context.Background(), "2", 2, 5)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []entity.FacilityMovement{expectedMovement}, actual)
+	assert.Equal(suite.T(), model.Paging{Page: 2, RowsPerPage: 5, TotalRows: 6, TotalPages: 2}, paging)
+}
+
+func (suite *FacilityMovementRepositoryTestSuite) TestListMovements_Failure() {
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectFacilityMovements)).WithArgs("2", 5, 0).WillReturnError(fmt.Errorf("error"))
+
+	_, _, err := suite.repo.ListMovements(context.Background(), "2", 1, 5)
+
+	assert.Error(suite.T(), err)
+}
+
+func TestFacilityMovementRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(FacilityMovementRepositoryTestSuite))
+}
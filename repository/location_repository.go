@@ -0,0 +1,321 @@
+package repository
+
+import (
+	"booking-room-app/config"
+	"booking-room-app/entity"
+	"booking-room-app/shared/model"
+	"context"
+	"database/sql"
+	"log/slog"
+	"math"
+	"time"
+)
+
+type LocationRepository interface {
+	CreateSite(ctx context.Context, payload entity.Site) (entity.Site, error)
+	GetSite(ctx context.Context, id string) (entity.Site, error)
+	ListSites(ctx context.Context, page, size int) ([]entity.Site, model.Paging, error)
+	UpdateSite(ctx context.Context, payload entity.Site) (entity.Site, error)
+	DeleteSite(ctx context.Context, id string) error
+	CreateBuilding(ctx context.Context, payload entity.Building) (entity.Building, error)
+	GetBuilding(ctx context.Context, id string) (entity.Building, error)
+	ListBuildings(ctx context.Context, siteId string, page, size int) ([]entity.Building, model.Paging, error)
+	UpdateBuilding(ctx context.Context, payload entity.Building) (entity.Building, error)
+	DeleteBuilding(ctx context.Context, id string) error
+	CreateFloor(ctx context.Context, payload entity.Floor) (entity.Floor, error)
+	GetFloor(ctx context.Context, id string) (entity.Floor, error)
+	ListFloors(ctx context.Context, buildingId string, page, size int) ([]entity.Floor, model.Paging, error)
+	UpdateFloor(ctx context.Context, payload entity.Floor) (entity.Floor, error)
+	DeleteFloor(ctx context.Context, id string) error
+}
+
+type locationRepository struct {
+	db       *sql.DB
+	timeouts Timeouts
+}
+
+// CreateSite implements LocationRepository.
+func (l *locationRepository) CreateSite(ctx context.Context, payload entity.Site) (entity.Site, error) {
+	ctx, cancel := context.WithTimeout(ctx, l.timeouts.Query)
+	defer cancel()
+
+	err := l.db.QueryRowContext(ctx, config.InsertSite, payload.Name, payload.Address, payload.Timezone).Scan(&payload.ID, &payload.CreatedAt, &payload.UpdatedAt)
+	if err != nil {
+		slog.ErrorContext(ctx, "locationRepository.CreateSiteQueryRow", "err", err)
+		return entity.Site{}, err
+	}
+
+	return payload, nil
+}
+
+// GetSite implements LocationRepository.
+func (l *locationRepository) GetSite(ctx context.Context, id string) (entity.Site, error) {
+	ctx, cancel := context.WithTimeout(ctx, l.timeouts.Query)
+	defer cancel()
+
+	var site entity.Site
+	err := l.db.QueryRowContext(ctx, config.SelectSiteByID, id).Scan(&site.ID, &site.Name, &site.Address, &site.Timezone, &site.CreatedAt, &site.UpdatedAt)
+	if err != nil {
+		slog.ErrorContext(ctx, "locationRepository.GetSiteQueryRow", "err", err)
+		return entity.Site{}, err
+	}
+
+	return site, nil
+}
+
+// ListSites implements LocationRepository.
+func (l *locationRepository) ListSites(ctx context.Context, page, size int) ([]entity.Site, model.Paging, error) {
+	ctx, cancel := context.WithTimeout(ctx, l.timeouts.Query)
+	defer cancel()
+
+	var sites []entity.Site
+	offset := (page - 1) * size
+
+	rows, err := l.db.QueryContext(ctx, config.SelectSiteList, size, offset)
+	if err != nil {
+		slog.ErrorContext(ctx, "locationRepository.ListSitesQuery", "err", err)
+		return nil, model.Paging{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var site entity.Site
+		if err := rows.Scan(&site.ID, &site.Name, &site.Address, &site.Timezone, &site.CreatedAt, &site.UpdatedAt); err != nil {
+			slog.ErrorContext(ctx, "locationRepository.ListSitesScan", "err", err)
+			return nil, model.Paging{}, err
+		}
+		sites = append(sites, site)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, model.Paging{}, err
+	}
+
+	totalRows := 0
+	if err := l.db.QueryRowContext(ctx, config.SelectCountSite).Scan(&totalRows); err != nil {
+		return nil, model.Paging{}, err
+	}
+
+	return sites, paging(page, size, totalRows), nil
+}
+
+// UpdateSite implements LocationRepository.
+func (l *locationRepository) UpdateSite(ctx context.Context, payload entity.Site) (entity.Site, error) {
+	ctx, cancel := context.WithTimeout(ctx, l.timeouts.Query)
+	defer cancel()
+
+	err := l.db.QueryRowContext(ctx, config.UpdateSite, payload.ID, payload.Name, payload.Address, payload.Timezone).Scan(&payload.CreatedAt, &payload.UpdatedAt)
+	if err != nil {
+		slog.ErrorContext(ctx, "locationRepository.UpdateSiteQueryRow", "err", err)
+		return entity.Site{}, err
+	}
+
+	return payload, nil
+}
+
+// DeleteSite implements LocationRepository.
+func (l *locationRepository) DeleteSite(ctx context.Context, id string) error {
+	return deleteByID(ctx, l.db, l.timeouts.Query, config.DeleteSite, id)
+}
+
+// CreateBuilding implements LocationRepository.
+func (l *locationRepository) CreateBuilding(ctx context.Context, payload entity.Building) (entity.Building, error) {
+	ctx, cancel := context.WithTimeout(ctx, l.timeouts.Query)
+	defer cancel()
+
+	err := l.db.QueryRowContext(ctx, config.InsertBuilding, payload.SiteId, payload.Name).Scan(&payload.ID, &payload.CreatedAt, &payload.UpdatedAt)
+	if err != nil {
+		slog.ErrorContext(ctx, "locationRepository.CreateBuildingQueryRow", "err", err)
+		return entity.Building{}, err
+	}
+
+	return payload, nil
+}
+
+// GetBuilding implements LocationRepository.
+func (l *locationRepository) GetBuilding(ctx context.Context, id string) (entity.Building, error) {
+	ctx, cancel := context.WithTimeout(ctx, l.timeouts.Query)
+	defer cancel()
+
+	var building entity.Building
+	err := l.db.QueryRowContext(ctx, config.SelectBuildingByID, id).Scan(&building.ID, &building.SiteId, &building.Name, &building.CreatedAt, &building.UpdatedAt)
+	if err != nil {
+		slog.ErrorContext(ctx, "locationRepository.GetBuildingQueryRow", "err", err)
+		return entity.Building{}, err
+	}
+
+	return building, nil
+}
+
+// ListBuildings implements LocationRepository. An empty siteId lists the
+// buildings of every site.
+func (l *locationRepository) ListBuildings(ctx context.Context, siteId string, page, size int) ([]entity.Building, model.Paging, error) {
+	ctx, cancel := context.WithTimeout(ctx, l.timeouts.Query)
+	defer cancel()
+
+	var buildings []entity.Building
+	offset := (page - 1) * size
+
+	rows, err := l.db.QueryContext(ctx, config.SelectBuildingList, siteId, size, offset)
+	if err != nil {
+		slog.ErrorContext(ctx, "locationRepository.ListBuildingsQuery", "err", err)
+		return nil, model.Paging{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var building entity.Building
+		if err := rows.Scan(&building.ID, &building.SiteId, &building.Name, &building.CreatedAt, &building.UpdatedAt); err != nil {
+			slog.ErrorContext(ctx, "locationRepository.ListBuildingsScan", "err", err)
+			return nil, model.Paging{}, err
+		}
+		buildings = append(buildings, building)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, model.Paging{}, err
+	}
+
+	totalRows := 0
+	if err := l.db.QueryRowContext(ctx, config.SelectCountBuilding, siteId).Scan(&totalRows); err != nil {
+		return nil, model.Paging{}, err
+	}
+
+	return buildings, paging(page, size, totalRows), nil
+}
+
+// UpdateBuilding implements LocationRepository.
+func (l *locationRepository) UpdateBuilding(ctx context.Context, payload entity.Building) (entity.Building, error) {
+	ctx, cancel := context.WithTimeout(ctx, l.timeouts.Query)
+	defer cancel()
+
+	err := l.db.QueryRowContext(ctx, config.UpdateBuilding, payload.ID, payload.SiteId, payload.Name).Scan(&payload.CreatedAt, &payload.UpdatedAt)
+	if err != nil {
+		slog.ErrorContext(ctx, "locationRepository.UpdateBuildingQueryRow", "err", err)
+		return entity.Building{}, err
+	}
+
+	return payload, nil
+}
+
+// DeleteBuilding implements LocationRepository.
+func (l *locationRepository) DeleteBuilding(ctx context.Context, id string) error {
+	return deleteByID(ctx, l.db, l.timeouts.Query, config.DeleteBuilding, id)
+}
+
+// CreateFloor implements LocationRepository.
+func (l *locationRepository) CreateFloor(ctx context.Context, payload entity.Floor) (entity.Floor, error) {
+	ctx, cancel := context.WithTimeout(ctx, l.timeouts.Query)
+	defer cancel()
+
+	err := l.db.QueryRowContext(ctx, config.InsertFloor, payload.BuildingId, payload.Name, payload.Level).Scan(&payload.ID, &payload.CreatedAt, &payload.UpdatedAt)
+	if err != nil {
+		slog.ErrorContext(ctx, "locationRepository.CreateFloorQueryRow", "err", err)
+		return entity.Floor{}, err
+	}
+
+	return payload, nil
+}
+
+// GetFloor implements LocationRepository.
+func (l *locationRepository) GetFloor(ctx context.Context, id string) (entity.Floor, error) {
+	ctx, cancel := context.WithTimeout(ctx, l.timeouts.Query)
+	defer cancel()
+
+	var floor entity.Floor
+	err := l.db.QueryRowContext(ctx, config.SelectFloorByID, id).Scan(&floor.ID, &floor.BuildingId, &floor.Name, &floor.Level, &floor.CreatedAt, &floor.UpdatedAt)
+	if err != nil {
+		slog.ErrorContext(ctx, "locationRepository.GetFloorQueryRow", "err", err)
+		return entity.Floor{}, err
+	}
+
+	return floor, nil
+}
+
+// ListFloors implements LocationRepository. An empty buildingId lists the
+// floors of every building.
+func (l *locationRepository) ListFloors(ctx context.Context, buildingId string, page, size int) ([]entity.Floor, model.Paging, error) {
+	ctx, cancel := context.WithTimeout(ctx, l.timeouts.Query)
+	defer cancel()
+
+	var floors []entity.Floor
+	offset := (page - 1) * size
+
+	rows, err := l.db.QueryContext(ctx, config.SelectFloorList, buildingId, size, offset)
+	if err != nil {
+		slog.ErrorContext(ctx, "locationRepository.ListFloorsQuery", "err", err)
+		return nil, model.Paging{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var floor entity.Floor
+		if err := rows.Scan(&floor.ID, &floor.BuildingId, &floor.Name, &floor.Level, &floor.CreatedAt, &floor.UpdatedAt); err != nil {
+			slog.ErrorContext(ctx, "locationRepository.ListFloorsScan", "err", err)
+			return nil, model.Paging{}, err
+		}
+		floors = append(floors, floor)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, model.Paging{}, err
+	}
+
+	totalRows := 0
+	if err := l.db.QueryRowContext(ctx, config.SelectCountFloor, buildingId).Scan(&totalRows); err != nil {
+		return nil, model.Paging{}, err
+	}
+
+	return floors, paging(page, size, totalRows), nil
+}
+
+// UpdateFloor implements LocationRepository.
+func (l *locationRepository) UpdateFloor(ctx context.Context, payload entity.Floor) (entity.Floor, error) {
+	ctx, cancel := context.WithTimeout(ctx, l.timeouts.Query)
+	defer cancel()
+
+	err := l.db.QueryRowContext(ctx, config.UpdateFloor, payload.ID, payload.BuildingId, payload.Name, payload.Level).Scan(&payload.CreatedAt, &payload.UpdatedAt)
+	if err != nil {
+		slog.ErrorContext(ctx, "locationRepository.UpdateFloorQueryRow", "err", err)
+		return entity.Floor{}, err
+	}
+
+	return payload, nil
+}
+
+// DeleteFloor implements LocationRepository.
+func (l *locationRepository) DeleteFloor(ctx context.Context, id string) error {
+	return deleteByID(ctx, l.db, l.timeouts.Query, config.DeleteFloor, id)
+}
+
+// deleteByID runs a DELETE by id and reports sql.ErrNoRows when nothing was
+// deleted.
+func deleteByID(ctx context.Context, db *sql.DB, timeout time.Duration, query, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	result, err := db.ExecContext(ctx, query, id)
+	if err != nil {
+		slog.ErrorContext(ctx, "repository.DeleteByIDExec", "err", err)
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+func paging(page, size, totalRows int) model.Paging {
+	return model.Paging{
+		Page:        page,
+		RowsPerPage: size,
+		TotalRows:   totalRows,
+		TotalPages:  int(math.Ceil(float64(totalRows) / float64(size))),
+	}
+}
+
+func NewLocationRepository(db *sql.DB, timeouts Timeouts) LocationRepository {
+	return &locationRepository{db: db, timeouts: timeouts}
+}
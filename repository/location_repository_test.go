@@ -0,0 +1,150 @@
+package repository
+
+import (
+	"booking-room-app/config"
+	"booking-room-app/entity"
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+var expectedSite = entity.Site{
+	ID:        "1",
+	Name:      "Jakarta HQ",
+	Address:   "Jl. Sudirman 1",
+	Timezone:  "Asia/Jakarta",
+	CreatedAt: time.Now(),
+	UpdatedAt: time.Now(),
+}
+
+var expectedBuilding = entity.Building{
+	ID:        "2",
+	SiteId:    "1",
+	Name:      "Tower A",
+	CreatedAt: time.Now(),
+	UpdatedAt: time.Now(),
+}
+
+var expectedFloor = entity.Floor{
+	ID:         "3",
+	BuildingId: "2",
+	Name:       "Ground floor",
+	Level:      0,
+	CreatedAt:  time.Now(),
+	UpdatedAt:  time.Now(),
+}
+
+type LocationRepositoryTestSuite struct {
+	suite.Suite
+	mockDb  *sql.DB
+	mockSql sqlmock.Sqlmock
+	repo    LocationRepository
+}
+
+func (suite *LocationRepositoryTestSuite) SetupTest() {
+	db, mock, _ := sqlmock.New()
+	suite.mockDb = db
+	suite.mockSql = mock
+	suite.repo = NewLocationRepository(suite.mockDb, DefaultTimeouts())
+}
+
+func (suite *LocationRepositoryTestSuite) TestCreateSite_Success() {
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.InsertSite)).WithArgs(expectedSite.Name, expectedSite.Address, expectedSite.Timezone).WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow(expectedSite.ID, expectedSite.CreatedAt, expectedSite.UpdatedAt))
+
+	actual, err := suite.repo.CreateSite(context.Background(), entity.Site{Name: expectedSite.Name, Address: expectedSite.Address, Timezone: expectedSite.Timezone})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expectedSite, actual)
+}
+
+func (suite *LocationRepositoryTestSuite) TestCreateSite_Failure() {
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.InsertSite)).WillReturnError(&pq.Error{Code: "23505"})
+
+	_, err := suite.repo.CreateSite(context.Background(), expectedSite)
+
+	assert.Error(suite.T(), err)
+}
+
+func (suite *LocationRepositoryTestSuite) TestGetSite_Success() {
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectSiteByID)).WithArgs(expectedSite.ID).WillReturnRows(sqlmock.NewRows([]string{"id", "name", "address", "timezone", "created_at", "updated_at"}).AddRow(expectedSite.ID, expectedSite.Name, expectedSite.Address, expectedSite.Timezone, expectedSite.CreatedAt, expectedSite.UpdatedAt))
+
+	actual, err := suite.repo.GetSite(context.Background(), expectedSite.ID)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expectedSite, actual)
+}
+
+func (suite *LocationRepositoryTestSuite) TestListSites_Success() {
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectSiteList)).WithArgs(5, 0).WillReturnRows(sqlmock.NewRows([]string{"id", "name", "address", "timezone", "created_at", "updated_at"}).AddRow(expectedSite.ID, expectedSite.Name, expectedSite.Address, expectedSite.Timezone, expectedSite.CreatedAt, expectedSite.UpdatedAt))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectCountSite)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(6))
+
+	actual, paging, err := suite.repo.ListSites(context.Background(), 1, 5)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []entity.Site{expectedSite}, actual)
+	assert.Equal(suite.T(), 2, paging.TotalPages)
+}
+
+func (suite *LocationRepositoryTestSuite) TestListBuildings_BySiteSuccess() {
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectBuildingList)).WithArgs(expectedSite.ID, 5, 0).WillReturnRows(sqlmock.NewRows([]string{"id", "site_id", "name", "created_at", "updated_at"}).AddRow(expectedBuilding.ID, expectedBuilding.SiteId, expectedBuilding.Name, expectedBuilding.CreatedAt, expectedBuilding.UpdatedAt))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectCountBuilding)).WithArgs(expectedSite.ID).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	actual, paging, err := suite.repo.ListBuildings(context.Background(), expectedSite.ID, 1, 5)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []entity.Building{expectedBuilding}, actual)
+	assert.Equal(suite.T(), 1, paging.TotalRows)
+}
+
+func (suite *LocationRepositoryTestSuite) TestListFloors_ScanFailure() {
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectFloorList)).WithArgs("", 5, 0).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(expectedFloor.ID))
+
+	_, _, err := suite.repo.ListFloors(context.Background(), "", 1, 5)
+
+	assert.Error(suite.T(), err)
+}
+
+func (suite *LocationRepositoryTestSuite) TestUpdateFloor_Success() {
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.UpdateFloor)).WithArgs(expectedFloor.ID, expectedFloor.BuildingId, expectedFloor.Name, expectedFloor.Level).WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(expectedFloor.CreatedAt, expectedFloor.UpdatedAt))
+
+	actual, err := suite.repo.UpdateFloor(context.Background(), entity.Floor{ID: expectedFloor.ID, BuildingId: expectedFloor.BuildingId, Name: expectedFloor.Name, Level: expectedFloor.Level})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expectedFloor, actual)
+}
+
+func (suite *LocationRepositoryTestSuite) TestDeleteBuilding_Success() {
+	suite.mockSql.ExpectExec(regexp.QuoteMeta(config.DeleteBuilding)).WithArgs(expectedBuilding.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := suite.repo.DeleteBuilding(context.Background(), expectedBuilding.ID)
+
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *LocationRepositoryTestSuite) TestDeleteFloor_NotFoundFailure() {
+	suite.mockSql.ExpectExec(regexp.QuoteMeta(config.DeleteFloor)).WithArgs(expectedFloor.ID).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := suite.repo.DeleteFloor(context.Background(), expectedFloor.ID)
+
+	assert.ErrorIs(suite.T(), err, sql.ErrNoRows)
+}
+
+func (suite *LocationRepositoryTestSuite) TestDeleteSite_Failure() {
+	suite.mockSql.ExpectExec(regexp.QuoteMeta(config.DeleteSite)).WithArgs(expectedSite.ID).WillReturnError(fmt.Errorf("error"))
+
+	err := suite.repo.DeleteSite(context.Background(), expectedSite.ID)
+
+	assert.Error(suite.T(), err)
+}
+
+func TestLocationRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(LocationRepositoryTestSuite))
+}
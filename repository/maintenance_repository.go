@@ -0,0 +1,253 @@
+package repository
+
+import (
+	"booking-room-app/config"
+	"booking-room-app/entity"
+	"booking-room-app/shared/model"
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type MaintenanceRepository interface {
+	Create(ctx context.Context, payload entity.Maintenance) (entity.Maintenance, error)
+	Get(ctx context.Context, id string) (entity.Maintenance, error)
+	List(ctx context.Context, filter entity.MaintenanceFilter, page, size int) ([]entity.Maintenance, model.Paging, error)
+	Update(ctx context.Context, payload entity.Maintenance) (entity.Maintenance, error)
+	Delete(ctx context.Context, id string) error
+	ListConflicts(ctx context.Context, maintenanceId string, now time.Time) ([]entity.MaintenanceConflict, error)
+	SyncRoomStatus(ctx context.Context, now time.Time) (started, completed int64, err error)
+}
+
+type maintenanceRepository struct {
+	db       *sql.DB
+	timeouts Timeouts
+}
+
+// Create implements MaintenanceRepository.
+func (m *maintenanceRepository) Create(ctx context.Context, payload entity.Maintenance) (entity.Maintenance, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.timeouts.Query)
+	defer cancel()
+
+	err := m.db.QueryRowContext(ctx, config.InsertMaintenance, payload.RoomId, payload.AssigneeId, payload.Reason, payload.StartTime, payload.EndTime).Scan(&payload.ID, &payload.CreatedAt, &payload.UpdatedAt)
+	if err != nil {
+		slog.ErrorContext(ctx, "maintenanceRepository.CreateQueryRow", "err", err)
+		return entity.Maintenance{}, err
+	}
+
+	return payload, nil
+}
+
+// Get implements MaintenanceRepository.
+func (m *maintenanceRepository) Get(ctx context.Context, id string) (entity.Maintenance, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.timeouts.Query)
+	defer cancel()
+
+	var maintenance entity.Maintenance
+	err := m.db.QueryRowContext(ctx, config.SelectMaintenanceByID, id).Scan(&maintenance.ID, &maintenance.RoomId, &maintenance.AssigneeId, &maintenance.Reason, &maintenance.StartTime, &maintenance.EndTime, &maintenance.StartedAt, &maintenance.CompletedAt, &maintenance.CreatedAt, &maintenance.UpdatedAt)
+	if err != nil {
+		slog.ErrorContext(ctx, "maintenanceRepository.GetQueryRow", "err", err)
+		return entity.Maintenance{}, err
+	}
+
+	return maintenance, nil
+}
+
+// List implements MaintenanceRepository.
+func (m *maintenanceRepository) List(ctx context.Context, filter entity.MaintenanceFilter, page, size int) ([]entity.Maintenance, model.Paging, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.timeouts.Query)
+	defer cancel()
+
+	offset := (page - 1) * size
+	rows, err := m.db.QueryContext(ctx, config.SelectMaintenanceList, filter.RoomId, filter.Open, size, offset)
+	if err != nil {
+		slog.ErrorContext(ctx, "maintenanceRepository.ListQuery", "err", err)
+		return nil, model.Paging{}, err
+	}
+	defer rows.Close()
+
+	windows, err := scanMaintenance(rows)
+	if err != nil {
+		return nil, model.Paging{}, err
+	}
+
+	totalRows := 0
+	if err := m.db.QueryRowContext(ctx, config.SelectCountMaintenance, filter.RoomId, filter.Open).Scan(&totalRows); err != nil {
+		return nil, model.Paging{}, err
+	}
+
+	return windows, paging(page, size, totalRows), nil
+}
+
+// Update implements MaintenanceRepository. The room of a window cannot be
+// changed.
+func (m *maintenanceRepository) Update(ctx context.Context, payload entity.Maintenance) (entity.Maintenance, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.timeouts.Query)
+	defer cancel()
+
+	err := m.db.QueryRowContext(ctx, config.UpdateMaintenance, payload.ID, payload.AssigneeId, payload.Reason, payload.StartTime, payload.EndTime).Scan(&payload.RoomId, &payload.StartedAt, &payload.CompletedAt, &payload.CreatedAt, &payload.UpdatedAt)
+	if err != nil {
+		slog.ErrorContext(ctx, "maintenanceRepository.UpdateQueryRow", "err", err)
+		return entity.Maintenance{}, err
+	}
+
+	return payload, nil
+}
+
+// Delete implements MaintenanceRepository. Cancelling a window that has
+// taken its room out of service returns the room to service.
+func (m *maintenanceRepository) Delete(ctx context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, m.timeouts.Query)
+	defer cancel()
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		slog.ErrorContext(ctx, "maintenanceRepository.DeleteBeginTransaction", "err", err)
+		return err
+	}
+
+	var roomId string
+	var inProgress bool
+	if err := tx.QueryRowContext(ctx, config.LockMaintenance, id).Scan(&roomId, &inProgress); err != nil {
+		slog.ErrorContext(ctx, "maintenanceRepository.DeleteLock", "err", err)
+		tx.Rollback()
+		return err
+	}
+
+	if _, err := tx.ExecContext(ctx, config.DeleteMaintenance, id); err != nil {
+		slog.ErrorContext(ctx, "maintenanceRepository.DeleteExec", "err", err)
+		tx.Rollback()
+		return err
+	}
+
+	if inProgress {
+		if _, err := tx.ExecContext(ctx, config.ReturnRoomsToService, pq.Array([]string{roomId})); err != nil {
+			slog.ErrorContext(ctx, "maintenanceRepository.DeleteReturnRoom", "err", err)
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		slog.ErrorContext(ctx, "maintenanceRepository.DeleteCommit", "err", err)
+		return err
+	}
+	return nil
+}
+
+// ListConflicts implements MaintenanceRepository. It returns the accepted
+// bookings that have not ended at now and overlap the window, or any window
+// when maintenanceId is empty.
+func (m *maintenanceRepository) ListConflicts(ctx context.Context, maintenanceId string, now time.Time) ([]entity.MaintenanceConflict, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.timeouts.Query)
+	defer cancel()
+
+	rows, err := m.db.QueryContext(ctx, config.SelectMaintenanceConflicts, maintenanceId, now)
+	if err != nil {
+		slog.ErrorContext(ctx, "maintenanceRepository.ListConflictsQuery", "err", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	conflicts := []entity.MaintenanceConflict{}
+	for rows.Next() {
+		var conflict entity.MaintenanceConflict
+		t := &conflict.Transaction
+		if err := rows.Scan(&conflict.MaintenanceId, &conflict.Reason, &t.ID, &t.EmployeeId, &t.RoomId, &t.Description, &t.Status, &t.StartTime, &t.EndTime, &t.CreatedAt, &t.UpdatedAt); err != nil {
+			slog.ErrorContext(ctx, "maintenanceRepository.ListConflictsScan", "err", err)
+			return nil, err
+		}
+		conflicts = append(conflicts, conflict)
+	}
+	return conflicts, rows.Err()
+}
+
+// SyncRoomStatus implements MaintenanceRepository. In one database
+// transaction it takes the rooms of the windows that have begun at now out of
+// service and returns those of the windows that have ended, unless another
+// window still holds the room. A room returns to the status it had before it
+// was taken out; one that was already unavailable is left alone, and one
+// whose status was changed during the window keeps it.
+func (m *maintenanceRepository) SyncRoomStatus(ctx context.Context, now time.Time) (int64, int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, m.timeouts.Query)
+	defer cancel()
+
+	tx, err := m.db.BeginTx(ctx, nil)
+	if err != nil {
+		slog.ErrorContext(ctx, "maintenanceRepository.SyncRoomStatusBeginTransaction", "err", err)
+		return 0, 0, err
+	}
+
+	started, err := roomIDs(ctx, tx, config.StartDueMaintenance, now)
+	if err != nil {
+		tx.Rollback()
+		return 0, 0, err
+	}
+	if len(started) > 0 {
+		if _, err := tx.ExecContext(ctx, config.TakeRoomsOutOfService, pq.Array(started)); err != nil {
+			slog.ErrorContext(ctx, "maintenanceRepository.SyncRoomStatusTakeOut", "err", err)
+			tx.Rollback()
+			return 0, 0, err
+		}
+	}
+
+	completed, err := roomIDs(ctx, tx, config.CompleteDueMaintenance, now)
+	if err != nil {
+		tx.Rollback()
+		return 0, 0, err
+	}
+	if len(completed) > 0 {
+		if _, err := tx.ExecContext(ctx, config.ReturnRoomsToService, pq.Array(completed)); err != nil {
+			slog.ErrorContext(ctx, "maintenanceRepository.SyncRoomStatusReturn", "err", err)
+			tx.Rollback()
+			return 0, 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		slog.ErrorContext(ctx, "maintenanceRepository.SyncRoomStatusCommit", "err", err)
+		return 0, 0, err
+	}
+	return int64(len(started)), int64(len(completed)), nil
+}
+
+// roomIDs runs a statement that returns the room_id of every row it changes.
+func roomIDs(ctx context.Context, tx *sql.Tx, query string, now time.Time) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, query, now)
+	if err != nil {
+		slog.ErrorContext(ctx, "maintenanceRepository.RoomIDsQuery", "err", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			slog.ErrorContext(ctx, "maintenanceRepository.RoomIDsScan", "err", err)
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, rows.Err()
+}
+
+func scanMaintenance(rows *sql.Rows) ([]entity.Maintenance, error) {
+	windows := []entity.Maintenance{}
+	for rows.Next() {
+		var maintenance entity.Maintenance
+		if err := rows.Scan(&maintenance.ID, &maintenance.RoomId, &maintenance.AssigneeId, &maintenance.Reason, &maintenance.StartTime, &maintenance.EndTime, &maintenance.StartedAt, &maintenance.CompletedAt, &maintenance.CreatedAt, &maintenance.UpdatedAt); err != nil {
+			slog.Error("maintenanceRepository.Scan", "err", err)
+			return nil, err
+		}
+		windows = append(windows, maintenance)
+	}
+	return windows, rows.Err()
+}
+
+func NewMaintenanceRepository(db *sql.DB, timeouts Timeouts) MaintenanceRepository {
+	return &maintenanceRepository{db: db, timeouts: timeouts}
+}
@@ -0,0 +1,146 @@
+package repository
+
+import (
+	"booking-room-app/config"
+	"booking-room-app/entity"
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+var maintenanceColumns = []string{"id", "room_id", "assignee_id", "reason", "start_time", "end_time", "started_at", "completed_at", "created_at", "updated_at"}
+
+type MaintenanceRepositoryTestSuite struct {
+	suite.Suite
+	mockDb  *sql.DB
+	mockSql sqlmock.Sqlmock
+	repo    MaintenanceRepository
+}
+
+func (suite *MaintenanceRepositoryTestSuite) SetupTest() {
+	db, mock, _ := sqlmock.New()
+	suite.mockDb = db
+	suite.mockSql = mock
+	suite.repo = NewMaintenanceRepository(suite.mockDb, DefaultTimeouts())
+}
+
+func (suite *MaintenanceRepositoryTestSuite) TestCreate_Success() {
+	start := time.Date(2024, time.March, 1, 8, 0, 0, 0, time.UTC)
+	payload := entity.Maintenance{RoomId: "9", AssigneeId: "2", Reason: "Replace projector", StartTime: start, EndTime: start.Add(4 * time.Hour)}
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.InsertMaintenance)).WithArgs("9", "2", "Replace projector", payload.StartTime, payload.EndTime).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow("1", start, start))
+
+	actual, err := suite.repo.Create(context.Background(), payload)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "1", actual.ID)
+	assert.Nil(suite.T(), actual.StartedAt)
+}
+
+func (suite *MaintenanceRepositoryTestSuite) TestGet_Success() {
+	start := time.Date(2024, time.March, 1, 8, 0, 0, 0, time.UTC)
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectMaintenanceByID)).WithArgs("1").
+		WillReturnRows(sqlmock.NewRows(maintenanceColumns).AddRow("1", "9", "2", "Replace projector", start, start.Add(4*time.Hour), start, nil, start, start))
+
+	actual, err := suite.repo.Get(context.Background(), "1")
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), start, *actual.StartedAt)
+	assert.Nil(suite.T(), actual.CompletedAt)
+}
+
+func (suite *MaintenanceRepositoryTestSuite) TestDelete_InProgressReturnsRoomSuccess() {
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.LockMaintenance)).WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{"room_id", "in_progress"}).AddRow("9", true))
+	suite.mockSql.ExpectExec(regexp.QuoteMeta(config.DeleteMaintenance)).WithArgs("1").WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mockSql.ExpectExec(regexp.QuoteMeta(config.ReturnRoomsToService)).WithArgs(pq.Array([]string{"9"})).WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mockSql.ExpectCommit()
+
+	err := suite.repo.Delete(context.Background(), "1")
+
+	assert.NoError(suite.T(), err)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *MaintenanceRepositoryTestSuite) TestDelete_NotFoundFail() {
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.LockMaintenance)).WithArgs("1").WillReturnError(sql.ErrNoRows)
+	suite.mockSql.ExpectRollback()
+
+	err := suite.repo.Delete(context.Background(), "1")
+
+	assert.ErrorIs(suite.T(), err, sql.ErrNoRows)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *MaintenanceRepositoryTestSuite) TestListConflicts_Success() {
+	start := time.Date(2024, time.March, 1, 9, 0, 0, 0, time.UTC)
+	now := start.Add(-time.Hour)
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectMaintenanceConflicts)).WithArgs("1", now).
+		WillReturnRows(sqlmock.NewRows([]string{"maintenance_id", "reason", "id", "employee_id", "room_id", "description", "status", "start_time", "end_time", "created_at", "updated_at"}).
+			AddRow("1", "Replace projector", "t1", "e1", "9", "Weekly sync", "accepted", start, start.Add(time.Hour), now, now))
+
+	actual, err := suite.repo.ListConflicts(context.Background(), "1", now)
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), actual, 1)
+	assert.Equal(suite.T(), "t1", actual[0].Transaction.ID)
+	assert.Equal(suite.T(), "Replace projector", actual[0].Reason)
+}
+
+func (suite *MaintenanceRepositoryTestSuite) TestSyncRoomStatus_Success() {
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.StartDueMaintenance)).WithArgs(now).WillReturnRows(sqlmock.NewRows([]string{"room_id"}).AddRow("9"))
+	suite.mockSql.ExpectExec(regexp.QuoteMeta(config.TakeRoomsOutOfService)).WithArgs(pq.Array([]string{"9"})).WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.CompleteDueMaintenance)).WithArgs(now).WillReturnRows(sqlmock.NewRows([]string{"room_id"}).AddRow("7").AddRow("8"))
+	suite.mockSql.ExpectExec(regexp.QuoteMeta(config.ReturnRoomsToService)).WithArgs(pq.Array([]string{"7", "8"})).WillReturnResult(sqlmock.NewResult(0, 2))
+	suite.mockSql.ExpectCommit()
+
+	started, completed, err := suite.repo.SyncRoomStatus(context.Background(), now)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(1), started)
+	assert.Equal(suite.T(), int64(2), completed)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *MaintenanceRepositoryTestSuite) TestSyncRoomStatus_NothingDueSuccess() {
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.StartDueMaintenance)).WithArgs(now).WillReturnRows(sqlmock.NewRows([]string{"room_id"}))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.CompleteDueMaintenance)).WithArgs(now).WillReturnRows(sqlmock.NewRows([]string{"room_id"}))
+	suite.mockSql.ExpectCommit()
+
+	started, completed, err := suite.repo.SyncRoomStatus(context.Background(), now)
+
+	assert.NoError(suite.T(), err)
+	assert.Zero(suite.T(), started)
+	assert.Zero(suite.T(), completed)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *MaintenanceRepositoryTestSuite) TestSyncRoomStatus_TakeOutFail() {
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.StartDueMaintenance)).WithArgs(now).WillReturnRows(sqlmock.NewRows([]string{"room_id"}).AddRow("9"))
+	suite.mockSql.ExpectExec(regexp.QuoteMeta(config.TakeRoomsOutOfService)).WithArgs(pq.Array([]string{"9"})).WillReturnError(errors.New("error"))
+	suite.mockSql.ExpectRollback()
+
+	_, _, err := suite.repo.SyncRoomStatus(context.Background(), now)
+
+	assert.Error(suite.T(), err)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func TestMaintenanceRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(MaintenanceRepositoryTestSuite))
+}
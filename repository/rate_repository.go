@@ -0,0 +1,161 @@
+package repository
+
+import (
+	"booking-room-app/config"
+	"booking-room-app/entity"
+	"context"
+	"database/sql"
+	"log/slog"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type RateRepository interface {
+	CreateRoomRate(ctx context.Context, payload entity.RoomRate) (entity.RoomRate, error)
+	ListRoomRates(ctx context.Context, roomId string) ([]entity.RoomRate, error)
+	GetRoomRateAt(ctx context.Context, roomId string, at time.Time) (entity.RoomRate, error)
+	CreateFacilityRate(ctx context.Context, payload entity.FacilityRate) (entity.FacilityRate, error)
+	ListFacilityRates(ctx context.Context, facilityId string) ([]entity.FacilityRate, error)
+	GetFacilityRatesAt(ctx context.Context, facilityIds []string, at time.Time) (map[string]int64, error)
+}
+
+type rateRepository struct {
+	db       *sql.DB
+	timeouts Timeouts
+}
+
+// CreateRoomRate implements RateRepository.
+func (r *rateRepository) CreateRoomRate(ctx context.Context, payload entity.RoomRate) (entity.RoomRate, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeouts.Query)
+	defer cancel()
+
+	err := r.db.QueryRowContext(ctx, config.InsertRoomRate,
+		payload.RoomId,
+		payload.HourlyRate,
+		payload.EffectiveFrom).Scan(&payload.ID, &payload.CreatedAt)
+	if err != nil {
+		slog.ErrorContext(ctx, "rateRepository.CreateRoomRateQueryRow", "err", err)
+		return entity.RoomRate{}, err
+	}
+
+	return payload, nil
+}
+
+// ListRoomRates implements RateRepository.
+func (r *rateRepository) ListRoomRates(ctx context.Context, roomId string) ([]entity.RoomRate, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeouts.Query)
+	defer cancel()
+
+	var rates []entity.RoomRate
+
+	rows, err := r.db.QueryContext(ctx, config.SelectRoomRatesByRoomID, roomId)
+	if err != nil {
+		slog.ErrorContext(ctx, "rateRepository.ListRoomRatesQuery", "err", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var rate entity.RoomRate
+		if err := rows.Scan(&rate.ID, &rate.RoomId, &rate.HourlyRate, &rate.EffectiveFrom, &rate.CreatedAt); err != nil {
+			slog.ErrorContext(ctx, "rateRepository.ListRoomRatesScan", "err", err)
+			return nil, err
+		}
+		rates = append(rates, rate)
+	}
+
+	return rates, rows.Err()
+}
+
+// GetRoomRateAt implements RateRepository.
+func (r *rateRepository) GetRoomRateAt(ctx context.Context, roomId string, at time.Time) (entity.RoomRate, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeouts.Query)
+	defer cancel()
+
+	var rate entity.RoomRate
+	err := r.db.QueryRowContext(ctx, config.SelectRoomRateAt, roomId, at).Scan(&rate.ID, &rate.RoomId, &rate.HourlyRate, &rate.EffectiveFrom, &rate.CreatedAt)
+	if err != nil {
+		return entity.RoomRate{}, err
+	}
+
+	return rate, nil
+}
+
+// CreateFacilityRate implements RateRepository.
+func (r *rateRepository) CreateFacilityRate(ctx context.Context, payload entity.FacilityRate) (entity.FacilityRate, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeouts.Query)
+	defer cancel()
+
+	err := r.db.QueryRowContext(ctx, config.InsertFacilityRate,
+		payload.FacilityId,
+		payload.UnitRate,
+		payload.EffectiveFrom).Scan(&payload.ID, &payload.CreatedAt)
+	if err != nil {
+		slog.ErrorContext(ctx, "rateRepository.CreateFacilityRateQueryRow", "err", err)
+		return entity.FacilityRate{}, err
+	}
+
+	return payload, nil
+}
+
+// ListFacilityRates implements RateRepository.
+func (r *rateRepository) ListFacilityRates(ctx context.Context, facilityId string) ([]entity.FacilityRate, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeouts.Query)
+	defer cancel()
+
+	var rates []entity.FacilityRate
+
+	rows, err := r.db.QueryContext(ctx, config.SelectFacilityRatesByID, facilityId)
+	if err != nil {
+		slog.ErrorContext(ctx, "rateRepository.ListFacilityRatesQuery", "err", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var rate entity.FacilityRate
+		if err := rows.Scan(&rate.ID, &rate.FacilityId, &rate.UnitRate, &rate.EffectiveFrom, &rate.CreatedAt); err != nil {
+			slog.ErrorContext(ctx, "rateRepository.ListFacilityRatesScan", "err", err)
+			return nil, err
+		}
+		rates = append(rates, rate)
+	}
+
+	return rates, rows.Err()
+}
+
+// GetFacilityRatesAt returns the unit rate in effect at the given time for
+// every facility that has one, keyed by facility ID.
+func (r *rateRepository) GetFacilityRatesAt(ctx context.Context, facilityIds []string, at time.Time) (map[string]int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeouts.Query)
+	defer cancel()
+
+	rates := make(map[string]int64)
+	if len(facilityIds) == 0 {
+		return rates, nil
+	}
+
+	rows, err := r.db.QueryContext(ctx, config.SelectFacilityRatesAt, pq.Array(facilityIds), at)
+	if err != nil {
+		slog.ErrorContext(ctx, "rateRepository.GetFacilityRatesAtQuery", "err", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var facilityId string
+		var unitRate int64
+		if err := rows.Scan(&facilityId, &unitRate); err != nil {
+			slog.ErrorContext(ctx, "rateRepository.GetFacilityRatesAtScan", "err", err)
+			return nil, err
+		}
+		rates[facilityId] = unitRate
+	}
+
+	return rates, rows.Err()
+}
+
+func NewRateRepository(db *sql.DB, timeouts Timeouts) RateRepository {
+	return &rateRepository{db: db, timeouts: timeouts}
+}
@@ -0,0 +1,121 @@
+package repository
+
+import (
+	"booking-room-app/config"
+	"booking-room-app/entity"
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+var expectedRoomRate = entity.RoomRate{
+	ID:            "1",
+	RoomId:        "1",
+	HourlyRate:    150000,
+	EffectiveFrom: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+	CreatedAt:     time.Now(),
+}
+
+var expectedFacilityRate = entity.FacilityRate{
+	ID:            "1",
+	FacilityId:    "1",
+	UnitRate:      25000,
+	EffectiveFrom: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC),
+	CreatedAt:     time.Now(),
+}
+
+type RateRepositoryTestSuite struct {
+	suite.Suite
+	mockDb  *sql.DB
+	mockSql sqlmock.Sqlmock
+	repo    RateRepository
+}
+
+func (suite *RateRepositoryTestSuite) SetupTest() {
+	db, mock, _ := sqlmock.New()
+	suite.mockDb = db
+	suite.mockSql = mock
+	suite.repo = NewRateRepository(suite.mockDb, DefaultTimeouts())
+}
+
+func (suite *RateRepositoryTestSuite) TestCreateRoomRate_Success() {
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.InsertRoomRate)).WithArgs(expectedRoomRate.RoomId, expectedRoomRate.HourlyRate, expectedRoomRate.EffectiveFrom).WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(expectedRoomRate.ID, expectedRoomRate.CreatedAt))
+
+	actual, err := suite.repo.CreateRoomRate(context.Background(), expectedRoomRate)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expectedRoomRate, actual)
+}
+
+func (suite *RateRepositoryTestSuite) TestCreateRoomRate_Failure() {
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.InsertRoomRate)).WithArgs(expectedRoomRate.RoomId, expectedRoomRate.HourlyRate, expectedRoomRate.EffectiveFrom).WillReturnError(fmt.Errorf("error"))
+
+	_, err := suite.repo.CreateRoomRate(context.Background(), expectedRoomRate)
+
+	assert.Error(suite.T(), err)
+}
+
+func (suite *RateRepositoryTestSuite) TestListRoomRates_Success() {
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectRoomRatesByRoomID)).WithArgs(expectedRoomRate.RoomId).WillReturnRows(sqlmock.NewRows([]string{"id", "room_id", "hourly_rate", "effective_from", "created_at"}).AddRow(expectedRoomRate.ID, expectedRoomRate.RoomId, expectedRoomRate.HourlyRate, expectedRoomRate.EffectiveFrom, expectedRoomRate.CreatedAt))
+
+	actual, err := suite.repo.ListRoomRates(context.Background(), expectedRoomRate.RoomId)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []entity.RoomRate{expectedRoomRate}, actual)
+}
+
+func (suite *RateRepositoryTestSuite) TestGetRoomRateAt_NoRateFailure() {
+	at := time.Now()
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectRoomRateAt)).WithArgs(expectedRoomRate.RoomId, at).WillReturnError(sql.ErrNoRows)
+
+	_, err := suite.repo.GetRoomRateAt(context.Background(), expectedRoomRate.RoomId, at)
+
+	assert.ErrorIs(suite.T(), err, sql.ErrNoRows)
+}
+
+func (suite *RateRepositoryTestSuite) TestCreateFacilityRate_Success() {
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.InsertFacilityRate)).WithArgs(expectedFacilityRate.FacilityId, expectedFacilityRate.UnitRate, expectedFacilityRate.EffectiveFrom).WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(expectedFacilityRate.ID, expectedFacilityRate.CreatedAt))
+
+	actual, err := suite.repo.CreateFacilityRate(context.Background(), expectedFacilityRate)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expectedFacilityRate, actual)
+}
+
+func (suite *RateRepositoryTestSuite) TestListFacilityRates_ScanFailure() {
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectFacilityRatesByID)).WithArgs(expectedFacilityRate.FacilityId).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(expectedFacilityRate.ID))
+
+	_, err := suite.repo.ListFacilityRates(context.Background(), expectedFacilityRate.FacilityId)
+
+	assert.Error(suite.T(), err)
+}
+
+func (suite *RateRepositoryTestSuite) TestGetFacilityRatesAt_Success() {
+	at := time.Now()
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectFacilityRatesAt)).WithArgs(pq.Array([]string{"1", "2"}), at).WillReturnRows(sqlmock.NewRows([]string{"facility_id", "unit_rate"}).AddRow("1", 25000))
+
+	actual, err := suite.repo.GetFacilityRatesAt(context.Background(), []string{"1", "2"}, at)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), map[string]int64{"1": 25000}, actual)
+}
+
+func (suite *RateRepositoryTestSuite) TestGetFacilityRatesAt_EmptySuccess() {
+	actual, err := suite.repo.GetFacilityRatesAt(context.Background(), nil, time.Now())
+
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), actual)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func TestRateRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(RateRepositoryTestSuite))
+}
@@ -3,30 +3,41 @@ package repository
 import (
 	"booking-room-app/config"
 	"booking-room-app/entity/dto"
+	"context"
 	"database/sql"
-	"log"
+	"log/slog"
 	"time"
+
+	"github.com/lib/pq"
 )
 
 type ReportRepository interface {
-	List(startDate, endDate time.Time) ([]dto.ReportDto, error)
+	List(ctx context.Context, startDate, endDate time.Time) ([]dto.ReportDto, error)
+	Chargeback(ctx context.Context, startDate, endDate time.Time, period string) ([]dto.ChargebackDto, error)
 }
 
 type reportRepository struct {
-	db *sql.DB
+	db       *sql.DB
+	timeouts Timeouts
 }
 
 // List implements ReportRepository.
-func (r *reportRepository) List(startDate, endDate time.Time) ([]dto.ReportDto, error) {
+func (r *reportRepository) List(ctx context.Context, startDate, endDate time.Time) ([]dto.ReportDto, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeouts.Report)
+	defer cancel()
+
 	var reports []dto.ReportDto
 
-	rows, err := r.db.Query(config.SelectReportList, startDate, endDate)
+	rows, err := r.db.QueryContext(ctx, config.SelectReportList, startDate, endDate)
 	if err != nil {
-		log.Println("transactionsRepository.Query:", err.Error())
+		slog.ErrorContext(ctx, "transactionsRepository.Query", "err", err)
 		return nil, err
 	}
+	defer rows.Close()
+
 	for rows.Next() {
 		var report dto.ReportDto
+		var location roomLocation
 		err = rows.Scan(
 			&report.ID,
 			&report.EmployeeId,
@@ -44,36 +55,112 @@ func (r *reportRepository) List(startDate, endDate time.Time) ([]dto.ReportDto,
 			&report.StartTime,
 			&report.EndTime,
 			&report.CreatedAt,
-			&report.UpdatedAt)
+			&report.UpdatedAt,
+			&location.floorId,
+			&location.floorName,
+			&location.level,
+			&location.buildingId,
+			&location.buildingName,
+			&location.siteId,
+			&location.siteName,
+			&location.timezone)
 		if err != nil {
-			log.Println("transactionsRepository.Rows.Next():", err.Error())
+			slog.ErrorContext(ctx, "transactionsRepository.Rows.Next()", "err", err)
 			return nil, err
 		}
+		location.apply(&report.Room)
+
+		reports = append(reports, report)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	rows.Close()
+
+	if err := r.attachFacilities(ctx, reports); err != nil {
+		return nil, err
+	}
 
-		roomFacilities, err := r.db.Query(config.SelectReportFacilityByRoomID, report.RoomId)
+	return reports, nil
+}
+
+// attachFacilities loads the facilities requested by every reported
+// transaction with a single query.
+func (r *reportRepository) attachFacilities(ctx context.Context, reports []dto.ReportDto) error {
+	if len(reports) == 0 {
+		return nil
+	}
+
+	transactionIds := make([]string, len(reports))
+	for i, report := range reports {
+		transactionIds[i] = report.ID
+	}
+
+	rows, err := r.db.QueryContext(ctx, config.SelectReportFacilityByTransactionIDs, pq.Array(transactionIds))
+	if err != nil {
+		slog.ErrorContext(ctx, "transactionsRepository.Query", "err", err)
+		return err
+	}
+	defer rows.Close()
+
+	facilities := make(map[string][]dto.RoomFacilityDto)
+	for rows.Next() {
+		var transactionId string
+		var facility dto.RoomFacilityDto
+		err = rows.Scan(
+			&transactionId,
+			&facility.FacilityID,
+			&facility.Name,
+			&facility.Quantity)
 		if err != nil {
-			log.Println("transactionsRepository.Query:", err.Error())
-			return nil, err
-		}
-		for roomFacilities.Next() {
-			var roomFacility dto.RoomFacilityDto
-			err = roomFacilities.Scan(
-				&roomFacility.FacilityID,
-				&roomFacility.Name,
-				&roomFacility.Quantity)
-			if err != nil {
-				log.Println("transactionsRepository.Rows.Next():", err.Error())
-				return nil, err
-			}
-			report.RoomFacilities = append(report.RoomFacilities, roomFacility)
+			slog.ErrorContext(ctx, "transactionsRepository.Rows.Next()", "err", err)
+			return err
 		}
+		facilities[transactionId] = append(facilities[transactionId], facility)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
 
-		reports = append(reports, report)
+	for i := range reports {
+		reports[i].Facilities = facilities[reports[i].ID]
 	}
+	return nil
+}
 
-	return reports, nil
+// Chargeback implements ReportRepository.
+func (r *reportRepository) Chargeback(ctx context.Context, startDate, endDate time.Time, period string) ([]dto.ChargebackDto, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeouts.Report)
+	defer cancel()
+
+	var chargebacks []dto.ChargebackDto
+
+	rows, err := r.db.QueryContext(ctx, config.SelectChargebackByDivision, startDate, endDate, period)
+	if err != nil {
+		slog.ErrorContext(ctx, "reportRepository.ChargebackQuery", "err", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var chargeback dto.ChargebackDto
+		err = rows.Scan(
+			&chargeback.Division,
+			&chargeback.Period,
+			&chargeback.Transactions,
+			&chargeback.RoomCost,
+			&chargeback.FacilityCost,
+			&chargeback.TotalCost)
+		if err != nil {
+			slog.ErrorContext(ctx, "reportRepository.ChargebackScan", "err", err)
+			return nil, err
+		}
+		chargebacks = append(chargebacks, chargeback)
+	}
+
+	return chargebacks, rows.Err()
 }
 
-func NewReportRepository(db *sql.DB) ReportRepository {
-	return &reportRepository{db: db}
+func NewReportRepository(db *sql.DB, timeouts Timeouts) ReportRepository {
+	return &reportRepository{db: db, timeouts: timeouts}
 }
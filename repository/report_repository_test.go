@@ -1,14 +1,18 @@
 package repository
 
 import (
+	"booking-room-app/config"
 	"booking-room-app/entity"
 	"booking-room-app/entity/dto"
+	"context"
 	"database/sql"
 	"fmt"
+	"regexp"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 )
@@ -57,26 +61,27 @@ func (suite *ReportRepositoryTestSuite) SetupTest() {
 	db, mock, _ := sqlmock.New()
 	suite.mockDb = db
 	suite.mockSql = mock
-	suite.repo = NewReportRepository(suite.mockDb)
+	suite.repo = NewReportRepository(suite.mockDb, DefaultTimeouts())
 }
 
 func (suite *ReportRepositoryTestSuite) TestList_Success() {
-	rows := sqlmock.NewRows([]string{"id", "employee_id", "name", "username", "division", "position", "contact", "room_id", "name", "room_type", "capacity", "description", "status", "start_time", "end_time", "created_at", "updated_at"}).AddRow(expectedReport.ID, expectedReport.EmployeeId, expectedReport.Employee.Name, expectedReport.Employee.Username, expectedReport.Employee.Division, expectedReport.Employee.Position, expectedReport.Employee.Contact, expectedReport.RoomId, expectedReport.Room.Name, expectedReport.Room.RoomType, expectedReport.Room.Capacity, expectedReport.Description, expectedReport.Status, expectedReport.StartTime, expectedReport.EndTime, expectedReport.CreatedAt, expectedReport.UpdatedAt)
+	rows := sqlmock.NewRows([]string{"id", "employee_id", "name", "username", "division", "position", "contact", "room_id", "name", "room_type", "capacity", "description", "status", "start_time", "end_time", "created_at", "updated_at", "floor_id", "floor_name", "level", "building_id", "building_name", "site_id", "site_name", "timezone"}).AddRow(expectedReport.ID, expectedReport.EmployeeId, expectedReport.Employee.Name, expectedReport.Employee.Username, expectedReport.Employee.Division, expectedReport.Employee.Position, expectedReport.Employee.Contact, expectedReport.RoomId, expectedReport.Room.Name, expectedReport.Room.RoomType, expectedReport.Room.Capacity, expectedReport.Description, expectedReport.Status, expectedReport.StartTime, expectedReport.EndTime, expectedReport.CreatedAt, expectedReport.UpdatedAt, nil, nil, nil, nil, nil, nil, nil, nil)
 
 	suite.mockSql.ExpectQuery(`SELECT`).WithArgs(expectedReport.StartTime, expectedReport.EndTime).WillReturnRows(rows)
-	suite.mockSql.ExpectQuery(`SELECT`).WithArgs(expectedReport.RoomId).WillReturnRows(sqlmock.NewRows([]string{"facility_id", "name", "quantity"}).AddRow(expectedRoomFacilityty.FacilityID, expectedRoomFacilityty.Name, expectedRoomFacilityty.Quantity))
+	suite.mockSql.ExpectQuery(`SELECT`).WithArgs(pq.Array([]string{expectedReport.ID})).WillReturnRows(sqlmock.NewRows([]string{"transaction_id", "facility_id", "name", "quantity"}).AddRow(expectedReport.ID, expectedRoomFacilityty.FacilityID, expectedRoomFacilityty.Name, expectedRoomFacilityty.Quantity))
 
-	actual, err := suite.repo.List(expectedReport.StartTime, expectedReport.EndTime)
+	actual, err := suite.repo.List(context.Background(), expectedReport.StartTime, expectedReport.EndTime)
 
 	assert.Nil(suite.T(), err)
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), expectedReport.ID, actual[0].ID)
+	assert.Equal(suite.T(), []dto.RoomFacilityDto{expectedRoomFacilityty}, actual[0].Facilities)
 }
 
 func (suite *ReportRepositoryTestSuite) TestList_Failure() {
 	suite.mockSql.ExpectQuery(`SELECT`).WithArgs(expectedReport.StartTime, expectedReport.EndTime).WillReturnError(fmt.Errorf("error"))
 
-	_, err := suite.repo.List(expectedReport.StartTime, expectedReport.EndTime)
+	_, err := suite.repo.List(context.Background(), expectedReport.StartTime, expectedReport.EndTime)
 
 	assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
@@ -85,35 +90,109 @@ func (suite *ReportRepositoryTestSuite) TestList_Failure() {
 func (suite *ReportRepositoryTestSuite) TestList_ScanFailure() {
 	suite.mockSql.ExpectQuery(`SELECT`).WithArgs(expectedReport.StartTime, expectedReport.EndTime).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(expectedReport.ID))
 
-	_, err := suite.repo.List(expectedReport.StartTime, expectedReport.EndTime)
+	_, err := suite.repo.List(context.Background(), expectedReport.StartTime, expectedReport.EndTime)
 	assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
 }
 
 func (suite *ReportRepositoryTestSuite) TestList_RoomFacilityFailure() {
-	rows := sqlmock.NewRows([]string{"id", "employee_id", "name", "username", "division", "position", "contact", "room_id", "name", "room_type", "capacity", "description", "status", "start_time", "end_time", "created_at", "updated_at"}).AddRow(expectedReport.ID, expectedReport.EmployeeId, expectedReport.Employee.Name, expectedReport.Employee.Username, expectedReport.Employee.Division, expectedReport.Employee.Position, expectedReport.Employee.Contact, expectedReport.RoomId, expectedReport.Room.Name, expectedReport.Room.RoomType, expectedReport.Room.Capacity, expectedReport.Description, expectedReport.Status, expectedReport.StartTime, expectedReport.EndTime, expectedReport.CreatedAt, expectedReport.UpdatedAt)
+	rows := sqlmock.NewRows([]string{"id", "employee_id", "name", "username", "division", "position", "contact", "room_id", "name", "room_type", "capacity", "description", "status", "start_time", "end_time", "created_at", "updated_at", "floor_id", "floor_name", "level", "building_id", "building_name", "site_id", "site_name", "timezone"}).AddRow(expectedReport.ID, expectedReport.EmployeeId, expectedReport.Employee.Name, expectedReport.Employee.Username, expectedReport.Employee.Division, expectedReport.Employee.Position, expectedReport.Employee.Contact, expectedReport.RoomId, expectedReport.Room.Name, expectedReport.Room.RoomType, expectedReport.Room.Capacity, expectedReport.Description, expectedReport.Status, expectedReport.StartTime, expectedReport.EndTime, expectedReport.CreatedAt, expectedReport.UpdatedAt, nil, nil, nil, nil, nil, nil, nil, nil)
 
 	suite.mockSql.ExpectQuery(`SELECT`).WithArgs(expectedReport.StartTime, expectedReport.EndTime).WillReturnRows(rows)
-	suite.mockSql.ExpectQuery(`SELECT`).WithArgs(expectedReport.RoomId).WillReturnError(fmt.Errorf("error"))
+	suite.mockSql.ExpectQuery(`SELECT`).WithArgs(pq.Array([]string{expectedReport.ID})).WillReturnError(fmt.Errorf("error"))
 
-	_, err := suite.repo.List(expectedReport.StartTime, expectedReport.EndTime)
+	_, err := suite.repo.List(context.Background(), expectedReport.StartTime, expectedReport.EndTime)
 
 	assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
 }
 
 func (suite *ReportRepositoryTestSuite) TestList_ScanRoomFacilityFailure() {
-	rows := sqlmock.NewRows([]string{"id", "employee_id", "name", "username", "division", "position", "contact", "room_id", "name", "room_type", "capacity", "description", "status", "start_time", "end_time", "created_at", "updated_at"}).AddRow(expectedReport.ID, expectedReport.EmployeeId, expectedReport.Employee.Name, expectedReport.Employee.Username, expectedReport.Employee.Division, expectedReport.Employee.Position, expectedReport.Employee.Contact, expectedReport.RoomId, expectedReport.Room.Name, expectedReport.Room.RoomType, expectedReport.Room.Capacity, expectedReport.Description, expectedReport.Status, expectedReport.StartTime, expectedReport.EndTime, expectedReport.CreatedAt, expectedReport.UpdatedAt)
+	rows := sqlmock.NewRows([]string{"id", "employee_id", "name", "username", "division", "position", "contact", "room_id", "name", "room_type", "capacity", "description", "status", "start_time", "end_time", "created_at", "updated_at", "floor_id", "floor_name", "level", "building_id", "building_name", "site_id", "site_name", "timezone"}).AddRow(expectedReport.ID, expectedReport.EmployeeId, expectedReport.Employee.Name, expectedReport.Employee.Username, expectedReport.Employee.Division, expectedReport.Employee.Position, expectedReport.Employee.Contact, expectedReport.RoomId, expectedReport.Room.Name, expectedReport.Room.RoomType, expectedReport.Room.Capacity, expectedReport.Description, expectedReport.Status, expectedReport.StartTime, expectedReport.EndTime, expectedReport.CreatedAt, expectedReport.UpdatedAt, nil, nil, nil, nil, nil, nil, nil, nil)
 
 	suite.mockSql.ExpectQuery(`SELECT`).WithArgs(expectedReport.StartTime, expectedReport.EndTime).WillReturnRows(rows)
-	suite.mockSql.ExpectQuery(`SELECT`).WithArgs(expectedReport.RoomId).WillReturnRows(sqlmock.NewRows([]string{"facility_id"}).AddRow(expectedRoomFacilityty.FacilityID))
+	suite.mockSql.ExpectQuery(`SELECT`).WithArgs(pq.Array([]string{expectedReport.ID})).WillReturnRows(sqlmock.NewRows([]string{"facility_id"}).AddRow(expectedRoomFacilityty.FacilityID))
 
-	_, err := suite.repo.List(expectedReport.StartTime, expectedReport.EndTime)
+	_, err := suite.repo.List(context.Background(), expectedReport.StartTime, expectedReport.EndTime)
 
 	assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
 }
 
+// reportListMock prepares size reports, each in its own room, and counts every
+// statement the repository sends.
+func reportListMock(size int) (*sql.DB, sqlmock.Sqlmock, *int) {
+	queries := 0
+	matcher := sqlmock.QueryMatcherFunc(func(expectedSQL, actualSQL string) error {
+		queries++
+		return sqlmock.QueryMatcherRegexp.Match(expectedSQL, actualSQL)
+	})
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(matcher))
+
+	rows := sqlmock.NewRows([]string{"id", "employee_id", "name", "username", "division", "position", "contact", "room_id", "name", "room_type", "capacity", "description", "status", "start_time", "end_time", "created_at", "updated_at", "floor_id", "floor_name", "level", "building_id", "building_name", "site_id", "site_name", "timezone"})
+	facilities := sqlmock.NewRows([]string{"transaction_id", "facility_id", "name", "quantity"})
+	for i := 0; i < size; i++ {
+		rows.AddRow(fmt.Sprint(i), "1", employee.Name, employee.Username, employee.Division, employee.Position, employee.Contact, fmt.Sprintf("room-%d", i), room.Name, room.RoomType, room.Capacity, "", "pending", time.Now(), time.Now(), time.Now(), time.Now(), nil, nil, nil, nil, nil, nil, nil, nil)
+		facilities.AddRow(fmt.Sprint(i), "1", "LED Proyektor", 1)
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta(config.SelectReportList)).WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnRows(rows)
+	mock.ExpectQuery(regexp.QuoteMeta(config.SelectReportFacilityByTransactionIDs)).WithArgs(sqlmock.AnyArg()).WillReturnRows(facilities)
+	return db, mock, &queries
+}
+
+func (suite *ReportRepositoryTestSuite) TestList_QueryCountIndependentOfRows() {
+	for _, size := range []int{1, 5, 50} {
+		db, mock, queries := reportListMock(size)
+
+		actual, err := NewReportRepository(db, DefaultTimeouts()).List(context.Background(), time.Now(), time.Now())
+
+		assert.NoError(suite.T(), err)
+		assert.Len(suite.T(), actual, size)
+		assert.Len(suite.T(), actual[size-1].Facilities, 1)
+		assert.Equal(suite.T(), 2, *queries)
+		assert.NoError(suite.T(), mock.ExpectationsWereMet())
+	}
+}
+
+func BenchmarkReportRepositoryList(b *testing.B) {
+	for _, size := range []int{5, 50, 500} {
+		b.Run(fmt.Sprintf("size=%d", size), func(b *testing.B) {
+			queries := 0
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				db, _, count := reportListMock(size)
+				repo := NewReportRepository(db, DefaultTimeouts())
+				b.StartTimer()
+
+				if _, err := repo.List(context.Background(), time.Now(), time.Now()); err != nil {
+					b.Fatal(err)
+				}
+				queries += *count
+			}
+			b.ReportMetric(float64(queries)/float64(b.N), "queries/op")
+		})
+	}
+}
+
+func (suite *ReportRepositoryTestSuite) TestChargeback_Success() {
+	period := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectChargebackByDivision)).WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), "month").WillReturnRows(sqlmock.NewRows([]string{"division", "period", "count", "room_cost", "facility_cost", "total_cost"}).AddRow("IT", period, 2, 300000, 50000, 350000))
+
+	actual, err := suite.repo.Chargeback(context.Background(), period, period.AddDate(0, 1, 0), "month")
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []dto.ChargebackDto{{Division: "IT", Period: period, Transactions: 2, RoomCost: 300000, FacilityCost: 50000, TotalCost: 350000}}, actual)
+}
+
+func (suite *ReportRepositoryTestSuite) TestChargeback_Failure() {
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectChargebackByDivision)).WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), "month").WillReturnError(fmt.Errorf("error"))
+
+	_, err := suite.repo.Chargeback(context.Background(), time.Now(), time.Now(), "month")
+
+	assert.Error(suite.T(), err)
+}
+
 func TestReportRepositoryTestSuite(t *testing.T) {
 	suite.Run(t, new(ReportRepositoryTestSuite))
 }
@@ -0,0 +1,245 @@
+package repository
+
+import (
+	"booking-room-app/config"
+	"booking-room-app/entity"
+	"booking-room-app/shared/model"
+	"context"
+	"database/sql"
+	"errors"
+	"log/slog"
+	"math"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+type ReportScheduleRepository interface {
+	Create(ctx context.Context, payload entity.ReportSchedule) (entity.ReportSchedule, error)
+	Get(ctx context.Context, id string) (entity.ReportSchedule, error)
+	List(ctx context.Context, page, size int) ([]entity.ReportSchedule, model.Paging, error)
+	ListActive(ctx context.Context) ([]entity.ReportSchedule, error)
+	Update(ctx context.Context, payload entity.ReportSchedule) (entity.ReportSchedule, error)
+	ClaimRun(ctx context.Context, id string, runAt time.Time) (bool, error)
+	Delete(ctx context.Context, id string) error
+}
+
+type reportScheduleRepository struct {
+	db       *sql.DB
+	timeouts Timeouts
+}
+
+// Create implements ReportScheduleRepository.
+func (r *reportScheduleRepository) Create(ctx context.Context, payload entity.ReportSchedule) (entity.ReportSchedule, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeouts.Query)
+	defer cancel()
+
+	err := r.db.QueryRowContext(ctx, config.InsertReportSchedule,
+		payload.Name,
+		payload.CronExpression,
+		payload.Range,
+		payload.Filter.Status,
+		payload.Filter.Division,
+		payload.Filter.RoomId,
+		payload.Filter.SiteId,
+		payload.Filter.BuildingId,
+		payload.Filter.FloorId,
+		payload.Format,
+		pq.Array(payload.Recipients),
+		payload.IsActive).Scan(&payload.ID, &payload.CreatedAt, &payload.UpdatedAt)
+	if err != nil {
+		slog.ErrorContext(ctx, "reportScheduleRepository.CreateQueryRow", "err", err)
+		return entity.ReportSchedule{}, err
+	}
+
+	return payload, nil
+}
+
+// Get implements ReportScheduleRepository.
+func (r *reportScheduleRepository) Get(ctx context.Context, id string) (entity.ReportSchedule, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeouts.Query)
+	defer cancel()
+
+	schedule, err := scanReportSchedule(r.db.QueryRowContext(ctx, config.SelectReportScheduleByID, id))
+	if err != nil {
+		slog.ErrorContext(ctx, "reportScheduleRepository.GetQueryRow", "err", err)
+		return entity.ReportSchedule{}, err
+	}
+
+	return schedule, nil
+}
+
+// List implements ReportScheduleRepository.
+func (r *reportScheduleRepository) List(ctx context.Context, page, size int) ([]entity.ReportSchedule, model.Paging, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeouts.Query)
+	defer cancel()
+
+	var schedules []entity.ReportSchedule
+	offset := (page - 1) * size
+
+	rows, err := r.db.QueryContext(ctx, config.SelectReportScheduleList, size, offset)
+	if err != nil {
+		slog.ErrorContext(ctx, "reportScheduleRepository.ListQuery", "err", err)
+		return nil, model.Paging{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		schedule, err := scanReportSchedule(rows)
+		if err != nil {
+			slog.ErrorContext(ctx, "reportScheduleRepository.ListScan", "err", err)
+			return nil, model.Paging{}, err
+		}
+		schedules = append(schedules, schedule)
+	}
+
+	totalRows := 0
+	if err := r.db.QueryRowContext(ctx, config.SelectCountReportSchedule).Scan(&totalRows); err != nil {
+		return nil, model.Paging{}, err
+	}
+
+	paging := model.Paging{
+		Page:        page,
+		RowsPerPage: size,
+		TotalRows:   totalRows,
+		TotalPages:  int(math.Ceil(float64(totalRows) / float64(size))),
+	}
+
+	return schedules, paging, nil
+}
+
+// ListActive implements ReportScheduleRepository.
+func (r *reportScheduleRepository) ListActive(ctx context.Context) ([]entity.ReportSchedule, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeouts.Query)
+	defer cancel()
+
+	var schedules []entity.ReportSchedule
+
+	rows, err := r.db.QueryContext(ctx, config.SelectActiveReportSchedule)
+	if err != nil {
+		slog.ErrorContext(ctx, "reportScheduleRepository.ListActiveQuery", "err", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		schedule, err := scanReportSchedule(rows)
+		if err != nil {
+			slog.ErrorContext(ctx, "reportScheduleRepository.ListActiveScan", "err", err)
+			return nil, err
+		}
+		schedules = append(schedules, schedule)
+	}
+
+	return schedules, nil
+}
+
+// Update implements ReportScheduleRepository.
+func (r *reportScheduleRepository) Update(ctx context.Context, payload entity.ReportSchedule) (entity.ReportSchedule, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeouts.Query)
+	defer cancel()
+
+	var lastRunAt sql.NullTime
+	err := r.db.QueryRowContext(ctx, config.UpdateReportSchedule,
+		payload.Name,
+		payload.CronExpression,
+		payload.Range,
+		payload.Filter.Status,
+		payload.Filter.Division,
+		payload.Filter.RoomId,
+		payload.Filter.SiteId,
+		payload.Filter.BuildingId,
+		payload.Filter.FloorId,
+		payload.Format,
+		pq.Array(payload.Recipients),
+		payload.IsActive,
+		payload.ID).Scan(&lastRunAt, &payload.CreatedAt, &payload.UpdatedAt)
+	if err != nil {
+		slog.ErrorContext(ctx, "reportScheduleRepository.UpdateQueryRow", "err", err)
+		return entity.ReportSchedule{}, err
+	}
+
+	payload.LastRunAt = nil
+	if lastRunAt.Valid {
+		payload.LastRunAt = &lastRunAt.Time
+	}
+
+	return payload, nil
+}
+
+// ClaimRun implements ReportScheduleRepository. It sets the last run of the
+// schedule to runAt unless it already ran then, and reports whether it did,
+// so that only one instance delivers a run.
+func (r *reportScheduleRepository) ClaimRun(ctx context.Context, id string, runAt time.Time) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeouts.Query)
+	defer cancel()
+
+	err := r.db.QueryRowContext(ctx, config.UpdateReportScheduleLastRun, id, runAt).Scan(&id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "reportScheduleRepository.ClaimRunQueryRow", "err", err)
+		return false, err
+	}
+	return true, nil
+}
+
+// Delete implements ReportScheduleRepository.
+func (r *reportScheduleRepository) Delete(ctx context.Context, id string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeouts.Query)
+	defer cancel()
+
+	result, err := r.db.ExecContext(ctx, config.DeleteReportSchedule, id)
+	if err != nil {
+		slog.ErrorContext(ctx, "reportScheduleRepository.DeleteExec", "err", err)
+		return err
+	}
+
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if affected == 0 {
+		return sql.ErrNoRows
+	}
+	return nil
+}
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanReportSchedule(row rowScanner) (entity.ReportSchedule, error) {
+	var schedule entity.ReportSchedule
+	var lastRunAt sql.NullTime
+	err := row.Scan(
+		&schedule.ID,
+		&schedule.Name,
+		&schedule.CronExpression,
+		&schedule.Range,
+		&schedule.Filter.Status,
+		&schedule.Filter.Division,
+		&schedule.Filter.RoomId,
+		&schedule.Filter.SiteId,
+		&schedule.Filter.BuildingId,
+		&schedule.Filter.FloorId,
+		&schedule.Format,
+		pq.Array(&schedule.Recipients),
+		&schedule.IsActive,
+		&lastRunAt,
+		&schedule.CreatedAt,
+		&schedule.UpdatedAt)
+	if err != nil {
+		return entity.ReportSchedule{}, err
+	}
+
+	if lastRunAt.Valid {
+		schedule.LastRunAt = &lastRunAt.Time
+	}
+	return schedule, nil
+}
+
+func NewReportScheduleRepository(db *sql.DB, timeouts Timeouts) ReportScheduleRepository {
+	return &reportScheduleRepository{db: db, timeouts: timeouts}
+}
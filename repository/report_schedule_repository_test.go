@@ -0,0 +1,179 @@
+package repository
+
+import (
+	"booking-room-app/entity"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+var expectedReportSchedule = entity.ReportSchedule{
+	ID:             "1",
+	Name:           "Weekly booking report",
+	CronExpression: "0 8 * * 1",
+	Range:          "week",
+	Filter:         entity.ReportFilter{Status: "accepted"},
+	Format:         "csv",
+	Recipients:     []string{"manager@example.com", "finance@example.com"},
+	IsActive:       true,
+	CreatedAt:      time.Now(),
+	UpdatedAt:      time.Now(),
+}
+
+var reportScheduleColumns = []string{"id", "name", "cron_expression", "range_param", "filter_status", "filter_division", "filter_room_id", "filter_site_id", "filter_building_id", "filter_floor_id", "format", "recipients", "is_active", "last_run_at", "created_at", "updated_at"}
+
+func reportScheduleRow(rows *sqlmock.Rows, s entity.ReportSchedule) *sqlmock.Rows {
+	return rows.AddRow(s.ID, s.Name, s.CronExpression, s.Range, s.Filter.Status, s.Filter.Division, s.Filter.RoomId, s.Filter.SiteId, s.Filter.BuildingId, s.Filter.FloorId, s.Format, "{manager@example.com,finance@example.com}", s.IsActive, nil, s.CreatedAt, s.UpdatedAt)
+}
+
+func anyArgs(n int) []driver.Value {
+	args := make([]driver.Value, n)
+	for i := range args {
+		args[i] = sqlmock.AnyArg()
+	}
+	return args
+}
+
+type ReportScheduleRepositoryTestSuite struct {
+	suite.Suite
+	mockDb  *sql.DB
+	mockSql sqlmock.Sqlmock
+	repo    ReportScheduleRepository
+}
+
+func (suite *ReportScheduleRepositoryTestSuite) SetupTest() {
+	db, mock, _ := sqlmock.New()
+	suite.mockDb = db
+	suite.mockSql = mock
+	suite.repo = NewReportScheduleRepository(suite.mockDb, DefaultTimeouts())
+}
+
+func (suite *ReportScheduleRepositoryTestSuite) TestCreate_Success() {
+	suite.mockSql.ExpectQuery(`INSERT INTO report_schedules`).WithArgs(anyArgs(12)...).WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow(expectedReportSchedule.ID, expectedReportSchedule.CreatedAt, expectedReportSchedule.UpdatedAt))
+
+	actual, err := suite.repo.Create(context.Background(), expectedReportSchedule)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expectedReportSchedule.ID, actual.ID)
+	assert.Equal(suite.T(), expectedReportSchedule.Recipients, actual.Recipients)
+}
+
+func (suite *ReportScheduleRepositoryTestSuite) TestCreate_Failure() {
+	suite.mockSql.ExpectQuery(`INSERT INTO report_schedules`).WithArgs(anyArgs(12)...).WillReturnError(fmt.Errorf("error"))
+
+	_, err := suite.repo.Create(context.Background(), expectedReportSchedule)
+
+	assert.Error(suite.T(), err)
+}
+
+func (suite *ReportScheduleRepositoryTestSuite) TestGet_Success() {
+	suite.mockSql.ExpectQuery(`SELECT (.+) FROM report_schedules WHERE id`).WithArgs(expectedReportSchedule.ID).WillReturnRows(reportScheduleRow(sqlmock.NewRows(reportScheduleColumns), expectedReportSchedule))
+
+	actual, err := suite.repo.Get(context.Background(), expectedReportSchedule.ID)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expectedReportSchedule.Recipients, actual.Recipients)
+	assert.Nil(suite.T(), actual.LastRunAt)
+}
+
+func (suite *ReportScheduleRepositoryTestSuite) TestGet_Failure() {
+	suite.mockSql.ExpectQuery(`SELECT (.+) FROM report_schedules WHERE id`).WithArgs(expectedReportSchedule.ID).WillReturnError(sql.ErrNoRows)
+
+	_, err := suite.repo.Get(context.Background(), expectedReportSchedule.ID)
+
+	assert.Error(suite.T(), err)
+}
+
+func (suite *ReportScheduleRepositoryTestSuite) TestList_Success() {
+	suite.mockSql.ExpectQuery(`SELECT (.+) FROM report_schedules ORDER BY`).WithArgs(5, 0).WillReturnRows(reportScheduleRow(sqlmock.NewRows(reportScheduleColumns), expectedReportSchedule))
+	suite.mockSql.ExpectQuery(`SELECT COUNT`).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	actual, paging, err := suite.repo.List(context.Background(), 1, 5)
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), actual, 1)
+	assert.Equal(suite.T(), 1, paging.TotalPages)
+}
+
+func (suite *ReportScheduleRepositoryTestSuite) TestList_ScanFailure() {
+	suite.mockSql.ExpectQuery(`SELECT (.+) FROM report_schedules ORDER BY`).WithArgs(5, 0).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("1"))
+
+	_, _, err := suite.repo.List(context.Background(), 1, 5)
+
+	assert.Error(suite.T(), err)
+}
+
+func (suite *ReportScheduleRepositoryTestSuite) TestListActive_Success() {
+	suite.mockSql.ExpectQuery(`SELECT (.+) FROM report_schedules WHERE is_active`).WillReturnRows(reportScheduleRow(sqlmock.NewRows(reportScheduleColumns), expectedReportSchedule))
+
+	actual, err := suite.repo.ListActive(context.Background())
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), actual, 1)
+}
+
+func (suite *ReportScheduleRepositoryTestSuite) TestUpdate_Success() {
+	lastRunAt := time.Now()
+	suite.mockSql.ExpectQuery(`UPDATE report_schedules`).WithArgs(anyArgs(13)...).WillReturnRows(sqlmock.NewRows([]string{"last_run_at", "created_at", "updated_at"}).AddRow(lastRunAt, expectedReportSchedule.CreatedAt, expectedReportSchedule.UpdatedAt))
+
+	actual, err := suite.repo.Update(context.Background(), expectedReportSchedule)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), lastRunAt, *actual.LastRunAt)
+}
+
+func (suite *ReportScheduleRepositoryTestSuite) TestClaimRun_Success() {
+	runAt := time.Now()
+	suite.mockSql.ExpectQuery(`UPDATE report_schedules SET last_run_at`).WithArgs(expectedReportSchedule.ID, runAt).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(expectedReportSchedule.ID))
+
+	claimed, err := suite.repo.ClaimRun(context.Background(), expectedReportSchedule.ID, runAt)
+
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), claimed)
+}
+
+func (suite *ReportScheduleRepositoryTestSuite) TestClaimRun_AlreadyClaimedSuccess() {
+	runAt := time.Now()
+	suite.mockSql.ExpectQuery(`UPDATE report_schedules SET last_run_at`).WithArgs(expectedReportSchedule.ID, runAt).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	claimed, err := suite.repo.ClaimRun(context.Background(), expectedReportSchedule.ID, runAt)
+
+	assert.NoError(suite.T(), err)
+	assert.False(suite.T(), claimed)
+}
+
+func (suite *ReportScheduleRepositoryTestSuite) TestClaimRun_Failure() {
+	suite.mockSql.ExpectQuery(`UPDATE report_schedules SET last_run_at`).WillReturnError(fmt.Errorf("error"))
+
+	claimed, err := suite.repo.ClaimRun(context.Background(), expectedReportSchedule.ID, time.Now())
+
+	assert.Error(suite.T(), err)
+	assert.False(suite.T(), claimed)
+}
+
+func (suite *ReportScheduleRepositoryTestSuite) TestDelete_Success() {
+	suite.mockSql.ExpectExec(`DELETE FROM report_schedules`).WithArgs(expectedReportSchedule.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := suite.repo.Delete(context.Background(), expectedReportSchedule.ID)
+
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *ReportScheduleRepositoryTestSuite) TestDelete_NotFoundFailure() {
+	suite.mockSql.ExpectExec(`DELETE FROM report_schedules`).WithArgs(expectedReportSchedule.ID).WillReturnResult(sqlmock.NewResult(0, 0))
+
+	err := suite.repo.Delete(context.Background(), expectedReportSchedule.ID)
+
+	assert.ErrorIs(suite.T(), err, sql.ErrNoRows)
+}
+
+func TestReportScheduleRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(ReportScheduleRepositoryTestSuite))
+}
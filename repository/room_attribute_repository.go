@@ -0,0 +1,235 @@
+package repository
+
+import (
+	"booking-room-app/config"
+	"booking-room-app/entity"
+	"context"
+	"database/sql"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// OptionsInUseError is returned when an update removes enum options that
+// rooms still have.
+type OptionsInUseError struct {
+	Options []string
+}
+
+func (e *OptionsInUseError) Error() string {
+	return "rooms still use the options " + strings.Join(e.Options, ", ")
+}
+
+type RoomAttributeRepository interface {
+	Create(ctx context.Context, payload entity.RoomAttribute) (entity.RoomAttribute, error)
+	Get(ctx context.Context, id string) (entity.RoomAttribute, error)
+	List(ctx context.Context) ([]entity.RoomAttribute, error)
+	Update(ctx context.Context, payload entity.RoomAttribute) (entity.RoomAttribute, error)
+	Delete(ctx context.Context, id string) error
+	ListValues(ctx context.Context, roomIds []string) ([]entity.RoomAttributeValue, error)
+	ReplaceValues(ctx context.Context, roomId string, values []entity.RoomAttributeValue) error
+}
+
+type roomAttributeRepository struct {
+	db       *sql.DB
+	timeouts Timeouts
+}
+
+// Create implements RoomAttributeRepository.
+func (r *roomAttributeRepository) Create(ctx context.Context, payload entity.RoomAttribute) (entity.RoomAttribute, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeouts.Query)
+	defer cancel()
+
+	err := r.db.QueryRowContext(ctx, config.InsertRoomAttribute, payload.Key, payload.Name, payload.Type, pq.Array(options(payload.Options))).Scan(&payload.ID, &payload.CreatedAt, &payload.UpdatedAt)
+	if err != nil {
+		slog.ErrorContext(ctx, "roomAttributeRepository.CreateQueryRow", "err", err)
+		return entity.RoomAttribute{}, err
+	}
+
+	return payload, nil
+}
+
+// Get implements RoomAttributeRepository.
+func (r *roomAttributeRepository) Get(ctx context.Context, id string) (entity.RoomAttribute, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeouts.Query)
+	defer cancel()
+
+	var attribute entity.RoomAttribute
+	err := r.db.QueryRowContext(ctx, config.SelectRoomAttributeByID, id).Scan(&attribute.ID, &attribute.Key, &attribute.Name, &attribute.Type, pq.Array(&attribute.Options), &attribute.CreatedAt, &attribute.UpdatedAt)
+	if err != nil {
+		slog.ErrorContext(ctx, "roomAttributeRepository.GetQueryRow", "err", err)
+		return entity.RoomAttribute{}, err
+	}
+	return attribute, nil
+}
+
+// List implements RoomAttributeRepository.
+func (r *roomAttributeRepository) List(ctx context.Context) ([]entity.RoomAttribute, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeouts.Query)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, config.SelectRoomAttributeList)
+	if err != nil {
+		slog.ErrorContext(ctx, "roomAttributeRepository.ListQuery", "err", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	attributes := []entity.RoomAttribute{}
+	for rows.Next() {
+		var attribute entity.RoomAttribute
+		if err := rows.Scan(&attribute.ID, &attribute.Key, &attribute.Name, &attribute.Type, pq.Array(&attribute.Options), &attribute.CreatedAt, &attribute.UpdatedAt); err != nil {
+			slog.ErrorContext(ctx, "roomAttributeRepository.ListScan", "err", err)
+			return nil, err
+		}
+		attributes = append(attributes, attribute)
+	}
+	return attributes, rows.Err()
+}
+
+// Update implements RoomAttributeRepository. The key and type of an
+// attribute cannot be changed, and an enum keeps every option that rooms
+// still have.
+func (r *roomAttributeRepository) Update(ctx context.Context, payload entity.RoomAttribute) (entity.RoomAttribute, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeouts.Query)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		slog.ErrorContext(ctx, "roomAttributeRepository.UpdateBeginTransaction", "err", err)
+		return entity.RoomAttribute{}, err
+	}
+
+	var attributeType string
+	if err := tx.QueryRowContext(ctx, config.LockRoomAttribute, payload.ID).Scan(&attributeType); err != nil {
+		slog.ErrorContext(ctx, "roomAttributeRepository.UpdateLock", "err", err)
+		tx.Rollback()
+		return entity.RoomAttribute{}, err
+	}
+
+	if attributeType == entity.AttributeEnum {
+		rows, err := tx.QueryContext(ctx, config.SelectUnlistedOptions, payload.ID, pq.Array(options(payload.Options)))
+		if err != nil {
+			slog.ErrorContext(ctx, "roomAttributeRepository.UpdateUnlistedOptions", "err", err)
+			tx.Rollback()
+			return entity.RoomAttribute{}, err
+		}
+		var unlisted []string
+		for rows.Next() {
+			var option string
+			if err := rows.Scan(&option); err != nil {
+				rows.Close()
+				tx.Rollback()
+				return entity.RoomAttribute{}, err
+			}
+			unlisted = append(unlisted, option)
+		}
+		rows.Close()
+		if len(unlisted) > 0 {
+			tx.Rollback()
+			return entity.RoomAttribute{}, &OptionsInUseError{Options: unlisted}
+		}
+	}
+
+	err = tx.QueryRowContext(ctx, config.UpdateRoomAttribute, payload.ID, payload.Name, pq.Array(options(payload.Options))).Scan(&payload.Key, &payload.Type, &payload.CreatedAt, &payload.UpdatedAt)
+	if err != nil {
+		slog.ErrorContext(ctx, "roomAttributeRepository.UpdateQueryRow", "err", err)
+		tx.Rollback()
+		return entity.RoomAttribute{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		slog.ErrorContext(ctx, "roomAttributeRepository.UpdateCommit", "err", err)
+		return entity.RoomAttribute{}, err
+	}
+	return payload, nil
+}
+
+// Delete implements RoomAttributeRepository. The values of the attribute go
+// with it.
+func (r *roomAttributeRepository) Delete(ctx context.Context, id string) error {
+	return deleteByID(ctx, r.db, r.timeouts.Query, config.DeleteRoomAttribute, id)
+}
+
+// ListValues implements RoomAttributeRepository.
+func (r *roomAttributeRepository) ListValues(ctx context.Context, roomIds []string) ([]entity.RoomAttributeValue, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeouts.Query)
+	defer cancel()
+
+	rows, err := r.db.QueryContext(ctx, config.SelectRoomAttributeValues, pq.Array(roomIds))
+	if err != nil {
+		slog.ErrorContext(ctx, "roomAttributeRepository.ListValuesQuery", "err", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var values []entity.RoomAttributeValue
+	for rows.Next() {
+		var value entity.RoomAttributeValue
+		if err := rows.Scan(&value.RoomId, &value.AttributeId, &value.Key, &value.Type, &value.Value); err != nil {
+			slog.ErrorContext(ctx, "roomAttributeRepository.ListValuesScan", "err", err)
+			return nil, err
+		}
+		values = append(values, value)
+	}
+	return values, rows.Err()
+}
+
+// ReplaceValues implements RoomAttributeRepository. In one database
+// transaction the values of the room are replaced by values; an archived
+// room is reported as missing.
+func (r *roomAttributeRepository) ReplaceValues(ctx context.Context, roomId string, values []entity.RoomAttributeValue) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeouts.Query)
+	defer cancel()
+
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		slog.ErrorContext(ctx, "roomAttributeRepository.ReplaceValuesBeginTransaction", "err", err)
+		return err
+	}
+
+	var archivedAt *time.Time
+	if err := tx.QueryRowContext(ctx, config.LockRoom, roomId).Scan(&archivedAt); err != nil {
+		slog.ErrorContext(ctx, "roomAttributeRepository.ReplaceValuesLock", "err", err)
+		tx.Rollback()
+		return err
+	}
+	if archivedAt != nil {
+		tx.Rollback()
+		return sql.ErrNoRows
+	}
+
+	if _, err := tx.ExecContext(ctx, config.DeleteRoomAttributeValues, roomId); err != nil {
+		slog.ErrorContext(ctx, "roomAttributeRepository.ReplaceValuesDelete", "err", err)
+		tx.Rollback()
+		return err
+	}
+
+	for _, value := range values {
+		if _, err := tx.ExecContext(ctx, config.InsertRoomAttributeValue, roomId, value.AttributeId, value.Value); err != nil {
+			slog.ErrorContext(ctx, "roomAttributeRepository.ReplaceValuesInsert", "err", err)
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		slog.ErrorContext(ctx, "roomAttributeRepository.ReplaceValuesCommit", "err", err)
+		return err
+	}
+	return nil
+}
+
+// options keeps the NOT NULL options column an empty array instead of NULL.
+func options(values []string) []string {
+	if values == nil {
+		return []string{}
+	}
+	return values
+}
+
+func NewRoomAttributeRepository(db *sql.DB, timeouts Timeouts) RoomAttributeRepository {
+	return &roomAttributeRepository{db: db, timeouts: timeouts}
+}
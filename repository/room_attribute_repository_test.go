@@ -0,0 +1,116 @@
+package repository
+
+import (
+	"booking-room-app/config"
+	"booking-room-app/entity"
+	"context"
+	"database/sql"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type RoomAttributeRepositoryTestSuite struct {
+	suite.Suite
+	mockDb  *sql.DB
+	mockSql sqlmock.Sqlmock
+	repo    RoomAttributeRepository
+}
+
+func (suite *RoomAttributeRepositoryTestSuite) SetupTest() {
+	db, mock, _ := sqlmock.New()
+	suite.mockDb = db
+	suite.mockSql = mock
+	suite.repo = NewRoomAttributeRepository(suite.mockDb, DefaultTimeouts())
+}
+
+func (suite *RoomAttributeRepositoryTestSuite) TestCreate_Success() {
+	now := time.Now()
+	payload := entity.RoomAttribute{Key: "whiteboard", Name: "Whiteboard", Type: "boolean"}
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.InsertRoomAttribute)).WithArgs("whiteboard", "Whiteboard", "boolean", pq.Array([]string{})).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow("1", now, now))
+
+	actual, err := suite.repo.Create(context.Background(), payload)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "1", actual.ID)
+}
+
+func (suite *RoomAttributeRepositoryTestSuite) TestList_Success() {
+	now := time.Now()
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectRoomAttributeList)).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "key", "name", "type", "options", "created_at", "updated_at"}).
+			AddRow("1", "layout", "Layout", "enum", "{boardroom,classroom}", now, now))
+
+	actual, err := suite.repo.List(context.Background())
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"boardroom", "classroom"}, actual[0].Options)
+}
+
+func (suite *RoomAttributeRepositoryTestSuite) TestUpdate_OptionsInUseFail() {
+	payload := entity.RoomAttribute{ID: "1", Name: "Layout", Options: []string{"boardroom"}}
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.LockRoomAttribute)).WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{"type"}).AddRow("enum"))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectUnlistedOptions)).WithArgs("1", pq.Array([]string{"boardroom"})).
+		WillReturnRows(sqlmock.NewRows([]string{"value"}).AddRow("classroom"))
+	suite.mockSql.ExpectRollback()
+
+	_, err := suite.repo.Update(context.Background(), payload)
+
+	var inUse *OptionsInUseError
+	assert.True(suite.T(), errors.As(err, &inUse))
+	assert.Equal(suite.T(), []string{"classroom"}, inUse.Options)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *RoomAttributeRepositoryTestSuite) TestUpdate_Success() {
+	now := time.Now()
+	payload := entity.RoomAttribute{ID: "1", Name: "Has a whiteboard"}
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.LockRoomAttribute)).WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{"type"}).AddRow("boolean"))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.UpdateRoomAttribute)).WithArgs("1", "Has a whiteboard", pq.Array([]string{})).
+		WillReturnRows(sqlmock.NewRows([]string{"key", "type", "created_at", "updated_at"}).AddRow("whiteboard", "boolean", now, now))
+	suite.mockSql.ExpectCommit()
+
+	actual, err := suite.repo.Update(context.Background(), payload)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "whiteboard", actual.Key)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *RoomAttributeRepositoryTestSuite) TestReplaceValues_Success() {
+	values := []entity.RoomAttributeValue{{AttributeId: "a1", Value: "true"}, {AttributeId: "a2", Value: "boardroom"}}
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.LockRoom)).WithArgs("9").WillReturnRows(sqlmock.NewRows([]string{"archived_at"}).AddRow(nil))
+	suite.mockSql.ExpectExec(regexp.QuoteMeta(config.DeleteRoomAttributeValues)).WithArgs("9").WillReturnResult(sqlmock.NewResult(0, 3))
+	suite.mockSql.ExpectExec(regexp.QuoteMeta(config.InsertRoomAttributeValue)).WithArgs("9", "a1", "true").WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mockSql.ExpectExec(regexp.QuoteMeta(config.InsertRoomAttributeValue)).WithArgs("9", "a2", "boardroom").WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mockSql.ExpectCommit()
+
+	err := suite.repo.ReplaceValues(context.Background(), "9", values)
+
+	assert.NoError(suite.T(), err)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *RoomAttributeRepositoryTestSuite) TestReplaceValues_ArchivedFail() {
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.LockRoom)).WithArgs("9").WillReturnRows(sqlmock.NewRows([]string{"archived_at"}).AddRow(time.Now()))
+	suite.mockSql.ExpectRollback()
+
+	err := suite.repo.ReplaceValues(context.Background(), "9", nil)
+
+	assert.True(suite.T(), errors.Is(err, sql.ErrNoRows))
+}
+
+func TestRoomAttributeRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(RoomAttributeRepositoryTestSuite))
+}
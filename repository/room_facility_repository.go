@@ -9,44 +9,68 @@ import (
 	"booking-room-app/config"
 	"booking-room-app/entity"
 	"booking-room-app/shared/model"
+	"context"
 	"database/sql"
-	"log"
+	"errors"
+	"log/slog"
 	"math"
 )
 
+var (
+	// ErrInsufficientAllocation is returned when a transfer moves more than
+	// the room has.
+	ErrInsufficientAllocation = errors.New("quantity more than allocated to the room")
+	// ErrSameRoom is returned when a transfer targets the room the facility
+	// is already in.
+	ErrSameRoom = errors.New("the facility is already in the room")
+	// ErrRoomOutOfService is returned when a transfer targets a room that is
+	// archived or under maintenance.
+	ErrRoomOutOfService = errors.New("the room is archived or under maintenance")
+)
+
 type RoomFacilityRepository interface {
-	CreateRoomFacility(payload entity.RoomFacility, newQuantity int) (entity.RoomFacility, error)
-	ListRoomFacility(page, size int) ([]entity.RoomFacility, model.Paging, error)
-	GetRoomFacilityById(id string) (entity.RoomFacility, error)
-	UpdateRoomFacility(payload entity.RoomFacility, newFacilityQuantity int) (entity.RoomFacility, error)
-	GetQuantityFacilityByID(id string) (int, error)
+	CreateRoomFacility(ctx context.Context, payload entity.RoomFacility) (entity.RoomFacility, error)
+	ListRoomFacility(ctx context.Context, page, size int) ([]entity.RoomFacility, model.Paging, error)
+	GetRoomFacilityById(ctx context.Context, id string) (entity.RoomFacility, error)
+	UpdateRoomFacility(ctx context.Context, payload entity.RoomFacility) (entity.RoomFacility, error)
+	GetQuantityFacilityByID(ctx context.Context, id string) (int, error)
+	DeleteRoomFacility(ctx context.Context, id string) (entity.RoomFacility, error)
+	TransferRoomFacility(ctx context.Context, payload entity.RoomFacilityTransfer) (entity.RoomFacilityTransfer, error)
 }
 
 type roomFacilityRepository struct {
-	db *sql.DB
+	db       *sql.DB
+	timeouts Timeouts
 }
 
 // get quantity from facilities by facility ID
-func (t *roomFacilityRepository) GetQuantityFacilityByID(id string) (int, error) {
+func (t *roomFacilityRepository) GetQuantityFacilityByID(ctx context.Context, id string) (int, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeouts.Query)
+	defer cancel()
+
 	var quantity int
-	err := t.db.QueryRow(config.GetQuantityFacilityByID, id).Scan(&quantity)
+	err := t.db.QueryRowContext(ctx, config.GetQuantityFacilityByID, id).Scan(&quantity)
 	if err != nil {
-		log.Println("roomFacilityRepository.QueryGetQuantityFacilityByID:", err.Error())
+		slog.ErrorContext(ctx, "roomFacilityRepository.QueryGetQuantityFacilityByID", "err", err)
 		return quantity, err
 	}
 	return quantity, nil
 }
 
 // get all room facilities (ADMIN) -GET
-func (t *roomFacilityRepository) ListRoomFacility(page, size int) ([]entity.RoomFacility, model.Paging, error) {
+func (t *roomFacilityRepository) ListRoomFacility(ctx context.Context, page, size int) ([]entity.RoomFacility, model.Paging, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeouts.Query)
+	defer cancel()
+
 	var roomFacilities []entity.RoomFacility
 	offset := (page - 1) * size
 
-	rows, err := t.db.Query(config.SelectRoomFacilityList, size, offset)
+	rows, err := t.db.QueryContext(ctx, config.SelectRoomFacilityList, size, offset)
 	if err != nil {
-		log.Println("roomFacilityRepository.Query:", err.Error())
+		slog.ErrorContext(ctx, "roomFacilityRepository.Query", "err", err)
 		return nil, model.Paging{}, err
 	}
+	defer rows.Close()
 
 	for rows.Next() {
 		var roomFacility entity.RoomFacility
@@ -59,15 +83,15 @@ func (t *roomFacilityRepository) ListRoomFacility(page, size int) ([]entity.Room
 			&roomFacility.CreatedAt,
 			&roomFacility.UpdatedAt)
 		if err != nil {
-			log.Println("roomFacilityRepository.Rows.Next():", err.Error())
+			slog.ErrorContext(ctx, "roomFacilityRepository.Rows.Next()", "err", err)
 			return nil, model.Paging{}, err
 		}
 		roomFacilities = append(roomFacilities, roomFacility)
 	}
 
 	totalRows := 0
-	if err := t.db.QueryRow(config.GetCountRoomFacility).Scan(&totalRows); err != nil {
-		log.Println("roomFacilityRepository.QueryRowGetCountRoomFacility:", err.Error())
+	if err := t.db.QueryRowContext(ctx, config.GetCountRoomFacility).Scan(&totalRows); err != nil {
+		slog.ErrorContext(ctx, "roomFacilityRepository.QueryRowGetCountRoomFacility", "err", err)
 		return nil, model.Paging{}, err
 	}
 
@@ -83,9 +107,12 @@ func (t *roomFacilityRepository) ListRoomFacility(page, size int) ([]entity.Room
 }
 
 // get by ID room facilities (ADMIN) -GET
-func (t *roomFacilityRepository) GetRoomFacilityById(id string) (entity.RoomFacility, error) {
+func (t *roomFacilityRepository) GetRoomFacilityById(ctx context.Context, id string) (entity.RoomFacility, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeouts.Query)
+	defer cancel()
+
 	var roomFacility entity.RoomFacility
-	err := t.db.QueryRow(config.SelectRoomFacilityByID, id).Scan(
+	err := t.db.QueryRowContext(ctx, config.SelectRoomFacilityByID, id).Scan(
 		&roomFacility.ID,
 		&roomFacility.RoomId,
 		&roomFacility.FacilityId,
@@ -95,28 +122,44 @@ func (t *roomFacilityRepository) GetRoomFacilityById(id string) (entity.RoomFaci
 		&roomFacility.UpdatedAt)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			log.Println("roomFacilityRepository.QueryRowSelectRoomFacilityByID:", err.Error())
+			slog.ErrorContext(ctx, "roomFacilityRepository.QueryRowSelectRoomFacilityByID", "err", err)
 			return entity.RoomFacility{}, err
 		}
-		log.Println("roomFacilityRepository.QueryRowSelectRoomFacilityByID:", err.Error())
+		slog.ErrorContext(ctx, "roomFacilityRepository.QueryRowSelectRoomFacilityByID", "err", err)
 		return entity.RoomFacility{}, err
 	}
 	return roomFacility, nil
 }
 
 // create room facilities (ADMIN) -POST
-func (t *roomFacilityRepository) CreateRoomFacility(payload entity.RoomFacility, newFacilityQuantity int) (entity.RoomFacility, error) {
+func (t *roomFacilityRepository) CreateRoomFacility(ctx context.Context, payload entity.RoomFacility) (entity.RoomFacility, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeouts.Query)
+	defer cancel()
+
 	var roomFacilities entity.RoomFacility
 
 	// begin transaction
-	tx, err := t.db.Begin()
+	tx, err := t.db.BeginTx(ctx, nil)
 	if err != nil {
-		log.Println("roomFacilityRepository.BeginTransaction:", err.Error())
+		slog.ErrorContext(ctx, "roomFacilityRepository.BeginTransaction", "err", err)
 		return entity.RoomFacility{}, err
 	}
 
+	// lock the facility stock so it cannot change between the check and the allocation
+	var stock int
+	err = tx.QueryRowContext(ctx, config.LockFacilityQuantity, payload.FacilityId).Scan(&stock)
+	if err != nil {
+		slog.ErrorContext(ctx, "roomFacilityRepository.LockFacilityQuantity", "err", err)
+		tx.Rollback()
+		return entity.RoomFacility{}, err
+	}
+	if payload.Quantity > stock {
+		tx.Rollback()
+		return entity.RoomFacility{}, ErrInsufficientStock
+	}
+
 	// insert data
-	err = tx.QueryRow(
+	err = tx.QueryRowContext(ctx,
 		config.InsertTrxRoomFacility,
 		payload.RoomId,
 		payload.FacilityId,
@@ -127,14 +170,21 @@ func (t *roomFacilityRepository) CreateRoomFacility(payload entity.RoomFacility,
 			&payload.CreatedAt,
 			&payload.UpdatedAt)
 	if err != nil {
-		log.Println("roomFacilityRepository.QueryInsertData:", err.Error())
+		slog.ErrorContext(ctx, "roomFacilityRepository.QueryInsertData", "err", err)
+		tx.Rollback()
 		return entity.RoomFacility{}, err
 	}
 
-	// reduce quantity in facility
-	_, err = tx.Exec(config.UpdateQuantityFacilityByID, newFacilityQuantity, payload.FacilityId)
+	// take the allocated quantity out of the facility stock
+	_, err = recordMovement(ctx, tx, entity.FacilityMovement{
+		FacilityId:  payload.FacilityId,
+		Kind:        entity.MovementAllocation,
+		Quantity:    -payload.Quantity,
+		Reason:      "allocated to room",
+		ReferenceId: payload.ID,
+	})
 	if err != nil {
-		log.Println("roomFacilityRepository.QueryReduceQuantity:", err.Error())
+		tx.Rollback()
 		return entity.RoomFacility{}, err
 	}
 
@@ -142,7 +192,7 @@ func (t *roomFacilityRepository) CreateRoomFacility(payload entity.RoomFacility,
 	err = tx.Commit()
 	if err != nil {
 		tx.Rollback()
-		log.Println("roomFacilityRepository.TransactionCommit:", err.Error())
+		slog.ErrorContext(ctx, "roomFacilityRepository.TransactionCommit", "err", err)
 		return entity.RoomFacility{}, err
 	}
 
@@ -151,18 +201,31 @@ func (t *roomFacilityRepository) CreateRoomFacility(payload entity.RoomFacility,
 }
 
 // update room facilites (ADMIN) -GET
-func (t *roomFacilityRepository) UpdateRoomFacility(payload entity.RoomFacility, newFacilityQuantity int) (entity.RoomFacility, error) {
+func (t *roomFacilityRepository) UpdateRoomFacility(ctx context.Context, payload entity.RoomFacility) (entity.RoomFacility, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeouts.Query)
+	defer cancel()
+
 	var roomFacility entity.RoomFacility
 
 	// begin transaction
-	tx, err := t.db.Begin()
+	tx, err := t.db.BeginTx(ctx, nil)
 	if err != nil {
-		log.Println("roomFacilityRepository.BeginTransaction:", err.Error())
+		slog.ErrorContext(ctx, "roomFacilityRepository.BeginTransaction", "err", err)
+		return entity.RoomFacility{}, err
+	}
+
+	// lock the current allocation
+	var oldFacilityId string
+	var oldQuantity int
+	err = tx.QueryRowContext(ctx, config.LockRoomFacilityAllocation, payload.ID).Scan(&oldFacilityId, &oldQuantity)
+	if err != nil {
+		slog.ErrorContext(ctx, "roomFacilityRepository.LockRoomFacility", "err", err)
+		tx.Rollback()
 		return entity.RoomFacility{}, err
 	}
 
 	// update room-facility
-	err = tx.QueryRow(
+	err = tx.QueryRowContext(ctx,
 		config.UpdateRoomFacility,
 		payload.RoomId,
 		payload.FacilityId,
@@ -170,15 +233,15 @@ func (t *roomFacilityRepository) UpdateRoomFacility(payload entity.RoomFacility,
 		payload.Description,
 		payload.ID).Scan(&payload.CreatedAt, &payload.UpdatedAt)
 	if err != nil {
-		log.Println("roomFacilityRepository.UpdateRoomFacility:", err.Error())
+		slog.ErrorContext(ctx, "roomFacilityRepository.UpdateRoomFacility", "err", err)
+		tx.Rollback()
 		return entity.RoomFacility{}, err
 	}
 
-	// change facility quantity
-	if newFacilityQuantity != -1 {
-		_, err = tx.Exec(config.UpdateQuantityFacilityByID, newFacilityQuantity, payload.FacilityId)
-		if err != nil {
-			log.Println("roomFacilityRepository.QueryChangeQuantity:", err.Error())
+	// move the difference between the facility stock and the room
+	for _, movement := range allocationMovements(payload.ID, oldFacilityId, oldQuantity, payload.FacilityId, payload.Quantity) {
+		if _, err := recordMovement(ctx, tx, movement); err != nil {
+			tx.Rollback()
 			return entity.RoomFacility{}, err
 		}
 	}
@@ -187,7 +250,7 @@ func (t *roomFacilityRepository) UpdateRoomFacility(payload entity.RoomFacility,
 	err = tx.Commit()
 	if err != nil {
 		tx.Rollback()
-		log.Println("roomFacilityRepository.CommitTransaction:", err.Error())
+		slog.ErrorContext(ctx, "roomFacilityRepository.CommitTransaction", "err", err)
 		return entity.RoomFacility{}, err
 	}
 
@@ -195,6 +258,213 @@ func (t *roomFacilityRepository) UpdateRoomFacility(payload entity.RoomFacility,
 	return roomFacility, err
 }
 
-func NewRoomFacilityRepository(db *sql.DB) RoomFacilityRepository {
-	return &roomFacilityRepository{db: db}
+// DeleteRoomFacility removes an allocation and returns its quantity to the
+// facility stock.
+func (t *roomFacilityRepository) DeleteRoomFacility(ctx context.Context, id string) (entity.RoomFacility, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeouts.Query)
+	defer cancel()
+
+	tx, err := t.db.BeginTx(ctx, nil)
+	if err != nil {
+		slog.ErrorContext(ctx, "roomFacilityRepository.BeginTransaction", "err", err)
+		return entity.RoomFacility{}, err
+	}
+
+	roomFacility, err := scanRoomFacility(tx.QueryRowContext(ctx, config.LockRoomFacility, id))
+	if err != nil {
+		slog.ErrorContext(ctx, "roomFacilityRepository.LockRoomFacility", "err", err)
+		tx.Rollback()
+		return entity.RoomFacility{}, err
+	}
+
+	if _, err := tx.ExecContext(ctx, config.DeleteRoomFacility, id); err != nil {
+		slog.ErrorContext(ctx, "roomFacilityRepository.DeleteRoomFacility", "err", err)
+		tx.Rollback()
+		return entity.RoomFacility{}, err
+	}
+
+	if roomFacility.Quantity > 0 {
+		_, err = recordMovement(ctx, tx, entity.FacilityMovement{
+			FacilityId:  roomFacility.FacilityId,
+			Kind:        entity.MovementRelease,
+			Quantity:    roomFacility.Quantity,
+			Reason:      "removed from room",
+			ReferenceId: roomFacility.ID,
+		})
+		if err != nil {
+			tx.Rollback()
+			return entity.RoomFacility{}, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		slog.ErrorContext(ctx, "roomFacilityRepository.CommitTransaction", "err", err)
+		return entity.RoomFacility{}, err
+	}
+	return roomFacility, nil
+}
+
+// TransferRoomFacility moves a quantity of an allocation to another room,
+// adding it to the allocation of the facility there or creating one. The
+// ledger records it as a release from one allocation and an allocation to the
+// other, so the stock is unchanged. It returns ErrRoomNotFound for an unknown
+// room and ErrRoomOutOfService for one that is archived or under maintenance.
+func (t *roomFacilityRepository) TransferRoomFacility(ctx context.Context, payload entity.RoomFacilityTransfer) (entity.RoomFacilityTransfer, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeouts.Query)
+	defer cancel()
+
+	tx, err := t.db.BeginTx(ctx, nil)
+	if err != nil {
+		slog.ErrorContext(ctx, "roomFacilityRepository.BeginTransaction", "err", err)
+		return entity.RoomFacilityTransfer{}, err
+	}
+
+	// the target room must exist and stay in service until the quantity is in
+	var roomStatus string
+	err = tx.QueryRowContext(ctx, config.LockRoomStatus, payload.RoomId).Scan(&roomStatus)
+	if errors.Is(err, sql.ErrNoRows) {
+		tx.Rollback()
+		return entity.RoomFacilityTransfer{}, ErrRoomNotFound
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "roomFacilityRepository.LockRoomStatus", "err", err)
+		tx.Rollback()
+		return entity.RoomFacilityTransfer{}, err
+	}
+	if roomStatus == "archived" || roomStatus == "unavailable" {
+		tx.Rollback()
+		return entity.RoomFacilityTransfer{}, ErrRoomOutOfService
+	}
+
+	// lock the source and the allocation of the facility in the target room
+	source, target, err := t.lockTransfer(ctx, tx, payload)
+	if err != nil {
+		slog.ErrorContext(ctx, "roomFacilityRepository.LockTransferRoomFacilities", "err", err)
+		tx.Rollback()
+		return entity.RoomFacilityTransfer{}, err
+	}
+	switch {
+	case source.RoomId == payload.RoomId:
+		tx.Rollback()
+		return entity.RoomFacilityTransfer{}, ErrSameRoom
+	case payload.Quantity > source.Quantity:
+		tx.Rollback()
+		return entity.RoomFacilityTransfer{}, ErrInsufficientAllocation
+	}
+
+	// take the quantity out of the source, removing it when nothing is left
+	source.Quantity -= payload.Quantity
+	if source.Quantity == 0 {
+		_, err = tx.ExecContext(ctx, config.DeleteRoomFacility, source.ID)
+	} else {
+		err = tx.QueryRowContext(ctx, config.UpdateRoomFacilityQuantity, source.ID, source.Quantity).Scan(&source.UpdatedAt)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "roomFacilityRepository.TransferSource", "err", err)
+		tx.Rollback()
+		return entity.RoomFacilityTransfer{}, err
+	}
+
+	// add it to the allocation of the facility in the target room
+	if target.ID != "" {
+		target.Quantity += payload.Quantity
+		err = tx.QueryRowContext(ctx, config.UpdateRoomFacilityQuantity, target.ID, target.Quantity).Scan(&target.UpdatedAt)
+	} else {
+		target = entity.RoomFacility{RoomId: payload.RoomId, FacilityId: source.FacilityId, Quantity: payload.Quantity, Description: source.Description}
+		err = tx.QueryRowContext(ctx, config.InsertTrxRoomFacility, target.RoomId, target.FacilityId, target.Quantity, target.Description).Scan(&target.ID, &target.CreatedAt, &target.UpdatedAt)
+	}
+	if err != nil {
+		slog.ErrorContext(ctx, "roomFacilityRepository.TransferTarget", "err", err)
+		tx.Rollback()
+		return entity.RoomFacilityTransfer{}, err
+	}
+
+	movements := []entity.FacilityMovement{
+		{FacilityId: source.FacilityId, Kind: entity.MovementRelease, Quantity: payload.Quantity, Reason: "transferred to another room", ReferenceId: source.ID},
+		{FacilityId: source.FacilityId, Kind: entity.MovementAllocation, Quantity: -payload.Quantity, Reason: "transferred from another room", ReferenceId: target.ID},
+	}
+	for _, movement := range movements {
+		if _, err := recordMovement(ctx, tx, movement); err != nil {
+			tx.Rollback()
+			return entity.RoomFacilityTransfer{}, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		slog.ErrorContext(ctx, "roomFacilityRepository.CommitTransaction", "err", err)
+		return entity.RoomFacilityTransfer{}, err
+	}
+
+	payload.Source = source
+	payload.Target = target
+	return payload, nil
+}
+
+// lockTransfer locks the allocation a transfer takes from and, when there is
+// one, the allocation of the same facility in the target room, both in one
+// statement. It returns sql.ErrNoRows when the source does not exist.
+func (t *roomFacilityRepository) lockTransfer(ctx context.Context, tx *sql.Tx, payload entity.RoomFacilityTransfer) (source, target entity.RoomFacility, err error) {
+	rows, err := tx.QueryContext(ctx, config.LockTransferRoomFacilities, payload.RoomFacilityId, payload.RoomId)
+	if err != nil {
+		return source, target, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		roomFacility, err := scanRoomFacility(rows)
+		if err != nil {
+			return source, target, err
+		}
+		if roomFacility.ID == payload.RoomFacilityId {
+			source = roomFacility
+		} else {
+			target = roomFacility
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return source, target, err
+	}
+	if source.ID == "" {
+		return source, target, sql.ErrNoRows
+	}
+	return source, target, nil
+}
+
+func scanRoomFacility(row rowScanner) (entity.RoomFacility, error) {
+	var roomFacility entity.RoomFacility
+	err := row.Scan(
+		&roomFacility.ID,
+		&roomFacility.RoomId,
+		&roomFacility.FacilityId,
+		&roomFacility.Quantity,
+		&roomFacility.Description,
+		&roomFacility.CreatedAt,
+		&roomFacility.UpdatedAt)
+	return roomFacility, err
+}
+
+// allocationMovements are the movements that turn an allocation of
+// oldQuantity from oldFacilityId into one of quantity from facilityId: the
+// difference when the facility stays, otherwise the old quantity released
+// and the new one allocated.
+func allocationMovements(id, oldFacilityId string, oldQuantity int, facilityId string, quantity int) []entity.FacilityMovement {
+	movement := func(facilityId string, quantity int) entity.FacilityMovement {
+		m := entity.FacilityMovement{FacilityId: facilityId, Kind: entity.MovementAllocation, Quantity: -quantity, Reason: "allocated to room", ReferenceId: id}
+		if quantity < 0 {
+			m.Kind, m.Reason = entity.MovementRelease, "released from room"
+		}
+		return m
+	}
+
+	if oldFacilityId == facilityId {
+		if quantity == oldQuantity {
+			return nil
+		}
+		return []entity.FacilityMovement{movement(facilityId, quantity-oldQuantity)}
+	}
+	return []entity.FacilityMovement{movement(oldFacilityId, -oldQuantity), movement(facilityId, quantity)}
+}
+
+func NewRoomFacilityRepository(db *sql.DB, timeouts Timeouts) RoomFacilityRepository {
+	return &roomFacilityRepository{db: db, timeouts: timeouts}
 }
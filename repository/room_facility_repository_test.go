@@ -1,14 +1,18 @@
 package repository
 
 import (
+	"booking-room-app/config"
 	"booking-room-app/entity"
 	"booking-room-app/shared/model"
+	"context"
 	"database/sql"
 	"fmt"
+	"regexp"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 )
@@ -34,7 +38,7 @@ func (suite *RoomFacilityRepositoryTestSuite) SetupTest() {
 	db, mock, _ := sqlmock.New()
 	suite.mockDB = db
 	suite.mockSql = mock
-	suite.repo = NewRoomFacilityRepository(suite.mockDB)
+	suite.repo = NewRoomFacilityRepository(suite.mockDB, DefaultTimeouts())
 }
 
 /*  GetRoomFacilityById Success*/
@@ -51,7 +55,7 @@ func (suite *RoomFacilityRepositoryTestSuite) TestGetRoomFacilityById_Success()
 	)
 
 	suite.mockSql.ExpectQuery("SELECT").WithArgs(expectedRoomFacility.ID).WillReturnRows(rows)
-	actualRoomFacility, actualErr := suite.repo.GetRoomFacilityById(expectedRoomFacility.ID)
+	actualRoomFacility, actualErr := suite.repo.GetRoomFacilityById(context.Background(), expectedRoomFacility.ID)
 
 	assert.Nil(suite.T(), actualErr)
 	assert.NoError(suite.T(), actualErr)
@@ -61,14 +65,14 @@ func (suite *RoomFacilityRepositoryTestSuite) TestGetRoomFacilityById_Success()
 /*  GetRoomFacilityById Fail*/
 func (suite *RoomFacilityRepositoryTestSuite) TestGetRoomFacilityById_Fail() {
 	suite.mockSql.ExpectQuery("SELECT").WithArgs("invalid id").WillReturnError(fmt.Errorf("just error"))
-	actualRoomFacility, actualErr := suite.repo.GetRoomFacilityById("invalid id")
+	actualRoomFacility, actualErr := suite.repo.GetRoomFacilityById(context.Background(), "invalid id")
 
 	assert.NotNil(suite.T(), actualErr)
 	assert.Error(suite.T(), actualErr)
 	assert.Equal(suite.T(), entity.RoomFacility{}, actualRoomFacility)
 
 	suite.mockSql.ExpectQuery("SELECT").WithArgs("id that not found").WillReturnError(sql.ErrNoRows)
-	actualRoomFacility, actualErr = suite.repo.GetRoomFacilityById("id that not found")
+	actualRoomFacility, actualErr = suite.repo.GetRoomFacilityById(context.Background(), "id that not found")
 	assert.NotNil(suite.T(), actualErr)
 	assert.Error(suite.T(), actualErr)
 	assert.Equal(suite.T(), sql.ErrNoRows, actualErr)
@@ -82,7 +86,7 @@ func (suite *RoomFacilityRepositoryTestSuite) TestGetQuantityFacilityByID_Succes
 		expectedQuantity,
 	)
 	suite.mockSql.ExpectQuery("SELECT").WithArgs("1").WillReturnRows(rows)
-	actualQuantity, actualErr := suite.repo.GetQuantityFacilityByID("1")
+	actualQuantity, actualErr := suite.repo.GetQuantityFacilityByID(context.Background(), "1")
 	assert.Nil(suite.T(), actualErr)
 	assert.NoError(suite.T(), actualErr)
 	assert.Equal(suite.T(), expectedQuantity, actualQuantity)
@@ -91,7 +95,7 @@ func (suite *RoomFacilityRepositoryTestSuite) TestGetQuantityFacilityByID_Succes
 /* GetQuantityFacilityByID Fail */
 func (suite *RoomFacilityRepositoryTestSuite) TestGetQuantityFacilityByID_Fail() {
 	suite.mockSql.ExpectQuery("SELECT").WithArgs("1").WillReturnError(fmt.Errorf("internal server error"))
-	actualQuantity, actualErr := suite.repo.GetQuantityFacilityByID("1")
+	actualQuantity, actualErr := suite.repo.GetQuantityFacilityByID(context.Background(), "1")
 	assert.NotNil(suite.T(), actualErr)
 	assert.Error(suite.T(), actualErr)
 	assert.Equal(suite.T(), 0, actualQuantity)
@@ -100,7 +104,7 @@ func (suite *RoomFacilityRepositoryTestSuite) TestGetQuantityFacilityByID_Fail()
 /* GetQuantityFacilityByID Fail No rows */
 func (suite *RoomFacilityRepositoryTestSuite) TestGetQuantityFacilityByID_NoRowsFail() {
 	suite.mockSql.ExpectQuery("SELECT").WithArgs("1").WillReturnError(sql.ErrNoRows)
-	actualQuantity, actualErr := suite.repo.GetQuantityFacilityByID("1")
+	actualQuantity, actualErr := suite.repo.GetQuantityFacilityByID(context.Background(), "1")
 	assert.NotNil(suite.T(), actualErr)
 	assert.Error(suite.T(), actualErr)
 	assert.Equal(suite.T(), 0, actualQuantity)
@@ -142,7 +146,7 @@ func (suite *RoomFacilityRepositoryTestSuite) TestListRoomFacility_Success() {
 	suite.mockSql.ExpectQuery("SELECT").WithArgs(size, (page-1)*size).WillReturnRows(rows)
 	suite.mockSql.ExpectQuery("SELECT").WillReturnRows(sqlmock.NewRows([]string{"totalRows"}).AddRow(10))
 
-	actualRoomFacility, actualPaging, actualErr := suite.repo.ListRoomFacility(page, size)
+	actualRoomFacility, actualPaging, actualErr := suite.repo.ListRoomFacility(context.Background(), page, size)
 	assert.Nil(suite.T(), actualErr)
 	assert.NoError(suite.T(), actualErr)
 	assert.Equal(suite.T(), expectedPaging, actualPaging)
@@ -154,7 +158,7 @@ func (suite *RoomFacilityRepositoryTestSuite) TestListRoomFacility_QueryFail() {
 	size, page := 5, 1
 	suite.mockSql.ExpectQuery("SELECT").WithArgs(5, (page-1)*size).WillReturnError(fmt.Errorf("error"))
 
-	actualRoomFacility, actualPaging, actualErr := suite.repo.ListRoomFacility(page, size)
+	actualRoomFacility, actualPaging, actualErr := suite.repo.ListRoomFacility(context.Background(), page, size)
 	assert.NotNil(suite.T(), actualErr)
 	assert.Error(suite.T(), actualErr)
 	assert.Equal(suite.T(), model.Paging{}, actualPaging)
@@ -176,7 +180,7 @@ func (suite *RoomFacilityRepositoryTestSuite) TestListRoomFacility_ScanRowFail()
 
 	suite.mockSql.ExpectQuery("SELECT id, room_id, facility_id, quantity, description, created_at").WithArgs(5, 0).WillReturnRows(rows)
 
-	actualRoomFacility, actualPaging, actualErr := suite.repo.ListRoomFacility(page, size)
+	actualRoomFacility, actualPaging, actualErr := suite.repo.ListRoomFacility(context.Background(), page, size)
 	assert.NotNil(suite.T(), actualErr)
 	assert.Error(suite.T(), actualErr)
 	assert.Equal(suite.T(), model.Paging{}, actualPaging)
@@ -197,7 +201,7 @@ func (suite *RoomFacilityRepositoryTestSuite) TestListRoomFacility_CountRoomFaci
 
 	suite.mockSql.ExpectQuery("SELECT id, room_id, facility_id, quantity, description, created_at").WithArgs(5, 0).WillReturnRows(rows)
 	suite.mockSql.ExpectQuery(`SELECT`).WillReturnError(fmt.Errorf("error count room-facility"))
-	actualRoomFacility, actualPaging, actualErr := suite.repo.ListRoomFacility(page, size)
+	actualRoomFacility, actualPaging, actualErr := suite.repo.ListRoomFacility(context.Background(), page, size)
 	assert.NotNil(suite.T(), actualErr)
 	assert.Error(suite.T(), actualErr)
 	assert.Equal(suite.T(), model.Paging{}, actualPaging)
@@ -207,6 +211,7 @@ func (suite *RoomFacilityRepositoryTestSuite) TestListRoomFacility_CountRoomFaci
 /* Test CreateRoomFacility Success */
 func (suite *RoomFacilityRepositoryTestSuite) TestCreateRoomFacility_Success() {
 	suite.mockSql.ExpectBegin().WillReturnError(nil)
+	suite.expectStockLock(expectedRoomFacility.FacilityId, 5)
 	rows := sqlmock.NewRows([]string{"id", "create_at", "updated_at"}).AddRow(
 		expectedRoomFacility.ID,
 		expectedRoomFacility.CreatedAt,
@@ -218,9 +223,9 @@ func (suite *RoomFacilityRepositoryTestSuite) TestCreateRoomFacility_Success() {
 		expectedRoomFacility.Quantity,
 		expectedRoomFacility.Description,
 	).WillReturnRows(rows)
-	suite.mockSql.ExpectExec(`UPDATE`).WithArgs(5, expectedRoomFacility.FacilityId).WillReturnResult(sqlmock.NewResult(4545, 01101))
+	suite.mockSql.ExpectQuery("INSERT INTO facility_movements").WithArgs(expectedRoomFacility.FacilityId, entity.MovementAllocation, -expectedRoomFacility.Quantity, "actor id", "allocated to room", expectedRoomFacility.ID).WillReturnRows(sqlmock.NewRows([]string{"id", "balance", "created_at"}).AddRow("movement id", 3, time.Time{}))
 	suite.mockSql.ExpectCommit().WillReturnError(nil)
-	actualRoomFacility, actualErr := suite.repo.CreateRoomFacility(expectedRoomFacility, 5)
+	actualRoomFacility, actualErr := suite.repo.CreateRoomFacility(actorCtx, expectedRoomFacility)
 	assert.Nil(suite.T(), actualErr)
 	assert.NoError(suite.T(), actualErr)
 	assert.Equal(suite.T(), expectedRoomFacility, actualRoomFacility)
@@ -229,7 +234,7 @@ func (suite *RoomFacilityRepositoryTestSuite) TestCreateRoomFacility_Success() {
 /* Test CreateRoomFacility Fail Begin */
 func (suite *RoomFacilityRepositoryTestSuite) TestCreateRoomFacility_BeginTxFail() {
 	suite.mockSql.ExpectBegin().WillReturnError(fmt.Errorf("failed to begin transaction tx"))
-	actualRoomFacility, actualErr := suite.repo.CreateRoomFacility(expectedRoomFacility, 5)
+	actualRoomFacility, actualErr := suite.repo.CreateRoomFacility(actorCtx, expectedRoomFacility)
 	assert.NotNil(suite.T(), actualErr)
 	assert.Error(suite.T(), actualErr)
 	assert.Equal(suite.T(), entity.RoomFacility{}, actualRoomFacility)
@@ -238,21 +243,34 @@ func (suite *RoomFacilityRepositoryTestSuite) TestCreateRoomFacility_BeginTxFail
 /* Test CreateRoomFacility Fail Insert */
 func (suite *RoomFacilityRepositoryTestSuite) TestCreateRoomFacility_InsertFail() {
 	suite.mockSql.ExpectBegin().WillReturnError(nil)
+	suite.expectStockLock(expectedRoomFacility.FacilityId, 5)
 	suite.mockSql.ExpectQuery(`INSERT`).WithArgs(
 		expectedRoomFacility.RoomId,
 		expectedRoomFacility.FacilityId,
 		expectedRoomFacility.Quantity,
 		expectedRoomFacility.Description,
 	).WillReturnError(fmt.Errorf("failed to insert data"))
-	actualRoomFacility, actualErr := suite.repo.CreateRoomFacility(expectedRoomFacility, 5)
+	actualRoomFacility, actualErr := suite.repo.CreateRoomFacility(actorCtx, expectedRoomFacility)
 	assert.NotNil(suite.T(), actualErr)
 	assert.Error(suite.T(), actualErr)
 	assert.Equal(suite.T(), entity.RoomFacility{}, actualRoomFacility)
 }
 
+/* Test CreateRoomFacility Fail when the locked stock is short */
+func (suite *RoomFacilityRepositoryTestSuite) TestCreateRoomFacility_InsufficientStockFail() {
+	suite.mockSql.ExpectBegin()
+	suite.expectStockLock(expectedRoomFacility.FacilityId, expectedRoomFacility.Quantity-1)
+	suite.mockSql.ExpectRollback()
+	actualRoomFacility, actualErr := suite.repo.CreateRoomFacility(actorCtx, expectedRoomFacility)
+	assert.ErrorIs(suite.T(), actualErr, ErrInsufficientStock)
+	assert.Equal(suite.T(), entity.RoomFacility{}, actualRoomFacility)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
 /* Test CreateRoomFacility Fail Reduce Quantity in Facility */
 func (suite *RoomFacilityRepositoryTestSuite) TestCreateRoomFacility_ReduceQuantityFail() {
 	suite.mockSql.ExpectBegin().WillReturnError(nil)
+	suite.expectStockLock(expectedRoomFacility.FacilityId, 5)
 	rows := sqlmock.NewRows([]string{"id", "create_at", "updated_at"}).AddRow(
 		expectedRoomFacility.ID,
 		expectedRoomFacility.CreatedAt,
@@ -264,8 +282,9 @@ func (suite *RoomFacilityRepositoryTestSuite) TestCreateRoomFacility_ReduceQuant
 		expectedRoomFacility.Quantity,
 		expectedRoomFacility.Description,
 	).WillReturnRows(rows)
-	suite.mockSql.ExpectExec("UPDATE").WithArgs(5, expectedRoomFacility.FacilityId).WillReturnError(fmt.Errorf("failed to insert data"))
-	actualRoomFacility, actualErr := suite.repo.CreateRoomFacility(expectedRoomFacility, 5)
+	suite.mockSql.ExpectQuery("INSERT INTO facility_movements").WithArgs(expectedRoomFacility.FacilityId, entity.MovementAllocation, -expectedRoomFacility.Quantity, "actor id", "allocated to room", expectedRoomFacility.ID).WillReturnError(&pq.Error{Code: "23514", Constraint: "facility_movements_stock"})
+	actualRoomFacility, actualErr := suite.repo.CreateRoomFacility(actorCtx, expectedRoomFacility)
+	assert.ErrorIs(suite.T(), actualErr, ErrInsufficientStock)
 	assert.NotNil(suite.T(), actualErr)
 	assert.Error(suite.T(), actualErr)
 	assert.Equal(suite.T(), entity.RoomFacility{}, actualRoomFacility)
@@ -275,6 +294,7 @@ func (suite *RoomFacilityRepositoryTestSuite) TestCreateRoomFacility_ReduceQuant
 
 func (suite *RoomFacilityRepositoryTestSuite) TestCreateRoomFacility_CommitFail() {
 	suite.mockSql.ExpectBegin().WillReturnError(nil)
+	suite.expectStockLock(expectedRoomFacility.FacilityId, 5)
 	rows := sqlmock.NewRows([]string{"id", "create_at", "updated_at"}).AddRow(
 		expectedRoomFacility.ID,
 		expectedRoomFacility.CreatedAt,
@@ -286,9 +306,9 @@ func (suite *RoomFacilityRepositoryTestSuite) TestCreateRoomFacility_CommitFail(
 		expectedRoomFacility.Quantity,
 		expectedRoomFacility.Description,
 	).WillReturnRows(rows)
-	suite.mockSql.ExpectExec("UPDATE").WithArgs(5, expectedRoomFacility.FacilityId).WillReturnResult(sqlmock.NewResult(1, 1))
+	suite.mockSql.ExpectQuery("INSERT INTO facility_movements").WithArgs(expectedRoomFacility.FacilityId, entity.MovementAllocation, -expectedRoomFacility.Quantity, "actor id", "allocated to room", expectedRoomFacility.ID).WillReturnRows(sqlmock.NewRows([]string{"id", "balance", "created_at"}).AddRow("movement id", 3, time.Time{}))
 	suite.mockSql.ExpectCommit().WillReturnError(fmt.Errorf("failed to commit"))
-	actualRoomFacility, actualErr := suite.repo.CreateRoomFacility(expectedRoomFacility, 5)
+	actualRoomFacility, actualErr := suite.repo.CreateRoomFacility(actorCtx, expectedRoomFacility)
 	assert.NotNil(suite.T(), actualErr)
 	assert.Error(suite.T(), actualErr)
 	assert.Equal(suite.T(), entity.RoomFacility{}, actualRoomFacility)
@@ -296,9 +316,8 @@ func (suite *RoomFacilityRepositoryTestSuite) TestCreateRoomFacility_CommitFail(
 
 /* Test UpdateRoomFacility Success */
 func (suite *RoomFacilityRepositoryTestSuite) TestUpdateRoomFacility_Success() {
-	newFacilityQuantity := 5
-
 	suite.mockSql.ExpectBegin().WillReturnError(nil)
+	suite.expectAllocationLock(expectedRoomFacility.FacilityId, 5)
 
 	rows := sqlmock.NewRows([]string{"create_at", "updated_at"}).AddRow(
 		expectedRoomFacility.CreatedAt,
@@ -313,11 +332,11 @@ func (suite *RoomFacilityRepositoryTestSuite) TestUpdateRoomFacility_Success() {
 		expectedRoomFacility.ID,
 	).WillReturnRows(rows)
 
-	suite.mockSql.ExpectExec("UPDATE").WithArgs(newFacilityQuantity, expectedRoomFacility.FacilityId).WillReturnResult(sqlmock.NewResult(123, 4567))
+	suite.mockSql.ExpectQuery("INSERT INTO facility_movements").WithArgs(expectedRoomFacility.FacilityId, entity.MovementRelease, 3, "actor id", "released from room", expectedRoomFacility.ID).WillReturnRows(sqlmock.NewRows([]string{"id", "balance", "created_at"}).AddRow("movement id", 3, time.Time{}))
 
 	suite.mockSql.ExpectCommit().WillReturnError(nil)
 
-	actualRoomFacility, actualErr := suite.repo.UpdateRoomFacility(expectedRoomFacility, newFacilityQuantity)
+	actualRoomFacility, actualErr := suite.repo.UpdateRoomFacility(actorCtx, expectedRoomFacility)
 	assert.Nil(suite.T(), actualErr)
 	assert.NoError(suite.T(), actualErr)
 	assert.Equal(suite.T(), expectedRoomFacility, actualRoomFacility)
@@ -325,9 +344,8 @@ func (suite *RoomFacilityRepositoryTestSuite) TestUpdateRoomFacility_Success() {
 
 /* Test UpdateRoomFacility Failed Begin */
 func (suite *RoomFacilityRepositoryTestSuite) TestUpdateRoomFacility_BeginFail() {
-	newFacilityQuantity := 5
 	suite.mockSql.ExpectBegin().WillReturnError(fmt.Errorf("failed to begin transaction tx"))
-	actualRoomFacility, actualErr := suite.repo.UpdateRoomFacility(expectedRoomFacility, newFacilityQuantity)
+	actualRoomFacility, actualErr := suite.repo.UpdateRoomFacility(actorCtx, expectedRoomFacility)
 	assert.NotNil(suite.T(), actualErr)
 	assert.Error(suite.T(), actualErr)
 	assert.Equal(suite.T(), entity.RoomFacility{}, actualRoomFacility)
@@ -335,9 +353,8 @@ func (suite *RoomFacilityRepositoryTestSuite) TestUpdateRoomFacility_BeginFail()
 
 /* Test UpdateRoomFacility Failed Update Room-Facility*/
 func (suite *RoomFacilityRepositoryTestSuite) TestUpdateRoomFacility_UpdateRoomFacilityFail() {
-	newFacilityQuantity := 5
-
 	suite.mockSql.ExpectBegin().WillReturnError(nil)
+	suite.expectAllocationLock(expectedRoomFacility.FacilityId, 5)
 	suite.mockSql.ExpectQuery("UPDATE").WithArgs(
 		expectedRoomFacility.RoomId,
 		expectedRoomFacility.FacilityId,
@@ -346,7 +363,7 @@ func (suite *RoomFacilityRepositoryTestSuite) TestUpdateRoomFacility_UpdateRoomF
 		expectedRoomFacility.ID,
 	).WillReturnError(fmt.Errorf("failed to update data"))
 
-	actualRoomFacility, actualErr := suite.repo.UpdateRoomFacility(expectedRoomFacility, newFacilityQuantity)
+	actualRoomFacility, actualErr := suite.repo.UpdateRoomFacility(actorCtx, expectedRoomFacility)
 	assert.NotNil(suite.T(), actualErr)
 	assert.Error(suite.T(), actualErr)
 	assert.Equal(suite.T(), entity.RoomFacility{}, actualRoomFacility)
@@ -354,9 +371,8 @@ func (suite *RoomFacilityRepositoryTestSuite) TestUpdateRoomFacility_UpdateRoomF
 
 /* Test UpdateRoomFacility Failed Update Facility Quantity*/
 func (suite *RoomFacilityRepositoryTestSuite) TestUpdateRoomFacility_UpdateFacilityQuantityFail() {
-	newFacilityQuantity := 5
-
 	suite.mockSql.ExpectBegin().WillReturnError(nil)
+	suite.expectAllocationLock(expectedRoomFacility.FacilityId, 5)
 
 	rows := sqlmock.NewRows([]string{"create_at", "updated_at"}).AddRow(
 		expectedRoomFacility.CreatedAt,
@@ -371,9 +387,9 @@ func (suite *RoomFacilityRepositoryTestSuite) TestUpdateRoomFacility_UpdateFacil
 		expectedRoomFacility.ID,
 	).WillReturnRows(rows)
 
-	suite.mockSql.ExpectExec("UPDATE").WithArgs(newFacilityQuantity, expectedRoomFacility.FacilityId).WillReturnError(fmt.Errorf("failed to update facility quantity"))
+	suite.mockSql.ExpectQuery("INSERT INTO facility_movements").WithArgs(expectedRoomFacility.FacilityId, entity.MovementRelease, 3, "actor id", "released from room", expectedRoomFacility.ID).WillReturnError(fmt.Errorf("failed to update facility quantity"))
 
-	actualRoomFacility, actualErr := suite.repo.UpdateRoomFacility(expectedRoomFacility, newFacilityQuantity)
+	actualRoomFacility, actualErr := suite.repo.UpdateRoomFacility(actorCtx, expectedRoomFacility)
 	assert.NotNil(suite.T(), actualErr)
 	assert.Error(suite.T(), actualErr)
 	assert.Equal(suite.T(), entity.RoomFacility{}, actualRoomFacility)
@@ -381,9 +397,8 @@ func (suite *RoomFacilityRepositoryTestSuite) TestUpdateRoomFacility_UpdateFacil
 
 /* Test UpdateRoomFacility Failed Commit Transaction*/
 func (suite *RoomFacilityRepositoryTestSuite) TestUpdateRoomFacility_CommitFail() {
-	newFacilityQuantity := 5
-
 	suite.mockSql.ExpectBegin().WillReturnError(nil)
+	suite.expectAllocationLock(expectedRoomFacility.FacilityId, 5)
 
 	rows := sqlmock.NewRows([]string{"create_at", "updated_at"}).AddRow(
 		expectedRoomFacility.CreatedAt,
@@ -398,16 +413,198 @@ func (suite *RoomFacilityRepositoryTestSuite) TestUpdateRoomFacility_CommitFail(
 		expectedRoomFacility.ID,
 	).WillReturnRows(rows)
 
-	suite.mockSql.ExpectExec("UPDATE").WithArgs(newFacilityQuantity, expectedRoomFacility.FacilityId).WillReturnResult(sqlmock.NewResult(123, 4567))
+	suite.mockSql.ExpectQuery("INSERT INTO facility_movements").WithArgs(expectedRoomFacility.FacilityId, entity.MovementRelease, 3, "actor id", "released from room", expectedRoomFacility.ID).WillReturnRows(sqlmock.NewRows([]string{"id", "balance", "created_at"}).AddRow("movement id", 3, time.Time{}))
 
 	suite.mockSql.ExpectCommit().WillReturnError(fmt.Errorf("failed to commit transaction"))
 
-	actualRoomFacility, actualErr := suite.repo.UpdateRoomFacility(expectedRoomFacility, newFacilityQuantity)
+	actualRoomFacility, actualErr := suite.repo.UpdateRoomFacility(actorCtx, expectedRoomFacility)
 	assert.NotNil(suite.T(), actualErr)
 	assert.Error(suite.T(), actualErr)
 	assert.Equal(suite.T(), entity.RoomFacility{}, actualRoomFacility)
 }
 
+/* Test UpdateRoomFacility moving the allocation to another facility */
+func (suite *RoomFacilityRepositoryTestSuite) TestUpdateRoomFacility_FacilityChangedSuccess() {
+	suite.mockSql.ExpectBegin()
+	suite.expectAllocationLock("old facility id", 4)
+	suite.mockSql.ExpectQuery("UPDATE").WithArgs(
+		expectedRoomFacility.RoomId,
+		expectedRoomFacility.FacilityId,
+		expectedRoomFacility.Quantity,
+		expectedRoomFacility.Description,
+		expectedRoomFacility.ID,
+	).WillReturnRows(sqlmock.NewRows([]string{"create_at", "updated_at"}).AddRow(time.Time{}, time.Time{}))
+	suite.mockSql.ExpectQuery("INSERT INTO facility_movements").WithArgs("old facility id", entity.MovementRelease, 4, "actor id", "released from room", expectedRoomFacility.ID).WillReturnRows(sqlmock.NewRows([]string{"id", "balance", "created_at"}).AddRow("movement id", 3, time.Time{}))
+	suite.mockSql.ExpectQuery("INSERT INTO facility_movements").WithArgs(expectedRoomFacility.FacilityId, entity.MovementAllocation, -expectedRoomFacility.Quantity, "actor id", "allocated to room", expectedRoomFacility.ID).WillReturnRows(sqlmock.NewRows([]string{"id", "balance", "created_at"}).AddRow("movement id", 3, time.Time{}))
+	suite.mockSql.ExpectCommit()
+
+	_, actualErr := suite.repo.UpdateRoomFacility(actorCtx, expectedRoomFacility)
+	assert.NoError(suite.T(), actualErr)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+/* Test DeleteRoomFacility returning the quantity to the stock */
+func (suite *RoomFacilityRepositoryTestSuite) TestDeleteRoomFacility_Success() {
+	suite.mockSql.ExpectBegin()
+	suite.expectRoomFacilityLock(expectedRoomFacility)
+	suite.mockSql.ExpectExec(regexp.QuoteMeta(config.DeleteRoomFacility)).WithArgs(expectedRoomFacility.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.expectMovement(expectedRoomFacility.FacilityId, entity.MovementRelease, expectedRoomFacility.Quantity, "removed from room", expectedRoomFacility.ID)
+	suite.mockSql.ExpectCommit()
+
+	actual, actualErr := suite.repo.DeleteRoomFacility(actorCtx, expectedRoomFacility.ID)
+	assert.NoError(suite.T(), actualErr)
+	assert.Equal(suite.T(), expectedRoomFacility, actual)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *RoomFacilityRepositoryTestSuite) TestDeleteRoomFacility_NotFoundFail() {
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.LockRoomFacility)).WithArgs(expectedRoomFacility.ID).WillReturnError(sql.ErrNoRows)
+	suite.mockSql.ExpectRollback()
+
+	_, actualErr := suite.repo.DeleteRoomFacility(actorCtx, expectedRoomFacility.ID)
+	assert.ErrorIs(suite.T(), actualErr, sql.ErrNoRows)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+/* Test TransferRoomFacility moving part of an allocation to a room without one */
+func (suite *RoomFacilityRepositoryTestSuite) TestTransferRoomFacility_NewAllocationSuccess() {
+	payload := entity.RoomFacilityTransfer{RoomFacilityId: expectedRoomFacility.ID, RoomId: "other room id", Quantity: 1}
+	suite.mockSql.ExpectBegin()
+	suite.expectRoomStatus("other room id", "available")
+	suite.expectTransferLock(payload, expectedRoomFacility)
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.UpdateRoomFacilityQuantity)).WithArgs(expectedRoomFacility.ID, 1).WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(time.Time{}))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.InsertTrxRoomFacility)).WithArgs("other room id", expectedRoomFacility.FacilityId, 1, expectedRoomFacility.Description).WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow("target id", time.Time{}, time.Time{}))
+	suite.expectMovement(expectedRoomFacility.FacilityId, entity.MovementRelease, 1, "transferred to another room", expectedRoomFacility.ID)
+	suite.expectMovement(expectedRoomFacility.FacilityId, entity.MovementAllocation, -1, "transferred from another room", "target id")
+	suite.mockSql.ExpectCommit()
+
+	actual, actualErr := suite.repo.TransferRoomFacility(actorCtx, payload)
+	assert.NoError(suite.T(), actualErr)
+	assert.Equal(suite.T(), 1, actual.Source.Quantity)
+	assert.Equal(suite.T(), entity.RoomFacility{ID: "target id", RoomId: "other room id", FacilityId: expectedRoomFacility.FacilityId, Quantity: 1, Description: expectedRoomFacility.Description}, actual.Target)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+/* Test TransferRoomFacility moving a whole allocation onto the one in the other room */
+func (suite *RoomFacilityRepositoryTestSuite) TestTransferRoomFacility_MergeSuccess() {
+	payload := entity.RoomFacilityTransfer{RoomFacilityId: expectedRoomFacility.ID, RoomId: "other room id", Quantity: expectedRoomFacility.Quantity}
+	target := entity.RoomFacility{ID: "target id", RoomId: "other room id", FacilityId: expectedRoomFacility.FacilityId, Quantity: 3}
+	suite.mockSql.ExpectBegin()
+	suite.expectRoomStatus("other room id", "booked")
+	suite.expectTransferLock(payload, expectedRoomFacility, target)
+	suite.mockSql.ExpectExec(regexp.QuoteMeta(config.DeleteRoomFacility)).WithArgs(expectedRoomFacility.ID).WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.UpdateRoomFacilityQuantity)).WithArgs("target id", 5).WillReturnRows(sqlmock.NewRows([]string{"updated_at"}).AddRow(time.Time{}))
+	suite.expectMovement(expectedRoomFacility.FacilityId, entity.MovementRelease, 2, "transferred to another room", expectedRoomFacility.ID)
+	suite.expectMovement(expectedRoomFacility.FacilityId, entity.MovementAllocation, -2, "transferred from another room", "target id")
+	suite.mockSql.ExpectCommit()
+
+	actual, actualErr := suite.repo.TransferRoomFacility(actorCtx, payload)
+	assert.NoError(suite.T(), actualErr)
+	assert.Equal(suite.T(), 0, actual.Source.Quantity)
+	assert.Equal(suite.T(), 5, actual.Target.Quantity)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *RoomFacilityRepositoryTestSuite) TestTransferRoomFacility_InsufficientAllocationFail() {
+	payload := entity.RoomFacilityTransfer{RoomFacilityId: expectedRoomFacility.ID, RoomId: "other room id", Quantity: 3}
+	suite.mockSql.ExpectBegin()
+	suite.expectRoomStatus("other room id", "available")
+	suite.expectTransferLock(payload, expectedRoomFacility)
+	suite.mockSql.ExpectRollback()
+
+	_, actualErr := suite.repo.TransferRoomFacility(actorCtx, payload)
+	assert.ErrorIs(suite.T(), actualErr, ErrInsufficientAllocation)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *RoomFacilityRepositoryTestSuite) TestTransferRoomFacility_SameRoomFail() {
+	payload := entity.RoomFacilityTransfer{RoomFacilityId: expectedRoomFacility.ID, RoomId: expectedRoomFacility.RoomId, Quantity: 1}
+	suite.mockSql.ExpectBegin()
+	suite.expectRoomStatus(expectedRoomFacility.RoomId, "available")
+	suite.expectTransferLock(payload, expectedRoomFacility)
+	suite.mockSql.ExpectRollback()
+
+	_, actualErr := suite.repo.TransferRoomFacility(actorCtx, payload)
+	assert.ErrorIs(suite.T(), actualErr, ErrSameRoom)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *RoomFacilityRepositoryTestSuite) TestTransferRoomFacility_NotFoundFail() {
+	payload := entity.RoomFacilityTransfer{RoomFacilityId: expectedRoomFacility.ID, RoomId: "other room id", Quantity: 1}
+	suite.mockSql.ExpectBegin()
+	suite.expectRoomStatus("other room id", "available")
+	suite.expectTransferLock(payload)
+	suite.mockSql.ExpectRollback()
+
+	_, actualErr := suite.repo.TransferRoomFacility(actorCtx, payload)
+	assert.ErrorIs(suite.T(), actualErr, sql.ErrNoRows)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *RoomFacilityRepositoryTestSuite) TestTransferRoomFacility_UnknownRoomFail() {
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.LockRoomStatus)).WithArgs("unknown room id").WillReturnError(sql.ErrNoRows)
+	suite.mockSql.ExpectRollback()
+
+	_, actualErr := suite.repo.TransferRoomFacility(actorCtx, entity.RoomFacilityTransfer{RoomFacilityId: expectedRoomFacility.ID, RoomId: "unknown room id", Quantity: 1})
+	assert.ErrorIs(suite.T(), actualErr, ErrRoomNotFound)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *RoomFacilityRepositoryTestSuite) TestTransferRoomFacility_RoomUnderMaintenanceFail() {
+	suite.mockSql.ExpectBegin()
+	suite.expectRoomStatus("other room id", "unavailable")
+	suite.mockSql.ExpectRollback()
+
+	_, actualErr := suite.repo.TransferRoomFacility(actorCtx, entity.RoomFacilityTransfer{RoomFacilityId: expectedRoomFacility.ID, RoomId: "other room id", Quantity: 1})
+	assert.ErrorIs(suite.T(), actualErr, ErrRoomOutOfService)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *RoomFacilityRepositoryTestSuite) TestTransferRoomFacility_RoomArchivedFail() {
+	suite.mockSql.ExpectBegin()
+	suite.expectRoomStatus("other room id", "archived")
+	suite.mockSql.ExpectRollback()
+
+	_, actualErr := suite.repo.TransferRoomFacility(actorCtx, entity.RoomFacilityTransfer{RoomFacilityId: expectedRoomFacility.ID, RoomId: "other room id", Quantity: 1})
+	assert.ErrorIs(suite.T(), actualErr, ErrRoomOutOfService)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func roomFacilityRows(roomFacility entity.RoomFacility) *sqlmock.Rows {
+	return sqlmock.NewRows([]string{"id", "room_id", "facility_id", "quantity", "description", "created_at", "updated_at"}).
+		AddRow(roomFacility.ID, roomFacility.RoomId, roomFacility.FacilityId, roomFacility.Quantity, roomFacility.Description, roomFacility.CreatedAt, roomFacility.UpdatedAt)
+}
+
+func (suite *RoomFacilityRepositoryTestSuite) expectRoomFacilityLock(roomFacility entity.RoomFacility) {
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.LockRoomFacility)).WithArgs(roomFacility.ID).WillReturnRows(roomFacilityRows(roomFacility))
+}
+
+func (suite *RoomFacilityRepositoryTestSuite) expectRoomStatus(roomId, status string) {
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.LockRoomStatus)).WithArgs(roomId).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow(status))
+}
+
+func (suite *RoomFacilityRepositoryTestSuite) expectTransferLock(payload entity.RoomFacilityTransfer, roomFacilities ...entity.RoomFacility) {
+	rows := sqlmock.NewRows([]string{"id", "room_id", "facility_id", "quantity", "description", "created_at", "updated_at"})
+	for _, roomFacility := range roomFacilities {
+		rows.AddRow(roomFacility.ID, roomFacility.RoomId, roomFacility.FacilityId, roomFacility.Quantity, roomFacility.Description, roomFacility.CreatedAt, roomFacility.UpdatedAt)
+	}
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.LockTransferRoomFacilities)).WithArgs(payload.RoomFacilityId, payload.RoomId).WillReturnRows(rows)
+}
+
+func (suite *RoomFacilityRepositoryTestSuite) expectStockLock(facilityId string, quantity int) {
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.LockFacilityQuantity)).WithArgs(facilityId).WillReturnRows(sqlmock.NewRows([]string{"quantity"}).AddRow(quantity))
+}
+
+func (suite *RoomFacilityRepositoryTestSuite) expectMovement(facilityId, kind string, quantity int, reason, referenceId string) {
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.InsertFacilityMovement)).WithArgs(facilityId, kind, quantity, "actor id", reason, referenceId).WillReturnRows(sqlmock.NewRows([]string{"id", "balance", "created_at"}).AddRow("movement id", 3, time.Time{}))
+}
+
+func (suite *RoomFacilityRepositoryTestSuite) expectAllocationLock(facilityId string, quantity int) {
+	suite.mockSql.ExpectQuery("SELECT facility_id, quantity FROM trx_room_facility").WithArgs(expectedRoomFacility.ID).WillReturnRows(sqlmock.NewRows([]string{"facility_id", "quantity"}).AddRow(facilityId, quantity))
+}
+
 func TestRoomFacilityRepositoryTestSuite(t *testing.T) {
 	suite.Run(t, new(RoomFacilityRepositoryTestSuite))
 }
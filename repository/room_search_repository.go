@@ -0,0 +1,135 @@
+package repository
+
+import (
+	"booking-room-app/config"
+	"booking-room-app/entity"
+	"booking-room-app/shared/model"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"log/slog"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+type RoomSearchRepository interface {
+	Search(ctx context.Context, search entity.RoomSearch, page, size int) ([]entity.RoomSearchItem, entity.RoomFacets, model.Paging, error)
+}
+
+type roomSearchRepository struct {
+	db       *sql.DB
+	timeouts Timeouts
+}
+
+// likeEscaper makes the wildcards of a search text match literally.
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
+// Search implements RoomSearchRepository. It returns a page of the matching
+// rooms, without their attributes, and the facets of all of them. Every room
+// has one status, so the status counts add up to the number of matches.
+func (r *roomSearchRepository) Search(ctx context.Context, search entity.RoomSearch, page, size int) ([]entity.RoomSearchItem, entity.RoomFacets, model.Paging, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeouts.Query)
+	defer cancel()
+
+	args := searchArgs(search)
+	offset := (page - 1) * size
+	rows, err := r.db.QueryContext(ctx, config.SelectRoomSearch, append(args, search.Sort, size, offset)...)
+	if err != nil {
+		slog.ErrorContext(ctx, "roomSearchRepository.SearchQuery", "err", err)
+		return nil, entity.RoomFacets{}, model.Paging{}, err
+	}
+	defer rows.Close()
+
+	items := []entity.RoomSearchItem{}
+	for rows.Next() {
+		var item entity.RoomSearchItem
+		var location roomLocation
+		var distance sql.NullInt64
+		room := &item.Room
+		err := rows.Scan(append(append([]any{&room.ID, &room.Name, &room.RoomType, &room.Capacity, &room.Status, &room.CreatedAt, &room.UpdatedAt}, location.dest()...), &distance)...)
+		if err != nil {
+			slog.ErrorContext(ctx, "roomSearchRepository.SearchScan", "err", err)
+			return nil, entity.RoomFacets{}, model.Paging{}, err
+		}
+		location.apply(room)
+		if distance.Valid && search.Sort == "distance" {
+			floors := int(distance.Int64)
+			item.Distance = &floors
+		}
+		items = append(items, item)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, entity.RoomFacets{}, model.Paging{}, err
+	}
+
+	facets, err := r.facets(ctx, args)
+	if err != nil {
+		return nil, entity.RoomFacets{}, model.Paging{}, err
+	}
+	totalRows := 0
+	for _, count := range facets.Status {
+		totalRows += count.Count
+	}
+
+	return items, facets, paging(page, size, totalRows), nil
+}
+
+func (r *roomSearchRepository) facets(ctx context.Context, args []any) (entity.RoomFacets, error) {
+	rows, err := r.db.QueryContext(ctx, config.SelectRoomSearchFacets, args...)
+	if err != nil {
+		slog.ErrorContext(ctx, "roomSearchRepository.FacetsQuery", "err", err)
+		return entity.RoomFacets{}, err
+	}
+	defer rows.Close()
+
+	facets := entity.RoomFacets{
+		Status:     []entity.FacetCount{},
+		RoomType:   []entity.FacetCount{},
+		Building:   []entity.FacetCount{},
+		Attributes: map[string][]entity.FacetCount{},
+	}
+	for rows.Next() {
+		var facet string
+		var count entity.FacetCount
+		if err := rows.Scan(&facet, &count.Value, &count.Label, &count.Count); err != nil {
+			slog.ErrorContext(ctx, "roomSearchRepository.FacetsScan", "err", err)
+			return entity.RoomFacets{}, err
+		}
+		switch facet {
+		case "status":
+			facets.Status = append(facets.Status, count)
+		case "roomType":
+			facets.RoomType = append(facets.RoomType, count)
+		case "building":
+			facets.Building = append(facets.Building, count)
+		default:
+			key := strings.TrimPrefix(facet, "attr.")
+			facets.Attributes[key] = append(facets.Attributes[key], count)
+		}
+	}
+	return facets, rows.Err()
+}
+
+// searchArgs are $1 to $13 of the search queries. The attribute filters are
+// passed as parallel arrays, with the values of each as a JSON array.
+func searchArgs(search entity.RoomSearch) []any {
+	var keys, values, lows, highs []string
+	for _, filter := range search.Attributes {
+		encoded, _ := json.Marshal(append([]string{}, filter.Values...))
+		keys = append(keys, filter.Key)
+		values = append(values, string(encoded))
+		lows = append(lows, filter.Min)
+		highs = append(highs, filter.Max)
+	}
+
+	return []any{
+		likeEscaper.Replace(search.Query), search.Status, search.RoomType, search.MinCapacity, search.MaxCapacity,
+		search.Location.SiteId, search.Location.BuildingId, search.Location.FloorId,
+		pq.Array(keys), pq.Array(values), pq.Array(lows), pq.Array(highs), search.NearFloorId,
+	}
+}
+
+func NewRoomSearchRepository(db *sql.DB, timeouts Timeouts) RoomSearchRepository {
+	return &roomSearchRepository{db: db, timeouts: timeouts}
+}
@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"booking-room-app/config"
+	"booking-room-app/entity"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type RoomSearchRepositoryTestSuite struct {
+	suite.Suite
+	mockDb  *sql.DB
+	mockSql sqlmock.Sqlmock
+	repo    RoomSearchRepository
+}
+
+func (suite *RoomSearchRepositoryTestSuite) SetupTest() {
+	db, mock, _ := sqlmock.New()
+	suite.mockDb = db
+	suite.mockSql = mock
+	suite.repo = NewRoomSearchRepository(suite.mockDb, DefaultTimeouts())
+}
+
+func (suite *RoomSearchRepositoryTestSuite) TestSearch_Success() {
+	now := time.Now()
+	search := entity.RoomSearch{
+		Query:       "50%",
+		MinCapacity: 6,
+		Attributes:  []entity.AttributeFilter{{Key: "layout", Values: []string{"boardroom", "u_shape"}}, {Key: "seats", Min: "10"}},
+		Sort:        "distance",
+		NearFloorId: "f1",
+	}
+	args := []driver.Value{`50\%`, "", "", 6, 0, "", "", "", pq.Array([]string{"layout", "seats"}), pq.Array([]string{`["boardroom","u_shape"]`, `[]`}), pq.Array([]string{"", "10"}), pq.Array([]string{"", ""}), "f1"}
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectRoomSearch)).WithArgs(append(args[:len(args):len(args)], "distance", 5, 0)...).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "room_type", "capacity", "status", "created_at", "updated_at", "floor_id", "floor_name", "level", "building_id", "building_name", "site_id", "site_name", "timezone", "distance"}).
+			AddRow("9", "Melati 50%", "meeting", 8, "available", now, now, "f2", "Second", 2, "b1", "Tower", "s1", "Jakarta", "Asia/Jakarta", 1).
+			AddRow("10", "Mawar 50%", "meeting", 6, "booked", now, now, nil, nil, nil, nil, nil, nil, nil, nil, nil))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectRoomSearchFacets)).WithArgs(args...).
+		WillReturnRows(sqlmock.NewRows([]string{"facet", "value", "label", "count"}).
+			AddRow("attr.layout", "boardroom", "", 2).
+			AddRow("building", "b1", "Tower", 1).
+			AddRow("roomType", "meeting", "", 2).
+			AddRow("status", "available", "", 1).
+			AddRow("status", "booked", "", 6))
+
+	items, facets, paging, err := suite.repo.Search(context.Background(), search, 1, 5)
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), items, 2)
+	assert.Equal(suite.T(), 1, *items[0].Distance)
+	assert.Equal(suite.T(), "Tower", items[0].Location.BuildingName)
+	assert.Nil(suite.T(), items[1].Distance)
+	assert.Equal(suite.T(), []entity.FacetCount{{Value: "boardroom", Count: 2}}, facets.Attributes["layout"])
+	assert.Equal(suite.T(), []entity.FacetCount{{Value: "b1", Label: "Tower", Count: 1}}, facets.Building)
+	assert.Equal(suite.T(), 7, paging.TotalRows)
+	assert.Equal(suite.T(), 2, paging.TotalPages)
+}
+
+func TestRoomSearchRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(RoomSearchRepositoryTestSuite))
+}
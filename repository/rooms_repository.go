@@ -4,31 +4,41 @@ import (
 	"booking-room-app/config"
 	"booking-room-app/entity"
 	"booking-room-app/shared/model"
+	"context"
 	"database/sql"
-	"log"
+	"log/slog"
 	"math"
 	"time"
 )
 
 type RoomRepository interface {
-	Create(payload entity.Room) (entity.Room, error)
-	Get(id string) (entity.Room, error)
-	List(page, size int) ([]entity.Room, model.Paging, error)
-	ListStatus(status string, page, size int) ([]entity.Room, model.Paging, error)
-	Update(payload entity.Room) (entity.Room, error)
-	UpdateStatus(payload entity.Room) (entity.Room, error)
+	Create(ctx context.Context, payload entity.Room) (entity.Room, error)
+	Get(ctx context.Context, id string) (entity.Room, error)
+	List(ctx context.Context, page, size int) ([]entity.Room, model.Paging, error)
+	ListStatus(ctx context.Context, status string, page, size int) ([]entity.Room, model.Paging, error)
+	ListByLocation(ctx context.Context, filter entity.LocationFilter, status string, page, size int) ([]entity.Room, model.Paging, error)
+	ListAvailable(ctx context.Context, filter entity.LocationFilter, start, end time.Time, page, size int) ([]entity.Room, model.Paging, error)
+	Update(ctx context.Context, payload entity.Room) (entity.Room, error)
+	UpdateStatus(ctx context.Context, payload entity.Room) (entity.Room, error)
+	ListArchived(ctx context.Context, page, size int) ([]entity.Room, model.Paging, error)
+	Archive(ctx context.Context, id string, cascade bool) (entity.Archival, error)
+	Restore(ctx context.Context, id string) (entity.Room, error)
 }
 
 type roomRepository struct {
-	db *sql.DB
+	db       *sql.DB
+	timeouts Timeouts
 }
 
 // Create implements RoomRepository.
-func (r *roomRepository) Create(payload entity.Room) (entity.Room, error) {
+func (r *roomRepository) Create(ctx context.Context, payload entity.Room) (entity.Room, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeouts.Query)
+	defer cancel()
+
 	var room entity.Room
-	err := r.db.QueryRow(config.InsertRoom, payload.Name, payload.RoomType, payload.Capacity, payload.Status).Scan(&room.ID, &room.CreatedAt, &room.UpdatedAt)
+	err := r.db.QueryRowContext(ctx, config.InsertRoom, payload.Name, payload.RoomType, payload.Capacity, payload.Status, payload.FloorId).Scan(&room.ID, &room.CreatedAt, &room.UpdatedAt)
 	if err != nil {
-		log.Println("roomRepository.CreateQueryRow", err.Error())
+		slog.ErrorContext(ctx, "roomRepository.CreateQueryRow", "err", err)
 		return entity.Room{}, err
 	}
 
@@ -36,46 +46,60 @@ func (r *roomRepository) Create(payload entity.Room) (entity.Room, error) {
 	room.RoomType = payload.RoomType
 	room.Capacity = payload.Capacity
 	room.Status = payload.Status
+	room.FloorId = payload.FloorId
 
 	return room, nil
 }
 
 // Get implements RoomRepository.
-func (r *roomRepository) Get(id string) (entity.Room, error) {
+func (r *roomRepository) Get(ctx context.Context, id string) (entity.Room, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeouts.Query)
+	defer cancel()
+
 	var room entity.Room
-	err := r.db.QueryRow(config.SelectRoomByID, id).Scan(&room.ID, &room.Name, &room.RoomType, &room.Capacity, &room.Status, &room.CreatedAt, &room.UpdatedAt)
+	var archived sql.NullTime
+	var location roomLocation
+	err := r.db.QueryRowContext(ctx, config.SelectRoomByID, id).Scan(append([]any{&room.ID, &room.Name, &room.RoomType, &room.Capacity, &room.Status, &room.CreatedAt, &room.UpdatedAt, &archived}, location.dest()...)...)
 	if err != nil {
-		log.Println("roomRepository.GetQueryRow", err.Error())
+		slog.ErrorContext(ctx, "roomRepository.GetQueryRow", "err", err)
 		return entity.Room{}, err
 	}
+	room.ArchivedAt = archivedAt(archived)
+	location.apply(&room)
 
 	return room, nil
 }
 
 // List implements RoomRepository.
-func (r *roomRepository) List(page, size int) ([]entity.Room, model.Paging, error) {
+func (r *roomRepository) List(ctx context.Context, page, size int) ([]entity.Room, model.Paging, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeouts.Query)
+	defer cancel()
+
 	var rooms []entity.Room
 	offset := (page - 1) * size
 
-	rows, err := r.db.Query(config.SelectRoomList, size, offset)
+	rows, err := r.db.QueryContext(ctx, config.SelectRoomList, size, offset)
 	if err != nil {
-		log.Println("roomRepository.ListQuery", err.Error())
+		slog.ErrorContext(ctx, "roomRepository.ListQuery", "err", err)
 		return []entity.Room{}, model.Paging{}, err
 	}
+	defer rows.Close()
 
 	for rows.Next() {
 		var room entity.Room
-		err := rows.Scan(&room.ID, &room.Name, &room.RoomType, &room.Capacity, &room.Status, &room.CreatedAt, &room.UpdatedAt)
+		var location roomLocation
+		err := rows.Scan(append([]any{&room.ID, &room.Name, &room.RoomType, &room.Capacity, &room.Status, &room.CreatedAt, &room.UpdatedAt}, location.dest()...)...)
 		if err != nil {
-			log.Println("roomRepository.ListScan", err.Error())
+			slog.ErrorContext(ctx, "roomRepository.ListScan", "err", err)
 			return []entity.Room{}, model.Paging{}, err
 		}
+		location.apply(&room)
 
 		rooms = append(rooms, room)
 	}
 
 	totalRows := 0
-	if err := r.db.QueryRow(config.SelectCountRoom).Scan(&totalRows); err != nil {
+	if err := r.db.QueryRowContext(ctx, config.SelectCountRoom).Scan(&totalRows); err != nil {
 		return nil, model.Paging{}, err
 	}
 
@@ -90,29 +114,127 @@ func (r *roomRepository) List(page, size int) ([]entity.Room, model.Paging, erro
 }
 
 // List with Status implements RoomRepository.
-func (r *roomRepository) ListStatus(status string, page, size int) ([]entity.Room, model.Paging, error) {
+func (r *roomRepository) ListStatus(ctx context.Context, status string, page, size int) ([]entity.Room, model.Paging, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeouts.Query)
+	defer cancel()
+
 	var rooms []entity.Room
 	offset := (page - 1) * size
 
-	rows, err := r.db.Query(config.SelectRoomListStatus, status, size, offset)
+	rows, err := r.db.QueryContext(ctx, config.SelectRoomListStatus, status, size, offset)
 	if err != nil {
-		log.Println("roomRepository.ListQuery", err.Error())
+		slog.ErrorContext(ctx, "roomRepository.ListQuery", "err", err)
 		return []entity.Room{}, model.Paging{}, err
 	}
+	defer rows.Close()
 
 	for rows.Next() {
 		var room entity.Room
-		err := rows.Scan(&room.ID, &room.Name, &room.RoomType, &room.Capacity, &room.Status, &room.CreatedAt, &room.UpdatedAt)
+		var location roomLocation
+		err := rows.Scan(append([]any{&room.ID, &room.Name, &room.RoomType, &room.Capacity, &room.Status, &room.CreatedAt, &room.UpdatedAt}, location.dest()...)...)
 		if err != nil {
-			log.Println("roomRepository.ListScan", err.Error())
+			slog.ErrorContext(ctx, "roomRepository.ListScan", "err", err)
 			return []entity.Room{}, model.Paging{}, err
 		}
+		location.apply(&room)
+
+		rooms = append(rooms, room)
+	}
+
+	totalRows := 0
+	if err := r.db.QueryRowContext(ctx, config.SelectCountRoomStatus, status).Scan(&totalRows); err != nil {
+		return nil, model.Paging{}, err
+	}
+
+	paging := model.Paging{
+		Page:        page,
+		RowsPerPage: size,
+		TotalRows:   totalRows,
+		TotalPages:  int(math.Ceil(float64(totalRows) / float64(size))),
+	}
+
+	return rooms, paging, nil
+}
+
+// ListByLocation implements RoomRepository.
+func (r *roomRepository) ListByLocation(ctx context.Context, filter entity.LocationFilter, status string, page, size int) ([]entity.Room, model.Paging, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeouts.Query)
+	defer cancel()
+
+	var rooms []entity.Room
+	offset := (page - 1) * size
+
+	rows, err := r.db.QueryContext(ctx, config.SelectRoomListByLocation, status, filter.SiteId, filter.BuildingId, filter.FloorId, size, offset)
+	if err != nil {
+		slog.ErrorContext(ctx, "roomRepository.ListByLocationQuery", "err", err)
+		return []entity.Room{}, model.Paging{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var room entity.Room
+		var location roomLocation
+		err := rows.Scan(append([]any{&room.ID, &room.Name, &room.RoomType, &room.Capacity, &room.Status, &room.CreatedAt, &room.UpdatedAt}, location.dest()...)...)
+		if err != nil {
+			slog.ErrorContext(ctx, "roomRepository.ListByLocationScan", "err", err)
+			return []entity.Room{}, model.Paging{}, err
+		}
+		location.apply(&room)
+
+		rooms = append(rooms, room)
+	}
+
+	totalRows := 0
+	if err := r.db.QueryRowContext(ctx, config.SelectCountRoomByLocation, status, filter.SiteId, filter.BuildingId, filter.FloorId).Scan(&totalRows); err != nil {
+		return nil, model.Paging{}, err
+	}
+
+	paging := model.Paging{
+		Page:        page,
+		RowsPerPage: size,
+		TotalRows:   totalRows,
+		TotalPages:  int(math.Ceil(float64(totalRows) / float64(size))),
+	}
+
+	return rooms, paging, nil
+}
+
+// ListAvailable implements RoomRepository. It returns the available rooms at
+// the location that can be booked from start to end: without a booking,
+// maintenance window or blackout overlapping the period, and open according
+// to their holidays and business hours.
+func (r *roomRepository) ListAvailable(ctx context.Context, filter entity.LocationFilter, start, end time.Time, page, size int) ([]entity.Room, model.Paging, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeouts.Query)
+	defer cancel()
+
+	rooms := []entity.Room{}
+	offset := (page - 1) * size
+
+	rows, err := r.db.QueryContext(ctx, config.SelectAvailableRooms, filter.SiteId, filter.BuildingId, filter.FloorId, start, end, start, end, size, offset)
+	if err != nil {
+		slog.ErrorContext(ctx, "roomRepository.ListAvailableQuery", "err", err)
+		return nil, model.Paging{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var room entity.Room
+		var location roomLocation
+		err := rows.Scan(append([]any{&room.ID, &room.Name, &room.RoomType, &room.Capacity, &room.Status, &room.CreatedAt, &room.UpdatedAt}, location.dest()...)...)
+		if err != nil {
+			slog.ErrorContext(ctx, "roomRepository.ListAvailableScan", "err", err)
+			return nil, model.Paging{}, err
+		}
+		location.apply(&room)
 
 		rooms = append(rooms, room)
 	}
+	if err := rows.Err(); err != nil {
+		return nil, model.Paging{}, err
+	}
 
 	totalRows := 0
-	if err := r.db.QueryRow(config.SelectCountRoomStatus, status).Scan(&totalRows); err != nil {
+	if err := r.db.QueryRowContext(ctx, config.SelectCountAvailableRooms, filter.SiteId, filter.BuildingId, filter.FloorId, start, end, start, end).Scan(&totalRows); err != nil {
 		return nil, model.Paging{}, err
 	}
 
@@ -127,14 +249,17 @@ func (r *roomRepository) ListStatus(status string, page, size int) ([]entity.Roo
 }
 
 // Update implements RoomRepository.
-func (r *roomRepository) Update(payload entity.Room) (entity.Room, error) {
+func (r *roomRepository) Update(ctx context.Context, payload entity.Room) (entity.Room, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeouts.Query)
+	defer cancel()
+
 	var room entity.Room
 	room.ID = payload.ID
 	payload.UpdatedAt = time.Now()
 
-	err := r.db.QueryRow(config.UpdateRoomByID, room.ID, payload.Name, payload.RoomType, payload.Capacity, payload.Status).Scan(&room.CreatedAt, &room.UpdatedAt)
+	err := r.db.QueryRowContext(ctx, config.UpdateRoomByID, room.ID, payload.Name, payload.RoomType, payload.Capacity, payload.Status, payload.FloorId).Scan(&room.CreatedAt, &room.UpdatedAt)
 	if err != nil {
-		log.Println("roomRepository.UpdateQueryRow", err.Error())
+		slog.ErrorContext(ctx, "roomRepository.UpdateQueryRow", "err", err)
 		return entity.Room{}, err
 	}
 
@@ -142,19 +267,23 @@ func (r *roomRepository) Update(payload entity.Room) (entity.Room, error) {
 	room.RoomType = payload.RoomType
 	room.Capacity = payload.Capacity
 	room.Status = payload.Status
+	room.FloorId = payload.FloorId
 
 	return room, nil
 }
 
 // UpdateStatus implements RoomRepository.
-func (r *roomRepository) UpdateStatus(payload entity.Room) (entity.Room, error) {
+func (r *roomRepository) UpdateStatus(ctx context.Context, payload entity.Room) (entity.Room, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeouts.Query)
+	defer cancel()
+
 	var room entity.Room
 	room.ID = payload.ID
 	payload.UpdatedAt = time.Now()
 
-	err := r.db.QueryRow(config.UpdateRoomStatus, room.ID, payload.Status).Scan(&room.Name, &room.RoomType, &room.Capacity, &room.CreatedAt, &room.UpdatedAt)
+	err := r.db.QueryRowContext(ctx, config.UpdateRoomStatus, room.ID, payload.Status).Scan(&room.Name, &room.RoomType, &room.Capacity, &room.CreatedAt, &room.UpdatedAt)
 	if err != nil {
-		log.Println("roomRepository.UpdateStatusQueryRow", err.Error())
+		slog.ErrorContext(ctx, "roomRepository.UpdateStatusQueryRow", "err", err)
 		return entity.Room{}, err
 	}
 
@@ -163,12 +292,107 @@ func (r *roomRepository) UpdateStatus(payload entity.Room) (entity.Room, error)
 	return room, nil
 }
 
+// ListArchived implements RoomRepository.
+func (r *roomRepository) ListArchived(ctx context.Context, page, size int) ([]entity.Room, model.Paging, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeouts.Query)
+	defer cancel()
+
+	var rooms []entity.Room
+	offset := (page - 1) * size
+
+	rows, err := r.db.QueryContext(ctx, config.SelectArchivedRoomList, size, offset)
+	if err != nil {
+		slog.ErrorContext(ctx, "roomRepository.ListArchivedQuery", "err", err)
+		return []entity.Room{}, model.Paging{}, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var room entity.Room
+		var archived sql.NullTime
+		err := rows.Scan(&room.ID, &room.Name, &room.RoomType, &room.Capacity, &room.Status, &room.CreatedAt, &room.UpdatedAt, &archived)
+		if err != nil {
+			slog.ErrorContext(ctx, "roomRepository.ListArchivedScan", "err", err)
+			return []entity.Room{}, model.Paging{}, err
+		}
+		room.ArchivedAt = archivedAt(archived)
+
+		rooms = append(rooms, room)
+	}
+
+	totalRows := 0
+	if err := r.db.QueryRowContext(ctx, config.SelectCountArchivedRoom).Scan(&totalRows); err != nil {
+		return nil, model.Paging{}, err
+	}
+
+	paging := model.Paging{
+		Page:        page,
+		RowsPerPage: size,
+		TotalRows:   totalRows,
+		TotalPages:  int(math.Ceil(float64(totalRows) / float64(size))),
+	}
+
+	return rooms, paging, nil
+}
+
+// Archive implements RoomRepository.
+func (r *roomRepository) Archive(ctx context.Context, id string, cascade bool) (entity.Archival, error) {
+	return archive(ctx, r.db, r.timeouts.Query, roomArchive, id, cascade)
+}
+
+// Restore implements RoomRepository.
+func (r *roomRepository) Restore(ctx context.Context, id string) (entity.Room, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeouts.Query)
+	defer cancel()
+
+	var room entity.Room
+	err := r.db.QueryRowContext(ctx, config.RestoreRoom, id).Scan(&room.ID, &room.Name, &room.RoomType, &room.Capacity, &room.Status, &room.CreatedAt, &room.UpdatedAt)
+	if err != nil {
+		slog.ErrorContext(ctx, "roomRepository.RestoreQueryRow", "err", err)
+		return entity.Room{}, err
+	}
+
+	return room, nil
+}
+
+// roomLocation receives the nullable floor, building and site columns that
+// follow the room columns in the room queries.
+type roomLocation struct {
+	floorId, floorName       sql.NullString
+	level                    sql.NullInt64
+	buildingId, buildingName sql.NullString
+	siteId, siteName         sql.NullString
+	timezone                 sql.NullString
+}
+
+func (l *roomLocation) dest() []any {
+	return []any{&l.floorId, &l.floorName, &l.level, &l.buildingId, &l.buildingName, &l.siteId, &l.siteName, &l.timezone}
+}
+
+// apply sets the floor and location of a room that is on a floor.
+func (l *roomLocation) apply(room *entity.Room) {
+	if !l.floorId.Valid {
+		return
+	}
+	room.FloorId = l.floorId.String
+	room.Location = &entity.RoomLocation{
+		SiteId:       l.siteId.String,
+		SiteName:     l.siteName.String,
+		Timezone:     l.timezone.String,
+		BuildingId:   l.buildingId.String,
+		BuildingName: l.buildingName.String,
+		FloorId:      l.floorId.String,
+		FloorName:    l.floorName.String,
+		Level:        int(l.level.Int64),
+	}
+}
+
 // create room (ADMIN) -GET
 // get all rooms (ALL ROLE) -GET
 // get by room by ID (ALL ROLE) -GET
 // update room status (GA & ADMIN) -PUT
 // update room (ADMIN) -PUT
 
-func NewRoomRepository(db *sql.DB) RoomRepository {
-	return &roomRepository{db: db}
+func NewRoomRepository(db *sql.DB, timeouts Timeouts) RoomRepository {
+	return &roomRepository{db: db, timeouts: timeouts}
 }
@@ -1,14 +1,18 @@
 package repository
 
 import (
+	"booking-room-app/config"
 	"booking-room-app/entity"
 	"booking-room-app/shared/model"
+	"context"
 	"database/sql"
 	"fmt"
+	"regexp"
 	"testing"
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 )
@@ -55,6 +59,8 @@ var expectedPaging = model.Paging{
 	TotalPages:  1,
 }
 
+var roomLocationColumns = []string{"floor_id", "floor_name", "level", "building_id", "building_name", "site_id", "site_name", "timezone"}
+
 type RoomRepositoryTestSuite struct {
 	suite.Suite
 	mockDb  *sql.DB
@@ -66,13 +72,13 @@ func (suite *RoomRepositoryTestSuite) SetupTest() {
 	db, mock, _ := sqlmock.New()
 	suite.mockDb = db
 	suite.mockSql = mock
-	suite.repo = NewRoomRepository(suite.mockDb)
+	suite.repo = NewRoomRepository(suite.mockDb, DefaultTimeouts())
 }
 
 func (suite *RoomRepositoryTestSuite) TestCreate_Success() {
-	suite.mockSql.ExpectQuery(`INSERT INTO rooms`).WithArgs(expectedRoom.Name, expectedRoom.RoomType, expectedRoom.Capacity, expectedRoom.Status).WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow(expectedRoom.ID, expectedRoom.CreatedAt, expectedRoom.UpdatedAt))
+	suite.mockSql.ExpectQuery(`INSERT INTO rooms`).WithArgs(expectedRoom.Name, expectedRoom.RoomType, expectedRoom.Capacity, expectedRoom.Status, expectedRoom.FloorId).WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow(expectedRoom.ID, expectedRoom.CreatedAt, expectedRoom.UpdatedAt))
 
-	actual, err := suite.repo.Create(expectedRoom)
+	actual, err := suite.repo.Create(context.Background(), expectedRoom)
 
 	assert.Nil(suite.T(), err)
 	assert.NoError(suite.T(), err)
@@ -82,7 +88,7 @@ func (suite *RoomRepositoryTestSuite) TestCreate_Success() {
 func (suite *RoomRepositoryTestSuite) TestCreate_Failure() {
 	suite.mockSql.ExpectQuery(`INSERT INTO rooms`).WillReturnError(fmt.Errorf("error"))
 
-	actual, err := suite.repo.Create(expectedRoom)
+	actual, err := suite.repo.Create(context.Background(), expectedRoom)
 
 	assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
@@ -90,33 +96,56 @@ func (suite *RoomRepositoryTestSuite) TestCreate_Failure() {
 }
 
 func (suite *RoomRepositoryTestSuite) TestGet_Success() {
-	suite.mockSql.ExpectQuery(`SELECT`).WithArgs(expectedRoom.ID).WillReturnRows(sqlmock.NewRows([]string{"id", "name", "room_type", "capacity", "status", "created_at", "updated_at"}).AddRow(expectedRoom.ID, expectedRoom.Name, expectedRoom.RoomType, expectedRoom.Capacity, expectedRoom.Status, expectedRoom.CreatedAt, expectedRoom.UpdatedAt))
+	suite.mockSql.ExpectQuery(`SELECT`).WithArgs(expectedRoom.ID).WillReturnRows(sqlmock.NewRows(append([]string{"id", "name", "room_type", "capacity", "status", "created_at", "updated_at", "archived_at"}, roomLocationColumns...)).AddRow(expectedRoom.ID, expectedRoom.Name, expectedRoom.RoomType, expectedRoom.Capacity, expectedRoom.Status, expectedRoom.CreatedAt, expectedRoom.UpdatedAt, nil, nil, nil, nil, nil, nil, nil, nil, nil))
 
-	actual, err := suite.repo.Get(expectedRoom.ID)
+	actual, err := suite.repo.Get(context.Background(), expectedRoom.ID)
 
 	assert.Nil(suite.T(), err)
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), expectedRoom.Name, actual.Name)
+	assert.Nil(suite.T(), actual.ArchivedAt)
 }
 
 func (suite *RoomRepositoryTestSuite) TestGet_Failure() {
 	suite.mockSql.ExpectQuery(`SELECT`).WillReturnError(fmt.Errorf("error"))
 
-	actual, err := suite.repo.Get(expectedRoom.ID)
+	actual, err := suite.repo.Get(context.Background(), expectedRoom.ID)
 
 	assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
 	assert.Equal(suite.T(), entity.Room{}, actual)
 }
 
+func (suite *RoomRepositoryTestSuite) TestGet_CanceledFailure() {
+	suite.mockSql.ExpectQuery(`SELECT`).WithArgs(expectedRoom.ID).WillDelayFor(time.Second).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := suite.repo.Get(ctx, expectedRoom.ID)
+
+	assert.ErrorIs(suite.T(), err, context.Canceled)
+}
+
+func (suite *RoomRepositoryTestSuite) TestGet_DeadlineFailure() {
+	repo := NewRoomRepository(suite.mockDb, Timeouts{Query: 10 * time.Millisecond})
+	suite.mockSql.ExpectQuery(`SELECT`).WithArgs(expectedRoom.ID).WillDelayFor(time.Second).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	start := time.Now()
+	_, err := repo.Get(context.Background(), expectedRoom.ID)
+
+	// the query is abandoned at the deadline instead of after its delay
+	assert.Error(suite.T(), err)
+	assert.Less(suite.T(), time.Since(start), 500*time.Millisecond)
+}
+
 func (suite *RoomRepositoryTestSuite) TestList_Success() {
-	rows := sqlmock.NewRows([]string{"id", "name", "room_type", "capacity", "status", "created_at", "updated_at"}).AddRow(expectedRooms[0].ID, expectedRooms[0].Name, expectedRooms[0].RoomType, expectedRooms[0].Capacity, expectedRooms[0].Status, expectedRooms[0].CreatedAt, expectedRooms[0].UpdatedAt).AddRow(expectedRooms[1].ID, expectedRooms[1].Name, expectedRooms[1].RoomType, expectedRooms[1].Capacity, expectedRooms[1].Status, expectedRooms[0].CreatedAt, expectedRooms[1].UpdatedAt)
+	rows := sqlmock.NewRows(append([]string{"id", "name", "room_type", "capacity", "status", "created_at", "updated_at"}, roomLocationColumns...)).AddRow(expectedRooms[0].ID, expectedRooms[0].Name, expectedRooms[0].RoomType, expectedRooms[0].Capacity, expectedRooms[0].Status, expectedRooms[0].CreatedAt, expectedRooms[0].UpdatedAt, nil, nil, nil, nil, nil, nil, nil, nil).AddRow(expectedRooms[1].ID, expectedRooms[1].Name, expectedRooms[1].RoomType, expectedRooms[1].Capacity, expectedRooms[1].Status, expectedRooms[0].CreatedAt, expectedRooms[1].UpdatedAt, nil, nil, nil, nil, nil, nil, nil, nil)
 
 	suite.mockSql.ExpectQuery(`SELECT`).WithArgs(size, offset).WillReturnRows(rows)
 
 	suite.mockSql.ExpectQuery(`SELECT`).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
 
-	actual, paging, err := suite.repo.List(page, size)
+	actual, paging, err := suite.repo.List(context.Background(), page, size)
 
 	assert.Nil(suite.T(), err)
 	assert.NoError(suite.T(), err)
@@ -127,7 +156,7 @@ func (suite *RoomRepositoryTestSuite) TestList_Success() {
 func (suite *RoomRepositoryTestSuite) TestList_Failure() {
 	suite.mockSql.ExpectQuery(`SELECT`).WithArgs(size, offset).WillReturnError(fmt.Errorf("error"))
 
-	_, _, err := suite.repo.List(page, size)
+	_, _, err := suite.repo.List(context.Background(), page, size)
 
 	assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
@@ -136,7 +165,7 @@ func (suite *RoomRepositoryTestSuite) TestList_Failure() {
 func (suite *RoomRepositoryTestSuite) TestList_ScanFailure() {
 	suite.mockSql.ExpectQuery(`SELECT`).WithArgs(size, offset).WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(expectedRoom.ID, expectedRoom.Name))
 
-	_, _, err := suite.repo.List(page, size)
+	_, _, err := suite.repo.List(context.Background(), page, size)
 
 	assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
@@ -147,20 +176,20 @@ func (suite *RoomRepositoryTestSuite) TestList_TotalRowsFailure() {
 
 	suite.mockSql.ExpectQuery(`SELECT`).WithArgs(size, offset).WillReturnError(fmt.Errorf("error"))
 
-	_, _, err := suite.repo.List(page, size)
+	_, _, err := suite.repo.List(context.Background(), page, size)
 
 	assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
 }
 
 func (suite *RoomRepositoryTestSuite) TestListStatus_Success() {
-	rows := sqlmock.NewRows([]string{"id", "name", "room_type", "capacity", "status", "created_at", "updated_at"}).AddRow(expectedRooms[0].ID, expectedRooms[0].Name, expectedRooms[0].RoomType, expectedRooms[0].Capacity, expectedRooms[0].Status, expectedRooms[0].CreatedAt, expectedRooms[0].UpdatedAt).AddRow(expectedRooms[1].ID, expectedRooms[1].Name, expectedRooms[1].RoomType, expectedRooms[1].Capacity, expectedRooms[1].Status, expectedRooms[0].CreatedAt, expectedRooms[1].UpdatedAt)
+	rows := sqlmock.NewRows(append([]string{"id", "name", "room_type", "capacity", "status", "created_at", "updated_at"}, roomLocationColumns...)).AddRow(expectedRooms[0].ID, expectedRooms[0].Name, expectedRooms[0].RoomType, expectedRooms[0].Capacity, expectedRooms[0].Status, expectedRooms[0].CreatedAt, expectedRooms[0].UpdatedAt, nil, nil, nil, nil, nil, nil, nil, nil).AddRow(expectedRooms[1].ID, expectedRooms[1].Name, expectedRooms[1].RoomType, expectedRooms[1].Capacity, expectedRooms[1].Status, expectedRooms[0].CreatedAt, expectedRooms[1].UpdatedAt, nil, nil, nil, nil, nil, nil, nil, nil)
 
 	suite.mockSql.ExpectQuery(`SELECT`).WithArgs(expectedRoom.Status, size, offset).WillReturnRows(rows)
 
 	suite.mockSql.ExpectQuery(`SELECT`).WithArgs(expectedRoom.Status).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
 
-	actual, paging, err := suite.repo.ListStatus(expectedRoom.Status, page, size)
+	actual, paging, err := suite.repo.ListStatus(context.Background(), expectedRoom.Status, page, size)
 
 	assert.Nil(suite.T(), err)
 	assert.NoError(suite.T(), err)
@@ -171,7 +200,7 @@ func (suite *RoomRepositoryTestSuite) TestListStatus_Success() {
 func (suite *RoomRepositoryTestSuite) TestListStatus_Failure() {
 	suite.mockSql.ExpectQuery(`SELECT`).WithArgs(expectedRoom.Status, size, offset).WillReturnError(fmt.Errorf("error"))
 
-	_, _, err := suite.repo.ListStatus(expectedRoom.Status, page, size)
+	_, _, err := suite.repo.ListStatus(context.Background(), expectedRoom.Status, page, size)
 
 	assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
@@ -180,7 +209,7 @@ func (suite *RoomRepositoryTestSuite) TestListStatus_Failure() {
 func (suite *RoomRepositoryTestSuite) TestListStatus_ScanFailure() {
 	suite.mockSql.ExpectQuery(`SELECT`).WithArgs(expectedRoom.Status, size, offset).WillReturnRows(sqlmock.NewRows([]string{"id", "name"}).AddRow(expectedRoom.ID, expectedRoom.Name))
 
-	_, _, err := suite.repo.ListStatus(expectedRoom.Status, page, size)
+	_, _, err := suite.repo.ListStatus(context.Background(), expectedRoom.Status, page, size)
 
 	assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
@@ -191,16 +220,16 @@ func (suite *RoomRepositoryTestSuite) TestListStatus_TotalRowsFailure() {
 
 	suite.mockSql.ExpectQuery(`SELECT`).WithArgs(expectedRoom.Status, size, offset).WillReturnError(fmt.Errorf("error"))
 
-	_, _, err := suite.repo.ListStatus(expectedRoom.Status, page, size)
+	_, _, err := suite.repo.ListStatus(context.Background(), expectedRoom.Status, page, size)
 
 	assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
 }
 
 func (suite *RoomRepositoryTestSuite) TestUpdate_Success() {
-	suite.mockSql.ExpectQuery(`UPDATE`).WithArgs(expectedRoom.ID, expectedRoom.Name, expectedRoom.RoomType, expectedRoom.Capacity, expectedRoom.Status).WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(expectedRoom.CreatedAt, expectedRoom.UpdatedAt))
+	suite.mockSql.ExpectQuery(`UPDATE`).WithArgs(expectedRoom.ID, expectedRoom.Name, expectedRoom.RoomType, expectedRoom.Capacity, expectedRoom.Status, expectedRoom.FloorId).WillReturnRows(sqlmock.NewRows([]string{"created_at", "updated_at"}).AddRow(expectedRoom.CreatedAt, expectedRoom.UpdatedAt))
 
-	actual, err := suite.repo.Update(expectedRoom)
+	actual, err := suite.repo.Update(context.Background(), expectedRoom)
 
 	assert.Nil(suite.T(), err)
 	assert.NoError(suite.T(), err)
@@ -210,7 +239,7 @@ func (suite *RoomRepositoryTestSuite) TestUpdate_Success() {
 func (suite *RoomRepositoryTestSuite) TestUpdate_Failure() {
 	suite.mockSql.ExpectQuery(`UPDATE`).WillReturnError(fmt.Errorf("error"))
 
-	actual, err := suite.repo.Update(expectedRoom)
+	actual, err := suite.repo.Update(context.Background(), expectedRoom)
 
 	assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
@@ -220,7 +249,7 @@ func (suite *RoomRepositoryTestSuite) TestUpdate_Failure() {
 func (suite *RoomRepositoryTestSuite) TestUpdateStatus_Success() {
 	suite.mockSql.ExpectQuery(`UPDATE`).WithArgs(expectedRoom.ID, expectedRoom.Status).WillReturnRows(sqlmock.NewRows([]string{"name", "room_type", "capacity", "created_at", "updated_at"}).AddRow(expectedRoom.Name, expectedRoom.RoomType, expectedRoom.Capacity, expectedRoom.CreatedAt, expectedRoom.UpdatedAt))
 
-	actual, err := suite.repo.UpdateStatus(expectedRoom)
+	actual, err := suite.repo.UpdateStatus(context.Background(), expectedRoom)
 
 	assert.Nil(suite.T(), err)
 	assert.NoError(suite.T(), err)
@@ -230,13 +259,148 @@ func (suite *RoomRepositoryTestSuite) TestUpdateStatus_Success() {
 func (suite *RoomRepositoryTestSuite) TestUpdateStatus_Failure() {
 	suite.mockSql.ExpectQuery(`UPDATE`).WillReturnError(fmt.Errorf("error"))
 
-	actual, err := suite.repo.UpdateStatus(expectedRoom)
+	actual, err := suite.repo.UpdateStatus(context.Background(), expectedRoom)
 
 	assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
 	assert.Equal(suite.T(), entity.Room{}, actual)
 }
 
+func (suite *RoomRepositoryTestSuite) TestArchive_Success() {
+	archivedAt := time.Now()
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.LockRoom)).WithArgs(expectedRoom.ID).WillReturnRows(sqlmock.NewRows([]string{"archived_at"}).AddRow(nil))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectOpenRoomTransactions)).WithArgs(expectedRoom.ID).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.ArchiveRoom)).WithArgs(expectedRoom.ID).WillReturnRows(sqlmock.NewRows([]string{"archived_at"}).AddRow(archivedAt))
+	suite.mockSql.ExpectCommit()
+
+	actual, err := suite.repo.Archive(actorCtx, expectedRoom.ID, false)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), entity.Archival{ID: expectedRoom.ID, ArchivedAt: archivedAt, DeclinedTransactions: []string{}}, actual)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *RoomRepositoryTestSuite) TestArchive_CascadeSuccess() {
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.LockRoom)).WithArgs(expectedRoom.ID).WillReturnRows(sqlmock.NewRows([]string{"archived_at"}).AddRow(nil))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectOpenRoomTransactions)).WithArgs(expectedRoom.ID).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("t1").AddRow("t2"))
+	suite.mockSql.ExpectExec(regexp.QuoteMeta(config.DeclineTransactions)).WithArgs(pq.Array([]string{"t1", "t2"})).WillReturnResult(sqlmock.NewResult(0, 2))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectBookedFacilities)).WithArgs(pq.Array([]string{"t1", "t2"})).WillReturnRows(sqlmock.NewRows([]string{"transaction_id", "facility_id", "sum"}).AddRow("t2", "f1", 3))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.InsertFacilityMovement)).WithArgs("f1", entity.MovementRelease, 3, "actor id", "booking declined", "t2").WillReturnRows(sqlmock.NewRows([]string{"id", "balance", "created_at"}).AddRow("m1", 5, time.Now()))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.ArchiveRoom)).WithArgs(expectedRoom.ID).WillReturnRows(sqlmock.NewRows([]string{"archived_at"}).AddRow(time.Now()))
+	suite.mockSql.ExpectCommit()
+
+	actual, err := suite.repo.Archive(actorCtx, expectedRoom.ID, true)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"t1", "t2"}, actual.DeclinedTransactions)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *RoomRepositoryTestSuite) TestArchive_OpenTransactionsFailure() {
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.LockRoom)).WithArgs(expectedRoom.ID).WillReturnRows(sqlmock.NewRows([]string{"archived_at"}).AddRow(nil))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectOpenRoomTransactions)).WithArgs(expectedRoom.ID).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow("t1"))
+	suite.mockSql.ExpectRollback()
+
+	_, err := suite.repo.Archive(actorCtx, expectedRoom.ID, false)
+
+	assert.ErrorIs(suite.T(), err, ErrOpenTransactions)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *RoomRepositoryTestSuite) TestArchive_AlreadyArchivedSuccess() {
+	archivedAt := time.Now().Add(-time.Hour)
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.LockRoom)).WithArgs(expectedRoom.ID).WillReturnRows(sqlmock.NewRows([]string{"archived_at"}).AddRow(archivedAt))
+	suite.mockSql.ExpectCommit()
+
+	actual, err := suite.repo.Archive(actorCtx, expectedRoom.ID, false)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), archivedAt, actual.ArchivedAt)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *RoomRepositoryTestSuite) TestArchive_NotFoundFailure() {
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.LockRoom)).WithArgs(expectedRoom.ID).WillReturnError(sql.ErrNoRows)
+	suite.mockSql.ExpectRollback()
+
+	_, err := suite.repo.Archive(actorCtx, expectedRoom.ID, false)
+
+	assert.ErrorIs(suite.T(), err, sql.ErrNoRows)
+}
+
+func (suite *RoomRepositoryTestSuite) TestRestore_Success() {
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.RestoreRoom)).WithArgs(expectedRoom.ID).WillReturnRows(sqlmock.NewRows([]string{"id", "name", "room_type", "capacity", "status", "created_at", "updated_at"}).AddRow(expectedRoom.ID, expectedRoom.Name, expectedRoom.RoomType, expectedRoom.Capacity, expectedRoom.Status, expectedRoom.CreatedAt, expectedRoom.UpdatedAt))
+
+	actual, err := suite.repo.Restore(context.Background(), expectedRoom.ID)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expectedRoom.Name, actual.Name)
+	assert.Nil(suite.T(), actual.ArchivedAt)
+}
+
+func (suite *RoomRepositoryTestSuite) TestListArchived_Success() {
+	archivedAt := time.Now()
+	rows := sqlmock.NewRows([]string{"id", "name", "room_type", "capacity", "status", "created_at", "updated_at", "archived_at"}).AddRow(expectedRoom.ID, expectedRoom.Name, expectedRoom.RoomType, expectedRoom.Capacity, expectedRoom.Status, expectedRoom.CreatedAt, expectedRoom.UpdatedAt, archivedAt)
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectArchivedRoomList)).WithArgs(size, offset).WillReturnRows(rows)
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectCountArchivedRoom)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	actual, paging, err := suite.repo.ListArchived(context.Background(), page, size)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), &archivedAt, actual[0].ArchivedAt)
+	assert.Equal(suite.T(), 1, paging.TotalRows)
+}
+
+func (suite *RoomRepositoryTestSuite) TestListByLocation_Success() {
+	filter := entity.LocationFilter{SiteId: "1"}
+	rows := sqlmock.NewRows(append([]string{"id", "name", "room_type", "capacity", "status", "created_at", "updated_at"}, roomLocationColumns...)).AddRow(expectedRoom.ID, expectedRoom.Name, expectedRoom.RoomType, expectedRoom.Capacity, expectedRoom.Status, expectedRoom.CreatedAt, expectedRoom.UpdatedAt, "3", "Ground floor", 0, "2", "Tower A", "1", "Jakarta HQ", "Asia/Jakarta")
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectRoomListByLocation)).WithArgs("available", "1", "", "", size, offset).WillReturnRows(rows)
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectCountRoomByLocation)).WithArgs("available", "1", "", "").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	actual, paging, err := suite.repo.ListByLocation(context.Background(), filter, "available", page, size)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "3", actual[0].FloorId)
+	assert.Equal(suite.T(), &entity.RoomLocation{SiteId: "1", SiteName: "Jakarta HQ", Timezone: "Asia/Jakarta", BuildingId: "2", BuildingName: "Tower A", FloorId: "3", FloorName: "Ground floor", Level: 0}, actual[0].Location)
+	assert.Equal(suite.T(), 1, paging.TotalRows)
+}
+
+func (suite *RoomRepositoryTestSuite) TestListByLocation_Failure() {
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectRoomListByLocation)).WillReturnError(fmt.Errorf("error"))
+
+	_, _, err := suite.repo.ListByLocation(context.Background(), entity.LocationFilter{FloorId: "3"}, "", page, size)
+
+	assert.Error(suite.T(), err)
+}
+
+func (suite *RoomRepositoryTestSuite) TestListAvailable_Success() {
+	start := time.Date(2024, time.January, 8, 9, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	rows := sqlmock.NewRows(append([]string{"id", "name", "room_type", "capacity", "status", "created_at", "updated_at"}, roomLocationColumns...)).AddRow(expectedRoom.ID, expectedRoom.Name, expectedRoom.RoomType, expectedRoom.Capacity, expectedRoom.Status, expectedRoom.CreatedAt, expectedRoom.UpdatedAt, nil, nil, nil, nil, nil, nil, nil, nil)
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectAvailableRooms)).WithArgs("1", "", "", start, end, start, end, size, 0).WillReturnRows(rows)
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectCountAvailableRooms)).WithArgs("1", "", "", start, end, start, end).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	actual, paging, err := suite.repo.ListAvailable(context.Background(), entity.LocationFilter{SiteId: "1"}, start, end, page, size)
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), actual, 1)
+	assert.Nil(suite.T(), actual[0].Location)
+	assert.Equal(suite.T(), 1, paging.TotalRows)
+}
+
+func (suite *RoomRepositoryTestSuite) TestListAvailable_Failure() {
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectAvailableRooms)).WillReturnError(fmt.Errorf("error"))
+
+	_, _, err := suite.repo.ListAvailable(context.Background(), entity.LocationFilter{}, time.Now(), time.Now().Add(time.Hour), page, size)
+
+	assert.Error(suite.T(), err)
+}
+
 func TestRoomRepositoryTestSuite(t *testing.T) {
 	suite.Run(t, new(RoomRepositoryTestSuite))
 }
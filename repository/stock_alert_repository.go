@@ -0,0 +1,176 @@
+package repository
+
+import (
+	"booking-room-app/config"
+	"booking-room-app/entity"
+	"booking-room-app/shared/model"
+	"context"
+	"database/sql"
+	"log/slog"
+
+	"github.com/lib/pq"
+)
+
+type StockAlertRepository interface {
+	GetThreshold(ctx context.Context, facilityId string) (entity.StockThreshold, error)
+	UpdateThreshold(ctx context.Context, payload entity.StockThreshold) (entity.StockThreshold, error)
+	Check(ctx context.Context, facilityIds []string) ([]entity.StockAlert, error)
+	ClaimNotifications(ctx context.Context) ([]entity.StockAlert, error)
+	UnclaimNotifications(ctx context.Context, ids []string) error
+	List(ctx context.Context, status string, page, size int) ([]entity.StockAlert, model.Paging, error)
+	Acknowledge(ctx context.Context, id, actorId string) (entity.StockAlert, error)
+}
+
+type stockAlertRepository struct {
+	db       *sql.DB
+	timeouts Timeouts
+}
+
+// GetThreshold implements StockAlertRepository.
+func (s *stockAlertRepository) GetThreshold(ctx context.Context, facilityId string) (entity.StockThreshold, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Query)
+	defer cancel()
+
+	var threshold entity.StockThreshold
+	err := s.db.QueryRowContext(ctx, config.SelectFacilityThreshold, facilityId).Scan(&threshold.FacilityId, &threshold.MinQuantity, &threshold.Quantity, &threshold.UpdatedAt)
+	if err != nil {
+		slog.ErrorContext(ctx, "stockAlertRepository.GetThresholdQueryRow", "err", err)
+		return entity.StockThreshold{}, err
+	}
+	return threshold, nil
+}
+
+// UpdateThreshold implements StockAlertRepository.
+func (s *stockAlertRepository) UpdateThreshold(ctx context.Context, payload entity.StockThreshold) (entity.StockThreshold, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Query)
+	defer cancel()
+
+	var threshold entity.StockThreshold
+	err := s.db.QueryRowContext(ctx, config.UpdateFacilityThreshold, payload.FacilityId, payload.MinQuantity).Scan(&threshold.FacilityId, &threshold.MinQuantity, &threshold.Quantity, &threshold.UpdatedAt)
+	if err != nil {
+		slog.ErrorContext(ctx, "stockAlertRepository.UpdateThresholdQueryRow", "err", err)
+		return entity.StockThreshold{}, err
+	}
+	return threshold, nil
+}
+
+// Check implements StockAlertRepository. It resolves the open alerts of the
+// facilities whose stock is back at their threshold and raises one for each
+// facility below it that has none, returning the raised alerts. A nil
+// facilityIds checks every facility.
+func (s *stockAlertRepository) Check(ctx context.Context, facilityIds []string) ([]entity.StockAlert, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Query)
+	defer cancel()
+
+	if _, err := s.db.ExecContext(ctx, config.ResolveStockAlerts, pq.Array(facilityIds)); err != nil {
+		slog.ErrorContext(ctx, "stockAlertRepository.CheckResolve", "err", err)
+		return nil, err
+	}
+
+	alerts, err := s.query(ctx, config.RaiseStockAlerts, pq.Array(facilityIds))
+	if err != nil {
+		slog.ErrorContext(ctx, "stockAlertRepository.CheckRaise", "err", err)
+		return nil, err
+	}
+	return alerts, nil
+}
+
+// ClaimNotifications implements StockAlertRepository. The open alerts that
+// were not sent yet are marked as notified and returned, so that only one
+// caller sends them.
+func (s *stockAlertRepository) ClaimNotifications(ctx context.Context) ([]entity.StockAlert, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Query)
+	defer cancel()
+
+	alerts, err := s.query(ctx, config.ClaimStockAlertNotifications)
+	if err != nil {
+		slog.ErrorContext(ctx, "stockAlertRepository.ClaimNotifications", "err", err)
+		return nil, err
+	}
+	return alerts, nil
+}
+
+// UnclaimNotifications implements StockAlertRepository, for alerts that
+// could not be sent.
+func (s *stockAlertRepository) UnclaimNotifications(ctx context.Context, ids []string) error {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Query)
+	defer cancel()
+
+	if _, err := s.db.ExecContext(ctx, config.UnclaimStockAlertNotifications, pq.Array(ids)); err != nil {
+		slog.ErrorContext(ctx, "stockAlertRepository.UnclaimNotifications", "err", err)
+		return err
+	}
+	return nil
+}
+
+// List implements StockAlertRepository. status is open, resolved or empty
+// for both.
+func (s *stockAlertRepository) List(ctx context.Context, status string, page, size int) ([]entity.StockAlert, model.Paging, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Query)
+	defer cancel()
+
+	alerts, err := s.query(ctx, config.SelectStockAlerts, status, size, (page-1)*size)
+	if err != nil {
+		slog.ErrorContext(ctx, "stockAlertRepository.ListQuery", "err", err)
+		return nil, model.Paging{}, err
+	}
+
+	totalRows := 0
+	if err := s.db.QueryRowContext(ctx, config.CountStockAlerts, status).Scan(&totalRows); err != nil {
+		slog.ErrorContext(ctx, "stockAlertRepository.ListCount", "err", err)
+		return nil, model.Paging{}, err
+	}
+
+	return alerts, paging(page, size, totalRows), nil
+}
+
+// Acknowledge implements StockAlertRepository.
+func (s *stockAlertRepository) Acknowledge(ctx context.Context, id, actorId string) (entity.StockAlert, error) {
+	ctx, cancel := context.WithTimeout(ctx, s.timeouts.Query)
+	defer cancel()
+
+	alert, err := scanStockAlert(s.db.QueryRowContext(ctx, config.AcknowledgeStockAlert, id, actorId))
+	if err != nil {
+		slog.ErrorContext(ctx, "stockAlertRepository.AcknowledgeQueryRow", "err", err)
+		return entity.StockAlert{}, err
+	}
+	return alert, nil
+}
+
+func (s *stockAlertRepository) query(ctx context.Context, query string, args ...any) ([]entity.StockAlert, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var alerts []entity.StockAlert
+	for rows.Next() {
+		alert, err := scanStockAlert(rows)
+		if err != nil {
+			return nil, err
+		}
+		alerts = append(alerts, alert)
+	}
+	return alerts, rows.Err()
+}
+
+func scanStockAlert(row rowScanner) (entity.StockAlert, error) {
+	var alert entity.StockAlert
+	err := row.Scan(
+		&alert.ID,
+		&alert.FacilityId,
+		&alert.FacilityName,
+		&alert.Quantity,
+		&alert.MinQuantity,
+		&alert.NotifiedAt,
+		&alert.AcknowledgedBy,
+		&alert.AcknowledgedAt,
+		&alert.ResolvedAt,
+		&alert.CreatedAt)
+	return alert, err
+}
+
+func NewStockAlertRepository(db *sql.DB, timeouts Timeouts) StockAlertRepository {
+	return &stockAlertRepository{db: db, timeouts: timeouts}
+}
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"booking-room-app/config"
+	"booking-room-app/entity"
+	"booking-room-app/shared/model"
+	"context"
+	"database/sql"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+var expectedStockAlert = entity.StockAlert{
+	ID:           "1",
+	FacilityId:   "2",
+	FacilityName: "HDMI adapter",
+	Quantity:     1,
+	MinQuantity:  3,
+	CreatedAt:    time.Now(),
+}
+
+var stockAlertRows = []string{"id", "facility_id", "name", "quantity", "min_quantity", "notified_at", "acknowledged_by", "acknowledged_at", "resolved_at", "created_at"}
+
+func stockAlertRow(rows *sqlmock.Rows, alert entity.StockAlert) *sqlmock.Rows {
+	return rows.AddRow(alert.ID, alert.FacilityId, alert.FacilityName, alert.Quantity, alert.MinQuantity, alert.NotifiedAt, alert.AcknowledgedBy, alert.AcknowledgedAt, alert.ResolvedAt, alert.CreatedAt)
+}
+
+type StockAlertRepositoryTestSuite struct {
+	suite.Suite
+	mockDb  *sql.DB
+	mockSql sqlmock.Sqlmock
+	repo    StockAlertRepository
+}
+
+func (suite *StockAlertRepositoryTestSuite) SetupTest() {
+	db, mock, _ := sqlmock.New()
+	suite.mockDb = db
+	suite.mockSql = mock
+	suite.repo = NewStockAlertRepository(suite.mockDb, DefaultTimeouts())
+}
+
+func (suite *StockAlertRepositoryTestSuite) TestUpdateThreshold_Success() {
+	threshold := entity.StockThreshold{FacilityId: "2", MinQuantity: 3, Quantity: 1, UpdatedAt: time.Now()}
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.UpdateFacilityThreshold)).WithArgs("2", 3).WillReturnRows(sqlmock.NewRows([]string{"id", "min_quantity", "quantity", "updated_at"}).AddRow("2", 3, 1, threshold.UpdatedAt))
+
+	actual, err := suite.repo.UpdateThreshold(context.Background(), entity.StockThreshold{FacilityId: "2", MinQuantity: 3})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), threshold, actual)
+}
+
+func (suite *StockAlertRepositoryTestSuite) TestUpdateThreshold_NotFoundFailure() {
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.UpdateFacilityThreshold)).WithArgs("2", 3).WillReturnError(sql.ErrNoRows)
+
+	_, err := suite.repo.UpdateThreshold(context.Background(), entity.StockThreshold{FacilityId: "2", MinQuantity: 3})
+
+	assert.ErrorIs(suite.T(), err, sql.ErrNoRows)
+}
+
+func (suite *StockAlertRepositoryTestSuite) TestCheck_Success() {
+	ids := []string{"2"}
+	suite.mockSql.ExpectExec(regexp.QuoteMeta(config.ResolveStockAlerts)).WithArgs(pq.Array(ids)).WillReturnResult(sqlmock.NewResult(0, 1))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.RaiseStockAlerts)).WithArgs(pq.Array(ids)).WillReturnRows(stockAlertRow(sqlmock.NewRows(stockAlertRows), expectedStockAlert))
+
+	actual, err := suite.repo.Check(context.Background(), ids)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []entity.StockAlert{expectedStockAlert}, actual)
+}
+
+func (suite *StockAlertRepositoryTestSuite) TestCheck_ResolveFailure() {
+	suite.mockSql.ExpectExec(regexp.QuoteMeta(config.ResolveStockAlerts)).WithArgs(pq.Array([]string(nil))).WillReturnError(fmt.Errorf("error"))
+
+	_, err := suite.repo.Check(context.Background(), nil)
+
+	assert.Error(suite.T(), err)
+}
+
+func (suite *StockAlertRepositoryTestSuite) TestClaimNotifications_Success() {
+	notifiedAt := time.Now()
+	alert := expectedStockAlert
+	alert.NotifiedAt = &notifiedAt
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.ClaimStockAlertNotifications)).WithArgs().WillReturnRows(stockAlertRow(sqlmock.NewRows(stockAlertRows), alert))
+
+	actual, err := suite.repo.ClaimNotifications(context.Background())
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []entity.StockAlert{alert}, actual)
+}
+
+func (suite *StockAlertRepositoryTestSuite) TestUnclaimNotifications_Success() {
+	suite.mockSql.ExpectExec(regexp.QuoteMeta(config.UnclaimStockAlertNotifications)).WithArgs(pq.Array([]string{"1"})).WillReturnResult(sqlmock.NewResult(0, 1))
+
+	err := suite.repo.UnclaimNotifications(context.Background(), []string{"1"})
+
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *StockAlertRepositoryTestSuite) TestList_Success() {
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectStockAlerts)).WithArgs("open", 5, 0).WillReturnRows(stockAlertRow(sqlmock.NewRows(stockAlertRows), expectedStockAlert))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.CountStockAlerts)).WithArgs("open").WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	actual, paging, err := suite.repo.List(context.Background(), "open", 1, 5)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []entity.StockAlert{expectedStockAlert}, actual)
+	assert.Equal(suite.T(), model.Paging{Page: 1, RowsPerPage: 5, TotalRows: 1, TotalPages: 1}, paging)
+}
+
+func (suite *StockAlertRepositoryTestSuite) TestList_Failure() {
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectStockAlerts)).WithArgs("", 5, 0).WillReturnError(fmt.Errorf("error"))
+
+	_, _, err := suite.repo.List(context.Background(), "", 1, 5)
+
+	assert.Error(suite.T(), err)
+}
+
+func (suite *StockAlertRepositoryTestSuite) TestAcknowledge_Success() {
+	acknowledgedAt := time.Now()
+	alert := expectedStockAlert
+	alert.AcknowledgedBy = "3"
+	alert.AcknowledgedAt = &acknowledgedAt
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.AcknowledgeStockAlert)).WithArgs("1", "3").WillReturnRows(stockAlertRow(sqlmock.NewRows(stockAlertRows), alert))
+
+	actual, err := suite.repo.Acknowledge(context.Background(), "1", "3")
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), alert, actual)
+}
+
+func (suite *StockAlertRepositoryTestSuite) TestAcknowledge_NotFoundFailure() {
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.AcknowledgeStockAlert)).WithArgs("1", "3").WillReturnError(sql.ErrNoRows)
+
+	_, err := suite.repo.Acknowledge(context.Background(), "1", "3")
+
+	assert.ErrorIs(suite.T(), err, sql.ErrNoRows)
+}
+
+func TestStockAlertRepositoryTestSuite(t *testing.T) {
+	suite.Run(t, new(StockAlertRepositoryTestSuite))
+}
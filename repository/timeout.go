@@ -0,0 +1,17 @@
+package repository
+
+import "time"
+
+// Timeouts are the deadlines applied to every repository operation on top of
+// the caller's context, so a slow query is cancelled even when the client
+// keeps waiting. Report is the longer one for reports and purges. They come
+// from DB_QUERY_TIMEOUT and DB_REPORT_TIMEOUT.
+type Timeouts struct {
+	Query  time.Duration
+	Report time.Duration
+}
+
+// DefaultTimeouts returns the default deadlines of the configuration.
+func DefaultTimeouts() Timeouts {
+	return Timeouts{Query: 5 * time.Second, Report: 30 * time.Second}
+}
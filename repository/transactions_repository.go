@@ -4,77 +4,68 @@ import (
 	"booking-room-app/config"
 	"booking-room-app/entity"
 	"booking-room-app/shared/model"
+	"context"
 	"database/sql"
-	"fmt"
-	"log"
+	"errors"
+	"log/slog"
 	"math"
+	"sort"
 	"time"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrRoomUnavailable   = errors.New("the room cannot be booked")
+	ErrInsufficientStock = errors.New("quantity more than stock")
+	ErrRoomNotFound      = errors.New("the room does not exist")
+	ErrFacilityNotFound  = errors.New("the facility does not exist")
+	// ErrFacilityArchived is returned when a booking requests an archived
+	// facility.
+	ErrFacilityArchived = errors.New("the facility is archived")
+	// ErrBookingDeclined is returned when the status of a declined booking is
+	// changed; its facilities have already gone back to the stock.
+	ErrBookingDeclined = errors.New("the booking is declined")
 )
 
 type TransactionsRepository interface {
-	Create(payload entity.Transaction) (entity.Transaction, error)
-	List(page, size int, startDate, endDate time.Time) ([]entity.Transaction, model.Paging, error)
-	GetTransactionById(id string) (entity.Transaction, error)
-	GetTransactionByEmployeId(EmployeeId string,page, size int) ([]entity.Transaction, model.Paging, error)
-	UpdatePemission(payload entity.Transaction) (entity.Transaction, error)
+	Create(ctx context.Context, payload entity.Transaction) (entity.Transaction, error)
+	List(ctx context.Context, page, size int, startDate, endDate time.Time) ([]entity.Transaction, model.Paging, error)
+	GetTransactionById(ctx context.Context, id string) (entity.Transaction, error)
+	GetTransactionByEmployeId(ctx context.Context, EmployeeId string, page, size int) ([]entity.Transaction, model.Paging, error)
+	UpdatePemission(ctx context.Context, payload entity.Transaction) (entity.Transaction, error)
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
 }
 
 type transactionsRepository struct {
-	db *sql.DB
+	db       *sql.DB
+	timeouts Timeouts
 }
 
 // list transaction (admin & GA) -GET
-func (t *transactionsRepository) List(page, size int,startDate, endDate time.Time) ([]entity.Transaction, model.Paging, error) {
-	var transactions []entity.Transaction
+func (t *transactionsRepository) List(ctx context.Context, page, size int, startDate, endDate time.Time) ([]entity.Transaction, model.Paging, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeouts.Query)
+	defer cancel()
+
 	offset := (page - 1) * size
 
-	rows, err := t.db.Query(config.SelectTransactionList, size, offset, startDate, endDate)
+	rows, err := t.db.QueryContext(ctx, config.SelectTransactionList, size, offset, startDate, endDate)
 	if err != nil {
-		log.Println("transactionsRepository.Query:", err.Error())
+		slog.ErrorContext(ctx, "transactionsRepository.Query", "err", err)
 		return nil, model.Paging{}, err
 	}
-	for rows.Next() {
-		var transaction entity.Transaction
-		err = rows.Scan(
-			&transaction.ID,
-			&transaction.EmployeeId,
-			&transaction.RoomId,
-			&transaction.Description,
-			&transaction.Status,
-			&transaction.StartTime,
-			&transaction.EndTime,
-			&transaction.CreatedAt,
-			&transaction.UpdatedAt)
+	transactions, err := scanTransactions(rows)
 	if err != nil {
-		log.Println("transactionsRepository.Rows.Next():", err.Error())
+		slog.ErrorContext(ctx, "transactionsRepository.Rows.Next()", "err", err)
 		return nil, model.Paging{}, err
 	}
 
-	RoomFacilitiesRows, err := t.db.Query(config.SelectRoomWithFacilities, transaction.RoomId)
-	if err!= nil {
-        log.Println("transactionsRepository.Query:", err.Error())
-        return nil, model.Paging{}, err
-    }
-	for RoomFacilitiesRows.Next() {
-		var roomFacility entity.RoomFacility
-        err = RoomFacilitiesRows.Scan(
-            &roomFacility.ID,
-            &roomFacility.FacilityId,
-            &roomFacility.Quantity,
-            &roomFacility.Description,
-            &roomFacility.CreatedAt,
-            &roomFacility.UpdatedAt)
-        if err!= nil {
-            log.Println("transactionsRepository.Rows.Next():", err.Error())
-            return nil, model.Paging{}, err
-        }
-        transaction.RoomFacilities = append(transaction.RoomFacilities, roomFacility)
-    }
-
-		transactions = append(transactions, transaction)
+	if err := t.attachFacilities(ctx, transactions); err != nil {
+		return nil, model.Paging{}, err
 	}
+
 	totalRows := 0
-	if err := t.db.QueryRow(config.GetIdListTransaction).Scan(&totalRows); err != nil {
+	if err := t.db.QueryRowContext(ctx, config.GetIdListTransaction).Scan(&totalRows); err != nil {
 		return nil,
 			model.Paging{}, err
 	}
@@ -90,54 +81,75 @@ func (t *transactionsRepository) List(page, size int,startDate, endDate time.Tim
 }
 
 // get transaction by id (GA) - GET
-func (t *transactionsRepository) GetTransactionById(id string) (entity.Transaction, error) {
+func (t *transactionsRepository) GetTransactionById(ctx context.Context, id string) (entity.Transaction, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeouts.Query)
+	defer cancel()
+
 	var transactions entity.Transaction
-	err := t.db.QueryRow(config.SelectTransactionByID, id).Scan(
+	err := t.db.QueryRowContext(ctx, config.SelectTransactionByID, id).Scan(
 		&transactions.ID,
 		&transactions.EmployeeId,
 		&transactions.RoomId,
 		&transactions.Description,
 		&transactions.Status,
-		&transactions.StartTime, 
+		&transactions.StartTime,
 		&transactions.EndTime,
 		&transactions.CreatedAt,
 		&transactions.UpdatedAt)
 	if err != nil {
 		return entity.Transaction{}, err
 	}
-		RoomFacilitiesRows, err := t.db.Query(config.SelectRoomWithFacilities, transactions.RoomId)
-		if err!= nil {
-			log.Println("transactionsRepository.Query:", err.Error())
-			return entity.Transaction{}, err
-		}
-		for RoomFacilitiesRows.Next() {
-			var roomFacility entity.RoomFacility
-			err = RoomFacilitiesRows.Scan(
-				&roomFacility.ID,
-				&roomFacility.FacilityId,
-				&roomFacility.Quantity,
-				&roomFacility.Description,
-				&roomFacility.CreatedAt,
-				&roomFacility.UpdatedAt)
-			if err!= nil {
-				log.Println("transactionsRoomFacilitiesRepository.Rows.Next():", err.Error())
-				return entity.Transaction{}, err
-			}
-			transactions.RoomFacilities = append(transactions.RoomFacilities, roomFacility)
-		}
-	return transactions, nil
+
+	result := []entity.Transaction{transactions}
+	if err := t.attachFacilities(ctx, result); err != nil {
+		return entity.Transaction{}, err
+	}
+	return result[0], nil
 }
 
 // list transaction by employee ID (employee) -GET
-func (t *transactionsRepository) GetTransactionByEmployeId(employeeId string,page, size int) ([]entity.Transaction, model.Paging, error) {
-	var transactions []entity.Transaction
+func (t *transactionsRepository) GetTransactionByEmployeId(ctx context.Context, employeeId string, page, size int) ([]entity.Transaction, model.Paging, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeouts.Query)
+	defer cancel()
+
 	offset := (page - 1) * size
 
-	rows, err := t.db.Query(config.SelectTransactionByEmployeeID, employeeId, size, offset)
-	
+	rows, err := t.db.QueryContext(ctx, config.SelectTransactionByEmployeeID, employeeId, size, offset)
+	if err != nil {
+		return nil, model.Paging{}, err
+	}
+	transactions, err := scanTransactions(rows)
 	if err != nil {
+		slog.ErrorContext(ctx, "transactionRepository.Rows.Next()", "err", err)
 		return nil, model.Paging{}, err
 	}
+
+	if err := t.attachFacilities(ctx, transactions); err != nil {
+		return nil, model.Paging{}, err
+	}
+
+	totalRows := 0
+	if err := t.db.QueryRowContext(ctx, config.GetEmployeeIdListTransaction, employeeId).Scan(&totalRows); err != nil {
+		return nil,
+			model.Paging{}, err
+	}
+
+	paging := model.Paging{
+		Page:        page,
+		RowsPerPage: size,
+		TotalRows:   totalRows,
+		TotalPages:  int(math.Ceil(float64(totalRows) / float64(size))),
+	}
+
+	return transactions, paging, nil
+}
+
+// scanTransactions reads and closes rows, so the connection is released
+// before the facilities of the page are loaded.
+func scanTransactions(rows *sql.Rows) ([]entity.Transaction, error) {
+	defer rows.Close()
+
+	var transactions []entity.Transaction
 	for rows.Next() {
 		var transaction entity.Transaction
 		err := rows.Scan(
@@ -146,135 +158,299 @@ func (t *transactionsRepository) GetTransactionByEmployeId(employeeId string,pag
 			&transaction.RoomId,
 			&transaction.Description,
 			&transaction.Status,
-			&transaction.StartTime, 
+			&transaction.StartTime,
 			&transaction.EndTime,
 			&transaction.CreatedAt,
 			&transaction.UpdatedAt)
 		if err != nil {
-			log.Println("transactionRepository.Rows.Next():",
-				err.Error())
-			return nil, model.Paging{}, err
-		}
-		RoomFacilitiesRows, err := t.db.Query(config.SelectRoomWithFacilities, transaction.RoomId)
-		if err!= nil {
-			log.Println("transactionsRepository.Query:", err.Error())
-			return nil, model.Paging{}, err
-		}
-		for RoomFacilitiesRows.Next() {
-			var roomFacility entity.RoomFacility
-			err = RoomFacilitiesRows.Scan(
-				&roomFacility.ID,
-				&roomFacility.FacilityId,
-				&roomFacility.Quantity,
-				&roomFacility.Description,
-				&roomFacility.CreatedAt,
-				&roomFacility.UpdatedAt)
-			if err!= nil {
-				log.Println("transactionsRepository.Rows.Next():", err.Error())
-				return nil, model.Paging{}, err
-			}
-			transaction.RoomFacilities = append(transaction.RoomFacilities, roomFacility)
+			return nil, err
 		}
 		transactions = append(transactions, transaction)
 	}
+	return transactions, rows.Err()
+}
 
-	totalRows := 0
-	if err := t.db.QueryRow(config.GetEmployeeIdListTransaction, employeeId).Scan(&totalRows); err != nil {
-		return nil,
-			model.Paging{}, err
+// attachFacilities loads the facilities requested by every transaction on
+// the page with a single query, whatever the page size.
+func (t *transactionsRepository) attachFacilities(ctx context.Context, transactions []entity.Transaction) error {
+	if len(transactions) == 0 {
+		return nil
 	}
 
-	paging := model.Paging{
-		Page:        page,
-		RowsPerPage: size,
-		TotalRows:   totalRows,
-		TotalPages:  int(math.Ceil(float64(totalRows) / float64(size))),
+	transactionIds := make([]string, len(transactions))
+	for i, transaction := range transactions {
+		transactionIds[i] = transaction.ID
 	}
 
-	return transactions, paging, nil
+	rows, err := t.db.QueryContext(ctx, config.SelectTransactionFacilitiesByTransactionIDs, pq.Array(transactionIds))
+	if err != nil {
+		slog.ErrorContext(ctx, "transactionsRepository.Query", "err", err)
+		return err
+	}
+	defer rows.Close()
+
+	facilities := make(map[string][]entity.TransactionFacility)
+	for rows.Next() {
+		var facility entity.TransactionFacility
+		err = rows.Scan(
+			&facility.ID,
+			&facility.TransactionId,
+			&facility.FacilityId,
+			&facility.Quantity,
+			&facility.Description,
+			&facility.CreatedAt,
+			&facility.UpdatedAt)
+		if err != nil {
+			slog.ErrorContext(ctx, "transactionFacilitiesRepository.Rows.Next()", "err", err)
+			return err
+		}
+		facilities[facility.TransactionId] = append(facilities[facility.TransactionId], facility)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for i := range transactions {
+		transactions[i].Facilities = facilities[transactions[i].ID]
+	}
+	return nil
 }
 
 // (create transaction) Request booking rooms (employee & admin) -POST
-func (t *transactionsRepository) Create(payload entity.Transaction) (entity.Transaction, error) {
+func (t *transactionsRepository) Create(ctx context.Context, payload entity.Transaction) (entity.Transaction, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeouts.Query)
+	defer cancel()
+
+	tx, err := t.db.BeginTx(ctx, nil)
+	if err != nil {
+		return entity.Transaction{}, err
+	}
+
 	var roomStatus string
-	err := t.db.QueryRow(config.SelectRoomByID2,
+	err = tx.QueryRowContext(ctx, config.LockRoomStatus,
 		payload.RoomId).Scan(&roomStatus)
+	if errors.Is(err, sql.ErrNoRows) {
+		tx.Rollback()
+		return entity.Transaction{}, ErrRoomNotFound
+	}
+	if err != nil {
+		tx.Rollback()
+		return entity.Transaction{}, err
+	}
+	if roomStatus != "available" {
+		tx.Rollback()
+		return entity.Transaction{}, ErrRoomUnavailable
+	}
+
+	// Kunci stok setiap fasilitas sebelum booking dicatat, berurutan menurut id
+	// supaya dua booking yang meminta fasilitas yang sama tidak saling menunggu
+	requested := make(map[string]int)
+	var facilityIds []string
+	for _, facility := range payload.Facilities {
+		if _, ok := requested[facility.FacilityId]; !ok {
+			facilityIds = append(facilityIds, facility.FacilityId)
+		}
+		requested[facility.FacilityId] += facility.Quantity
+	}
+	sort.Strings(facilityIds)
+	for _, facilityId := range facilityIds {
+		var quantity int
+		var archived bool
+		err = tx.QueryRowContext(ctx, config.SelectQuantityFacility,
+			facilityId).Scan(&quantity, &archived)
+		if errors.Is(err, sql.ErrNoRows) {
+			tx.Rollback()
+			return entity.Transaction{}, ErrFacilityNotFound
+		}
 		if err != nil {
+			tx.Rollback()
 			return entity.Transaction{}, err
 		}
-	if roomStatus != "available" {
-		return entity.Transaction{}, fmt.Errorf("the room cannot be booked")
+		if archived {
+			tx.Rollback()
+			return entity.Transaction{}, ErrFacilityArchived
+		}
+		if requested[facilityId] > quantity {
+			tx.Rollback()
+			return entity.Transaction{}, ErrInsufficientStock
+		}
 	}
-	var transactions entity.Transaction	
-	err = t.db.QueryRow(config.InsertTransactions,
+
+	err = tx.QueryRowContext(ctx, config.InsertTransactions,
 		payload.EmployeeId,
 		payload.RoomId,
 		payload.Description,
 		payload.StartTime,
 		payload.EndTime).Scan(&payload.ID, &payload.Status, &payload.CreatedAt, &payload.UpdatedAt)
-		if err != nil {
-			return entity.Transaction{}, err
-		}
+	if err != nil {
+		slog.ErrorContext(ctx, "transactionsRepository.Create", "err", err)
+		tx.Rollback()
+		return entity.Transaction{}, err
+	}
 
-		if payload.RoomFacilities == nil {
-			transactions = payload
-			return transactions, err
-		} else {
-		  // Insert ke tabel roomFacilities dan kurangi quantity di facilities
-			var roomFacilities[] entity.RoomFacility
-			for _, roomFacility := range payload.RoomFacilities {
-				err = t.db.QueryRow(config.InsertRoomFacility,
-					payload.RoomId,
-					roomFacility.FacilityId,
-					roomFacility.Quantity,
-					roomFacility.Description).Scan(&roomFacility.ID, &roomFacility.CreatedAt, &roomFacility.UpdatedAt)
-		
-				if err != nil {
-					return entity.Transaction{}, err
-				}
-				var quantity int
-				err = t.db.QueryRow(config.SelectQuantityFacility,
-					roomFacility.FacilityId).Scan(&quantity)
-				if err != nil {
-					return entity.Transaction{}, err
-				}
-				if roomFacility.Quantity > quantity {
-					return entity.Transaction{}, fmt.Errorf("quantity more than stock")
-				}
-		
-				// Kurangi quantity di tabel facilities
-				_, err := t.db.Query(config.UpdateFacilityQuantity,
-					roomFacility.Quantity,
-					roomFacility.FacilityId)
-				if err != nil {
-					return entity.Transaction{}, err
-				}
-				roomFacilities = append(roomFacilities, roomFacility)
+	if payload.Facilities != nil {
+		// Catat fasilitas yang diminta booking ini dan kurangi quantity di facilities
+		var facilities []entity.TransactionFacility
+		for _, facility := range payload.Facilities {
+			facility.TransactionId = payload.ID
+			err = tx.QueryRowContext(ctx, config.InsertTransactionFacility,
+				facility.TransactionId,
+				facility.FacilityId,
+				facility.Quantity,
+				facility.Description).Scan(&facility.ID, &facility.CreatedAt, &facility.UpdatedAt)
+			if err != nil {
+				slog.ErrorContext(ctx, "transactionsRepository.Create", "err", err)
+				tx.Rollback()
+				return entity.Transaction{}, err
 			}
-			payload.RoomFacilities = roomFacilities
 
+			// Kurangi quantity di tabel facilities
+			_, err = recordMovement(ctx, tx, entity.FacilityMovement{
+				FacilityId:  facility.FacilityId,
+				Kind:        entity.MovementAllocation,
+				Quantity:    -facility.Quantity,
+				Reason:      "booked",
+				ReferenceId: payload.ID,
+			})
+			if err != nil {
+				tx.Rollback()
+				return entity.Transaction{}, err
+			}
+			facilities = append(facilities, facility)
+		}
+		payload.Facilities = facilities
+	}
+
+	if err := tx.Commit(); err != nil {
+		return entity.Transaction{}, err
 	}
-	transactions = payload
-	return transactions, err
+	return payload, nil
 }
 
 // update permission (GA) -PUT
-func (t *transactionsRepository) UpdatePemission(payload entity.Transaction) (entity.Transaction, error) {
-	var transactions entity.Transaction
-	
-	err := t.db.QueryRow(config.UpdatePermission,
+// Declining a booking gives the facilities it took back to the stock, so a
+// declined booking cannot be accepted again. Accepting one stores the cost it
+// was priced at together with the status.
+func (t *transactionsRepository) UpdatePemission(ctx context.Context, payload entity.Transaction) (entity.Transaction, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeouts.Query)
+	defer cancel()
+
+	tx, err := t.db.BeginTx(ctx, nil)
+	if err != nil {
+		return entity.Transaction{}, err
+	}
+
+	var status string
+	if err := tx.QueryRowContext(ctx, config.LockTransactionStatus, payload.ID).Scan(&status); err != nil {
+		slog.ErrorContext(ctx, "transactionsRepository.UpdateStatus.Lock", "err", err)
+		tx.Rollback()
+		return entity.Transaction{}, err
+	}
+	if status == "declined" && payload.Status != "declined" {
+		tx.Rollback()
+		return entity.Transaction{}, ErrBookingDeclined
+	}
+
+	err = tx.QueryRowContext(ctx, config.UpdatePermission,
 		payload.Status,
-		payload.ID).Scan(&payload.EmployeeId, &payload.RoomId,&payload.Description,&payload.StartTime, &payload.EndTime, &payload.CreatedAt)
+		payload.ID).Scan(&payload.EmployeeId, &payload.RoomId, &payload.Description, &payload.StartTime, &payload.EndTime, &payload.CreatedAt)
+	if err != nil {
+		slog.ErrorContext(ctx, "transactionsRepository.UpdateStatus", "err", err)
+		tx.Rollback()
+		return entity.Transaction{}, err
+	}
+
+	if payload.Status == "declined" && status != "declined" {
+		if err := releaseBookings(ctx, tx, []string{payload.ID}); err != nil {
+			tx.Rollback()
+			return entity.Transaction{}, err
+		}
+	}
+
+	if payload.Status == "accepted" && payload.Cost != nil {
+		cost := *payload.Cost
+		cost.TransactionId = payload.ID
+		err = tx.QueryRowContext(ctx, config.UpsertTransactionCost,
+			cost.TransactionId,
+			cost.HourlyRate,
+			cost.RoomCost,
+			cost.FacilityCost,
+			cost.TotalCost).Scan(&cost.CreatedAt)
 		if err != nil {
-			log.Println("transactionsRepository.UpdateStatus:", err.Error())
+			slog.ErrorContext(ctx, "transactionsRepository.UpdateStatus.Cost", "err", err)
+			tx.Rollback()
 			return entity.Transaction{}, err
 		}
+		payload.Cost = &cost
+	}
+
+	if err := tx.Commit(); err != nil {
+		return entity.Transaction{}, err
+	}
+	return payload, nil
+}
+
+// releaseBookings records the release of the facilities the given bookings
+// still hold of the stock, when they are declined.
+func releaseBookings(ctx context.Context, tx *sql.Tx, transactionIds []string) error {
+	rows, err := tx.QueryContext(ctx, config.SelectBookedFacilities, pq.Array(transactionIds))
+	if err != nil {
+		slog.ErrorContext(ctx, "transactionsRepository.releaseBookings", "err", err)
+		return err
+	}
+	var releases []entity.FacilityMovement
+	for rows.Next() {
+		release := entity.FacilityMovement{Kind: entity.MovementRelease, Reason: "booking declined"}
+		if err := rows.Scan(&release.ReferenceId, &release.FacilityId, &release.Quantity); err != nil {
+			rows.Close()
+			return err
+		}
+		releases = append(releases, release)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
 
-	transactions = payload
-	return transactions, err
+	for _, release := range releases {
+		if _, err := recordMovement(ctx, tx, release); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
-func NewTransactionsRepository(db *sql.DB) TransactionsRepository {
-	return &transactionsRepository{db: db}
+// DeleteBefore removes the transactions that ended before the given time,
+// together with their facilities and costs, and returns how many were removed.
+func (t *transactionsRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	ctx, cancel := context.WithTimeout(ctx, t.timeouts.Report)
+	defer cancel()
+
+	tx, err := t.db.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	for _, query := range []string{config.DeleteTransactionFacilitiesBefore, config.DeleteTransactionCostsBefore} {
+		if _, err := tx.ExecContext(ctx, query, before); err != nil {
+			slog.ErrorContext(ctx, "transactionsRepository.DeleteBefore", "err", err)
+			tx.Rollback()
+			return 0, err
+		}
+	}
+	result, err := tx.ExecContext(ctx, config.DeleteTransactionsBefore, before)
+	if err != nil {
+		slog.ErrorContext(ctx, "transactionsRepository.DeleteBefore", "err", err)
+		tx.Rollback()
+		return 0, err
+	}
+	deleted, err := result.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return 0, err
+	}
+	return deleted, tx.Commit()
 }
 
+func NewTransactionsRepository(db *sql.DB, timeouts Timeouts) TransactionsRepository {
+	return &transactionsRepository{db: db, timeouts: timeouts}
+}
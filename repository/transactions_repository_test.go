@@ -3,6 +3,7 @@ package repository
 import (
 	"booking-room-app/config"
 	"booking-room-app/entity"
+	"context"
 	"database/sql"
 	"fmt"
 	"regexp"
@@ -10,6 +11,7 @@ import (
 	"time"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/lib/pq"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 )
@@ -20,7 +22,7 @@ var expectedTransactions = entity.Transaction{
 	ID:        "1",
     EmployeeId: "1",
     RoomId:    "1",
-	RoomFacilities: []entity.RoomFacility{expectedRoomFacilities},
+	Facilities: []entity.TransactionFacility{expectedTransactionFacilities},
 	Status: "pending",
 	StartTime:  time.Now(),
 	EndTime:  time.Now(),
@@ -32,10 +34,10 @@ var expectedTransaction = []entity.Transaction{
 	{ID:        "1",
     EmployeeId: "1",
     RoomId:    "1",
-	RoomFacilities: []entity.RoomFacility{
+	Facilities: []entity.TransactionFacility{
 		{
 			ID:        "1",
-			RoomId:    "1",
+			TransactionId:    "1",
 			FacilityId: "1",
 			Quantity:  1,
 			CreatedAt: time.Now(),
@@ -43,7 +45,7 @@ var expectedTransaction = []entity.Transaction{
 		},
 		{
 			ID:        "2",
-			RoomId:    "2",
+			TransactionId:    "2",
 			FacilityId: "2",
 			Quantity:  2,
 			CreatedAt: time.Now(),
@@ -59,10 +61,10 @@ var expectedTransaction = []entity.Transaction{
 	{ID:        "2",
     EmployeeId: "2",
     RoomId:    "2",
-	RoomFacilities: []entity.RoomFacility{
+	Facilities: []entity.TransactionFacility{
 		{
 			ID:        "1",
-			RoomId:    "1",
+			TransactionId:    "1",
 			FacilityId: "1",
 			Quantity:  1,
 			CreatedAt: time.Now(),
@@ -70,7 +72,7 @@ var expectedTransaction = []entity.Transaction{
 		},
 		{
 			ID:        "2",
-			RoomId:    "2",
+			TransactionId:    "2",
 			FacilityId: "2",
 			Quantity:  2,
 			CreatedAt: time.Now(),
@@ -85,9 +87,9 @@ var expectedTransaction = []entity.Transaction{
 	},
 }
 
-var expectedRoomFacilities = entity.RoomFacility {
+var expectedTransactionFacilities = entity.TransactionFacility {
 	ID:        "1",
-    RoomId:    "1",
+    TransactionId:    "1",
     FacilityId: "1",
     Quantity: 1,
 	Description: "test",
@@ -107,10 +109,12 @@ func (suite *TransactionsRepositoryTestSuite) SetupTest() {
 	db, mock, _ := sqlmock.New()
 	suite.mockDb = db
 	suite.mockSql = mock
-	suite.repo = NewTransactionsRepository(suite.mockDb)
+	suite.repo = NewTransactionsRepository(suite.mockDb, DefaultTimeouts())
 }
 
 func (suite *TransactionsRepositoryTestSuite) TestUpdatePermission_Success() {
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.LockTransactionStatus)).WithArgs(expectedTransactions.ID).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("pending"))
 	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.UpdatePermission)).WithArgs(expectedTransactions.Status, expectedTransactions.ID).WillReturnRows(
 	sqlmock.NewRows([]string{"employee_id", "room_id", "description", "start_time", "end_time", "created_at"}).AddRow(
 		expectedTransactions.EmployeeId, 
@@ -120,19 +124,108 @@ func (suite *TransactionsRepositoryTestSuite) TestUpdatePermission_Success() {
 		expectedTransactions.EndTime,
 		expectedTransactions.CreatedAt,
 		))
+	suite.mockSql.ExpectCommit()
 
-	actual, err := suite.repo.UpdatePemission(expectedTransactions)
+	actual, err := suite.repo.UpdatePemission(actorCtx, expectedTransactions)
 	assert.Nil(suite.T(), err)
 	assert.Equal(suite.T(), expectedTransactions.Description, actual.Description)
 }
 
 func (suite *TransactionsRepositoryTestSuite) TestUpdatePermission_Fail() {
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.LockTransactionStatus)).WithArgs(expectedTransactions.ID).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("pending"))
 	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.UpdatePermission)).WithArgs(expectedTransactions.Status, expectedTransactions.ID).WillReturnRows(sqlmock.NewRows([]string{"employee_id"}).AddRow(expectedTransactions.EmployeeId))
+	suite.mockSql.ExpectRollback()
 
-	_, err := suite.repo.UpdatePemission(expectedTransactions)
+	_, err := suite.repo.UpdatePemission(actorCtx, expectedTransactions)
 	assert.Error(suite.T(), err)
 }
 
+func (suite *TransactionsRepositoryTestSuite) TestUpdatePermission_DeclineReleasesStock() {
+	declined := expectedTransactions
+	declined.Status = "declined"
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.LockTransactionStatus)).WithArgs(declined.ID).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("accepted"))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.UpdatePermission)).WithArgs("declined", declined.ID).WillReturnRows(
+		sqlmock.NewRows([]string{"employee_id", "room_id", "description", "start_time", "end_time", "created_at"}).AddRow(declined.EmployeeId, declined.RoomId, declined.Description, declined.StartTime, declined.EndTime, declined.CreatedAt))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectBookedFacilities)).WithArgs(pq.Array([]string{declined.ID})).WillReturnRows(
+		sqlmock.NewRows([]string{"transaction_id", "facility_id", "sum"}).AddRow(declined.ID, "1", 2))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.InsertFacilityMovement)).WithArgs("1", entity.MovementRelease, 2, "actor id", "booking declined", declined.ID).WillReturnRows(
+		sqlmock.NewRows([]string{"id", "balance", "created_at"}).AddRow("m1", 12, time.Now()))
+	suite.mockSql.ExpectCommit()
+
+	_, err := suite.repo.UpdatePemission(actorCtx, declined)
+
+	assert.NoError(suite.T(), err)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *TransactionsRepositoryTestSuite) TestUpdatePermission_DeclineTwiceSuccess() {
+	declined := expectedTransactions
+	declined.Status = "declined"
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.LockTransactionStatus)).WithArgs(declined.ID).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("declined"))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.UpdatePermission)).WithArgs("declined", declined.ID).WillReturnRows(
+		sqlmock.NewRows([]string{"employee_id", "room_id", "description", "start_time", "end_time", "created_at"}).AddRow(declined.EmployeeId, declined.RoomId, declined.Description, declined.StartTime, declined.EndTime, declined.CreatedAt))
+	suite.mockSql.ExpectCommit()
+
+	_, err := suite.repo.UpdatePemission(actorCtx, declined)
+
+	assert.NoError(suite.T(), err)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *TransactionsRepositoryTestSuite) TestUpdatePermission_AcceptSavesCost() {
+	accepted := expectedTransactions
+	accepted.Status = "accepted"
+	accepted.Cost = &entity.TransactionCost{HourlyRate: 150000, RoomCost: 150000, FacilityCost: 25000, TotalCost: 175000}
+	createdAt := time.Now()
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.LockTransactionStatus)).WithArgs(accepted.ID).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("pending"))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.UpdatePermission)).WithArgs("accepted", accepted.ID).WillReturnRows(
+		sqlmock.NewRows([]string{"employee_id", "room_id", "description", "start_time", "end_time", "created_at"}).AddRow(accepted.EmployeeId, accepted.RoomId, accepted.Description, accepted.StartTime, accepted.EndTime, accepted.CreatedAt))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.UpsertTransactionCost)).WithArgs(accepted.ID, int64(150000), int64(150000), int64(25000), int64(175000)).WillReturnRows(
+		sqlmock.NewRows([]string{"created_at"}).AddRow(createdAt))
+	suite.mockSql.ExpectCommit()
+
+	actual, err := suite.repo.UpdatePemission(actorCtx, accepted)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), accepted.ID, actual.Cost.TransactionId)
+	assert.Equal(suite.T(), createdAt, actual.Cost.CreatedAt)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *TransactionsRepositoryTestSuite) TestUpdatePermission_CostFailRollback() {
+	accepted := expectedTransactions
+	accepted.Status = "accepted"
+	accepted.Cost = &entity.TransactionCost{TotalCost: 175000}
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.LockTransactionStatus)).WithArgs(accepted.ID).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("pending"))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.UpdatePermission)).WithArgs("accepted", accepted.ID).WillReturnRows(
+		sqlmock.NewRows([]string{"employee_id", "room_id", "description", "start_time", "end_time", "created_at"}).AddRow(accepted.EmployeeId, accepted.RoomId, accepted.Description, accepted.StartTime, accepted.EndTime, accepted.CreatedAt))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.UpsertTransactionCost)).WithArgs(accepted.ID, int64(0), int64(0), int64(0), int64(175000)).WillReturnError(fmt.Errorf("error"))
+	suite.mockSql.ExpectRollback()
+
+	_, err := suite.repo.UpdatePemission(actorCtx, accepted)
+
+	assert.Error(suite.T(), err)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *TransactionsRepositoryTestSuite) TestUpdatePermission_AcceptDeclinedFail() {
+	accepted := expectedTransactions
+	accepted.Status = "accepted"
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.LockTransactionStatus)).WithArgs(accepted.ID).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("declined"))
+	suite.mockSql.ExpectRollback()
+
+	_, err := suite.repo.UpdatePemission(actorCtx, accepted)
+
+	assert.ErrorIs(suite.T(), err, ErrBookingDeclined)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
 func (suite *TransactionsRepositoryTestSuite) TestGetByEmployeeId_Success() {
 	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectTransactionByEmployeeID)).WithArgs(expectedTransactions.EmployeeId, size, offset).WillReturnRows(sqlmock.NewRows([]string{"id", "employee_id", "room_id","description", "status", "start_time", "end_time", "created_at", "updated_at"}).AddRow(
 		expectedTransactions.ID, 
@@ -145,28 +238,28 @@ func (suite *TransactionsRepositoryTestSuite) TestGetByEmployeeId_Success() {
 		expectedTransactions.CreatedAt, 
 		expectedTransactions.UpdatedAt))
 
-	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectRoomWithFacilities)).WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{"r.id", "r.facility_id", "r.quantity", "r.description", "r.created_at", "r.updated_at"}).AddRow(
-		expectedRoomFacilities.ID, 
-		expectedRoomFacilities.FacilityId,
-		expectedRoomFacilities.Quantity, 
-		expectedRoomFacilities.Description, 
-		expectedRoomFacilities.CreatedAt, 
-		expectedRoomFacilities.UpdatedAt))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectTransactionFacilitiesByTransactionIDs)).WithArgs(pq.Array([]string{"1"})).WillReturnRows(sqlmock.NewRows([]string{"id", "transaction_id", "facility_id", "quantity", "description", "created_at", "updated_at"}).AddRow(
+		expectedTransactionFacilities.ID, expectedTransactionFacilities.TransactionId, 
+		expectedTransactionFacilities.FacilityId,
+		expectedTransactionFacilities.Quantity, 
+		expectedTransactionFacilities.Description, 
+		expectedTransactionFacilities.CreatedAt, 
+		expectedTransactionFacilities.UpdatedAt))
 
 	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.GetEmployeeIdListTransaction)).WithArgs(expectedTransactions.EmployeeId).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
-    _, _, err := suite.repo.GetTransactionByEmployeId(expectedTransactions.ID, page, size)
+    _, _, err := suite.repo.GetTransactionByEmployeId(context.Background(), expectedTransactions.ID, page, size)
     assert.Nil(suite.T(), err)
 	assert.NoError(suite.T(), err)
 }
 
 func (suite *TransactionsRepositoryTestSuite) TestGetByEmployeeId_Fail() {
-	_, _, err := suite.repo.GetTransactionByEmployeId(expectedTransactions.EmployeeId, size, offset)
+	_, _, err := suite.repo.GetTransactionByEmployeId(context.Background(), expectedTransactions.EmployeeId, size, offset)
 	suite.Error(err)
 	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectTransactionByEmployeeID)).WithArgs(expectedTransactions.EmployeeId, size, offset).
 		WillReturnRows(sqlmock.NewRows([]string{"employee_id"}).
 		AddRow(expectedTransactions.EmployeeId))
 
-    _, _, err = suite.repo.GetTransactionByEmployeId("1", page, size)
+    _, _, err = suite.repo.GetTransactionByEmployeId(context.Background(), "1", page, size)
     assert.Error(suite.T(), err)
 	assert.NotNil(suite.T(), err)
 }
@@ -174,9 +267,9 @@ func (suite *TransactionsRepositoryTestSuite) TestGetByEmployeeId_Fail() {
 func (suite *TransactionsRepositoryTestSuite) TestGetIdEmployeeId_Fail() {
 	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectTransactionByEmployeeID)).WithArgs(expectedTransactions.EmployeeId, size, offset).WillReturnRows(sqlmock.NewRows([]string{"id", "employee_id", "room_id","description", "status", "start_time", "end_time", "created_at", "updated_at"}).AddRow(expectedTransactions.ID, expectedTransactions.EmployeeId, expectedTransactions.RoomId, expectedTransactions.Description, expectedTransactions.Status, expectedTransactions.StartTime, expectedTransactions.EndTime, expectedTransactions.CreatedAt, expectedTransactions.UpdatedAt))
 
-	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectRoomWithFacilities)).WillReturnRows(sqlmock.NewRows([]string{"r.id", "r.facility_id", "r.quantity", "r.description", "r.created_at", "r.updated_at"}).AddRow(expectedRoomFacilities.ID, expectedRoomFacilities.FacilityId, expectedRoomFacilities.Quantity, expectedRoomFacilities.Description,expectedRoomFacilities.CreatedAt, expectedRoomFacilities.UpdatedAt))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectTransactionFacilitiesByTransactionIDs)).WithArgs(pq.Array([]string{"1"})).WillReturnRows(sqlmock.NewRows([]string{"id", "transaction_id", "facility_id", "quantity", "description", "created_at", "updated_at"}).AddRow(expectedTransactionFacilities.ID, expectedTransactionFacilities.TransactionId, expectedTransactionFacilities.FacilityId, expectedTransactionFacilities.Quantity, expectedTransactionFacilities.Description,expectedTransactionFacilities.CreatedAt, expectedTransactionFacilities.UpdatedAt))
 
-    _, _, err := suite.repo.GetTransactionByEmployeId(expectedTransactions.ID, page, size)
+    _, _, err := suite.repo.GetTransactionByEmployeId(context.Background(), expectedTransactions.ID, page, size)
     assert.Error(suite.T(), err)
 	assert.NotNil(suite.T(), err)
 }
@@ -184,9 +277,9 @@ func (suite *TransactionsRepositoryTestSuite) TestGetIdEmployeeId_Fail() {
 func (suite *TransactionsRepositoryTestSuite) TestListEmployeeGetByEmployeeId_Fail() {
 	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectTransactionByEmployeeID)).WithArgs(expectedTransactions.EmployeeId, size, offset).WillReturnRows(sqlmock.NewRows([]string{"id", "employee_id", "room_id","description", "status", "start_time", "end_time", "created_at", "updated_at"}).AddRow(expectedTransactions.ID, expectedTransactions.EmployeeId, expectedTransactions.RoomId, expectedTransactions.Description, expectedTransactions.Status, expectedTransactions.StartTime, expectedTransactions.EndTime, expectedTransactions.CreatedAt, expectedTransactions.UpdatedAt))
 
-	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectRoomWithFacilities)).WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{"r.id"}).AddRow(expectedRoomFacilities.ID))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectTransactionFacilitiesByTransactionIDs)).WithArgs(pq.Array([]string{"1"})).WillReturnRows(sqlmock.NewRows([]string{"r.id"}).AddRow(expectedTransactionFacilities.ID))
 
-    _, _, err := suite.repo.GetTransactionByEmployeId("1", page, size)
+    _, _, err := suite.repo.GetTransactionByEmployeId(context.Background(), "1", page, size)
     assert.Error(suite.T(), err)
 	assert.NotNil(suite.T(), err)
 } 
@@ -203,16 +296,16 @@ func (suite *TransactionsRepositoryTestSuite) TestListGetByEmployeeIdScanCount_F
 		expectedTransactions.CreatedAt, 
 		expectedTransactions.UpdatedAt))
 
-	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectRoomWithFacilities)).WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{"r.id", "r.facility_id", "r.quantity", "r.description", "r.created_at", "r.updated_at"}).AddRow(
-		expectedRoomFacilities.ID, 
-		expectedRoomFacilities.FacilityId,
-		expectedRoomFacilities.Quantity, 
-		expectedRoomFacilities.Description, 
-		expectedRoomFacilities.CreatedAt, 
-		expectedRoomFacilities.UpdatedAt))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectTransactionFacilitiesByTransactionIDs)).WithArgs(pq.Array([]string{"1"})).WillReturnRows(sqlmock.NewRows([]string{"id", "transaction_id", "facility_id", "quantity", "description", "created_at", "updated_at"}).AddRow(
+		expectedTransactionFacilities.ID, expectedTransactionFacilities.TransactionId, 
+		expectedTransactionFacilities.FacilityId,
+		expectedTransactionFacilities.Quantity, 
+		expectedTransactionFacilities.Description, 
+		expectedTransactionFacilities.CreatedAt, 
+		expectedTransactionFacilities.UpdatedAt))
 
 	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.GetEmployeeIdListTransaction)).WithArgs(expectedTransactions.EmployeeId).WillReturnError(fmt.Errorf("error"))
-    _, _, err := suite.repo.GetTransactionByEmployeId(expectedTransactions.ID, page, size)
+    _, _, err := suite.repo.GetTransactionByEmployeId(context.Background(), expectedTransactions.ID, page, size)
 	
     assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
@@ -221,33 +314,33 @@ func (suite *TransactionsRepositoryTestSuite) TestListGetByEmployeeIdScanCount_F
 func (suite *TransactionsRepositoryTestSuite) TestGetById_Success() {
 	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectTransactionByID)).WithArgs(expectedTransactions.ID).WillReturnRows(sqlmock.NewRows([]string{"id", "employee_id", "room_id","description", "status", "start_time", "end_time", "created_at", "updated_at"}).AddRow(expectedTransactions.ID, expectedTransactions.EmployeeId, expectedTransactions.RoomId, expectedTransactions.Description, expectedTransactions.Status, expectedTransactions.StartTime, expectedTransactions.EndTime, expectedTransactions.CreatedAt, expectedTransactions.UpdatedAt))
 
-	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectRoomWithFacilities)).WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{"r.id", "r.facility_id", "r.quantity", "r.description", "r.created_at", "r.updated_at"}).AddRow(expectedRoomFacilities.ID, expectedRoomFacilities.FacilityId, expectedRoomFacilities.Quantity, expectedRoomFacilities.Description, expectedRoomFacilities.CreatedAt, expectedRoomFacilities.UpdatedAt))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectTransactionFacilitiesByTransactionIDs)).WithArgs(pq.Array([]string{"1"})).WillReturnRows(sqlmock.NewRows([]string{"id", "transaction_id", "facility_id", "quantity", "description", "created_at", "updated_at"}).AddRow(expectedTransactionFacilities.ID, expectedTransactionFacilities.TransactionId, expectedTransactionFacilities.FacilityId, expectedTransactionFacilities.Quantity, expectedTransactionFacilities.Description, expectedTransactionFacilities.CreatedAt, expectedTransactionFacilities.UpdatedAt))
 
-    _, err := suite.repo.GetTransactionById(expectedTransactions.ID)
+    _, err := suite.repo.GetTransactionById(context.Background(), expectedTransactions.ID)
     suite.NoError(err)
 }
 
 func (suite *TransactionsRepositoryTestSuite) TestScanGetById_Fail() {
-	_, err := suite.repo.GetTransactionById(expectedTransactions.ID)
+	_, err := suite.repo.GetTransactionById(context.Background(), expectedTransactions.ID)
 	suite.Error(err)
 	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectTransactionByID)).
         WithArgs(expectedTransactions.ID).
         WillReturnRows(sqlmock.NewRows([]string{"id"}).
             AddRow(expectedTransactions.ID))
 
-    _, err = suite.repo.GetTransactionById(expectedTransactions.ID)
+    _, err = suite.repo.GetTransactionById(context.Background(), expectedTransactions.ID)
     suite.Error(err)
 } 
 
-func (suite *TransactionsRepositoryTestSuite) TestGetRoomFacilities_Fail() {
+func (suite *TransactionsRepositoryTestSuite) TestGetFacilities_Fail() {
 	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectTransactionByID)).
         WithArgs(expectedTransactions.ID).
 		WillReturnRows(sqlmock.NewRows([]string{"id", "employee_id", "room_id", "description", "status", "start_time", "end_time", "created_at", "updated_at"}).
 		AddRow(expectedTransactions.ID, expectedTransactions.EmployeeId, expectedTransactions.RoomId, expectedTransactions.Description, expectedTransactions.Status, expectedTransactions.StartTime, expectedTransactions.EndTime, expectedTransactions.CreatedAt, expectedTransactions.UpdatedAt))
 
-	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectRoomWithFacilities)).WillReturnError(fmt.Errorf("error"))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectTransactionFacilitiesByTransactionIDs)).WithArgs(pq.Array([]string{"1"})).WillReturnError(fmt.Errorf("error"))
 
-    _, err := suite.repo.GetTransactionById("1")
+    _, err := suite.repo.GetTransactionById(context.Background(), "1")
     assert.Error(suite.T(), err)
 	assert.NotNil(suite.T(), err)
 }
@@ -258,17 +351,20 @@ func (suite *TransactionsRepositoryTestSuite) TestGetRoomFacilitiRows_Fail() {
 		WillReturnRows(sqlmock.NewRows([]string{"id", "employee_id", "room_id", "description", "status", "start_time", "end_time", "created_at", "updated_at"}).
 		AddRow(expectedTransactions.ID, expectedTransactions.EmployeeId, expectedTransactions.RoomId, expectedTransactions.Description, expectedTransactions.Status, expectedTransactions.StartTime, expectedTransactions.EndTime, expectedTransactions.CreatedAt, expectedTransactions.UpdatedAt))
 
-	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectRoomWithFacilities)).WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{ "r.facility_id", "r.quantity", "r.created_at", "r.updated_at"}).AddRow(expectedRoomFacilities.FacilityId, expectedRoomFacilities.Quantity, expectedRoomFacilities.CreatedAt, expectedRoomFacilities.UpdatedAt))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectTransactionFacilitiesByTransactionIDs)).WithArgs(pq.Array([]string{"1"})).WillReturnRows(sqlmock.NewRows([]string{ "r.facility_id", "r.quantity", "r.created_at", "r.updated_at"}).AddRow(expectedTransactionFacilities.FacilityId, expectedTransactionFacilities.Quantity, expectedTransactionFacilities.CreatedAt, expectedTransactionFacilities.UpdatedAt))
 
-    _, err := suite.repo.GetTransactionById("1")
+    _, err := suite.repo.GetTransactionById(context.Background(), "1")
     assert.Error(suite.T(), err)
 	assert.NotNil(suite.T(), err)
 }
 
 func (suite *TransactionsRepositoryTestSuite) TestCreate_Success() {
 	var expectedStatus = "available"
+	suite.mockSql.ExpectBegin()
 	rows := sqlmock.NewRows([]string{"status"}).AddRow(expectedStatus)
-	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectRoomByID2)).WithArgs(expectedRoomFacilities.FacilityId).WillReturnRows(rows)
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.LockRoomStatus)).WithArgs(expectedTransactionFacilities.FacilityId).WillReturnRows(rows)
+	rows = sqlmock.NewRows([]string{"quantity", "archived"}).AddRow(expectedFasilities.Quantity, false)
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectQuantityFacility)).WithArgs(expectedTransactionFacilities.FacilityId).WillReturnRows(rows)
 	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.InsertTransactions)).WithArgs(
         expectedTransactions.EmployeeId,
         expectedTransactions.RoomId,
@@ -281,40 +377,43 @@ func (suite *TransactionsRepositoryTestSuite) TestCreate_Success() {
 		expectedTransactions.CreatedAt,
 		expectedTransactions.UpdatedAt))
 		
-		suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.InsertRoomFacility)).WithArgs(
-			expectedRoomFacilities.RoomId, 
-			expectedRoomFacilities.FacilityId, 
-			expectedRoomFacilities.Quantity,
-			expectedRoomFacilities.Description).WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow(
-				expectedRoomFacilities.ID, 
-				expectedRoomFacilities.CreatedAt, 
-				expectedRoomFacilities.UpdatedAt))
-				
-				
-	rows = sqlmock.NewRows([]string{"quantity"}).AddRow(expectedFasilities.Quantity)
-	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectQuantityFacility)).WithArgs(expectedRoomFacilities.FacilityId).WillReturnRows(rows)
-
-	suite.mockSql.ExpectQuery(regexp.QuoteMeta(`UPDATE facilities SET quantity = quantity - $1, updated_at = CURRENT_TIMESTAMP WHERE id = $2`)).WithArgs(expectedRoomFacilities.Quantity, expectedFasilities.ID).RowsWillBeClosed().WillReturnRows()
+		suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.InsertTransactionFacility)).WithArgs(
+			expectedTransactionFacilities.TransactionId, 
+			expectedTransactionFacilities.FacilityId, 
+			expectedTransactionFacilities.Quantity,
+			expectedTransactionFacilities.Description).WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow(
+				expectedTransactionFacilities.ID, 
+				expectedTransactionFacilities.CreatedAt, 
+				expectedTransactionFacilities.UpdatedAt))
+
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.InsertFacilityMovement)).WithArgs(expectedTransactionFacilities.FacilityId, entity.MovementAllocation, -expectedTransactionFacilities.Quantity, "actor id", "booked", expectedTransactions.ID).WillReturnRows(sqlmock.NewRows([]string{"id", "balance", "created_at"}).AddRow("1", expectedFasilities.Quantity-expectedTransactionFacilities.Quantity, time.Now()))
+	suite.mockSql.ExpectCommit()
 	
-	actual, err := suite.repo.Create(expectedTransactions)
+	actual, err := suite.repo.Create(actorCtx, expectedTransactions)
 	assert.Nil(suite.T(), err)			
     assert.Equal(suite.T(), expectedTransactions.Description, actual.Description)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
 }
 
 func (suite *TransactionsRepositoryTestSuite) TestGetStatusRoom_Fail() {
 	var expectedStatus = "err"
+	suite.mockSql.ExpectBegin()
 	rows := sqlmock.NewRows([]string{"status"}).AddRow(expectedStatus)
-	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectRoomByID2)).WithArgs(expectedRoomFacilities.FacilityId).WillReturnRows(rows)
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.LockRoomStatus)).WithArgs(expectedTransactionFacilities.FacilityId).WillReturnRows(rows)
+	suite.mockSql.ExpectRollback()
 	
-	_, err := suite.repo.Create(expectedTransactions)
+	_, err := suite.repo.Create(actorCtx, expectedTransactions)
 	assert.NotNil(suite.T(), err)
     assert.Error(suite.T(), err)
 }
 
 func (suite *TransactionsRepositoryTestSuite) TestCreate_Fail() {
 	var expectedStatus = "available"
+	suite.mockSql.ExpectBegin()
 	rows := sqlmock.NewRows([]string{"status"}).AddRow(expectedStatus)
-	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectRoomByID2)).WithArgs(expectedRoomFacilities.FacilityId).WillReturnRows(rows)
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.LockRoomStatus)).WithArgs(expectedTransactionFacilities.FacilityId).WillReturnRows(rows)
+	rows = sqlmock.NewRows([]string{"quantity", "archived"}).AddRow(expectedFasilities.Quantity, false)
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectQuantityFacility)).WithArgs(expectedTransactionFacilities.FacilityId).WillReturnRows(rows)
 
 	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.InsertTransactions)).WithArgs(
         expectedTransactions.EmployeeId,
@@ -322,22 +421,24 @@ func (suite *TransactionsRepositoryTestSuite) TestCreate_Fail() {
         expectedTransactions.Description,
         expectedTransactions.StartTime,
         expectedTransactions.EndTime).WillReturnError(fmt.Errorf("error"))
+	suite.mockSql.ExpectRollback()
 
-    _, err := suite.repo.Create(expectedTransactions)
+    _, err := suite.repo.Create(actorCtx, expectedTransactions)
     assert.NotNil(suite.T(), err)
     assert.Error(suite.T(), err)
 }
 
-func (suite *TransactionsRepositoryTestSuite) TestCreate_RoomFacilitiesNil() {
+func (suite *TransactionsRepositoryTestSuite) TestCreate_FacilitiesNil() {
 	var expectedStatus = "available"
+	suite.mockSql.ExpectBegin()
 	rows := sqlmock.NewRows([]string{"status"}).AddRow(expectedStatus)
-	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectRoomByID2)).WithArgs(expectedRoomFacilities.FacilityId).WillReturnRows(rows)
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.LockRoomStatus)).WithArgs(expectedTransactionFacilities.FacilityId).WillReturnRows(rows)
 
 	var expected = entity.Transaction{
 		ID:        "1",
 		EmployeeId: "1",
 		RoomId:    "1",
-		RoomFacilities: nil,
+		Facilities: nil,
 		Status: "pending",
 		StartTime:  time.Now(),
 		EndTime:  time.Now(),
@@ -355,16 +456,20 @@ func (suite *TransactionsRepositoryTestSuite) TestCreate_RoomFacilitiesNil() {
 			expectedTransactions.Status,
 			expectedTransactions.CreatedAt,
 			expectedTransactions.UpdatedAt))
+	suite.mockSql.ExpectCommit()
 
-	actual, _ := suite.repo.Create(expected)
+	actual, _ := suite.repo.Create(actorCtx, expected)
     // assert.Nil(suite.T(), err)
-	assert.Equal(suite.T(), expected.RoomFacilities, actual.RoomFacilities)
+	assert.Equal(suite.T(), expected.Facilities, actual.Facilities)
 } 
 
-func (suite *TransactionsRepositoryTestSuite) TestCreate_RoomFacilitiesScanFaill() {
+func (suite *TransactionsRepositoryTestSuite) TestCreate_FacilitiesScanFaill() {
 	var expectedStatus = "available"
+	suite.mockSql.ExpectBegin()
 	rows := sqlmock.NewRows([]string{"status"}).AddRow(expectedStatus)
-	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectRoomByID2)).WithArgs(expectedRoomFacilities.FacilityId).WillReturnRows(rows)
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.LockRoomStatus)).WithArgs(expectedTransactionFacilities.FacilityId).WillReturnRows(rows)
+	rows = sqlmock.NewRows([]string{"quantity", "archived"}).AddRow(expectedFasilities.Quantity, false)
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectQuantityFacility)).WithArgs(expectedTransactionFacilities.FacilityId).WillReturnRows(rows)
 
 	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.InsertTransactions)).WithArgs(
         expectedTransactions.EmployeeId,
@@ -378,59 +483,42 @@ func (suite *TransactionsRepositoryTestSuite) TestCreate_RoomFacilitiesScanFaill
 			expectedTransactions.CreatedAt,
 			expectedTransactions.UpdatedAt))
 		
-	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.InsertRoomFacility)).WithArgs(
-		expectedRoomFacilities.RoomId, 
-		expectedRoomFacilities.FacilityId, 
-		expectedRoomFacilities.Quantity, 
-		expectedRoomFacilities.Description, 
-		expectedRoomFacilities.Description).WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow(
-			expectedRoomFacilities.ID, 
-			expectedRoomFacilities.CreatedAt, 
-			expectedRoomFacilities.UpdatedAt))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.InsertTransactionFacility)).WithArgs(
+		expectedTransactionFacilities.TransactionId, 
+		expectedTransactionFacilities.FacilityId, 
+		expectedTransactionFacilities.Quantity, 
+		expectedTransactionFacilities.Description, 
+		expectedTransactionFacilities.Description).WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow(
+			expectedTransactionFacilities.ID, 
+			expectedTransactionFacilities.CreatedAt, 
+			expectedTransactionFacilities.UpdatedAt))
+	suite.mockSql.ExpectRollback()
 		
-	_, err := suite.repo.Create(expectedTransactions)
+	_, err := suite.repo.Create(actorCtx, expectedTransactions)
     assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
 }
 
-func (suite *TransactionsRepositoryTestSuite) TestCreate_RoomFacilitiesScanQuantityFaill() {
+func (suite *TransactionsRepositoryTestSuite) TestCreate_FacilitiesScanQuantityFaill() {
 	var expectedStatus = "available"
+	suite.mockSql.ExpectBegin()
 	rows := sqlmock.NewRows([]string{"status"}).AddRow(expectedStatus)
-	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectRoomByID2)).WithArgs(expectedRoomFacilities.FacilityId).WillReturnRows(rows)
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.LockRoomStatus)).WithArgs(expectedTransactionFacilities.FacilityId).WillReturnRows(rows)
 
-	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.InsertTransactions)).WithArgs(
-        expectedTransactions.EmployeeId,
-        expectedTransactions.RoomId,
-        expectedTransactions.Description,
-        expectedTransactions.StartTime,
-        expectedTransactions.EndTime).WillReturnRows(
-        sqlmock.NewRows([]string{"id", "status", "created_at", "updated_at"}).AddRow(
-		expectedTransactions.ID, 
-		expectedTransactions.Status,
-		expectedTransactions.CreatedAt,
-		expectedTransactions.UpdatedAt))
-		
-		suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.InsertRoomFacility)).WithArgs(
-			expectedRoomFacilities.RoomId, 
-			expectedRoomFacilities.FacilityId, 
-			expectedRoomFacilities.Quantity,
-			expectedRoomFacilities.Description).WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow(
-				expectedRoomFacilities.ID, 
-				expectedRoomFacilities.CreatedAt, 
-				expectedRoomFacilities.UpdatedAt))
-
-		rows = sqlmock.NewRows([]string{"quantity"}).AddRow(expectedFasilities.Quantity)
+		rows = sqlmock.NewRows([]string{"quantity", "archived"}).AddRow(expectedFasilities.Quantity, false)
 		suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectQuantityFacility)).WithArgs("xxx").WillReturnRows(rows)
+	suite.mockSql.ExpectRollback()
 		
-	_, err := suite.repo.Create(expectedTransactions)
+	_, err := suite.repo.Create(actorCtx, expectedTransactions)
     assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)	
 }
 
-func (suite *TransactionsRepositoryTestSuite) TestCreate_RoomFacilitiesQuantityFaill() {
+func (suite *TransactionsRepositoryTestSuite) TestCreate_FacilitiesQuantityFaill() {
 	var expectedStatus = "available"
+	suite.mockSql.ExpectBegin()
 	rows := sqlmock.NewRows([]string{"status"}).AddRow(expectedStatus)
-	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectRoomByID2)).WithArgs(expectedRoomFacilities.FacilityId).WillReturnRows(rows)
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.LockRoomStatus)).WithArgs(expectedTransactionFacilities.FacilityId).WillReturnRows(rows)
 	var expectedF = entity.Facilities{
 		ID:        "1",
 		Name:      "This is name",
@@ -439,40 +527,70 @@ func (suite *TransactionsRepositoryTestSuite) TestCreate_RoomFacilitiesQuantityF
 		UpdatedAt: time.Now(),
 	}
 
-	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.InsertTransactions)).WithArgs(
-        expectedTransactions.EmployeeId,
-        expectedTransactions.RoomId,
-        expectedTransactions.Description,
-        expectedTransactions.StartTime,
-        expectedTransactions.EndTime).WillReturnRows(
-        sqlmock.NewRows([]string{"id", "status", "created_at", "updated_at"}).AddRow(
-		expectedTransactions.ID, 
-		expectedTransactions.Status,
-		expectedTransactions.CreatedAt,
-		expectedTransactions.UpdatedAt))
-		
-		suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.InsertRoomFacility)).WithArgs(
-			expectedRoomFacilities.RoomId, 
-			expectedRoomFacilities.FacilityId, 
-			expectedRoomFacilities.Quantity,
-			expectedRoomFacilities.Description).WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow(
-				expectedRoomFacilities.ID, 
-				expectedRoomFacilities.CreatedAt, 
-				expectedRoomFacilities.UpdatedAt))
-
-	rows = sqlmock.NewRows([]string{"quantity"}).AddRow(expectedF.Quantity)
-	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectQuantityFacility)).WithArgs(expectedRoomFacilities.FacilityId).WillReturnRows(rows)
-
-	// expectedF.Quantity < expectedRoomFacilities.Quantity
-	_, err := suite.repo.Create(expectedTransactions)
+	rows = sqlmock.NewRows([]string{"quantity", "archived"}).AddRow(expectedF.Quantity, false)
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectQuantityFacility)).WithArgs(expectedTransactionFacilities.FacilityId).WillReturnRows(rows)
+	suite.mockSql.ExpectRollback()
+
+	// expectedF.Quantity < expectedTransactionFacilities.Quantity
+	_, err := suite.repo.Create(actorCtx, expectedTransactions)
     assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
 }
 
+func (suite *TransactionsRepositoryTestSuite) TestCreate_InsufficientStockRollback() {
+	booking := expectedTransactions
+	booking.Facilities = []entity.TransactionFacility{
+		{FacilityId: "2", Quantity: 1},
+		{FacilityId: "1", Quantity: 2},
+		{FacilityId: "2", Quantity: 2},
+	}
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.LockRoomStatus)).WithArgs(booking.RoomId).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("available"))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectQuantityFacility)).WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{"quantity", "archived"}).AddRow(5, false))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectQuantityFacility)).WithArgs("2").WillReturnRows(sqlmock.NewRows([]string{"quantity", "archived"}).AddRow(2, false))
+	suite.mockSql.ExpectRollback()
+
+	_, err := suite.repo.Create(actorCtx, booking)
+
+	assert.ErrorIs(suite.T(), err, ErrInsufficientStock)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *TransactionsRepositoryTestSuite) TestCreate_FacilityArchivedFail() {
+	booking := expectedTransactions
+	booking.Facilities = []entity.TransactionFacility{{FacilityId: "1", Quantity: 1}}
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.LockRoomStatus)).WithArgs(booking.RoomId).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("available"))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectQuantityFacility)).WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{"quantity", "archived"}).AddRow(5, true))
+	suite.mockSql.ExpectRollback()
+
+	_, err := suite.repo.Create(actorCtx, booking)
+
+	assert.ErrorIs(suite.T(), err, ErrFacilityArchived)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *TransactionsRepositoryTestSuite) TestCreate_FacilityNotFoundFail() {
+	booking := expectedTransactions
+	booking.Facilities = []entity.TransactionFacility{{FacilityId: "1", Quantity: 1}}
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.LockRoomStatus)).WithArgs(booking.RoomId).WillReturnRows(sqlmock.NewRows([]string{"status"}).AddRow("available"))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectQuantityFacility)).WithArgs("1").WillReturnError(sql.ErrNoRows)
+	suite.mockSql.ExpectRollback()
+
+	_, err := suite.repo.Create(actorCtx, booking)
+
+	assert.ErrorIs(suite.T(), err, ErrFacilityNotFound)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
 func (suite *TransactionsRepositoryTestSuite) TestCreateUpdateFacilityQuantity_Fail() {
 	var expectedStatus = "available"
+	suite.mockSql.ExpectBegin()
 	rows := sqlmock.NewRows([]string{"status"}).AddRow(expectedStatus)
-	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectRoomByID2)).WithArgs(expectedRoomFacilities.FacilityId).WillReturnRows(rows)
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.LockRoomStatus)).WithArgs(expectedTransactionFacilities.FacilityId).WillReturnRows(rows)
+	rows = sqlmock.NewRows([]string{"quantity", "archived"}).AddRow(expectedFasilities.Quantity, false)
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectQuantityFacility)).WithArgs(expectedTransactionFacilities.FacilityId).WillReturnRows(rows)
 	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.InsertTransactions)).WithArgs(
         expectedTransactions.EmployeeId,
         expectedTransactions.RoomId,
@@ -485,23 +603,22 @@ func (suite *TransactionsRepositoryTestSuite) TestCreateUpdateFacilityQuantity_F
 		expectedTransactions.CreatedAt,
 		expectedTransactions.UpdatedAt))
 		
-		suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.InsertRoomFacility)).WithArgs(
-			expectedRoomFacilities.RoomId, 
-			expectedRoomFacilities.FacilityId, 
-			expectedRoomFacilities.Quantity,
-			expectedRoomFacilities.Description).WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow(
-				expectedRoomFacilities.ID, 
-				expectedRoomFacilities.CreatedAt, 
-				expectedRoomFacilities.UpdatedAt))
-
-	rows = sqlmock.NewRows([]string{"quantity"}).AddRow(expectedFasilities.Quantity)
-	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectQuantityFacility)).WithArgs(expectedRoomFacilities.FacilityId).WillReturnRows(rows)
+		suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.InsertTransactionFacility)).WithArgs(
+			expectedTransactionFacilities.TransactionId, 
+			expectedTransactionFacilities.FacilityId, 
+			expectedTransactionFacilities.Quantity,
+			expectedTransactionFacilities.Description).WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow(
+				expectedTransactionFacilities.ID, 
+				expectedTransactionFacilities.CreatedAt, 
+				expectedTransactionFacilities.UpdatedAt))
 		
-	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.UpdateFacilityQuantity)).WithArgs(expectedRoomFacilities.Quantity, expectedFasilities.ID).WillReturnError(fmt.Errorf("error"))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.InsertFacilityMovement)).WithArgs(expectedTransactionFacilities.FacilityId, entity.MovementAllocation, -expectedTransactionFacilities.Quantity, "actor id", "booked", expectedTransactions.ID).WillReturnError(fmt.Errorf("error"))
+	suite.mockSql.ExpectRollback()
 
-    _, err := suite.repo.Create(expectedTransactions)
+    _, err := suite.repo.Create(actorCtx, expectedTransactions)
     assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
 }
 
 func (suite *TransactionsRepositoryTestSuite) TestUpdate_Fail() {
@@ -518,21 +635,21 @@ func (suite *TransactionsRepositoryTestSuite) TestUpdate_Fail() {
 		expectedTransactions.CreatedAt))
 		
 	suite.mockSql.ExpectQuery(regexp.QuoteMeta( `INSERT INTO trx_room_facility (room_id, facility_id, quantity, updated_at) VALUES ($1, $2, $3, $4) RETURNING id, created_at, updated_at`)).WithArgs(
-		expectedRoomFacilities.RoomId, 
-		expectedRoomFacilities.FacilityId, 
-		expectedRoomFacilities.Quantity, 
-		expectedRoomFacilities.UpdatedAt).WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow(
-		expectedRoomFacilities.ID, 
-		expectedRoomFacilities.CreatedAt, 
-		expectedRoomFacilities.UpdatedAt))
+		expectedTransactionFacilities.TransactionId, 
+		expectedTransactionFacilities.FacilityId, 
+		expectedTransactionFacilities.Quantity, 
+		expectedTransactionFacilities.UpdatedAt).WillReturnRows(sqlmock.NewRows([]string{"id", "created_at", "updated_at"}).AddRow(
+		expectedTransactionFacilities.ID, 
+		expectedTransactionFacilities.CreatedAt, 
+		expectedTransactionFacilities.UpdatedAt))
 
 	rows := sqlmock.NewRows([]string{"quantity"}).AddRow(expectedFasilities.Quantity)
-	suite.mockSql.ExpectQuery(regexp.QuoteMeta(`SELECT quantity FROM facilities WHERE id = $1`)).WithArgs(expectedRoomFacilities.FacilityId).WillReturnRows(rows)
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(`SELECT quantity FROM facilities WHERE id = $1`)).WithArgs(expectedTransactionFacilities.FacilityId).WillReturnRows(rows)
 		
-	suite.mockSql.ExpectQuery(regexp.QuoteMeta(`UPDATE facilities SET quantity = quantity - $1 WHERE id = $2 RETURNING id, created_at, updated_at`)).WithArgs(expectedRoomFacilities.Quantity, expectedFasilities.ID).WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(expectedFasilities.ID, expectedFasilities.CreatedAt))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(`UPDATE facilities SET quantity = quantity - $1 WHERE id = $2 RETURNING id, created_at, updated_at`)).WithArgs(expectedTransactionFacilities.Quantity, expectedFasilities.ID).WillReturnRows(sqlmock.NewRows([]string{"id", "created_at"}).AddRow(expectedFasilities.ID, expectedFasilities.CreatedAt))
 
 
-    _, err := suite.repo.Create(expectedTransactions)
+    _, err := suite.repo.Create(actorCtx, expectedTransactions)
     assert.NotNil(suite.T(), err)
     assert.Error(suite.T(), err)    
 }
@@ -551,17 +668,17 @@ func (suite *TransactionsRepositoryTestSuite) TestList_Success() {
 		)
 
 	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectTransactionList)).WithArgs(size, offset, expectedTransaction[0].CreatedAt, expectedTransaction[0].CreatedAt).WillReturnRows(rows)
-	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectRoomWithFacilities)).WithArgs(expectedRoomFacilities.RoomId).WillReturnRows(sqlmock.NewRows([]string{"r.id", "r.facility_id", "r.quantity", "r.description", "r.created_at", "r.updated_at"}).AddRow(
-		expectedRoomFacilities.ID, 
-		expectedRoomFacilities.FacilityId, 
-		expectedRoomFacilities.Quantity, 
-		expectedRoomFacilities.Description, 
-		expectedRoomFacilities.CreatedAt, 
-		expectedRoomFacilities.UpdatedAt))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectTransactionFacilitiesByTransactionIDs)).WithArgs(pq.Array([]string{expectedTransactionFacilities.TransactionId})).WillReturnRows(sqlmock.NewRows([]string{"id", "transaction_id", "facility_id", "quantity", "description", "created_at", "updated_at"}).AddRow(
+		expectedTransactionFacilities.ID, expectedTransactionFacilities.TransactionId, 
+		expectedTransactionFacilities.FacilityId, 
+		expectedTransactionFacilities.Quantity, 
+		expectedTransactionFacilities.Description, 
+		expectedTransactionFacilities.CreatedAt, 
+		expectedTransactionFacilities.UpdatedAt))
 
 	suite.mockSql.ExpectQuery(`SELECT`).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(2))
 
-	_, _, err := suite.repo.List(page, size, expectedTransaction[0].CreatedAt, expectedTransaction[0].CreatedAt)
+	_, _, err := suite.repo.List(context.Background(), page, size, expectedTransaction[0].CreatedAt, expectedTransaction[0].CreatedAt)
 
 	assert.Nil(suite.T(), err)
 	assert.NoError(suite.T(), err)
@@ -570,7 +687,7 @@ func (suite *TransactionsRepositoryTestSuite) TestList_Success() {
 func (suite *TransactionsRepositoryTestSuite) TestList_Fail() {
 	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectTransactionList)).WithArgs(size, offset, expectedTransaction[0].CreatedAt, expectedTransaction[0].CreatedAt).WillReturnError(fmt.Errorf("error"))
 
-	_, _, err := suite.repo.List(page, size, expectedTransaction[0].CreatedAt, expectedTransaction[0].CreatedAt)
+	_, _, err := suite.repo.List(context.Background(), page, size, expectedTransaction[0].CreatedAt, expectedTransaction[0].CreatedAt)
 
 	assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
@@ -584,13 +701,13 @@ func (suite *TransactionsRepositoryTestSuite) TestListScan_Fail() {
 
 	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectTransactionList)).WithArgs(size, offset, expectedTransaction[0].CreatedAt, expectedTransaction[0].CreatedAt).WillReturnRows(rows)
 
-	_, _, err := suite.repo.List(page, size, expectedTransaction[0].CreatedAt, expectedTransaction[0].CreatedAt)
+	_, _, err := suite.repo.List(context.Background(), page, size, expectedTransaction[0].CreatedAt, expectedTransaction[0].CreatedAt)
 
 	assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
 }
 
-func (suite *TransactionsRepositoryTestSuite) TestSelectRoomFacilities_Fail() {
+func (suite *TransactionsRepositoryTestSuite) TestSelectFacilities_Fail() {
 	rows := sqlmock.NewRows([]string{"id", "employee_id", "room_id","description", "status", "start_time", "end_time", "created_at", "updated_at"}).AddRow(
 		expectedTransaction[0].ID, 
 		expectedTransaction[0].EmployeeId, 
@@ -606,15 +723,15 @@ func (suite *TransactionsRepositoryTestSuite) TestSelectRoomFacilities_Fail() {
 
 	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectTransactionList)).WithArgs(size, offset, expectedTransaction[0].CreatedAt, expectedTransaction[0].CreatedAt).WillReturnRows(rows)
 
-	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectRoomWithFacilities)).WithArgs(expectedRoomFacilities.RoomId).WillReturnError(fmt.Errorf("error"))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectTransactionFacilitiesByTransactionIDs)).WithArgs(pq.Array([]string{expectedTransactionFacilities.TransactionId})).WillReturnError(fmt.Errorf("error"))
 
-	_, _, err := suite.repo.List(page, size, expectedTransaction[0].CreatedAt, expectedTransaction[0].CreatedAt)
+	_, _, err := suite.repo.List(context.Background(), page, size, expectedTransaction[0].CreatedAt, expectedTransaction[0].CreatedAt)
 
 	assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
 }
 
-func (suite *TransactionsRepositoryTestSuite) TestScanRoomFacilities_Fail() {
+func (suite *TransactionsRepositoryTestSuite) TestScanFacilities_Fail() {
 	rows := sqlmock.NewRows([]string{"id", "employee_id", "room_id","description", "status", "start_time", "end_time", "created_at", "updated_at"}).AddRow(
 		expectedTransaction[0].ID, 
 		expectedTransaction[0].EmployeeId, 
@@ -629,10 +746,10 @@ func (suite *TransactionsRepositoryTestSuite) TestScanRoomFacilities_Fail() {
 
 
 	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectTransactionList)).WithArgs(size, offset, expectedTransaction[0].CreatedAt, expectedTransaction[0].CreatedAt).WillReturnRows(rows)
-	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectRoomWithFacilities)).WithArgs(expectedRoomFacilities.RoomId).WillReturnRows(sqlmock.NewRows([]string{"r.id"}).AddRow(
-		expectedRoomFacilities.ID))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectTransactionFacilitiesByTransactionIDs)).WithArgs(pq.Array([]string{expectedTransactionFacilities.TransactionId})).WillReturnRows(sqlmock.NewRows([]string{"r.id"}).AddRow(
+		expectedTransactionFacilities.ID))
 	
-		_, _, err := suite.repo.List(page, size, expectedTransaction[0].CreatedAt, expectedTransaction[0].CreatedAt)
+		_, _, err := suite.repo.List(context.Background(), page, size, expectedTransaction[0].CreatedAt, expectedTransaction[0].CreatedAt)
 
 	assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
@@ -653,24 +770,106 @@ func (suite *TransactionsRepositoryTestSuite) TestScanCount_Fail() {
 
 
 	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectTransactionList)).WithArgs(size, offset, expectedTransaction[0].CreatedAt, expectedTransaction[0].CreatedAt).WillReturnRows(rows)
-	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectRoomWithFacilities)).WithArgs(expectedRoomFacilities.RoomId).WillReturnRows(sqlmock.NewRows([]string{"r.id", "r.facility_id", "r.quantity", "r.description", "r.created_at", "r.updated_at"}).AddRow(
-		expectedRoomFacilities.ID, 
-		expectedRoomFacilities.FacilityId, 
-		expectedRoomFacilities.Quantity, 
-		expectedRoomFacilities.Description, 
-		expectedRoomFacilities.CreatedAt, 
-		expectedRoomFacilities.UpdatedAt))
+	suite.mockSql.ExpectQuery(regexp.QuoteMeta(config.SelectTransactionFacilitiesByTransactionIDs)).WithArgs(pq.Array([]string{expectedTransactionFacilities.TransactionId})).WillReturnRows(sqlmock.NewRows([]string{"id", "transaction_id", "facility_id", "quantity", "description", "created_at", "updated_at"}).AddRow(
+		expectedTransactionFacilities.ID, expectedTransactionFacilities.TransactionId, 
+		expectedTransactionFacilities.FacilityId, 
+		expectedTransactionFacilities.Quantity, 
+		expectedTransactionFacilities.Description, 
+		expectedTransactionFacilities.CreatedAt, 
+		expectedTransactionFacilities.UpdatedAt))
 
 	suite.mockSql.ExpectQuery(`SELECT`).WillReturnError(fmt.Errorf("error"))
 	
-		_, _, err := suite.repo.List(page, size, expectedTransaction[0].CreatedAt, expectedTransaction[0].CreatedAt)
+		_, _, err := suite.repo.List(context.Background(), page, size, expectedTransaction[0].CreatedAt, expectedTransaction[0].CreatedAt)
 
 	assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
 }
 
+func (suite *TransactionsRepositoryTestSuite) TestDeleteBefore_Success() {
+	before := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectExec(regexp.QuoteMeta(config.DeleteTransactionFacilitiesBefore)).WithArgs(before).WillReturnResult(sqlmock.NewResult(0, 4))
+	suite.mockSql.ExpectExec(regexp.QuoteMeta(config.DeleteTransactionCostsBefore)).WithArgs(before).WillReturnResult(sqlmock.NewResult(0, 2))
+	suite.mockSql.ExpectExec(regexp.QuoteMeta(config.DeleteTransactionsBefore)).WithArgs(before).WillReturnResult(sqlmock.NewResult(0, 2))
+	suite.mockSql.ExpectCommit()
+
+	deleted, err := suite.repo.DeleteBefore(context.Background(), before)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(2), deleted)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
+func (suite *TransactionsRepositoryTestSuite) TestDeleteBefore_Failure() {
+	before := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	suite.mockSql.ExpectBegin()
+	suite.mockSql.ExpectExec(regexp.QuoteMeta(config.DeleteTransactionFacilitiesBefore)).WithArgs(before).WillReturnError(fmt.Errorf("error"))
+	suite.mockSql.ExpectRollback()
+
+	_, err := suite.repo.DeleteBefore(context.Background(), before)
+
+	assert.Error(suite.T(), err)
+	assert.NoError(suite.T(), suite.mockSql.ExpectationsWereMet())
+}
+
 func TestTransactionsRepositoryTestSuite(t *testing.T) {
 	suite.Run(t, new(TransactionsRepositoryTestSuite))
 }
-	
 
+// transactionListMock prepares a page of size transactions, each in its own room,
+// and counts every statement the repository sends.
+func transactionListMock(size int) (*sql.DB, sqlmock.Sqlmock, *int) {
+	queries := 0
+	matcher := sqlmock.QueryMatcherFunc(func(expectedSQL, actualSQL string) error {
+		queries++
+		return sqlmock.QueryMatcherRegexp.Match(expectedSQL, actualSQL)
+	})
+	db, mock, _ := sqlmock.New(sqlmock.QueryMatcherOption(matcher))
+
+	rows := sqlmock.NewRows([]string{"id", "employee_id", "room_id", "description", "status", "start_time", "end_time", "created_at", "updated_at"})
+	facilities := sqlmock.NewRows([]string{"id", "transaction_id", "facility_id", "quantity", "description", "created_at", "updated_at"})
+	for i := 0; i < size; i++ {
+		rows.AddRow(fmt.Sprint(i), "1", fmt.Sprintf("room-%d", i), "", "pending", time.Now(), time.Now(), time.Now(), time.Now())
+		facilities.AddRow(fmt.Sprint(i), fmt.Sprint(i), "1", 1, "", time.Now(), time.Now())
+	}
+
+	mock.ExpectQuery(regexp.QuoteMeta(config.SelectTransactionList)).WithArgs(size, 0, sqlmock.AnyArg(), sqlmock.AnyArg()).WillReturnRows(rows)
+	mock.ExpectQuery(regexp.QuoteMeta(config.SelectTransactionFacilitiesByTransactionIDs)).WithArgs(sqlmock.AnyArg()).WillReturnRows(facilities)
+	mock.ExpectQuery(regexp.QuoteMeta(config.GetIdListTransaction)).WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(size))
+	return db, mock, &queries
+}
+
+func (suite *TransactionsRepositoryTestSuite) TestList_QueryCountIndependentOfPageSize() {
+	for _, size := range []int{1, 5, 50} {
+		db, mock, queries := transactionListMock(size)
+
+		actual, _, err := NewTransactionsRepository(db, DefaultTimeouts()).List(context.Background(), 1, size, time.Now(), time.Now())
+
+		assert.NoError(suite.T(), err)
+		assert.Len(suite.T(), actual, size)
+		assert.Len(suite.T(), actual[size-1].Facilities, 1)
+		assert.Equal(suite.T(), 3, *queries)
+		assert.NoError(suite.T(), mock.ExpectationsWereMet())
+	}
+}
+
+func BenchmarkTransactionsRepositoryList(b *testing.B) {
+	for _, size := range []int{5, 50, 500} {
+		b.Run(fmt.Sprintf("size=%d", size), func(b *testing.B) {
+			queries := 0
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				db, _, count := transactionListMock(size)
+				repo := NewTransactionsRepository(db, DefaultTimeouts())
+				b.StartTimer()
+
+				if _, _, err := repo.List(context.Background(), 1, size, time.Now(), time.Now()); err != nil {
+					b.Fatal(err)
+				}
+				queries += *count
+			}
+			b.ReportMetric(float64(queries)/float64(b.N), "queries/op")
+		})
+	}
+}
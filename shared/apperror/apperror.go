@@ -0,0 +1,151 @@
+// Package apperror is the error model shared by the usecases and the HTTP
+// layer. A usecase returns an *Error that says what went wrong in terms of the
+// domain; common.SendErrorResponse turns its Kind into a status code and its
+// Code into a stable, machine-readable error code. The cause is only logged.
+package apperror
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+type Kind string
+
+const (
+	KindValidation   Kind = "validation"
+	KindUnauthorized Kind = "unauthorized"
+	KindForbidden    Kind = "forbidden"
+	KindNotFound     Kind = "not_found"
+	KindConflict     Kind = "conflict"
+	KindTooLarge     Kind = "too_large"
+	KindInternal     Kind = "internal"
+)
+
+// Codes used by more than one usecase.
+const (
+	CodeValidationFailed = "validation_failed"
+	CodeForbidden        = "forbidden"
+	CodeInternal         = "internal_error"
+)
+
+// FieldError describes one invalid field of a request.
+type FieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+type Error struct {
+	Kind    Kind
+	Code    string
+	Message string
+	Fields  []FieldError
+	Err     error
+}
+
+func (e *Error) Error() string {
+	if e.Err != nil {
+		return fmt.Sprintf("%s: %v", e.Message, e.Err)
+	}
+	return e.Message
+}
+
+func (e *Error) Unwrap() error {
+	return e.Err
+}
+
+// Is matches another *Error with the same kind and code, so errors.Is works
+// with the Err* values below.
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Kind == e.Kind && t.Code == e.Code
+}
+
+// Wrap returns a copy of e that records err as its cause.
+func (e *Error) Wrap(err error) *Error {
+	wrapped := *e
+	wrapped.Err = err
+	return &wrapped
+}
+
+// NotFound reports a missing resource, e.g. NotFound("room") has the code
+// room_not_found.
+func NotFound(resource string) *Error {
+	return &Error{
+		Kind:    KindNotFound,
+		Code:    codeOf(resource) + "_not_found",
+		Message: resource + " not found",
+	}
+}
+
+// Conflict reports a request that clashes with the current state, such as a
+// booked room or a taken username.
+func Conflict(code, message string) *Error {
+	return &Error{Kind: KindConflict, Code: code, Message: message}
+}
+
+// Validation reports invalid input, with the offending fields when known.
+func Validation(message string, fields ...FieldError) *Error {
+	return &Error{Kind: KindValidation, Code: CodeValidationFailed, Message: message, Fields: fields}
+}
+
+// Field is a shorthand for a FieldError.
+func Field(field, code, message string) FieldError {
+	return FieldError{Field: field, Code: code, Message: message}
+}
+
+// RequiredField is the FieldError for a missing field.
+func RequiredField(field string) FieldError {
+	return Field(field, "required", field+" is required")
+}
+
+// Required reports missing fields with one FieldError each.
+func Required(fields ...string) *Error {
+	details := make([]FieldError, 0, len(fields))
+	for _, field := range fields {
+		details = append(details, RequiredField(field))
+	}
+	return Validation("required fields are missing", details...)
+}
+
+// TooLarge reports a request body, such as an upload, above the allowed size.
+func TooLarge(code, message string) *Error {
+	return &Error{Kind: KindTooLarge, Code: code, Message: message}
+}
+
+func Unauthorized(code, message string) *Error {
+	return &Error{Kind: KindUnauthorized, Code: code, Message: message}
+}
+
+func Forbidden(message string) *Error {
+	return &Error{Kind: KindForbidden, Code: CodeForbidden, Message: message}
+}
+
+// Internal hides err from the client behind a generic message.
+func Internal(err error) *Error {
+	return &Error{Kind: KindInternal, Code: CodeInternal, Message: "internal server error", Err: err}
+}
+
+// From returns err as an *Error, treating anything untyped as internal.
+func From(err error) *Error {
+	var appErr *Error
+	if errors.As(err, &appErr) {
+		return appErr
+	}
+	return Internal(err)
+}
+
+// KindOf returns the kind of err, KindInternal for untyped errors.
+func KindOf(err error) Kind {
+	return From(err).Kind
+}
+
+func codeOf(resource string) string {
+	return strings.ReplaceAll(strings.ToLower(strings.TrimSpace(resource)), " ", "_")
+}
+
+// InvalidBody reports a request body that could not be decoded.
+func InvalidBody(err error) *Error {
+	return Validation("the request body is invalid").Wrap(err)
+}
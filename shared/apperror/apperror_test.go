@@ -0,0 +1,51 @@
+package apperror
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNotFound_Success(t *testing.T) {
+	err := NotFound("report schedule")
+
+	assert.Equal(t, KindNotFound, err.Kind)
+	assert.Equal(t, "report_schedule_not_found", err.Code)
+	assert.Equal(t, "report schedule not found", err.Error())
+}
+
+func TestWrap_Success(t *testing.T) {
+	base := Conflict("room_unavailable", "the room cannot be booked")
+	cause := errors.New("overlapping booking")
+
+	err := fmt.Errorf("booking: %w", base.Wrap(cause))
+
+	assert.ErrorIs(t, err, base)
+	assert.ErrorIs(t, err, cause)
+	assert.Nil(t, base.Err)
+	assert.False(t, errors.Is(err, Conflict("insufficient_stock", "")))
+}
+
+func TestRequired_Success(t *testing.T) {
+	err := Required("name", "capacity")
+
+	assert.Equal(t, KindValidation, err.Kind)
+	assert.Equal(t, CodeValidationFailed, err.Code)
+	assert.Equal(t, []FieldError{
+		{Field: "name", Code: "required", Message: "name is required"},
+		{Field: "capacity", Code: "required", Message: "capacity is required"},
+	}, err.Fields)
+}
+
+func TestFrom_Success(t *testing.T) {
+	typed := Forbidden("no access")
+	assert.Same(t, typed, From(fmt.Errorf("wrapped: %w", typed)))
+
+	plain := errors.New("pq: connection refused")
+	internal := From(plain)
+	assert.Equal(t, KindInternal, internal.Kind)
+	assert.Equal(t, "internal server error", internal.Message)
+	assert.ErrorIs(t, internal, plain)
+}
@@ -0,0 +1,25 @@
+package buildinfo
+
+import (
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGet_LdflagsSuccess(t *testing.T) {
+	Commit, BuildTime = "abc123", "2024-01-01T00:00:00Z"
+	defer func() { Commit, BuildTime = "", "" }()
+
+	info := Get()
+
+	assert.Equal(t, Info{Commit: "abc123", BuildTime: "2024-01-01T00:00:00Z", GoVersion: runtime.Version()}, info)
+}
+
+func TestGet_DefaultSuccess(t *testing.T) {
+	info := Get()
+
+	assert.NotEmpty(t, info.Commit)
+	assert.NotEmpty(t, info.BuildTime)
+	assert.Equal(t, runtime.Version(), info.GoVersion)
+}
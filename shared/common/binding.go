@@ -0,0 +1,71 @@
+package common
+
+import (
+	"booking-room-app/shared/apperror"
+	"booking-room-app/shared/validation"
+	"encoding/json"
+	"errors"
+	"io"
+	"reflect"
+
+	"github.com/gin-gonic/gin"
+)
+
+// BindJSON decodes the request body into payload and checks its validate
+// tags. The error is an *apperror.Error that lists every invalid field, ready
+// for SendErrorResponse.
+func BindJSON(c *gin.Context, payload any) error {
+	if err := c.ShouldBindJSON(payload); err != nil {
+		return decodeError(err)
+	}
+	return validation.Struct(payload)
+}
+
+// BindQuery decodes the query string into payload using its form tags and
+// checks its validate tags, like BindJSON.
+func BindQuery(c *gin.Context, payload any) error {
+	if err := c.ShouldBindQuery(payload); err != nil {
+		return apperror.Validation("the query is invalid").Wrap(err)
+	}
+	return validation.Struct(payload)
+}
+
+// ParamUUID returns the path parameter name, or a validation error when it
+// is not a UUID.
+func ParamUUID(c *gin.Context, name string) (string, error) {
+	value := c.Param(name)
+	if err := validation.Var(name, value, "required,uuid"); err != nil {
+		return "", err
+	}
+	return value, nil
+}
+
+func decodeError(err error) error {
+	var typeErr *json.UnmarshalTypeError
+	switch {
+	case errors.Is(err, io.EOF):
+		return apperror.Validation("the request body is empty").Wrap(err)
+	case errors.As(err, &typeErr) && typeErr.Field != "":
+		return apperror.Validation("the request body is invalid",
+			apperror.Field(typeErr.Field, "type", typeErr.Field+" must be "+jsonType(typeErr.Type)),
+		).Wrap(err)
+	}
+	return apperror.InvalidBody(err)
+}
+
+func jsonType(t reflect.Type) string {
+	switch t.Kind() {
+	case reflect.String:
+		return "a string"
+	case reflect.Bool:
+		return "a boolean"
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return "an integer"
+	case reflect.Float32, reflect.Float64:
+		return "a number"
+	case reflect.Slice, reflect.Array:
+		return "an array"
+	}
+	return "an object"
+}
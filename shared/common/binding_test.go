@@ -0,0 +1,115 @@
+package common
+
+import (
+	"booking-room-app/shared/apperror"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type bindPayload struct {
+	Name     string `json:"name" validate:"required"`
+	Quantity int    `json:"quantity" validate:"gt=0"`
+}
+
+func bindContext(body string) *gin.Context {
+	c, _ := gin.CreateTestContext(httptest.NewRecorder())
+	c.Request = httptest.NewRequest(http.MethodPost, "/", strings.NewReader(body))
+	c.Request.Header.Set("Content-Type", "application/json")
+	return c
+}
+
+func bindFail(t *testing.T, body string) *apperror.Error {
+	var payload bindPayload
+	err := BindJSON(bindContext(body), &payload)
+	require.Error(t, err)
+	appErr := apperror.From(err)
+	require.Equal(t, apperror.KindValidation, appErr.Kind)
+	return appErr
+}
+
+func TestBindJSON_Success(t *testing.T) {
+	var payload bindPayload
+
+	err := BindJSON(bindContext(`{"name": "projector", "quantity": 2}`), &payload)
+
+	assert.NoError(t, err)
+	assert.Equal(t, bindPayload{Name: "projector", Quantity: 2}, payload)
+}
+
+func TestBindJSON_EmptyBodyFail(t *testing.T) {
+	appErr := bindFail(t, "")
+
+	assert.Equal(t, "the request body is empty", appErr.Message)
+}
+
+func TestBindJSON_MalformedBodyFail(t *testing.T) {
+	appErr := bindFail(t, `{"name": `)
+
+	assert.Equal(t, "the request body is invalid", appErr.Message)
+	assert.Empty(t, appErr.Fields)
+}
+
+func TestBindJSON_TypeFail(t *testing.T) {
+	appErr := bindFail(t, `{"name": "projector", "quantity": "two"}`)
+
+	assert.Equal(t, []apperror.FieldError{{Field: "quantity", Code: "type", Message: "quantity must be an integer"}}, appErr.Fields)
+}
+
+func TestBindJSON_RulesFail(t *testing.T) {
+	appErr := bindFail(t, `{"quantity": 0}`)
+
+	assert.Equal(t, []apperror.FieldError{
+		{Field: "name", Code: "required", Message: "name is required"},
+		{Field: "quantity", Code: "gt", Message: "quantity must be more than 0"},
+	}, appErr.Fields)
+}
+
+func TestParamUUID_Success(t *testing.T) {
+	c := bindContext("")
+	c.Params = gin.Params{{Key: "id", Value: "0f8fad5b-d9cb-469f-a165-70867728950e"}}
+
+	id, err := ParamUUID(c, "id")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "0f8fad5b-d9cb-469f-a165-70867728950e", id)
+}
+
+func TestParamUUID_Fail(t *testing.T) {
+	c := bindContext("")
+	c.Params = gin.Params{{Key: "id", Value: "1"}}
+
+	_, err := ParamUUID(c, "id")
+
+	assert.Equal(t, apperror.KindValidation, apperror.KindOf(err))
+}
+
+type queryPayload struct {
+	SiteId string `form:"siteId" json:"siteId" validate:"omitempty,uuid"`
+}
+
+func TestBindQuery_Success(t *testing.T) {
+	var payload queryPayload
+	c := bindContext("")
+	c.Request = httptest.NewRequest(http.MethodGet, "/?siteId=0f8fad5b-d9cb-469f-a165-70867728950e", nil)
+
+	err := BindQuery(c, &payload)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "0f8fad5b-d9cb-469f-a165-70867728950e", payload.SiteId)
+}
+
+func TestBindQuery_RulesFail(t *testing.T) {
+	var payload queryPayload
+	c := bindContext("")
+	c.Request = httptest.NewRequest(http.MethodGet, "/?siteId=jakarta", nil)
+
+	err := BindQuery(c, &payload)
+
+	assert.Equal(t, []apperror.FieldError{{Field: "siteId", Code: "uuid", Message: "siteId must be a UUID"}}, apperror.From(err).Fields)
+}
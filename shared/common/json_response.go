@@ -1,7 +1,10 @@
 package common
 
 import (
+	"booking-room-app/shared/apperror"
+	"booking-room-app/shared/logger"
 	"booking-room-app/shared/model"
+	"log/slog"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
@@ -27,11 +30,49 @@ func SendSingleResponse(c *gin.Context, data interface{}, message string) {
 	})
 }
 
-func SendErrorResponse(c *gin.Context, code int, message string) {
-	c.AbortWithStatusJSON(code, &model.Status{
+// SendErrorResponse aborts the request with the status that matches the kind
+// of err. Untyped errors are reported as internal errors so driver messages
+// never reach the client; the cause is only logged.
+func SendErrorResponse(c *gin.Context, err error) {
+	appErr := apperror.From(err)
+	code := StatusOf(appErr.Kind)
+
+	response := &model.ErrorResponse{
 		Code:    code,
-		Message: message,
-	})
+		Message: appErr.Message,
+		Error:   appErr.Code,
+		Fields:  appErr.Fields,
+	}
+	if c.Request != nil {
+		ctx := c.Request.Context()
+		level := slog.LevelWarn
+		if code >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		slog.Log(ctx, level, appErr.Message, "status", code, "error", appErr.Code, "err", appErr.Err)
+		response.RequestId = logger.RequestID(ctx)
+	}
+	c.AbortWithStatusJSON(code, response)
+}
+
+// StatusOf is the HTTP status for an error kind.
+func StatusOf(kind apperror.Kind) int {
+	switch kind {
+	case apperror.KindValidation:
+		return http.StatusBadRequest
+	case apperror.KindUnauthorized:
+		return http.StatusUnauthorized
+	case apperror.KindForbidden:
+		return http.StatusForbidden
+	case apperror.KindNotFound:
+		return http.StatusNotFound
+	case apperror.KindConflict:
+		return http.StatusConflict
+	case apperror.KindTooLarge:
+		return http.StatusRequestEntityTooLarge
+	default:
+		return http.StatusInternalServerError
+	}
 }
 
 func SendNoContentResponse(c *gin.Context) {
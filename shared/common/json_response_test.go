@@ -0,0 +1,65 @@
+package common
+
+import (
+	"booking-room-app/shared/apperror"
+	"booking-room-app/shared/logger"
+	"booking-room-app/shared/model"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func sendError(t *testing.T, err error) (*httptest.ResponseRecorder, model.ErrorResponse) {
+	recorder := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(recorder)
+	c.Request = httptest.NewRequest(http.MethodGet, "/", nil)
+	c.Request = c.Request.WithContext(logger.WithRequestID(c.Request.Context(), "req-1"))
+
+	SendErrorResponse(c, err)
+
+	var body model.ErrorResponse
+	require.NoError(t, json.Unmarshal(recorder.Body.Bytes(), &body))
+	return recorder, body
+}
+
+func TestSendErrorResponse_StatusSuccess(t *testing.T) {
+	tests := []struct {
+		err    error
+		status int
+	}{
+		{apperror.Required("name"), http.StatusBadRequest},
+		{apperror.Unauthorized("invalid_token", "the token is invalid"), http.StatusUnauthorized},
+		{apperror.Forbidden("no access"), http.StatusForbidden},
+		{apperror.NotFound("room"), http.StatusNotFound},
+		{apperror.Conflict("room_unavailable", "the room cannot be booked"), http.StatusConflict},
+		{apperror.TooLarge("attachment_too_large", "the file is too large"), http.StatusRequestEntityTooLarge},
+		{errors.New("boom"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		recorder, body := sendError(t, tt.err)
+
+		assert.Equal(t, tt.status, recorder.Code)
+		assert.Equal(t, tt.status, body.Code)
+	}
+}
+
+func TestSendErrorResponse_BodySuccess(t *testing.T) {
+	_, body := sendError(t, apperror.Required("name"))
+
+	assert.Equal(t, apperror.CodeValidationFailed, body.Error)
+	assert.Equal(t, "req-1", body.RequestId)
+	assert.Equal(t, []apperror.FieldError{{Field: "name", Code: "required", Message: "name is required"}}, body.Fields)
+}
+
+func TestSendErrorResponse_HidesCauseSuccess(t *testing.T) {
+	_, body := sendError(t, errors.New(`pq: duplicate key value violates unique constraint "employee_username_key"`))
+
+	assert.Equal(t, apperror.CodeInternal, body.Error)
+	assert.Equal(t, "internal server error", body.Message)
+}
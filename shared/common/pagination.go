@@ -16,4 +16,16 @@ func SendPagedResponse(c *gin.Context, data []interface{}, paging model.Paging,
 		Data:   data,
 		Paging: paging,
 	})
+}
+
+func SendSearchResponse(c *gin.Context, data []interface{}, paging model.Paging, facets interface{}, message string) {
+	c.JSON(http.StatusOK, &model.SearchResponse{
+		Status: model.Status{
+			Code:    http.StatusOK,
+			Message: message,
+		},
+		Data:   data,
+		Paging: paging,
+		Facets: facets,
+	})
 }
\ No newline at end of file
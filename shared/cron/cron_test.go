@@ -0,0 +1,57 @@
+package cron
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type CronTestSuite struct {
+	suite.Suite
+}
+
+func (suite *CronTestSuite) TestParse_WeeklyMondaySuccess() {
+	schedule, err := Parse("0 8 * * 1")
+	assert.NoError(suite.T(), err)
+
+	monday := time.Date(2024, time.January, 1, 8, 0, 0, 0, time.UTC)
+	assert.True(suite.T(), schedule.Match(monday))
+	assert.False(suite.T(), schedule.Match(monday.Add(time.Minute)))
+	assert.False(suite.T(), schedule.Match(monday.AddDate(0, 0, 1)))
+	assert.Equal(suite.T(), monday.AddDate(0, 0, 7), schedule.Next(monday))
+}
+
+func (suite *CronTestSuite) TestParse_StepListAndRangeSuccess() {
+	schedule, err := Parse("*/15 9-17 * * 1,3,5")
+	assert.NoError(suite.T(), err)
+
+	wednesday := time.Date(2024, time.January, 3, 9, 45, 0, 0, time.UTC)
+	assert.True(suite.T(), schedule.Match(wednesday))
+	assert.False(suite.T(), schedule.Match(wednesday.Add(5*time.Minute)))
+	assert.False(suite.T(), schedule.Match(wednesday.Add(9*time.Hour)))
+}
+
+func (suite *CronTestSuite) TestParse_ShortcutSuccess() {
+	schedule, err := Parse("@monthly")
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), schedule.Match(time.Date(2024, time.March, 1, 0, 0, 0, 0, time.UTC)))
+}
+
+func (suite *CronTestSuite) TestParse_SundayAliasSuccess() {
+	schedule, err := Parse("0 0 * * 7")
+	assert.NoError(suite.T(), err)
+	assert.True(suite.T(), schedule.Match(time.Date(2024, time.January, 7, 0, 0, 0, 0, time.UTC)))
+}
+
+func (suite *CronTestSuite) TestParse_Failure() {
+	for _, expr := range []string{"", "* * * *", "60 * * * *", "* 24 * * *", "* * 0 * *", "5-1 * * * *", "*/0 * * * *", "a * * * *"} {
+		_, err := Parse(expr)
+		assert.Error(suite.T(), err, expr)
+	}
+}
+
+func TestCronTestSuite(t *testing.T) {
+	suite.Run(t, new(CronTestSuite))
+}
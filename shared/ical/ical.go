@@ -0,0 +1,124 @@
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+// Event is a VEVENT of an iCalendar (RFC 5545) file reduced to what a
+// holiday calendar needs: a summary and the days it covers. Start and End are
+// dates at midnight UTC and End is exclusive, as DTEND of an all-day event.
+type Event struct {
+	Summary string
+	Start   time.Time
+	End     time.Time
+}
+
+// Days returns the dates the event covers, at least its start date.
+func (e Event) Days() []time.Time {
+	days := []time.Time{e.Start}
+	for day := e.Start.AddDate(0, 0, 1); day.Before(e.End); day = day.AddDate(0, 0, 1) {
+		days = append(days, day)
+	}
+	return days
+}
+
+// Parse reads the events of an iCalendar file. Only the date part of DTSTART
+// and DTEND is used, so timed events count for the day they start on. Events
+// without DTSTART are rejected.
+func Parse(r io.Reader) ([]Event, error) {
+	lines, err := unfold(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(lines) == 0 || !strings.EqualFold(lines[0].text, "BEGIN:VCALENDAR") {
+		return nil, fmt.Errorf("invalid calendar: expected BEGIN:VCALENDAR")
+	}
+
+	var events []Event
+	var event *Event
+	var begin int
+	for _, line := range lines {
+		name, value := property(line.text)
+		switch {
+		case name == "BEGIN" && strings.EqualFold(value, "VEVENT"):
+			event, begin = &Event{}, line.number
+		case name == "END" && strings.EqualFold(value, "VEVENT") && event != nil:
+			if event.Start.IsZero() {
+				return nil, fmt.Errorf("invalid calendar: event on line %d has no DTSTART", begin)
+			}
+			if !event.End.After(event.Start) {
+				event.End = event.Start.AddDate(0, 0, 1)
+			}
+			events = append(events, *event)
+			event = nil
+		case event == nil:
+		case name == "SUMMARY":
+			event.Summary = unescape(value)
+		case name == "DTSTART" || name == "DTEND":
+			date, err := parseDate(value)
+			if err != nil {
+				return nil, fmt.Errorf("invalid calendar: line %d: %v", line.number, err)
+			}
+			if name == "DTSTART" {
+				event.Start = date
+			} else {
+				event.End = date
+			}
+		}
+	}
+	if event != nil {
+		return nil, fmt.Errorf("invalid calendar: event on line %d has no END:VEVENT", begin)
+	}
+	return events, nil
+}
+
+type line struct {
+	number int
+	text   string
+}
+
+// unfold joins the continuation lines, which start with a space or a tab,
+// to the line before them.
+func unfold(r io.Reader) ([]line, error) {
+	var lines []line
+	scanner := bufio.NewScanner(r)
+	for number := 1; scanner.Scan(); number++ {
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(text, " ") || strings.HasPrefix(text, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1].text += text[1:]
+			continue
+		}
+		if strings.TrimSpace(text) == "" {
+			continue
+		}
+		lines = append(lines, line{number: number, text: text})
+	}
+	return lines, scanner.Err()
+}
+
+// property splits a content line such as DTSTART;VALUE=DATE:20240101 into
+// its upper-case name, without parameters, and its value.
+func property(text string) (string, string) {
+	head, value, _ := strings.Cut(text, ":")
+	name, _, _ := strings.Cut(head, ";")
+	return strings.ToUpper(strings.TrimSpace(name)), value
+}
+
+func parseDate(value string) (time.Time, error) {
+	if len(value) < 8 {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+	date, err := time.Parse("20060102", value[:8])
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q", value)
+	}
+	return date, nil
+}
+
+func unescape(value string) string {
+	return strings.NewReplacer(`\n`, " ", `\N`, " ", `\,`, ",", `\;`, ";", `\\`, `\`).Replace(value)
+}
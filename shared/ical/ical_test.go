@@ -0,0 +1,67 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ICalTestSuite struct {
+	suite.Suite
+}
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func (suite *ICalTestSuite) TestParse_AllDayAndTimedEventsSuccess() {
+	calendar := "BEGIN:VCALENDAR\r\n" +
+		"VERSION:2.0\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART;VALUE=DATE:20240101\r\n" +
+		"DTEND;VALUE=DATE:20240102\r\n" +
+		"SUMMARY:New Year\\, 2024\r\n" +
+		"END:VEVENT\r\n" +
+		"BEGIN:VEVENT\r\n" +
+		"DTSTART;TZID=Asia/Jakarta:20240410T080000\r\n" +
+		"SUMMARY:Idul Fitri \r\n" +
+		" 1445 H\r\n" +
+		"END:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+
+	events, err := Parse(strings.NewReader(calendar))
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []Event{
+		{Summary: "New Year, 2024", Start: date(2024, time.January, 1), End: date(2024, time.January, 2)},
+		{Summary: "Idul Fitri 1445 H", Start: date(2024, time.April, 10), End: date(2024, time.April, 11)},
+	}, events)
+}
+
+func (suite *ICalTestSuite) TestEventDays_MultiDaySuccess() {
+	event := Event{Start: date(2024, time.April, 10), End: date(2024, time.April, 13)}
+
+	assert.Equal(suite.T(), []time.Time{date(2024, time.April, 10), date(2024, time.April, 11), date(2024, time.April, 12)}, event.Days())
+}
+
+func (suite *ICalTestSuite) TestParse_NotACalendarFail() {
+	_, err := Parse(strings.NewReader("name,date\nNew Year,2024-01-01\n"))
+	assert.Error(suite.T(), err)
+}
+
+func (suite *ICalTestSuite) TestParse_InvalidDateFail() {
+	_, err := Parse(strings.NewReader("BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART:2024-01-01\nEND:VEVENT\nEND:VCALENDAR\n"))
+	assert.EqualError(suite.T(), err, `invalid calendar: line 3: invalid date "2024-01-01"`)
+}
+
+func (suite *ICalTestSuite) TestParse_MissingStartFail() {
+	_, err := Parse(strings.NewReader("BEGIN:VCALENDAR\nBEGIN:VEVENT\nSUMMARY:Holiday\nEND:VEVENT\nEND:VCALENDAR\n"))
+	assert.EqualError(suite.T(), err, "invalid calendar: event on line 2 has no DTSTART")
+}
+
+func TestICalTestSuite(t *testing.T) {
+	suite.Run(t, new(ICalTestSuite))
+}
@@ -0,0 +1,59 @@
+package logger
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestNew_AddsContextValues(t *testing.T) {
+	var buf bytes.Buffer
+	log := New(&buf, slog.LevelInfo)
+
+	ctx := WithRoute(WithUserID(WithRequestID(context.Background(), "req-1"), "user-1"), "/api/v1/rooms/:id")
+	log.With("component", "test").ErrorContext(ctx, "roomRepository.Get", "err", "boom")
+
+	var line map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+	assert.Equal(t, "req-1", line["requestId"])
+	assert.Equal(t, "user-1", line["userId"])
+	assert.Equal(t, "/api/v1/rooms/:id", line["route"])
+	assert.Equal(t, "test", line["component"])
+	assert.Equal(t, "boom", line["err"])
+}
+
+func TestNew_AddsTraceID(t *testing.T) {
+	var buf bytes.Buffer
+	traceID, _ := trace.TraceIDFromHex("4bf92f3577b34da6a3ce929d0e0e4736")
+	spanID, _ := trace.SpanIDFromHex("00f067aa0ba902b7")
+	ctx := trace.ContextWithSpanContext(context.Background(), trace.NewSpanContext(trace.SpanContextConfig{TraceID: traceID, SpanID: spanID}))
+
+	New(&buf, slog.LevelInfo).InfoContext(ctx, "traced")
+
+	var line map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+	assert.Equal(t, "4bf92f3577b34da6a3ce929d0e0e4736", line["traceId"])
+}
+
+func TestNew_WithoutContextValues(t *testing.T) {
+	var buf bytes.Buffer
+	New(&buf, slog.LevelInfo).Info("server listening")
+
+	var line map[string]any
+	require.NoError(t, json.Unmarshal(buf.Bytes(), &line))
+	assert.NotContains(t, line, "requestId")
+	assert.Equal(t, "server listening", line["msg"])
+}
+
+func TestNew_FiltersBelowLevel(t *testing.T) {
+	var buf bytes.Buffer
+	New(&buf, slog.LevelWarn).Info("ignored")
+
+	assert.Empty(t, buf.String())
+}
@@ -0,0 +1,78 @@
+// Package metrics holds the Prometheus collectors of the application. They
+// are registered on Registry, which is served on /metrics.
+package metrics
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+)
+
+const namespace = "reservify"
+
+// Booking events counted by BookingsTotal.
+const (
+	BookingCreated  = "created"
+	BookingAccepted = "accepted"
+	BookingDeclined = "declined"
+)
+
+// Reasons counted by BookingConflictsTotal.
+const (
+	ConflictRoomUnavailable   = "room_unavailable"
+	ConflictInsufficientStock = "insufficient_stock"
+	ConflictRoomClosed        = "room_closed"
+)
+
+// Report kinds observed by ReportGenerationDuration.
+const (
+	ReportTransactions = "transactions"
+	ReportChargeback   = "chargeback"
+)
+
+var Registry = prometheus.NewRegistry()
+
+var (
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by method, route template and status code.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route", "status"})
+
+	BookingsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "bookings_total",
+		Help:      "Bookings by lifecycle event: created, accepted or declined.",
+	}, []string{"event"})
+
+	BookingConflictsTotal = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "booking_conflicts_total",
+		Help:      "Booking requests rejected because the room or facilities were not available.",
+	}, []string{"reason"})
+
+	LoginFailuresTotal = prometheus.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "login_failures_total",
+		Help:      "Failed login attempts.",
+	})
+
+	ReportGenerationDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "report_generation_duration_seconds",
+		Help:      "Time spent generating reports by report kind.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"report"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		HTTPRequestDuration,
+		BookingsTotal,
+		BookingConflictsTotal,
+		LoginFailuresTotal,
+		ReportGenerationDuration,
+	)
+}
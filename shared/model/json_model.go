@@ -1,5 +1,7 @@
 package model
 
+import "booking-room-app/shared/apperror"
+
 type Status struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
@@ -15,3 +17,22 @@ type PagedResponse struct {
 	Data   []interface{} `json:"data"`
 	Paging Paging        `json:"paging"`
 }
+
+// SearchResponse is a page of search results with the counts of the values
+// the results can be narrowed down by.
+type SearchResponse struct {
+	Status Status        `json:"status"`
+	Data   []interface{} `json:"data"`
+	Paging Paging        `json:"paging"`
+	Facets interface{}   `json:"facets"`
+}
+
+// ErrorResponse is the body of every failed request. Error is a stable,
+// machine-readable code; Message is meant for people and may change.
+type ErrorResponse struct {
+	Code      int                   `json:"code"`
+	Message   string                `json:"message"`
+	Error     string                `json:"error"`
+	Fields    []apperror.FieldError `json:"fields,omitempty"`
+	RequestId string                `json:"requestId,omitempty"`
+}
@@ -0,0 +1,14 @@
+package model
+
+type MailAttachment struct {
+	Filename    string
+	ContentType string
+	Content     []byte
+}
+
+type MailMessage struct {
+	To          []string
+	Subject     string
+	Body        string
+	Attachments []MailAttachment
+}
@@ -0,0 +1,155 @@
+package service
+
+import (
+	"booking-room-app/config"
+	"booking-room-app/shared/model"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/tls"
+	"encoding/base64"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strings"
+	"time"
+)
+
+type MailService interface {
+	// Send delivers the message, giving up when ctx is done.
+	Send(ctx context.Context, message model.MailMessage) error
+}
+
+type smtpMailService struct {
+	cfg config.MailConfig
+}
+
+func (s *smtpMailService) Send(ctx context.Context, message model.MailMessage) error {
+	if len(message.To) == 0 {
+		return fmt.Errorf("oops, mail has no recipients")
+	}
+
+	body, err := buildMessage(s.cfg.MailFrom, message)
+	if err != nil {
+		return fmt.Errorf("oops, failed to build mail: %v", err)
+	}
+
+	var auth smtp.Auth
+	if s.cfg.MailUser != "" {
+		auth = smtp.PlainAuth("", s.cfg.MailUser, s.cfg.MailPassword, s.cfg.MailHost)
+	}
+
+	addr := net.JoinHostPort(s.cfg.MailHost, s.cfg.MailPort)
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", addr)
+	if err != nil {
+		return fmt.Errorf("oops, failed to send mail: %v", err)
+	}
+	defer conn.Close()
+
+	// a server that stops answering must not outlive the context
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetDeadline(deadline)
+	}
+	stop := context.AfterFunc(ctx, func() { conn.SetDeadline(time.Now()) })
+	defer stop()
+
+	if err := s.send(conn, auth, message.To, body); err != nil {
+		return fmt.Errorf("oops, failed to send mail: %v", err)
+	}
+	return nil
+}
+
+// send holds the SMTP conversation of smtp.SendMail on an open connection.
+func (s *smtpMailService) send(conn net.Conn, auth smtp.Auth, to []string, body []byte) error {
+	client, err := smtp.NewClient(conn, s.cfg.MailHost)
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: s.cfg.MailHost}); err != nil {
+			return err
+		}
+	}
+	if auth != nil {
+		if ok, _ := client.Extension("AUTH"); !ok {
+			return fmt.Errorf("server doesn't support AUTH")
+		}
+		if err := client.Auth(auth); err != nil {
+			return err
+		}
+	}
+
+	if err := client.Mail(s.cfg.MailFrom); err != nil {
+		return err
+	}
+	for _, recipient := range to {
+		if err := client.Rcpt(recipient); err != nil {
+			return err
+		}
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(body); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
+
+func buildMessage(from string, message model.MailMessage) ([]byte, error) {
+	var buf bytes.Buffer
+
+	boundary, err := randomBoundary()
+	if err != nil {
+		return nil, err
+	}
+
+	fmt.Fprintf(&buf, "From: %s\r\n", from)
+	fmt.Fprintf(&buf, "To: %s\r\n", strings.Join(message.To, ", "))
+	fmt.Fprintf(&buf, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", message.Subject))
+	fmt.Fprintf(&buf, "Date: %s\r\n", time.Now().Format(time.RFC1123Z))
+	fmt.Fprintf(&buf, "MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&buf, "Content-Type: multipart/mixed; boundary=%q\r\n\r\n", boundary)
+
+	fmt.Fprintf(&buf, "--%s\r\n", boundary)
+	fmt.Fprintf(&buf, "Content-Type: text/plain; charset=utf-8\r\n\r\n")
+	fmt.Fprintf(&buf, "%s\r\n", message.Body)
+
+	for _, attachment := range message.Attachments {
+		fmt.Fprintf(&buf, "--%s\r\n", boundary)
+		fmt.Fprintf(&buf, "Content-Type: %s\r\n", attachment.ContentType)
+		fmt.Fprintf(&buf, "Content-Transfer-Encoding: base64\r\n")
+		fmt.Fprintf(&buf, "Content-Disposition: attachment; filename=%q\r\n\r\n", attachment.Filename)
+
+		encoded := base64.StdEncoding.EncodeToString(attachment.Content)
+		// RFC 2045 limits encoded lines to 76 characters
+		for len(encoded) > 76 {
+			fmt.Fprintf(&buf, "%s\r\n", encoded[:76])
+			encoded = encoded[76:]
+		}
+		fmt.Fprintf(&buf, "%s\r\n", encoded)
+	}
+	fmt.Fprintf(&buf, "--%s--\r\n", boundary)
+
+	return buf.Bytes(), nil
+}
+
+func randomBoundary() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%x", b), nil
+}
+
+func NewMailService(cfg config.MailConfig) MailService {
+	return &smtpMailService{cfg: cfg}
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"booking-room-app/config"
+	"booking-room-app/shared/model"
+	"bufio"
+	"context"
+	"net"
+	"net/textproto"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// smtpSink is a minimal SMTP server that records every message it receives.
+type smtpSink struct {
+	listener net.Listener
+	messages chan sinkMessage
+}
+
+type sinkMessage struct {
+	from string
+	to   []string
+	data string
+}
+
+func newSmtpSink() (*smtpSink, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	sink := &smtpSink{listener: listener, messages: make(chan sinkMessage, 10)}
+	go sink.serve()
+	return sink, nil
+}
+
+func (s *smtpSink) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.handle(conn)
+	}
+}
+
+func (s *smtpSink) handle(conn net.Conn) {
+	defer conn.Close()
+	tp := textproto.NewConn(conn)
+	var msg sinkMessage
+
+	tp.PrintfLine("220 sink ready")
+	for {
+		line, err := tp.ReadLine()
+		if err != nil {
+			return
+		}
+		cmd := strings.ToUpper(line)
+		switch {
+		case strings.HasPrefix(cmd, "EHLO"), strings.HasPrefix(cmd, "HELO"):
+			tp.PrintfLine("250 sink")
+		case strings.HasPrefix(cmd, "MAIL FROM:"):
+			msg.from = strings.Trim(line[len("MAIL FROM:"):], "<> ")
+			tp.PrintfLine("250 ok")
+		case strings.HasPrefix(cmd, "RCPT TO:"):
+			msg.to = append(msg.to, strings.Trim(line[len("RCPT TO:"):], "<> "))
+			tp.PrintfLine("250 ok")
+		case cmd == "DATA":
+			tp.PrintfLine("354 go ahead")
+			data, err := tp.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			msg.data = string(data)
+			s.messages <- msg
+			msg = sinkMessage{}
+			tp.PrintfLine("250 queued")
+		case cmd == "QUIT":
+			tp.PrintfLine("221 bye")
+			return
+		default:
+			tp.PrintfLine("250 ok")
+		}
+	}
+}
+
+func (s *smtpSink) port() string {
+	_, port, _ := net.SplitHostPort(s.listener.Addr().String())
+	return port
+}
+
+type MailServiceTestSuite struct {
+	suite.Suite
+	sink *smtpSink
+	ms   MailService
+}
+
+func (suite *MailServiceTestSuite) SetupTest() {
+	sink, err := newSmtpSink()
+	suite.Require().NoError(err)
+	suite.sink = sink
+	suite.ms = NewMailService(config.MailConfig{MailHost: "127.0.0.1", MailPort: sink.port(), MailFrom: "reservify@example.com"})
+}
+
+func (suite *MailServiceTestSuite) TearDownTest() {
+	suite.sink.listener.Close()
+}
+
+func (suite *MailServiceTestSuite) TestSend_Success() {
+	err := suite.ms.Send(context.Background(), model.MailMessage{
+		To:      []string{"manager@example.com", "finance@example.com"},
+		Subject: "Weekly booking report",
+		Body:    "Please find the report attached.",
+		Attachments: []model.MailAttachment{
+			{Filename: "transaction.csv", ContentType: "text/csv", Content: []byte("ID,Nama Pegawai\n1,Admin Enigma\n")},
+		},
+	})
+	assert.NoError(suite.T(), err)
+
+	received := <-suite.sink.messages
+	assert.Equal(suite.T(), "reservify@example.com", received.from)
+	assert.Equal(suite.T(), []string{"manager@example.com", "finance@example.com"}, received.to)
+
+	reader := bufio.NewReader(strings.NewReader(received.data))
+	header, err := textproto.NewReader(reader).ReadMIMEHeader()
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "Weekly booking report", header.Get("Subject"))
+	assert.Contains(suite.T(), header.Get("Content-Type"), "multipart/mixed")
+	assert.Contains(suite.T(), received.data, `filename="transaction.csv"`)
+}
+
+func (suite *MailServiceTestSuite) TestSend_NoRecipientsFailure() {
+	err := suite.ms.Send(context.Background(), model.MailMessage{Subject: "Weekly booking report"})
+	assert.Error(suite.T(), err)
+}
+
+func (suite *MailServiceTestSuite) TestSend_ConnectionFailure() {
+	suite.sink.listener.Close()
+	err := suite.ms.Send(context.Background(), model.MailMessage{To: []string{"manager@example.com"}, Subject: "Weekly booking report"})
+	assert.Error(suite.T(), err)
+}
+
+func (suite *MailServiceTestSuite) TestSend_StuckServerFailure() {
+	// a server that accepts the connection but never greets
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	suite.Require().NoError(err)
+	defer listener.Close()
+	go func() {
+		conn, err := listener.Accept()
+		if err == nil {
+			defer conn.Close()
+			time.Sleep(5 * time.Second)
+		}
+	}()
+	_, port, _ := net.SplitHostPort(listener.Addr().String())
+	ms := NewMailService(config.MailConfig{MailHost: "127.0.0.1", MailPort: port, MailFrom: "reservify@example.com"})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	start := time.Now()
+	err = ms.Send(ctx, model.MailMessage{To: []string{"manager@example.com"}, Subject: "Weekly booking report"})
+	assert.Error(suite.T(), err)
+	assert.Less(suite.T(), time.Since(start), time.Second)
+}
+
+func TestMailServiceTestSuite(t *testing.T) {
+	suite.Run(t, new(MailServiceTestSuite))
+}
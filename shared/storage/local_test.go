@@ -0,0 +1,67 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type LocalStoreTestSuite struct {
+	suite.Suite
+	dir   string
+	store *LocalStore
+}
+
+func (suite *LocalStoreTestSuite) SetupTest() {
+	suite.dir = suite.T().TempDir()
+	store, err := NewLocalStore(filepath.Join(suite.dir, "attachments"))
+	suite.Require().NoError(err)
+	suite.store = store
+}
+
+func (suite *LocalStoreTestSuite) TestPutGetDelete_Success() {
+	ctx := context.Background()
+	err := suite.store.Put(ctx, "rooms/9/photo.jpg", strings.NewReader("jpeg"), 4, "image/jpeg")
+	assert.NoError(suite.T(), err)
+
+	body, err := suite.store.Get(ctx, "rooms/9/photo.jpg")
+	assert.NoError(suite.T(), err)
+	content, _ := io.ReadAll(body)
+	body.Close()
+	assert.Equal(suite.T(), "jpeg", string(content))
+
+	assert.NoError(suite.T(), suite.store.Delete(ctx, "rooms/9/photo.jpg"))
+	_, err = suite.store.Get(ctx, "rooms/9/photo.jpg")
+	assert.ErrorIs(suite.T(), err, ErrNotFound)
+}
+
+func (suite *LocalStoreTestSuite) TestPut_LeavesNoTemporaryFilesSuccess() {
+	err := suite.store.Put(context.Background(), "rooms/9/plan.pdf", strings.NewReader("%PDF"), 4, "application/pdf")
+	assert.NoError(suite.T(), err)
+
+	entries, _ := os.ReadDir(filepath.Join(suite.dir, "attachments", "rooms", "9"))
+	assert.Len(suite.T(), entries, 1)
+}
+
+func (suite *LocalStoreTestSuite) TestDelete_MissingSuccess() {
+	assert.NoError(suite.T(), suite.store.Delete(context.Background(), "rooms/9/missing.jpg"))
+}
+
+func (suite *LocalStoreTestSuite) TestPut_EscapingKeyFail() {
+	for _, key := range []string{"../secret", "/etc/passwd", "rooms//photo.jpg", `rooms\photo.jpg`, ""} {
+		err := suite.store.Put(context.Background(), key, strings.NewReader("x"), 1, "")
+		assert.Error(suite.T(), err, key)
+	}
+	_, err := os.Stat(filepath.Join(suite.dir, "secret"))
+	assert.True(suite.T(), os.IsNotExist(err))
+}
+
+func TestLocalStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(LocalStoreTestSuite))
+}
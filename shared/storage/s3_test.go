@@ -0,0 +1,124 @@
+package storage
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+// fakeS3 is a MinIO-style stand-in that keeps the objects of path-style
+// requests in memory and refuses requests that are not signed.
+type fakeS3 struct {
+	mu      sync.Mutex
+	objects map[string]fakeObject
+	server  *httptest.Server
+}
+
+type fakeObject struct {
+	contentType string
+	data        []byte
+}
+
+func newFakeS3() *fakeS3 {
+	f := &fakeS3{objects: make(map[string]fakeObject)}
+	f.server = httptest.NewServer(http.HandlerFunc(f.serve))
+	return f
+}
+
+func (f *fakeS3) serve(w http.ResponseWriter, r *http.Request) {
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=minio/") || r.Header.Get("X-Amz-Content-Sha256") == "" {
+		http.Error(w, "<Error><Code>AccessDenied</Code></Error>", http.StatusForbidden)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	switch r.Method {
+	case http.MethodPut:
+		data, _ := io.ReadAll(r.Body)
+		f.objects[r.URL.Path] = fakeObject{contentType: r.Header.Get("Content-Type"), data: data}
+	case http.MethodGet:
+		object, ok := f.objects[r.URL.Path]
+		if !ok {
+			http.Error(w, "<Error><Code>NoSuchKey</Code></Error>", http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", object.contentType)
+		w.Write(object.data)
+	case http.MethodDelete:
+		delete(f.objects, r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+type S3StoreTestSuite struct {
+	suite.Suite
+	s3    *fakeS3
+	store *S3Store
+}
+
+func (suite *S3StoreTestSuite) SetupTest() {
+	suite.s3 = newFakeS3()
+	store, err := NewS3Store(S3Config{Endpoint: suite.s3.server.URL, Bucket: "attachments", AccessKey: "minio", SecretKey: "minio123"})
+	suite.Require().NoError(err)
+	suite.store = store
+}
+
+func (suite *S3StoreTestSuite) TearDownTest() {
+	suite.s3.server.Close()
+}
+
+func (suite *S3StoreTestSuite) TestPutGetDelete_Success() {
+	ctx := context.Background()
+	err := suite.store.Put(ctx, "rooms/9/ruang melati.jpg", strings.NewReader("jpeg"), 4, "image/jpeg")
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "image/jpeg", suite.s3.objects["/attachments/rooms/9/ruang melati.jpg"].contentType)
+
+	body, err := suite.store.Get(ctx, "rooms/9/ruang melati.jpg")
+	assert.NoError(suite.T(), err)
+	content, _ := io.ReadAll(body)
+	body.Close()
+	assert.Equal(suite.T(), "jpeg", string(content))
+
+	assert.NoError(suite.T(), suite.store.Delete(ctx, "rooms/9/ruang melati.jpg"))
+	_, err = suite.store.Get(ctx, "rooms/9/ruang melati.jpg")
+	assert.ErrorIs(suite.T(), err, ErrNotFound)
+}
+
+func (suite *S3StoreTestSuite) TestPut_AccessDeniedFail() {
+	store, _ := NewS3Store(S3Config{Endpoint: suite.s3.server.URL, Bucket: "attachments", AccessKey: "other", SecretKey: "secret"})
+
+	err := store.Put(context.Background(), "rooms/9/photo.jpg", strings.NewReader("jpeg"), 4, "image/jpeg")
+
+	assert.ErrorContains(suite.T(), err, "403")
+	assert.ErrorContains(suite.T(), err, "AccessDenied")
+}
+
+func (suite *S3StoreTestSuite) TestNewS3Store_InvalidEndpointFail() {
+	_, err := NewS3Store(S3Config{Endpoint: "localhost:9000", Bucket: "attachments"})
+
+	assert.Error(suite.T(), err)
+}
+
+// TestSignV4_Success checks the signer against the get-vanilla example of
+// the AWS Signature Version 4 test suite.
+func (suite *S3StoreTestSuite) TestSignV4_Success() {
+	req, _ := http.NewRequest(http.MethodGet, "https://example.amazonaws.com/", nil)
+
+	signV4(req, emptyPayload, "AKIDEXAMPLE", "wJalrXUtnFEMI/K7MDENG+bPxRfiCYEXAMPLEKEY", "us-east-1", "service", time.Date(2015, time.August, 30, 12, 36, 0, 0, time.UTC))
+
+	assert.Equal(suite.T(), "20150830T123600Z", req.Header.Get("X-Amz-Date"))
+	assert.Equal(suite.T(), "AWS4-HMAC-SHA256 Credential=AKIDEXAMPLE/20150830/us-east-1/service/aws4_request, SignedHeaders=host;x-amz-date, Signature=5fa00fa31553b73ebf1942676e86291e8372ff2a2260956d9b8aae1d763fbf31", req.Header.Get("Authorization"))
+}
+
+func TestS3StoreTestSuite(t *testing.T) {
+	suite.Run(t, new(S3StoreTestSuite))
+}
@@ -0,0 +1,67 @@
+package thumbnail
+
+import (
+	"bytes"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ThumbnailTestSuite struct {
+	suite.Suite
+}
+
+func encodePNG(width, height int, fill color.Color) []byte {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, fill)
+		}
+	}
+	var buf bytes.Buffer
+	png.Encode(&buf, img)
+	return buf.Bytes()
+}
+
+func (suite *ThumbnailTestSuite) TestGenerate_LandscapeSuccess() {
+	data, err := Generate(bytes.NewReader(encodePNG(800, 400, color.RGBA{R: 200, A: 255})), 320)
+
+	assert.NoError(suite.T(), err)
+	img, err := jpeg.Decode(bytes.NewReader(data))
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), image.Rect(0, 0, 320, 160), img.Bounds())
+	r, _, _, _ := img.At(100, 80).RGBA()
+	assert.InDelta(suite.T(), 200, r>>8, 6)
+}
+
+func (suite *ThumbnailTestSuite) TestGenerate_PortraitSuccess() {
+	data, err := Generate(bytes.NewReader(encodePNG(300, 900, color.White)), 300)
+
+	assert.NoError(suite.T(), err)
+	img, _ := jpeg.Decode(bytes.NewReader(data))
+	assert.Equal(suite.T(), image.Rect(0, 0, 100, 300), img.Bounds())
+}
+
+func (suite *ThumbnailTestSuite) TestGenerate_SmallImageKeepsSizeSuccess() {
+	data, err := Generate(bytes.NewReader(encodePNG(40, 30, color.Black)), 320)
+
+	assert.NoError(suite.T(), err)
+	img, _ := jpeg.Decode(bytes.NewReader(data))
+	assert.Equal(suite.T(), image.Rect(0, 0, 40, 30), img.Bounds())
+}
+
+func (suite *ThumbnailTestSuite) TestGenerate_NotAnImageFail() {
+	_, err := Generate(strings.NewReader("%PDF-1.7"), 320)
+
+	assert.ErrorIs(suite.T(), err, image.ErrFormat)
+}
+
+func TestThumbnailTestSuite(t *testing.T) {
+	suite.Run(t, new(ThumbnailTestSuite))
+}
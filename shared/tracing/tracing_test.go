@@ -0,0 +1,88 @@
+package tracing
+
+import (
+	"booking-room-app/config"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/XSAM/otelsql"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestSetup_NoopWithoutEndpoint(t *testing.T) {
+	shutdown, err := Setup(context.Background(), config.TracingConfig{})
+
+	assert.NoError(t, err)
+	assert.NoError(t, shutdown(context.Background()))
+}
+
+func TestSetup_ExportsToConfiguredEndpoint(t *testing.T) {
+	var exports atomic.Int32
+	collector := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodPost && r.URL.Path == "/v1/traces" {
+			exports.Add(1)
+		}
+	}))
+	defer collector.Close()
+	t.Setenv("OTEL_EXPORTER_OTLP_ENDPOINT", "")
+	t.Setenv("OTEL_EXPORTER_OTLP_TRACES_ENDPOINT", "")
+	previous := otel.GetTracerProvider()
+	defer otel.SetTracerProvider(previous)
+
+	shutdown, err := Setup(context.Background(), config.TracingConfig{OtlpEndpoint: collector.URL + "/v1/traces"})
+	require.NoError(t, err)
+	_, span := otel.Tracer("tracing_test").Start(context.Background(), "test")
+	span.End()
+
+	require.NoError(t, shutdown(context.Background()))
+	assert.Equal(t, int32(1), exports.Load())
+}
+
+func TestSQLSpanName(t *testing.T) {
+	cases := map[string]string{
+		"SELECT id, name FROM rooms WHERE id = $1":            "SELECT rooms",
+		"INSERT INTO transaction_facilities (transaction_id)": "INSERT transaction_facilities",
+		"UPDATE facilities SET quantity = quantity - $1":      "UPDATE facilities",
+		"select count(*)\n\t\tfrom trx_room_booking":          "SELECT trx_room_booking",
+		"": string(otelsql.MethodConnQuery),
+	}
+	for query, expected := range cases {
+		assert.Equal(t, expected, SQLSpanName(context.Background(), otelsql.MethodConnQuery, query))
+	}
+}
+
+func TestSQLSpans_InMemoryExporter(t *testing.T) {
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	_, mock, err := sqlmock.NewWithDSN("tracing_test")
+	require.NoError(t, err)
+	db, err := otelsql.Open("sqlmock", "tracing_test", otelsql.WithTracerProvider(provider), otelsql.WithSpanNameFormatter(SQLSpanName))
+	require.NoError(t, err)
+	defer db.Close()
+
+	mock.ExpectQuery("SELECT").WithArgs("1").WillReturnRows(sqlmock.NewRows([]string{"name"}).AddRow("Ruang Candradimuka"))
+
+	ctx, parent := provider.Tracer("test").Start(context.Background(), "roomUseCase.FindRoomByID")
+	var name string
+	require.NoError(t, db.QueryRowContext(ctx, "SELECT name FROM rooms WHERE id = $1", "1").Scan(&name))
+	parent.End()
+
+	spans := exporter.GetSpans()
+	var query *tracetest.SpanStub
+	for i := range spans {
+		if spans[i].Name == "SELECT rooms" {
+			query = &spans[i]
+		}
+	}
+	require.NotNil(t, query)
+	assert.Equal(t, parent.SpanContext().SpanID(), query.Parent.SpanID())
+}
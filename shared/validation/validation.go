@@ -0,0 +1,195 @@
+// Package validation checks request DTOs against their `validate` tags and
+// reports every problem as an apperror.FieldError named after the JSON field.
+//
+// Besides the rules of go-playground/validator it knows:
+//
+//	notblank            the string is not only whitespace
+//	cron                a five-field cron expression
+//	timezone            an IANA time zone name such as Asia/Jakarta
+//	clock               a time of day from 00:00 to 24:00
+//	role                admin, employee or ga
+//	room_status         available, booked or unavailable
+//	room_type           meeting, conference, training or hall
+//	transaction_status  pending, accepted or declined
+//	report_range        day, week, month or year
+//	report_format       csv or json
+package validation
+
+import (
+	"booking-room-app/shared/apperror"
+	"booking-room-app/shared/cron"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var validate = newValidator()
+
+var clockPattern = regexp.MustCompile(`^(([01][0-9]|2[0-3]):[0-5][0-9]|24:00)$`)
+
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	v.RegisterTagNameFunc(jsonName)
+
+	must(v.RegisterValidation("notblank", func(fl validator.FieldLevel) bool {
+		return strings.TrimSpace(fl.Field().String()) != ""
+	}))
+	must(v.RegisterValidation("cron", func(fl validator.FieldLevel) bool {
+		_, err := cron.Parse(fl.Field().String())
+		return err == nil
+	}))
+	must(v.RegisterValidation("timezone", func(fl validator.FieldLevel) bool {
+		_, err := time.LoadLocation(fl.Field().String())
+		return err == nil && fl.Field().String() != "Local"
+	}))
+	must(v.RegisterValidation("clock", func(fl validator.FieldLevel) bool {
+		return clockPattern.MatchString(fl.Field().String())
+	}))
+
+	v.RegisterAlias("role", "oneof=admin employee ga")
+	v.RegisterAlias("room_status", "oneof=available booked unavailable")
+	v.RegisterAlias("room_type", "oneof=meeting conference training hall")
+	v.RegisterAlias("transaction_status", "oneof=pending accepted declined")
+	v.RegisterAlias("report_range", "oneof=day week month year")
+	v.RegisterAlias("report_format", "oneof=csv json")
+	return v
+}
+
+func must(err error) {
+	if err != nil {
+		panic(err)
+	}
+}
+
+// Struct validates payload and returns an *apperror.Error with one FieldError
+// per failed rule, or nil when payload is valid.
+func Struct(payload any) error {
+	return result(validate.Struct(payload))
+}
+
+// Var validates a single value, such as a path parameter, reporting problems
+// under the given field name.
+func Var(field string, value any, tag string) error {
+	err := validate.Var(value, tag)
+	var invalid validator.ValidationErrors
+	if !errors.As(err, &invalid) {
+		return result(err)
+	}
+	fields := make([]apperror.FieldError, 0, len(invalid))
+	for _, fe := range invalid {
+		fields = append(fields, apperror.Field(field, fe.Tag(), message(field, fe)))
+	}
+	return apperror.Validation("the request is invalid", fields...)
+}
+
+func result(err error) error {
+	if err == nil {
+		return nil
+	}
+	var invalid validator.ValidationErrors
+	if !errors.As(err, &invalid) {
+		return apperror.Internal(err)
+	}
+	fields := make([]apperror.FieldError, 0, len(invalid))
+	for _, fe := range invalid {
+		field := fieldPath(fe.Namespace())
+		fields = append(fields, apperror.Field(field, fe.Tag(), message(field, fe)))
+	}
+	return apperror.Validation("the request is invalid", fields...)
+}
+
+// embedded names an embedded struct in a namespace; its fields belong to the
+// enclosing JSON object.
+const embedded = "~"
+
+// fieldPath turns a namespace such as RoomRequestDto.facilities[0].quantity
+// into facilities[0].quantity. The Go names of the top-level and embedded
+// structs are dropped since they are not part of the JSON document.
+func fieldPath(namespace string) string {
+	segments := strings.Split(namespace, ".")
+	path := make([]string, 0, len(segments))
+	for _, segment := range segments[1:] {
+		if segment == embedded {
+			continue
+		}
+		path = append(path, segment)
+	}
+	return strings.Join(path, ".")
+}
+
+func message(field string, fe validator.FieldError) string {
+	switch fe.Tag() {
+	case "required":
+		return field + " is required"
+	case "required_without_all":
+		return fmt.Sprintf("%s is required when %s are empty", field, fieldNames(fe.Param()))
+	case "notblank":
+		return field + " must not be blank"
+	case "uuid", "uuid4":
+		return field + " must be a UUID"
+	case "email":
+		return field + " must be a valid email address"
+	case "cron":
+		return field + " must be a cron expression with five fields"
+	case "timezone":
+		return field + " must be an IANA time zone such as Asia/Jakarta"
+	case "clock":
+		return field + " must be a time of day such as 08:00"
+	case "datetime":
+		return field + " must be formatted as " + fe.Param()
+	case "min", "gte":
+		return bound(field, "at least", fe)
+	case "max", "lte":
+		return bound(field, "at most", fe)
+	case "gt":
+		return bound(field, "more than", fe)
+	case "lt":
+		return bound(field, "less than", fe)
+	case "gtfield":
+		return fmt.Sprintf("%s must be after %s", field, fieldNames(fe.Param()))
+	case "gtefield":
+		return fmt.Sprintf("%s must not be before %s", field, fieldNames(fe.Param()))
+	}
+	if fe.ActualTag() == "oneof" {
+		return fmt.Sprintf("%s must be one of %s", field, strings.ReplaceAll(fe.Param(), " ", ", "))
+	}
+	return field + " is invalid"
+}
+
+// bound describes a size rule in terms of characters for strings, items for
+// collections and the value itself for numbers.
+func bound(field, relation string, fe validator.FieldError) string {
+	switch fe.Kind() {
+	case reflect.String:
+		return fmt.Sprintf("%s must have %s %s characters", field, relation, fe.Param())
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return fmt.Sprintf("%s must have %s %s items", field, relation, fe.Param())
+	}
+	return fmt.Sprintf("%s must be %s %s", field, relation, fe.Param())
+}
+
+// fieldNames turns the Go field names in a rule parameter into JSON-style
+// names, e.g. "StartTime" becomes "startTime".
+func fieldNames(param string) string {
+	names := strings.Fields(param)
+	for i, name := range names {
+		names[i] = strings.ToLower(name[:1]) + name[1:]
+	}
+	return strings.Join(names, ", ")
+}
+
+func jsonName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("json"), ",")
+	switch {
+	case name == "-":
+		return ""
+	case name == "" && field.Anonymous:
+		return embedded
+	}
+	return name
+}
@@ -0,0 +1,103 @@
+package validation
+
+import (
+	"booking-room-app/shared/apperror"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type itemPayload struct {
+	FacilityId string `json:"facilityId" validate:"required,uuid"`
+	Quantity   int    `json:"quantity" validate:"required,gt=0"`
+}
+
+type basePayload struct {
+	Name string `json:"name" validate:"notblank,max=5"`
+}
+
+type bookingPayload struct {
+	basePayload
+	Type      string        `json:"type" validate:"room_type"`
+	Schedule  string        `json:"schedule" validate:"omitempty,cron"`
+	Items     []itemPayload `json:"items" validate:"omitempty,dive"`
+	StartTime time.Time     `json:"startTime" validate:"required"`
+	EndTime   time.Time     `json:"endTime" validate:"required,gtfield=StartTime"`
+}
+
+func validPayload() bookingPayload {
+	start := time.Date(2024, time.January, 8, 9, 0, 0, 0, time.UTC)
+	return bookingPayload{
+		basePayload: basePayload{Name: "room"},
+		Type:        "meeting",
+		Schedule:    "0 7 * * 1",
+		Items:       []itemPayload{{FacilityId: "0f8fad5b-d9cb-469f-a165-70867728950e", Quantity: 1}},
+		StartTime:   start,
+		EndTime:     start.Add(time.Hour),
+	}
+}
+
+func fieldsOf(t *testing.T, err error) []apperror.FieldError {
+	require.Error(t, err)
+	appErr := apperror.From(err)
+	require.Equal(t, apperror.KindValidation, appErr.Kind)
+	return appErr.Fields
+}
+
+func TestStruct_Success(t *testing.T) {
+	assert.NoError(t, Struct(validPayload()))
+}
+
+func TestStruct_FieldsFail(t *testing.T) {
+	payload := validPayload()
+	payload.Name = "   "
+	payload.Type = "office"
+	payload.Schedule = "every monday"
+	payload.Items[0].Quantity = 0
+	payload.EndTime = payload.StartTime
+
+	assert.Equal(t, []apperror.FieldError{
+		{Field: "name", Code: "notblank", Message: "name must not be blank"},
+		{Field: "type", Code: "room_type", Message: "type must be one of meeting, conference, training, hall"},
+		{Field: "schedule", Code: "cron", Message: "schedule must be a cron expression with five fields"},
+		{Field: "items[0].quantity", Code: "required", Message: "items[0].quantity is required"},
+		{Field: "endTime", Code: "gtfield", Message: "endTime must be after startTime"},
+	}, fieldsOf(t, Struct(payload)))
+}
+
+func TestStruct_BoundFail(t *testing.T) {
+	payload := validPayload()
+	payload.Name = "meeting room"
+	payload.Items[0].Quantity = -1
+
+	assert.Equal(t, []apperror.FieldError{
+		{Field: "name", Code: "max", Message: "name must have at most 5 characters"},
+		{Field: "items[0].quantity", Code: "gt", Message: "items[0].quantity must be more than 0"},
+	}, fieldsOf(t, Struct(payload)))
+}
+
+func TestVar_Success(t *testing.T) {
+	assert.NoError(t, Var("id", "0f8fad5b-d9cb-469f-a165-70867728950e", "required,uuid"))
+}
+
+func TestVar_Fail(t *testing.T) {
+	assert.Equal(t, []apperror.FieldError{
+		{Field: "id", Code: "uuid", Message: "id must be a UUID"},
+	}, fieldsOf(t, Var("id", "1", "required,uuid")))
+}
+
+func TestVar_TimezoneFail(t *testing.T) {
+	assert.NoError(t, Var("timezone", "Asia/Jakarta", "timezone"))
+	assert.Equal(t, []apperror.FieldError{
+		{Field: "timezone", Code: "timezone", Message: "timezone must be an IANA time zone such as Asia/Jakarta"},
+	}, fieldsOf(t, Var("timezone", "Local", "timezone")))
+}
+
+func TestVar_ClockFail(t *testing.T) {
+	assert.NoError(t, Var("close", "24:00", "clock"))
+	assert.Equal(t, []apperror.FieldError{
+		{Field: "open", Code: "clock", Message: "open must be a time of day such as 08:00"},
+	}, fieldsOf(t, Var("open", "8:00", "clock")))
+}
@@ -0,0 +1,29 @@
+package usecase
+
+import (
+	"booking-room-app/entity"
+	"booking-room-app/shared/metrics"
+)
+
+// Archive policies decide what archiving a room, facility or employee does to
+// the pending and accepted bookings that have not ended yet and refer to it.
+const (
+	// ArchiveRefuse keeps the record and reports the open bookings.
+	ArchiveRefuse = "refuse"
+	// ArchiveCascade declines the open bookings and archives the record.
+	ArchiveCascade = "cascade"
+)
+
+// cascadeArchive reports whether policy, from ARCHIVE_POLICY, declines the
+// open bookings.
+func cascadeArchive(policy string) bool {
+	return policy == ArchiveCascade
+}
+
+// declinedByArchive records the bookings an archive cascade declined.
+func declinedByArchive(archival entity.Archival) {
+	if len(archival.DeclinedTransactions) == 0 {
+		return
+	}
+	metrics.BookingsTotal.WithLabelValues(metrics.BookingDeclined).Add(float64(len(archival.DeclinedTransactions)))
+}
@@ -0,0 +1,234 @@
+package usecase
+
+import (
+	"booking-room-app/entity"
+	"booking-room-app/repository"
+	"booking-room-app/shared/apperror"
+	"booking-room-app/shared/storage"
+	"booking-room-app/shared/thumbnail"
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"path"
+	"strings"
+)
+
+// ThumbnailSize is the edge in pixels of the square a thumbnail fits in.
+const ThumbnailSize = 320
+
+// attachmentTypes are the accepted content types, sniffed from the file
+// itself rather than trusted from the client, with their file extension.
+var attachmentTypes = map[string]string{
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+	"application/pdf": ".pdf",
+}
+
+// attachmentDirs are the blob store prefixes of the attachment owners.
+var attachmentDirs = map[string]string{
+	entity.AttachmentOwnerRoom:     "rooms",
+	entity.AttachmentOwnerFacility: "facilities",
+}
+
+var ErrAttachmentTooLarge = apperror.TooLarge("attachment_too_large", "the file exceeds the maximum upload size")
+
+type AttachmentUseCase interface {
+	UploadAttachment(ctx context.Context, ownerType, ownerId, filename string, body io.Reader) (entity.Attachment, error)
+	FindAttachments(ctx context.Context, ownerType, ownerId string) ([]entity.Attachment, error)
+	OpenAttachment(ctx context.Context, id string, thumbnail bool) (entity.Attachment, io.ReadCloser, error)
+	DeleteAttachment(ctx context.Context, id string) error
+}
+
+type attachmentUseCase struct {
+	repo  repository.AttachmentRepository
+	store storage.BlobStore
+	// maxSize is the largest file in bytes that can be uploaded.
+	maxSize int64
+}
+
+// UploadAttachment implements AttachmentUseCase. The file is stored before
+// its row is written, and removed again when the row cannot be written, so a
+// listed attachment can always be downloaded.
+func (a *attachmentUseCase) UploadAttachment(ctx context.Context, ownerType, ownerId, filename string, body io.Reader) (entity.Attachment, error) {
+	ctx, span := startSpan(ctx, "attachmentUseCase.UploadAttachment")
+	defer span.End()
+
+	dir, ok := attachmentDirs[ownerType]
+	if !ok {
+		return entity.Attachment{}, apperror.Validation("the request is invalid", apperror.Field("ownerType", "oneof", "ownerType must be one of room facility"))
+	}
+
+	data, err := io.ReadAll(io.LimitReader(body, a.maxSize+1))
+	if err != nil {
+		var maxErr *http.MaxBytesError
+		if errors.As(err, &maxErr) {
+			return entity.Attachment{}, ErrAttachmentTooLarge.Wrap(err)
+		}
+		return entity.Attachment{}, apperror.Validation("the file could not be read").Wrap(err)
+	}
+	if len(data) == 0 {
+		return entity.Attachment{}, apperror.Required("file")
+	}
+	if int64(len(data)) > a.maxSize {
+		return entity.Attachment{}, ErrAttachmentTooLarge
+	}
+
+	contentType := http.DetectContentType(data)
+	if i := strings.IndexByte(contentType, ';'); i >= 0 {
+		contentType = contentType[:i]
+	}
+	ext, ok := attachmentTypes[contentType]
+	if !ok {
+		return entity.Attachment{}, apperror.Validation("the request is invalid", apperror.Field("file", "content_type", "file must be a JPEG or PNG image or a PDF document"))
+	}
+
+	exists, err := a.repo.OwnerExists(ctx, ownerType, ownerId)
+	if err != nil {
+		return entity.Attachment{}, dbError(err, ownerType)
+	}
+	if !exists {
+		return entity.Attachment{}, apperror.NotFound(ownerType)
+	}
+
+	var thumb []byte
+	if strings.HasPrefix(contentType, "image/") {
+		thumb, err = thumbnail.Generate(bytes.NewReader(data), ThumbnailSize)
+		if err != nil {
+			return entity.Attachment{}, apperror.Validation("the request is invalid", apperror.Field("file", "image", "file is not a readable image")).Wrap(err)
+		}
+	}
+
+	name, err := blobName()
+	if err != nil {
+		return entity.Attachment{}, apperror.Internal(err)
+	}
+	attachment := entity.Attachment{
+		OwnerType:   ownerType,
+		OwnerId:     ownerId,
+		Filename:    attachmentFilename(filename, ext),
+		ContentType: contentType,
+		Size:        int64(len(data)),
+		StorageKey:  dir + "/" + ownerId + "/" + name + ext,
+	}
+	if thumb != nil {
+		attachment.ThumbnailKey = dir + "/" + ownerId + "/" + name + "_thumb.jpg"
+	}
+
+	if err := a.store.Put(ctx, attachment.StorageKey, bytes.NewReader(data), attachment.Size, contentType); err != nil {
+		return entity.Attachment{}, apperror.Internal(err)
+	}
+	if thumb != nil {
+		if err := a.store.Put(ctx, attachment.ThumbnailKey, bytes.NewReader(thumb), int64(len(thumb)), "image/jpeg"); err != nil {
+			a.removeBlobs(ctx, attachment)
+			return entity.Attachment{}, apperror.Internal(err)
+		}
+	}
+
+	created, err := a.repo.Create(ctx, attachment)
+	if err != nil {
+		a.removeBlobs(ctx, attachment)
+		return entity.Attachment{}, dbError(err, "attachment")
+	}
+	return created, nil
+}
+
+// FindAttachments implements AttachmentUseCase.
+func (a *attachmentUseCase) FindAttachments(ctx context.Context, ownerType, ownerId string) ([]entity.Attachment, error) {
+	ctx, span := startSpan(ctx, "attachmentUseCase.FindAttachments")
+	defer span.End()
+
+	attachments, err := a.repo.List(ctx, ownerType, ownerId)
+	if err != nil {
+		return nil, dbError(err, "attachment")
+	}
+	return attachments, nil
+}
+
+// OpenAttachment implements AttachmentUseCase. With thumbnail set it opens
+// the preview of an image instead, whose size is unknown and reported as -1.
+// The caller closes the reader.
+func (a *attachmentUseCase) OpenAttachment(ctx context.Context, id string, thumbnail bool) (entity.Attachment, io.ReadCloser, error) {
+	ctx, span := startSpan(ctx, "attachmentUseCase.OpenAttachment")
+	defer span.End()
+
+	attachment, err := a.repo.Get(ctx, id)
+	if err != nil {
+		return entity.Attachment{}, nil, dbError(err, "attachment")
+	}
+
+	key := attachment.StorageKey
+	if thumbnail {
+		if attachment.ThumbnailKey == "" {
+			return entity.Attachment{}, nil, apperror.NotFound("thumbnail")
+		}
+		key = attachment.ThumbnailKey
+		attachment.ContentType = "image/jpeg"
+		attachment.Size = -1
+	}
+
+	body, err := a.store.Get(ctx, key)
+	if errors.Is(err, storage.ErrNotFound) {
+		return entity.Attachment{}, nil, apperror.NotFound("attachment").Wrap(err)
+	}
+	if err != nil {
+		return entity.Attachment{}, nil, apperror.Internal(err)
+	}
+	return attachment, body, nil
+}
+
+// DeleteAttachment implements AttachmentUseCase. The row goes first; a blob
+// that cannot be removed afterwards is only logged, as it is unreachable.
+func (a *attachmentUseCase) DeleteAttachment(ctx context.Context, id string) error {
+	ctx, span := startSpan(ctx, "attachmentUseCase.DeleteAttachment")
+	defer span.End()
+
+	attachment, err := a.repo.Delete(ctx, id)
+	if err != nil {
+		return dbError(err, "attachment")
+	}
+	a.removeBlobs(ctx, attachment)
+	return nil
+}
+
+func (a *attachmentUseCase) removeBlobs(ctx context.Context, attachment entity.Attachment) {
+	for _, key := range []string{attachment.StorageKey, attachment.ThumbnailKey} {
+		if key == "" {
+			continue
+		}
+		if err := a.store.Delete(ctx, key); err != nil {
+			slog.WarnContext(ctx, "attachment blob could not be removed", "key", key, "err", err)
+		}
+	}
+}
+
+// blobName is a random name, so uploads never overwrite each other and the
+// client's file name never reaches the store.
+func blobName() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
+
+// attachmentFilename keeps the base name the client sent, for downloads,
+// falling back to a generic name.
+func attachmentFilename(filename, ext string) string {
+	name := strings.TrimSpace(path.Base(strings.ReplaceAll(filename, `\`, "/")))
+	if name == "" || name == "." || name == "/" {
+		return "attachment" + ext
+	}
+	if len(name) > 255 {
+		name = name[len(name)-255:]
+	}
+	return name
+}
+
+func NewAttachmentUseCase(repo repository.AttachmentRepository, store storage.BlobStore, maxSize int64) AttachmentUseCase {
+	return &attachmentUseCase{repo: repo, store: store, maxSize: maxSize}
+}
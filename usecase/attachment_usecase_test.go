@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"booking-room-app/entity"
+	"booking-room-app/mock/repo_mock"
+	"booking-room-app/shared/apperror"
+	"booking-room-app/shared/storage"
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"image"
+	"image/png"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type AttachmentUseCaseTestSuite struct {
+	suite.Suite
+	arm *repo_mock.AttachmentRepoMock
+	dir string
+	auc AttachmentUseCase
+}
+
+func (suite *AttachmentUseCaseTestSuite) SetupTest() {
+	suite.arm = new(repo_mock.AttachmentRepoMock)
+	suite.dir = suite.T().TempDir()
+	store, err := storage.NewLocalStore(suite.dir)
+	require.NoError(suite.T(), err)
+	suite.auc = NewAttachmentUseCase(suite.arm, store, 10<<20)
+}
+
+func (suite *AttachmentUseCaseTestSuite) pngImage() []byte {
+	var buf bytes.Buffer
+	require.NoError(suite.T(), png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 800, 600))))
+	return buf.Bytes()
+}
+
+func (suite *AttachmentUseCaseTestSuite) TestUploadAttachment_ImageSuccess() {
+	suite.arm.On("OwnerExists", mock.Anything, "room", "9").Return(true, nil)
+	var actual entity.Attachment
+	suite.arm.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		actual = args.Get(1).(entity.Attachment)
+	}).Return(entity.Attachment{ID: "1"}, nil)
+
+	created, err := suite.auc.UploadAttachment(context.Background(), "room", "9", `C:\photos\melati.png`, bytes.NewReader(suite.pngImage()))
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "1", created.ID)
+	assert.Equal(suite.T(), "melati.png", actual.Filename)
+	assert.Equal(suite.T(), "image/png", actual.ContentType)
+	assert.True(suite.T(), strings.HasPrefix(actual.StorageKey, "rooms/9/"))
+	assert.FileExists(suite.T(), filepath.Join(suite.dir, actual.StorageKey))
+	assert.FileExists(suite.T(), filepath.Join(suite.dir, actual.ThumbnailKey))
+}
+
+func (suite *AttachmentUseCaseTestSuite) TestUploadAttachment_PdfSuccess() {
+	suite.arm.On("OwnerExists", mock.Anything, "facility", "3").Return(true, nil)
+	var actual entity.Attachment
+	suite.arm.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		actual = args.Get(1).(entity.Attachment)
+	}).Return(entity.Attachment{ID: "1"}, nil)
+
+	_, err := suite.auc.UploadAttachment(context.Background(), "facility", "3", "manual.pdf", strings.NewReader("%PDF-1.7\n%comment\n"))
+
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "application/pdf", actual.ContentType)
+	assert.True(suite.T(), strings.HasPrefix(actual.StorageKey, "facilities/3/"))
+	assert.Empty(suite.T(), actual.ThumbnailKey)
+}
+
+func (suite *AttachmentUseCaseTestSuite) TestUploadAttachment_ContentTypeFail() {
+	_, err := suite.auc.UploadAttachment(context.Background(), "room", "9", "photo.png", strings.NewReader("MZ not an image at all"))
+
+	assert.Equal(suite.T(), apperror.KindValidation, apperror.KindOf(err))
+	assert.Equal(suite.T(), "content_type", apperror.From(err).Fields[0].Code)
+	suite.arm.AssertNotCalled(suite.T(), "OwnerExists", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *AttachmentUseCaseTestSuite) TestUploadAttachment_TooLargeFail() {
+	store, err := storage.NewLocalStore(suite.dir)
+	require.NoError(suite.T(), err)
+	auc := NewAttachmentUseCase(suite.arm, store, 16)
+
+	_, err = auc.UploadAttachment(context.Background(), "room", "9", "manual.pdf", strings.NewReader("%PDF-1.7\n"+strings.Repeat("x", 32)))
+
+	assert.True(suite.T(), errors.Is(err, ErrAttachmentTooLarge))
+}
+
+func (suite *AttachmentUseCaseTestSuite) TestUploadAttachment_OwnerNotFoundFail() {
+	suite.arm.On("OwnerExists", mock.Anything, "room", "9").Return(false, nil)
+
+	_, err := suite.auc.UploadAttachment(context.Background(), "room", "9", "manual.pdf", strings.NewReader("%PDF-1.7\n"))
+
+	assert.Equal(suite.T(), "room_not_found", apperror.From(err).Code)
+}
+
+func (suite *AttachmentUseCaseTestSuite) TestUploadAttachment_RemovesBlobsFail() {
+	suite.arm.On("OwnerExists", mock.Anything, "room", "9").Return(true, nil)
+	suite.arm.On("Create", mock.Anything, mock.Anything).Return(entity.Attachment{}, errors.New("connection reset"))
+
+	_, err := suite.auc.UploadAttachment(context.Background(), "room", "9", "melati.png", bytes.NewReader(suite.pngImage()))
+
+	assert.Equal(suite.T(), apperror.KindInternal, apperror.KindOf(err))
+	entries, _ := os.ReadDir(filepath.Join(suite.dir, "rooms", "9"))
+	assert.Empty(suite.T(), entries)
+}
+
+func (suite *AttachmentUseCaseTestSuite) TestOpenAttachment_ThumbnailSuccess() {
+	store, _ := storage.NewLocalStore(suite.dir)
+	require.NoError(suite.T(), store.Put(context.Background(), "rooms/9/a_thumb.jpg", strings.NewReader("thumb"), 5, "image/jpeg"))
+	suite.arm.On("Get", mock.Anything, "1").Return(entity.Attachment{ID: "1", ContentType: "image/png", Size: 2048, StorageKey: "rooms/9/a.png", ThumbnailKey: "rooms/9/a_thumb.jpg"}, nil)
+
+	actual, body, err := suite.auc.OpenAttachment(context.Background(), "1", true)
+
+	require.NoError(suite.T(), err)
+	defer body.Close()
+	content, _ := io.ReadAll(body)
+	assert.Equal(suite.T(), "thumb", string(content))
+	assert.Equal(suite.T(), "image/jpeg", actual.ContentType)
+	assert.Equal(suite.T(), int64(-1), actual.Size)
+}
+
+func (suite *AttachmentUseCaseTestSuite) TestOpenAttachment_NoThumbnailFail() {
+	suite.arm.On("Get", mock.Anything, "1").Return(entity.Attachment{ID: "1", ContentType: "application/pdf", StorageKey: "rooms/9/a.pdf"}, nil)
+
+	_, _, err := suite.auc.OpenAttachment(context.Background(), "1", true)
+
+	assert.Equal(suite.T(), "thumbnail_not_found", apperror.From(err).Code)
+}
+
+func (suite *AttachmentUseCaseTestSuite) TestDeleteAttachment_Success() {
+	store, _ := storage.NewLocalStore(suite.dir)
+	require.NoError(suite.T(), store.Put(context.Background(), "rooms/9/a.pdf", strings.NewReader("%PDF-"), 5, "application/pdf"))
+	suite.arm.On("Delete", mock.Anything, "1").Return(entity.Attachment{ID: "1", StorageKey: "rooms/9/a.pdf"}, nil)
+
+	err := suite.auc.DeleteAttachment(context.Background(), "1")
+
+	assert.NoError(suite.T(), err)
+	assert.NoFileExists(suite.T(), filepath.Join(suite.dir, "rooms", "9", "a.pdf"))
+}
+
+func (suite *AttachmentUseCaseTestSuite) TestDeleteAttachment_Fail() {
+	suite.arm.On("Delete", mock.Anything, "1").Return(entity.Attachment{}, sql.ErrNoRows)
+
+	err := suite.auc.DeleteAttachment(context.Background(), "1")
+
+	assert.Equal(suite.T(), apperror.KindNotFound, apperror.KindOf(err))
+}
+
+func TestAttachmentUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(AttachmentUseCaseTestSuite))
+}
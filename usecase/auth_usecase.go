@@ -2,11 +2,14 @@ package usecase
 
 import (
 	"booking-room-app/entity/dto"
+	"booking-room-app/shared/apperror"
+	"booking-room-app/shared/metrics"
 	"booking-room-app/shared/service"
+	"context"
 )
 
 type AuthUseCase interface {
-	Login(payload dto.AuthRequestDto) (dto.AuthResponseDto, error)
+	Login(ctx context.Context, payload dto.AuthRequestDto) (dto.AuthResponseDto, error)
 }
 
 type authUseCase struct {
@@ -14,14 +17,18 @@ type authUseCase struct {
 	jwtService service.JwtService
 }
 
-func (a *authUseCase) Login(payload dto.AuthRequestDto) (dto.AuthResponseDto, error) {
-	user, err := a.userUC.FindEmployeForLogin(payload.User, payload.Password)
+func (a *authUseCase) Login(ctx context.Context, payload dto.AuthRequestDto) (dto.AuthResponseDto, error) {
+	ctx, span := startSpan(ctx, "authUseCase.Login")
+	defer span.End()
+
+	user, err := a.userUC.FindEmployeForLogin(ctx, payload.User, payload.Password)
 	if err != nil {
+		metrics.LoginFailuresTotal.Inc()
 		return dto.AuthResponseDto{}, err
 	}
 	token, err := a.jwtService.CreateToken(user)
 	if err != nil {
-		return dto.AuthResponseDto{}, err
+		return dto.AuthResponseDto{}, apperror.Internal(err)
 	}
 
 	return token, nil
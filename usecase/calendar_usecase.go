@@ -0,0 +1,337 @@
+package usecase
+
+import (
+	"booking-room-app/entity"
+	"booking-room-app/repository"
+	"booking-room-app/shared/apperror"
+	"booking-room-app/shared/ical"
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type CalendarUseCase interface {
+	FindHours(ctx context.Context, siteId, roomId string) (entity.WeeklyHours, error)
+	ReplaceHours(ctx context.Context, payload entity.WeeklyHours) (entity.WeeklyHours, error)
+	RegisterHoliday(ctx context.Context, payload entity.Holiday) (entity.Holiday, error)
+	ImportHolidays(ctx context.Context, siteId string, calendar io.Reader) (int64, error)
+	FindHolidays(ctx context.Context, siteId string, year int) ([]entity.Holiday, error)
+	DeleteHoliday(ctx context.Context, id string) error
+	RegisterBlackout(ctx context.Context, payload entity.Blackout) (entity.Blackout, error)
+	FindBlackouts(ctx context.Context, from, to time.Time) ([]entity.Blackout, error)
+	DeleteBlackout(ctx context.Context, id string) error
+	CheckBookable(ctx context.Context, roomId string, start, end time.Time) error
+}
+
+type calendarUseCase struct {
+	repo repository.CalendarRepository
+}
+
+// FindHours implements CalendarUseCase.
+func (c *calendarUseCase) FindHours(ctx context.Context, siteId, roomId string) (entity.WeeklyHours, error) {
+	ctx, span := startSpan(ctx, "calendarUseCase.FindHours")
+	defer span.End()
+
+	if err := hoursScope(siteId, roomId); err != nil {
+		return entity.WeeklyHours{}, err
+	}
+
+	hours, err := c.repo.ListHours(ctx, siteId, roomId)
+	if err != nil {
+		return entity.WeeklyHours{}, dbError(err, "business hours")
+	}
+	return entity.WeeklyHours{SiteId: siteId, RoomId: roomId, Hours: hours}, nil
+}
+
+// ReplaceHours implements CalendarUseCase. An empty week removes the hours,
+// so a room falls back to its site and a site is open around the clock.
+func (c *calendarUseCase) ReplaceHours(ctx context.Context, payload entity.WeeklyHours) (entity.WeeklyHours, error) {
+	ctx, span := startSpan(ctx, "calendarUseCase.ReplaceHours")
+	defer span.End()
+
+	if err := hoursScope(payload.SiteId, payload.RoomId); err != nil {
+		return entity.WeeklyHours{}, err
+	}
+	if err := validateHours(payload.Hours); err != nil {
+		return entity.WeeklyHours{}, err
+	}
+
+	hours, err := c.repo.ReplaceHours(ctx, payload)
+	if err != nil {
+		return entity.WeeklyHours{}, dbError(err, "business hours")
+	}
+	return hours, nil
+}
+
+// RegisterHoliday implements CalendarUseCase.
+func (c *calendarUseCase) RegisterHoliday(ctx context.Context, payload entity.Holiday) (entity.Holiday, error) {
+	ctx, span := startSpan(ctx, "calendarUseCase.RegisterHoliday")
+	defer span.End()
+
+	var problems []apperror.FieldError
+	for _, field := range missingFields("date", payload.Date, "name", payload.Name) {
+		problems = append(problems, apperror.RequiredField(field))
+	}
+	if _, err := time.Parse(time.DateOnly, payload.Date); payload.Date != "" && err != nil {
+		problems = append(problems, apperror.Field("date", "date", "date must be formatted as 2006-01-02"))
+	}
+	if err := invalid(problems); err != nil {
+		return entity.Holiday{}, err
+	}
+
+	holiday, err := c.repo.CreateHoliday(ctx, payload)
+	if err != nil {
+		return entity.Holiday{}, dbError(err, "holiday")
+	}
+	return holiday, nil
+}
+
+// ImportHolidays implements CalendarUseCase. Every day of every event in the
+// iCalendar becomes a holiday of the site, or of every site when siteId is
+// empty. It returns how many days were new.
+func (c *calendarUseCase) ImportHolidays(ctx context.Context, siteId string, calendar io.Reader) (int64, error) {
+	ctx, span := startSpan(ctx, "calendarUseCase.ImportHolidays")
+	defer span.End()
+
+	events, err := ical.Parse(calendar)
+	if err != nil {
+		return 0, apperror.Validation("the calendar is invalid", apperror.Field("calendar", "ical", err.Error()))
+	}
+
+	var holidays []entity.Holiday
+	for _, event := range events {
+		name := strings.TrimSpace(event.Summary)
+		if name == "" {
+			name = "Holiday"
+		}
+		for _, day := range event.Days() {
+			holidays = append(holidays, entity.Holiday{SiteId: siteId, Date: day.Format(time.DateOnly), Name: name})
+		}
+	}
+
+	imported, err := c.repo.ImportHolidays(ctx, holidays)
+	if err != nil {
+		return 0, dbError(err, "holiday")
+	}
+	return imported, nil
+}
+
+// FindHolidays implements CalendarUseCase.
+func (c *calendarUseCase) FindHolidays(ctx context.Context, siteId string, year int) ([]entity.Holiday, error) {
+	ctx, span := startSpan(ctx, "calendarUseCase.FindHolidays")
+	defer span.End()
+
+	holidays, err := c.repo.ListHolidays(ctx, siteId, year)
+	if err != nil {
+		return nil, dbError(err, "holiday")
+	}
+	return holidays, nil
+}
+
+// DeleteHoliday implements CalendarUseCase.
+func (c *calendarUseCase) DeleteHoliday(ctx context.Context, id string) error {
+	ctx, span := startSpan(ctx, "calendarUseCase.DeleteHoliday")
+	defer span.End()
+
+	if err := c.repo.DeleteHoliday(ctx, id); err != nil {
+		return dbError(err, "holiday")
+	}
+	return nil
+}
+
+// RegisterBlackout implements CalendarUseCase.
+func (c *calendarUseCase) RegisterBlackout(ctx context.Context, payload entity.Blackout) (entity.Blackout, error) {
+	ctx, span := startSpan(ctx, "calendarUseCase.RegisterBlackout")
+	defer span.End()
+
+	var problems []apperror.FieldError
+	for _, field := range missingFields("reason", payload.Reason) {
+		problems = append(problems, apperror.RequiredField(field))
+	}
+	if payload.SiteId != "" && payload.RoomId != "" {
+		problems = append(problems, apperror.Field("roomId", "excluded_with", "roomId must be empty when siteId is set"))
+	}
+	if !payload.EndTime.After(payload.StartTime) {
+		problems = append(problems, apperror.Field("endTime", "gtfield", "endTime must be after startTime"))
+	}
+	if err := invalid(problems); err != nil {
+		return entity.Blackout{}, err
+	}
+
+	blackout, err := c.repo.CreateBlackout(ctx, payload)
+	if err != nil {
+		return entity.Blackout{}, dbError(err, "blackout")
+	}
+	return blackout, nil
+}
+
+// FindBlackouts implements CalendarUseCase. Without an end it looks a year
+// ahead of from.
+func (c *calendarUseCase) FindBlackouts(ctx context.Context, from, to time.Time) ([]entity.Blackout, error) {
+	ctx, span := startSpan(ctx, "calendarUseCase.FindBlackouts")
+	defer span.End()
+
+	if to.IsZero() {
+		to = from.AddDate(1, 0, 0)
+	}
+	if !to.After(from) {
+		return nil, apperror.Validation("the period is invalid", apperror.Field("to", "gtfield", "to must be after from"))
+	}
+
+	blackouts, err := c.repo.ListBlackouts(ctx, from, to)
+	if err != nil {
+		return nil, dbError(err, "blackout")
+	}
+	return blackouts, nil
+}
+
+// DeleteBlackout implements CalendarUseCase.
+func (c *calendarUseCase) DeleteBlackout(ctx context.Context, id string) error {
+	ctx, span := startSpan(ctx, "calendarUseCase.DeleteBlackout")
+	defer span.End()
+
+	if err := c.repo.DeleteBlackout(ctx, id); err != nil {
+		return dbError(err, "blackout")
+	}
+	return nil
+}
+
+// CheckBookable implements CalendarUseCase. It returns a conflict when a
+// maintenance window, blackout or holiday closes the room during the period,
+// or when the period does not fit in one opening interval of the room.
+func (c *calendarUseCase) CheckBookable(ctx context.Context, roomId string, start, end time.Time) error {
+	ctx, span := startSpan(ctx, "calendarUseCase.CheckBookable")
+	defer span.End()
+
+	calendar, err := c.repo.GetRoomCalendar(ctx, roomId, start, end)
+	if err != nil {
+		return dbError(err, "room")
+	}
+	return checkCalendar(calendar, start, end)
+}
+
+func checkCalendar(calendar entity.RoomCalendar, start, end time.Time) error {
+	for _, window := range calendar.Maintenance {
+		if window.StartTime.Before(end) && window.EndTime.After(start) {
+			return apperror.Conflict("room_maintenance", "the room is under maintenance for "+window.Reason)
+		}
+	}
+	for _, blackout := range calendar.Blackouts {
+		if blackout.StartTime.Before(end) && blackout.EndTime.After(start) {
+			return apperror.Conflict("blackout", "the room is closed for "+blackout.Reason)
+		}
+	}
+
+	location, err := time.LoadLocation(calendar.Timezone)
+	if err != nil {
+		location = time.UTC
+	}
+	start, end = start.In(location), end.In(location)
+
+	holidays := make(map[string]string, len(calendar.Holidays))
+	for _, holiday := range calendar.Holidays {
+		holidays[holiday.Date] = holiday.Name
+	}
+	for day := midnight(start); day.Before(end); day = day.AddDate(0, 0, 1) {
+		if name, ok := holidays[day.Format(time.DateOnly)]; ok {
+			return apperror.Conflict("holiday", fmt.Sprintf("the room is closed on %s for %s", day.Format(time.DateOnly), name))
+		}
+	}
+
+	if len(calendar.Hours) > 0 && !withinHours(calendar.Hours, start, end) {
+		return ErrOutsideHours
+	}
+	return nil
+}
+
+// withinHours reports whether the local period start, end fits in one
+// opening interval of the day it starts on.
+func withinHours(hours []entity.BusinessHours, start, end time.Time) bool {
+	from := start.Hour()*60 + start.Minute()
+	to := end.Hour()*60 + end.Minute()
+	switch day := midnight(start); {
+	case midnight(end).Equal(day):
+	case midnight(end).Equal(day.AddDate(0, 0, 1)) && to == 0:
+		to = 24 * 60
+	default:
+		return false
+	}
+
+	for _, interval := range hours {
+		open, _ := clockMinutes(interval.Open)
+		closing, _ := clockMinutes(interval.Close)
+		if interval.Weekday == start.Weekday() && open <= from && to <= closing {
+			return true
+		}
+	}
+	return false
+}
+
+func midnight(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// clockMinutes parses HH:MM, up to 24:00, into minutes after midnight.
+func clockMinutes(clock string) (int, bool) {
+	hour, minute, ok := strings.Cut(clock, ":")
+	if !ok || len(hour) != 2 || len(minute) != 2 {
+		return 0, false
+	}
+	h, err := strconv.Atoi(hour)
+	if err != nil {
+		return 0, false
+	}
+	m, err := strconv.Atoi(minute)
+	if err != nil || h < 0 || m < 0 || m > 59 || h*60+m > 24*60 {
+		return 0, false
+	}
+	return h*60 + m, true
+}
+
+func hoursScope(siteId, roomId string) error {
+	if (siteId == "") == (roomId == "") {
+		return apperror.Validation("set either siteId or roomId", apperror.Field("siteId", "required_without", "set either siteId or roomId"))
+	}
+	return nil
+}
+
+func validateHours(hours []entity.BusinessHours) error {
+	var problems []apperror.FieldError
+	for i, interval := range hours {
+		field := fmt.Sprintf("hours[%d]", i)
+		if interval.Weekday < time.Sunday || interval.Weekday > time.Saturday {
+			problems = append(problems, apperror.Field(field+".weekday", "weekday", field+".weekday must be from 0 (Sunday) to 6 (Saturday)"))
+		}
+		open, okOpen := clockMinutes(interval.Open)
+		closing, okClose := clockMinutes(interval.Close)
+		if !okOpen {
+			problems = append(problems, apperror.Field(field+".open", "clock", field+".open must be a time such as 08:00"))
+		}
+		if !okClose {
+			problems = append(problems, apperror.Field(field+".close", "clock", field+".close must be a time such as 17:00"))
+		}
+		if !okOpen || !okClose {
+			continue
+		}
+		if closing <= open {
+			problems = append(problems, apperror.Field(field+".close", "gtfield", field+".close must be after "+field+".open"))
+			continue
+		}
+		for j, other := range hours[:i] {
+			otherOpen, _ := clockMinutes(other.Open)
+			otherClose, _ := clockMinutes(other.Close)
+			if other.Weekday == interval.Weekday && open < otherClose && otherOpen < closing {
+				problems = append(problems, apperror.Field(field, "overlap", fmt.Sprintf("%s overlaps hours[%d]", field, j)))
+				break
+			}
+		}
+	}
+	return invalid(problems)
+}
+
+func NewCalendarUseCase(repo repository.CalendarRepository) CalendarUseCase {
+	return &calendarUseCase{repo: repo}
+}
@@ -0,0 +1,211 @@
+package usecase
+
+import (
+	"booking-room-app/entity"
+	"booking-room-app/mock/repo_mock"
+	"booking-room-app/repository"
+	"booking-room-app/shared/apperror"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+// officeHours is open 08:00-17:00 in Asia/Jakarta (UTC+7) from Monday to
+// Friday.
+var officeHours = func() []entity.BusinessHours {
+	var hours []entity.BusinessHours
+	for day := time.Monday; day <= time.Friday; day++ {
+		hours = append(hours, entity.BusinessHours{Weekday: day, Open: "08:00", Close: "17:00"})
+	}
+	return hours
+}()
+
+var jakarta, _ = time.LoadLocation("Asia/Jakarta")
+
+type CalendarUseCaseTestSuite struct {
+	suite.Suite
+	crm *repo_mock.CalendarRepoMock
+	cuc CalendarUseCase
+}
+
+func (suite *CalendarUseCaseTestSuite) SetupTest() {
+	suite.crm = new(repo_mock.CalendarRepoMock)
+	suite.cuc = NewCalendarUseCase(suite.crm)
+}
+
+func (suite *CalendarUseCaseTestSuite) checkBookable(calendar entity.RoomCalendar, start, end time.Time) error {
+	calendar.RoomId = "9"
+	suite.crm.On("GetRoomCalendar", mock.Anything, "9", start, end).Return(calendar, nil)
+	return suite.cuc.CheckBookable(context.Background(), "9", start, end)
+}
+
+func (suite *CalendarUseCaseTestSuite) TestCheckBookable_WithinHoursSuccess() {
+	// Monday 09:00-11:00 in Jakarta, sent in UTC
+	start := time.Date(2024, time.January, 8, 2, 0, 0, 0, time.UTC)
+
+	err := suite.checkBookable(entity.RoomCalendar{Timezone: "Asia/Jakarta", Hours: officeHours}, start, start.Add(2*time.Hour))
+
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *CalendarUseCaseTestSuite) TestCheckBookable_OutsideHoursFail() {
+	// Monday 16:00-18:00 in Jakarta
+	start := time.Date(2024, time.January, 8, 16, 0, 0, 0, jakarta)
+
+	err := suite.checkBookable(entity.RoomCalendar{Timezone: "Asia/Jakarta", Hours: officeHours}, start, start.Add(2*time.Hour))
+
+	assert.ErrorIs(suite.T(), err, ErrOutsideHours)
+}
+
+func (suite *CalendarUseCaseTestSuite) TestCheckBookable_WeekendFail() {
+	start := time.Date(2024, time.January, 6, 9, 0, 0, 0, jakarta)
+
+	err := suite.checkBookable(entity.RoomCalendar{Timezone: "Asia/Jakarta", Hours: officeHours}, start, start.Add(time.Hour))
+
+	assert.ErrorIs(suite.T(), err, ErrOutsideHours)
+}
+
+func (suite *CalendarUseCaseTestSuite) TestCheckBookable_UntilMidnightSuccess() {
+	hours := []entity.BusinessHours{{Weekday: time.Monday, Open: "18:00", Close: "24:00"}}
+	start := time.Date(2024, time.January, 8, 22, 0, 0, 0, time.UTC)
+
+	err := suite.checkBookable(entity.RoomCalendar{Timezone: "UTC", Hours: hours}, start, start.Add(2*time.Hour))
+
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *CalendarUseCaseTestSuite) TestCheckBookable_WithoutHoursSuccess() {
+	start := time.Date(2024, time.January, 6, 23, 0, 0, 0, time.UTC)
+
+	err := suite.checkBookable(entity.RoomCalendar{Timezone: "UTC"}, start, start.Add(3*time.Hour))
+
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *CalendarUseCaseTestSuite) TestCheckBookable_HolidayFail() {
+	// 1 January 02:00 in Jakarta is still 31 December in UTC
+	start := time.Date(2023, time.December, 31, 19, 0, 0, 0, time.UTC)
+	calendar := entity.RoomCalendar{Timezone: "Asia/Jakarta", Holidays: []entity.Holiday{{Date: "2024-01-01", Name: "New Year"}}}
+
+	err := suite.checkBookable(calendar, start, start.Add(time.Hour))
+
+	appErr := apperror.From(err)
+	assert.Equal(suite.T(), apperror.KindConflict, appErr.Kind)
+	assert.Equal(suite.T(), "holiday", appErr.Code)
+	assert.Equal(suite.T(), "the room is closed on 2024-01-01 for New Year", appErr.Message)
+}
+
+func (suite *CalendarUseCaseTestSuite) TestCheckBookable_BlackoutFail() {
+	start := time.Date(2024, time.January, 8, 9, 0, 0, 0, jakarta)
+	calendar := entity.RoomCalendar{Timezone: "Asia/Jakarta", Hours: officeHours, Blackouts: []entity.Blackout{
+		{Reason: "Town hall", StartTime: start.Add(30 * time.Minute), EndTime: start.Add(3 * time.Hour)},
+	}}
+
+	err := suite.checkBookable(calendar, start, start.Add(time.Hour))
+
+	assert.Equal(suite.T(), "blackout", apperror.From(err).Code)
+}
+
+func (suite *CalendarUseCaseTestSuite) TestCheckBookable_MaintenanceFail() {
+	start := time.Date(2024, time.January, 8, 9, 0, 0, 0, jakarta)
+	calendar := entity.RoomCalendar{Timezone: "Asia/Jakarta", Hours: officeHours, Maintenance: []entity.Maintenance{
+		{Reason: "Replace projector", StartTime: start.Add(-time.Hour), EndTime: start.Add(30 * time.Minute)},
+	}}
+
+	err := suite.checkBookable(calendar, start, start.Add(time.Hour))
+
+	assert.Equal(suite.T(), "room_maintenance", apperror.From(err).Code)
+	assert.Equal(suite.T(), apperror.KindConflict, apperror.KindOf(err))
+}
+
+func (suite *CalendarUseCaseTestSuite) TestCheckBookable_AfterMaintenanceSuccess() {
+	start := time.Date(2024, time.January, 8, 9, 0, 0, 0, jakarta)
+	calendar := entity.RoomCalendar{Timezone: "Asia/Jakarta", Hours: officeHours, Maintenance: []entity.Maintenance{
+		{Reason: "Replace projector", StartTime: start.Add(-time.Hour), EndTime: start},
+	}}
+
+	err := suite.checkBookable(calendar, start, start.Add(time.Hour))
+
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *CalendarUseCaseTestSuite) TestCheckBookable_UnknownRoomFail() {
+	start := time.Now()
+	suite.crm.On("GetRoomCalendar", mock.Anything, "9", start, start).Return(entity.RoomCalendar{}, repository.ErrRoomNotFound)
+
+	err := suite.cuc.CheckBookable(context.Background(), "9", start, start)
+
+	assert.Equal(suite.T(), apperror.KindValidation, apperror.KindOf(err))
+}
+
+func (suite *CalendarUseCaseTestSuite) TestReplaceHours_Success() {
+	week := entity.WeeklyHours{SiteId: "1", Hours: officeHours}
+	suite.crm.On("ReplaceHours", mock.Anything, week).Return(week, nil)
+
+	actual, err := suite.cuc.ReplaceHours(context.Background(), week)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), week, actual)
+}
+
+func (suite *CalendarUseCaseTestSuite) TestReplaceHours_InvalidFail() {
+	week := entity.WeeklyHours{RoomId: "9", Hours: []entity.BusinessHours{
+		{Weekday: time.Monday, Open: "08:00", Close: "12:00"},
+		{Weekday: time.Monday, Open: "11:00", Close: "13:00"},
+		{Weekday: time.Tuesday, Open: "17:00", Close: "08:00"},
+		{Weekday: 7, Open: "8", Close: "17:00"},
+	}}
+
+	_, err := suite.cuc.ReplaceHours(context.Background(), week)
+
+	assert.Equal(suite.T(), []apperror.FieldError{
+		{Field: "hours[1]", Code: "overlap", Message: "hours[1] overlaps hours[0]"},
+		{Field: "hours[2].close", Code: "gtfield", Message: "hours[2].close must be after hours[2].open"},
+		{Field: "hours[3].weekday", Code: "weekday", Message: "hours[3].weekday must be from 0 (Sunday) to 6 (Saturday)"},
+		{Field: "hours[3].open", Code: "clock", Message: "hours[3].open must be a time such as 08:00"},
+	}, apperror.From(err).Fields)
+	suite.crm.AssertNotCalled(suite.T(), "ReplaceHours", mock.Anything, mock.Anything)
+}
+
+func (suite *CalendarUseCaseTestSuite) TestReplaceHours_ScopeFail() {
+	_, err := suite.cuc.ReplaceHours(context.Background(), entity.WeeklyHours{SiteId: "1", RoomId: "9"})
+
+	assert.Equal(suite.T(), apperror.KindValidation, apperror.KindOf(err))
+}
+
+func (suite *CalendarUseCaseTestSuite) TestImportHolidays_Success() {
+	calendar := "BEGIN:VCALENDAR\nBEGIN:VEVENT\nDTSTART;VALUE=DATE:20240410\nDTEND;VALUE=DATE:20240412\nSUMMARY:Idul Fitri\nEND:VEVENT\nEND:VCALENDAR\n"
+	suite.crm.On("ImportHolidays", mock.Anything, []entity.Holiday{
+		{SiteId: "1", Date: "2024-04-10", Name: "Idul Fitri"},
+		{SiteId: "1", Date: "2024-04-11", Name: "Idul Fitri"},
+	}).Return(int64(2), nil)
+
+	imported, err := suite.cuc.ImportHolidays(context.Background(), "1", strings.NewReader(calendar))
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(2), imported)
+}
+
+func (suite *CalendarUseCaseTestSuite) TestImportHolidays_InvalidCalendarFail() {
+	_, err := suite.cuc.ImportHolidays(context.Background(), "", strings.NewReader("New Year,2024-01-01"))
+
+	appErr := apperror.From(err)
+	assert.Equal(suite.T(), apperror.KindValidation, appErr.Kind)
+	assert.Equal(suite.T(), "calendar", appErr.Fields[0].Field)
+}
+
+func (suite *CalendarUseCaseTestSuite) TestRegisterBlackout_SiteAndRoomFail() {
+	start := time.Now()
+	_, err := suite.cuc.RegisterBlackout(context.Background(), entity.Blackout{SiteId: "1", RoomId: "9", Reason: "Town hall", StartTime: start, EndTime: start.Add(time.Hour)})
+
+	assert.Equal(suite.T(), []apperror.FieldError{{Field: "roomId", Code: "excluded_with", Message: "roomId must be empty when siteId is set"}}, apperror.From(err).Fields)
+}
+
+func TestCalendarUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(CalendarUseCaseTestSuite))
+}
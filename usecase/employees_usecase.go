@@ -3,86 +3,182 @@ package usecase
 import (
 	"booking-room-app/entity"
 	"booking-room-app/repository"
+	"booking-room-app/shared/apperror"
 	"booking-room-app/shared/model"
+	"context"
+	"database/sql"
 	"errors"
-	"fmt"
 )
 
 type EmployeesUseCase interface {
-	FindEmployeesByID(id string) (entity.Employee, error)
-	FindEmployeesByUsername(username string) (entity.Employee, error)
-	FindEmployeForLogin(username, password string) (entity.Employee, error)
-	RegisterNewEmployee(payload entity.Employee) (entity.Employee, error)
-	UpdateEmployee(payload entity.Employee) (entity.Employee, error)
-	ListAll(page, size int) ([]entity.Employee, model.Paging, error)
+	FindEmployeesByID(ctx context.Context, id string) (entity.Employee, error)
+	FindEmployeesByUsername(ctx context.Context, username string) (entity.Employee, error)
+	FindEmployeForLogin(ctx context.Context, username, password string) (entity.Employee, error)
+	RegisterNewEmployee(ctx context.Context, payload entity.Employee) (entity.Employee, error)
+	UpdateEmployee(ctx context.Context, payload entity.Employee) (entity.Employee, error)
+	ListAll(ctx context.Context, page, size int) ([]entity.Employee, model.Paging, error)
+	ResetPassword(ctx context.Context, username, password string) (entity.Employee, error)
+	ArchiveEmployee(ctx context.Context, id string) (entity.Archival, error)
+	RestoreEmployee(ctx context.Context, id string) (entity.Employee, error)
+	FindArchivedEmployees(ctx context.Context, page, size int) ([]entity.Employee, model.Paging, error)
 }
 
 type employeesUseCase struct {
-	repo repository.EmployeeRepository
+	repo          repository.EmployeeRepository
+	archivePolicy string
 }
 
 // FindEmployeesByUsername implements EmployeesUseCase.
-func (e *employeesUseCase) FindEmployeesByUsername(username string) (entity.Employee, error) {
+func (e *employeesUseCase) FindEmployeesByUsername(ctx context.Context, username string) (entity.Employee, error) {
+	ctx, span := startSpan(ctx, "employeesUseCase.FindEmployeesByUsername")
+	defer span.End()
+
 	if username == "" {
-		return entity.Employee{}, errors.New("username harus diisi")
+		return entity.Employee{}, apperror.Required("username")
+	}
+	employee, err := e.repo.GetEmployeesByUsername(ctx, username)
+	if err != nil {
+		return entity.Employee{}, dbError(err, "employee")
 	}
-	return e.repo.GetEmployeesByUsername(username)
+	return employee, nil
 }
 
-func (e *employeesUseCase) FindEmployeForLogin(username, password string) (entity.Employee, error) {
-	if username == "" && password == "" {
-		return entity.Employee{}, errors.New("username dan password harus diisi")
-	}
-	if username == "" {
-		return entity.Employee{}, errors.New("username harus diisi")
-	}
-	if password == "" {
-		return entity.Employee{}, errors.New("password harus diisi")
+func (e *employeesUseCase) FindEmployeForLogin(ctx context.Context, username, password string) (entity.Employee, error) {
+	ctx, span := startSpan(ctx, "employeesUseCase.FindEmployeForLogin")
+	defer span.End()
+
+	if missing := missingFields("username", username, "password", password); len(missing) > 0 {
+		return entity.Employee{}, apperror.Required(missing...)
 	}
 
-	employee, err := e.repo.GetEmployeesByUsernameForLogin(username, password)
+	employee, err := e.repo.GetEmployeesByUsernameForLogin(ctx, username, password)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.Employee{}, ErrInvalidCredentials.Wrap(err)
+	}
 	if err != nil {
-		return entity.Employee{}, errors.New("username atau password salah")
+		return entity.Employee{}, dbError(err, "employee")
 	}
 	return employee, nil
 }
 
 // ListAll implements EmployeesUseCase.
-func (e *employeesUseCase) ListAll(page int, size int) ([]entity.Employee, model.Paging, error) {
-	return e.repo.List(page, size)
+func (e *employeesUseCase) ListAll(ctx context.Context, page int, size int) ([]entity.Employee, model.Paging, error) {
+	ctx, span := startSpan(ctx, "employeesUseCase.ListAll")
+	defer span.End()
+
+	employees, paging, err := e.repo.List(ctx, page, size)
+	if err != nil {
+		return nil, model.Paging{}, dbError(err, "employee")
+	}
+	return employees, paging, nil
 }
 
 // FindEmployeesByID implements EmployeesUseCase.
-func (e *employeesUseCase) FindEmployeesByID(id string) (entity.Employee, error) {
-	return e.repo.GetEmployeesByID(id)
+func (e *employeesUseCase) FindEmployeesByID(ctx context.Context, id string) (entity.Employee, error) {
+	ctx, span := startSpan(ctx, "employeesUseCase.FindEmployeesByID")
+	defer span.End()
+
+	if id == "" {
+		return entity.Employee{}, apperror.Required("id")
+	}
+	employee, err := e.repo.GetEmployeesByID(ctx, id)
+	if err != nil {
+		return entity.Employee{}, dbError(err, "employee")
+	}
+	return employee, nil
 }
 
 // RegisterNewEmployee implements EmployeesUseCase.
-func (e *employeesUseCase) RegisterNewEmployee(payload entity.Employee) (entity.Employee, error) {
-	if payload.Name == "" || payload.Password == "" || payload.Role == "" || payload.Division == "" || payload.Position == "" || payload.Contact == "" {
-		return entity.Employee{}, errors.New("oops, field required")
+func (e *employeesUseCase) RegisterNewEmployee(ctx context.Context, payload entity.Employee) (entity.Employee, error) {
+	ctx, span := startSpan(ctx, "employeesUseCase.RegisterNewEmployee")
+	defer span.End()
+
+	if missing := missingFields("name", payload.Name, "password", payload.Password, "role", payload.Role, "division", payload.Division, "position", payload.Position, "contact", payload.Contact); len(missing) > 0 {
+		return entity.Employee{}, apperror.Required(missing...)
 	}
 
-	employee, err := e.repo.CreateEmployee(payload)
+	employee, err := e.repo.CreateEmployee(ctx, payload)
 	if err != nil {
-		return entity.Employee{}, fmt.Errorf("oppps, failed to save data employee :%v", err.Error())
+		return entity.Employee{}, dbError(err, "employee")
 	}
 	return employee, nil
 }
 
 // UpdateEmployee implements EmployeesUseCase.
-func (e *employeesUseCase) UpdateEmployee(payload entity.Employee) (entity.Employee, error) {
-	if payload.ID == "" || payload.Name == "" || payload.Password == "" || payload.Role == "" || payload.Division == "" || payload.Position == "" || payload.Contact == "" {
-		return entity.Employee{}, errors.New("oops, field required")
+func (e *employeesUseCase) UpdateEmployee(ctx context.Context, payload entity.Employee) (entity.Employee, error) {
+	ctx, span := startSpan(ctx, "employeesUseCase.UpdateEmployee")
+	defer span.End()
+
+	if missing := missingFields("id", payload.ID, "name", payload.Name, "password", payload.Password, "role", payload.Role, "division", payload.Division, "position", payload.Position, "contact", payload.Contact); len(missing) > 0 {
+		return entity.Employee{}, apperror.Required(missing...)
+	}
+
+	employee, err := e.repo.UpdateEmployee(ctx, payload)
+	if err != nil {
+		return entity.Employee{}, dbError(err, "employee")
+	}
+	return employee, nil
+}
+
+// ResetPassword sets a new password for the employee with the given username.
+func (e *employeesUseCase) ResetPassword(ctx context.Context, username, password string) (entity.Employee, error) {
+	ctx, span := startSpan(ctx, "employeesUseCase.ResetPassword")
+	defer span.End()
+
+	if missing := missingFields("username", username, "password", password); len(missing) > 0 {
+		return entity.Employee{}, apperror.Required(missing...)
+	}
+
+	employee, err := e.repo.GetEmployeesByUsername(ctx, username)
+	if err != nil {
+		return entity.Employee{}, dbError(err, "employee")
 	}
+	employee.Password = password
 
-	employee, err := e.repo.UpdateEmployee(payload)
+	employee, err = e.repo.UpdateEmployee(ctx, employee)
 	if err != nil {
-		return entity.Employee{}, fmt.Errorf("oppps, failed to save data employee :%v", err.Error())
+		return entity.Employee{}, dbError(err, "employee")
 	}
 	return employee, nil
 }
 
-func NewEmployeeUseCase(repo repository.EmployeeRepository) EmployeesUseCase {
-	return &employeesUseCase{repo: repo}
+// ArchiveEmployee implements EmployeesUseCase.
+func (e *employeesUseCase) ArchiveEmployee(ctx context.Context, id string) (entity.Archival, error) {
+	ctx, span := startSpan(ctx, "employeesUseCase.ArchiveEmployee")
+	defer span.End()
+
+	archival, err := e.repo.Archive(ctx, id, cascadeArchive(e.archivePolicy))
+	if err != nil {
+		return entity.Archival{}, dbError(err, "employee")
+	}
+	declinedByArchive(archival)
+	return archival, nil
+}
+
+// RestoreEmployee implements EmployeesUseCase.
+func (e *employeesUseCase) RestoreEmployee(ctx context.Context, id string) (entity.Employee, error) {
+	ctx, span := startSpan(ctx, "employeesUseCase.RestoreEmployee")
+	defer span.End()
+
+	employee, err := e.repo.Restore(ctx, id)
+	if err != nil {
+		return entity.Employee{}, dbError(err, "employee")
+	}
+	return employee, nil
+}
+
+// FindArchivedEmployees implements EmployeesUseCase.
+func (e *employeesUseCase) FindArchivedEmployees(ctx context.Context, page, size int) ([]entity.Employee, model.Paging, error) {
+	ctx, span := startSpan(ctx, "employeesUseCase.FindArchivedEmployees")
+	defer span.End()
+
+	employees, paging, err := e.repo.ListArchived(ctx, page, size)
+	if err != nil {
+		return nil, model.Paging{}, dbError(err, "employee")
+	}
+	return employees, paging, nil
+}
+
+func NewEmployeeUseCase(repo repository.EmployeeRepository, archivePolicy string) EmployeesUseCase {
+	return &employeesUseCase{repo: repo, archivePolicy: archivePolicy}
 }
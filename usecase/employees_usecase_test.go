@@ -3,13 +3,16 @@ package usecase
 import (
 	"booking-room-app/entity"
 	"booking-room-app/mock/repo_mock"
+	"booking-room-app/shared/apperror"
 	"booking-room-app/shared/model"
-	"errors"
+	"context"
+	"database/sql"
 	"fmt"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -34,45 +37,45 @@ type EmployeeUseCaseTestSuite struct {
 
 func (suite *EmployeeUseCaseTestSuite) SetupTest(){
 	suite.erm = new(repo_mock.EmployeeRepoMock)
-	suite.euc = NewEmployeeUseCase(suite.erm)
+	suite.euc = NewEmployeeUseCase(suite.erm, ArchiveRefuse)
 }
 
 func (suite *EmployeeUseCaseTestSuite) TestListAll_success(){
-	suite.erm.On("List", 1, 5).Return([]entity.Employee{}, model.Paging{}, nil)
-	actualEmployee, actualPaging, err := suite.euc.ListAll(1, 5)
+	suite.erm.On("List", mock.Anything, 1, 5).Return([]entity.Employee{}, model.Paging{}, nil)
+	actualEmployee, actualPaging, err := suite.euc.ListAll(context.Background(), 1, 5)
 	assert.Nil(suite.T(), err)
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), model.Paging{}, actualPaging)
 	assert.Equal(suite.T(), []entity.Employee{}, actualEmployee)
 }
 func (suite *EmployeeUseCaseTestSuite) TestUpdateEmployee_success() {
-	suite.erm.On("UpdateEmployee", expectEmployee).Return(expectEmployee, nil)
+	suite.erm.On("UpdateEmployee", mock.Anything, expectEmployee).Return(expectEmployee, nil)
 
-	actual, err := suite.euc.UpdateEmployee(expectEmployee)
+	actual, err := suite.euc.UpdateEmployee(context.Background(), expectEmployee)
 	assert.Nil(suite.T(), err)
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), expectEmployee, actual)
 }
 func (suite *EmployeeUseCaseTestSuite) TestCreateEmployee_success() {
-	suite.erm.On("CreateEmployee", expectEmployee).Return(expectEmployee, nil)
+	suite.erm.On("CreateEmployee", mock.Anything, expectEmployee).Return(expectEmployee, nil)
 
-	actual, err := suite.euc.RegisterNewEmployee(expectEmployee)
+	actual, err := suite.euc.RegisterNewEmployee(context.Background(), expectEmployee)
 	assert.Nil(suite.T(), err)
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), expectEmployee, actual)
 }
 func (suite *EmployeeUseCaseTestSuite) TestCreateEmployee_Fail() {
-	suite.erm.On("CreateEmployee", expectEmployee).Return(entity.Employee{}, fmt.Errorf("error"))
+	suite.erm.On("CreateEmployee", mock.Anything, expectEmployee).Return(entity.Employee{}, fmt.Errorf("error"))
 
-	_, err := suite.euc.RegisterNewEmployee(expectEmployee)
+	_, err := suite.euc.RegisterNewEmployee(context.Background(), expectEmployee)
 	assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
 
 }
 func (suite *EmployeeUseCaseTestSuite) TestUpdateEmployee_Fail(){
-	suite.erm.On("UpdateEmployee", expectEmployee).Return(entity.Employee{}, fmt.Errorf("error"))
+	suite.erm.On("UpdateEmployee", mock.Anything, expectEmployee).Return(entity.Employee{}, fmt.Errorf("error"))
 
-	_, err := suite.euc.UpdateEmployee(expectEmployee)
+	_, err := suite.euc.UpdateEmployee(context.Background(), expectEmployee)
 	assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
 
@@ -91,7 +94,7 @@ func (suite *EmployeeUseCaseTestSuite) TestCreateEmployee_emptyField(){
 		UpdatedAt: time.Now(),
 	}
 
-	_, err := suite.euc.UpdateEmployee(payload)
+	_, err := suite.euc.UpdateEmployee(context.Background(), payload)
 	assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
 }
@@ -109,61 +112,93 @@ func (suite *EmployeeUseCaseTestSuite) TestUpdateEmployee_emptyField(){
 		UpdatedAt: time.Now(),
 	}
 
-	_, err := suite.euc.RegisterNewEmployee(payload)
+	_, err := suite.euc.RegisterNewEmployee(context.Background(), payload)
 	assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
 }
 
 func (suite *EmployeeUseCaseTestSuite) TestGetEmployeeByID_success() {
-	suite.erm.On("GetEmployeesByID",  expectEmployee.ID).Return(expectEmployee, nil)
+	suite.erm.On("GetEmployeesByID", mock.Anything,  expectEmployee.ID).Return(expectEmployee, nil)
 
-	actualEmployee, err := suite.euc.FindEmployeesByID(expectEmployee.ID)
+	actualEmployee, err := suite.euc.FindEmployeesByID(context.Background(), expectEmployee.ID)
 	assert.Nil(suite.T(), err)
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), expectEmployee, actualEmployee)
 }
 func (suite *EmployeeUseCaseTestSuite) TestGetEmployeeByID_emptyId() {
-	suite.erm.On("GetEmployeesByID", "").Return(entity.Employee{}, errors.New("id harus diisi"))
-
-	_, err := suite.euc.FindEmployeesByID("")
+	_, err := suite.euc.FindEmployeesByID(context.Background(), "")
 	assert.NotNil(suite.T(), err)
-	assert.Equal(suite.T(), "id harus diisi", err.Error())
+	assert.Equal(suite.T(), apperror.KindValidation, apperror.KindOf(err))
+	assert.Equal(suite.T(), "id", apperror.From(err).Fields[0].Field)
 }
 
 func (suite *EmployeeUseCaseTestSuite) TestGetEmployeeByUsername_success() {
-	suite.erm.On("GetEmployeesByUsername",  expectEmployee.Username).Return(expectEmployee, nil)
+	suite.erm.On("GetEmployeesByUsername", mock.Anything,  expectEmployee.Username).Return(expectEmployee, nil)
 
-	actualEmployee, err := suite.euc.FindEmployeesByUsername(expectEmployee.Username)
+	actualEmployee, err := suite.euc.FindEmployeesByUsername(context.Background(), expectEmployee.Username)
 	assert.Nil(suite.T(), err)
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), expectEmployee, actualEmployee)
 }
 
 func (suite *EmployeeUseCaseTestSuite) TestGetEmployeeByUsername_emptyId() {
-	suite.erm.On("GetEmployeesByUsername", "").Return(entity.Employee{}, errors.New("username harus diisi"))
-
-	_, err := suite.euc.FindEmployeesByUsername("")
+	_, err := suite.euc.FindEmployeesByUsername(context.Background(), "")
 	assert.NotNil(suite.T(), err)
-	assert.Equal(suite.T(), "username harus diisi", err.Error())
+	assert.Equal(suite.T(), apperror.KindValidation, apperror.KindOf(err))
+	assert.Equal(suite.T(), "username", apperror.From(err).Fields[0].Field)
 }
 
 func (suite *EmployeeUseCaseTestSuite) TestEmployeeForLogin_emptyUsername() {
-	suite.erm.On("GetEmployeesByUsernameForLogin", "", "").Return(entity.Employee{}, errors.New("username harus diisi"))
-
-	_, err := suite.euc.FindEmployeForLogin("", "")
+	_, err := suite.euc.FindEmployeForLogin(context.Background(), "", "")
 	assert.NotNil(suite.T(), err)
-	assert.Equal(suite.T(), "username harus diisi", err.Error())
+	assert.Equal(suite.T(), apperror.KindValidation, apperror.KindOf(err))
+	assert.Len(suite.T(), apperror.From(err).Fields, 2)
 }
 
 func (suite *EmployeeUseCaseTestSuite) TestEmployeeForLogin_success() {
-	suite.erm.On("GetEmployeesByUsernameForLogin",  expectEmployee.Username, expectEmployee.Password).Return(expectEmployee, nil)
+	suite.erm.On("GetEmployeesByUsernameForLogin", mock.Anything,  expectEmployee.Username, expectEmployee.Password).Return(expectEmployee, nil)
 
-	actualEmployee, err := suite.euc.FindEmployeForLogin(expectEmployee.Username, expectEmployee.Password)
+	actualEmployee, err := suite.euc.FindEmployeForLogin(context.Background(), expectEmployee.Username, expectEmployee.Password)
 	assert.Nil(suite.T(), err)
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), expectEmployee, actualEmployee)
 }
 
+func (suite *EmployeeUseCaseTestSuite) TestEmployeeForLogin_InvalidCredentialsFailure() {
+	suite.erm.On("GetEmployeesByUsernameForLogin", mock.Anything, expectEmployee.Username, "wrong").Return(entity.Employee{}, sql.ErrNoRows)
+
+	_, err := suite.euc.FindEmployeForLogin(context.Background(), expectEmployee.Username, "wrong")
+
+	assert.ErrorIs(suite.T(), err, ErrInvalidCredentials)
+}
+
+func (suite *EmployeeUseCaseTestSuite) TestResetPassword_Success() {
+	reset := expectEmployee
+	reset.Password = "newSecret01"
+	suite.erm.On("GetEmployeesByUsername", mock.Anything, expectEmployee.Username).Return(expectEmployee, nil)
+	suite.erm.On("UpdateEmployee", mock.Anything, reset).Return(reset, nil)
+
+	actual, err := suite.euc.ResetPassword(context.Background(), expectEmployee.Username, "newSecret01")
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), reset, actual)
+}
+
+func (suite *EmployeeUseCaseTestSuite) TestResetPassword_NotFoundFailure() {
+	suite.erm.On("GetEmployeesByUsername", mock.Anything, "nobody").Return(entity.Employee{}, sql.ErrNoRows)
+
+	_, err := suite.euc.ResetPassword(context.Background(), "nobody", "newSecret01")
+
+	assert.Equal(suite.T(), apperror.KindNotFound, apperror.KindOf(err))
+	suite.erm.AssertNotCalled(suite.T(), "UpdateEmployee", mock.Anything, mock.Anything)
+}
+
+func (suite *EmployeeUseCaseTestSuite) TestResetPassword_EmptyFieldFailure() {
+	_, err := suite.euc.ResetPassword(context.Background(), expectEmployee.Username, "")
+
+	assert.Error(suite.T(), err)
+}
+
 func TestEmployeeUseCaseTestSuite(e *testing.T) {
 	suite.Run(e, new(EmployeeUseCaseTestSuite))
 }
@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"booking-room-app/repository"
+	"booking-room-app/shared/apperror"
+	"database/sql"
+	"errors"
+	"regexp"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+var (
+	ErrInvalidCredentials   = apperror.Unauthorized("invalid_credentials", "invalid username or password")
+	ErrRoomUnavailable      = apperror.Conflict("room_unavailable", "the room cannot be booked")
+	ErrInsufficientStock    = apperror.Conflict("insufficient_stock", "quantity exceeds the facility stock")
+	ErrUnknownRoom          = apperror.Validation("the room does not exist", apperror.Field("roomId", "exists", "roomId does not refer to a room"))
+	ErrFacilityArchived     = apperror.Conflict("facility_unavailable", "the facility is archived")
+	ErrUnknownFacility      = apperror.Validation("the facility does not exist", apperror.Field("facilityId", "exists", "facilityId does not refer to a facility"))
+	ErrOutsideHours         = apperror.Conflict("outside_business_hours", "the booking is outside the business hours of the room")
+	ErrMaintenanceCompleted = apperror.Conflict("maintenance_completed", "the maintenance window has already ended")
+
+	ErrInsufficientAllocation = apperror.Conflict("insufficient_allocation", "quantity exceeds the quantity allocated to the room")
+	ErrSameRoom               = apperror.Validation("the facility is already in the room", apperror.Field("roomId", "different", "roomId must be another room than the one the facility is in"))
+	ErrRoomOutOfService       = apperror.Conflict("room_out_of_service", "the room is archived or under maintenance")
+
+	ErrUntrackedStock     = apperror.Conflict("untracked_stock", "the facility has stock or room allocations that are not tracked by asset")
+	ErrFacilityTracked    = apperror.Conflict("facility_tracked", "the stock of the facility follows its assets")
+	ErrAssetRetired       = apperror.Conflict("asset_retired", "the asset is retired")
+	ErrAssetUnavailable   = apperror.Conflict("asset_unavailable", "the asset is damaged or retired")
+	ErrAssetCheckedOut    = apperror.Conflict("asset_checked_out", "the asset is checked out")
+	ErrAssetNotCheckedOut = apperror.Conflict("asset_not_checked_out", "the asset is not checked out")
+	ErrUnknownBooking     = apperror.Validation("the booking does not exist", apperror.Field("transactionId", "exists", "transactionId does not refer to a booking"))
+	ErrBookingClosed      = apperror.Conflict("booking_closed", "the booking is not accepted or has ended")
+	ErrFacilityNotBooked  = apperror.Conflict("facility_not_booked", "the booking has no unit of the facility left to check out")
+	ErrBookingDeclined    = apperror.Conflict("booking_declined", "the booking is declined and cannot change status")
+)
+
+// fkColumn finds the column in the detail of a foreign key violation, e.g.
+// Key (facility_id)=(...) is not present in table "facilities".
+var fkColumn = regexp.MustCompile(`^Key \((\w+)\)=`)
+
+// dbError translates a repository error about resource into a domain error,
+// keeping the original as the logged cause.
+func dbError(err error, resource string) error {
+	var appErr *apperror.Error
+	switch {
+	case errors.As(err, &appErr):
+		return err
+	case errors.Is(err, sql.ErrNoRows):
+		return apperror.NotFound(resource).Wrap(err)
+	case errors.Is(err, repository.ErrRoomUnavailable):
+		return ErrRoomUnavailable.Wrap(err)
+	case errors.Is(err, repository.ErrInsufficientStock):
+		return ErrInsufficientStock.Wrap(err)
+	case errors.Is(err, repository.ErrRoomNotFound):
+		return ErrUnknownRoom.Wrap(err)
+	case errors.Is(err, repository.ErrFacilityNotFound):
+		return ErrUnknownFacility.Wrap(err)
+	case errors.Is(err, repository.ErrInsufficientAllocation):
+		return ErrInsufficientAllocation.Wrap(err)
+	case errors.Is(err, repository.ErrSameRoom):
+		return ErrSameRoom.Wrap(err)
+	case errors.Is(err, repository.ErrRoomOutOfService):
+		return ErrRoomOutOfService.Wrap(err)
+	case errors.Is(err, repository.ErrUntrackedStock):
+		return ErrUntrackedStock.Wrap(err)
+	case errors.Is(err, repository.ErrFacilityTracked):
+		return ErrFacilityTracked.Wrap(err)
+	case errors.Is(err, repository.ErrAssetRetired):
+		return ErrAssetRetired.Wrap(err)
+	case errors.Is(err, repository.ErrAssetUnavailable):
+		return ErrAssetUnavailable.Wrap(err)
+	case errors.Is(err, repository.ErrAssetCheckedOut):
+		return ErrAssetCheckedOut.Wrap(err)
+	case errors.Is(err, repository.ErrAssetNotCheckedOut):
+		return ErrAssetNotCheckedOut.Wrap(err)
+	case errors.Is(err, repository.ErrBookingNotFound):
+		return ErrUnknownBooking.Wrap(err)
+	case errors.Is(err, repository.ErrBookingClosed):
+		return ErrBookingClosed.Wrap(err)
+	case errors.Is(err, repository.ErrFacilityNotBooked):
+		return ErrFacilityNotBooked.Wrap(err)
+	case errors.Is(err, repository.ErrBookingDeclined):
+		return ErrBookingDeclined.Wrap(err)
+	case errors.Is(err, repository.ErrFacilityArchived):
+		return ErrFacilityArchived.Wrap(err)
+	case errors.Is(err, repository.ErrOpenTransactions):
+		return apperror.Conflict("open_transactions", "the "+resource+" has open bookings").Wrap(err)
+	}
+
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) {
+		switch pqErr.Code {
+		case "23505": // unique_violation
+			return apperror.Conflict(strings.ReplaceAll(resource, " ", "_")+"_exists", resource+" already exists").Wrap(err)
+		case "23503": // foreign_key_violation
+			var fields []apperror.FieldError
+			if match := fkColumn.FindStringSubmatch(pqErr.Detail); match != nil {
+				field := camelCase(match[1])
+				fields = append(fields, apperror.Field(field, "exists", field+" does not refer to an existing record"))
+			}
+			return apperror.Validation(resource+" refers to a record that does not exist", fields...).Wrap(err)
+		case "22P02", "23514": // invalid_text_representation, check_violation
+			return apperror.Validation(resource + " contains an invalid value").Wrap(err)
+		}
+	}
+	return apperror.Internal(err)
+}
+
+// missingFields takes name, value pairs and returns the names whose value is
+// empty.
+func missingFields(pairs ...string) []string {
+	var missing []string
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if strings.TrimSpace(pairs[i+1]) == "" {
+			missing = append(missing, pairs[i])
+		}
+	}
+	return missing
+}
+
+// invalid reports the collected problems as one validation error, or nil when
+// there are none.
+func invalid(problems []apperror.FieldError) error {
+	if len(problems) == 0 {
+		return nil
+	}
+	return apperror.Validation("the request is invalid", problems...)
+}
+
+// camelCase turns a column name such as facility_id into facilityId.
+func camelCase(column string) string {
+	parts := strings.Split(column, "_")
+	for i := 1; i < len(parts); i++ {
+		if parts[i] != "" {
+			parts[i] = strings.ToUpper(parts[i][:1]) + parts[i][1:]
+		}
+	}
+	return strings.Join(parts, "")
+}
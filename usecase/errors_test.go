@@ -0,0 +1,68 @@
+package usecase
+
+import (
+	"booking-room-app/repository"
+	"booking-room-app/shared/apperror"
+	"database/sql"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDbError_Success(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		kind apperror.Kind
+		code string
+	}{
+		{"no rows", sql.ErrNoRows, apperror.KindNotFound, "room_not_found"},
+		{"room unavailable", fmt.Errorf("create: %w", repository.ErrRoomUnavailable), apperror.KindConflict, "room_unavailable"},
+		{"insufficient stock", repository.ErrInsufficientStock, apperror.KindConflict, "insufficient_stock"},
+		{"facility tracked", repository.ErrFacilityTracked, apperror.KindConflict, "facility_tracked"},
+		{"asset checked out", repository.ErrAssetCheckedOut, apperror.KindConflict, "asset_checked_out"},
+		{"unique violation", &pq.Error{Code: "23505"}, apperror.KindConflict, "room_exists"},
+		{"foreign key violation", &pq.Error{Code: "23503"}, apperror.KindValidation, apperror.CodeValidationFailed},
+		{"invalid uuid", &pq.Error{Code: "22P02"}, apperror.KindValidation, apperror.CodeValidationFailed},
+		{"typed", apperror.Forbidden("no access"), apperror.KindForbidden, apperror.CodeForbidden},
+		{"other", errors.New("pq: connection refused"), apperror.KindInternal, apperror.CodeInternal},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := apperror.From(dbError(tt.err, "room"))
+
+			assert.Equal(t, tt.kind, err.Kind)
+			assert.Equal(t, tt.code, err.Code)
+			assert.NotContains(t, err.Message, "pq:")
+		})
+	}
+}
+
+func TestMissingFields_Success(t *testing.T) {
+	assert.Equal(t, []string{"name", "role"}, missingFields("name", "", "username", "john", "role", " "))
+	assert.Empty(t, missingFields("name", "John"))
+}
+
+func TestDbError_ForeignKeyFieldSuccess(t *testing.T) {
+	err := apperror.From(dbError(&pq.Error{Code: "23503", Detail: `Key (facility_id)=(0f8fad5b-d9cb-469f-a165-70867728950e) is not present in table "facilities".`}, "room facility"))
+
+	assert.Equal(t, []apperror.FieldError{{Field: "facilityId", Code: "exists", Message: "facilityId does not refer to an existing record"}}, err.Fields)
+}
+
+func TestDbError_UnknownRoomSuccess(t *testing.T) {
+	err := apperror.From(dbError(repository.ErrRoomNotFound, "transaction"))
+
+	assert.Equal(t, apperror.KindValidation, err.Kind)
+	assert.Equal(t, "roomId", err.Fields[0].Field)
+}
+
+func TestDbError_OpenTransactionsSuccess(t *testing.T) {
+	err := apperror.From(dbError(fmt.Errorf("%w: 2 open bookings", repository.ErrOpenTransactions), "facility"))
+
+	assert.Equal(t, apperror.KindConflict, err.Kind)
+	assert.Equal(t, "open_transactions", err.Code)
+	assert.Equal(t, "the facility has open bookings", err.Message)
+}
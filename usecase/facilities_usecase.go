@@ -3,60 +3,197 @@ package usecase
 import (
 	"booking-room-app/entity"
 	"booking-room-app/repository"
+	"booking-room-app/shared/apperror"
 	"booking-room-app/shared/model"
-	"fmt"
+	"context"
+	"strings"
 )
 
 type FacilitiesUseCase interface {
-	FindAllFacilities(page, size int) ([]entity.Facilities, model.Paging, error)
-	RegisterNewFacilities(payload entity.Facilities) (entity.Facilities, error)
-	FindFacilitiesById(id string) (entity.Facilities, error)
-	EditFacilities(payload entity.Facilities) (entity.Facilities, error)
+	FindAllFacilities(ctx context.Context, page, size int) ([]entity.Facilities, model.Paging, error)
+	RegisterNewFacilities(ctx context.Context, payload entity.Facilities) (entity.Facilities, error)
+	FindFacilitiesById(ctx context.Context, id string) (entity.Facilities, error)
+	EditFacilities(ctx context.Context, payload entity.Facilities) (entity.Facilities, error)
+	ArchiveFacility(ctx context.Context, id string) (entity.Archival, error)
+	RestoreFacility(ctx context.Context, id string) (entity.Facilities, error)
+	FindArchivedFacilities(ctx context.Context, page, size int) ([]entity.Facilities, model.Paging, error)
+	RecordMovement(ctx context.Context, movement entity.FacilityMovement) (entity.FacilityMovement, error)
+	FindMovements(ctx context.Context, facilityId string, page, size int) ([]entity.FacilityMovement, model.Paging, error)
 }
 
 type facilitiesUseCase struct {
-	repo repository.FasilitiesRepository
+	repo          repository.FasilitiesRepository
+	archivePolicy string
 }
 
 // FindAllFacilities implements FacilitiesUseCase.
-func (f *facilitiesUseCase) FindAllFacilities(page int, size int) ([]entity.Facilities, model.Paging, error) {
-	return f.repo.List(page, size)
+func (f *facilitiesUseCase) FindAllFacilities(ctx context.Context, page int, size int) ([]entity.Facilities, model.Paging, error) {
+	ctx, span := startSpan(ctx, "facilitiesUseCase.FindAllFacilities")
+	defer span.End()
+
+	facilities, paging, err := f.repo.List(ctx, page, size)
+	if err != nil {
+		return nil, model.Paging{}, dbError(err, "facility")
+	}
+	return facilities, paging, nil
 }
 
 // FindFacilitiesById implements FacilitiesUseCase.
-func (f *facilitiesUseCase) FindFacilitiesById(id string) (entity.Facilities, error) {
-	return f.repo.GetById(id)
+func (f *facilitiesUseCase) FindFacilitiesById(ctx context.Context, id string) (entity.Facilities, error) {
+	ctx, span := startSpan(ctx, "facilitiesUseCase.FindFacilitiesById")
+	defer span.End()
+
+	facility, err := f.repo.GetById(ctx, id)
+	if err != nil {
+		return entity.Facilities{}, dbError(err, "facility")
+	}
+	return facility, nil
 }
 
 // RegisterNewFacilities implements FacilitiesUseCase.
-func (f *facilitiesUseCase) RegisterNewFacilities(payload entity.Facilities) (entity.Facilities, error) {
-	if payload.Name == "" || payload.Quantity <= 0 {
-		return entity.Facilities{}, fmt.Errorf("oops, field required")
+func (f *facilitiesUseCase) RegisterNewFacilities(ctx context.Context, payload entity.Facilities) (entity.Facilities, error) {
+	ctx, span := startSpan(ctx, "facilitiesUseCase.RegisterNewFacilities")
+	defer span.End()
+
+	if err := validateFacility(payload); err != nil {
+		return entity.Facilities{}, err
 	}
 
-	facility, err := f.repo.Create(payload)
+	facility, err := f.repo.Create(ctx, payload)
 	if err != nil {
-		return entity.Facilities{}, fmt.Errorf("oops failed save facility : %v", err)
+		return entity.Facilities{}, dbError(err, "facility")
 	}
 
 	return facility, nil
 }
 
-// EditFacilitiesById implements FacilitiesUseCase.
-func (f *facilitiesUseCase) EditFacilities(payload entity.Facilities) (entity.Facilities, error) {
-	if payload.Name == "" || payload.Quantity <= 0 {
-		return entity.Facilities{}, fmt.Errorf("oops, field required")
+// EditFacilitiesById implements FacilitiesUseCase. Only the name is edited,
+// the quantity is moved through RecordMovement.
+func (f *facilitiesUseCase) EditFacilities(ctx context.Context, payload entity.Facilities) (entity.Facilities, error) {
+	ctx, span := startSpan(ctx, "facilitiesUseCase.EditFacilities")
+	defer span.End()
+
+	if payload.Name == "" {
+		return entity.Facilities{}, invalid([]apperror.FieldError{apperror.RequiredField("name")})
+	}
+
+	facility, err := f.repo.UpdateById(ctx, payload)
+	if err != nil {
+		return entity.Facilities{}, dbError(err, "facility")
 	}
 
-	facility, err := f.repo.UpdateById(payload)
-	fmt.Println(facility)
+	return facility, nil
+}
+
+// ArchiveFacility implements FacilitiesUseCase.
+func (f *facilitiesUseCase) ArchiveFacility(ctx context.Context, id string) (entity.Archival, error) {
+	ctx, span := startSpan(ctx, "facilitiesUseCase.ArchiveFacility")
+	defer span.End()
+
+	archival, err := f.repo.Archive(ctx, id, cascadeArchive(f.archivePolicy))
 	if err != nil {
-		return entity.Facilities{}, fmt.Errorf("oops failed save facility : %v", err)
+		return entity.Archival{}, dbError(err, "facility")
 	}
+	declinedByArchive(archival)
+	return archival, nil
+}
+
+// RestoreFacility implements FacilitiesUseCase.
+func (f *facilitiesUseCase) RestoreFacility(ctx context.Context, id string) (entity.Facilities, error) {
+	ctx, span := startSpan(ctx, "facilitiesUseCase.RestoreFacility")
+	defer span.End()
 
+	facility, err := f.repo.Restore(ctx, id)
+	if err != nil {
+		return entity.Facilities{}, dbError(err, "facility")
+	}
 	return facility, nil
 }
 
-func NewFacilitiesUseCase(repo repository.FasilitiesRepository) FacilitiesUseCase {
-	return &facilitiesUseCase{repo: repo}
+// FindArchivedFacilities implements FacilitiesUseCase.
+func (f *facilitiesUseCase) FindArchivedFacilities(ctx context.Context, page, size int) ([]entity.Facilities, model.Paging, error) {
+	ctx, span := startSpan(ctx, "facilitiesUseCase.FindArchivedFacilities")
+	defer span.End()
+
+	facilities, paging, err := f.repo.ListArchived(ctx, page, size)
+	if err != nil {
+		return nil, model.Paging{}, dbError(err, "facility")
+	}
+	return facilities, paging, nil
+}
+
+// RecordMovement implements FacilitiesUseCase. Only purchases, damage,
+// write-offs and adjustments are recorded by hand; allocations and releases
+// follow room facilities and bookings. The quantity of damage and write-offs
+// is given as a positive count and taken out of the stock.
+func (f *facilitiesUseCase) RecordMovement(ctx context.Context, movement entity.FacilityMovement) (entity.FacilityMovement, error) {
+	ctx, span := startSpan(ctx, "facilitiesUseCase.RecordMovement")
+	defer span.End()
+
+	if err := validateMovement(movement); err != nil {
+		return entity.FacilityMovement{}, err
+	}
+	if _, err := f.repo.GetById(ctx, movement.FacilityId); err != nil {
+		return entity.FacilityMovement{}, dbError(err, "facility")
+	}
+
+	if movement.Kind == entity.MovementDamage || movement.Kind == entity.MovementWriteOff {
+		movement.Quantity = -movement.Quantity
+	}
+	movement.ReferenceId = ""
+	recorded, err := f.repo.RecordMovement(ctx, movement)
+	if err != nil {
+		return entity.FacilityMovement{}, dbError(err, "facility movement")
+	}
+	return recorded, nil
+}
+
+// FindMovements implements FacilitiesUseCase.
+func (f *facilitiesUseCase) FindMovements(ctx context.Context, facilityId string, page, size int) ([]entity.FacilityMovement, model.Paging, error) {
+	ctx, span := startSpan(ctx, "facilitiesUseCase.FindMovements")
+	defer span.End()
+
+	if _, err := f.repo.GetById(ctx, facilityId); err != nil {
+		return nil, model.Paging{}, dbError(err, "facility")
+	}
+	movements, paging, err := f.repo.ListMovements(ctx, facilityId, page, size)
+	if err != nil {
+		return nil, model.Paging{}, dbError(err, "facility movement")
+	}
+	return movements, paging, nil
+}
+
+func validateMovement(movement entity.FacilityMovement) error {
+	var problems []apperror.FieldError
+	switch movement.Kind {
+	case entity.MovementPurchase, entity.MovementDamage, entity.MovementWriteOff:
+		if movement.Quantity <= 0 {
+			problems = append(problems, apperror.Field("quantity", "min", "quantity must be greater than zero"))
+		}
+	case entity.MovementAdjustment:
+		if movement.Quantity == 0 {
+			problems = append(problems, apperror.Field("quantity", "nonzero", "an adjustment must change the quantity"))
+		}
+	default:
+		problems = append(problems, apperror.Field("kind", "oneof", "kind must be one of purchase, damage, write_off, adjustment"))
+	}
+	if strings.TrimSpace(movement.Reason) == "" {
+		problems = append(problems, apperror.RequiredField("reason"))
+	}
+	return invalid(problems)
+}
+
+func validateFacility(payload entity.Facilities) error {
+	var problems []apperror.FieldError
+	if payload.Name == "" {
+		problems = append(problems, apperror.RequiredField("name"))
+	}
+	if payload.Quantity < 0 {
+		problems = append(problems, apperror.Field("quantity", "min", "quantity must not be negative"))
+	}
+	return invalid(problems)
+}
+
+func NewFacilitiesUseCase(repo repository.FasilitiesRepository, archivePolicy string) FacilitiesUseCase {
+	return &facilitiesUseCase{repo: repo, archivePolicy: archivePolicy}
 }
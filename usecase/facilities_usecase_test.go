@@ -3,12 +3,17 @@ package usecase
 import (
 	"booking-room-app/entity"
 	"booking-room-app/mock/repo_mock"
+	"booking-room-app/repository"
+	"booking-room-app/shared/apperror"
 	"booking-room-app/shared/model"
+	"context"
+	"database/sql"
 	"fmt"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -28,13 +33,13 @@ type FacilitiesUseCaseTestSuite struct {
 
 func (suite *FacilitiesUseCaseTestSuite) SetupTest() {
 	suite.frm = new(repo_mock.FacilitiesRepoMock)
-	suite.fuc = NewFacilitiesUseCase(suite.frm)
+	suite.fuc = NewFacilitiesUseCase(suite.frm, ArchiveRefuse)
 }
 
 // test create
 func (suite *FacilitiesUseCaseTestSuite) TestRegisterNewFacilities_Success() {
-	suite.frm.On("Create", expectedFasilities).Return(expectedFasilities, nil)
-	actual, err := suite.fuc.RegisterNewFacilities(expectedFasilities)
+	suite.frm.On("Create", mock.Anything, expectedFasilities).Return(expectedFasilities, nil)
+	actual, err := suite.fuc.RegisterNewFacilities(context.Background(), expectedFasilities)
 	assert.Nil(suite.T(), err)
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), expectedFasilities.Name, actual.Name)
@@ -49,27 +54,54 @@ func (suite *FacilitiesUseCaseTestSuite) TestRegisterNewFacilities_EmptyField()
 		UpdatedAt: time.Now(),
 	}
 
-	_, err := suite.fuc.RegisterNewFacilities(payloadMock)
+	_, err := suite.fuc.RegisterNewFacilities(context.Background(), payloadMock)
 	assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
 }
 
+func (suite *FacilitiesUseCaseTestSuite) TestRegisterNewFacilities_ZeroQuantitySuccess() {
+	payload := entity.Facilities{Name: "Laptop"}
+	suite.frm.On("Create", mock.Anything, payload).Return(entity.Facilities{ID: "1", Name: "Laptop"}, nil)
+
+	actual, err := suite.fuc.RegisterNewFacilities(context.Background(), payload)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 0, actual.Quantity)
+}
+
+func (suite *FacilitiesUseCaseTestSuite) TestRegisterNewFacilities_NegativeQuantityFail() {
+	_, err := suite.fuc.RegisterNewFacilities(context.Background(), entity.Facilities{Name: "Laptop", Quantity: -1})
+
+	assert.Equal(suite.T(), apperror.KindValidation, apperror.KindOf(err))
+	suite.frm.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
 func (suite *FacilitiesUseCaseTestSuite) TestRegisterNewFacilities_Fail() {
-	suite.frm.On("Create", expectedFasilities).Return(entity.Facilities{}, fmt.Errorf("error"))
-	_, err := suite.fuc.RegisterNewFacilities(expectedFasilities)
+	suite.frm.On("Create", mock.Anything, expectedFasilities).Return(entity.Facilities{}, fmt.Errorf("error"))
+	_, err := suite.fuc.RegisterNewFacilities(context.Background(), expectedFasilities)
 	assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
 }
 
 // test edit
 func (suite *FacilitiesUseCaseTestSuite) TestEditFacilities_Success() {
-	suite.frm.On("UpdateById", expectedFasilities).Return(expectedFasilities, nil)
-	actual, err := suite.fuc.EditFacilities(expectedFasilities)
+	suite.frm.On("UpdateById", mock.Anything, expectedFasilities).Return(expectedFasilities, nil)
+	actual, err := suite.fuc.EditFacilities(context.Background(), expectedFasilities)
 	assert.Nil(suite.T(), err)
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), expectedFasilities.Name, actual.Name)
 }
 
+func (suite *FacilitiesUseCaseTestSuite) TestEditFacilities_NameOnlySuccess() {
+	payload := entity.Facilities{ID: "1", Name: "This is name"}
+	suite.frm.On("UpdateById", mock.Anything, payload).Return(expectedFasilities, nil)
+
+	actual, err := suite.fuc.EditFacilities(context.Background(), payload)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expectedFasilities.Quantity, actual.Quantity)
+}
+
 func (suite *FacilitiesUseCaseTestSuite) TestEditFacilities_EmptyField() {
 	payloadMock := entity.Facilities{
 		ID:        "1",
@@ -79,14 +111,14 @@ func (suite *FacilitiesUseCaseTestSuite) TestEditFacilities_EmptyField() {
 		UpdatedAt: time.Now(),
 	}
 
-	_, err := suite.fuc.EditFacilities(payloadMock)
+	_, err := suite.fuc.EditFacilities(context.Background(), payloadMock)
 	assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
 }
 
 func (suite *FacilitiesUseCaseTestSuite) TestEditFacilities_Fail() {
-	suite.frm.On("UpdateById", expectedFasilities).Return(entity.Facilities{}, fmt.Errorf("error"))
-	_, err := suite.fuc.EditFacilities(expectedFasilities)
+	suite.frm.On("UpdateById", mock.Anything, expectedFasilities).Return(entity.Facilities{}, fmt.Errorf("error"))
+	_, err := suite.fuc.EditFacilities(context.Background(), expectedFasilities)
 	assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
 }
@@ -100,8 +132,8 @@ func (suite *FacilitiesUseCaseTestSuite) TestFindAllFacilities_Success() {
 		TotalRows:   5,
 		TotalPages:  1,
 	}
-	suite.frm.On("List", 1, 5).Return(mockData, mockPaging, nil)
-	actual, paging, err := suite.fuc.FindAllFacilities(1, 5)
+	suite.frm.On("List", mock.Anything, 1, 5).Return(mockData, mockPaging, nil)
+	actual, paging, err := suite.fuc.FindAllFacilities(context.Background(), 1, 5)
 	assert.Nil(suite.T(), err)
 	assert.NoError(suite.T(), err)
 	assert.Len(suite.T(), actual, 1)
@@ -111,13 +143,55 @@ func (suite *FacilitiesUseCaseTestSuite) TestFindAllFacilities_Success() {
 // test FindFacilitiesById
 func (suite *FacilitiesUseCaseTestSuite) TestFindFacilitiesById_Success() {
 
-	suite.frm.On("GetById", expectedFasilities.ID).Return(expectedFasilities, nil)
-	actual, err := suite.fuc.FindFacilitiesById(expectedFasilities.ID)
+	suite.frm.On("GetById", mock.Anything, expectedFasilities.ID).Return(expectedFasilities, nil)
+	actual, err := suite.fuc.FindFacilitiesById(context.Background(), expectedFasilities.ID)
 	assert.Nil(suite.T(), err)
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), actual.Name, expectedFasilities.Name)
 }
 
+func (suite *FacilitiesUseCaseTestSuite) TestRecordMovement_DamageSuccess() {
+	suite.frm.On("GetById", mock.Anything, "1").Return(expectedFasilities, nil)
+	suite.frm.On("RecordMovement", mock.Anything, entity.FacilityMovement{FacilityId: "1", Kind: entity.MovementDamage, Quantity: -2, Reason: "broken"}).Return(entity.FacilityMovement{ID: "m1", Quantity: -2, Balance: 8}, nil)
+
+	actual, err := suite.fuc.RecordMovement(context.Background(), entity.FacilityMovement{FacilityId: "1", Kind: entity.MovementDamage, Quantity: 2, Reason: "broken"})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), 8, actual.Balance)
+}
+
+func (suite *FacilitiesUseCaseTestSuite) TestRecordMovement_InvalidFail() {
+	cases := map[string]entity.FacilityMovement{
+		"kind":     {FacilityId: "1", Kind: entity.MovementAllocation, Quantity: 1, Reason: "moved"},
+		"quantity": {FacilityId: "1", Kind: entity.MovementPurchase, Quantity: -1, Reason: "bought"},
+		"reason":   {FacilityId: "1", Kind: entity.MovementAdjustment, Quantity: -1, Reason: " "},
+	}
+	for field, movement := range cases {
+		_, err := suite.fuc.RecordMovement(context.Background(), movement)
+
+		assert.Equal(suite.T(), field, apperror.From(err).Fields[0].Field)
+	}
+	suite.frm.AssertNotCalled(suite.T(), "RecordMovement", mock.Anything, mock.Anything)
+}
+
+func (suite *FacilitiesUseCaseTestSuite) TestRecordMovement_InsufficientStockFail() {
+	suite.frm.On("GetById", mock.Anything, "1").Return(expectedFasilities, nil)
+	suite.frm.On("RecordMovement", mock.Anything, mock.Anything).Return(entity.FacilityMovement{}, repository.ErrInsufficientStock)
+
+	_, err := suite.fuc.RecordMovement(context.Background(), entity.FacilityMovement{FacilityId: "1", Kind: entity.MovementWriteOff, Quantity: 20, Reason: "lost"})
+
+	assert.Equal(suite.T(), "insufficient_stock", apperror.From(err).Code)
+}
+
+func (suite *FacilitiesUseCaseTestSuite) TestFindMovements_NotFoundFail() {
+	suite.frm.On("GetById", mock.Anything, "9").Return(entity.Facilities{}, sql.ErrNoRows)
+
+	_, _, err := suite.fuc.FindMovements(context.Background(), "9", 1, 5)
+
+	assert.Equal(suite.T(), apperror.KindNotFound, apperror.KindOf(err))
+	suite.frm.AssertNotCalled(suite.T(), "ListMovements", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestFacilitiesUseCaseTestSuite(t *testing.T) {
 	suite.Run(t, new(FacilitiesUseCaseTestSuite))
 }
@@ -0,0 +1,181 @@
+package usecase
+
+import (
+	"booking-room-app/entity"
+	"booking-room-app/repository"
+	"booking-room-app/shared/apperror"
+	"booking-room-app/shared/model"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+type FacilityAssetUseCase interface {
+	RegisterAsset(ctx context.Context, payload entity.FacilityAsset) (entity.FacilityAsset, error)
+	FindAssets(ctx context.Context, facilityId string, page, size int) ([]entity.FacilityAsset, model.Paging, error)
+	FindAssetById(ctx context.Context, id string) (entity.FacilityAsset, error)
+	UpdateAsset(ctx context.Context, payload entity.FacilityAsset) (entity.FacilityAsset, error)
+	CheckOutAsset(ctx context.Context, id, transactionId string) (entity.FacilityAsset, error)
+	CheckInAsset(ctx context.Context, payload entity.AssetCheckIn) (entity.FacilityAsset, error)
+	FindCheckouts(ctx context.Context, id string, page, size int) ([]entity.AssetCheckout, model.Paging, error)
+}
+
+type facilityAssetUseCase struct {
+	repo         repository.FacilityAssetRepository
+	stockAlertUC StockAlertUseCase
+}
+
+// RegisterAsset implements FacilityAssetUseCase. The first asset of a
+// facility turns it into a tracked facility whose stock is its serviceable
+// assets.
+func (f *facilityAssetUseCase) RegisterAsset(ctx context.Context, payload entity.FacilityAsset) (entity.FacilityAsset, error) {
+	ctx, span := startSpan(ctx, "facilityAssetUseCase.RegisterAsset")
+	defer span.End()
+
+	if err := validateAsset(payload, "facilityId", payload.FacilityId); err != nil {
+		return entity.FacilityAsset{}, err
+	}
+
+	asset, err := f.repo.Create(ctx, payload)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.FacilityAsset{}, dbError(err, "facility")
+	}
+	if err != nil {
+		return entity.FacilityAsset{}, dbError(err, "facility asset")
+	}
+	checkStockAfter(ctx, f.stockAlertUC, asset.FacilityId)
+	return asset, nil
+}
+
+// FindAssets implements FacilityAssetUseCase.
+func (f *facilityAssetUseCase) FindAssets(ctx context.Context, facilityId string, page, size int) ([]entity.FacilityAsset, model.Paging, error) {
+	ctx, span := startSpan(ctx, "facilityAssetUseCase.FindAssets")
+	defer span.End()
+
+	assets, paging, err := f.repo.List(ctx, facilityId, page, size)
+	if err != nil {
+		return nil, model.Paging{}, dbError(err, "facility asset")
+	}
+	return assets, paging, nil
+}
+
+// FindAssetById implements FacilityAssetUseCase.
+func (f *facilityAssetUseCase) FindAssetById(ctx context.Context, id string) (entity.FacilityAsset, error) {
+	ctx, span := startSpan(ctx, "facilityAssetUseCase.FindAssetById")
+	defer span.End()
+
+	asset, err := f.repo.GetById(ctx, id)
+	if err != nil {
+		return entity.FacilityAsset{}, dbError(err, "facility asset")
+	}
+	return asset, nil
+}
+
+// UpdateAsset implements FacilityAssetUseCase. Damaging or retiring an asset
+// takes it out of the stock of its facility and repairing it puts it back.
+func (f *facilityAssetUseCase) UpdateAsset(ctx context.Context, payload entity.FacilityAsset) (entity.FacilityAsset, error) {
+	ctx, span := startSpan(ctx, "facilityAssetUseCase.UpdateAsset")
+	defer span.End()
+
+	if err := validateAsset(payload, "id", payload.ID); err != nil {
+		return entity.FacilityAsset{}, err
+	}
+
+	asset, err := f.repo.Update(ctx, payload)
+	if err != nil {
+		return entity.FacilityAsset{}, dbError(err, "facility asset")
+	}
+	checkStockAfter(ctx, f.stockAlertUC, asset.FacilityId)
+	return asset, nil
+}
+
+// CheckOutAsset implements FacilityAssetUseCase, for one of the units of the
+// facility the booking took.
+func (f *facilityAssetUseCase) CheckOutAsset(ctx context.Context, id, transactionId string) (entity.FacilityAsset, error) {
+	ctx, span := startSpan(ctx, "facilityAssetUseCase.CheckOutAsset")
+	defer span.End()
+
+	if transactionId == "" {
+		return entity.FacilityAsset{}, invalid([]apperror.FieldError{apperror.RequiredField("transactionId")})
+	}
+
+	asset, err := f.repo.CheckOut(ctx, id, transactionId)
+	if err != nil {
+		return entity.FacilityAsset{}, dbError(err, "facility asset")
+	}
+	return asset, nil
+}
+
+// CheckInAsset implements FacilityAssetUseCase. An asset that comes back
+// damaged or is retired leaves the stock of its facility.
+func (f *facilityAssetUseCase) CheckInAsset(ctx context.Context, payload entity.AssetCheckIn) (entity.FacilityAsset, error) {
+	ctx, span := startSpan(ctx, "facilityAssetUseCase.CheckInAsset")
+	defer span.End()
+
+	var problems []apperror.FieldError
+	switch {
+	case payload.Condition == "":
+		problems = append(problems, apperror.RequiredField("condition"))
+	case !validCondition(payload.Condition):
+		problems = append(problems, conditionProblem())
+	}
+	if err := invalid(problems); err != nil {
+		return entity.FacilityAsset{}, err
+	}
+
+	asset, err := f.repo.CheckIn(ctx, payload)
+	if err != nil {
+		return entity.FacilityAsset{}, dbError(err, "facility asset")
+	}
+	checkStockAfter(ctx, f.stockAlertUC, asset.FacilityId)
+	return asset, nil
+}
+
+// FindCheckouts implements FacilityAssetUseCase.
+func (f *facilityAssetUseCase) FindCheckouts(ctx context.Context, id string, page, size int) ([]entity.AssetCheckout, model.Paging, error) {
+	ctx, span := startSpan(ctx, "facilityAssetUseCase.FindCheckouts")
+	defer span.End()
+
+	checkouts, paging, err := f.repo.ListCheckouts(ctx, id, page, size)
+	if err != nil {
+		return nil, model.Paging{}, dbError(err, "facility asset")
+	}
+	return checkouts, paging, nil
+}
+
+// validateAsset checks an asset to save, identified by the field idField of
+// value id.
+func validateAsset(payload entity.FacilityAsset, idField, id string) error {
+	var problems []apperror.FieldError
+	for _, field := range missingFields(idField, id, "assetTag", payload.AssetTag, "condition", payload.Condition) {
+		problems = append(problems, apperror.RequiredField(field))
+	}
+	if len(payload.AssetTag) > 50 {
+		problems = append(problems, apperror.Field("assetTag", "max", "assetTag must be at most 50 characters"))
+	}
+	if len(payload.SerialNumber) > 100 {
+		problems = append(problems, apperror.Field("serialNumber", "max", "serialNumber must be at most 100 characters"))
+	}
+	if payload.Condition != "" && !validCondition(payload.Condition) {
+		problems = append(problems, conditionProblem())
+	}
+	if payload.PurchaseDate != "" {
+		if _, err := time.Parse(time.DateOnly, payload.PurchaseDate); err != nil {
+			problems = append(problems, apperror.Field("purchaseDate", "datetime", "purchaseDate must be formatted as 2006-01-02"))
+		}
+	}
+	return invalid(problems)
+}
+
+func validCondition(condition string) bool {
+	return entity.Serviceable(condition) || condition == entity.AssetConditionDamaged || condition == entity.AssetConditionRetired
+}
+
+func conditionProblem() apperror.FieldError {
+	return apperror.Field("condition", "oneof", "condition must be one of new, good, fair, damaged or retired")
+}
+
+func NewFacilityAssetUseCase(repo repository.FacilityAssetRepository, stockAlertUC StockAlertUseCase) FacilityAssetUseCase {
+	return &facilityAssetUseCase{repo: repo, stockAlertUC: stockAlertUC}
+}
@@ -0,0 +1,213 @@
+package usecase
+
+import (
+	"booking-room-app/entity"
+	"booking-room-app/mock/repo_mock"
+	"booking-room-app/mock/usecase_mock"
+	"booking-room-app/repository"
+	"booking-room-app/shared/apperror"
+	"booking-room-app/shared/model"
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+var expectedAsset = entity.FacilityAsset{
+	ID:           "1",
+	FacilityId:   "2",
+	AssetTag:     "LPT-001",
+	SerialNumber: "SN123",
+	Condition:    entity.AssetConditionGood,
+	PurchaseDate: "2024-01-15",
+}
+
+type FacilityAssetUseCaseTestSuite struct {
+	suite.Suite
+	farm *repo_mock.FacilityAssetRepoMock
+	saum *usecase_mock.StockAlertUseCaseMock
+	fauc FacilityAssetUseCase
+}
+
+func (suite *FacilityAssetUseCaseTestSuite) SetupTest() {
+	suite.farm = new(repo_mock.FacilityAssetRepoMock)
+	suite.saum = new(usecase_mock.StockAlertUseCaseMock)
+	suite.fauc = NewFacilityAssetUseCase(suite.farm, suite.saum)
+}
+
+func (suite *FacilityAssetUseCaseTestSuite) TestRegisterAsset_Success() {
+	suite.farm.On("Create", mock.Anything, expectedAsset).Return(expectedAsset, nil)
+	suite.saum.On("CheckStock", mock.Anything, []string{"2"}).Return(nil)
+
+	actual, err := suite.fauc.RegisterAsset(context.Background(), expectedAsset)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expectedAsset, actual)
+	suite.saum.AssertExpectations(suite.T())
+}
+
+func (suite *FacilityAssetUseCaseTestSuite) TestRegisterAsset_InvalidFailure() {
+	payload := entity.FacilityAsset{FacilityId: "2", Condition: "broken", PurchaseDate: "15/01/2024"}
+
+	_, err := suite.fauc.RegisterAsset(context.Background(), payload)
+
+	appErr := apperror.From(err)
+	assert.Equal(suite.T(), apperror.KindValidation, appErr.Kind)
+	var fields []string
+	for _, field := range appErr.Fields {
+		fields = append(fields, field.Field)
+	}
+	assert.Equal(suite.T(), []string{"assetTag", "condition", "purchaseDate"}, fields)
+	suite.farm.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *FacilityAssetUseCaseTestSuite) TestRegisterAsset_FacilityNotFoundFailure() {
+	suite.farm.On("Create", mock.Anything, expectedAsset).Return(entity.FacilityAsset{}, sql.ErrNoRows)
+
+	_, err := suite.fauc.RegisterAsset(context.Background(), expectedAsset)
+
+	assert.Equal(suite.T(), "facility_not_found", apperror.From(err).Code)
+}
+
+func (suite *FacilityAssetUseCaseTestSuite) TestRegisterAsset_UntrackedStockFailure() {
+	suite.farm.On("Create", mock.Anything, expectedAsset).Return(entity.FacilityAsset{}, repository.ErrUntrackedStock)
+
+	_, err := suite.fauc.RegisterAsset(context.Background(), expectedAsset)
+
+	assert.Equal(suite.T(), "untracked_stock", apperror.From(err).Code)
+	suite.saum.AssertNotCalled(suite.T(), "CheckStock", mock.Anything, mock.Anything)
+}
+
+func (suite *FacilityAssetUseCaseTestSuite) TestFindAssets_Success() {
+	paging := model.Paging{Page: 1, RowsPerPage: 5, TotalRows: 1, TotalPages: 1}
+	suite.farm.On("List", mock.Anything, "2", 1, 5).Return([]entity.FacilityAsset{expectedAsset}, paging, nil)
+
+	actual, actualPaging, err := suite.fauc.FindAssets(context.Background(), "2", 1, 5)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []entity.FacilityAsset{expectedAsset}, actual)
+	assert.Equal(suite.T(), paging, actualPaging)
+}
+
+func (suite *FacilityAssetUseCaseTestSuite) TestFindAssetById_NotFoundFailure() {
+	suite.farm.On("GetById", mock.Anything, "1").Return(entity.FacilityAsset{}, sql.ErrNoRows)
+
+	_, err := suite.fauc.FindAssetById(context.Background(), "1")
+
+	assert.Equal(suite.T(), apperror.KindNotFound, apperror.KindOf(err))
+}
+
+func (suite *FacilityAssetUseCaseTestSuite) TestUpdateAsset_Success() {
+	suite.farm.On("Update", mock.Anything, expectedAsset).Return(expectedAsset, nil)
+	suite.saum.On("CheckStock", mock.Anything, []string{"2"}).Return(nil)
+
+	actual, err := suite.fauc.UpdateAsset(context.Background(), expectedAsset)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expectedAsset, actual)
+	suite.saum.AssertExpectations(suite.T())
+}
+
+func (suite *FacilityAssetUseCaseTestSuite) TestUpdateAsset_RetiredFailure() {
+	suite.farm.On("Update", mock.Anything, expectedAsset).Return(entity.FacilityAsset{}, repository.ErrAssetRetired)
+
+	_, err := suite.fauc.UpdateAsset(context.Background(), expectedAsset)
+
+	assert.Equal(suite.T(), "asset_retired", apperror.From(err).Code)
+}
+
+func (suite *FacilityAssetUseCaseTestSuite) TestCheckOutAsset_Success() {
+	checkedOut := expectedAsset
+	checkedOut.TransactionId = "3"
+	suite.farm.On("CheckOut", mock.Anything, "1", "3").Return(checkedOut, nil)
+
+	actual, err := suite.fauc.CheckOutAsset(context.Background(), "1", "3")
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), checkedOut, actual)
+}
+
+func (suite *FacilityAssetUseCaseTestSuite) TestCheckOutAsset_RequiredFailure() {
+	_, err := suite.fauc.CheckOutAsset(context.Background(), "1", "")
+
+	assert.Equal(suite.T(), apperror.KindValidation, apperror.KindOf(err))
+	suite.farm.AssertNotCalled(suite.T(), "CheckOut", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *FacilityAssetUseCaseTestSuite) TestCheckOutAsset_Failure() {
+	tests := []struct {
+		err  error
+		code string
+	}{
+		{repository.ErrAssetUnavailable, "asset_unavailable"},
+		{repository.ErrAssetCheckedOut, "asset_checked_out"},
+		{repository.ErrBookingClosed, "booking_closed"},
+		{repository.ErrFacilityNotBooked, "facility_not_booked"},
+		{fmt.Errorf("error"), apperror.CodeInternal},
+	}
+	for _, test := range tests {
+		suite.SetupTest()
+		suite.farm.On("CheckOut", mock.Anything, "1", "3").Return(entity.FacilityAsset{}, test.err)
+
+		_, err := suite.fauc.CheckOutAsset(context.Background(), "1", "3")
+
+		assert.Equal(suite.T(), test.code, apperror.From(err).Code)
+	}
+}
+
+func (suite *FacilityAssetUseCaseTestSuite) TestCheckOutAsset_UnknownBookingFailure() {
+	suite.farm.On("CheckOut", mock.Anything, "1", "3").Return(entity.FacilityAsset{}, repository.ErrBookingNotFound)
+
+	_, err := suite.fauc.CheckOutAsset(context.Background(), "1", "3")
+
+	appErr := apperror.From(err)
+	assert.Equal(suite.T(), apperror.KindValidation, appErr.Kind)
+	assert.Equal(suite.T(), "transactionId", appErr.Fields[0].Field)
+}
+
+func (suite *FacilityAssetUseCaseTestSuite) TestCheckInAsset_Success() {
+	payload := entity.AssetCheckIn{AssetId: "1", Condition: entity.AssetConditionDamaged, Location: "storage"}
+	suite.farm.On("CheckIn", mock.Anything, payload).Return(expectedAsset, nil)
+	suite.saum.On("CheckStock", mock.Anything, []string{"2"}).Return(fmt.Errorf("error"))
+
+	actual, err := suite.fauc.CheckInAsset(context.Background(), payload)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expectedAsset, actual)
+	suite.saum.AssertExpectations(suite.T())
+}
+
+func (suite *FacilityAssetUseCaseTestSuite) TestCheckInAsset_InvalidConditionFailure() {
+	_, err := suite.fauc.CheckInAsset(context.Background(), entity.AssetCheckIn{AssetId: "1", Condition: "broken"})
+
+	appErr := apperror.From(err)
+	assert.Equal(suite.T(), apperror.KindValidation, appErr.Kind)
+	assert.Equal(suite.T(), "condition", appErr.Fields[0].Field)
+}
+
+func (suite *FacilityAssetUseCaseTestSuite) TestCheckInAsset_NotCheckedOutFailure() {
+	payload := entity.AssetCheckIn{AssetId: "1", Condition: entity.AssetConditionGood}
+	suite.farm.On("CheckIn", mock.Anything, payload).Return(entity.FacilityAsset{}, repository.ErrAssetNotCheckedOut)
+
+	_, err := suite.fauc.CheckInAsset(context.Background(), payload)
+
+	assert.Equal(suite.T(), "asset_not_checked_out", apperror.From(err).Code)
+}
+
+func (suite *FacilityAssetUseCaseTestSuite) TestFindCheckouts_Success() {
+	checkouts := []entity.AssetCheckout{{ID: "4", AssetId: "1", TransactionId: "3"}}
+	suite.farm.On("ListCheckouts", mock.Anything, "1", 1, 5).Return(checkouts, model.Paging{TotalRows: 1}, nil)
+
+	actual, _, err := suite.fauc.FindCheckouts(context.Background(), "1", 1, 5)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), checkouts, actual)
+}
+
+func TestFacilityAssetUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(FacilityAssetUseCaseTestSuite))
+}
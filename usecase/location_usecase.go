@@ -0,0 +1,274 @@
+package usecase
+
+import (
+	"booking-room-app/entity"
+	"booking-room-app/repository"
+	"booking-room-app/shared/apperror"
+	"booking-room-app/shared/model"
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/lib/pq"
+)
+
+// DefaultTimezone is the time zone of a site that is created without one.
+const DefaultTimezone = "UTC"
+
+type LocationUseCase interface {
+	RegisterSite(ctx context.Context, payload entity.Site) (entity.Site, error)
+	FindSiteByID(ctx context.Context, id string) (entity.Site, error)
+	FindAllSites(ctx context.Context, page, size int) ([]entity.Site, model.Paging, error)
+	UpdateSite(ctx context.Context, payload entity.Site) (entity.Site, error)
+	DeleteSite(ctx context.Context, id string) error
+	RegisterBuilding(ctx context.Context, payload entity.Building) (entity.Building, error)
+	FindBuildingByID(ctx context.Context, id string) (entity.Building, error)
+	FindAllBuildings(ctx context.Context, siteId string, page, size int) ([]entity.Building, model.Paging, error)
+	UpdateBuilding(ctx context.Context, payload entity.Building) (entity.Building, error)
+	DeleteBuilding(ctx context.Context, id string) error
+	RegisterFloor(ctx context.Context, payload entity.Floor) (entity.Floor, error)
+	FindFloorByID(ctx context.Context, id string) (entity.Floor, error)
+	FindAllFloors(ctx context.Context, buildingId string, page, size int) ([]entity.Floor, model.Paging, error)
+	UpdateFloor(ctx context.Context, payload entity.Floor) (entity.Floor, error)
+	DeleteFloor(ctx context.Context, id string) error
+}
+
+type locationUseCase struct {
+	repo repository.LocationRepository
+}
+
+// RegisterSite implements LocationUseCase.
+func (l *locationUseCase) RegisterSite(ctx context.Context, payload entity.Site) (entity.Site, error) {
+	ctx, span := startSpan(ctx, "locationUseCase.RegisterSite")
+	defer span.End()
+
+	if payload.Timezone == "" {
+		payload.Timezone = DefaultTimezone
+	}
+	if err := validateSite(payload, false); err != nil {
+		return entity.Site{}, err
+	}
+
+	site, err := l.repo.CreateSite(ctx, payload)
+	if err != nil {
+		return entity.Site{}, dbError(err, "site")
+	}
+	return site, nil
+}
+
+// FindSiteByID implements LocationUseCase.
+func (l *locationUseCase) FindSiteByID(ctx context.Context, id string) (entity.Site, error) {
+	ctx, span := startSpan(ctx, "locationUseCase.FindSiteByID")
+	defer span.End()
+
+	site, err := l.repo.GetSite(ctx, id)
+	if err != nil {
+		return entity.Site{}, dbError(err, "site")
+	}
+	return site, nil
+}
+
+// FindAllSites implements LocationUseCase.
+func (l *locationUseCase) FindAllSites(ctx context.Context, page, size int) ([]entity.Site, model.Paging, error) {
+	ctx, span := startSpan(ctx, "locationUseCase.FindAllSites")
+	defer span.End()
+
+	sites, paging, err := l.repo.ListSites(ctx, page, size)
+	if err != nil {
+		return nil, model.Paging{}, dbError(err, "site")
+	}
+	return sites, paging, nil
+}
+
+// UpdateSite implements LocationUseCase.
+func (l *locationUseCase) UpdateSite(ctx context.Context, payload entity.Site) (entity.Site, error) {
+	ctx, span := startSpan(ctx, "locationUseCase.UpdateSite")
+	defer span.End()
+
+	if payload.Timezone == "" {
+		payload.Timezone = DefaultTimezone
+	}
+	if err := validateSite(payload, true); err != nil {
+		return entity.Site{}, err
+	}
+
+	site, err := l.repo.UpdateSite(ctx, payload)
+	if err != nil {
+		return entity.Site{}, dbError(err, "site")
+	}
+	return site, nil
+}
+
+// DeleteSite implements LocationUseCase.
+func (l *locationUseCase) DeleteSite(ctx context.Context, id string) error {
+	ctx, span := startSpan(ctx, "locationUseCase.DeleteSite")
+	defer span.End()
+
+	if err := l.repo.DeleteSite(ctx, id); err != nil {
+		return deleteError(err, "site", "buildings")
+	}
+	return nil
+}
+
+// RegisterBuilding implements LocationUseCase.
+func (l *locationUseCase) RegisterBuilding(ctx context.Context, payload entity.Building) (entity.Building, error) {
+	ctx, span := startSpan(ctx, "locationUseCase.RegisterBuilding")
+	defer span.End()
+
+	if missing := missingFields("siteId", payload.SiteId, "name", payload.Name); len(missing) > 0 {
+		return entity.Building{}, apperror.Required(missing...)
+	}
+
+	building, err := l.repo.CreateBuilding(ctx, payload)
+	if err != nil {
+		return entity.Building{}, dbError(err, "building")
+	}
+	return building, nil
+}
+
+// FindBuildingByID implements LocationUseCase.
+func (l *locationUseCase) FindBuildingByID(ctx context.Context, id string) (entity.Building, error) {
+	ctx, span := startSpan(ctx, "locationUseCase.FindBuildingByID")
+	defer span.End()
+
+	building, err := l.repo.GetBuilding(ctx, id)
+	if err != nil {
+		return entity.Building{}, dbError(err, "building")
+	}
+	return building, nil
+}
+
+// FindAllBuildings implements LocationUseCase.
+func (l *locationUseCase) FindAllBuildings(ctx context.Context, siteId string, page, size int) ([]entity.Building, model.Paging, error) {
+	ctx, span := startSpan(ctx, "locationUseCase.FindAllBuildings")
+	defer span.End()
+
+	buildings, paging, err := l.repo.ListBuildings(ctx, siteId, page, size)
+	if err != nil {
+		return nil, model.Paging{}, dbError(err, "building")
+	}
+	return buildings, paging, nil
+}
+
+// UpdateBuilding implements LocationUseCase.
+func (l *locationUseCase) UpdateBuilding(ctx context.Context, payload entity.Building) (entity.Building, error) {
+	ctx, span := startSpan(ctx, "locationUseCase.UpdateBuilding")
+	defer span.End()
+
+	if missing := missingFields("id", payload.ID, "siteId", payload.SiteId, "name", payload.Name); len(missing) > 0 {
+		return entity.Building{}, apperror.Required(missing...)
+	}
+
+	building, err := l.repo.UpdateBuilding(ctx, payload)
+	if err != nil {
+		return entity.Building{}, dbError(err, "building")
+	}
+	return building, nil
+}
+
+// DeleteBuilding implements LocationUseCase.
+func (l *locationUseCase) DeleteBuilding(ctx context.Context, id string) error {
+	ctx, span := startSpan(ctx, "locationUseCase.DeleteBuilding")
+	defer span.End()
+
+	if err := l.repo.DeleteBuilding(ctx, id); err != nil {
+		return deleteError(err, "building", "floors")
+	}
+	return nil
+}
+
+// RegisterFloor implements LocationUseCase.
+func (l *locationUseCase) RegisterFloor(ctx context.Context, payload entity.Floor) (entity.Floor, error) {
+	ctx, span := startSpan(ctx, "locationUseCase.RegisterFloor")
+	defer span.End()
+
+	if missing := missingFields("buildingId", payload.BuildingId, "name", payload.Name); len(missing) > 0 {
+		return entity.Floor{}, apperror.Required(missing...)
+	}
+
+	floor, err := l.repo.CreateFloor(ctx, payload)
+	if err != nil {
+		return entity.Floor{}, dbError(err, "floor")
+	}
+	return floor, nil
+}
+
+// FindFloorByID implements LocationUseCase.
+func (l *locationUseCase) FindFloorByID(ctx context.Context, id string) (entity.Floor, error) {
+	ctx, span := startSpan(ctx, "locationUseCase.FindFloorByID")
+	defer span.End()
+
+	floor, err := l.repo.GetFloor(ctx, id)
+	if err != nil {
+		return entity.Floor{}, dbError(err, "floor")
+	}
+	return floor, nil
+}
+
+// FindAllFloors implements LocationUseCase.
+func (l *locationUseCase) FindAllFloors(ctx context.Context, buildingId string, page, size int) ([]entity.Floor, model.Paging, error) {
+	ctx, span := startSpan(ctx, "locationUseCase.FindAllFloors")
+	defer span.End()
+
+	floors, paging, err := l.repo.ListFloors(ctx, buildingId, page, size)
+	if err != nil {
+		return nil, model.Paging{}, dbError(err, "floor")
+	}
+	return floors, paging, nil
+}
+
+// UpdateFloor implements LocationUseCase.
+func (l *locationUseCase) UpdateFloor(ctx context.Context, payload entity.Floor) (entity.Floor, error) {
+	ctx, span := startSpan(ctx, "locationUseCase.UpdateFloor")
+	defer span.End()
+
+	if missing := missingFields("id", payload.ID, "buildingId", payload.BuildingId, "name", payload.Name); len(missing) > 0 {
+		return entity.Floor{}, apperror.Required(missing...)
+	}
+
+	floor, err := l.repo.UpdateFloor(ctx, payload)
+	if err != nil {
+		return entity.Floor{}, dbError(err, "floor")
+	}
+	return floor, nil
+}
+
+// DeleteFloor implements LocationUseCase.
+func (l *locationUseCase) DeleteFloor(ctx context.Context, id string) error {
+	ctx, span := startSpan(ctx, "locationUseCase.DeleteFloor")
+	defer span.End()
+
+	if err := l.repo.DeleteFloor(ctx, id); err != nil {
+		return deleteError(err, "floor", "rooms")
+	}
+	return nil
+}
+
+func validateSite(payload entity.Site, update bool) error {
+	var problems []apperror.FieldError
+	if update && payload.ID == "" {
+		problems = append(problems, apperror.RequiredField("id"))
+	}
+	for _, field := range missingFields("name", payload.Name) {
+		problems = append(problems, apperror.RequiredField(field))
+	}
+	if _, err := time.LoadLocation(payload.Timezone); err != nil || payload.Timezone == "Local" {
+		problems = append(problems, apperror.Field("timezone", "timezone", "timezone must be an IANA time zone such as Asia/Jakarta"))
+	}
+	return invalid(problems)
+}
+
+// deleteError reports a delete that is refused because other records still
+// refer to the resource, e.g. a site that still has buildings, as a conflict.
+func deleteError(err error, resource, children string) error {
+	var pqErr *pq.Error
+	if errors.As(err, &pqErr) && pqErr.Code == "23503" { // foreign_key_violation
+		return apperror.Conflict(strings.ReplaceAll(resource, " ", "_")+"_in_use", "the "+resource+" still has "+children).Wrap(err)
+	}
+	return dbError(err, resource)
+}
+
+func NewLocationUseCase(repo repository.LocationRepository) LocationUseCase {
+	return &locationUseCase{repo: repo}
+}
@@ -0,0 +1,122 @@
+package usecase
+
+import (
+	"booking-room-app/entity"
+	"booking-room-app/mock/repo_mock"
+	"booking-room-app/shared/apperror"
+	"booking-room-app/shared/model"
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/lib/pq"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type LocationUseCaseTestSuite struct {
+	suite.Suite
+	lrm *repo_mock.LocationRepoMock
+	luc LocationUseCase
+}
+
+func (suite *LocationUseCaseTestSuite) SetupTest() {
+	suite.lrm = new(repo_mock.LocationRepoMock)
+	suite.luc = NewLocationUseCase(suite.lrm)
+}
+
+func (suite *LocationUseCaseTestSuite) TestRegisterSite_Success() {
+	payload := entity.Site{Name: "Jakarta HQ", Address: "Jl. Sudirman 1", Timezone: "Asia/Jakarta"}
+	expected := payload
+	expected.ID = "1"
+	suite.lrm.On("CreateSite", mock.Anything, payload).Return(expected, nil)
+
+	actual, err := suite.luc.RegisterSite(context.Background(), payload)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expected, actual)
+}
+
+func (suite *LocationUseCaseTestSuite) TestRegisterSite_DefaultTimezoneSuccess() {
+	suite.lrm.On("CreateSite", mock.Anything, entity.Site{Name: "Bandung", Timezone: DefaultTimezone}).Return(entity.Site{ID: "1"}, nil)
+
+	_, err := suite.luc.RegisterSite(context.Background(), entity.Site{Name: "Bandung"})
+
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *LocationUseCaseTestSuite) TestRegisterSite_InvalidTimezoneFail() {
+	_, err := suite.luc.RegisterSite(context.Background(), entity.Site{Name: "Bandung", Timezone: "Asia/Bandung"})
+
+	appErr := apperror.From(err)
+	assert.Equal(suite.T(), apperror.KindValidation, appErr.Kind)
+	assert.Equal(suite.T(), "timezone", appErr.Fields[0].Field)
+	suite.lrm.AssertNotCalled(suite.T(), "CreateSite", mock.Anything, mock.Anything)
+}
+
+func (suite *LocationUseCaseTestSuite) TestUpdateSite_MissingIdFail() {
+	_, err := suite.luc.UpdateSite(context.Background(), entity.Site{Name: "Bandung"})
+
+	assert.Equal(suite.T(), apperror.KindValidation, apperror.KindOf(err))
+}
+
+func (suite *LocationUseCaseTestSuite) TestFindSiteByID_NotFoundFail() {
+	suite.lrm.On("GetSite", mock.Anything, "1").Return(entity.Site{}, sql.ErrNoRows)
+
+	_, err := suite.luc.FindSiteByID(context.Background(), "1")
+
+	assert.Equal(suite.T(), apperror.KindNotFound, apperror.KindOf(err))
+}
+
+func (suite *LocationUseCaseTestSuite) TestDeleteSite_InUseFail() {
+	suite.lrm.On("DeleteSite", mock.Anything, "1").Return(&pq.Error{Code: "23503"})
+
+	err := suite.luc.DeleteSite(context.Background(), "1")
+
+	appErr := apperror.From(err)
+	assert.Equal(suite.T(), apperror.KindConflict, appErr.Kind)
+	assert.Equal(suite.T(), "site_in_use", appErr.Code)
+	assert.Equal(suite.T(), "the site still has buildings", appErr.Message)
+}
+
+func (suite *LocationUseCaseTestSuite) TestDeleteFloor_NotFoundFail() {
+	suite.lrm.On("DeleteFloor", mock.Anything, "3").Return(sql.ErrNoRows)
+
+	err := suite.luc.DeleteFloor(context.Background(), "3")
+
+	assert.Equal(suite.T(), apperror.KindNotFound, apperror.KindOf(err))
+}
+
+func (suite *LocationUseCaseTestSuite) TestRegisterBuilding_UnknownSiteFail() {
+	suite.lrm.On("CreateBuilding", mock.Anything, entity.Building{SiteId: "1", Name: "Tower A"}).Return(entity.Building{}, &pq.Error{Code: "23503", Detail: `Key (site_id)=(1) is not present in table "sites".`})
+
+	_, err := suite.luc.RegisterBuilding(context.Background(), entity.Building{SiteId: "1", Name: "Tower A"})
+
+	appErr := apperror.From(err)
+	assert.Equal(suite.T(), apperror.KindValidation, appErr.Kind)
+	assert.Equal(suite.T(), "siteId", appErr.Fields[0].Field)
+}
+
+func (suite *LocationUseCaseTestSuite) TestRegisterFloor_MissingFieldsFail() {
+	_, err := suite.luc.RegisterFloor(context.Background(), entity.Floor{Level: 2})
+
+	assert.Equal(suite.T(), apperror.KindValidation, apperror.KindOf(err))
+	suite.lrm.AssertNotCalled(suite.T(), "CreateFloor", mock.Anything, mock.Anything)
+}
+
+func (suite *LocationUseCaseTestSuite) TestFindAllFloors_Success() {
+	floors := []entity.Floor{{ID: "3", BuildingId: "2", Name: "Ground floor"}}
+	paging := model.Paging{Page: 1, RowsPerPage: 5, TotalRows: 1, TotalPages: 1}
+	suite.lrm.On("ListFloors", mock.Anything, "2", 1, 5).Return(floors, paging, nil)
+
+	actual, actualPaging, err := suite.luc.FindAllFloors(context.Background(), "2", 1, 5)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), floors, actual)
+	assert.Equal(suite.T(), paging, actualPaging)
+}
+
+func TestLocationUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(LocationUseCaseTestSuite))
+}
@@ -0,0 +1,165 @@
+package usecase
+
+import (
+	"booking-room-app/entity"
+	"booking-room-app/repository"
+	"booking-room-app/shared/apperror"
+	"booking-room-app/shared/model"
+	"context"
+	"log/slog"
+	"time"
+)
+
+type MaintenanceUseCase interface {
+	RegisterMaintenance(ctx context.Context, payload entity.Maintenance) (entity.Maintenance, error)
+	FindMaintenanceByID(ctx context.Context, id string) (entity.Maintenance, error)
+	FindAllMaintenance(ctx context.Context, filter entity.MaintenanceFilter, page, size int) ([]entity.Maintenance, model.Paging, error)
+	UpdateMaintenance(ctx context.Context, payload entity.Maintenance) (entity.Maintenance, error)
+	DeleteMaintenance(ctx context.Context, id string) error
+	FindConflicts(ctx context.Context) ([]entity.MaintenanceConflict, error)
+	SyncRoomStatus(ctx context.Context, now time.Time) error
+}
+
+type maintenanceUseCase struct {
+	repo repository.MaintenanceRepository
+}
+
+// RegisterMaintenance implements MaintenanceUseCase. The window is returned
+// with the accepted bookings it conflicts with, so they can be relocated.
+func (m *maintenanceUseCase) RegisterMaintenance(ctx context.Context, payload entity.Maintenance) (entity.Maintenance, error) {
+	ctx, span := startSpan(ctx, "maintenanceUseCase.RegisterMaintenance")
+	defer span.End()
+
+	if err := validateMaintenance(payload, false); err != nil {
+		return entity.Maintenance{}, err
+	}
+
+	maintenance, err := m.repo.Create(ctx, payload)
+	if err != nil {
+		return entity.Maintenance{}, dbError(err, "maintenance")
+	}
+	return m.withConflicts(ctx, maintenance)
+}
+
+// FindMaintenanceByID implements MaintenanceUseCase.
+func (m *maintenanceUseCase) FindMaintenanceByID(ctx context.Context, id string) (entity.Maintenance, error) {
+	ctx, span := startSpan(ctx, "maintenanceUseCase.FindMaintenanceByID")
+	defer span.End()
+
+	maintenance, err := m.repo.Get(ctx, id)
+	if err != nil {
+		return entity.Maintenance{}, dbError(err, "maintenance")
+	}
+	return m.withConflicts(ctx, maintenance)
+}
+
+// FindAllMaintenance implements MaintenanceUseCase.
+func (m *maintenanceUseCase) FindAllMaintenance(ctx context.Context, filter entity.MaintenanceFilter, page, size int) ([]entity.Maintenance, model.Paging, error) {
+	ctx, span := startSpan(ctx, "maintenanceUseCase.FindAllMaintenance")
+	defer span.End()
+
+	windows, paging, err := m.repo.List(ctx, filter, page, size)
+	if err != nil {
+		return nil, model.Paging{}, dbError(err, "maintenance")
+	}
+	return windows, paging, nil
+}
+
+// UpdateMaintenance implements MaintenanceUseCase. A window that has ended
+// can no longer be changed.
+func (m *maintenanceUseCase) UpdateMaintenance(ctx context.Context, payload entity.Maintenance) (entity.Maintenance, error) {
+	ctx, span := startSpan(ctx, "maintenanceUseCase.UpdateMaintenance")
+	defer span.End()
+
+	if err := validateMaintenance(payload, true); err != nil {
+		return entity.Maintenance{}, err
+	}
+
+	current, err := m.repo.Get(ctx, payload.ID)
+	if err != nil {
+		return entity.Maintenance{}, dbError(err, "maintenance")
+	}
+	if current.CompletedAt != nil {
+		return entity.Maintenance{}, ErrMaintenanceCompleted
+	}
+
+	maintenance, err := m.repo.Update(ctx, payload)
+	if err != nil {
+		return entity.Maintenance{}, dbError(err, "maintenance")
+	}
+	return m.withConflicts(ctx, maintenance)
+}
+
+// DeleteMaintenance implements MaintenanceUseCase.
+func (m *maintenanceUseCase) DeleteMaintenance(ctx context.Context, id string) error {
+	ctx, span := startSpan(ctx, "maintenanceUseCase.DeleteMaintenance")
+	defer span.End()
+
+	if err := m.repo.Delete(ctx, id); err != nil {
+		return dbError(err, "maintenance")
+	}
+	return nil
+}
+
+// FindConflicts implements MaintenanceUseCase. It lists the accepted bookings
+// that have not ended and overlap any maintenance window.
+func (m *maintenanceUseCase) FindConflicts(ctx context.Context) ([]entity.MaintenanceConflict, error) {
+	ctx, span := startSpan(ctx, "maintenanceUseCase.FindConflicts")
+	defer span.End()
+
+	conflicts, err := m.repo.ListConflicts(ctx, "", time.Now())
+	if err != nil {
+		return nil, dbError(err, "maintenance")
+	}
+	return conflicts, nil
+}
+
+// SyncRoomStatus implements MaintenanceUseCase. Rooms are taken out of
+// service when a window begins and return to service when it ends.
+func (m *maintenanceUseCase) SyncRoomStatus(ctx context.Context, now time.Time) error {
+	ctx, span := startSpan(ctx, "maintenanceUseCase.SyncRoomStatus")
+	defer span.End()
+
+	started, completed, err := m.repo.SyncRoomStatus(ctx, now)
+	if err != nil {
+		return dbError(err, "maintenance")
+	}
+	if started > 0 || completed > 0 {
+		slog.InfoContext(ctx, "maintenance windows synced", "started", started, "completed", completed)
+	}
+	return nil
+}
+
+func (m *maintenanceUseCase) withConflicts(ctx context.Context, maintenance entity.Maintenance) (entity.Maintenance, error) {
+	conflicts, err := m.repo.ListConflicts(ctx, maintenance.ID, time.Now())
+	if err != nil {
+		return entity.Maintenance{}, dbError(err, "maintenance")
+	}
+	for _, conflict := range conflicts {
+		maintenance.Conflicts = append(maintenance.Conflicts, conflict.Transaction)
+	}
+	return maintenance, nil
+}
+
+func validateMaintenance(payload entity.Maintenance, update bool) error {
+	var problems []apperror.FieldError
+	if update && payload.ID == "" {
+		problems = append(problems, apperror.RequiredField("id"))
+	}
+	if !update && payload.RoomId == "" {
+		problems = append(problems, apperror.RequiredField("roomId"))
+	}
+	for _, field := range missingFields("assigneeId", payload.AssigneeId, "reason", payload.Reason) {
+		problems = append(problems, apperror.RequiredField(field))
+	}
+	if !payload.EndTime.After(payload.StartTime) {
+		problems = append(problems, apperror.Field("endTime", "gtfield", "endTime must be after startTime"))
+	} else if !payload.EndTime.After(time.Now()) {
+		problems = append(problems, apperror.Field("endTime", "future", "endTime must be in the future"))
+	}
+	return invalid(problems)
+}
+
+func NewMaintenanceUseCase(repo repository.MaintenanceRepository) MaintenanceUseCase {
+	return &maintenanceUseCase{repo: repo}
+}
@@ -0,0 +1,129 @@
+package usecase
+
+import (
+	"booking-room-app/entity"
+	"booking-room-app/mock/repo_mock"
+	"booking-room-app/shared/apperror"
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type MaintenanceUseCaseTestSuite struct {
+	suite.Suite
+	mrm *repo_mock.MaintenanceRepoMock
+	muc MaintenanceUseCase
+}
+
+func (suite *MaintenanceUseCaseTestSuite) SetupTest() {
+	suite.mrm = new(repo_mock.MaintenanceRepoMock)
+	suite.muc = NewMaintenanceUseCase(suite.mrm)
+}
+
+func (suite *MaintenanceUseCaseTestSuite) window() entity.Maintenance {
+	start := time.Now().Add(24 * time.Hour).Truncate(time.Hour)
+	return entity.Maintenance{RoomId: "9", AssigneeId: "2", Reason: "Replace projector", StartTime: start, EndTime: start.Add(4 * time.Hour)}
+}
+
+func (suite *MaintenanceUseCaseTestSuite) TestRegisterMaintenance_FlagsConflictsSuccess() {
+	payload := suite.window()
+	created := payload
+	created.ID = "1"
+	booking := entity.Transaction{ID: "t1", RoomId: "9", Status: "accepted", StartTime: payload.StartTime, EndTime: payload.StartTime.Add(time.Hour)}
+	suite.mrm.On("Create", mock.Anything, payload).Return(created, nil)
+	suite.mrm.On("ListConflicts", mock.Anything, "1", mock.Anything).Return([]entity.MaintenanceConflict{{MaintenanceId: "1", Reason: payload.Reason, Transaction: booking}}, nil)
+
+	actual, err := suite.muc.RegisterMaintenance(context.Background(), payload)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "1", actual.ID)
+	assert.Equal(suite.T(), []entity.Transaction{booking}, actual.Conflicts)
+}
+
+func (suite *MaintenanceUseCaseTestSuite) TestRegisterMaintenance_InvalidFail() {
+	payload := suite.window()
+	payload.AssigneeId = ""
+	payload.EndTime = payload.StartTime
+
+	_, err := suite.muc.RegisterMaintenance(context.Background(), payload)
+
+	appErr := apperror.From(err)
+	assert.Equal(suite.T(), apperror.KindValidation, appErr.Kind)
+	assert.Len(suite.T(), appErr.Fields, 2)
+	suite.mrm.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *MaintenanceUseCaseTestSuite) TestRegisterMaintenance_EndedFail() {
+	payload := suite.window()
+	payload.StartTime = time.Now().Add(-2 * time.Hour)
+	payload.EndTime = time.Now().Add(-time.Hour)
+
+	_, err := suite.muc.RegisterMaintenance(context.Background(), payload)
+
+	assert.Equal(suite.T(), "future", apperror.From(err).Fields[0].Code)
+}
+
+func (suite *MaintenanceUseCaseTestSuite) TestUpdateMaintenance_CompletedFail() {
+	payload := suite.window()
+	payload.ID = "1"
+	completed := time.Now().Add(-time.Hour)
+	suite.mrm.On("Get", mock.Anything, "1").Return(entity.Maintenance{ID: "1", CompletedAt: &completed}, nil)
+
+	_, err := suite.muc.UpdateMaintenance(context.Background(), payload)
+
+	assert.ErrorIs(suite.T(), err, ErrMaintenanceCompleted)
+	suite.mrm.AssertNotCalled(suite.T(), "Update", mock.Anything, mock.Anything)
+}
+
+func (suite *MaintenanceUseCaseTestSuite) TestUpdateMaintenance_Success() {
+	payload := suite.window()
+	payload.ID = "1"
+	payload.RoomId = ""
+	updated := payload
+	updated.RoomId = "9"
+	suite.mrm.On("Get", mock.Anything, "1").Return(entity.Maintenance{ID: "1", RoomId: "9"}, nil)
+	suite.mrm.On("Update", mock.Anything, payload).Return(updated, nil)
+	suite.mrm.On("ListConflicts", mock.Anything, "1", mock.Anything).Return([]entity.MaintenanceConflict{}, nil)
+
+	actual, err := suite.muc.UpdateMaintenance(context.Background(), payload)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "9", actual.RoomId)
+	assert.Empty(suite.T(), actual.Conflicts)
+}
+
+func (suite *MaintenanceUseCaseTestSuite) TestDeleteMaintenance_NotFoundFail() {
+	suite.mrm.On("Delete", mock.Anything, "1").Return(sql.ErrNoRows)
+
+	err := suite.muc.DeleteMaintenance(context.Background(), "1")
+
+	assert.Equal(suite.T(), apperror.KindNotFound, apperror.KindOf(err))
+}
+
+func (suite *MaintenanceUseCaseTestSuite) TestSyncRoomStatus_Success() {
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	suite.mrm.On("SyncRoomStatus", mock.Anything, now).Return(int64(1), int64(2), nil)
+
+	err := suite.muc.SyncRoomStatus(context.Background(), now)
+
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *MaintenanceUseCaseTestSuite) TestSyncRoomStatus_Fail() {
+	now := time.Date(2024, time.March, 1, 12, 0, 0, 0, time.UTC)
+	suite.mrm.On("SyncRoomStatus", mock.Anything, now).Return(int64(0), int64(0), errors.New("error"))
+
+	err := suite.muc.SyncRoomStatus(context.Background(), now)
+
+	assert.Error(suite.T(), err)
+}
+
+func TestMaintenanceUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(MaintenanceUseCaseTestSuite))
+}
@@ -0,0 +1,142 @@
+package usecase
+
+import (
+	"booking-room-app/entity"
+	"booking-room-app/repository"
+	"booking-room-app/shared/apperror"
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+)
+
+type RateUseCase interface {
+	RegisterRoomRate(ctx context.Context, payload entity.RoomRate) (entity.RoomRate, error)
+	FindRoomRates(ctx context.Context, roomId string) ([]entity.RoomRate, error)
+	RegisterFacilityRate(ctx context.Context, payload entity.FacilityRate) (entity.FacilityRate, error)
+	FindFacilityRates(ctx context.Context, facilityId string) ([]entity.FacilityRate, error)
+	PriceTransaction(ctx context.Context, transaction entity.Transaction) (entity.TransactionCost, error)
+}
+
+type rateUseCase struct {
+	repo repository.RateRepository
+}
+
+// RegisterRoomRate implements RateUseCase.
+func (r *rateUseCase) RegisterRoomRate(ctx context.Context, payload entity.RoomRate) (entity.RoomRate, error) {
+	ctx, span := startSpan(ctx, "rateUseCase.RegisterRoomRate")
+	defer span.End()
+
+	if payload.RoomId == "" {
+		return entity.RoomRate{}, apperror.Required("roomId")
+	}
+	if payload.HourlyRate < 0 {
+		return entity.RoomRate{}, apperror.Validation("hourly rate must not be negative", apperror.Field("hourlyRate", "min", "hourlyRate must not be negative"))
+	}
+	if payload.EffectiveFrom.IsZero() {
+		payload.EffectiveFrom = time.Now()
+	}
+
+	rate, err := r.repo.CreateRoomRate(ctx, payload)
+	if err != nil {
+		return entity.RoomRate{}, dbError(err, "room rate")
+	}
+	return rate, nil
+}
+
+// FindRoomRates implements RateUseCase.
+func (r *rateUseCase) FindRoomRates(ctx context.Context, roomId string) ([]entity.RoomRate, error) {
+	ctx, span := startSpan(ctx, "rateUseCase.FindRoomRates")
+	defer span.End()
+
+	rates, err := r.repo.ListRoomRates(ctx, roomId)
+	if err != nil {
+		return nil, dbError(err, "room rate")
+	}
+	return rates, nil
+}
+
+// RegisterFacilityRate implements RateUseCase.
+func (r *rateUseCase) RegisterFacilityRate(ctx context.Context, payload entity.FacilityRate) (entity.FacilityRate, error) {
+	ctx, span := startSpan(ctx, "rateUseCase.RegisterFacilityRate")
+	defer span.End()
+
+	if payload.FacilityId == "" {
+		return entity.FacilityRate{}, apperror.Required("facilityId")
+	}
+	if payload.UnitRate < 0 {
+		return entity.FacilityRate{}, apperror.Validation("unit rate must not be negative", apperror.Field("unitRate", "min", "unitRate must not be negative"))
+	}
+	if payload.EffectiveFrom.IsZero() {
+		payload.EffectiveFrom = time.Now()
+	}
+
+	rate, err := r.repo.CreateFacilityRate(ctx, payload)
+	if err != nil {
+		return entity.FacilityRate{}, dbError(err, "facility rate")
+	}
+	return rate, nil
+}
+
+// FindFacilityRates implements RateUseCase.
+func (r *rateUseCase) FindFacilityRates(ctx context.Context, facilityId string) ([]entity.FacilityRate, error) {
+	ctx, span := startSpan(ctx, "rateUseCase.FindFacilityRates")
+	defer span.End()
+
+	rates, err := r.repo.ListFacilityRates(ctx, facilityId)
+	if err != nil {
+		return nil, dbError(err, "facility rate")
+	}
+	return rates, nil
+}
+
+// PriceTransaction prices the transaction with the rates in effect at its
+// start time. The cost is stored with the transaction when it is accepted, so
+// later rate changes do not alter it. Rooms and facilities without a rate are
+// charged nothing.
+func (r *rateUseCase) PriceTransaction(ctx context.Context, transaction entity.Transaction) (entity.TransactionCost, error) {
+	ctx, span := startSpan(ctx, "rateUseCase.PriceTransaction")
+	defer span.End()
+
+	roomRate, err := r.repo.GetRoomRateAt(ctx, transaction.RoomId, transaction.StartTime)
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return entity.TransactionCost{}, dbError(err, "room rate")
+	}
+
+	var facilityIds []string
+	for _, facility := range transaction.Facilities {
+		facilityIds = append(facilityIds, facility.FacilityId)
+	}
+	facilityRates, err := r.repo.GetFacilityRatesAt(ctx, facilityIds, transaction.StartTime)
+	if err != nil {
+		return entity.TransactionCost{}, dbError(err, "facility rate")
+	}
+
+	cost := entity.TransactionCost{
+		TransactionId: transaction.ID,
+		HourlyRate:    roomRate.HourlyRate,
+		RoomCost:      roomCost(roomRate.HourlyRate, transaction.StartTime, transaction.EndTime),
+	}
+	for _, facility := range transaction.Facilities {
+		cost.FacilityCost += facilityRates[facility.FacilityId] * int64(facility.Quantity)
+	}
+	cost.TotalCost = cost.RoomCost + cost.FacilityCost
+	return cost, nil
+}
+
+// roomCost charges the hourly rate per started minute, rounded to the
+// nearest currency unit.
+func roomCost(hourlyRate int64, startTime, endTime time.Time) int64 {
+	minutes := int64(endTime.Sub(startTime) / time.Minute)
+	if endTime.Sub(startTime)%time.Minute > 0 {
+		minutes++
+	}
+	if minutes <= 0 {
+		return 0
+	}
+	return (hourlyRate*minutes + 30) / 60
+}
+
+func NewRateUseCase(repo repository.RateRepository) RateUseCase {
+	return &rateUseCase{repo: repo}
+}
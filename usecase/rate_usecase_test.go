@@ -0,0 +1,120 @@
+package usecase
+
+import (
+	"booking-room-app/entity"
+	"booking-room-app/mock/repo_mock"
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+type RateUseCaseTestSuite struct {
+	suite.Suite
+	rrm *repo_mock.RateRepoMock
+	ruc RateUseCase
+}
+
+func (suite *RateUseCaseTestSuite) SetupTest() {
+	suite.rrm = new(repo_mock.RateRepoMock)
+	suite.ruc = NewRateUseCase(suite.rrm)
+}
+
+func (suite *RateUseCaseTestSuite) TestRegisterRoomRate_Success() {
+	payload := entity.RoomRate{RoomId: "1", HourlyRate: 150000, EffectiveFrom: time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)}
+	expected := payload
+	expected.ID = "1"
+	suite.rrm.On("CreateRoomRate", mock.Anything, payload).Return(expected, nil)
+
+	actual, err := suite.ruc.RegisterRoomRate(context.Background(), payload)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expected, actual)
+}
+
+func (suite *RateUseCaseTestSuite) TestRegisterRoomRate_NegativeRateFailure() {
+	_, err := suite.ruc.RegisterRoomRate(context.Background(), entity.RoomRate{RoomId: "1", HourlyRate: -1})
+
+	assert.Error(suite.T(), err)
+	suite.rrm.AssertNotCalled(suite.T(), "CreateRoomRate", mock.Anything)
+}
+
+func (suite *RateUseCaseTestSuite) TestRegisterFacilityRate_DefaultEffectiveFromSuccess() {
+	suite.rrm.On("CreateFacilityRate", mock.Anything, mock.MatchedBy(func(rate entity.FacilityRate) bool {
+		return !rate.EffectiveFrom.IsZero()
+	})).Return(entity.FacilityRate{ID: "1"}, nil)
+
+	_, err := suite.ruc.RegisterFacilityRate(context.Background(), entity.FacilityRate{FacilityId: "1", UnitRate: 25000})
+
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *RateUseCaseTestSuite) TestRegisterFacilityRate_Failure() {
+	suite.rrm.On("CreateFacilityRate", mock.Anything, mock.Anything).Return(entity.FacilityRate{}, fmt.Errorf("error"))
+
+	_, err := suite.ruc.RegisterFacilityRate(context.Background(), entity.FacilityRate{FacilityId: "1", UnitRate: 25000})
+
+	assert.Error(suite.T(), err)
+}
+
+func (suite *RateUseCaseTestSuite) TestPriceTransaction_Success() {
+	startTime := time.Date(2024, time.January, 8, 9, 0, 0, 0, time.UTC)
+	transaction := entity.Transaction{
+		ID:        "1",
+		RoomId:    "1",
+		StartTime: startTime,
+		EndTime:   startTime.Add(90 * time.Minute),
+		Facilities: []entity.TransactionFacility{
+			{FacilityId: "1", Quantity: 2},
+			{FacilityId: "2", Quantity: 1},
+		},
+	}
+	expected := entity.TransactionCost{TransactionId: "1", HourlyRate: 100000, RoomCost: 150000, FacilityCost: 50000, TotalCost: 200000}
+	suite.rrm.On("GetRoomRateAt", mock.Anything, "1", startTime).Return(entity.RoomRate{HourlyRate: 100000}, nil)
+	suite.rrm.On("GetFacilityRatesAt", mock.Anything, []string{"1", "2"}, startTime).Return(map[string]int64{"1": 25000}, nil)
+
+	actual, err := suite.ruc.PriceTransaction(context.Background(), transaction)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expected, actual)
+}
+
+func (suite *RateUseCaseTestSuite) TestPriceTransaction_NoRateSuccess() {
+	startTime := time.Date(2024, time.January, 8, 9, 0, 0, 0, time.UTC)
+	transaction := entity.Transaction{ID: "1", RoomId: "1", StartTime: startTime, EndTime: startTime.Add(time.Hour)}
+	expected := entity.TransactionCost{TransactionId: "1"}
+	suite.rrm.On("GetRoomRateAt", mock.Anything, "1", startTime).Return(entity.RoomRate{}, sql.ErrNoRows)
+	suite.rrm.On("GetFacilityRatesAt", mock.Anything, []string(nil), startTime).Return(map[string]int64{}, nil)
+
+	actual, err := suite.ruc.PriceTransaction(context.Background(), transaction)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expected, actual)
+}
+
+func (suite *RateUseCaseTestSuite) TestPriceTransaction_RoomRateFailure() {
+	suite.rrm.On("GetRoomRateAt", mock.Anything, "1", mock.Anything).Return(entity.RoomRate{}, fmt.Errorf("error"))
+
+	_, err := suite.ruc.PriceTransaction(context.Background(), entity.Transaction{ID: "1", RoomId: "1"})
+
+	assert.Error(suite.T(), err)
+}
+
+func TestRoomCost(t *testing.T) {
+	start := time.Date(2024, time.January, 8, 9, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, int64(100000), roomCost(100000, start, start.Add(time.Hour)))
+	assert.Equal(t, int64(25000), roomCost(100000, start, start.Add(15*time.Minute)))
+	assert.Equal(t, int64(1667), roomCost(100000, start, start.Add(time.Minute)))
+	assert.Equal(t, int64(3333), roomCost(100000, start, start.Add(90*time.Second)))
+	assert.Equal(t, int64(0), roomCost(100000, start, start))
+}
+
+func TestRateUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(RateUseCaseTestSuite))
+}
@@ -0,0 +1,217 @@
+package usecase
+
+import (
+	"booking-room-app/entity"
+	"booking-room-app/repository"
+	"booking-room-app/shared/apperror"
+	"booking-room-app/shared/cron"
+	"booking-room-app/shared/model"
+	"booking-room-app/shared/service"
+	"context"
+	"fmt"
+	"log/slog"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+type ReportScheduleUseCase interface {
+	RegisterNewSchedule(ctx context.Context, payload entity.ReportSchedule) (entity.ReportSchedule, error)
+	FindScheduleByID(ctx context.Context, id string) (entity.ReportSchedule, error)
+	FindAllSchedules(ctx context.Context, page, size int) ([]entity.ReportSchedule, model.Paging, error)
+	UpdateSchedule(ctx context.Context, payload entity.ReportSchedule) (entity.ReportSchedule, error)
+	DeleteSchedule(ctx context.Context, id string) error
+	RunDueSchedules(ctx context.Context, now time.Time) error
+}
+
+type reportScheduleUseCase struct {
+	repo        repository.ReportScheduleRepository
+	reportUC    ReportUseCase
+	mailService service.MailService
+}
+
+// RegisterNewSchedule implements ReportScheduleUseCase.
+func (r *reportScheduleUseCase) RegisterNewSchedule(ctx context.Context, payload entity.ReportSchedule) (entity.ReportSchedule, error) {
+	ctx, span := startSpan(ctx, "reportScheduleUseCase.RegisterNewSchedule")
+	defer span.End()
+
+	if err := validateReportSchedule(&payload); err != nil {
+		return entity.ReportSchedule{}, err
+	}
+	payload.IsActive = true
+
+	schedule, err := r.repo.Create(ctx, payload)
+	if err != nil {
+		return entity.ReportSchedule{}, dbError(err, "report schedule")
+	}
+	return schedule, nil
+}
+
+// FindScheduleByID implements ReportScheduleUseCase.
+func (r *reportScheduleUseCase) FindScheduleByID(ctx context.Context, id string) (entity.ReportSchedule, error) {
+	ctx, span := startSpan(ctx, "reportScheduleUseCase.FindScheduleByID")
+	defer span.End()
+
+	schedule, err := r.repo.Get(ctx, id)
+	if err != nil {
+		return entity.ReportSchedule{}, dbError(err, "report schedule")
+	}
+	return schedule, nil
+}
+
+// FindAllSchedules implements ReportScheduleUseCase.
+func (r *reportScheduleUseCase) FindAllSchedules(ctx context.Context, page, size int) ([]entity.ReportSchedule, model.Paging, error) {
+	ctx, span := startSpan(ctx, "reportScheduleUseCase.FindAllSchedules")
+	defer span.End()
+
+	schedules, paging, err := r.repo.List(ctx, page, size)
+	if err != nil {
+		return nil, model.Paging{}, dbError(err, "report schedule")
+	}
+	return schedules, paging, nil
+}
+
+// UpdateSchedule implements ReportScheduleUseCase.
+func (r *reportScheduleUseCase) UpdateSchedule(ctx context.Context, payload entity.ReportSchedule) (entity.ReportSchedule, error) {
+	ctx, span := startSpan(ctx, "reportScheduleUseCase.UpdateSchedule")
+	defer span.End()
+
+	if payload.ID == "" {
+		return entity.ReportSchedule{}, apperror.Required("id")
+	}
+	if err := validateReportSchedule(&payload); err != nil {
+		return entity.ReportSchedule{}, err
+	}
+
+	schedule, err := r.repo.Update(ctx, payload)
+	if err != nil {
+		return entity.ReportSchedule{}, dbError(err, "report schedule")
+	}
+	return schedule, nil
+}
+
+// DeleteSchedule implements ReportScheduleUseCase.
+func (r *reportScheduleUseCase) DeleteSchedule(ctx context.Context, id string) error {
+	ctx, span := startSpan(ctx, "reportScheduleUseCase.DeleteSchedule")
+	defer span.End()
+
+	if err := r.repo.Delete(ctx, id); err != nil {
+		return dbError(err, "report schedule")
+	}
+	return nil
+}
+
+// RunDueSchedules sends every active schedule whose cron expression fires at now.
+// A run is claimed before it is sent, so a schedule is delivered once per minute
+// however many instances run it; a run that fails after the claim is not retried.
+// A failing schedule is logged and does not stop the others from being delivered.
+func (r *reportScheduleUseCase) RunDueSchedules(ctx context.Context, now time.Time) error {
+	ctx, span := startSpan(ctx, "reportScheduleUseCase.RunDueSchedules")
+	defer span.End()
+
+	now = now.Truncate(time.Minute)
+
+	schedules, err := r.repo.ListActive(ctx)
+	if err != nil {
+		return fmt.Errorf("oops, failed to get report schedules: %v", err.Error())
+	}
+
+	var failed []string
+	for _, schedule := range schedules {
+		cronSchedule, err := cron.Parse(schedule.CronExpression)
+		if err != nil {
+			slog.ErrorContext(ctx, "reportScheduleUseCase.RunDueSchedules", "scheduleId", schedule.ID, "err", err)
+			failed = append(failed, schedule.ID)
+			continue
+		}
+		if !cronSchedule.Match(now) {
+			continue
+		}
+
+		// already delivered in this minute, e.g. by another instance or before a restart
+		claimed, err := r.repo.ClaimRun(ctx, schedule.ID, now)
+		if err != nil {
+			slog.ErrorContext(ctx, "reportScheduleUseCase.RunDueSchedules", "scheduleId", schedule.ID, "err", err)
+			failed = append(failed, schedule.ID)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		if err := r.deliver(ctx, schedule, now); err != nil {
+			slog.ErrorContext(ctx, "reportScheduleUseCase.RunDueSchedules", "scheduleId", schedule.ID, "err", err)
+			failed = append(failed, schedule.ID)
+		}
+	}
+
+	if len(failed) > 0 {
+		return fmt.Errorf("oops, failed to run report schedules: %s", strings.Join(failed, ", "))
+	}
+	return nil
+}
+
+func (r *reportScheduleUseCase) deliver(ctx context.Context, schedule entity.ReportSchedule, now time.Time) error {
+	content, err := r.reportUC.ExportReports(ctx, schedule.Range, schedule.Filter, schedule.Format)
+	if err != nil {
+		return err
+	}
+
+	contentType := "text/csv"
+	if schedule.Format == ReportFormatJSON {
+		contentType = "application/json"
+	}
+
+	return r.mailService.Send(ctx, model.MailMessage{
+		To:      schedule.Recipients,
+		Subject: fmt.Sprintf("[Reservify] %s", schedule.Name),
+		Body:    fmt.Sprintf("Booking report for the last %s, generated at %s.", schedule.Range, now.Format("02-01-2006 15:04")),
+		Attachments: []model.MailAttachment{
+			{
+				Filename:    fmt.Sprintf("transaction-%s.%s", now.Format("20060102"), schedule.Format),
+				ContentType: contentType,
+				Content:     content,
+			},
+		},
+	})
+}
+
+func validateReportSchedule(payload *entity.ReportSchedule) error {
+	var problems []apperror.FieldError
+	for _, field := range missingFields("name", payload.Name, "cronExpression", payload.CronExpression, "range", payload.Range) {
+		problems = append(problems, apperror.RequiredField(field))
+	}
+	if len(payload.Recipients) == 0 {
+		problems = append(problems, apperror.RequiredField("recipients"))
+	}
+
+	if payload.CronExpression != "" {
+		if _, err := cron.Parse(payload.CronExpression); err != nil {
+			problems = append(problems, apperror.Field("cronExpression", "cron", err.Error()))
+		}
+	}
+
+	payload.Range = strings.ToLower(payload.Range)
+	if payload.Range != "" && payload.Range != "day" && payload.Range != "week" && payload.Range != "month" && payload.Range != "year" {
+		problems = append(problems, apperror.Field("range", "oneof", "range must be day, week, month or year"))
+	}
+
+	payload.Format = strings.ToLower(payload.Format)
+	if payload.Format == "" {
+		payload.Format = ReportFormatCSV
+	}
+	if payload.Format != ReportFormatCSV && payload.Format != ReportFormatJSON {
+		problems = append(problems, apperror.Field("format", "oneof", "format must be csv or json"))
+	}
+
+	for _, recipient := range payload.Recipients {
+		if _, err := mail.ParseAddress(recipient); err != nil {
+			problems = append(problems, apperror.Field("recipients", "email", fmt.Sprintf("%s is not a valid email address", recipient)))
+		}
+	}
+	return invalid(problems)
+}
+
+func NewReportScheduleUseCase(repo repository.ReportScheduleRepository, reportUC ReportUseCase, mailService service.MailService) ReportScheduleUseCase {
+	return &reportScheduleUseCase{repo: repo, reportUC: reportUC, mailService: mailService}
+}
@@ -0,0 +1,196 @@
+package usecase
+
+import (
+	"booking-room-app/entity"
+	"booking-room-app/mock/repo_mock"
+	"booking-room-app/mock/service_mock"
+	"booking-room-app/mock/usecase_mock"
+	"booking-room-app/shared/model"
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+var expectedReportSchedule = entity.ReportSchedule{
+	ID:             "1",
+	Name:           "Weekly booking report",
+	CronExpression: "0 8 * * 1",
+	Range:          "week",
+	Filter:         entity.ReportFilter{Status: "accepted"},
+	Format:         "csv",
+	Recipients:     []string{"manager@example.com"},
+	IsActive:       true,
+}
+
+type ReportScheduleUseCaseTestSuite struct {
+	suite.Suite
+	rsrm *repo_mock.ReportScheduleRepoMock
+	rum  *usecase_mock.ReportUseCaseMock
+	msm  *service_mock.MailServiceMock
+	rsuc ReportScheduleUseCase
+}
+
+func (suite *ReportScheduleUseCaseTestSuite) SetupTest() {
+	suite.rsrm = new(repo_mock.ReportScheduleRepoMock)
+	suite.rum = new(usecase_mock.ReportUseCaseMock)
+	suite.msm = new(service_mock.MailServiceMock)
+	suite.rsuc = NewReportScheduleUseCase(suite.rsrm, suite.rum, suite.msm)
+}
+
+func (suite *ReportScheduleUseCaseTestSuite) TestRegisterNewSchedule_Success() {
+	suite.rsrm.On("Create", mock.Anything, expectedReportSchedule).Return(expectedReportSchedule, nil)
+
+	payload := expectedReportSchedule
+	payload.Range = "WEEK"
+	payload.IsActive = false
+	actual, err := suite.rsuc.RegisterNewSchedule(context.Background(), payload)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expectedReportSchedule.ID, actual.ID)
+}
+
+func (suite *ReportScheduleUseCaseTestSuite) TestRegisterNewSchedule_DefaultFormatSuccess() {
+	suite.rsrm.On("Create", mock.Anything, expectedReportSchedule).Return(expectedReportSchedule, nil)
+
+	payload := expectedReportSchedule
+	payload.Format = ""
+	_, err := suite.rsuc.RegisterNewSchedule(context.Background(), payload)
+
+	assert.NoError(suite.T(), err)
+}
+
+func (suite *ReportScheduleUseCaseTestSuite) TestRegisterNewSchedule_InvalidPayloadFailure() {
+	invalid := []func(s *entity.ReportSchedule){
+		func(s *entity.ReportSchedule) { s.Name = "" },
+		func(s *entity.ReportSchedule) { s.Recipients = nil },
+		func(s *entity.ReportSchedule) { s.CronExpression = "every monday" },
+		func(s *entity.ReportSchedule) { s.Range = "decade" },
+		func(s *entity.ReportSchedule) { s.Format = "pdf" },
+		func(s *entity.ReportSchedule) { s.Recipients = []string{"not-an-email"} },
+	}
+	for _, modify := range invalid {
+		payload := expectedReportSchedule
+		modify(&payload)
+		_, err := suite.rsuc.RegisterNewSchedule(context.Background(), payload)
+		assert.Error(suite.T(), err)
+	}
+	suite.rsrm.AssertNotCalled(suite.T(), "Create", mock.Anything)
+}
+
+func (suite *ReportScheduleUseCaseTestSuite) TestRegisterNewSchedule_Failure() {
+	suite.rsrm.On("Create", mock.Anything, expectedReportSchedule).Return(entity.ReportSchedule{}, fmt.Errorf("error"))
+
+	_, err := suite.rsuc.RegisterNewSchedule(context.Background(), expectedReportSchedule)
+
+	assert.Error(suite.T(), err)
+}
+
+func (suite *ReportScheduleUseCaseTestSuite) TestFindAllSchedules_Success() {
+	suite.rsrm.On("List", mock.Anything, 1, 5).Return([]entity.ReportSchedule{expectedReportSchedule}, model.Paging{Page: 1, RowsPerPage: 5, TotalRows: 1, TotalPages: 1}, nil)
+
+	actual, paging, err := suite.rsuc.FindAllSchedules(context.Background(), 1, 5)
+
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), actual, 1)
+	assert.Equal(suite.T(), 1, paging.TotalRows)
+}
+
+func (suite *ReportScheduleUseCaseTestSuite) TestUpdateSchedule_EmptyIDFailure() {
+	payload := expectedReportSchedule
+	payload.ID = ""
+	_, err := suite.rsuc.UpdateSchedule(context.Background(), payload)
+
+	assert.Error(suite.T(), err)
+}
+
+func (suite *ReportScheduleUseCaseTestSuite) TestUpdateSchedule_Success() {
+	suite.rsrm.On("Update", mock.Anything, expectedReportSchedule).Return(expectedReportSchedule, nil)
+
+	actual, err := suite.rsuc.UpdateSchedule(context.Background(), expectedReportSchedule)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expectedReportSchedule.ID, actual.ID)
+}
+
+func (suite *ReportScheduleUseCaseTestSuite) TestDeleteSchedule_Failure() {
+	suite.rsrm.On("Delete", mock.Anything, "1").Return(fmt.Errorf("error"))
+
+	err := suite.rsuc.DeleteSchedule(context.Background(), "1")
+
+	assert.Error(suite.T(), err)
+}
+
+func (suite *ReportScheduleUseCaseTestSuite) TestRunDueSchedules_Success() {
+	monday := time.Date(2024, time.January, 1, 8, 0, 30, 0, time.Local)
+	notDue := expectedReportSchedule
+	notDue.ID = "2"
+	notDue.CronExpression = "0 9 * * 1"
+
+	suite.rsrm.On("ListActive", mock.Anything).Return([]entity.ReportSchedule{expectedReportSchedule, notDue}, nil)
+	suite.rum.On("ExportReports", mock.Anything, "week", expectedReportSchedule.Filter, "csv").Return([]byte("ID\n1\n"), nil)
+	suite.msm.On("Send", mock.Anything, mock.MatchedBy(func(message model.MailMessage) bool {
+		return message.To[0] == "manager@example.com" && len(message.Attachments) == 1 && message.Attachments[0].ContentType == "text/csv"
+	})).Return(nil)
+	suite.rsrm.On("ClaimRun", mock.Anything, "1", monday.Truncate(time.Minute)).Return(true, nil)
+
+	err := suite.rsuc.RunDueSchedules(context.Background(), monday)
+
+	assert.NoError(suite.T(), err)
+	suite.msm.AssertNumberOfCalls(suite.T(), "Send", 1)
+	suite.rsrm.AssertNotCalled(suite.T(), "ClaimRun", mock.Anything, "2", mock.Anything)
+}
+
+func (suite *ReportScheduleUseCaseTestSuite) TestRunDueSchedules_AlreadyRunSuccess() {
+	monday := time.Date(2024, time.January, 1, 8, 0, 0, 0, time.Local)
+
+	suite.rsrm.On("ListActive", mock.Anything).Return([]entity.ReportSchedule{expectedReportSchedule}, nil)
+	suite.rsrm.On("ClaimRun", mock.Anything, "1", monday).Return(false, nil)
+
+	err := suite.rsuc.RunDueSchedules(context.Background(), monday)
+
+	assert.NoError(suite.T(), err)
+	suite.rum.AssertNotCalled(suite.T(), "ExportReports", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	suite.msm.AssertNotCalled(suite.T(), "Send", mock.Anything, mock.Anything)
+}
+
+func (suite *ReportScheduleUseCaseTestSuite) TestRunDueSchedules_ClaimFailure() {
+	monday := time.Date(2024, time.January, 1, 8, 0, 0, 0, time.Local)
+
+	suite.rsrm.On("ListActive", mock.Anything).Return([]entity.ReportSchedule{expectedReportSchedule}, nil)
+	suite.rsrm.On("ClaimRun", mock.Anything, "1", monday).Return(false, fmt.Errorf("error"))
+
+	err := suite.rsuc.RunDueSchedules(context.Background(), monday)
+
+	assert.Error(suite.T(), err)
+	suite.msm.AssertNotCalled(suite.T(), "Send", mock.Anything, mock.Anything)
+}
+
+func (suite *ReportScheduleUseCaseTestSuite) TestRunDueSchedules_SendFailure() {
+	monday := time.Date(2024, time.January, 1, 8, 0, 0, 0, time.Local)
+
+	suite.rsrm.On("ListActive", mock.Anything).Return([]entity.ReportSchedule{expectedReportSchedule}, nil)
+	suite.rsrm.On("ClaimRun", mock.Anything, "1", monday).Return(true, nil)
+	suite.rum.On("ExportReports", mock.Anything, "week", expectedReportSchedule.Filter, "csv").Return([]byte("ID\n"), nil)
+	suite.msm.On("Send", mock.Anything, mock.Anything).Return(fmt.Errorf("error"))
+
+	err := suite.rsuc.RunDueSchedules(context.Background(), monday)
+
+	assert.Error(suite.T(), err)
+}
+
+func (suite *ReportScheduleUseCaseTestSuite) TestRunDueSchedules_ListFailure() {
+	suite.rsrm.On("ListActive", mock.Anything).Return([]entity.ReportSchedule{}, fmt.Errorf("error"))
+
+	err := suite.rsuc.RunDueSchedules(context.Background(), time.Now())
+
+	assert.Error(suite.T(), err)
+}
+
+func TestReportScheduleUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(ReportScheduleUseCaseTestSuite))
+}
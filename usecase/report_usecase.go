@@ -1,17 +1,35 @@
 package usecase
 
 import (
+	"booking-room-app/entity"
 	"booking-room-app/entity/dto"
 	"booking-room-app/repository"
+	"booking-room-app/shared/apperror"
+	"booking-room-app/shared/metrics"
+	"bytes"
+	"context"
 	"encoding/csv"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"strconv"
+	"strings"
 	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	ReportFormatCSV  = "csv"
+	ReportFormatJSON = "json"
 )
 
 type ReportUseCase interface {
-	PrintAllReports(rangeParam string) ([]dto.ReportDto, error)
+	PrintAllReports(ctx context.Context, rangeParam string) ([]dto.ReportDto, error)
+	ExportReports(ctx context.Context, rangeParam string, filter entity.ReportFilter, format string) ([]byte, error)
+	ChargebackReport(ctx context.Context, startDate, endDate time.Time, period string) ([]dto.ChargebackDto, error)
+	ExportChargeback(ctx context.Context, startDate, endDate time.Time, period string, format string) ([]byte, error)
 }
 
 type reportUseCase struct {
@@ -19,27 +37,121 @@ type reportUseCase struct {
 }
 
 // FindAllReports implements ReportUseCase.
-func (r *reportUseCase) PrintAllReports(rangeParam string) ([]dto.ReportDto, error) {
+func (r *reportUseCase) PrintAllReports(ctx context.Context, rangeParam string) ([]dto.ReportDto, error) {
+	ctx, span := startSpan(ctx, "reportUseCase.PrintAllReports")
+	defer span.End()
+
+	timer := prometheus.NewTimer(metrics.ReportGenerationDuration.WithLabelValues(metrics.ReportTransactions))
+	defer timer.ObserveDuration()
+
 	// Generate folder
 	err := os.MkdirAll("public", os.ModePerm)
 	if err != nil {
-		return []dto.ReportDto{}, fmt.Errorf("failed to create reports directory: %v", err)
+		return []dto.ReportDto{}, apperror.Internal(fmt.Errorf("failed to create reports directory: %v", err))
 	}
 
 	// Generate file
 	file, err := os.Create("public/transaction.csv")
 	if err != nil {
-		return []dto.ReportDto{}, fmt.Errorf("failed to create reports file: %v", err.Error())
+		return []dto.ReportDto{}, apperror.Internal(fmt.Errorf("failed to create reports file: %v", err.Error()))
 	}
 	defer file.Close()
 
-	// Write data to file
-	writer := csv.NewWriter(file)
-	defer writer.Flush()
+	startDate, endDate := reportRange(rangeParam)
+	reports, err := r.repo.List(ctx, startDate, endDate)
+	if err != nil {
+		return []dto.ReportDto{}, dbError(err, "report")
+	}
 
-	// Write the file headers
-	writer.Write([]string{"ID", "ID Pegawai", "Nama Pegawai", "Username Akun Pegawai", "Divisi", "Jabatan", "Kontak Pegawai", "ID Ruangan", "Nama Ruangan", "Jenis Ruangan", "Kapasitas", "Daftar Fasilitas", "Catatan Pemesanan", "Status Pemesanan", "Jam Mulai Peminjaman Ruangan", "Jam Akhir Peminjaman Ruangan", "Waktu Pemesanan Dibuat", "Terakhir Diperbarui"})
+	if err := writeReportsCSV(file, reports); err != nil {
+		return []dto.ReportDto{}, apperror.Internal(fmt.Errorf("failed to write reports file: %v", err.Error()))
+	}
+
+	return reports, nil
+}
+
+// ExportReports implements ReportUseCase.
+func (r *reportUseCase) ExportReports(ctx context.Context, rangeParam string, filter entity.ReportFilter, format string) ([]byte, error) {
+	ctx, span := startSpan(ctx, "reportUseCase.ExportReports")
+	defer span.End()
 
+	timer := prometheus.NewTimer(metrics.ReportGenerationDuration.WithLabelValues(metrics.ReportTransactions))
+	defer timer.ObserveDuration()
+
+	startDate, endDate := reportRange(rangeParam)
+	reports, err := r.repo.List(ctx, startDate, endDate)
+	if err != nil {
+		return nil, dbError(err, "report")
+	}
+	reports = filterReports(reports, filter)
+
+	var buf bytes.Buffer
+	switch format {
+	case ReportFormatCSV:
+		err = writeReportsCSV(&buf, reports)
+	case ReportFormatJSON:
+		err = json.NewEncoder(&buf).Encode(reports)
+	default:
+		return nil, apperror.Validation("unsupported report format", apperror.Field("format", "oneof", "format must be csv or json"))
+	}
+	if err != nil {
+		return nil, apperror.Internal(fmt.Errorf("failed to write reports: %v", err.Error()))
+	}
+
+	return buf.Bytes(), nil
+}
+
+// ChargebackReport totals the cost of accepted transactions per division and
+// period, where period is one of day, week, month or year.
+func (r *reportUseCase) ChargebackReport(ctx context.Context, startDate, endDate time.Time, period string) ([]dto.ChargebackDto, error) {
+	ctx, span := startSpan(ctx, "reportUseCase.ChargebackReport")
+	defer span.End()
+
+	timer := prometheus.NewTimer(metrics.ReportGenerationDuration.WithLabelValues(metrics.ReportChargeback))
+	defer timer.ObserveDuration()
+
+	period = strings.ToLower(period)
+	if period != "day" && period != "week" && period != "month" && period != "year" {
+		return nil, apperror.Validation("invalid period", apperror.Field("period", "oneof", "period must be day, week, month or year"))
+	}
+	if endDate.Before(startDate) {
+		return nil, apperror.Validation("invalid date range", apperror.Field("endDate", "gtefield", "endDate must not be before startDate"))
+	}
+
+	chargebacks, err := r.repo.Chargeback(ctx, startDate, endDate, period)
+	if err != nil {
+		return nil, dbError(err, "report")
+	}
+	return chargebacks, nil
+}
+
+// ExportChargeback implements ReportUseCase.
+func (r *reportUseCase) ExportChargeback(ctx context.Context, startDate, endDate time.Time, period string, format string) ([]byte, error) {
+	ctx, span := startSpan(ctx, "reportUseCase.ExportChargeback")
+	defer span.End()
+
+	chargebacks, err := r.ChargebackReport(ctx, startDate, endDate, period)
+	if err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	switch format {
+	case ReportFormatCSV:
+		err = writeChargebackCSV(&buf, chargebacks)
+	case ReportFormatJSON:
+		err = json.NewEncoder(&buf).Encode(chargebacks)
+	default:
+		return nil, apperror.Validation("unsupported report format", apperror.Field("format", "oneof", "format must be csv or json"))
+	}
+	if err != nil {
+		return nil, apperror.Internal(fmt.Errorf("failed to write chargeback report: %v", err.Error()))
+	}
+
+	return buf.Bytes(), nil
+}
+
+func reportRange(rangeParam string) (time.Time, time.Time) {
 	var startDate, endDate time.Time
 	switch rangeParam {
 	case "day":
@@ -55,16 +167,39 @@ func (r *reportUseCase) PrintAllReports(rangeParam string) ([]dto.ReportDto, err
 		startDate = time.Now().AddDate(-1, 0, 0).Truncate(time.Second)
 		endDate = time.Now().Truncate(time.Second)
 	}
+	return startDate, endDate
+}
 
-	reports, err := r.repo.List(startDate, endDate)
-	if err != nil {
-		return []dto.ReportDto{}, fmt.Errorf("oopps, failed to get transactions data")
+func filterReports(reports []dto.ReportDto, filter entity.ReportFilter) []dto.ReportDto {
+	var filtered []dto.ReportDto
+	for _, report := range reports {
+		if filter.Status != "" && !strings.EqualFold(report.Status, filter.Status) {
+			continue
+		}
+		if filter.Division != "" && !strings.EqualFold(report.Employee.Division, filter.Division) {
+			continue
+		}
+		if filter.RoomId != "" && report.RoomId != filter.RoomId {
+			continue
+		}
+		if !filter.LocationFilter.Matches(report.Room.Location) {
+			continue
+		}
+		filtered = append(filtered, report)
 	}
+	return filtered
+}
+
+func writeReportsCSV(w io.Writer, reports []dto.ReportDto) error {
+	writer := csv.NewWriter(w)
+
+	// Write the file headers
+	writer.Write([]string{"ID", "ID Pegawai", "Nama Pegawai", "Username Akun Pegawai", "Divisi", "Jabatan", "Kontak Pegawai", "ID Ruangan", "Nama Ruangan", "Jenis Ruangan", "Kapasitas", "Daftar Fasilitas", "Catatan Pemesanan", "Status Pemesanan", "Jam Mulai Peminjaman Ruangan", "Jam Akhir Peminjaman Ruangan", "Waktu Pemesanan Dibuat", "Terakhir Diperbarui"})
 
 	// Write transaction data to csv file
 	for _, report := range reports {
 		var roomFacilityString string
-		for _, v := range report.RoomFacilities {
+		for _, v := range report.Facilities {
 			roomFacilityString += fmt.Sprintf("- %s, %d buah (facility_id: %s)\n", v.Name, v.Quantity, v.FacilityID)
 		}
 
@@ -91,7 +226,27 @@ func (r *reportUseCase) PrintAllReports(rangeParam string) ([]dto.ReportDto, err
 		writer.Write(row)
 	}
 
-	return reports, nil
+	writer.Flush()
+	return writer.Error()
+}
+
+func writeChargebackCSV(w io.Writer, chargebacks []dto.ChargebackDto) error {
+	writer := csv.NewWriter(w)
+
+	writer.Write([]string{"Divisi", "Periode", "Jumlah Pemesanan", "Biaya Ruangan", "Biaya Fasilitas", "Total Biaya"})
+	for _, chargeback := range chargebacks {
+		writer.Write([]string{
+			chargeback.Division,
+			chargeback.Period.Format("2006-01-02"),
+			strconv.Itoa(chargeback.Transactions),
+			strconv.FormatInt(chargeback.RoomCost, 10),
+			strconv.FormatInt(chargeback.FacilityCost, 10),
+			strconv.FormatInt(chargeback.TotalCost, 10),
+		})
+	}
+
+	writer.Flush()
+	return writer.Error()
 }
 
 func NewReportUseCase(repo repository.ReportRepository) ReportUseCase {
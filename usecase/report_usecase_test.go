@@ -4,11 +4,14 @@ import (
 	"booking-room-app/entity"
 	"booking-room-app/entity/dto"
 	"booking-room-app/mock/repo_mock"
+	"context"
+	"encoding/json"
 	"fmt"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -54,9 +57,9 @@ func (suite *ReportUseCaseTestSuite) SetupTest() {
 
 func (suite *ReportUseCaseTestSuite) TestPrintAllReports_DaySuccess() {
 	expectedReport[0].StartTime = time.Now().AddDate(0, 0, -1).Truncate(time.Second)
-	suite.rrm.On("List", expectedReport[0].StartTime, expectedReport[0].EndTime).Return(expectedReport, nil)
+	suite.rrm.On("List", mock.Anything, expectedReport[0].StartTime, expectedReport[0].EndTime).Return(expectedReport, nil)
 
-	_, err := suite.ruc.PrintAllReports("day")
+	_, err := suite.ruc.PrintAllReports(context.Background(), "day")
 	expectedReport[0].StartTime = time.Now()
 
 	assert.Nil(suite.T(), err)
@@ -65,9 +68,9 @@ func (suite *ReportUseCaseTestSuite) TestPrintAllReports_DaySuccess() {
 
 func (suite *ReportUseCaseTestSuite) TestPrintAllReports_WeekSuccess() {
 	expectedReport[0].StartTime = time.Now().AddDate(0, 0, -7).Truncate(time.Second)
-	suite.rrm.On("List", expectedReport[0].StartTime, expectedReport[0].EndTime).Return(expectedReport, nil)
+	suite.rrm.On("List", mock.Anything, expectedReport[0].StartTime, expectedReport[0].EndTime).Return(expectedReport, nil)
 
-	_, err := suite.ruc.PrintAllReports("week")
+	_, err := suite.ruc.PrintAllReports(context.Background(), "week")
 	expectedReport[0].StartTime = time.Now()
 
 	assert.Nil(suite.T(), err)
@@ -76,9 +79,9 @@ func (suite *ReportUseCaseTestSuite) TestPrintAllReports_WeekSuccess() {
 
 func (suite *ReportUseCaseTestSuite) TestPrintAllReports_MonthSuccess() {
 	expectedReport[0].StartTime = time.Now().AddDate(0, -1, 0).Truncate(time.Second)
-	suite.rrm.On("List", expectedReport[0].StartTime, expectedReport[0].EndTime).Return(expectedReport, nil)
+	suite.rrm.On("List", mock.Anything, expectedReport[0].StartTime, expectedReport[0].EndTime).Return(expectedReport, nil)
 
-	_, err := suite.ruc.PrintAllReports("month")
+	_, err := suite.ruc.PrintAllReports(context.Background(), "month")
 	expectedReport[0].StartTime = time.Now()
 
 	assert.Nil(suite.T(), err)
@@ -87,9 +90,9 @@ func (suite *ReportUseCaseTestSuite) TestPrintAllReports_MonthSuccess() {
 
 func (suite *ReportUseCaseTestSuite) TestPrintAllReports_YearSuccess() {
 	expectedReport[0].StartTime = time.Now().AddDate(-1, 0, 0).Truncate(time.Second)
-	suite.rrm.On("List", expectedReport[0].StartTime, expectedReport[0].EndTime).Return(expectedReport, nil)
+	suite.rrm.On("List", mock.Anything, expectedReport[0].StartTime, expectedReport[0].EndTime).Return(expectedReport, nil)
 
-	_, err := suite.ruc.PrintAllReports("year")
+	_, err := suite.ruc.PrintAllReports(context.Background(), "year")
 	expectedReport[0].StartTime = time.Now()
 
 	assert.Nil(suite.T(), err)
@@ -98,15 +101,108 @@ func (suite *ReportUseCaseTestSuite) TestPrintAllReports_YearSuccess() {
 
 func (suite *ReportUseCaseTestSuite) TestPrintAllReports_Failure() {
 	expectedReport[0].StartTime = time.Now().AddDate(0, 0, -1).Truncate(time.Second)
-	suite.rrm.On("List", expectedReport[0].StartTime, expectedReport[0].EndTime).Return(expectedReport, fmt.Errorf("error"))
+	suite.rrm.On("List", mock.Anything, expectedReport[0].StartTime, expectedReport[0].EndTime).Return(expectedReport, fmt.Errorf("error"))
 	expectedReport[0].StartTime = time.Now()
 
-	_, err := suite.ruc.PrintAllReports("day")
+	_, err := suite.ruc.PrintAllReports(context.Background(), "day")
 
 	assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
 }
 
+func (suite *ReportUseCaseTestSuite) TestExportReports_FilterSuccess() {
+	declined := expectedReport[0]
+	declined.ID = "2"
+	declined.Status = "declined"
+	startDate := time.Now().AddDate(0, 0, -7).Truncate(time.Second)
+	suite.rrm.On("List", mock.Anything, startDate, expectedReport[0].EndTime).Return([]dto.ReportDto{expectedReport[0], declined}, nil)
+
+	actual, err := suite.ruc.ExportReports(context.Background(), "week", entity.ReportFilter{Status: "Pending"}, "json")
+
+	var reports []dto.ReportDto
+	assert.NoError(suite.T(), err)
+	assert.NoError(suite.T(), json.Unmarshal(actual, &reports))
+	assert.Len(suite.T(), reports, 1)
+	assert.Equal(suite.T(), "1", reports[0].ID)
+}
+
+func (suite *ReportUseCaseTestSuite) TestExportReports_LocationFilterSuccess() {
+	tower := expectedReport[0]
+	tower.ID = "2"
+	tower.Room.Location = &entity.RoomLocation{SiteId: "1", BuildingId: "2", FloorId: "3"}
+	annex := expectedReport[0]
+	annex.ID = "3"
+	annex.Room.Location = &entity.RoomLocation{SiteId: "1", BuildingId: "4", FloorId: "5"}
+	startDate := time.Now().AddDate(0, 0, -7).Truncate(time.Second)
+	suite.rrm.On("List", mock.Anything, startDate, expectedReport[0].EndTime).Return([]dto.ReportDto{expectedReport[0], tower, annex}, nil)
+
+	actual, err := suite.ruc.ExportReports(context.Background(), "week", entity.ReportFilter{LocationFilter: entity.LocationFilter{SiteId: "1", BuildingId: "2"}}, "json")
+
+	var reports []dto.ReportDto
+	assert.NoError(suite.T(), err)
+	assert.NoError(suite.T(), json.Unmarshal(actual, &reports))
+	assert.Len(suite.T(), reports, 1)
+	assert.Equal(suite.T(), "2", reports[0].ID)
+}
+
+func (suite *ReportUseCaseTestSuite) TestExportReports_CsvSuccess() {
+	startDate := time.Now().AddDate(0, 0, -1).Truncate(time.Second)
+	suite.rrm.On("List", mock.Anything, startDate, expectedReport[0].EndTime).Return(expectedReport, nil)
+
+	actual, err := suite.ruc.ExportReports(context.Background(), "day", entity.ReportFilter{}, "csv")
+
+	assert.NoError(suite.T(), err)
+	assert.Contains(suite.T(), string(actual), "Ruang Candradimuka")
+}
+
+func (suite *ReportUseCaseTestSuite) TestExportReports_UnsupportedFormatFailure() {
+	startDate := time.Now().AddDate(0, 0, -1).Truncate(time.Second)
+	suite.rrm.On("List", mock.Anything, startDate, expectedReport[0].EndTime).Return(expectedReport, nil)
+
+	_, err := suite.ruc.ExportReports(context.Background(), "day", entity.ReportFilter{}, "pdf")
+
+	assert.Error(suite.T(), err)
+}
+
+func (suite *ReportUseCaseTestSuite) TestChargebackReport_Success() {
+	startDate := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2024, time.March, 31, 23, 59, 59, 0, time.UTC)
+	expected := []dto.ChargebackDto{{Division: "IT", Period: startDate, Transactions: 2, RoomCost: 300000, FacilityCost: 50000, TotalCost: 350000}}
+	suite.rrm.On("Chargeback", mock.Anything, startDate, endDate, "month").Return(expected, nil)
+
+	actual, err := suite.ruc.ChargebackReport(context.Background(), startDate, endDate, "Month")
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expected, actual)
+}
+
+func (suite *ReportUseCaseTestSuite) TestChargebackReport_InvalidPeriodFailure() {
+	_, err := suite.ruc.ChargebackReport(context.Background(), time.Now(), time.Now(), "decade")
+
+	assert.Error(suite.T(), err)
+	suite.rrm.AssertNotCalled(suite.T(), "Chargeback", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *ReportUseCaseTestSuite) TestExportChargeback_CsvSuccess() {
+	startDate := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	endDate := time.Date(2024, time.January, 31, 23, 59, 59, 0, time.UTC)
+	suite.rrm.On("Chargeback", mock.Anything, startDate, endDate, "month").Return([]dto.ChargebackDto{{Division: "Finance", Period: startDate, Transactions: 1, RoomCost: 150000, TotalCost: 150000}}, nil)
+
+	actual, err := suite.ruc.ExportChargeback(context.Background(), startDate, endDate, "month", "csv")
+
+	assert.NoError(suite.T(), err)
+	assert.Contains(suite.T(), string(actual), "Finance,2024-01-01,1,150000,0,150000")
+}
+
+func (suite *ReportUseCaseTestSuite) TestExportChargeback_Failure() {
+	startDate := time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC)
+	suite.rrm.On("Chargeback", mock.Anything, startDate, startDate, "day").Return([]dto.ChargebackDto{}, fmt.Errorf("error"))
+
+	_, err := suite.ruc.ExportChargeback(context.Background(), startDate, startDate, "day", "json")
+
+	assert.Error(suite.T(), err)
+}
+
 func TestReportUseCaseTestSuite(t *testing.T) {
 	suite.Run(t, new(ReportUseCaseTestSuite))
 }
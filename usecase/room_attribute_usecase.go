@@ -0,0 +1,402 @@
+package usecase
+
+import (
+	"booking-room-app/entity"
+	"booking-room-app/repository"
+	"booking-room-app/shared/apperror"
+	"booking-room-app/shared/model"
+	"context"
+	"errors"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// attributeKey is the form of an attribute key, which is also its name in
+// search queries and responses.
+var attributeKey = regexp.MustCompile(`^[a-z][a-z0-9_]{0,49}$`)
+
+type RoomAttributeUseCase interface {
+	RegisterAttribute(ctx context.Context, payload entity.RoomAttribute) (entity.RoomAttribute, error)
+	FindAllAttributes(ctx context.Context) ([]entity.RoomAttribute, error)
+	UpdateAttribute(ctx context.Context, payload entity.RoomAttribute) (entity.RoomAttribute, error)
+	DeleteAttribute(ctx context.Context, id string) error
+	FindRoomAttributes(ctx context.Context, roomId string) (map[string]any, error)
+	ReplaceRoomAttributes(ctx context.Context, roomId string, values map[string]any) (map[string]any, error)
+	SearchRooms(ctx context.Context, search entity.RoomSearch, page, size int) ([]entity.RoomSearchItem, entity.RoomFacets, model.Paging, error)
+}
+
+type roomAttributeUseCase struct {
+	repo       repository.RoomAttributeRepository
+	searchRepo repository.RoomSearchRepository
+	roomRepo   repository.RoomRepository
+}
+
+// RegisterAttribute implements RoomAttributeUseCase.
+func (r *roomAttributeUseCase) RegisterAttribute(ctx context.Context, payload entity.RoomAttribute) (entity.RoomAttribute, error) {
+	ctx, span := startSpan(ctx, "roomAttributeUseCase.RegisterAttribute")
+	defer span.End()
+
+	if missing := missingFields("key", payload.Key, "name", payload.Name, "type", payload.Type); len(missing) > 0 {
+		return entity.RoomAttribute{}, apperror.Required(missing...)
+	}
+	var problems []apperror.FieldError
+	if !attributeKey.MatchString(payload.Key) {
+		problems = append(problems, apperror.Field("key", "format", "key must be lower case letters, digits and underscores, starting with a letter"))
+	}
+	switch payload.Type {
+	case entity.AttributeEnum:
+		problems = append(problems, validateOptions(payload.Options)...)
+	case entity.AttributeBoolean, entity.AttributeNumber, entity.AttributeText:
+		if len(payload.Options) > 0 {
+			problems = append(problems, apperror.Field("options", "empty", "only an enum has options"))
+		}
+	default:
+		problems = append(problems, apperror.Field("type", "oneof", "type must be one of boolean number text enum"))
+	}
+	if err := invalid(problems); err != nil {
+		return entity.RoomAttribute{}, err
+	}
+
+	attribute, err := r.repo.Create(ctx, payload)
+	if err != nil {
+		return entity.RoomAttribute{}, dbError(err, "room attribute")
+	}
+	return attribute, nil
+}
+
+// FindAllAttributes implements RoomAttributeUseCase.
+func (r *roomAttributeUseCase) FindAllAttributes(ctx context.Context) ([]entity.RoomAttribute, error) {
+	ctx, span := startSpan(ctx, "roomAttributeUseCase.FindAllAttributes")
+	defer span.End()
+
+	attributes, err := r.repo.List(ctx)
+	if err != nil {
+		return nil, dbError(err, "room attribute")
+	}
+	return attributes, nil
+}
+
+// UpdateAttribute implements RoomAttributeUseCase. Only the name and the
+// options of an enum can change, and options that rooms have cannot be
+// removed. An enum is sent with all of its options.
+func (r *roomAttributeUseCase) UpdateAttribute(ctx context.Context, payload entity.RoomAttribute) (entity.RoomAttribute, error) {
+	ctx, span := startSpan(ctx, "roomAttributeUseCase.UpdateAttribute")
+	defer span.End()
+
+	if missing := missingFields("id", payload.ID, "name", payload.Name); len(missing) > 0 {
+		return entity.RoomAttribute{}, apperror.Required(missing...)
+	}
+	current, err := r.repo.Get(ctx, payload.ID)
+	if err != nil {
+		return entity.RoomAttribute{}, dbError(err, "room attribute")
+	}
+	if current.Type == entity.AttributeEnum {
+		if err := invalid(validateOptions(payload.Options)); err != nil {
+			return entity.RoomAttribute{}, err
+		}
+	} else if len(payload.Options) > 0 {
+		return entity.RoomAttribute{}, apperror.Validation("the request is invalid", apperror.Field("options", "empty", "only an enum has options"))
+	}
+
+	attribute, err := r.repo.Update(ctx, payload)
+	var inUse *repository.OptionsInUseError
+	if errors.As(err, &inUse) {
+		return entity.RoomAttribute{}, apperror.Conflict("attribute_option_in_use", "rooms still use "+strings.Join(inUse.Options, ", ")).Wrap(err)
+	}
+	if err != nil {
+		return entity.RoomAttribute{}, dbError(err, "room attribute")
+	}
+	return attribute, nil
+}
+
+// DeleteAttribute implements RoomAttributeUseCase. The values rooms have for
+// the attribute are deleted with it.
+func (r *roomAttributeUseCase) DeleteAttribute(ctx context.Context, id string) error {
+	ctx, span := startSpan(ctx, "roomAttributeUseCase.DeleteAttribute")
+	defer span.End()
+
+	if err := r.repo.Delete(ctx, id); err != nil {
+		return dbError(err, "room attribute")
+	}
+	return nil
+}
+
+// FindRoomAttributes implements RoomAttributeUseCase. The values are keyed by
+// attribute key and typed: a bool, a number or a string.
+func (r *roomAttributeUseCase) FindRoomAttributes(ctx context.Context, roomId string) (map[string]any, error) {
+	ctx, span := startSpan(ctx, "roomAttributeUseCase.FindRoomAttributes")
+	defer span.End()
+
+	if _, err := r.roomRepo.Get(ctx, roomId); err != nil {
+		return nil, dbError(err, "room")
+	}
+	values, err := r.repo.ListValues(ctx, []string{roomId})
+	if err != nil {
+		return nil, dbError(err, "room attribute")
+	}
+	attributes := attributeMaps(values)[roomId]
+	if attributes == nil {
+		attributes = map[string]any{}
+	}
+	return attributes, nil
+}
+
+// ReplaceRoomAttributes implements RoomAttributeUseCase. The room ends up
+// with exactly the given values; a null value, like a missing key, leaves the
+// attribute unset.
+func (r *roomAttributeUseCase) ReplaceRoomAttributes(ctx context.Context, roomId string, values map[string]any) (map[string]any, error) {
+	ctx, span := startSpan(ctx, "roomAttributeUseCase.ReplaceRoomAttributes")
+	defer span.End()
+
+	definitions, err := r.definitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var problems []apperror.FieldError
+	var stored []entity.RoomAttributeValue
+	for _, key := range keys {
+		value := values[key]
+		if value == nil {
+			continue
+		}
+		field := "attributes." + key
+		attribute, ok := definitions[key]
+		if !ok {
+			problems = append(problems, apperror.Field(field, "exists", field+" is not a room attribute"))
+			continue
+		}
+		text, problem := encodeAttribute(attribute, value)
+		if problem != "" {
+			problems = append(problems, apperror.Field(field, attribute.Type, field+" "+problem))
+			continue
+		}
+		stored = append(stored, entity.RoomAttributeValue{RoomId: roomId, AttributeId: attribute.ID, Key: key, Type: attribute.Type, Value: text})
+	}
+	if err := invalid(problems); err != nil {
+		return nil, err
+	}
+
+	if err := r.repo.ReplaceValues(ctx, roomId, stored); err != nil {
+		return nil, dbError(err, "room")
+	}
+	attributes := attributeMaps(stored)[roomId]
+	if attributes == nil {
+		attributes = map[string]any{}
+	}
+	return attributes, nil
+}
+
+// SearchRooms implements RoomAttributeUseCase. Attribute filters are checked
+// against the attribute definitions, and numbers and booleans are brought
+// into the form they are stored in.
+func (r *roomAttributeUseCase) SearchRooms(ctx context.Context, search entity.RoomSearch, page, size int) ([]entity.RoomSearchItem, entity.RoomFacets, model.Paging, error) {
+	ctx, span := startSpan(ctx, "roomAttributeUseCase.SearchRooms")
+	defer span.End()
+
+	var problems []apperror.FieldError
+	if search.MaxCapacity > 0 && search.MinCapacity > search.MaxCapacity {
+		problems = append(problems, apperror.Field("maxCapacity", "gtefield", "maxCapacity must not be less than minCapacity"))
+	}
+	if search.Sort == "distance" && search.NearFloorId == "" {
+		problems = append(problems, apperror.Field("nearFloorId", "required_with", "nearFloorId is required to sort by distance"))
+	}
+
+	if len(search.Attributes) > 0 {
+		definitions, err := r.definitions(ctx)
+		if err != nil {
+			return nil, entity.RoomFacets{}, model.Paging{}, err
+		}
+		for i, filter := range search.Attributes {
+			field := "attr." + filter.Key
+			attribute, ok := definitions[filter.Key]
+			if !ok {
+				problems = append(problems, apperror.Field(field, "exists", field+" is not a room attribute"))
+				continue
+			}
+			normalized, problem := normalizeFilter(attribute, filter)
+			if problem != "" {
+				problems = append(problems, apperror.Field(field, attribute.Type, field+" "+problem))
+				continue
+			}
+			search.Attributes[i] = normalized
+		}
+	}
+	if err := invalid(problems); err != nil {
+		return nil, entity.RoomFacets{}, model.Paging{}, err
+	}
+
+	items, facets, paging, err := r.searchRepo.Search(ctx, search, page, size)
+	if err != nil {
+		return nil, entity.RoomFacets{}, model.Paging{}, dbError(err, "room")
+	}
+	if len(items) == 0 {
+		return items, facets, paging, nil
+	}
+
+	roomIds := make([]string, len(items))
+	for i, item := range items {
+		roomIds[i] = item.ID
+	}
+	values, err := r.repo.ListValues(ctx, roomIds)
+	if err != nil {
+		return nil, entity.RoomFacets{}, model.Paging{}, dbError(err, "room attribute")
+	}
+	attributes := attributeMaps(values)
+	for i := range items {
+		items[i].Attributes = attributes[items[i].ID]
+		if items[i].Attributes == nil {
+			items[i].Attributes = map[string]any{}
+		}
+	}
+	return items, facets, paging, nil
+}
+
+// definitions returns the attributes by key.
+func (r *roomAttributeUseCase) definitions(ctx context.Context) (map[string]entity.RoomAttribute, error) {
+	attributes, err := r.repo.List(ctx)
+	if err != nil {
+		return nil, dbError(err, "room attribute")
+	}
+	definitions := make(map[string]entity.RoomAttribute, len(attributes))
+	for _, attribute := range attributes {
+		definitions[attribute.Key] = attribute
+	}
+	return definitions, nil
+}
+
+func validateOptions(options []string) []apperror.FieldError {
+	if len(options) == 0 {
+		return []apperror.FieldError{apperror.Field("options", "required", "an enum needs at least one option")}
+	}
+	seen := make(map[string]bool, len(options))
+	for _, option := range options {
+		if strings.TrimSpace(option) == "" {
+			return []apperror.FieldError{apperror.Field("options", "notblank", "options must not be blank")}
+		}
+		if seen[option] {
+			return []apperror.FieldError{apperror.Field("options", "unique", "options must not repeat "+option)}
+		}
+		seen[option] = true
+	}
+	return nil
+}
+
+// encodeAttribute turns a decoded JSON value into the text it is stored as,
+// or says why it does not fit the attribute.
+func encodeAttribute(attribute entity.RoomAttribute, value any) (string, string) {
+	switch attribute.Type {
+	case entity.AttributeBoolean:
+		if b, ok := value.(bool); ok {
+			return strconv.FormatBool(b), ""
+		}
+		return "", "must be true or false"
+	case entity.AttributeNumber:
+		if n, ok := value.(float64); ok {
+			return strconv.FormatFloat(n, 'f', -1, 64), ""
+		}
+		return "", "must be a number"
+	case entity.AttributeEnum:
+		if s, ok := value.(string); ok && contains(attribute.Options, s) {
+			return s, ""
+		}
+		return "", "must be one of " + strings.Join(attribute.Options, " ")
+	default:
+		if s, ok := value.(string); ok && strings.TrimSpace(s) != "" {
+			return s, ""
+		}
+		return "", "must be a non-blank string"
+	}
+}
+
+// normalizeFilter checks a search filter against its attribute. Only numbers
+// can be searched by range.
+func normalizeFilter(attribute entity.RoomAttribute, filter entity.AttributeFilter) (entity.AttributeFilter, string) {
+	if attribute.Type != entity.AttributeNumber && (filter.Min != "" || filter.Max != "") {
+		return filter, "cannot be searched by range"
+	}
+	values := make([]string, len(filter.Values))
+	for i, value := range filter.Values {
+		switch attribute.Type {
+		case entity.AttributeBoolean:
+			b, err := strconv.ParseBool(value)
+			if err != nil {
+				return filter, "must be true or false"
+			}
+			values[i] = strconv.FormatBool(b)
+		case entity.AttributeNumber:
+			n, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return filter, "must be a number"
+			}
+			values[i] = strconv.FormatFloat(n, 'f', -1, 64)
+		case entity.AttributeEnum:
+			if !contains(attribute.Options, value) {
+				return filter, "must be one of " + strings.Join(attribute.Options, " ")
+			}
+			values[i] = value
+		default:
+			values[i] = value
+		}
+	}
+	filter.Values = values
+
+	for _, bound := range []*string{&filter.Min, &filter.Max} {
+		if *bound == "" {
+			continue
+		}
+		n, err := strconv.ParseFloat(*bound, 64)
+		if err != nil {
+			return filter, "range bounds must be numbers"
+		}
+		*bound = strconv.FormatFloat(n, 'f', -1, 64)
+	}
+	return filter, ""
+}
+
+// attributeMaps groups stored values by room, decoded to their type.
+func attributeMaps(values []entity.RoomAttributeValue) map[string]map[string]any {
+	rooms := map[string]map[string]any{}
+	for _, value := range values {
+		if rooms[value.RoomId] == nil {
+			rooms[value.RoomId] = map[string]any{}
+		}
+		rooms[value.RoomId][value.Key] = decodeAttribute(value)
+	}
+	return rooms
+}
+
+func decodeAttribute(value entity.RoomAttributeValue) any {
+	switch value.Type {
+	case entity.AttributeBoolean:
+		if b, err := strconv.ParseBool(value.Value); err == nil {
+			return b
+		}
+	case entity.AttributeNumber:
+		if n, err := strconv.ParseFloat(value.Value, 64); err == nil {
+			return n
+		}
+	}
+	return value.Value
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+func NewRoomAttributeUseCase(repo repository.RoomAttributeRepository, searchRepo repository.RoomSearchRepository, roomRepo repository.RoomRepository) RoomAttributeUseCase {
+	return &roomAttributeUseCase{repo: repo, searchRepo: searchRepo, roomRepo: roomRepo}
+}
@@ -0,0 +1,202 @@
+package usecase
+
+import (
+	"booking-room-app/entity"
+	"booking-room-app/mock/repo_mock"
+	"booking-room-app/repository"
+	"booking-room-app/shared/apperror"
+	"booking-room-app/shared/model"
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+var roomAttributes = []entity.RoomAttribute{
+	{ID: "a1", Key: "whiteboard", Name: "Whiteboard", Type: entity.AttributeBoolean},
+	{ID: "a2", Key: "seats", Name: "Seats", Type: entity.AttributeNumber},
+	{ID: "a3", Key: "layout", Name: "Layout", Type: entity.AttributeEnum, Options: []string{"boardroom", "classroom"}},
+	{ID: "a4", Key: "note", Name: "Note", Type: entity.AttributeText},
+}
+
+type RoomAttributeUseCaseTestSuite struct {
+	suite.Suite
+	arm *repo_mock.RoomAttributeRepoMock
+	srm *repo_mock.RoomSearchRepoMock
+	rrm *repo_mock.RoomRepoMock
+	ruc RoomAttributeUseCase
+}
+
+func (suite *RoomAttributeUseCaseTestSuite) SetupTest() {
+	suite.arm = new(repo_mock.RoomAttributeRepoMock)
+	suite.srm = new(repo_mock.RoomSearchRepoMock)
+	suite.rrm = new(repo_mock.RoomRepoMock)
+	suite.ruc = NewRoomAttributeUseCase(suite.arm, suite.srm, suite.rrm)
+}
+
+func (suite *RoomAttributeUseCaseTestSuite) TestRegisterAttribute_Success() {
+	payload := entity.RoomAttribute{Key: "layout", Name: "Layout", Type: entity.AttributeEnum, Options: []string{"boardroom", "classroom"}}
+	suite.arm.On("Create", mock.Anything, payload).Return(roomAttributes[2], nil)
+
+	actual, err := suite.ruc.RegisterAttribute(context.Background(), payload)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), "a3", actual.ID)
+}
+
+func (suite *RoomAttributeUseCaseTestSuite) TestRegisterAttribute_InvalidFail() {
+	cases := map[string]entity.RoomAttribute{
+		"key":     {Key: "Video Conference", Name: "Video", Type: entity.AttributeBoolean},
+		"type":    {Key: "video", Name: "Video", Type: "date"},
+		"options": {Key: "layout", Name: "Layout", Type: entity.AttributeEnum, Options: []string{"open", "open"}},
+	}
+	for field, payload := range cases {
+		_, err := suite.ruc.RegisterAttribute(context.Background(), payload)
+
+		appErr := apperror.From(err)
+		assert.Equal(suite.T(), apperror.KindValidation, appErr.Kind, field)
+		assert.Equal(suite.T(), field, appErr.Fields[0].Field)
+	}
+	suite.arm.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *RoomAttributeUseCaseTestSuite) TestRegisterAttribute_OptionsOnBooleanFail() {
+	_, err := suite.ruc.RegisterAttribute(context.Background(), entity.RoomAttribute{Key: "video", Name: "Video", Type: entity.AttributeBoolean, Options: []string{"yes"}})
+
+	assert.Equal(suite.T(), "options", apperror.From(err).Fields[0].Field)
+}
+
+func (suite *RoomAttributeUseCaseTestSuite) TestUpdateAttribute_OptionInUseFail() {
+	payload := entity.RoomAttribute{ID: "a3", Name: "Layout", Options: []string{"boardroom"}}
+	suite.arm.On("Get", mock.Anything, "a3").Return(roomAttributes[2], nil)
+	suite.arm.On("Update", mock.Anything, payload).Return(entity.RoomAttribute{}, &repository.OptionsInUseError{Options: []string{"classroom"}})
+
+	_, err := suite.ruc.UpdateAttribute(context.Background(), payload)
+
+	appErr := apperror.From(err)
+	assert.Equal(suite.T(), apperror.KindConflict, appErr.Kind)
+	assert.Equal(suite.T(), "attribute_option_in_use", appErr.Code)
+}
+
+func (suite *RoomAttributeUseCaseTestSuite) TestUpdateAttribute_OptionsOnNumberFail() {
+	suite.arm.On("Get", mock.Anything, "a2").Return(roomAttributes[1], nil)
+
+	_, err := suite.ruc.UpdateAttribute(context.Background(), entity.RoomAttribute{ID: "a2", Name: "Seats", Options: []string{"10"}})
+
+	assert.Equal(suite.T(), apperror.KindValidation, apperror.KindOf(err))
+	suite.arm.AssertNotCalled(suite.T(), "Update", mock.Anything, mock.Anything)
+}
+
+func (suite *RoomAttributeUseCaseTestSuite) TestDeleteAttribute_NotFoundFail() {
+	suite.arm.On("Delete", mock.Anything, "a9").Return(sql.ErrNoRows)
+
+	err := suite.ruc.DeleteAttribute(context.Background(), "a9")
+
+	assert.Equal(suite.T(), "room_attribute_not_found", apperror.From(err).Code)
+}
+
+func (suite *RoomAttributeUseCaseTestSuite) TestFindRoomAttributes_Success() {
+	suite.rrm.On("Get", mock.Anything, "9").Return(entity.Room{ID: "9"}, nil)
+	suite.arm.On("ListValues", mock.Anything, []string{"9"}).Return([]entity.RoomAttributeValue{
+		{RoomId: "9", Key: "whiteboard", Type: entity.AttributeBoolean, Value: "true"},
+		{RoomId: "9", Key: "seats", Type: entity.AttributeNumber, Value: "12.5"},
+		{RoomId: "9", Key: "layout", Type: entity.AttributeEnum, Value: "boardroom"},
+	}, nil)
+
+	actual, err := suite.ruc.FindRoomAttributes(context.Background(), "9")
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), map[string]any{"whiteboard": true, "seats": 12.5, "layout": "boardroom"}, actual)
+}
+
+func (suite *RoomAttributeUseCaseTestSuite) TestFindRoomAttributes_RoomNotFoundFail() {
+	suite.rrm.On("Get", mock.Anything, "9").Return(entity.Room{}, sql.ErrNoRows)
+
+	_, err := suite.ruc.FindRoomAttributes(context.Background(), "9")
+
+	assert.Equal(suite.T(), "room_not_found", apperror.From(err).Code)
+}
+
+func (suite *RoomAttributeUseCaseTestSuite) TestReplaceRoomAttributes_Success() {
+	suite.arm.On("List", mock.Anything).Return(roomAttributes, nil)
+	expected := []entity.RoomAttributeValue{
+		{RoomId: "9", AttributeId: "a3", Key: "layout", Type: entity.AttributeEnum, Value: "classroom"},
+		{RoomId: "9", AttributeId: "a2", Key: "seats", Type: entity.AttributeNumber, Value: "20"},
+		{RoomId: "9", AttributeId: "a1", Key: "whiteboard", Type: entity.AttributeBoolean, Value: "false"},
+	}
+	suite.arm.On("ReplaceValues", mock.Anything, "9", expected).Return(nil)
+
+	actual, err := suite.ruc.ReplaceRoomAttributes(context.Background(), "9", map[string]any{"whiteboard": false, "seats": float64(20), "layout": "classroom", "note": nil})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), map[string]any{"whiteboard": false, "seats": float64(20), "layout": "classroom"}, actual)
+}
+
+func (suite *RoomAttributeUseCaseTestSuite) TestReplaceRoomAttributes_InvalidFail() {
+	suite.arm.On("List", mock.Anything).Return(roomAttributes, nil)
+
+	_, err := suite.ruc.ReplaceRoomAttributes(context.Background(), "9", map[string]any{"whiteboard": "yes", "layout": "theatre", "note": " ", "projector": true})
+
+	fields := apperror.From(err).Fields
+	assert.Len(suite.T(), fields, 4)
+	assert.Equal(suite.T(), "attributes.layout", fields[0].Field)
+	assert.Equal(suite.T(), "exists", fields[2].Code)
+	suite.arm.AssertNotCalled(suite.T(), "ReplaceValues", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func (suite *RoomAttributeUseCaseTestSuite) TestSearchRooms_Success() {
+	search := entity.RoomSearch{Attributes: []entity.AttributeFilter{
+		{Key: "whiteboard", Values: []string{"1"}},
+		{Key: "seats", Min: "10.0"},
+		{Key: "layout", Values: []string{"boardroom"}},
+	}}
+	normalized := entity.RoomSearch{Attributes: []entity.AttributeFilter{
+		{Key: "whiteboard", Values: []string{"true"}},
+		{Key: "seats", Values: []string{}, Min: "10"},
+		{Key: "layout", Values: []string{"boardroom"}},
+	}}
+	items := []entity.RoomSearchItem{{Room: entity.Room{ID: "9"}}, {Room: entity.Room{ID: "10"}}}
+	facets := entity.RoomFacets{Status: []entity.FacetCount{{Value: "available", Count: 2}}}
+	paging := model.Paging{Page: 1, RowsPerPage: 5, TotalRows: 2, TotalPages: 1}
+	suite.arm.On("List", mock.Anything).Return(roomAttributes, nil)
+	suite.srm.On("Search", mock.Anything, normalized, 1, 5).Return(items, facets, paging, nil)
+	suite.arm.On("ListValues", mock.Anything, []string{"9", "10"}).Return([]entity.RoomAttributeValue{{RoomId: "9", Key: "seats", Type: entity.AttributeNumber, Value: "12"}}, nil)
+
+	actual, actualFacets, actualPaging, err := suite.ruc.SearchRooms(context.Background(), search, 1, 5)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), map[string]any{"seats": float64(12)}, actual[0].Attributes)
+	assert.Equal(suite.T(), map[string]any{}, actual[1].Attributes)
+	assert.Equal(suite.T(), facets, actualFacets)
+	assert.Equal(suite.T(), paging, actualPaging)
+}
+
+func (suite *RoomAttributeUseCaseTestSuite) TestSearchRooms_InvalidFail() {
+	suite.arm.On("List", mock.Anything).Return(roomAttributes, nil)
+	search := entity.RoomSearch{
+		MinCapacity: 10,
+		MaxCapacity: 5,
+		Sort:        "distance",
+		Attributes: []entity.AttributeFilter{
+			{Key: "projector", Values: []string{"true"}},
+			{Key: "layout", Min: "1"},
+			{Key: "seats", Values: []string{"many"}},
+		},
+	}
+
+	_, _, _, err := suite.ruc.SearchRooms(context.Background(), search, 1, 5)
+
+	var fields []string
+	for _, field := range apperror.From(err).Fields {
+		fields = append(fields, field.Field)
+	}
+	assert.Equal(suite.T(), []string{"maxCapacity", "nearFloorId", "attr.projector", "attr.layout", "attr.seats"}, fields)
+	suite.srm.AssertNotCalled(suite.T(), "Search", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestRoomAttributeUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(RoomAttributeUseCaseTestSuite))
+}
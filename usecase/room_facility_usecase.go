@@ -3,61 +3,82 @@ package usecase
 import (
 	"booking-room-app/entity"
 	"booking-room-app/repository"
+	"booking-room-app/shared/apperror"
 	"booking-room-app/shared/model"
-	"fmt"
+	"context"
+	"database/sql"
+	"errors"
 )
 
 type RoomFacilityUsecase interface {
-	FindAllRoomFacility(page, size int) ([]entity.RoomFacility, model.Paging, error)
-	FindRoomFacilityById(id string) (entity.RoomFacility, error)
-	AddRoomFacilityTransaction(payload entity.RoomFacility) (entity.RoomFacility, error)
-	UpdateRoomFacilityTransaction(payload entity.RoomFacility) (entity.RoomFacility, error)
+	FindAllRoomFacility(ctx context.Context, page, size int) ([]entity.RoomFacility, model.Paging, error)
+	FindRoomFacilityById(ctx context.Context, id string) (entity.RoomFacility, error)
+	AddRoomFacilityTransaction(ctx context.Context, payload entity.RoomFacility) (entity.RoomFacility, error)
+	UpdateRoomFacilityTransaction(ctx context.Context, payload entity.RoomFacility) (entity.RoomFacility, error)
+	RemoveRoomFacility(ctx context.Context, id string) error
+	TransferRoomFacility(ctx context.Context, payload entity.RoomFacilityTransfer) (entity.RoomFacilityTransfer, error)
 }
 
 type roomFacilityUsecase struct {
-	repo repository.RoomFacilityRepository
+	repo         repository.RoomFacilityRepository
+	stockAlertUC StockAlertUseCase
 }
 
 // find all room-facility
-func (rf *roomFacilityUsecase) FindAllRoomFacility(page int, size int) ([]entity.RoomFacility, model.Paging, error) {
+func (rf *roomFacilityUsecase) FindAllRoomFacility(ctx context.Context, page int, size int) ([]entity.RoomFacility, model.Paging, error) {
+	ctx, span := startSpan(ctx, "roomFacilityUsecase.FindAllRoomFacility")
+	defer span.End()
+
 	if page == 0 && size == 0 {
 		page = 1
 		size = 5
 	}
-	return rf.repo.ListRoomFacility(page, size)
+	roomFacilities, paging, err := rf.repo.ListRoomFacility(ctx, page, size)
+	if err != nil {
+		return nil, model.Paging{}, dbError(err, "room facility")
+	}
+	return roomFacilities, paging, nil
 }
 
 // find room-facility by id
-func (rf *roomFacilityUsecase) FindRoomFacilityById(id string) (entity.RoomFacility, error) {
-	return rf.repo.GetRoomFacilityById(id)
-}
+func (rf *roomFacilityUsecase) FindRoomFacilityById(ctx context.Context, id string) (entity.RoomFacility, error) {
+	ctx, span := startSpan(ctx, "roomFacilityUsecase.FindRoomFacilityById")
+	defer span.End()
 
-// add room-facility
-func (rf *roomFacilityUsecase) AddRoomFacilityTransaction(payload entity.RoomFacility) (entity.RoomFacility, error) {
-	// Check that the quantity entered does not exceed the quantity in facility
-	quantity, err := rf.repo.GetQuantityFacilityByID(payload.FacilityId)
+	roomFacility, err := rf.repo.GetRoomFacilityById(ctx, id)
 	if err != nil {
-		return entity.RoomFacility{}, err
-	}
-	if payload.Quantity > quantity {
-		return entity.RoomFacility{}, fmt.Errorf("oppps, quantity exceeds the facility quantity")
+		return entity.RoomFacility{}, dbError(err, "room facility")
 	}
-	newFacilityQuantity := quantity - payload.Quantity
+	return roomFacility, nil
+}
+
+// add room-facility
+func (rf *roomFacilityUsecase) AddRoomFacilityTransaction(ctx context.Context, payload entity.RoomFacility) (entity.RoomFacility, error) {
+	ctx, span := startSpan(ctx, "roomFacilityUsecase.AddRoomFacilityTransaction")
+	defer span.End()
 
-	// create room-facility transaction
-	transactions, err := rf.repo.CreateRoomFacility(payload, newFacilityQuantity)
+	// create room-facility transaction, the allocation is recorded in the stock
+	// ledger and refused when it exceeds the quantity in facility
+	transactions, err := rf.repo.CreateRoomFacility(ctx, payload)
+	if errors.Is(err, sql.ErrNoRows) {
+		return entity.RoomFacility{}, dbError(err, "facility")
+	}
 	if err != nil {
-		return entity.RoomFacility{}, fmt.Errorf("oppps, failed to save room-facility transations :%v", err.Error())
+		return entity.RoomFacility{}, dbError(err, "room facility")
 	}
+	checkStockAfter(ctx, rf.stockAlertUC, payload.FacilityId)
 	return transactions, nil
 }
 
 // update room-facility
-func (rf *roomFacilityUsecase) UpdateRoomFacilityTransaction(payload entity.RoomFacility) (entity.RoomFacility, error) {
+func (rf *roomFacilityUsecase) UpdateRoomFacilityTransaction(ctx context.Context, payload entity.RoomFacility) (entity.RoomFacility, error) {
+	ctx, span := startSpan(ctx, "roomFacilityUsecase.UpdateRoomFacilityTransaction")
+	defer span.End()
+
 	// get old record
-	oldRoomFacility, err := rf.repo.GetRoomFacilityById(payload.ID)
+	oldRoomFacility, err := rf.repo.GetRoomFacilityById(ctx, payload.ID)
 	if err != nil {
-		return entity.RoomFacility{}, fmt.Errorf("oppps, failed to get previous data :%v", err.Error())
+		return entity.RoomFacility{}, dbError(err, "room facility")
 	}
 
 	// partial update checking
@@ -67,31 +88,63 @@ func (rf *roomFacilityUsecase) UpdateRoomFacilityTransaction(payload entity.Room
 	if payload.FacilityId == "" {
 		payload.FacilityId = oldRoomFacility.FacilityId
 	}
-	newFacilityQuantity := -1 // -1 is integer for none changed quantity
 	if payload.Quantity == 0 {
 		payload.Quantity = oldRoomFacility.Quantity
-	} else {
-		// check that the quantity entered does not exceed the quantity in facility
-		facilityQuantity, err := rf.repo.GetQuantityFacilityByID(payload.FacilityId)
-		if err != nil {
-			return entity.RoomFacility{}, err
-		}
-		newFacilityQuantity = oldRoomFacility.Quantity - payload.Quantity + facilityQuantity // surplus or defisit are included in this one formula
-		if newFacilityQuantity < 0 {
-			return entity.RoomFacility{}, fmt.Errorf("oppps, quantity exceeds the facility quantity")
-		}
 	}
 	if payload.Description == "" {
 		payload.Description = oldRoomFacility.Description
 	}
 
-	roomFacility, err := rf.repo.UpdateRoomFacility(payload, newFacilityQuantity)
+	// the difference in quantity is moved between the stock and the room, and
+	// refused when the stock is short
+	roomFacility, err := rf.repo.UpdateRoomFacility(ctx, payload)
 	if err != nil {
-		return entity.RoomFacility{}, fmt.Errorf("oppps, failed to update data transations :%v", err.Error())
+		return entity.RoomFacility{}, dbError(err, "room facility")
+	}
+	facilityIds := []string{payload.FacilityId}
+	if oldRoomFacility.FacilityId != payload.FacilityId {
+		facilityIds = append(facilityIds, oldRoomFacility.FacilityId)
 	}
+	checkStockAfter(ctx, rf.stockAlertUC, facilityIds...)
 	return roomFacility, nil
 }
 
-func NewRoomFacilityUsecase(repo repository.RoomFacilityRepository) RoomFacilityUsecase {
-	return &roomFacilityUsecase{repo: repo}
+// remove room-facility, its quantity goes back to the facility stock
+func (rf *roomFacilityUsecase) RemoveRoomFacility(ctx context.Context, id string) error {
+	ctx, span := startSpan(ctx, "roomFacilityUsecase.RemoveRoomFacility")
+	defer span.End()
+
+	roomFacility, err := rf.repo.DeleteRoomFacility(ctx, id)
+	if err != nil {
+		return dbError(err, "room facility")
+	}
+	checkStockAfter(ctx, rf.stockAlertUC, roomFacility.FacilityId)
+	return nil
+}
+
+// move a quantity of a room-facility to another room, the stock is unchanged
+func (rf *roomFacilityUsecase) TransferRoomFacility(ctx context.Context, payload entity.RoomFacilityTransfer) (entity.RoomFacilityTransfer, error) {
+	ctx, span := startSpan(ctx, "roomFacilityUsecase.TransferRoomFacility")
+	defer span.End()
+
+	var problems []apperror.FieldError
+	for _, field := range missingFields("roomFacilityId", payload.RoomFacilityId, "roomId", payload.RoomId) {
+		problems = append(problems, apperror.RequiredField(field))
+	}
+	if payload.Quantity <= 0 {
+		problems = append(problems, apperror.Field("quantity", "gt", "quantity must be greater than 0"))
+	}
+	if err := invalid(problems); err != nil {
+		return entity.RoomFacilityTransfer{}, err
+	}
+
+	transfer, err := rf.repo.TransferRoomFacility(ctx, payload)
+	if err != nil {
+		return entity.RoomFacilityTransfer{}, dbError(err, "room facility")
+	}
+	return transfer, nil
+}
+
+func NewRoomFacilityUsecase(repo repository.RoomFacilityRepository, stockAlertUC StockAlertUseCase) RoomFacilityUsecase {
+	return &roomFacilityUsecase{repo: repo, stockAlertUC: stockAlertUC}
 }
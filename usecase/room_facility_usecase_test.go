@@ -3,13 +3,19 @@ package usecase
 import (
 	"booking-room-app/entity"
 	"booking-room-app/mock/repo_mock"
+	"booking-room-app/mock/usecase_mock"
+	"booking-room-app/repository"
+	"booking-room-app/shared/apperror"
 	"booking-room-app/shared/model"
+	"context"
+	"database/sql"
 	"fmt"
 	"net/http"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -26,20 +32,23 @@ var expectedRoomFacility = entity.RoomFacility{
 type RoomFacilityUseCaseTestSuite struct {
 	suite.Suite
 	rfrm *repo_mock.RoomFacilityRepoMock
+	sum  *usecase_mock.StockAlertUseCaseMock
 	rfuc RoomFacilityUsecase
 }
 
 func (suite *RoomFacilityUseCaseTestSuite) SetupTest() {
 	suite.rfrm = new(repo_mock.RoomFacilityRepoMock)
-	suite.rfuc = NewRoomFacilityUsecase(suite.rfrm)
+	suite.sum = new(usecase_mock.StockAlertUseCaseMock)
+	suite.sum.On("CheckStock", mock.Anything, mock.Anything).Return(nil).Maybe()
+	suite.rfuc = NewRoomFacilityUsecase(suite.rfrm, suite.sum)
 }
 
 /* test AddRoomFacilityTransaction success*/
 func (suite *RoomFacilityUseCaseTestSuite) TestAddRoomFacilityTransaction_Success() {
 	availableFacilityQuantity := 10
-	suite.rfrm.On("GetQuantityFacilityByID").Return(availableFacilityQuantity, http.StatusOK, nil)
-	suite.rfrm.On("CreateRoomFacility").Return(expectedRoomFacility, http.StatusCreated, nil)
-	actualRoomFacility, actualErr := suite.rfuc.AddRoomFacilityTransaction(expectedRoomFacility)
+	suite.rfrm.On("GetQuantityFacilityByID", mock.Anything).Return(availableFacilityQuantity, http.StatusOK, nil)
+	suite.rfrm.On("CreateRoomFacility", mock.Anything).Return(expectedRoomFacility, http.StatusCreated, nil)
+	actualRoomFacility, actualErr := suite.rfuc.AddRoomFacilityTransaction(context.Background(), expectedRoomFacility)
 	assert.Nil(suite.T(), actualErr)
 	assert.NoError(suite.T(), actualErr)
 	assert.Equal(suite.T(), expectedRoomFacility, actualRoomFacility)
@@ -47,8 +56,8 @@ func (suite *RoomFacilityUseCaseTestSuite) TestAddRoomFacilityTransaction_Succes
 
 func (suite *RoomFacilityUseCaseTestSuite) TestAddRoomFacilityTransaction_GetQuantityFail() {
 	availableFacilityQuantity := 10
-	suite.rfrm.On("GetQuantityFacilityByID").Return(availableFacilityQuantity, http.StatusBadRequest, fmt.Errorf("failed to get facility quntity"))
-	actualRoomFacility, actualErr := suite.rfuc.AddRoomFacilityTransaction(expectedRoomFacility)
+	suite.rfrm.On("GetQuantityFacilityByID", mock.Anything).Return(availableFacilityQuantity, http.StatusBadRequest, fmt.Errorf("failed to get facility quntity"))
+	actualRoomFacility, actualErr := suite.rfuc.AddRoomFacilityTransaction(context.Background(), expectedRoomFacility)
 	assert.NotNil(suite.T(), actualErr)
 	assert.Error(suite.T(), actualErr)
 	assert.Equal(suite.T(), entity.RoomFacility{}, actualRoomFacility)
@@ -56,8 +65,8 @@ func (suite *RoomFacilityUseCaseTestSuite) TestAddRoomFacilityTransaction_GetQua
 
 func (suite *RoomFacilityUseCaseTestSuite) TestAddRoomFacilityTransaction_InsuffientQuantityFail() {
 	availableFacilityQuantity := 0
-	suite.rfrm.On("GetQuantityFacilityByID").Return(availableFacilityQuantity, http.StatusOK, nil)
-	actualRoomFacility, actualErr := suite.rfuc.AddRoomFacilityTransaction(expectedRoomFacility)
+	suite.rfrm.On("GetQuantityFacilityByID", mock.Anything).Return(availableFacilityQuantity, http.StatusOK, nil)
+	actualRoomFacility, actualErr := suite.rfuc.AddRoomFacilityTransaction(context.Background(), expectedRoomFacility)
 	assert.NotNil(suite.T(), actualErr)
 	assert.Error(suite.T(), actualErr)
 	assert.Equal(suite.T(), entity.RoomFacility{}, actualRoomFacility)
@@ -65,9 +74,9 @@ func (suite *RoomFacilityUseCaseTestSuite) TestAddRoomFacilityTransaction_Insuff
 
 func (suite *RoomFacilityUseCaseTestSuite) TestAddRoomFacilityTransaction_CreateRoomFacilityFail() {
 	availableFacilityQuantity := 10
-	suite.rfrm.On("GetQuantityFacilityByID").Return(availableFacilityQuantity, http.StatusOK, nil)
-	suite.rfrm.On("CreateRoomFacility").Return(expectedRoomFacility, http.StatusInternalServerError, fmt.Errorf("failed to create room-facility"))
-	actualRoomFacility, actualErr := suite.rfuc.AddRoomFacilityTransaction(expectedRoomFacility)
+	suite.rfrm.On("GetQuantityFacilityByID", mock.Anything).Return(availableFacilityQuantity, http.StatusOK, nil)
+	suite.rfrm.On("CreateRoomFacility", mock.Anything).Return(expectedRoomFacility, http.StatusInternalServerError, fmt.Errorf("failed to create room-facility"))
+	actualRoomFacility, actualErr := suite.rfuc.AddRoomFacilityTransaction(context.Background(), expectedRoomFacility)
 	assert.NotNil(suite.T(), actualErr)
 	assert.Error(suite.T(), actualErr)
 	assert.Equal(suite.T(), entity.RoomFacility{}, actualRoomFacility)
@@ -75,8 +84,8 @@ func (suite *RoomFacilityUseCaseTestSuite) TestAddRoomFacilityTransaction_Create
 
 /* test FindAllRoomFacility success */
 func (suite *RoomFacilityUseCaseTestSuite) TestFindAllRoomFacility_Success() {
-	suite.rfrm.On("ListRoomFacility").Return([]entity.RoomFacility{}, model.Paging{}, nil)
-	actualRoomFacility, actualPaging, actualErr := suite.rfuc.FindAllRoomFacility(0, 0)
+	suite.rfrm.On("ListRoomFacility", mock.Anything).Return([]entity.RoomFacility{}, model.Paging{}, nil)
+	actualRoomFacility, actualPaging, actualErr := suite.rfuc.FindAllRoomFacility(context.Background(), 0, 0)
 	assert.Nil(suite.T(), actualErr)
 	assert.NoError(suite.T(), actualErr)
 	assert.Equal(suite.T(), model.Paging{}, actualPaging)
@@ -85,8 +94,8 @@ func (suite *RoomFacilityUseCaseTestSuite) TestFindAllRoomFacility_Success() {
 
 /* Test FindRoomFacilityById Success*/
 func (suite *RoomFacilityUseCaseTestSuite) TestFindRoomFacilityById_Success() {
-	suite.rfrm.On("GetRoomFacilityById").Return(expectedRoomFacility, http.StatusOK, nil)
-	actualRoomFacility, actualErr := suite.rfuc.FindRoomFacilityById(expectedRoomFacility.ID)
+	suite.rfrm.On("GetRoomFacilityById", mock.Anything).Return(expectedRoomFacility, http.StatusOK, nil)
+	actualRoomFacility, actualErr := suite.rfuc.FindRoomFacilityById(context.Background(), expectedRoomFacility.ID)
 	assert.Nil(suite.T(), actualErr)
 	assert.NoError(suite.T(), actualErr)
 	assert.Equal(suite.T(), expectedRoomFacility, actualRoomFacility)
@@ -109,17 +118,17 @@ func (suite *RoomFacilityUseCaseTestSuite) TestUpdateRoomFacilityTransaction_Suc
 		CreatedAt:   time.Time{},
 		UpdatedAt:   time.Time{},
 	}
-	suite.rfrm.On("GetRoomFacilityById").Return(oldRoomFacility, http.StatusOK, nil)
-	suite.rfrm.On("UpdateRoomFacility").Return(expectedRoomFacility, http.StatusOK, nil)
-	actualRoomFacility, actualErr := suite.rfuc.UpdateRoomFacilityTransaction(postedRoomFacility)
+	suite.rfrm.On("GetRoomFacilityById", mock.Anything).Return(oldRoomFacility, http.StatusOK, nil)
+	suite.rfrm.On("UpdateRoomFacility", mock.Anything).Return(expectedRoomFacility, http.StatusOK, nil)
+	actualRoomFacility, actualErr := suite.rfuc.UpdateRoomFacilityTransaction(context.Background(), postedRoomFacility)
 	assert.Nil(suite.T(), actualErr)
 	assert.NoError(suite.T(), actualErr)
 	assert.Equal(suite.T(), expectedRoomFacility, actualRoomFacility)
 }
 
 func (suite *RoomFacilityUseCaseTestSuite) TestUpdateRoomFacilityTransaction_GetRoomFacilityByIDFail() {
-	suite.rfrm.On("GetRoomFacilityById").Return(entity.RoomFacility{}, http.StatusInternalServerError, fmt.Errorf("failed to get room-facility by id"))
-	actualRoomFacility, actualErr := suite.rfuc.UpdateRoomFacilityTransaction(expectedRoomFacility)
+	suite.rfrm.On("GetRoomFacilityById", mock.Anything).Return(entity.RoomFacility{}, http.StatusInternalServerError, fmt.Errorf("failed to get room-facility by id"))
+	actualRoomFacility, actualErr := suite.rfuc.UpdateRoomFacilityTransaction(context.Background(), expectedRoomFacility)
 	assert.NotNil(suite.T(), actualErr)
 	assert.Error(suite.T(), actualErr)
 	assert.Equal(suite.T(), entity.RoomFacility{}, actualRoomFacility)
@@ -141,9 +150,9 @@ func (suite *RoomFacilityUseCaseTestSuite) TestUpdateRoomFacilityTransaction_Get
 		CreatedAt:   time.Time{},
 		UpdatedAt:   time.Time{},
 	}
-	suite.rfrm.On("GetRoomFacilityById").Return(oldRoomFacility, http.StatusOK, nil)
-	suite.rfrm.On("GetQuantityFacilityByID").Return(0, http.StatusBadRequest, fmt.Errorf("failed to get quantity facility by id"))
-	actualRoomFacility, actualErr := suite.rfuc.UpdateRoomFacilityTransaction(postedRoomFacility)
+	suite.rfrm.On("GetRoomFacilityById", mock.Anything).Return(oldRoomFacility, http.StatusOK, nil)
+	suite.rfrm.On("GetQuantityFacilityByID", mock.Anything).Return(0, http.StatusBadRequest, fmt.Errorf("failed to get quantity facility by id"))
+	actualRoomFacility, actualErr := suite.rfuc.UpdateRoomFacilityTransaction(context.Background(), postedRoomFacility)
 	assert.NotNil(suite.T(), actualErr)
 	assert.Error(suite.T(), actualErr)
 	assert.Equal(suite.T(), entity.RoomFacility{}, actualRoomFacility)
@@ -165,9 +174,9 @@ func (suite *RoomFacilityUseCaseTestSuite) TestUpdateRoomFacilityTransaction_Ins
 		CreatedAt:   time.Time{},
 		UpdatedAt:   time.Time{},
 	}
-	suite.rfrm.On("GetRoomFacilityById").Return(oldRoomFacility, http.StatusOK, nil)
-	suite.rfrm.On("GetQuantityFacilityByID").Return(0, http.StatusOK, nil)
-	actualRoomFacility, actualErr := suite.rfuc.UpdateRoomFacilityTransaction(postedRoomFacility)
+	suite.rfrm.On("GetRoomFacilityById", mock.Anything).Return(oldRoomFacility, http.StatusOK, nil)
+	suite.rfrm.On("GetQuantityFacilityByID", mock.Anything).Return(0, http.StatusOK, nil)
+	actualRoomFacility, actualErr := suite.rfuc.UpdateRoomFacilityTransaction(context.Background(), postedRoomFacility)
 	assert.NotNil(suite.T(), actualErr)
 	assert.Error(suite.T(), actualErr)
 	assert.Equal(suite.T(), entity.RoomFacility{}, actualRoomFacility)
@@ -189,14 +198,96 @@ func (suite *RoomFacilityUseCaseTestSuite) TestUpdateRoomFacilityTransaction_Upd
 		CreatedAt:   time.Time{},
 		UpdatedAt:   time.Time{},
 	}
-	suite.rfrm.On("GetRoomFacilityById").Return(oldRoomFacility, http.StatusOK, nil)
-	suite.rfrm.On("UpdateRoomFacility").Return(entity.RoomFacility{}, http.StatusInternalServerError, fmt.Errorf("failed to update room-facility"))
-	actualRoomFacility, actualErr := suite.rfuc.UpdateRoomFacilityTransaction(postedRoomFacility)
+	suite.rfrm.On("GetRoomFacilityById", mock.Anything).Return(oldRoomFacility, http.StatusOK, nil)
+	suite.rfrm.On("UpdateRoomFacility", mock.Anything).Return(entity.RoomFacility{}, http.StatusInternalServerError, fmt.Errorf("failed to update room-facility"))
+	actualRoomFacility, actualErr := suite.rfuc.UpdateRoomFacilityTransaction(context.Background(), postedRoomFacility)
 	assert.NotNil(suite.T(), actualErr)
 	assert.Error(suite.T(), actualErr)
 	assert.Equal(suite.T(), entity.RoomFacility{}, actualRoomFacility)
 }
 
+func (suite *RoomFacilityUseCaseTestSuite) TestUpdateRoomFacilityTransaction_ChecksBothFacilities() {
+	payload := entity.RoomFacility{ID: "id", FacilityId: "new facility id"}
+	updated := expectedRoomFacility
+	updated.FacilityId = payload.FacilityId
+	suite.rfrm.On("GetRoomFacilityById", mock.Anything, "id").Return(expectedRoomFacility, nil)
+	suite.rfrm.On("UpdateRoomFacility", mock.Anything, mock.AnythingOfType("entity.RoomFacility")).Return(updated, nil)
+	stockAlertUC := new(usecase_mock.StockAlertUseCaseMock)
+	stockAlertUC.On("CheckStock", mock.Anything, []string{"new facility id", "facility id"}).Return(fmt.Errorf("error"))
+
+	actual, err := NewRoomFacilityUsecase(suite.rfrm, stockAlertUC).UpdateRoomFacilityTransaction(context.Background(), payload)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), updated, actual)
+	stockAlertUC.AssertExpectations(suite.T())
+}
+
+func (suite *RoomFacilityUseCaseTestSuite) TestRemoveRoomFacility_Success() {
+	suite.rfrm.On("DeleteRoomFacility", mock.Anything, "id").Return(expectedRoomFacility, nil)
+	stockAlertUC := new(usecase_mock.StockAlertUseCaseMock)
+	stockAlertUC.On("CheckStock", mock.Anything, []string{"facility id"}).Return(nil)
+
+	err := NewRoomFacilityUsecase(suite.rfrm, stockAlertUC).RemoveRoomFacility(context.Background(), "id")
+
+	assert.NoError(suite.T(), err)
+	stockAlertUC.AssertExpectations(suite.T())
+}
+
+func (suite *RoomFacilityUseCaseTestSuite) TestRemoveRoomFacility_NotFoundFail() {
+	suite.rfrm.On("DeleteRoomFacility", mock.Anything, "id").Return(entity.RoomFacility{}, sql.ErrNoRows)
+
+	err := suite.rfuc.RemoveRoomFacility(context.Background(), "id")
+
+	assert.Equal(suite.T(), apperror.KindNotFound, apperror.KindOf(err))
+}
+
+func (suite *RoomFacilityUseCaseTestSuite) TestTransferRoomFacility_Success() {
+	payload := entity.RoomFacilityTransfer{RoomFacilityId: "id", RoomId: "other room id", Quantity: 2}
+	transfer := payload
+	transfer.Source = expectedRoomFacility
+	transfer.Target = entity.RoomFacility{ID: "target id", RoomId: "other room id", FacilityId: "facility id", Quantity: 2}
+	suite.rfrm.On("TransferRoomFacility", mock.Anything, payload).Return(transfer, nil)
+
+	actual, err := suite.rfuc.TransferRoomFacility(context.Background(), payload)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), transfer, actual)
+}
+
+func (suite *RoomFacilityUseCaseTestSuite) TestTransferRoomFacility_ValidationFail() {
+	_, err := suite.rfuc.TransferRoomFacility(context.Background(), entity.RoomFacilityTransfer{RoomFacilityId: "id"})
+
+	assert.Equal(suite.T(), apperror.KindValidation, apperror.KindOf(err))
+	suite.rfrm.AssertNotCalled(suite.T(), "TransferRoomFacility", mock.Anything, mock.Anything)
+}
+
+func (suite *RoomFacilityUseCaseTestSuite) TestTransferRoomFacility_InsufficientAllocationFail() {
+	payload := entity.RoomFacilityTransfer{RoomFacilityId: "id", RoomId: "other room id", Quantity: 9}
+	suite.rfrm.On("TransferRoomFacility", mock.Anything, payload).Return(entity.RoomFacilityTransfer{}, repository.ErrInsufficientAllocation)
+
+	_, err := suite.rfuc.TransferRoomFacility(context.Background(), payload)
+
+	assert.ErrorIs(suite.T(), err, ErrInsufficientAllocation)
+}
+
+func (suite *RoomFacilityUseCaseTestSuite) TestTransferRoomFacility_RoomOutOfServiceFail() {
+	payload := entity.RoomFacilityTransfer{RoomFacilityId: "id", RoomId: "other room id", Quantity: 1}
+	suite.rfrm.On("TransferRoomFacility", mock.Anything, payload).Return(entity.RoomFacilityTransfer{}, repository.ErrRoomOutOfService)
+
+	_, err := suite.rfuc.TransferRoomFacility(context.Background(), payload)
+
+	assert.ErrorIs(suite.T(), err, ErrRoomOutOfService)
+}
+
+func (suite *RoomFacilityUseCaseTestSuite) TestAddRoomFacilityTransaction_LockedStockShortFail() {
+	suite.rfrm.On("CreateRoomFacility", mock.Anything, expectedRoomFacility).Return(entity.RoomFacility{}, repository.ErrInsufficientStock)
+
+	_, err := suite.rfuc.AddRoomFacilityTransaction(context.Background(), expectedRoomFacility)
+
+	assert.ErrorIs(suite.T(), err, ErrInsufficientStock)
+	suite.rfrm.AssertNotCalled(suite.T(), "GetQuantityFacilityByID", mock.Anything, mock.Anything)
+}
+
 func TestRoomFacilityUseCaseTestSuite(t *testing.T) {
 	suite.Run(t, new(RoomFacilityUseCaseTestSuite))
 }
@@ -3,84 +3,211 @@ package usecase
 import (
 	"booking-room-app/entity"
 	"booking-room-app/repository"
+	"booking-room-app/shared/apperror"
 	"booking-room-app/shared/model"
-	"fmt"
+	"context"
 	"strings"
+	"time"
 )
 
 type RoomUseCase interface {
-	RegisterNewRoom(payload entity.Room) (entity.Room, error)
-	FindRoomByID(id string) (entity.Room, error)
-	FindAllRoom(page, size int) ([]entity.Room, model.Paging, error)
-	FindAllRoomStatus(status string, page, size int) ([]entity.Room, model.Paging, error)
-	UpdateRoomDetail(payload entity.Room) (entity.Room, error)
-	UpdateRoomStatus(payload entity.Room) (entity.Room, error)
+	RegisterNewRoom(ctx context.Context, payload entity.Room) (entity.Room, error)
+	FindRoomByID(ctx context.Context, id string) (entity.Room, error)
+	FindAllRoom(ctx context.Context, page, size int) ([]entity.Room, model.Paging, error)
+	FindAllRoomStatus(ctx context.Context, status string, page, size int) ([]entity.Room, model.Paging, error)
+	FindRoomsByLocation(ctx context.Context, filter entity.LocationFilter, status string, page, size int) ([]entity.Room, model.Paging, error)
+	FindAvailableRooms(ctx context.Context, filter entity.LocationFilter, start, end time.Time, page, size int) ([]entity.Room, model.Paging, error)
+	UpdateRoomDetail(ctx context.Context, payload entity.Room) (entity.Room, error)
+	UpdateRoomStatus(ctx context.Context, payload entity.Room) (entity.Room, error)
+	ArchiveRoom(ctx context.Context, id string) (entity.Archival, error)
+	RestoreRoom(ctx context.Context, id string) (entity.Room, error)
+	FindArchivedRooms(ctx context.Context, page, size int) ([]entity.Room, model.Paging, error)
 }
 
 type roomUseCase struct {
-	repo repository.RoomRepository
+	repo          repository.RoomRepository
+	archivePolicy string
 }
 
 // FindAllRoom implements RoomUseCase.
-func (r *roomUseCase) FindAllRoom(page, size int) ([]entity.Room, model.Paging, error) {
-	return r.repo.List(page, size)
+func (r *roomUseCase) FindAllRoom(ctx context.Context, page, size int) ([]entity.Room, model.Paging, error) {
+	ctx, span := startSpan(ctx, "roomUseCase.FindAllRoom")
+	defer span.End()
+
+	rooms, paging, err := r.repo.List(ctx, page, size)
+	if err != nil {
+		return nil, model.Paging{}, dbError(err, "room")
+	}
+	return rooms, paging, nil
 }
 
 // FindAllRoomStatus implements RoomUseCase.
-func (r *roomUseCase) FindAllRoomStatus(status string, page, size int) ([]entity.Room, model.Paging, error) {
-	return r.repo.ListStatus(status, page, size)
+func (r *roomUseCase) FindAllRoomStatus(ctx context.Context, status string, page, size int) ([]entity.Room, model.Paging, error) {
+	ctx, span := startSpan(ctx, "roomUseCase.FindAllRoomStatus")
+	defer span.End()
+
+	rooms, paging, err := r.repo.ListStatus(ctx, status, page, size)
+	if err != nil {
+		return nil, model.Paging{}, dbError(err, "room")
+	}
+	return rooms, paging, nil
+}
+
+// FindRoomsByLocation lists the rooms at a site, building or floor, optionally
+// only those with the given status.
+func (r *roomUseCase) FindRoomsByLocation(ctx context.Context, filter entity.LocationFilter, status string, page, size int) ([]entity.Room, model.Paging, error) {
+	ctx, span := startSpan(ctx, "roomUseCase.FindRoomsByLocation")
+	defer span.End()
+
+	rooms, paging, err := r.repo.ListByLocation(ctx, filter, strings.ToLower(status), page, size)
+	if err != nil {
+		return nil, model.Paging{}, dbError(err, "room")
+	}
+	return rooms, paging, nil
+}
+
+// FindAvailableRooms lists the rooms at the location that can be booked from
+// start to end: available, not booked for the period, and open according to
+// their calendar.
+func (r *roomUseCase) FindAvailableRooms(ctx context.Context, filter entity.LocationFilter, start, end time.Time, page, size int) ([]entity.Room, model.Paging, error) {
+	ctx, span := startSpan(ctx, "roomUseCase.FindAvailableRooms")
+	defer span.End()
+
+	var problems []apperror.FieldError
+	if start.IsZero() {
+		problems = append(problems, apperror.RequiredField("startTime"))
+	}
+	if !end.After(start) {
+		problems = append(problems, apperror.Field("endTime", "gtfield", "endTime must be after startTime"))
+	}
+	if err := invalid(problems); err != nil {
+		return nil, model.Paging{}, err
+	}
+
+	rooms, paging, err := r.repo.ListAvailable(ctx, filter, start, end, page, size)
+	if err != nil {
+		return nil, model.Paging{}, dbError(err, "room")
+	}
+	return rooms, paging, nil
 }
 
 // FindRoomByID implements RoomUseCase.
-func (r *roomUseCase) FindRoomByID(id string) (entity.Room, error) {
-	return r.repo.Get(id)
+func (r *roomUseCase) FindRoomByID(ctx context.Context, id string) (entity.Room, error) {
+	ctx, span := startSpan(ctx, "roomUseCase.FindRoomByID")
+	defer span.End()
+
+	room, err := r.repo.Get(ctx, id)
+	if err != nil {
+		return entity.Room{}, dbError(err, "room")
+	}
+	return room, nil
 }
 
 // RegisterNewRoom implements RoomUseCase.
-func (r *roomUseCase) RegisterNewRoom(payload entity.Room) (entity.Room, error) {
-	if payload.Name == "" || payload.RoomType == "" || payload.Capacity == 0 || payload.Status == "" {
-		return entity.Room{}, fmt.Errorf("oops, field required")
+func (r *roomUseCase) RegisterNewRoom(ctx context.Context, payload entity.Room) (entity.Room, error) {
+	ctx, span := startSpan(ctx, "roomUseCase.RegisterNewRoom")
+	defer span.End()
+
+	if err := validateRoom(payload, false); err != nil {
+		return entity.Room{}, err
 	}
 
 	payload.Status = strings.ToLower(payload.Status)
 
-	room, err := r.repo.Create(payload)
+	room, err := r.repo.Create(ctx, payload)
 	if err != nil {
-		return entity.Room{}, fmt.Errorf("failed to create a new room list: %v", err.Error())
+		return entity.Room{}, dbError(err, "room")
 	}
 	return room, nil
 }
 
 // UpdateRoomDetail implements RoomUseCase.
-func (r *roomUseCase) UpdateRoomDetail(payload entity.Room) (entity.Room, error) {
-	if payload.ID == "" || payload.Name == "" || payload.RoomType == "" || payload.Capacity == 0 || payload.Status == "" {
-		return entity.Room{}, fmt.Errorf("oops, field required")
+func (r *roomUseCase) UpdateRoomDetail(ctx context.Context, payload entity.Room) (entity.Room, error) {
+	ctx, span := startSpan(ctx, "roomUseCase.UpdateRoomDetail")
+	defer span.End()
+
+	if err := validateRoom(payload, true); err != nil {
+		return entity.Room{}, err
 	}
 
 	payload.Status = strings.ToLower(payload.Status)
 
-	room, err := r.repo.Update(payload)
+	room, err := r.repo.Update(ctx, payload)
 	if err != nil {
-		return entity.Room{}, fmt.Errorf("failed to update room with ID %s: %v", payload.ID, err.Error())
+		return entity.Room{}, dbError(err, "room")
 	}
 	return room, nil
 }
 
 // UpdateRoomStatus implements RoomUseCase.
-func (r *roomUseCase) UpdateRoomStatus(payload entity.Room) (entity.Room, error) {
-	if payload.ID == "" || payload.Status == "" {
-		return entity.Room{}, fmt.Errorf("oops, field required")
+func (r *roomUseCase) UpdateRoomStatus(ctx context.Context, payload entity.Room) (entity.Room, error) {
+	ctx, span := startSpan(ctx, "roomUseCase.UpdateRoomStatus")
+	defer span.End()
+
+	if missing := missingFields("id", payload.ID, "status", payload.Status); len(missing) > 0 {
+		return entity.Room{}, apperror.Required(missing...)
 	}
 
 	payload.Status = strings.ToLower(payload.Status)
 
-	room, err := r.repo.UpdateStatus(payload)
+	room, err := r.repo.UpdateStatus(ctx, payload)
+	if err != nil {
+		return entity.Room{}, dbError(err, "room")
+	}
+	return room, nil
+}
+
+// ArchiveRoom implements RoomUseCase.
+func (r *roomUseCase) ArchiveRoom(ctx context.Context, id string) (entity.Archival, error) {
+	ctx, span := startSpan(ctx, "roomUseCase.ArchiveRoom")
+	defer span.End()
+
+	archival, err := r.repo.Archive(ctx, id, cascadeArchive(r.archivePolicy))
+	if err != nil {
+		return entity.Archival{}, dbError(err, "room")
+	}
+	declinedByArchive(archival)
+	return archival, nil
+}
+
+// RestoreRoom implements RoomUseCase.
+func (r *roomUseCase) RestoreRoom(ctx context.Context, id string) (entity.Room, error) {
+	ctx, span := startSpan(ctx, "roomUseCase.RestoreRoom")
+	defer span.End()
+
+	room, err := r.repo.Restore(ctx, id)
 	if err != nil {
-		return entity.Room{}, fmt.Errorf("failed to update room with ID %s: %v", payload.ID, err.Error())
+		return entity.Room{}, dbError(err, "room")
 	}
 	return room, nil
 }
 
-func NewRoomUseCase(repo repository.RoomRepository) RoomUseCase {
-	return &roomUseCase{repo: repo}
+// FindArchivedRooms implements RoomUseCase.
+func (r *roomUseCase) FindArchivedRooms(ctx context.Context, page, size int) ([]entity.Room, model.Paging, error) {
+	ctx, span := startSpan(ctx, "roomUseCase.FindArchivedRooms")
+	defer span.End()
+
+	rooms, paging, err := r.repo.ListArchived(ctx, page, size)
+	if err != nil {
+		return nil, model.Paging{}, dbError(err, "room")
+	}
+	return rooms, paging, nil
+}
+
+func validateRoom(payload entity.Room, update bool) error {
+	var problems []apperror.FieldError
+	if update && payload.ID == "" {
+		problems = append(problems, apperror.RequiredField("id"))
+	}
+	for _, field := range missingFields("name", payload.Name, "room_type", payload.RoomType, "status", payload.Status) {
+		problems = append(problems, apperror.RequiredField(field))
+	}
+	if payload.Capacity <= 0 {
+		problems = append(problems, apperror.Field("capacity", "min", "capacity must be greater than zero"))
+	}
+	return invalid(problems)
+}
+
+func NewRoomUseCase(repo repository.RoomRepository, archivePolicy string) RoomUseCase {
+	return &roomUseCase{repo: repo, archivePolicy: archivePolicy}
 }
@@ -3,12 +3,17 @@ package usecase
 import (
 	"booking-room-app/entity"
 	"booking-room-app/mock/repo_mock"
+	"booking-room-app/repository"
+	"booking-room-app/shared/apperror"
 	"booking-room-app/shared/model"
+	"context"
+	"database/sql"
 	"fmt"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -61,13 +66,13 @@ type RoomUseCaseTestSuite struct {
 
 func (suite *RoomUseCaseTestSuite) SetupTest() {
 	suite.rrm = new(repo_mock.RoomRepoMock)
-	suite.ruc = NewRoomUseCase(suite.rrm)
+	suite.ruc = NewRoomUseCase(suite.rrm, ArchiveRefuse)
 }
 
 func (suite *RoomUseCaseTestSuite) TestFindAllRoom() {
-	suite.rrm.On("List", page, size).Return(expectedRooms, expectedPaging, nil)
+	suite.rrm.On("List", mock.Anything, page, size).Return(expectedRooms, expectedPaging, nil)
 
-	actual, paging, err := suite.ruc.FindAllRoom(page, size)
+	actual, paging, err := suite.ruc.FindAllRoom(context.Background(), page, size)
 
 	assert.Nil(suite.T(), err)
 	assert.NoError(suite.T(), err)
@@ -76,9 +81,9 @@ func (suite *RoomUseCaseTestSuite) TestFindAllRoom() {
 }
 
 func (suite *RoomUseCaseTestSuite) TestFindAllRoomStatus() {
-	suite.rrm.On("ListStatus", expectedRoom.Status, page, size).Return(expectedRooms, expectedPaging, nil)
+	suite.rrm.On("ListStatus", mock.Anything, expectedRoom.Status, page, size).Return(expectedRooms, expectedPaging, nil)
 
-	actual, paging, err := suite.ruc.FindAllRoomStatus(expectedRoom.Status, page, size)
+	actual, paging, err := suite.ruc.FindAllRoomStatus(context.Background(), expectedRoom.Status, page, size)
 
 	assert.Nil(suite.T(), err)
 	assert.NoError(suite.T(), err)
@@ -87,9 +92,9 @@ func (suite *RoomUseCaseTestSuite) TestFindAllRoomStatus() {
 }
 
 func (suite *RoomUseCaseTestSuite) TestFindroomByID() {
-	suite.rrm.On("Get", expectedRoom.ID).Return(expectedRoom, nil)
+	suite.rrm.On("Get", mock.Anything, expectedRoom.ID).Return(expectedRoom, nil)
 
-	actual, err := suite.ruc.FindRoomByID(expectedRoom.ID)
+	actual, err := suite.ruc.FindRoomByID(context.Background(), expectedRoom.ID)
 
 	assert.Nil(suite.T(), err)
 	assert.NoError(suite.T(), err)
@@ -97,9 +102,9 @@ func (suite *RoomUseCaseTestSuite) TestFindroomByID() {
 }
 
 func (suite *RoomUseCaseTestSuite) TestRegisterNewRoom_Success() {
-	suite.rrm.On("Create", expectedRoom).Return(expectedRoom, nil)
+	suite.rrm.On("Create", mock.Anything, expectedRoom).Return(expectedRoom, nil)
 
-	actual, err := suite.ruc.RegisterNewRoom(expectedRoom)
+	actual, err := suite.ruc.RegisterNewRoom(context.Background(), expectedRoom)
 
 	assert.Nil(suite.T(), err)
 	assert.NoError(suite.T(), err)
@@ -110,9 +115,9 @@ func (suite *RoomUseCaseTestSuite) TestRegisterNewRoom_EmptyStatusSuccess() {
 	var expectedPayload entity.Room = expectedRoom
 	expectedRoom.Status = ""
 
-	suite.rrm.On("Create", expectedPayload).Return(expectedPayload, nil)
+	suite.rrm.On("Create", mock.Anything, expectedPayload).Return(expectedPayload, nil)
 
-	_, err := suite.ruc.RegisterNewRoom(expectedRoom)
+	_, err := suite.ruc.RegisterNewRoom(context.Background(), expectedRoom)
 	expectedRoom.Status = "available"
 
 	assert.Nil(suite.T(), err)
@@ -120,9 +125,9 @@ func (suite *RoomUseCaseTestSuite) TestRegisterNewRoom_EmptyStatusSuccess() {
 }
 
 func (suite *RoomUseCaseTestSuite) TestRegisterNewRoom_Failure() {
-	suite.rrm.On("Create", expectedRoom).Return(entity.Room{}, fmt.Errorf("error"))
+	suite.rrm.On("Create", mock.Anything, expectedRoom).Return(entity.Room{}, fmt.Errorf("error"))
 
-	_, err := suite.ruc.RegisterNewRoom(expectedRoom)
+	_, err := suite.ruc.RegisterNewRoom(context.Background(), expectedRoom)
 
 	assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
@@ -130,7 +135,7 @@ func (suite *RoomUseCaseTestSuite) TestRegisterNewRoom_Failure() {
 
 func (suite *RoomUseCaseTestSuite) TestRegisterNewRoom_EmptyFieldFailure() {
 	expectedRoom.Name = ""
-	_, err := suite.ruc.RegisterNewRoom(expectedRoom)
+	_, err := suite.ruc.RegisterNewRoom(context.Background(), expectedRoom)
 	expectedRoom.Name = "Ruang Candradimuka"
 
 	assert.NotNil(suite.T(), err)
@@ -138,9 +143,9 @@ func (suite *RoomUseCaseTestSuite) TestRegisterNewRoom_EmptyFieldFailure() {
 }
 
 func (suite *RoomUseCaseTestSuite) TestUpdateRoomDetail_Success() {
-	suite.rrm.On("Update", expectedRoom).Return(expectedRoom, nil)
+	suite.rrm.On("Update", mock.Anything, expectedRoom).Return(expectedRoom, nil)
 
-	actual, err := suite.ruc.UpdateRoomDetail(expectedRoom)
+	actual, err := suite.ruc.UpdateRoomDetail(context.Background(), expectedRoom)
 
 	assert.Nil(suite.T(), err)
 	assert.NoError(suite.T(), err)
@@ -148,9 +153,9 @@ func (suite *RoomUseCaseTestSuite) TestUpdateRoomDetail_Success() {
 }
 
 func (suite *RoomUseCaseTestSuite) TestUpdateRoomDetail_Failure() {
-	suite.rrm.On("Update", expectedRoom).Return(entity.Room{}, fmt.Errorf("error"))
+	suite.rrm.On("Update", mock.Anything, expectedRoom).Return(entity.Room{}, fmt.Errorf("error"))
 
-	_, err := suite.ruc.UpdateRoomDetail(expectedRoom)
+	_, err := suite.ruc.UpdateRoomDetail(context.Background(), expectedRoom)
 
 	assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
@@ -158,7 +163,7 @@ func (suite *RoomUseCaseTestSuite) TestUpdateRoomDetail_Failure() {
 
 func (suite *RoomUseCaseTestSuite) TestUpdateRoomDetail_EmptyFieldFailure() {
 	expectedRoom.Name = ""
-	_, err := suite.ruc.UpdateRoomDetail(expectedRoom)
+	_, err := suite.ruc.UpdateRoomDetail(context.Background(), expectedRoom)
 	expectedRoom.Name = "Ruang Candradimuka"
 
 	assert.NotNil(suite.T(), err)
@@ -169,9 +174,9 @@ func (suite *RoomUseCaseTestSuite) TestUpdateRoomDetail_EmptyStatusSuccess() {
 	var expectedPayload entity.Room = expectedRoom
 	expectedRoom.Status = ""
 
-	suite.rrm.On("Update", expectedPayload).Return(expectedPayload, nil)
+	suite.rrm.On("Update", mock.Anything, expectedPayload).Return(expectedPayload, nil)
 
-	_, err := suite.ruc.UpdateRoomDetail(expectedRoom)
+	_, err := suite.ruc.UpdateRoomDetail(context.Background(), expectedRoom)
 	expectedRoom.Status = "available"
 
 	assert.Nil(suite.T(), err)
@@ -179,9 +184,9 @@ func (suite *RoomUseCaseTestSuite) TestUpdateRoomDetail_EmptyStatusSuccess() {
 }
 
 func (suite *RoomUseCaseTestSuite) TestUpdateRoomStatus_Success() {
-	suite.rrm.On("UpdateStatus", expectedRoom).Return(expectedRoom, nil)
+	suite.rrm.On("UpdateStatus", mock.Anything, expectedRoom).Return(expectedRoom, nil)
 
-	actual, err := suite.ruc.UpdateRoomStatus(expectedRoom)
+	actual, err := suite.ruc.UpdateRoomStatus(context.Background(), expectedRoom)
 
 	assert.Nil(suite.T(), err)
 	assert.NoError(suite.T(), err)
@@ -189,9 +194,9 @@ func (suite *RoomUseCaseTestSuite) TestUpdateRoomStatus_Success() {
 }
 
 func (suite *RoomUseCaseTestSuite) TestUpdateRoomStatus_Failure() {
-	suite.rrm.On("UpdateStatus", expectedRoom).Return(entity.Room{}, fmt.Errorf("error"))
+	suite.rrm.On("UpdateStatus", mock.Anything, expectedRoom).Return(entity.Room{}, fmt.Errorf("error"))
 
-	_, err := suite.ruc.UpdateRoomStatus(expectedRoom)
+	_, err := suite.ruc.UpdateRoomStatus(context.Background(), expectedRoom)
 
 	assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
@@ -199,7 +204,7 @@ func (suite *RoomUseCaseTestSuite) TestUpdateRoomStatus_Failure() {
 
 func (suite *RoomUseCaseTestSuite) TestUpdateRoomStatus_EmptyFieldFailure() {
 	expectedRoom.ID = ""
-	_, err := suite.ruc.UpdateRoomStatus(expectedRoom)
+	_, err := suite.ruc.UpdateRoomStatus(context.Background(), expectedRoom)
 	expectedRoom.ID = "a3d8e4ef-2e85-4ea5-9509-795f256226c3"
 
 	assert.NotNil(suite.T(), err)
@@ -210,15 +215,106 @@ func (suite *RoomUseCaseTestSuite) TestUpdateRoomStatus_EmptyStatusSuccess() {
 	var expectedPayload entity.Room = expectedRoom
 	expectedRoom.Status = ""
 
-	suite.rrm.On("UpdateStatus", expectedPayload).Return(expectedPayload, nil)
+	suite.rrm.On("UpdateStatus", mock.Anything, expectedPayload).Return(expectedPayload, nil)
 
-	_, err := suite.ruc.UpdateRoomStatus(expectedRoom)
+	_, err := suite.ruc.UpdateRoomStatus(context.Background(), expectedRoom)
 	expectedRoom.Status = "available"
 
 	assert.Nil(suite.T(), err)
 	assert.NoError(suite.T(), err)
 }
 
+func (suite *RoomUseCaseTestSuite) TestArchiveRoom_Success() {
+	archival := entity.Archival{ID: expectedRoom.ID, ArchivedAt: time.Now(), DeclinedTransactions: []string{}}
+	suite.rrm.On("Archive", mock.Anything, expectedRoom.ID, false).Return(archival, nil)
+
+	actual, err := suite.ruc.ArchiveRoom(context.Background(), expectedRoom.ID)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), archival, actual)
+}
+
+func (suite *RoomUseCaseTestSuite) TestArchiveRoom_CascadeSuccess() {
+	ruc := NewRoomUseCase(suite.rrm, ArchiveCascade)
+	archival := entity.Archival{ID: expectedRoom.ID, ArchivedAt: time.Now(), DeclinedTransactions: []string{"t1"}}
+	suite.rrm.On("Archive", mock.Anything, expectedRoom.ID, true).Return(archival, nil)
+
+	actual, err := ruc.ArchiveRoom(context.Background(), expectedRoom.ID)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []string{"t1"}, actual.DeclinedTransactions)
+}
+
+func (suite *RoomUseCaseTestSuite) TestArchiveRoom_OpenTransactionsFail() {
+	suite.rrm.On("Archive", mock.Anything, expectedRoom.ID, false).Return(entity.Archival{}, fmt.Errorf("%w: 1 open bookings", repository.ErrOpenTransactions))
+
+	_, err := suite.ruc.ArchiveRoom(context.Background(), expectedRoom.ID)
+
+	assert.Equal(suite.T(), apperror.KindConflict, apperror.KindOf(err))
+	assert.Equal(suite.T(), "open_transactions", apperror.From(err).Code)
+}
+
+func (suite *RoomUseCaseTestSuite) TestRestoreRoom_NotFoundFail() {
+	suite.rrm.On("Restore", mock.Anything, expectedRoom.ID).Return(entity.Room{}, sql.ErrNoRows)
+
+	_, err := suite.ruc.RestoreRoom(context.Background(), expectedRoom.ID)
+
+	assert.Equal(suite.T(), apperror.KindNotFound, apperror.KindOf(err))
+}
+
+func (suite *RoomUseCaseTestSuite) TestFindArchivedRooms_Success() {
+	suite.rrm.On("ListArchived", mock.Anything, page, size).Return(expectedRooms, expectedPaging, nil)
+
+	actual, paging, err := suite.ruc.FindArchivedRooms(context.Background(), page, size)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expectedRooms, actual)
+	assert.Equal(suite.T(), expectedPaging, paging)
+}
+
+func (suite *RoomUseCaseTestSuite) TestFindRoomsByLocation_Success() {
+	filter := entity.LocationFilter{BuildingId: "2"}
+	suite.rrm.On("ListByLocation", mock.Anything, filter, "available", page, size).Return(expectedRooms, expectedPaging, nil)
+
+	actual, paging, err := suite.ruc.FindRoomsByLocation(context.Background(), filter, "Available", page, size)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expectedRooms, actual)
+	assert.Equal(suite.T(), expectedPaging, paging)
+}
+
+func (suite *RoomUseCaseTestSuite) TestFindRoomsByLocation_Fail() {
+	suite.rrm.On("ListByLocation", mock.Anything, mock.Anything, "", page, size).Return([]entity.Room{}, model.Paging{}, fmt.Errorf("error"))
+
+	_, _, err := suite.ruc.FindRoomsByLocation(context.Background(), entity.LocationFilter{SiteId: "1"}, "", page, size)
+
+	assert.Equal(suite.T(), apperror.KindInternal, apperror.KindOf(err))
+}
+
+func (suite *RoomUseCaseTestSuite) TestFindAvailableRooms_Success() {
+	filter := entity.LocationFilter{SiteId: "1"}
+	start := time.Date(2024, time.January, 8, 9, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	rooms := []entity.Room{{ID: "3", Name: "Mawar"}}
+	paging := model.Paging{Page: 2, RowsPerPage: 1, TotalRows: 2, TotalPages: 2}
+	suite.rrm.On("ListAvailable", mock.Anything, filter, start, end, 2, 1).Return(rooms, paging, nil)
+
+	actual, actualPaging, err := suite.ruc.FindAvailableRooms(context.Background(), filter, start, end, 2, 1)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), rooms, actual)
+	assert.Equal(suite.T(), paging, actualPaging)
+}
+
+func (suite *RoomUseCaseTestSuite) TestFindAvailableRooms_InvalidPeriodFail() {
+	start := time.Now()
+
+	_, _, err := suite.ruc.FindAvailableRooms(context.Background(), entity.LocationFilter{}, start, start, page, size)
+
+	assert.Equal(suite.T(), apperror.KindValidation, apperror.KindOf(err))
+	suite.rrm.AssertNotCalled(suite.T(), "ListAvailable", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestRoomUseCaseTestSuite(t *testing.T) {
 	suite.Run(t, new(RoomUseCaseTestSuite))
 }
@@ -0,0 +1,168 @@
+package usecase
+
+import (
+	"booking-room-app/entity"
+	"booking-room-app/repository"
+	"booking-room-app/shared/apperror"
+	"booking-room-app/shared/logger"
+	"booking-room-app/shared/model"
+	"booking-room-app/shared/service"
+	"context"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+)
+
+type StockAlertUseCase interface {
+	FindThreshold(ctx context.Context, facilityId string) (entity.StockThreshold, error)
+	UpdateThreshold(ctx context.Context, payload entity.StockThreshold) (entity.StockThreshold, error)
+	CheckStock(ctx context.Context, facilityIds ...string) error
+	CheckStockLevels(ctx context.Context, now time.Time) error
+	SendAlerts(ctx context.Context, now time.Time) error
+	FindAlerts(ctx context.Context, status string, page, size int) ([]entity.StockAlert, model.Paging, error)
+	AcknowledgeAlert(ctx context.Context, id string) (entity.StockAlert, error)
+}
+
+type stockAlertUseCase struct {
+	repo        repository.StockAlertRepository
+	mailService service.MailService
+	recipients  []string
+}
+
+// FindThreshold implements StockAlertUseCase.
+func (s *stockAlertUseCase) FindThreshold(ctx context.Context, facilityId string) (entity.StockThreshold, error) {
+	ctx, span := startSpan(ctx, "stockAlertUseCase.FindThreshold")
+	defer span.End()
+
+	threshold, err := s.repo.GetThreshold(ctx, facilityId)
+	if err != nil {
+		return entity.StockThreshold{}, dbError(err, "facility")
+	}
+	return threshold, nil
+}
+
+// UpdateThreshold implements StockAlertUseCase. The facility is checked
+// against its new threshold right away, a failing check does not undo the
+// saved threshold.
+func (s *stockAlertUseCase) UpdateThreshold(ctx context.Context, payload entity.StockThreshold) (entity.StockThreshold, error) {
+	ctx, span := startSpan(ctx, "stockAlertUseCase.UpdateThreshold")
+	defer span.End()
+
+	if payload.MinQuantity < 0 {
+		return entity.StockThreshold{}, invalid([]apperror.FieldError{apperror.Field("minQuantity", "gte", "minQuantity must not be negative")})
+	}
+
+	threshold, err := s.repo.UpdateThreshold(ctx, payload)
+	if err != nil {
+		return entity.StockThreshold{}, dbError(err, "facility")
+	}
+	checkStockAfter(ctx, s, threshold.FacilityId)
+	return threshold, nil
+}
+
+// CheckStock implements StockAlertUseCase. The given facilities, or all of
+// them when none is given, raise an alert when their stock is below their
+// threshold and resolve it when it is back. The new alerts wait for
+// SendAlerts, so that no mail is sent while a request is served.
+func (s *stockAlertUseCase) CheckStock(ctx context.Context, facilityIds ...string) error {
+	ctx, span := startSpan(ctx, "stockAlertUseCase.CheckStock")
+	defer span.End()
+
+	alerts, err := s.repo.Check(ctx, facilityIds)
+	if err != nil {
+		return dbError(err, "stock alert")
+	}
+	for _, alert := range alerts {
+		slog.InfoContext(ctx, "low stock alert raised", "facilityId", alert.FacilityId, "quantity", alert.Quantity, "minQuantity", alert.MinQuantity)
+	}
+	return nil
+}
+
+// CheckStockLevels checks every facility, catching the stock that went below
+// its threshold outside of the allocations and the alerts whose notification
+// failed, then sends the alerts.
+func (s *stockAlertUseCase) CheckStockLevels(ctx context.Context, now time.Time) error {
+	if err := s.CheckStock(ctx); err != nil {
+		return err
+	}
+	return s.SendAlerts(ctx, now)
+}
+
+// FindAlerts implements StockAlertUseCase, status is open, resolved or empty
+// for both.
+func (s *stockAlertUseCase) FindAlerts(ctx context.Context, status string, page, size int) ([]entity.StockAlert, model.Paging, error) {
+	ctx, span := startSpan(ctx, "stockAlertUseCase.FindAlerts")
+	defer span.End()
+
+	alerts, paging, err := s.repo.List(ctx, status, page, size)
+	if err != nil {
+		return nil, model.Paging{}, dbError(err, "stock alert")
+	}
+	return alerts, paging, nil
+}
+
+// AcknowledgeAlert implements StockAlertUseCase. The alert stays open until
+// the stock is back at its threshold.
+func (s *stockAlertUseCase) AcknowledgeAlert(ctx context.Context, id string) (entity.StockAlert, error) {
+	ctx, span := startSpan(ctx, "stockAlertUseCase.AcknowledgeAlert")
+	defer span.End()
+
+	alert, err := s.repo.Acknowledge(ctx, id, logger.UserID(ctx))
+	if err != nil {
+		return entity.StockAlert{}, dbError(err, "stock alert")
+	}
+	return alert, nil
+}
+
+// SendAlerts implements StockAlertUseCase. It mails the open alerts GA was not
+// told about yet. They are claimed before sending so that each is sent once,
+// and released when sending fails so that the next run retries them.
+func (s *stockAlertUseCase) SendAlerts(ctx context.Context, now time.Time) error {
+	if s.mailService == nil || len(s.recipients) == 0 {
+		return nil
+	}
+
+	alerts, err := s.repo.ClaimNotifications(ctx)
+	if err != nil {
+		return dbError(err, "stock alert")
+	}
+	if len(alerts) == 0 {
+		return nil
+	}
+
+	subject := fmt.Sprintf("[Reservify] %d facilities are low on stock", len(alerts))
+	if len(alerts) == 1 {
+		subject = fmt.Sprintf("[Reservify] Low stock: %s", alerts[0].FacilityName)
+	}
+	var body strings.Builder
+	ids := make([]string, 0, len(alerts))
+	for _, alert := range alerts {
+		fmt.Fprintf(&body, "%s: %d left, minimum %d\n", alert.FacilityName, alert.Quantity, alert.MinQuantity)
+		ids = append(ids, alert.ID)
+	}
+
+	if err := s.mailService.Send(ctx, model.MailMessage{To: s.recipients, Subject: subject, Body: body.String()}); err != nil {
+		if err := s.repo.UnclaimNotifications(ctx, ids); err != nil {
+			slog.ErrorContext(ctx, "stockAlertUseCase.SendAlerts", "err", err)
+		}
+		return fmt.Errorf("oops, failed to send stock alerts: %v", err.Error())
+	}
+	return nil
+}
+
+// checkStockAfter checks the facilities whose stock a change moved. The change
+// is already saved, so a failing check is logged and left to the periodic one;
+// the alerts it raises are mailed by the stock alert scheduler.
+func checkStockAfter(ctx context.Context, stockAlertUC StockAlertUseCase, facilityIds ...string) {
+	if stockAlertUC == nil || len(facilityIds) == 0 {
+		return
+	}
+	if err := stockAlertUC.CheckStock(ctx, facilityIds...); err != nil {
+		slog.WarnContext(ctx, "stock check failed", "facilityIds", facilityIds, "err", err)
+	}
+}
+
+func NewStockAlertUseCase(repo repository.StockAlertRepository, mailService service.MailService, recipients []string) StockAlertUseCase {
+	return &stockAlertUseCase{repo: repo, mailService: mailService, recipients: recipients}
+}
@@ -0,0 +1,158 @@
+package usecase
+
+import (
+	"booking-room-app/entity"
+	"booking-room-app/mock/repo_mock"
+	"booking-room-app/mock/service_mock"
+	"booking-room-app/shared/apperror"
+	"booking-room-app/shared/logger"
+	"booking-room-app/shared/model"
+	"context"
+	"database/sql"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/suite"
+)
+
+var expectedAlert = entity.StockAlert{
+	ID:           "1",
+	FacilityId:   "2",
+	FacilityName: "HDMI adapter",
+	Quantity:     1,
+	MinQuantity:  3,
+}
+
+type StockAlertUseCaseTestSuite struct {
+	suite.Suite
+	sarm *repo_mock.StockAlertRepoMock
+	msm  *service_mock.MailServiceMock
+	sauc StockAlertUseCase
+}
+
+func (suite *StockAlertUseCaseTestSuite) SetupTest() {
+	suite.sarm = new(repo_mock.StockAlertRepoMock)
+	suite.msm = new(service_mock.MailServiceMock)
+	suite.sauc = NewStockAlertUseCase(suite.sarm, suite.msm, []string{"ga@example.com"})
+}
+
+func (suite *StockAlertUseCaseTestSuite) TestUpdateThreshold_Success() {
+	threshold := entity.StockThreshold{FacilityId: "2", MinQuantity: 3, Quantity: 1, UpdatedAt: time.Now()}
+	suite.sarm.On("UpdateThreshold", mock.Anything, entity.StockThreshold{FacilityId: "2", MinQuantity: 3}).Return(threshold, nil)
+	suite.sarm.On("Check", mock.Anything, []string{"2"}).Return([]entity.StockAlert{expectedAlert}, nil)
+
+	actual, err := suite.sauc.UpdateThreshold(context.Background(), entity.StockThreshold{FacilityId: "2", MinQuantity: 3})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), threshold, actual)
+	suite.sarm.AssertExpectations(suite.T())
+	suite.msm.AssertNotCalled(suite.T(), "Send", mock.Anything, mock.Anything)
+}
+
+func (suite *StockAlertUseCaseTestSuite) TestUpdateThreshold_CheckFailureSuccess() {
+	threshold := entity.StockThreshold{FacilityId: "2", MinQuantity: 3, Quantity: 1, UpdatedAt: time.Now()}
+	suite.sarm.On("UpdateThreshold", mock.Anything, mock.Anything).Return(threshold, nil)
+	suite.sarm.On("Check", mock.Anything, []string{"2"}).Return([]entity.StockAlert(nil), fmt.Errorf("error"))
+
+	actual, err := suite.sauc.UpdateThreshold(context.Background(), entity.StockThreshold{FacilityId: "2", MinQuantity: 3})
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), threshold, actual)
+}
+
+func (suite *StockAlertUseCaseTestSuite) TestUpdateThreshold_NegativeFailure() {
+	_, err := suite.sauc.UpdateThreshold(context.Background(), entity.StockThreshold{FacilityId: "2", MinQuantity: -1})
+
+	assert.Equal(suite.T(), apperror.KindValidation, apperror.KindOf(err))
+	suite.sarm.AssertNotCalled(suite.T(), "UpdateThreshold", mock.Anything, mock.Anything)
+}
+
+func (suite *StockAlertUseCaseTestSuite) TestUpdateThreshold_NotFoundFailure() {
+	suite.sarm.On("UpdateThreshold", mock.Anything, mock.Anything).Return(entity.StockThreshold{}, sql.ErrNoRows)
+
+	_, err := suite.sauc.UpdateThreshold(context.Background(), entity.StockThreshold{FacilityId: "2", MinQuantity: 3})
+
+	assert.Equal(suite.T(), apperror.KindNotFound, apperror.KindOf(err))
+}
+
+func (suite *StockAlertUseCaseTestSuite) TestCheckStock_SummarySuccess() {
+	other := entity.StockAlert{ID: "3", FacilityId: "4", FacilityName: "Projector", Quantity: 0, MinQuantity: 1}
+	suite.sarm.On("Check", mock.Anything, []string(nil)).Return([]entity.StockAlert{}, nil)
+	suite.sarm.On("ClaimNotifications", mock.Anything).Return([]entity.StockAlert{expectedAlert, other}, nil)
+	suite.msm.On("Send", mock.Anything, model.MailMessage{
+		To:      []string{"ga@example.com"},
+		Subject: "[Reservify] 2 facilities are low on stock",
+		Body:    "HDMI adapter: 1 left, minimum 3\nProjector: 0 left, minimum 1\n",
+	}).Return(nil)
+
+	err := suite.sauc.CheckStockLevels(context.Background(), time.Now())
+
+	assert.NoError(suite.T(), err)
+	suite.msm.AssertExpectations(suite.T())
+}
+
+func (suite *StockAlertUseCaseTestSuite) TestCheckStock_QueuedSuccess() {
+	suite.sarm.On("Check", mock.Anything, []string{"2"}).Return([]entity.StockAlert{expectedAlert}, nil)
+
+	err := suite.sauc.CheckStock(context.Background(), "2")
+
+	assert.NoError(suite.T(), err)
+	suite.sarm.AssertNotCalled(suite.T(), "ClaimNotifications", mock.Anything)
+	suite.msm.AssertNotCalled(suite.T(), "Send", mock.Anything, mock.Anything)
+}
+
+func (suite *StockAlertUseCaseTestSuite) TestSendAlerts_SendFailure() {
+	suite.sarm.On("ClaimNotifications", mock.Anything).Return([]entity.StockAlert{expectedAlert}, nil)
+	suite.sarm.On("UnclaimNotifications", mock.Anything, []string{"1"}).Return(nil)
+	suite.msm.On("Send", mock.Anything, mock.Anything).Return(fmt.Errorf("error"))
+
+	err := suite.sauc.SendAlerts(context.Background(), time.Now())
+
+	assert.Error(suite.T(), err)
+	suite.sarm.AssertCalled(suite.T(), "UnclaimNotifications", mock.Anything, []string{"1"})
+}
+
+func (suite *StockAlertUseCaseTestSuite) TestSendAlerts_NoRecipientsSuccess() {
+	err := NewStockAlertUseCase(suite.sarm, suite.msm, nil).SendAlerts(context.Background(), time.Now())
+
+	assert.NoError(suite.T(), err)
+	suite.sarm.AssertNotCalled(suite.T(), "ClaimNotifications", mock.Anything)
+	suite.msm.AssertNotCalled(suite.T(), "Send", mock.Anything, mock.Anything)
+}
+
+func (suite *StockAlertUseCaseTestSuite) TestFindAlerts_Success() {
+	paging := model.Paging{Page: 1, RowsPerPage: 5, TotalRows: 1, TotalPages: 1}
+	suite.sarm.On("List", mock.Anything, "open", 1, 5).Return([]entity.StockAlert{expectedAlert}, paging, nil)
+
+	actual, actualPaging, err := suite.sauc.FindAlerts(context.Background(), "open", 1, 5)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []entity.StockAlert{expectedAlert}, actual)
+	assert.Equal(suite.T(), paging, actualPaging)
+}
+
+func (suite *StockAlertUseCaseTestSuite) TestAcknowledgeAlert_Success() {
+	acknowledged := expectedAlert
+	acknowledged.AcknowledgedBy = "5"
+	suite.sarm.On("Acknowledge", mock.Anything, "1", "5").Return(acknowledged, nil)
+
+	actual, err := suite.sauc.AcknowledgeAlert(logger.WithUserID(context.Background(), "5"), "1")
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), acknowledged, actual)
+}
+
+func (suite *StockAlertUseCaseTestSuite) TestAcknowledgeAlert_NotFoundFailure() {
+	suite.sarm.On("Acknowledge", mock.Anything, "1", "").Return(entity.StockAlert{}, sql.ErrNoRows)
+
+	_, err := suite.sauc.AcknowledgeAlert(context.Background(), "1")
+
+	assert.Equal(suite.T(), apperror.KindNotFound, apperror.KindOf(err))
+}
+
+func TestStockAlertUseCaseTestSuite(t *testing.T) {
+	suite.Run(t, new(StockAlertUseCaseTestSuite))
+}
@@ -0,0 +1,17 @@
+package usecase
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "booking-room-app/usecase"
+
+// startSpan opens a span named after the usecase method, e.g.
+// "transactionsUsecase.RequestNewBookingRooms". The tracer is looked up on
+// every call so a provider installed after start-up is honoured.
+func startSpan(ctx context.Context, name string) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name)
+}
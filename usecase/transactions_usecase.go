@@ -3,55 +3,148 @@ package usecase
 import (
 	"booking-room-app/entity"
 	"booking-room-app/repository"
+	"booking-room-app/shared/apperror"
+	"booking-room-app/shared/metrics"
 	"booking-room-app/shared/model"
-	"fmt"
+	"context"
+	"errors"
 	"time"
 )
 
 type TransactionsUsecase interface {
-	FindAllTransactions(page, size int,startDate, endDate time.Time) ([]entity.Transaction, model.Paging, error)
-	FindTransactionsById(id string) (entity.Transaction, error)
-	FindTransactionsByEmployeeId(employeeId string, page, size int) ([]entity.Transaction, model.Paging, error)
-	RequestNewBookingRooms(payload entity.Transaction) (entity.Transaction, error)
-	AccStatusBooking(payload entity.Transaction) (entity.Transaction, error)
+	FindAllTransactions(ctx context.Context, page, size int,startDate, endDate time.Time) ([]entity.Transaction, model.Paging, error)
+	FindTransactionsById(ctx context.Context, id string) (entity.Transaction, error)
+	FindTransactionsByEmployeeId(ctx context.Context, employeeId string, page, size int) ([]entity.Transaction, model.Paging, error)
+	RequestNewBookingRooms(ctx context.Context, payload entity.Transaction) (entity.Transaction, error)
+	AccStatusBooking(ctx context.Context, payload entity.Transaction) (entity.Transaction, error)
+	PurgeTransactions(ctx context.Context, before time.Time) (int64, error)
 }
 
 type transactionsUsecase struct {
-	repo repository.TransactionsRepository
+	repo         repository.TransactionsRepository
+	rateUC       RateUseCase
+	calendarUC   CalendarUseCase
+	stockAlertUC StockAlertUseCase
 }
 
-func (t *transactionsUsecase) FindAllTransactions(page, size int, startDate, endDate time.Time) ([]entity.Transaction, model.Paging, error) {
-	return t.repo.List(page, size, startDate, endDate)
+func (t *transactionsUsecase) FindAllTransactions(ctx context.Context, page, size int, startDate, endDate time.Time) ([]entity.Transaction, model.Paging, error) {
+	ctx, span := startSpan(ctx, "transactionsUsecase.FindAllTransactions")
+	defer span.End()
+
+	transactions, paging, err := t.repo.List(ctx, page, size, startDate, endDate)
+	if err != nil {
+		return nil, model.Paging{}, dbError(err, "transaction")
+	}
+	return transactions, paging, nil
 }
 
-func (t *transactionsUsecase) FindTransactionsById(id string) (entity.Transaction, error) {
-	return t.repo.GetTransactionById(id)
+func (t *transactionsUsecase) FindTransactionsById(ctx context.Context, id string) (entity.Transaction, error) {
+	ctx, span := startSpan(ctx, "transactionsUsecase.FindTransactionsById")
+	defer span.End()
+
+	transaction, err := t.repo.GetTransactionById(ctx, id)
+	if err != nil {
+		return entity.Transaction{}, dbError(err, "transaction")
+	}
+	return transaction, nil
 }
 
-func (t *transactionsUsecase) FindTransactionsByEmployeeId(employeeId string, page, size int) ([]entity.Transaction, model.Paging, error) {
-	return t.repo.GetTransactionByEmployeId(employeeId, page, size)
+func (t *transactionsUsecase) FindTransactionsByEmployeeId(ctx context.Context, employeeId string, page, size int) ([]entity.Transaction, model.Paging, error) {
+	ctx, span := startSpan(ctx, "transactionsUsecase.FindTransactionsByEmployeeId")
+	defer span.End()
+
+	transactions, paging, err := t.repo.GetTransactionByEmployeId(ctx, employeeId, page, size)
+	if err != nil {
+		return nil, model.Paging{}, dbError(err, "transaction")
+	}
+	return transactions, paging, nil
 }
 
-func (t *transactionsUsecase) RequestNewBookingRooms(payload entity.Transaction) (entity.Transaction, error) {
+func (t *transactionsUsecase) RequestNewBookingRooms(ctx context.Context, payload entity.Transaction) (entity.Transaction, error) {
+	ctx, span := startSpan(ctx, "transactionsUsecase.RequestNewBookingRooms")
+	defer span.End()
+
 	payload.UpdatedAt = time.Now()
 
-	transactions, err := t.repo.Create(payload)
+	// business hours, holidays and blackouts of the room
+	if err := t.calendarUC.CheckBookable(ctx, payload.RoomId, payload.StartTime, payload.EndTime); err != nil {
+		if apperror.KindOf(err) == apperror.KindConflict {
+			metrics.BookingConflictsTotal.WithLabelValues(metrics.ConflictRoomClosed).Inc()
+		}
+		return entity.Transaction{}, err
+	}
+
+	transactions, err := t.repo.Create(ctx, payload)
 	if err != nil {
-		return entity.Transaction{}, fmt.Errorf("oppps, failed to save data transations :%v", err.Error())
+		switch {
+		case errors.Is(err, repository.ErrRoomUnavailable):
+			metrics.BookingConflictsTotal.WithLabelValues(metrics.ConflictRoomUnavailable).Inc()
+		case errors.Is(err, repository.ErrInsufficientStock):
+			metrics.BookingConflictsTotal.WithLabelValues(metrics.ConflictInsufficientStock).Inc()
+		}
+		return entity.Transaction{}, dbError(err, "transaction")
+	}
+	metrics.BookingsTotal.WithLabelValues(metrics.BookingCreated).Inc()
+
+	// the facilities booked with the room are taken from the stock
+	var facilityIds []string
+	for _, facility := range payload.Facilities {
+		facilityIds = append(facilityIds, facility.FacilityId)
 	}
+	checkStockAfter(ctx, t.stockAlertUC, facilityIds...)
 		return transactions, nil
 }
 
-func (t *transactionsUsecase) AccStatusBooking(payload entity.Transaction) (entity.Transaction, error) {
+func (t *transactionsUsecase) AccStatusBooking(ctx context.Context, payload entity.Transaction) (entity.Transaction, error) {
+	ctx, span := startSpan(ctx, "transactionsUsecase.AccStatusBooking")
+	defer span.End()
+
 	payload.UpdatedAt = time.Now()
-	transactions, err := t.repo.UpdatePemission(payload)
+
+	// an accepted booking is priced with the facilities recorded for it, and
+	// the cost is stored together with the status
+	if payload.Status == "accepted" {
+		booking, err := t.repo.GetTransactionById(ctx, payload.ID)
+		if err != nil {
+			return entity.Transaction{}, dbError(err, "transaction")
+		}
+		cost, err := t.rateUC.PriceTransaction(ctx, booking)
+		if err != nil {
+			return entity.Transaction{}, err
+		}
+		payload.Facilities = booking.Facilities
+		payload.Cost = &cost
+	}
+
+	transactions, err := t.repo.UpdatePemission(ctx, payload)
 	if err != nil {
-		// fmt.Println(payload.Status)
-		return entity.Transaction{}, fmt.Errorf("oppps, failed to update data transations :%v", err.Error())
+		return entity.Transaction{}, dbError(err, "transaction")
+	}
+	switch transactions.Status {
+	case "accepted":
+		metrics.BookingsTotal.WithLabelValues(metrics.BookingAccepted).Inc()
+	case "declined":
+		metrics.BookingsTotal.WithLabelValues(metrics.BookingDeclined).Inc()
 	}
 		return transactions, nil
 }
 
-func NewTransactionsUsecase(repo repository.TransactionsRepository) TransactionsUsecase {
-	return &transactionsUsecase{repo: repo}
+// PurgeTransactions deletes the bookings that ended before the given time.
+func (t *transactionsUsecase) PurgeTransactions(ctx context.Context, before time.Time) (int64, error) {
+	ctx, span := startSpan(ctx, "transactionsUsecase.PurgeTransactions")
+	defer span.End()
+
+	if before.IsZero() || before.After(time.Now()) {
+		return 0, apperror.Validation("purge date must be in the past", apperror.Field("before", "past", "before must be in the past"))
+	}
+
+	deleted, err := t.repo.DeleteBefore(ctx, before)
+	if err != nil {
+		return 0, dbError(err, "transaction")
+	}
+	return deleted, nil
+}
+
+func NewTransactionsUsecase(repo repository.TransactionsRepository, rateUC RateUseCase, calendarUC CalendarUseCase, stockAlertUC StockAlertUseCase) TransactionsUsecase {
+	return &transactionsUsecase{repo: repo, rateUC: rateUC, calendarUC: calendarUC, stockAlertUC: stockAlertUC}
 }
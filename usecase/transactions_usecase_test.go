@@ -3,19 +3,29 @@ package usecase
 import (
 	"booking-room-app/entity"
 	"booking-room-app/mock/repo_mock"
+	"booking-room-app/mock/usecase_mock"
+	"booking-room-app/repository"
+	"booking-room-app/shared/metrics"
+	"context"
 	"fmt"
 	"testing"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/suite"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var expectedTransactions = entity.Transaction{
 	ID:        "1",
     EmployeeId: "1",
     RoomId:    "1",
-	RoomFacilities: []entity.RoomFacility{expectedRoomFacilities},
+	Facilities: []entity.TransactionFacility{expectedTransactionFacilities},
 	Status: "pending",
 	StartTime:  time.Now(),
 	EndTime:  time.Now(),
@@ -28,7 +38,7 @@ var expectedTransaction = []entity.Transaction{
 	ID:        "1",
     EmployeeId: "1",
     RoomId:    "1",
-	RoomFacilities: []entity.RoomFacility{expectedRoomFacilities},
+	Facilities: []entity.TransactionFacility{expectedTransactionFacilities},
 	Status: "pending",
 	StartTime:  time.Now(),
 	EndTime:  time.Now(),
@@ -39,7 +49,7 @@ var expectedTransaction = []entity.Transaction{
 		ID:        "2",
 		EmployeeId: "2",
 		RoomId:    "2",
-		RoomFacilities: []entity.RoomFacility{expectedRoomFacilities},
+		Facilities: []entity.TransactionFacility{expectedTransactionFacilities},
 		Status: "pending",
 		StartTime:  time.Now(),
 		EndTime:  time.Now(),
@@ -48,9 +58,9 @@ var expectedTransaction = []entity.Transaction{
 		},
 }
 
-var expectedRoomFacilities = entity.RoomFacility {
+var expectedTransactionFacilities = entity.TransactionFacility {
 	ID:        "1",
-    RoomId:    "1",
+    TransactionId:    "1",
     FacilityId: "1",
     Quantity: 1,
     CreatedAt: time.Now(),
@@ -60,12 +70,20 @@ var expectedRoomFacilities = entity.RoomFacility {
 type TransactionUseCaseTestSuite struct {
 	suite.Suite
 	trm *repo_mock.TransactionsRepoMock
+	rum *usecase_mock.RateUseCaseMock
+	cum *usecase_mock.CalendarUseCaseMock
+	sum *usecase_mock.StockAlertUseCaseMock
 	tuc TransactionsUsecase
 }
 
 func (suite *TransactionUseCaseTestSuite) SetupTest() {
 	suite.trm = new(repo_mock.TransactionsRepoMock)
-	suite.tuc = NewTransactionsUsecase(suite.trm)
+	suite.rum = new(usecase_mock.RateUseCaseMock)
+	suite.cum = new(usecase_mock.CalendarUseCaseMock)
+	suite.cum.On("CheckBookable", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil).Maybe()
+	suite.sum = new(usecase_mock.StockAlertUseCaseMock)
+	suite.sum.On("CheckStock", mock.Anything, mock.Anything).Return(nil).Maybe()
+	suite.tuc = NewTransactionsUsecase(suite.trm, suite.rum, suite.cum, suite.sum)
 }
 
 func (suite *TransactionUseCaseTestSuite) TestRequestNewBookingRooms_Success() {
@@ -73,15 +91,15 @@ func (suite *TransactionUseCaseTestSuite) TestRequestNewBookingRooms_Success() {
 		ID:        "1",
 		EmployeeId: "1",
 		RoomId:    "1",
-		RoomFacilities: nil,
+		Facilities: nil,
 		Status: "pending",
 		StartTime:  time.Now(),
 		EndTime:  time.Now(),
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
-	suite.trm.On("Create", expectedTransactions).Return(expectedTransactions, nil)
-	_, err := suite.tuc.RequestNewBookingRooms(expectedTransactions)
+	suite.trm.On("Create", mock.Anything, expectedTransactions).Return(expectedTransactions, nil)
+	_, err := suite.tuc.RequestNewBookingRooms(context.Background(), expectedTransactions)
 	assert.Nil(suite.T(), err)
 	assert.NoError(suite.T(), err)
 }
@@ -91,33 +109,82 @@ func (suite *TransactionUseCaseTestSuite) TestRequestNewBookingRooms_Fail() {
 		ID:        "1",
 		EmployeeId: "1",
 		RoomId:    "1",
-		RoomFacilities: nil,
+		Facilities: nil,
 		Status: "pending",
 		StartTime:  time.Now(),
 		EndTime:  time.Now(),
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
-	suite.trm.On("Create", expectedTransactions).Return(entity.Transaction{} ,fmt.Errorf("error"))
-	_, err := suite.tuc.RequestNewBookingRooms(expectedTransactions)
+	suite.trm.On("Create", mock.Anything, expectedTransactions).Return(entity.Transaction{} ,fmt.Errorf("error"))
+	_, err := suite.tuc.RequestNewBookingRooms(context.Background(), expectedTransactions)
 	assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
 }
 
+func (suite *TransactionUseCaseTestSuite) TestRequestNewBookingRooms_CountsConflict() {
+	payload := entity.Transaction{EmployeeId: "1", RoomId: "1", StartTime: time.Now(), EndTime: time.Now()}
+	conflicts := metrics.BookingConflictsTotal.WithLabelValues(metrics.ConflictRoomUnavailable)
+	before := testutil.ToFloat64(conflicts)
+
+	suite.trm.On("Create", mock.Anything, mock.AnythingOfType("entity.Transaction")).Return(entity.Transaction{}, fmt.Errorf("create: %w", repository.ErrRoomUnavailable))
+	_, err := suite.tuc.RequestNewBookingRooms(context.Background(), payload)
+
+	assert.Error(suite.T(), err)
+	assert.Equal(suite.T(), before+1, testutil.ToFloat64(conflicts))
+}
+
+func (suite *TransactionUseCaseTestSuite) TestRequestNewBookingRooms_ClosedRoomFail() {
+	payload := entity.Transaction{EmployeeId: "1", RoomId: "1", StartTime: time.Now(), EndTime: time.Now().Add(time.Hour)}
+	calendar := new(usecase_mock.CalendarUseCaseMock)
+	calendar.On("CheckBookable", mock.Anything, "1", payload.StartTime, payload.EndTime).Return(ErrOutsideHours)
+	conflicts := metrics.BookingConflictsTotal.WithLabelValues(metrics.ConflictRoomClosed)
+	before := testutil.ToFloat64(conflicts)
+
+	_, err := NewTransactionsUsecase(suite.trm, suite.rum, calendar, suite.sum).RequestNewBookingRooms(context.Background(), payload)
+
+	assert.ErrorIs(suite.T(), err, ErrOutsideHours)
+	assert.Equal(suite.T(), before+1, testutil.ToFloat64(conflicts))
+	suite.trm.AssertNotCalled(suite.T(), "Create", mock.Anything, mock.Anything)
+}
+
+func (suite *TransactionUseCaseTestSuite) TestRequestNewBookingRooms_Span() {
+	exporter := tracetest.NewInMemoryExporter()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter)))
+	defer otel.SetTracerProvider(previous)
+
+	payload := entity.Transaction{EmployeeId: "1", RoomId: "1", StartTime: time.Now(), EndTime: time.Now()}
+	var repoCtx context.Context
+	suite.trm.On("Create", mock.Anything, mock.AnythingOfType("entity.Transaction")).Run(func(args mock.Arguments) {
+		repoCtx = args.Get(0).(context.Context)
+	}).Return(payload, nil)
+
+	_, err := suite.tuc.RequestNewBookingRooms(context.Background(), payload)
+
+	assert.NoError(suite.T(), err)
+	spans := exporter.GetSpans()
+	if assert.Len(suite.T(), spans, 1) {
+		assert.Equal(suite.T(), "transactionsUsecase.RequestNewBookingRooms", spans[0].Name)
+		// the repository runs inside the usecase span, so its SQL spans nest under it
+		assert.Equal(suite.T(), spans[0].SpanContext.SpanID(), trace.SpanContextFromContext(repoCtx).SpanID())
+	}
+}
+
 func (suite *TransactionUseCaseTestSuite) TestAccStatusBooking_Success() {
 	var expectedTransactions = entity.Transaction{
 		ID:        "1",
 		EmployeeId: "1",
 		RoomId:    "1",
-		RoomFacilities: nil,
+		Facilities: nil,
 		Status: "pending",
 		StartTime:  time.Now(),
 		EndTime:  time.Now(),
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
-	suite.trm.On("UpdatePemission", expectedTransactions).Return(expectedTransactions, nil)
-	_, err := suite.tuc.AccStatusBooking(expectedTransactions)
+	suite.trm.On("UpdatePemission", mock.Anything, expectedTransactions).Return(expectedTransactions, nil)
+	_, err := suite.tuc.AccStatusBooking(context.Background(), expectedTransactions)
 	assert.Nil(suite.T(), err)
 	assert.NoError(suite.T(), err)
 }
@@ -127,31 +194,59 @@ func (suite *TransactionUseCaseTestSuite) TestAccStatusBooking_Fail() {
 		ID:        "1",
 		EmployeeId: "1",
 		RoomId:    "1",
-		RoomFacilities: nil,
+		Facilities: nil,
 		Status: "pending",
 		StartTime:  time.Now(),
 		EndTime:  time.Now(),
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
-	suite.trm.On("UpdatePemission", expectedTransactions).Return(entity.Transaction{} ,fmt.Errorf("error"))
-	_, err := suite.tuc.AccStatusBooking(expectedTransactions)
+	suite.trm.On("UpdatePemission", mock.Anything, expectedTransactions).Return(entity.Transaction{} ,fmt.Errorf("error"))
+	_, err := suite.tuc.AccStatusBooking(context.Background(), expectedTransactions)
 	assert.NotNil(suite.T(), err)
 	assert.Error(suite.T(), err)
 }
 
+func (suite *TransactionUseCaseTestSuite) TestAccStatusBooking_AcceptedSnapshotsCost() {
+	accepted := expectedTransactions
+	accepted.Status = "accepted"
+	expectedCost := entity.TransactionCost{TransactionId: "1", HourlyRate: 100000, RoomCost: 100000, TotalCost: 100000}
+	suite.trm.On("GetTransactionById", mock.Anything, accepted.ID).Return(accepted, nil)
+	suite.rum.On("PriceTransaction", mock.Anything, accepted).Return(expectedCost, nil)
+	suite.trm.On("UpdatePemission", mock.Anything, mock.MatchedBy(func(payload entity.Transaction) bool {
+		return payload.Cost != nil && *payload.Cost == expectedCost
+	})).Return(entity.Transaction{ID: accepted.ID, Status: "accepted", Cost: &expectedCost}, nil)
+
+	actual, err := suite.tuc.AccStatusBooking(context.Background(), accepted)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expectedCost, *actual.Cost)
+}
+
+func (suite *TransactionUseCaseTestSuite) TestAccStatusBooking_SnapshotCostFail() {
+	accepted := expectedTransactions
+	accepted.Status = "accepted"
+	suite.trm.On("GetTransactionById", mock.Anything, accepted.ID).Return(accepted, nil)
+	suite.rum.On("PriceTransaction", mock.Anything, accepted).Return(entity.TransactionCost{}, fmt.Errorf("error"))
+
+	_, err := suite.tuc.AccStatusBooking(context.Background(), accepted)
+
+	assert.Error(suite.T(), err)
+	suite.trm.AssertNotCalled(suite.T(), "UpdatePemission", mock.Anything, mock.Anything)
+}
+
 func (suite *TransactionUseCaseTestSuite) TestGetTransactionById_Success() {
-	suite.trm.On("GetTransactionById", expectedTransactions.ID).Return(expectedTransactions, nil)
-	actual, err := suite.tuc.FindTransactionsById(expectedTransactions.ID)
+	suite.trm.On("GetTransactionById", mock.Anything, expectedTransactions.ID).Return(expectedTransactions, nil)
+	actual, err := suite.tuc.FindTransactionsById(context.Background(), expectedTransactions.ID)
 	assert.Nil(suite.T(), err)
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), expectedTransactions.Description, actual.Description)
 }
 
 func (suite *TransactionUseCaseTestSuite) TestFindAllTransactions_Success() {
-	suite.trm.On("List", page, size, expectedTransaction[0].CreatedAt, expectedTransaction[0].CreatedAt).Return(expectedTransaction, expectedPaging, nil)
+	suite.trm.On("List", mock.Anything, page, size, expectedTransaction[0].CreatedAt, expectedTransaction[0].CreatedAt).Return(expectedTransaction, expectedPaging, nil)
 
-	actual, paging, err := suite.tuc.FindAllTransactions(page, size, expectedTransaction[0].CreatedAt, expectedTransaction[0].CreatedAt)
+	actual, paging, err := suite.tuc.FindAllTransactions(context.Background(), page, size, expectedTransaction[0].CreatedAt, expectedTransaction[0].CreatedAt)
 
 	assert.Nil(suite.T(), err)
 	assert.NoError(suite.T(), err)
@@ -161,15 +256,32 @@ func (suite *TransactionUseCaseTestSuite) TestFindAllTransactions_Success() {
 }
 
 func (suite *TransactionUseCaseTestSuite) TestFindTransactionsByEmployeeId_Success() {
-	suite.trm.On("GetTransactionByEmployeId", expectedTransactions.EmployeeId, page, size).Return(expectedTransaction, expectedPaging, nil)
+	suite.trm.On("GetTransactionByEmployeId", mock.Anything, expectedTransactions.EmployeeId, page, size).Return(expectedTransaction, expectedPaging, nil)
 
-	actual, _, err := suite.tuc.FindTransactionsByEmployeeId(expectedTransactions.EmployeeId, page, size)
+	actual, _, err := suite.tuc.FindTransactionsByEmployeeId(context.Background(), expectedTransactions.EmployeeId, page, size)
 
 	assert.Nil(suite.T(), err)
 	assert.NoError(suite.T(), err)
 	assert.Equal(suite.T(), expectedTransaction[0].Description, actual[0].Description)
 }
 
+func (suite *TransactionUseCaseTestSuite) TestPurgeTransactions_Success() {
+	before := time.Now().AddDate(-1, 0, 0)
+	suite.trm.On("DeleteBefore", mock.Anything, before).Return(int64(3), nil)
+
+	deleted, err := suite.tuc.PurgeTransactions(context.Background(), before)
+
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), int64(3), deleted)
+}
+
+func (suite *TransactionUseCaseTestSuite) TestPurgeTransactions_FutureFailure() {
+	_, err := suite.tuc.PurgeTransactions(context.Background(), time.Now().Add(time.Hour))
+
+	assert.Error(suite.T(), err)
+	suite.trm.AssertNotCalled(suite.T(), "DeleteBefore", mock.Anything, mock.Anything)
+}
+
 func TestTransactionUseCaseTestSuite(t *testing.T) {
 	suite.Run(t, new(TransactionUseCaseTestSuite))
 }